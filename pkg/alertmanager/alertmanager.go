@@ -14,8 +14,11 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
 	"github.com/prometheus/alertmanager/api"
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
 	"github.com/prometheus/alertmanager/inhibit"
@@ -29,6 +32,7 @@ import (
 	"github.com/prometheus/alertmanager/notify/victorops"
 	"github.com/prometheus/alertmanager/notify/webhook"
 	"github.com/prometheus/alertmanager/notify/wechat"
+	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/provider/mem"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/template"
@@ -38,9 +42,17 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/route"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
 )
 
-const notificationLogMaintenancePeriod = 15 * time.Minute
+const (
+	notificationLogMaintenancePeriod = 15 * time.Minute
+
+	// Timeout for restoring the tenant state from the alerts store.
+	restoreStateTimeout = 30 * time.Second
+)
 
 // Config configures an Alertmanager.
 type Config struct {
@@ -50,6 +62,15 @@ type Config struct {
 	Logger      log.Logger
 	Peer        *cluster.Peer
 	PeerTimeout time.Duration
+	// Replicator is used to replicate the state across the tenant replicas in the ring,
+	// in place of the gossip-based Peer. If both are nil, the state is not replicated.
+	Replicator Replicator
+	// Store is used to persist and restore the state. If nil, the state is not persisted.
+	Store     AlertStore
+	Persister PersisterConfig
+	// Limits are the per-tenant limits applied to the notifications and the alerts.
+	// If nil, no limit is applied.
+	Limits      Limits
 	Retention   time.Duration
 	ExternalURL *url.URL
 }
@@ -61,15 +82,25 @@ type Alertmanager struct {
 	logger          log.Logger
 	nflog           *nflog.Log
 	silences        *silence.Silences
+	state           *state
+	persister       *statePersister
 	marker          types.Marker
 	alerts          *mem.Alerts
 	dispatcher      *dispatch.Dispatcher
 	inhibitor       *inhibit.Inhibitor
 	pipelineBuilder *notify.PipelineBuilder
 	stop            chan struct{}
-	wg              sync.WaitGroup
-	mux             *http.ServeMux
-	registry        *prometheus.Registry
+
+	// Rate limiters of the notifications, by integration type. They're kept across
+	// config reloads, so that reloading the config doesn't reset the rate limits.
+	notificationLimiters map[string]*notificationLimiter
+	wg                   sync.WaitGroup
+	mux                  *http.ServeMux
+	registry             *prometheus.Registry
+
+	// Proxy the notifications are sent through if private addresses are blocked for the tenant.
+	// It's started the first time it's needed, and kept across config reloads.
+	firewall *firewallProxy
 
 	// The Dispatcher is the only component we need to recreate when we call ApplyConfig.
 	// Given its metrics don't have any variable labels we need to re-use the same metrics.
@@ -109,6 +140,10 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 
 	am.registry = reg
 
+	if cfg.Peer == nil && cfg.Replicator != nil {
+		am.state = newReplicatedStates(cfg.UserID, cfg.Replicator, cfg.Store, log.With(am.logger, "component", "state-replication"), am.registry)
+	}
+
 	am.wg.Add(1)
 	nflogID := fmt.Sprintf("nflog:%s", cfg.UserID)
 	var err error
@@ -125,6 +160,8 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 	if cfg.Peer != nil {
 		c := cfg.Peer.AddState("nfl:"+cfg.UserID, am.nflog, am.registry)
 		am.nflog.SetBroadcast(c.Broadcast)
+	} else if am.state != nil {
+		am.nflog.SetBroadcast(am.state.AddState(nflogStateKey, am.nflog))
 	}
 
 	am.marker = types.NewMarker(am.registry)
@@ -142,6 +179,27 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 	if cfg.Peer != nil {
 		c := cfg.Peer.AddState("sil:"+cfg.UserID, am.silences, am.registry)
 		am.silences.SetBroadcast(c.Broadcast)
+	} else if am.state != nil {
+		am.silences.SetBroadcast(am.state.AddState(silencesStateKey, am.silences))
+
+		// Sync the initial state from the other replicas (or the store) in background.
+		// Notifications are held by the pipeline until the sync has completed.
+		if err := am.state.StartAsync(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to start state replication: %v", err)
+		}
+	}
+
+	// Without replication via the ring, the state persisted in the store is merged into the local one,
+	// both when running alone and in the gossip cluster, because the peers may have lost the state too.
+	if am.state == nil && cfg.Store != nil {
+		am.restoreFullStateFromStore()
+	}
+
+	if cfg.Store != nil && cfg.Persister.Interval > 0 {
+		am.persister = newStatePersister(cfg.Persister, cfg.UserID, am, cfg.Store, log.With(am.logger, "component", "state-persister"), am.registry)
+		if err := am.persister.StartAsync(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to start state persister: %v", err)
+		}
 	}
 
 	am.pipelineBuilder = notify.NewPipelineBuilder(am.registry)
@@ -157,8 +215,26 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 		return nil, fmt.Errorf("failed to create alerts: %v", err)
 	}
 
+	var alerts provider.Alerts = am.alerts
+	if cfg.Limits != nil {
+		insertLimited := promauto.With(am.registry).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_alerts_insert_limited_total",
+			Help: "Number of alerts rejected because of the limits on the number and size of the alerts.",
+		})
+		alerts = newLimitedAlerts(am.alerts, cfg.UserID, cfg.Limits, am.logger, insertLimited)
+
+		rateLimited := promauto.With(am.registry).NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_notification_rate_limited_total",
+			Help: "Number of notifications dropped because of the rate limits.",
+		}, []string{"integration"})
+		am.notificationLimiters = make(map[string]*notificationLimiter, len(allIntegrations))
+		for _, integration := range allIntegrations {
+			am.notificationLimiters[integration] = newNotificationLimiter(cfg.UserID, integration, cfg.Limits, rateLimited.WithLabelValues(integration))
+		}
+	}
+
 	am.api, err = api.New(api.Options{
-		Alerts:     am.alerts,
+		Alerts:     alerts,
 		Silences:   am.silences,
 		StatusFunc: am.marker.Status,
 		Peer:       cfg.Peer,
@@ -177,6 +253,17 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 	ui.Register(router, webReload, log.With(am.logger, "component", "ui"))
 	am.mux = am.api.Register(router, am.cfg.ExternalURL.Path)
 
+	// Alerts rejected because of the limits are reported by the API as an internal error, so the
+	// requests posting alerts are wrapped to report them as a client error.
+	if cfg.Limits != nil {
+		apiMux := am.mux
+		am.mux = http.NewServeMux()
+		am.mux.Handle("/", apiMux)
+		for _, p := range []string{"/api/v1/alerts", "/api/v2/alerts"} {
+			am.mux.Handle(path.Join(am.cfg.ExternalURL.Path, p), limitedAlertsHandler(apiMux))
+		}
+	}
+
 	// Override some extra paths registered in the router (eg. /metrics which by default exposes prometheus.DefaultRegisterer).
 	// Entire router is registered in Mux to "/" path, so there is no conflict with overwriting specific paths.
 	for _, p := range []string{"/metrics", "/-/reload", "/debug/"} {
@@ -194,9 +281,22 @@ func New(cfg *Config, reg *prometheus.Registry) (*Alertmanager, error) {
 
 // clusterWait returns a function that inspects the current peer state and returns
 // a duration of one base timeout for each peer with a higher ID than ourselves.
-func clusterWait(p *cluster.Peer, timeout time.Duration) func() time.Duration {
+func clusterWait(position func() int, timeout time.Duration) func() time.Duration {
 	return func() time.Duration {
-		return time.Duration(p.Position()) * timeout
+		return time.Duration(position()) * timeout
+	}
+}
+
+// position returns the position of this instance among the replicas of the tenant,
+// used to delay the notifications so that they're deduplicated across replicas.
+func (am *Alertmanager) position() int {
+	switch {
+	case am.cfg.Peer != nil:
+		return am.cfg.Peer.Position()
+	case am.state != nil:
+		return am.state.Position()
+	default:
+		return 0
 	}
 }
 
@@ -229,7 +329,7 @@ func (am *Alertmanager) ApplyConfig(userID string, conf *config.Config, rawCfg s
 
 	am.inhibitor = inhibit.NewInhibitor(am.alerts, conf.InhibitRules, am.marker, log.With(am.logger, "component", "inhibitor"))
 
-	waitFunc := clusterWait(am.cfg.Peer, am.cfg.PeerTimeout)
+	waitFunc := clusterWait(am.position, am.cfg.PeerTimeout)
 	timeoutFunc := func(d time.Duration) time.Duration {
 		if d < notify.MinTimeout {
 			d = notify.MinTimeout
@@ -237,7 +337,19 @@ func (am *Alertmanager) ApplyConfig(userID string, conf *config.Config, rawCfg s
 		return d + waitFunc()
 	}
 
-	integrationsMap, err := buildIntegrationsMap(conf.Receivers, tmpl, am.logger)
+	// The addresses targeted by the receivers are checked when the configuration is validated,
+	// but the host names can only be checked once resolved, while sending the notifications.
+	receivers := conf.Receivers
+	if am.cfg.Limits != nil && am.cfg.Limits.AlertmanagerReceiversBlockPrivateAddresses(userID) {
+		if am.firewall == nil {
+			if am.firewall, err = newFirewallProxy(isPrivateIP, log.With(am.logger, "component", "firewall")); err != nil {
+				return err
+			}
+		}
+		receivers = proxiedReceivers(receivers, am.firewall.URL())
+	}
+
+	integrationsMap, err := buildIntegrationsMap(receivers, tmpl, am.logger, am.wrapNotifier)
 	if err != nil {
 		return nil
 	}
@@ -250,6 +362,15 @@ func (am *Alertmanager) ApplyConfig(userID string, conf *config.Config, rawCfg s
 		am.nflog,
 		am.cfg.Peer,
 	)
+
+	// When the state is replicated via the ring, there's no gossip to settle: hold the
+	// notifications until the state has been synced from the other replicas instead.
+	if am.state != nil {
+		for name, stage := range pipeline {
+			pipeline[name] = notify.MultiStage{waitReadyStage{state: am.state}, stage}
+		}
+	}
+
 	am.dispatcher = dispatch.NewDispatcher(
 		am.alerts,
 		dispatch.NewRoute(conf.Route, nil),
@@ -277,21 +398,125 @@ func (am *Alertmanager) Stop() {
 		am.dispatcher.Stop()
 	}
 
+	if am.state != nil {
+		am.state.StopAsync()
+	}
+
+	if am.persister != nil {
+		am.persister.StopAsync()
+	}
+
+	if am.firewall != nil {
+		am.firewall.Close()
+	}
+
 	am.alerts.Close()
 	close(am.stop)
 }
 
 func (am *Alertmanager) StopAndWait() {
 	am.Stop()
+
+	if am.state != nil {
+		_ = am.state.AwaitTerminated(context.Background())
+	}
+	if am.persister != nil {
+		_ = am.persister.AwaitTerminated(context.Background())
+	}
 	am.wg.Wait()
 }
 
+// mergePartialExternalState merges a partial state received from another replica of the tenant.
+func (am *Alertmanager) mergePartialExternalState(part *clusterpb.Part) error {
+	if am.state == nil {
+		return errors.New("ring-based state replication is not enabled")
+	}
+	return am.state.MergePartialState(part)
+}
+
+// getFullState returns the full state of the tenant, to be read by the other replicas
+// or persisted to the store.
+func (am *Alertmanager) getFullState() (*clusterpb.FullState, error) {
+	if am.state != nil {
+		return am.state.GetFullState()
+	}
+
+	nflogState, err := am.nflog.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode notification log")
+	}
+	silencesState, err := am.silences.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode silences")
+	}
+
+	return &clusterpb.FullState{Parts: []clusterpb.Part{
+		{Key: nflogStateKey, Data: nflogState},
+		{Key: silencesStateKey, Data: silencesState},
+	}}, nil
+}
+
+// shouldPersist returns whether this instance is in charge of persisting the tenant state. Only the
+// first replica of the tenant persists it, once the initial state has been synced from the other replicas.
+func (am *Alertmanager) shouldPersist() bool {
+	if am.state != nil && am.state.State() != services.Running {
+		return false
+	}
+	return am.position() == 0
+}
+
+// restoreFullStateFromStore merges the tenant state persisted in the store into the local one.
+// It's used when the state is not replicated via the ring, which restores it on its own. Merging
+// is idempotent, so in the gossip cluster the state received from the peers is preserved.
+func (am *Alertmanager) restoreFullStateFromStore() {
+	ctx, cancel := context.WithTimeout(context.Background(), restoreStateTimeout)
+	defer cancel()
+
+	fs, err := am.cfg.Store.GetFullState(ctx, am.cfg.UserID)
+	if errors.Is(err, alerts.ErrFullStateNotFound) {
+		return
+	}
+	if err != nil {
+		level.Warn(am.logger).Log("msg", "failed to read the state from the store, continuing with the local state", "err", err)
+		return
+	}
+	if fs.State == nil {
+		return
+	}
+
+	for _, part := range fs.State.Parts {
+		var err error
+		switch part.Key {
+		case nflogStateKey:
+			err = am.nflog.Merge(part.Data)
+		case silencesStateKey:
+			err = am.silences.Merge(part.Data)
+		default:
+			err = fmt.Errorf("unknown state key: %s", part.Key)
+		}
+		if err != nil {
+			level.Warn(am.logger).Log("msg", "failed to merge the state read from the store", "key", part.Key, "err", err)
+		}
+	}
+
+	level.Info(am.logger).Log("msg", "alertmanager state restored from the store")
+}
+
+// wrapNotifier wraps the notifier of the given integration type with its rate limiter, if any.
+func (am *Alertmanager) wrapNotifier(integration string, n notify.Notifier) notify.Notifier {
+	limiter, ok := am.notificationLimiters[integration]
+	if !ok {
+		return n
+	}
+	return newRateLimitedNotifier(n, limiter)
+}
+
 // buildIntegrationsMap builds a map of name to the list of integration notifiers off of a
 // list of receiver config.
-func buildIntegrationsMap(nc []*config.Receiver, tmpl *template.Template, logger log.Logger) (map[string][]notify.Integration, error) {
+func buildIntegrationsMap(nc []*config.Receiver, tmpl *template.Template, logger log.Logger, notifierWrapper func(string, notify.Notifier) notify.Notifier) (map[string][]notify.Integration, error) {
 	integrationsMap := make(map[string][]notify.Integration, len(nc))
 	for _, rcv := range nc {
-		integrations, err := buildReceiverIntegrations(rcv, tmpl, logger)
+		integrations, err := buildReceiverIntegrations(rcv, tmpl, logger, notifierWrapper)
 		if err != nil {
 			return nil, err
 		}
@@ -303,7 +528,8 @@ func buildIntegrationsMap(nc []*config.Receiver, tmpl *template.Template, logger
 // buildReceiverIntegrations builds a list of integration notifiers off of a
 // receiver config.
 // Taken from https://github.com/prometheus/alertmanager/blob/94d875f1227b29abece661db1a68c001122d1da5/cmd/alertmanager/main.go#L112-L159.
-func buildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, logger log.Logger) ([]notify.Integration, error) {
+// The notifierWrapper is called to wrap each notifier, eg. to apply the rate limits.
+func buildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, logger log.Logger, notifierWrapper func(string, notify.Notifier) notify.Notifier) ([]notify.Integration, error) {
 	var (
 		errs         types.MultiError
 		integrations []notify.Integration
@@ -313,33 +539,36 @@ func buildReceiverIntegrations(nc *config.Receiver, tmpl *template.Template, log
 				errs.Add(err)
 				return
 			}
+			if notifierWrapper != nil {
+				n = notifierWrapper(name, n)
+			}
 			integrations = append(integrations, notify.NewIntegration(n, rs, name, i))
 		}
 	)
 
 	for i, c := range nc.WebhookConfigs {
-		add("webhook", i, c, func(l log.Logger) (notify.Notifier, error) { return webhook.New(c, tmpl, l) })
+		add(integrationWebhook, i, c, func(l log.Logger) (notify.Notifier, error) { return webhook.New(c, tmpl, l) })
 	}
 	for i, c := range nc.EmailConfigs {
-		add("email", i, c, func(l log.Logger) (notify.Notifier, error) { return email.New(c, tmpl, l), nil })
+		add(integrationEmail, i, c, func(l log.Logger) (notify.Notifier, error) { return email.New(c, tmpl, l), nil })
 	}
 	for i, c := range nc.PagerdutyConfigs {
-		add("pagerduty", i, c, func(l log.Logger) (notify.Notifier, error) { return pagerduty.New(c, tmpl, l) })
+		add(integrationPagerDuty, i, c, func(l log.Logger) (notify.Notifier, error) { return pagerduty.New(c, tmpl, l) })
 	}
 	for i, c := range nc.OpsGenieConfigs {
-		add("opsgenie", i, c, func(l log.Logger) (notify.Notifier, error) { return opsgenie.New(c, tmpl, l) })
+		add(integrationOpsGenie, i, c, func(l log.Logger) (notify.Notifier, error) { return opsgenie.New(c, tmpl, l) })
 	}
 	for i, c := range nc.WechatConfigs {
-		add("wechat", i, c, func(l log.Logger) (notify.Notifier, error) { return wechat.New(c, tmpl, l) })
+		add(integrationWeChat, i, c, func(l log.Logger) (notify.Notifier, error) { return wechat.New(c, tmpl, l) })
 	}
 	for i, c := range nc.SlackConfigs {
-		add("slack", i, c, func(l log.Logger) (notify.Notifier, error) { return slack.New(c, tmpl, l) })
+		add(integrationSlack, i, c, func(l log.Logger) (notify.Notifier, error) { return slack.New(c, tmpl, l) })
 	}
 	for i, c := range nc.VictorOpsConfigs {
-		add("victorops", i, c, func(l log.Logger) (notify.Notifier, error) { return victorops.New(c, tmpl, l) })
+		add(integrationVictorOps, i, c, func(l log.Logger) (notify.Notifier, error) { return victorops.New(c, tmpl, l) })
 	}
 	for i, c := range nc.PushoverConfigs {
-		add("pushover", i, c, func(l log.Logger) (notify.Notifier, error) { return pushover.New(c, tmpl, l) })
+		add(integrationPushover, i, c, func(l log.Logger) (notify.Notifier, error) { return pushover.New(c, tmpl, l) })
 	}
 	if errs.Len() > 0 {
 		return nil, &errs
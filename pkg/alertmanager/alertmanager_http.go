@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kit/kit/log/level"
 
+	"github.com/cortexproject/cortex/pkg/ring"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/services"
 )
@@ -51,3 +52,13 @@ func (am *MultitenantAlertmanager) RingHandler(w http.ResponseWriter, req *http.
 
 	am.ring.ServeHTTP(w, req)
 }
+
+// Ring returns the alertmanager ring, or nil if sharding is disabled or the alertmanager
+// is not running yet.
+func (am *MultitenantAlertmanager) Ring() *ring.Ring {
+	if !am.cfg.ShardingEnabled || am.State() != services.Running {
+		return nil
+	}
+
+	return am.ring
+}
@@ -7,6 +7,7 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	clusterpb "github.com/prometheus/alertmanager/cluster/clusterpb"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -135,31 +136,81 @@ func (m *TemplateDesc) GetBody() string {
 	return ""
 }
 
+// FullStateDesc is the snapshot of the Alertmanager state (silences and
+// notification log) of a tenant, persisted to the alerts store.
+type FullStateDesc struct {
+	State *clusterpb.FullState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (m *FullStateDesc) Reset()      { *m = FullStateDesc{} }
+func (*FullStateDesc) ProtoMessage() {}
+func (*FullStateDesc) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20493709c38b81dc, []int{2}
+}
+func (m *FullStateDesc) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FullStateDesc) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FullStateDesc.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FullStateDesc) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FullStateDesc.Merge(m, src)
+}
+func (m *FullStateDesc) XXX_Size() int {
+	return m.Size()
+}
+func (m *FullStateDesc) XXX_DiscardUnknown() {
+	xxx_messageInfo_FullStateDesc.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FullStateDesc proto.InternalMessageInfo
+
+func (m *FullStateDesc) GetState() *clusterpb.FullState {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AlertConfigDesc)(nil), "alerts.AlertConfigDesc")
 	proto.RegisterType((*TemplateDesc)(nil), "alerts.TemplateDesc")
+	proto.RegisterType((*FullStateDesc)(nil), "alerts.FullStateDesc")
 }
 
 func init() { proto.RegisterFile("alerts.proto", fileDescriptor_20493709c38b81dc) }
 
 var fileDescriptor_20493709c38b81dc = []byte{
-	// 253 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xcc, 0x49, 0x2d,
-	0x2a, 0x29, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0xa4, 0x74, 0xd3, 0x33,
-	0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0xd3, 0xf3, 0xd3, 0xf3, 0xf5, 0xc1, 0xd2,
-	0x49, 0xa5, 0x69, 0x60, 0x1e, 0x98, 0x03, 0x66, 0x41, 0xb4, 0x29, 0x55, 0x70, 0xf1, 0x3b, 0x82,
-	0x34, 0x3a, 0xe7, 0xe7, 0xa5, 0x65, 0xa6, 0xbb, 0xa4, 0x16, 0x27, 0x0b, 0x09, 0x71, 0xb1, 0x94,
-	0x16, 0xa7, 0x16, 0x49, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x81, 0xd9, 0x42, 0xb2, 0x5c, 0x5c,
-	0x45, 0x89, 0xe5, 0xf1, 0xc9, 0x60, 0x55, 0x12, 0x4c, 0x60, 0x19, 0xce, 0xa2, 0xc4, 0x72, 0x88,
-	0x36, 0x21, 0x23, 0x2e, 0xce, 0x92, 0xd4, 0xdc, 0x82, 0x9c, 0xc4, 0x92, 0xd4, 0x62, 0x09, 0x66,
-	0x05, 0x66, 0x0d, 0x6e, 0x23, 0x11, 0x3d, 0xa8, 0xf3, 0x42, 0xa0, 0x12, 0x20, 0xb3, 0x83, 0x10,
-	0xca, 0x94, 0xec, 0xb8, 0x78, 0x90, 0xa5, 0x84, 0xa4, 0xb8, 0x38, 0xd2, 0x32, 0x73, 0x52, 0xf3,
-	0x12, 0x73, 0x53, 0xa1, 0x56, 0xc3, 0xf9, 0x20, 0x27, 0x25, 0xe5, 0xa7, 0x54, 0x42, 0x2d, 0x06,
-	0xb3, 0x9d, 0x4c, 0x2e, 0x3c, 0x94, 0x63, 0xb8, 0xf1, 0x50, 0x8e, 0xe1, 0xc3, 0x43, 0x39, 0xc6,
-	0x86, 0x47, 0x72, 0x8c, 0x2b, 0x1e, 0xc9, 0x31, 0x9e, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c,
-	0xe3, 0x83, 0x47, 0x72, 0x8c, 0x2f, 0x1e, 0xc9, 0x31, 0x7c, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1,
-	0x1c, 0xc3, 0x85, 0xc7, 0x72, 0x0c, 0x37, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81, 0xbd, 0x6d, 0x0c,
-	0x08, 0x00, 0x00, 0xff, 0xff, 0xbd, 0x90, 0x9e, 0xed, 0x3d, 0x01, 0x00, 0x00,
+	// 310 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x50, 0xbf, 0x4e, 0x02, 0x31,
+	0x18, 0x6f, 0x05, 0x89, 0x54, 0x8c, 0x49, 0xc3, 0x40, 0x48, 0xfc, 0x24, 0x4c, 0xc4, 0xe1, 0x2e,
+	0x41, 0x27, 0x07, 0x13, 0xd0, 0xf8, 0x00, 0xe8, 0x6e, 0x7a, 0x67, 0x39, 0x48, 0x7a, 0xf4, 0xd2,
+	0xf6, 0x82, 0x6e, 0x3e, 0x82, 0x8f, 0xe0, 0xe8, 0xa3, 0x38, 0x32, 0x32, 0x4a, 0x59, 0x18, 0x79,
+	0x04, 0xd3, 0xde, 0x01, 0x4e, 0xf7, 0xfb, 0xdd, 0xef, 0xcf, 0xf7, 0x7d, 0x25, 0x0d, 0x26, 0xb8,
+	0x32, 0x3a, 0xc8, 0x94, 0x34, 0x92, 0xd6, 0x0a, 0xd6, 0x6e, 0x26, 0x32, 0x91, 0xfe, 0x57, 0xe8,
+	0x50, 0xa1, 0xb6, 0x87, 0xc9, 0xd4, 0x4c, 0xf2, 0x28, 0x88, 0x65, 0x1a, 0x66, 0x4a, 0xa6, 0xdc,
+	0x4c, 0x78, 0xae, 0x43, 0x9f, 0x49, 0xd9, 0x8c, 0x25, 0x5c, 0x85, 0xb1, 0xc8, 0xb5, 0x39, 0x7c,
+	0xb3, 0x68, 0x87, 0x8a, 0x8e, 0xee, 0x1b, 0x39, 0x1f, 0x38, 0xff, 0xbd, 0x9c, 0x8d, 0xa7, 0xc9,
+	0x03, 0xd7, 0x31, 0xa5, 0xa4, 0x9a, 0x6b, 0xae, 0x5a, 0xb8, 0x83, 0x7b, 0xf5, 0x91, 0xc7, 0xf4,
+	0x82, 0x10, 0xc5, 0xe6, 0x2f, 0xb1, 0x77, 0xb5, 0x8e, 0xbc, 0x52, 0x57, 0x6c, 0x5e, 0xc4, 0x68,
+	0x9f, 0xd4, 0x0d, 0x4f, 0x33, 0xc1, 0x0c, 0xd7, 0xad, 0x4a, 0xa7, 0xd2, 0x3b, 0xed, 0x37, 0x83,
+	0xf2, 0x92, 0xe7, 0x52, 0x70, 0xdd, 0xa3, 0x83, 0xad, 0x7b, 0x47, 0x1a, 0xff, 0x25, 0xda, 0x26,
+	0x27, 0xe3, 0xa9, 0xe0, 0x33, 0x96, 0xf2, 0x72, 0xf4, 0x9e, 0xbb, 0x95, 0x22, 0xf9, 0xfa, 0x5e,
+	0x0e, 0xf6, 0xb8, 0x3b, 0x20, 0x67, 0x8f, 0xb9, 0x10, 0x4f, 0x66, 0x57, 0x70, 0x45, 0x8e, 0xb5,
+	0x23, 0x3e, 0xed, 0x16, 0xd8, 0xdf, 0x1c, 0xec, 0x8d, 0xa3, 0xc2, 0x72, 0x5b, 0xdd, 0x7c, 0x5d,
+	0xa2, 0xe1, 0xcd, 0x62, 0x05, 0x68, 0xb9, 0x02, 0xb4, 0x5d, 0x01, 0xfe, 0xb0, 0x80, 0xbf, 0x2d,
+	0xe0, 0x1f, 0x0b, 0x78, 0x61, 0x01, 0xff, 0x5a, 0xc0, 0x1b, 0x0b, 0x68, 0x6b, 0x01, 0x7f, 0xae,
+	0x01, 0x2d, 0xd6, 0x80, 0x96, 0x6b, 0x40, 0x51, 0xcd, 0xbf, 0xdc, 0xf5, 0xdf, 0x00, 0x63, 0x57,
+	0xef, 0x56, 0xab, 0x01, 0x00, 0x00,
 }
 
 func (this *AlertConfigDesc) Equal(that interface{}) bool {
@@ -249,6 +300,18 @@ func (this *TemplateDesc) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *FullStateDesc) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&alerts.FullStateDesc{")
+	if this.State != nil {
+		s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func valueToGoStringAlerts(v interface{}, typ string) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -345,6 +408,41 @@ func (m *TemplateDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FullStateDesc) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FullStateDesc) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FullStateDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.State != nil {
+		{
+			size, err := m.State.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAlerts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintAlerts(dAtA []byte, offset int, v uint64) int {
 	offset -= sovAlerts(v)
 	base := offset
@@ -396,6 +494,19 @@ func (m *TemplateDesc) Size() (n int) {
 	return n
 }
 
+func (m *FullStateDesc) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.State != nil {
+		l = m.State.Size()
+		n += 1 + l + sovAlerts(uint64(l))
+	}
+	return n
+}
+
 func sovAlerts(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -430,6 +541,16 @@ func (this *TemplateDesc) String() string {
 	}, "")
 	return s
 }
+func (this *FullStateDesc) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FullStateDesc{`,
+		`State:` + strings.Replace(fmt.Sprintf("%v", this.State), "FullState", "clusterpb.FullState", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func valueToStringAlerts(v interface{}) string {
 	rv := reflect.ValueOf(v)
 	if rv.IsNil() {
@@ -706,6 +827,95 @@ func (m *TemplateDesc) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FullStateDesc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAlerts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FullStateDesc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FullStateDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAlerts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAlerts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAlerts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.State == nil {
+				m.State = &clusterpb.FullState{}
+			}
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAlerts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAlerts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAlerts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipAlerts(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
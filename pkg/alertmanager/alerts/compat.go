@@ -3,7 +3,8 @@ package alerts
 import "errors"
 
 var (
-	ErrNotFound = errors.New("alertmanager config not found")
+	ErrNotFound          = errors.New("alertmanager config not found")
+	ErrFullStateNotFound = errors.New("alertmanager full state not found")
 )
 
 // ToProto transforms a yaml Alertmanager config and map of template files to an AlertConfigDesc
@@ -10,7 +10,8 @@ import (
 )
 
 var (
-	errReadOnly = errors.New("configdb alertmanager config storage is read-only")
+	errReadOnly          = errors.New("configdb alertmanager config storage is read-only")
+	errStateNotSupported = errors.New("configdb alertmanager storage doesn't support the alertmanager state")
 )
 
 // Store is a concrete implementation of RuleStore that sources rules from the config service
@@ -89,3 +90,24 @@ func (c *Store) SetAlertConfig(ctx context.Context, cfg alerts.AlertConfigDesc)
 func (c *Store) DeleteAlertConfig(ctx context.Context, user string) error {
 	return errReadOnly
 }
+
+// ListUsersWithFullState implements alertmanager.AlertStore. The configdb doesn't
+// store the alertmanager state, so no user is returned.
+func (c *Store) ListUsersWithFullState(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+// GetFullState implements alertmanager.AlertStore.
+func (c *Store) GetFullState(ctx context.Context, user string) (alerts.FullStateDesc, error) {
+	return alerts.FullStateDesc{}, alerts.ErrFullStateNotFound
+}
+
+// SetFullState implements alertmanager.AlertStore.
+func (c *Store) SetFullState(ctx context.Context, user string, fs alerts.FullStateDesc) error {
+	return errStateNotSupported
+}
+
+// DeleteFullState implements alertmanager.AlertStore.
+func (c *Store) DeleteFullState(ctx context.Context, user string) error {
+	return errStateNotSupported
+}
@@ -15,7 +15,8 @@ import (
 )
 
 var (
-	errReadOnly = errors.New("local alertmanager config storage is read-only")
+	errReadOnly          = errors.New("local alertmanager config storage is read-only")
+	errStateNotSupported = errors.New("local alertmanager storage doesn't support the alertmanager state")
 )
 
 // StoreConfig configures a static file alertmanager store
@@ -103,3 +104,24 @@ func (f *Store) SetAlertConfig(ctx context.Context, cfg alerts.AlertConfigDesc)
 func (f *Store) DeleteAlertConfig(ctx context.Context, user string) error {
 	return errReadOnly
 }
+
+// ListUsersWithFullState implements alertmanager.AlertStore. The local storage is read-only
+// and doesn't store the alertmanager state, so no user is returned.
+func (f *Store) ListUsersWithFullState(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+// GetFullState implements alertmanager.AlertStore.
+func (f *Store) GetFullState(ctx context.Context, user string) (alerts.FullStateDesc, error) {
+	return alerts.FullStateDesc{}, alerts.ErrFullStateNotFound
+}
+
+// SetFullState implements alertmanager.AlertStore.
+func (f *Store) SetFullState(ctx context.Context, user string, fs alerts.FullStateDesc) error {
+	return errStateNotSupported
+}
+
+// DeleteFullState implements alertmanager.AlertStore.
+func (f *Store) DeleteFullState(ctx context.Context, user string) error {
+	return errStateNotSupported
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io/ioutil"
 	"path"
+	"strings"
 
 	"github.com/thanos-io/thanos/pkg/runutil"
 
@@ -17,9 +18,13 @@ import (
 // =======================
 // Object Name: "alerts/<user_id>"
 // Storage Format: Encoded AlertConfigDesc
+//
+// Object Name: "alertmanager-state/<user_id>"
+// Storage Format: Encoded FullStateDesc
 
 const (
-	alertPrefix = "alerts/"
+	alertPrefix     = "alerts/"
+	fullStatePrefix = "alertmanager-state/"
 )
 
 // AlertStore allows cortex alertmanager configs to be stored using an object store backend.
@@ -100,3 +105,63 @@ func (a *AlertStore) SetAlertConfig(ctx context.Context, cfg alerts.AlertConfigD
 func (a *AlertStore) DeleteAlertConfig(ctx context.Context, user string) error {
 	return a.client.DeleteObject(ctx, path.Join(alertPrefix, user))
 }
+
+// ListUsersWithFullState returns the users which have the alertmanager state stored.
+func (a *AlertStore) ListUsersWithFullState(ctx context.Context) ([]string, error) {
+	objs, _, err := a.client.List(ctx, fullStatePrefix, "")
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		users = append(users, strings.TrimPrefix(obj.Key, fullStatePrefix))
+	}
+
+	return users, nil
+}
+
+// GetFullState returns a specified user's alertmanager state
+func (a *AlertStore) GetFullState(ctx context.Context, user string) (alerts.FullStateDesc, error) {
+	readCloser, err := a.client.GetObject(ctx, path.Join(fullStatePrefix, user))
+	if err == chunk.ErrStorageObjectNotFound {
+		return alerts.FullStateDesc{}, alerts.ErrFullStateNotFound
+	}
+	if err != nil {
+		return alerts.FullStateDesc{}, err
+	}
+
+	defer runutil.CloseWithLogOnErr(util_log.Logger, readCloser, "close alertmanager state reader")
+
+	buf, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return alerts.FullStateDesc{}, err
+	}
+
+	fs := alerts.FullStateDesc{}
+	if err := fs.Unmarshal(buf); err != nil {
+		return alerts.FullStateDesc{}, err
+	}
+
+	return fs, nil
+}
+
+// SetFullState sets a specified user's alertmanager state
+func (a *AlertStore) SetFullState(ctx context.Context, user string, fs alerts.FullStateDesc) error {
+	fsBytes, err := fs.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return a.client.PutObject(ctx, path.Join(fullStatePrefix, user), bytes.NewReader(fsBytes))
+}
+
+// DeleteFullState deletes a specified user's alertmanager state
+func (a *AlertStore) DeleteFullState(ctx context.Context, user string) error {
+	err := a.client.DeleteObject(ctx, path.Join(fullStatePrefix, user))
+	if err == chunk.ErrStorageObjectNotFound {
+		return nil
+	}
+
+	return err
+}
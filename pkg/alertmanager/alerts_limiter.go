@@ -0,0 +1,230 @@
+package alertmanager
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/alertmanager/provider"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+)
+
+// alertsLimitedMsg is part of the error returned when alerts are rejected because of the limits, so that
+// the error can be recognized in the responses of the API.
+const alertsLimitedMsg = "alerts rejected because of the limits"
+
+type trackedAlert struct {
+	size   int
+	endsAt time.Time
+}
+
+// limitedAlerts wraps an alerts provider to enforce the per-tenant limits on the
+// number and total size of the active alerts, when alerts are posted through the API.
+// Alerts exceeding the limits are rejected, while the other ones are stored.
+type limitedAlerts struct {
+	provider.Alerts
+
+	userID        string
+	limits        Limits
+	logger        log.Logger
+	insertLimited prometheus.Counter
+
+	mtx sync.Mutex
+	// Active alerts, used to compute the current count and size. Resolved alerts are
+	// lazily removed, only when the limits would be exceeded.
+	tracked   map[model.Fingerprint]trackedAlert
+	totalSize int
+}
+
+func newLimitedAlerts(alerts provider.Alerts, userID string, limits Limits, logger log.Logger, insertLimited prometheus.Counter) *limitedAlerts {
+	return &limitedAlerts{
+		Alerts:        alerts,
+		userID:        userID,
+		limits:        limits,
+		logger:        logger,
+		insertLimited: insertLimited,
+		tracked:       map[model.Fingerprint]trackedAlert{},
+	}
+}
+
+// Put implements provider.Alerts. If any alert is rejected because of the limits, the accepted
+// alerts are stored anyway and an error is returned.
+func (a *limitedAlerts) Put(alerts ...*types.Alert) error {
+	accepted := make([]*types.Alert, 0, len(alerts))
+	now := time.Now()
+
+	var limitErr error
+	a.mtx.Lock()
+	for _, alert := range alerts {
+		if err := a.track(alert, now); err != nil {
+			a.insertLimited.Inc()
+			level.Warn(a.logger).Log("msg", "alert rejected because of the limits", "alert", alert.Name(), "fingerprint", alert.Fingerprint(), "err", err)
+			if limitErr == nil {
+				limitErr = err
+			}
+			continue
+		}
+		accepted = append(accepted, alert)
+	}
+	a.mtx.Unlock()
+
+	if err := a.Alerts.Put(accepted...); err != nil {
+		return err
+	}
+	if limitErr != nil {
+		return fmt.Errorf("%d of %d %s: %v", len(alerts)-len(accepted), len(alerts), alertsLimitedMsg, limitErr)
+	}
+	return nil
+}
+
+// track checks the alert against the limits and keeps track of it if accepted.
+// Must be called with the lock held.
+func (a *limitedAlerts) track(alert *types.Alert, now time.Time) error {
+	fp := alert.Fingerprint()
+	existing, exists := a.tracked[fp]
+
+	// Resolving an alert is always allowed, and frees its share of the limits.
+	if alert.ResolvedAt(now) {
+		if exists {
+			a.untrack(fp, existing)
+		}
+		return nil
+	}
+
+	size := alertSize(alert.Alert)
+	if err := a.checkLimits(fp, size, now); err != nil {
+		return err
+	}
+
+	// The provider merges the alert with the existing one, so it stays active
+	// until the latest of the two end times.
+	endsAt := alert.EndsAt
+	if exists {
+		a.untrack(fp, existing)
+		if existing.endsAt.After(endsAt) {
+			endsAt = existing.endsAt
+		}
+	}
+	a.tracked[fp] = trackedAlert{size: size, endsAt: endsAt}
+	a.totalSize += size
+	return nil
+}
+
+func (a *limitedAlerts) checkLimits(fp model.Fingerprint, size int, now time.Time) error {
+	maxCount := a.limits.AlertmanagerMaxAlertsCount(a.userID)
+	maxSize := a.limits.AlertmanagerMaxAlertsSizeBytes(a.userID)
+	if maxCount <= 0 && maxSize <= 0 {
+		return nil
+	}
+
+	exceeds := func() error {
+		count, totalSize := len(a.tracked), a.totalSize+size
+		if existing, exists := a.tracked[fp]; exists {
+			totalSize -= existing.size
+		} else {
+			count++
+		}
+
+		if maxCount > 0 && count > maxCount {
+			return fmt.Errorf("exceeded the maximum number of alerts, limit: %d", maxCount)
+		}
+		if maxSize > 0 && totalSize > maxSize {
+			return fmt.Errorf("exceeded the maximum total size of the alerts, limit: %d bytes", maxSize)
+		}
+		return nil
+	}
+
+	if err := exceeds(); err == nil {
+		return nil
+	}
+
+	// Resolved alerts may be still tracked, so remove them and check again.
+	a.removeResolved(now)
+	return exceeds()
+}
+
+// removeResolved stops tracking the alerts resolved by the given time. Must be called with the lock held.
+func (a *limitedAlerts) removeResolved(now time.Time) {
+	for fp, t := range a.tracked {
+		if !t.endsAt.IsZero() && !t.endsAt.After(now) {
+			a.untrack(fp, t)
+		}
+	}
+}
+
+func (a *limitedAlerts) untrack(fp model.Fingerprint, t trackedAlert) {
+	delete(a.tracked, fp)
+	a.totalSize -= t.size
+}
+
+// alertSize returns the size of the alert, computed as the size of its labels,
+// annotations and generator URL.
+func alertSize(alert model.Alert) int {
+	size := len(alert.GeneratorURL)
+	for name, value := range alert.Labels {
+		size += len(name) + len(value)
+	}
+	for name, value := range alert.Annotations {
+		size += len(name) + len(value)
+	}
+	return size
+}
+
+// limitedAlertsHandler responds with 400 Bad Request to the requests posting alerts if any alert
+// has been rejected because of the limits, which the API reports as an internal error.
+func limitedAlertsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buf := &bufferedResponseWriter{header: w.Header()}
+		next.ServeHTTP(buf, r)
+
+		body := buf.body.Bytes()
+		status := buf.status
+		if status == http.StatusInternalServerError && bytes.Contains(body, []byte(alertsLimitedMsg)) {
+			status = http.StatusBadRequest
+			// The v1 API reports the type of the error in the body too.
+			body = bytes.Replace(body, []byte(`"errorType":"server_error"`), []byte(`"errorType":"bad_data"`), 1)
+			w.Header().Del("Content-Length")
+		}
+
+		if status != 0 {
+			w.WriteHeader(status)
+		}
+		_, _ = w.Write(body)
+	})
+}
+
+// bufferedResponseWriter buffers the status code and the body of a response, so that
+// they can be altered before being sent.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
@@ -0,0 +1,119 @@
+package alertmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/provider/mem"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAlert(name string, endsAt time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{model.AlertNameLabel: model.LabelValue(name)},
+			StartsAt: endsAt.Add(-time.Hour),
+			EndsAt:   endsAt,
+		},
+		UpdatedAt: time.Now(),
+	}
+}
+
+func TestLimitedAlerts(t *testing.T) {
+	now := time.Now()
+	active := now.Add(time.Hour)
+
+	tests := map[string]struct {
+		limits          *mockAlertManagerLimits
+		puts            [][]*types.Alert
+		expectedAlerts  []string
+		expectedLimited int
+		expectedErr     string
+	}{
+		"no limits": {
+			limits:         &mockAlertManagerLimits{},
+			puts:           [][]*types.Alert{{newTestAlert("a", active), newTestAlert("b", active), newTestAlert("c", active)}},
+			expectedAlerts: []string{"a", "b", "c"},
+		},
+		"max alerts count exceeded": {
+			limits:          &mockAlertManagerLimits{maxAlertsCount: 2},
+			puts:            [][]*types.Alert{{newTestAlert("a", active), newTestAlert("b", active), newTestAlert("c", active)}},
+			expectedAlerts:  []string{"a", "b"},
+			expectedLimited: 1,
+			expectedErr:     "1 of 3 alerts rejected because of the limits: exceeded the maximum number of alerts, limit: 2",
+		},
+		"updating an existing alert is allowed at the max alerts count": {
+			limits: &mockAlertManagerLimits{maxAlertsCount: 2},
+			puts: [][]*types.Alert{
+				{newTestAlert("a", active), newTestAlert("b", active)},
+				{newTestAlert("a", active.Add(time.Minute))},
+			},
+			expectedAlerts: []string{"a", "b"},
+		},
+		"resolving an alert frees its share of the limit": {
+			limits: &mockAlertManagerLimits{maxAlertsCount: 2},
+			puts: [][]*types.Alert{
+				{newTestAlert("a", active), newTestAlert("b", active)},
+				{newTestAlert("a", now.Add(-time.Minute)), newTestAlert("c", active)},
+			},
+			expectedAlerts: []string{"a", "b", "c"},
+		},
+		"alerts resolved by timeout don't count towards the limit": {
+			limits: &mockAlertManagerLimits{maxAlertsCount: 2},
+			puts: [][]*types.Alert{
+				{newTestAlert("a", now.Add(100*time.Millisecond)), newTestAlert("b", active)},
+				{newTestAlert("c", active)},
+			},
+			expectedAlerts: []string{"a", "b", "c"},
+		},
+		"max alerts size exceeded": {
+			// Each alert is 10 bytes: "alertname" + the name.
+			limits:          &mockAlertManagerLimits{maxAlertsSizeBytes: 25},
+			puts:            [][]*types.Alert{{newTestAlert("a", active), newTestAlert("b", active), newTestAlert("c", active)}},
+			expectedAlerts:  []string{"a", "b"},
+			expectedLimited: 1,
+			expectedErr:     "1 of 3 alerts rejected because of the limits: exceeded the maximum total size of the alerts, limit: 25 bytes",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			marker := types.NewMarker(prometheus.NewPedanticRegistry())
+			provider, err := mem.NewAlerts(context.Background(), marker, time.Hour, log.NewNopLogger())
+			require.NoError(t, err)
+			defer provider.Close()
+
+			counter := prometheus.NewCounter(prometheus.CounterOpts{})
+			alerts := newLimitedAlerts(provider, "user-1", testData.limits, log.NewNopLogger(), counter)
+
+			for i, put := range testData.puts {
+				if i > 0 {
+					time.Sleep(200 * time.Millisecond)
+				}
+				err := alerts.Put(put...)
+				if i == len(testData.puts)-1 && testData.expectedErr != "" {
+					require.EqualError(t, err, testData.expectedErr)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+
+			var names []string
+			it := alerts.GetPending()
+			for a := range it.Next() {
+				names = append(names, a.Name())
+			}
+			it.Close()
+
+			assert.ElementsMatch(t, testData.expectedAlerts, names)
+			assert.Equal(t, float64(testData.expectedLimited), testutil.ToFloat64(counter))
+		})
+	}
+}
@@ -96,7 +96,7 @@ func (am *MultitenantAlertmanager) SetUserConfig(w http.ResponseWriter, r *http.
 	}
 
 	cfgDesc := alerts.ToProto(cfg.AlertmanagerConfig, cfg.TemplateFiles, userID)
-	if err := validateUserConfig(logger, cfgDesc); err != nil {
+	if err := validateUserConfig(logger, cfgDesc, am.limits); err != nil {
 		level.Warn(logger).Log("msg", errValidatingConfig, "err", err.Error())
 		http.Error(w, fmt.Sprintf("%s: %s", errValidatingConfig, err.Error()), http.StatusBadRequest)
 		return
@@ -132,7 +132,7 @@ func (am *MultitenantAlertmanager) DeleteUserConfig(w http.ResponseWriter, r *ht
 }
 
 // Partially copied from: https://github.com/prometheus/alertmanager/blob/8e861c646bf67599a1704fc843c6a94d519ce312/cli/check_config.go#L65-L96
-func validateUserConfig(logger log.Logger, cfg alerts.AlertConfigDesc) error {
+func validateUserConfig(logger log.Logger, cfg alerts.AlertConfigDesc, limits Limits) error {
 	// We don't have a valid use case for empty configurations. If a tenant does not have a
 	// configuration set and issue a request to the Alertmanager, we'll a) upload an empty
 	// config and b) immediately start an Alertmanager instance for them if a fallback
@@ -141,11 +141,20 @@ func validateUserConfig(logger log.Logger, cfg alerts.AlertConfigDesc) error {
 		return fmt.Errorf("configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint")
 	}
 
+	// Check the size limits before parsing, so that very large configurations are rejected early.
+	if err := validateUserConfigSize(cfg, limits); err != nil {
+		return err
+	}
+
 	amCfg, err := config.Load(cfg.RawConfig)
 	if err != nil {
 		return err
 	}
 
+	if err := validateUserConfigReceivers(cfg.User, amCfg, limits); err != nil {
+		return err
+	}
+
 	// Create templates on disk in a temporary directory.
 	// Note: This means the validation will succeed if we can write to tmp but
 	// not to configured data dir, and on the flipside, it'll fail if we can't write
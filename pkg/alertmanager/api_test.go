@@ -21,6 +21,7 @@ func TestAMConfigValidationAPI(t *testing.T) {
 		name     string
 		cfg      string
 		response string
+		limits   *mockAlertManagerLimits
 		err      error
 	}{
 		{
@@ -122,14 +123,74 @@ template_files:
 `,
 			err: fmt.Errorf("error validating Alertmanager config: configuration provided is empty, if you'd like to remove your configuration please use the delete configuration endpoint"),
 		},
+		{
+			name: "It is not valid if the config exceeds the max size",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+`,
+			limits: &mockAlertManagerLimits{maxConfigSize: 10},
+			err:    fmt.Errorf("error validating Alertmanager config: Alertmanager configuration is too big, limit: 10 bytes, actual: 76 bytes"),
+		},
+		{
+			name: "It is not valid if a template exceeds the max size",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+template_files:
+  "good.tpl": "good-templ"
+`,
+			limits: &mockAlertManagerLimits{maxTemplateSize: 5},
+			err:    fmt.Errorf("error validating Alertmanager config: template good.tpl is too big, limit: 5 bytes, actual: 10 bytes"),
+		},
+		{
+			name: "It is not valid if the config exceeds the max number of receivers",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+    - name: other-receiver
+`,
+			limits: &mockAlertManagerLimits{maxReceiversCount: 1},
+			err:    fmt.Errorf("error validating Alertmanager config: too many receivers in the configuration, limit: 1, actual: 2"),
+		},
+		{
+			name: "It is not valid if a receiver targets a private address and they're blocked",
+			cfg: `
+alertmanager_config: |
+  route:
+    receiver: 'default-receiver'
+  receivers:
+    - name: default-receiver
+      webhook_configs:
+        - url: http://10.0.0.1/hook
+`,
+			limits: &mockAlertManagerLimits{blockPrivateAddresses: true},
+			err:    fmt.Errorf("error validating Alertmanager config: receiver default-receiver: integration webhook targets the private address 10.0.0.1, which is not allowed"),
+		},
 	}
 
-	am := &MultitenantAlertmanager{
-		store:  noopAlertStore{},
-		logger: util_log.Logger,
-	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			limits := tc.limits
+			if limits == nil {
+				limits = &mockAlertManagerLimits{}
+			}
+
+			am := &MultitenantAlertmanager{
+				store:  noopAlertStore{},
+				limits: limits,
+				logger: util_log.Logger,
+			}
+
 			req := httptest.NewRequest(http.MethodPost, "http://alertmanager/api/v1/alerts", bytes.NewReader([]byte(tc.cfg)))
 			ctx := user.InjectOrgID(req.Context(), "testing")
 			w := httptest.NewRecorder()
@@ -165,3 +226,15 @@ func (noopAlertStore) SetAlertConfig(ctx context.Context, cfg alerts.AlertConfig
 func (noopAlertStore) DeleteAlertConfig(ctx context.Context, user string) error {
 	return nil
 }
+func (noopAlertStore) ListUsersWithFullState(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+func (noopAlertStore) GetFullState(ctx context.Context, user string) (alerts.FullStateDesc, error) {
+	return alerts.FullStateDesc{}, alerts.ErrFullStateNotFound
+}
+func (noopAlertStore) SetFullState(ctx context.Context, user string, fs alerts.FullStateDesc) error {
+	return nil
+}
+func (noopAlertStore) DeleteFullState(ctx context.Context, user string) error {
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"path"
 	"strings"
 	"sync"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
 
+	"github.com/cortexproject/cortex/pkg/alertmanager/merger"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/ring/client"
 	"github.com/cortexproject/cortex/pkg/tenant"
@@ -64,14 +66,49 @@ func (d *Distributor) running(ctx context.Context) error {
 }
 
 // IsPathSupported returns true if the given route is currently supported by the Distributor.
-func (d *Distributor) IsPathSupported(path string) bool {
+func (d *Distributor) IsPathSupported(p string) bool {
 	// API can be found at https://petstore.swagger.io/?url=https://raw.githubusercontent.com/prometheus/alertmanager/master/api/v2/openapi.yaml.
-	return strings.HasSuffix(path, "/alerts") ||
-		strings.HasSuffix(path, "/alerts/groups")
+	return d.isQuorumWritePath(p) || d.isUnaryWritePath(p) || d.isQuorumReadPath(p) != nil
+}
+
+func (d *Distributor) isQuorumWritePath(p string) bool {
+	return strings.HasSuffix(p, "/alerts")
+}
+
+// isUnaryWritePath returns whether the writes to the given route must be sent to a single
+// alertmanager. The silences are replicated across the replicas of the tenant, and each
+// replica would otherwise create a different silence.
+func (d *Distributor) isUnaryWritePath(p string) bool {
+	return strings.HasSuffix(p, "/silences") ||
+		strings.HasSuffix(path.Dir(p), "/silence")
+}
+
+// isQuorumReadPath returns the merger of the responses for the given route, or nil if
+// the reads of the route are not supported.
+func (d *Distributor) isQuorumReadPath(p string) merger.Merger {
+	switch {
+	case strings.HasSuffix(p, "/v1/alerts"):
+		return merger.V1Alerts{}
+	case strings.HasSuffix(p, "/v2/alerts"):
+		return merger.V2Alerts{}
+	case strings.HasSuffix(p, "/v2/alerts/groups"):
+		return merger.V2AlertGroups{}
+	case strings.HasSuffix(p, "/v1/silences"):
+		return merger.V1Silences{}
+	case strings.HasSuffix(p, "/v2/silences"):
+		return merger.V2Silences{}
+	case strings.HasSuffix(path.Dir(p), "/v1/silence"):
+		return merger.V1SilenceID{}
+	case strings.HasSuffix(path.Dir(p), "/v2/silence"):
+		return merger.V2SilenceID{}
+	default:
+		return nil
+	}
 }
 
 // DistributeRequest shards the writes and returns as soon as the quorum is satisfied.
-// In case of reads, it proxies the request to one of the alertmanagers.
+// In case of reads, it sends the request to all the alertmanagers of the tenant and
+// merges the responses. Writes of silences are sent to one of the alertmanagers only.
 // DistributeRequest assumes that the caller has verified IsPathSupported returns
 // true for the route.
 func (d *Distributor) DistributeRequest(w http.ResponseWriter, r *http.Request) {
@@ -87,10 +124,16 @@ func (d *Distributor) DistributeRequest(w http.ResponseWriter, r *http.Request)
 	logger := util_log.WithContext(r.Context(), d.logger)
 
 	if r.Method == http.MethodGet || r.Method == http.MethodHead {
-		d.doRead(userID, w, r, logger)
-	} else {
+		if m := d.isQuorumReadPath(r.URL.Path); m != nil {
+			d.doQuorumRead(userID, w, r, logger, m)
+			return
+		}
+	} else if d.isQuorumWritePath(r.URL.Path) {
 		d.doWrite(userID, w, r, logger)
+		return
 	}
+
+	d.doUnary(userID, w, r, logger)
 }
 
 func (d *Distributor) doWrite(userID string, w http.ResponseWriter, r *http.Request, logger log.Logger) {
@@ -158,36 +201,118 @@ func (d *Distributor) doWrite(userID string, w http.ResponseWriter, r *http.Requ
 	}
 }
 
-func (d *Distributor) doRead(userID string, w http.ResponseWriter, r *http.Request, logger log.Logger) {
-	key := shardByUser(userID)
-	replicationSet, err := d.alertmanagerRing.Get(key, RingOp, nil, nil, nil)
-	if err != nil {
-		level.Error(logger).Log("msg", "failed to get replication set from the ring", "err", err)
-		w.WriteHeader(http.StatusInternalServerError)
+// doQuorumRead sends the request to all the alertmanagers of the tenant, and merges the
+// successful responses. It fails if more alertmanagers than tolerated by the replication
+// strategy fail.
+func (d *Distributor) doQuorumRead(userID string, w http.ResponseWriter, r *http.Request, logger log.Logger, m merger.Merger) {
+	replicationSet, req, ok := d.prepareRequest(userID, w, r, logger)
+	if !ok {
 		return
 	}
 
-	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, d.maxRecvMsgSize))
-	if err != nil {
-		if util.IsRequestBodyTooLarge(err) {
-			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+	sp, ctx := opentracing.StartSpanFromContext(r.Context(), "Distributor.doQuorumRead")
+	defer sp.Finish()
+
+	var (
+		wg        sync.WaitGroup
+		mtx       sync.Mutex
+		responses = make([]*httpgrpc.HTTPResponse, 0, len(replicationSet.Ingesters))
+		errs      = make([]error, 0, len(replicationSet.Ingesters))
+	)
+
+	for _, am := range replicationSet.Ingesters {
+		wg.Add(1)
+		go func(am ring.InstanceDesc) {
+			defer wg.Done()
+
+			resp, err := d.doRequest(ctx, am, req)
+			if err == nil && resp.Code/100 == 5 {
+				err = httpgrpc.ErrorFromHTTPResponse(resp)
+			}
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				level.Warn(logger).Log("msg", "failed to read from alertmanager", "alertmanager", am.Addr, "err", err)
+				errs = append(errs, err)
+				return
+			}
+			responses = append(responses, resp)
+		}(am)
+	}
+	wg.Wait()
+
+	if len(errs) > replicationSet.MaxErrors || len(responses) == 0 {
+		if len(errs) == 0 {
+			// This should not happen.
+			level.Error(logger).Log("msg", "distributor did not receive any response from alertmanagers, but there were no errors")
+			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		level.Error(logger).Log("msg", "failed to read the request body during read", "err", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		respondFromError(errs[0], w, logger)
 		return
 	}
-	req := &httpgrpc.HTTPRequest{
-		Method:  r.Method,
-		Url:     r.RequestURI,
+
+	var (
+		bodies   = make([][]byte, 0, len(responses))
+		firstOK  *httpgrpc.HTTPResponse
+		notFound *httpgrpc.HTTPResponse
+	)
+	for _, resp := range responses {
+		switch {
+		case resp.Code/100 == 2:
+			if firstOK == nil {
+				firstOK = resp
+			}
+			bodies = append(bodies, resp.Body)
+		case resp.Code == http.StatusNotFound:
+			// A replica may not have received a silence yet, so it's not found
+			// only if it's not found in any replica.
+			notFound = resp
+		default:
+			// Client errors are the same for all the replicas.
+			respondFromHTTPGRPCResponse(w, resp)
+			return
+		}
+	}
+
+	if firstOK == nil {
+		respondFromHTTPGRPCResponse(w, notFound)
+		return
+	}
+
+	body, err := m.MergeResponses(bodies)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to merge the responses from the alertmanagers", "err", err)
+		http.Error(w, "Failed to merge the responses from the alertmanagers", http.StatusInternalServerError)
+		return
+	}
+
+	headers := make([]*httpgrpc.Header, 0, len(firstOK.Headers))
+	for _, h := range firstOK.Headers {
+		// The body has changed, so its length can't be preserved.
+		if http.CanonicalHeaderKey(h.Key) == "Content-Length" {
+			continue
+		}
+		headers = append(headers, h)
+	}
+
+	respondFromHTTPGRPCResponse(w, &httpgrpc.HTTPResponse{
+		Code:    firstOK.Code,
+		Headers: headers,
 		Body:    body,
-		Headers: httpToHttpgrpcHeaders(r.Header),
+	})
+}
+
+// doUnary proxies the request to one of the alertmanagers of the tenant.
+func (d *Distributor) doUnary(userID string, w http.ResponseWriter, r *http.Request, logger log.Logger) {
+	replicationSet, req, ok := d.prepareRequest(userID, w, r, logger)
+	if !ok {
+		return
 	}
 
-	sp, ctx := opentracing.StartSpanFromContext(r.Context(), "Distributor.doRead")
+	sp, ctx := opentracing.StartSpanFromContext(r.Context(), "Distributor.doUnary")
 	defer sp.Finish()
-	// Until we have a mechanism to combine the results from multiple alertmanagers,
-	// we forward the request to only only of the alertmanagers.
 	amDesc := replicationSet.Ingesters[rand.Intn(len(replicationSet.Ingesters))]
 	resp, err := d.doRequest(ctx, amDesc, req)
 	if err != nil {
@@ -198,6 +323,36 @@ func (d *Distributor) doRead(userID string, w http.ResponseWriter, r *http.Reque
 	respondFromHTTPGRPCResponse(w, resp)
 }
 
+// prepareRequest looks up the alertmanagers of the tenant and builds the request to send them.
+// If it fails, the error is written to the response and false is returned.
+func (d *Distributor) prepareRequest(userID string, w http.ResponseWriter, r *http.Request, logger log.Logger) (ring.ReplicationSet, *httpgrpc.HTTPRequest, bool) {
+	key := shardByUser(userID)
+	replicationSet, err := d.alertmanagerRing.Get(key, RingOp, nil, nil, nil)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to get replication set from the ring", "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return ring.ReplicationSet{}, nil, false
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, d.maxRecvMsgSize))
+	if err != nil {
+		if util.IsRequestBodyTooLarge(err) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return ring.ReplicationSet{}, nil, false
+		}
+		level.Error(logger).Log("msg", "failed to read the request body", "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return ring.ReplicationSet{}, nil, false
+	}
+
+	return replicationSet, &httpgrpc.HTTPRequest{
+		Method:  r.Method,
+		Url:     r.RequestURI,
+		Body:    body,
+		Headers: httpToHttpgrpcHeaders(r.Header),
+	}, true
+}
+
 func respondFromError(err error, w http.ResponseWriter, logger log.Logger) {
 	httpResp, ok := httpgrpc.HTTPResponseFromError(errors.Cause(err))
 	if !ok {
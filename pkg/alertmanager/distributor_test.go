@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"net/http"
 	"net/http/httptest"
@@ -36,8 +37,11 @@ func TestDistributor_DistributeRequest(t *testing.T) {
 		numAM, numHappyAM   int
 		replicationFactor   int
 		isRead              bool
+		route               string
+		responseBody        []byte
 		expStatusCode       int
 		expectedTotalCalls  int
+		expectedBody        []byte
 		headersNotPreserved bool
 	}{
 		{
@@ -63,20 +67,54 @@ func TestDistributor_DistributeRequest(t *testing.T) {
 			expStatusCode:      http.StatusInternalServerError,
 			expectedTotalCalls: 3,
 		}, {
-			name:               "Read is sent to only 1 AM",
+			name:               "Read is sent to all the AMs of the tenant and the responses are merged",
 			numAM:              5,
 			numHappyAM:         5,
 			replicationFactor:  3,
 			isRead:             true,
+			responseBody:       []byte(`{"status":"success","data":[]}`),
+			expStatusCode:      http.StatusOK,
+			expectedTotalCalls: 3,
+			expectedBody:       []byte(`{"status":"success","data":[]}`),
+		}, {
+			name:               "Read fails if less than quorum AM succeed",
+			numAM:              5,
+			numHappyAM:         3, // Though we have 3 happy, it will hit >1 unhappy AM.
+			replicationFactor:  3,
+			isRead:             true,
+			responseBody:       []byte(`{"status":"success","data":[]}`),
+			expStatusCode:      http.StatusInternalServerError,
+			expectedTotalCalls: 3,
+		}, {
+			name:               "Read of a single silence is sent to all the AMs of the tenant",
+			numAM:              5,
+			numHappyAM:         5,
+			replicationFactor:  3,
+			isRead:             true,
+			route:              "/alertmanager/api/v2/silence/id1",
+			responseBody:       []byte(`{"id":"id1","status":{"state":"active"},"updatedAt":"2021-01-01T00:00:00.000Z","comment":"","createdBy":"","endsAt":"2021-01-02T00:00:00.000Z","matchers":[],"startsAt":"2021-01-01T00:00:00.000Z"}`),
+			expStatusCode:      http.StatusOK,
+			expectedTotalCalls: 3,
+			expectedBody:       []byte(`{"id":"id1","status":{"state":"active"},"updatedAt":"2021-01-01T00:00:00.000Z","comment":"","createdBy":"","endsAt":"2021-01-02T00:00:00.000Z","matchers":[],"startsAt":"2021-01-01T00:00:00.000Z"}`),
+		}, {
+			name:               "Write of a silence is sent to only 1 AM",
+			numAM:              5,
+			numHappyAM:         5,
+			replicationFactor:  3,
+			route:              "/alertmanager/api/v2/silences",
 			expStatusCode:      http.StatusOK,
 			expectedTotalCalls: 1,
 		},
 	}
 
-	route := "/alertmanager/api/v1/alerts"
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			d, ams, cleanup := prepare(t, c.numAM, c.numHappyAM, c.replicationFactor)
+			route := c.route
+			if route == "" {
+				route = "/alertmanager/api/v1/alerts"
+			}
+
+			d, ams, cleanup := prepare(t, c.numAM, c.numHappyAM, c.replicationFactor, c.responseBody)
 			t.Cleanup(cleanup)
 
 			ctx := user.InjectOrgID(context.Background(), "1")
@@ -94,6 +132,11 @@ func TestDistributor_DistributeRequest(t *testing.T) {
 			resp := w.Result()
 
 			require.Equal(t, c.expStatusCode, resp.StatusCode)
+			if c.expectedBody != nil {
+				body, err := ioutil.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, string(c.expectedBody), string(body))
+			}
 
 			if !c.headersNotPreserved {
 				// Making sure the headers are not altered.
@@ -126,13 +169,13 @@ func TestDistributor_DistributeRequest(t *testing.T) {
 
 }
 
-func prepare(t *testing.T, numAM, numHappyAM, replicationFactor int) (*Distributor, []*mockAlertmanager, func()) {
+func prepare(t *testing.T, numAM, numHappyAM, replicationFactor int, responseBody []byte) (*Distributor, []*mockAlertmanager, func()) {
 	ams := []*mockAlertmanager{}
 	for i := 0; i < numHappyAM; i++ {
-		ams = append(ams, newMockAlertmanager(i, true))
+		ams = append(ams, newMockAlertmanager(i, true, responseBody))
 	}
 	for i := numHappyAM; i < numAM; i++ {
-		ams = append(ams, newMockAlertmanager(i, false))
+		ams = append(ams, newMockAlertmanager(i, false, responseBody))
 	}
 
 	// Use a real ring with a mock KV store to test ring RF logic.
@@ -193,13 +236,15 @@ type mockAlertmanager struct {
 	mtx              sync.Mutex
 	myAddr           string
 	happy            bool
+	responseBody     []byte
 }
 
-func newMockAlertmanager(idx int, happy bool) *mockAlertmanager {
+func newMockAlertmanager(idx int, happy bool, responseBody []byte) *mockAlertmanager {
 	return &mockAlertmanager{
 		receivedRequests: make(map[string]map[int]int),
 		myAddr:           fmt.Sprintf("127.0.0.1:%05d", 10000+idx),
 		happy:            happy,
+		responseBody:     responseBody,
 	}
 }
 
@@ -231,6 +276,7 @@ func (am *mockAlertmanager) HandleRequest(_ context.Context, in *httpgrpc.HTTPRe
 					Values: []string{"ok-option-1", "ok-option-2"},
 				},
 			},
+			Body: am.responseBody,
 		}, nil
 	}
 
@@ -0,0 +1,223 @@
+package alertmanager
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	amconfig "github.com/prometheus/alertmanager/config"
+	commoncfg "github.com/prometheus/common/config"
+)
+
+// firewallProxy is an HTTP proxy, listening on the loopback interface, which blocks the connections
+// to the addresses for which blocked returns true. The addresses are checked when dialing, once the
+// host names have been resolved, so host names resolving to a blocked address can't be used to
+// bypass the check.
+//
+// The notifiers build their own HTTP client, which can't be given a custom dialer, so the notifiers
+// of the tenants whose private addresses are blocked are configured to send their requests through
+// this proxy. Plain HTTP requests are forwarded, while HTTPS requests are tunneled via CONNECT.
+type firewallProxy struct {
+	logger   log.Logger
+	dialer   *net.Dialer
+	forward  *httputil.ReverseProxy
+	listener net.Listener
+	server   *http.Server
+	url      *url.URL
+
+	wg sync.WaitGroup
+}
+
+func newFirewallProxy(blocked func(net.IP) bool, logger log.Logger) (*firewallProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &firewallProxy{
+		logger:   logger,
+		dialer:   newFirewallDialer(blocked),
+		listener: listener,
+		url:      &url.URL{Scheme: "http", Host: listener.Addr().String()},
+	}
+
+	p.forward = &httputil.ReverseProxy{
+		// The requests received by a proxy have an absolute URL, so they're forwarded as they are.
+		Director: func(req *http.Request) {
+			req.Header["X-Forwarded-For"] = nil
+		},
+		Transport: &http.Transport{
+			DialContext:     p.dialer.DialContext,
+			MaxIdleConns:    100,
+			IdleConnTimeout: 5 * time.Minute,
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			level.Warn(p.logger).Log("msg", "failed to forward the notification request", "host", req.Host, "err", err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		},
+	}
+
+	p.server = &http.Server{Handler: p}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		_ = p.server.Serve(listener)
+	}()
+
+	return p, nil
+}
+
+// newFirewallDialer returns a dialer failing to connect to the addresses for which blocked returns true.
+func newFirewallDialer(blocked func(net.IP) bool) *net.Dialer {
+	return &net.Dialer{
+		Timeout: 30 * time.Second,
+		// The Control function is called with the resolved address, right before connecting.
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			// Strip the IPv6 zone, if any.
+			if i := strings.LastIndex(host, "%"); i >= 0 {
+				host = host[:i]
+			}
+
+			if ip := net.ParseIP(host); ip == nil || blocked(ip) {
+				return fmt.Errorf("connections to the address %s are not allowed", host)
+			}
+			return nil
+		},
+	}
+}
+
+func (p *firewallProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodConnect {
+		p.forward.ServeHTTP(w, req)
+		return
+	}
+
+	dst, err := p.dialer.DialContext(req.Context(), "tcp", req.Host)
+	if err != nil {
+		level.Warn(p.logger).Log("msg", "failed to tunnel the notification request", "host", req.Host, "err", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		dst.Close()
+		http.Error(w, "tunneling not supported", http.StatusInternalServerError)
+		return
+	}
+
+	src, buf, err := hijacker.Hijack()
+	if err != nil {
+		dst.Close()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := io.WriteString(src, "HTTP/1.1 200 Connection established\r\n\r\n"); err != nil {
+		src.Close()
+		dst.Close()
+		return
+	}
+
+	// Close both connections as soon as either side is done.
+	go func() {
+		_, _ = io.Copy(dst, buf)
+		src.Close()
+		dst.Close()
+	}()
+	go func() {
+		_, _ = io.Copy(src, dst)
+		src.Close()
+		dst.Close()
+	}()
+}
+
+// URL returns the URL the notifiers should use as proxy URL.
+func (p *firewallProxy) URL() *url.URL {
+	return p.url
+}
+
+// Close stops the proxy. The connections tunneled via CONNECT are closed by the notifiers.
+func (p *firewallProxy) Close() {
+	_ = p.server.Close()
+	p.wg.Wait()
+}
+
+// proxiedReceivers returns a copy of the receivers, whose HTTP based integrations send their requests
+// through the proxy with the given URL. The email integration doesn't send HTTP requests, so it's left
+// as it is.
+func proxiedReceivers(receivers []*amconfig.Receiver, proxyURL *url.URL) []*amconfig.Receiver {
+	proxied := func(cfg *commoncfg.HTTPClientConfig) *commoncfg.HTTPClientConfig {
+		var c commoncfg.HTTPClientConfig
+		if cfg != nil {
+			c = *cfg
+		}
+		c.ProxyURL = commoncfg.URL{URL: proxyURL}
+		return &c
+	}
+
+	out := make([]*amconfig.Receiver, 0, len(receivers))
+	for _, rcv := range receivers {
+		r := *rcv
+
+		r.PagerdutyConfigs = nil
+		for _, c := range rcv.PagerdutyConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.PagerdutyConfigs = append(r.PagerdutyConfigs, &cc)
+		}
+		r.SlackConfigs = nil
+		for _, c := range rcv.SlackConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.SlackConfigs = append(r.SlackConfigs, &cc)
+		}
+		r.WebhookConfigs = nil
+		for _, c := range rcv.WebhookConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.WebhookConfigs = append(r.WebhookConfigs, &cc)
+		}
+		r.OpsGenieConfigs = nil
+		for _, c := range rcv.OpsGenieConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.OpsGenieConfigs = append(r.OpsGenieConfigs, &cc)
+		}
+		r.WechatConfigs = nil
+		for _, c := range rcv.WechatConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.WechatConfigs = append(r.WechatConfigs, &cc)
+		}
+		r.PushoverConfigs = nil
+		for _, c := range rcv.PushoverConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.PushoverConfigs = append(r.PushoverConfigs, &cc)
+		}
+		r.VictorOpsConfigs = nil
+		for _, c := range rcv.VictorOpsConfigs {
+			cc := *c
+			cc.HTTPConfig = proxied(c.HTTPConfig)
+			r.VictorOpsConfigs = append(r.VictorOpsConfigs, &cc)
+		}
+
+		out = append(out, &r)
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package alertmanager
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	amconfig "github.com/prometheus/alertmanager/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirewallProxy(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	httpsServer := httptest.NewTLSServer(handler)
+	defer httpsServer.Close()
+
+	tests := map[string]struct {
+		blocked       func(net.IP) bool
+		expectBlocked bool
+	}{
+		"private addresses blocked": {
+			blocked:       isPrivateIP,
+			expectBlocked: true,
+		},
+		"no address blocked": {
+			blocked:       func(net.IP) bool { return false },
+			expectBlocked: false,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			proxy, err := newFirewallProxy(testData.blocked, log.NewNopLogger())
+			require.NoError(t, err)
+			defer proxy.Close()
+
+			// Plain HTTP requests are forwarded by the proxy.
+			client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxy.URL())}}
+			resp, err := client.Get(httpServer.URL)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			if testData.expectBlocked {
+				assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+				assert.Contains(t, string(body), "are not allowed")
+			} else {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, "ok", string(body))
+			}
+
+			// HTTPS requests are tunneled by the proxy.
+			transport := httpsServer.Client().Transport.(*http.Transport).Clone()
+			transport.Proxy = http.ProxyURL(proxy.URL())
+			client = &http.Client{Transport: transport}
+			resp, err = client.Get(httpsServer.URL)
+
+			if testData.expectBlocked {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "Bad Gateway")
+			} else {
+				require.NoError(t, err)
+				body, err := ioutil.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+				assert.Equal(t, "ok", string(body))
+			}
+		})
+	}
+}
+
+func TestProxiedReceivers(t *testing.T) {
+	const cfg = `
+route:
+  receiver: webhook
+receivers:
+  - name: webhook
+    webhook_configs:
+      - url: https://example.com/alerts
+    slack_configs:
+      - api_url: https://hooks.slack.com/services/xxx
+        channel: '#alerts'
+    email_configs:
+      - to: test@example.com
+        from: test@example.com
+        smarthost: smtp.example.com:25
+`
+
+	amCfg, err := amconfig.Load(cfg)
+	require.NoError(t, err)
+
+	proxy, err := newFirewallProxy(isPrivateIP, log.NewNopLogger())
+	require.NoError(t, err)
+	defer proxy.Close()
+
+	receivers := proxiedReceivers(amCfg.Receivers, proxy.URL())
+	require.Len(t, receivers, 1)
+
+	// The HTTP based integrations are configured to use the proxy.
+	assert.Equal(t, proxy.URL(), receivers[0].WebhookConfigs[0].HTTPConfig.ProxyURL.URL)
+	assert.Equal(t, "https://example.com/alerts", receivers[0].WebhookConfigs[0].URL.String())
+	assert.Equal(t, proxy.URL(), receivers[0].SlackConfigs[0].HTTPConfig.ProxyURL.URL)
+	assert.Equal(t, amCfg.Receivers[0].EmailConfigs, receivers[0].EmailConfigs)
+
+	// The input configuration is left untouched.
+	assert.Nil(t, amCfg.Receivers[0].WebhookConfigs[0].HTTPConfig.ProxyURL.URL)
+	assert.Nil(t, amCfg.Receivers[0].SlackConfigs[0].HTTPConfig.ProxyURL.URL)
+}
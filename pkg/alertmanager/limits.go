@@ -0,0 +1,238 @@
+package alertmanager
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	amconfig "github.com/prometheus/alertmanager/config"
+	commoncfg "github.com/prometheus/common/config"
+	"golang.org/x/time/rate"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+)
+
+// Supported receiver integration types.
+const (
+	integrationEmail     = "email"
+	integrationPagerDuty = "pagerduty"
+	integrationSlack     = "slack"
+	integrationWebhook   = "webhook"
+	integrationOpsGenie  = "opsgenie"
+	integrationWeChat    = "wechat"
+	integrationPushover  = "pushover"
+	integrationVictorOps = "victorops"
+)
+
+// Limits defines limits used by the Alertmanager.
+type Limits interface {
+	// AlertmanagerMaxConfigSize returns the maximum size (bytes) of the Alertmanager configuration of a tenant, 0 for no limit.
+	AlertmanagerMaxConfigSize(tenant string) int
+	// AlertmanagerMaxTemplatesCount returns the maximum number of templates of a tenant, 0 for no limit.
+	AlertmanagerMaxTemplatesCount(tenant string) int
+	// AlertmanagerMaxTemplateSize returns the maximum size (bytes) of a single template of a tenant, 0 for no limit.
+	AlertmanagerMaxTemplateSize(tenant string) int
+	// AlertmanagerMaxReceiversCount returns the maximum number of receivers of a tenant, 0 for no limit.
+	AlertmanagerMaxReceiversCount(tenant string) int
+	// AlertmanagerReceiversAllowedIntegrations returns the receiver integration types a tenant is allowed to use, empty to allow all.
+	AlertmanagerReceiversAllowedIntegrations(tenant string) []string
+	// AlertmanagerReceiversDeniedIntegrations returns the receiver integration types a tenant is not allowed to use.
+	AlertmanagerReceiversDeniedIntegrations(tenant string) []string
+	// AlertmanagerReceiversBlockPrivateAddresses returns whether receivers targeting private, loopback or link-local addresses are rejected.
+	AlertmanagerReceiversBlockPrivateAddresses(tenant string) bool
+
+	// NotificationRateLimit returns the rate limit of the notifications sent by a tenant through the given integration type.
+	NotificationRateLimit(tenant string, integration string) rate.Limit
+	// NotificationBurstSize returns the burst size of the notifications sent by a tenant through the given integration type.
+	NotificationBurstSize(tenant string, integration string) int
+
+	// AlertmanagerMaxAlertsCount returns the maximum number of active alerts of a tenant, 0 for no limit.
+	AlertmanagerMaxAlertsCount(tenant string) int
+	// AlertmanagerMaxAlertsSizeBytes returns the maximum total size (bytes) of the active alerts of a tenant, 0 for no limit.
+	AlertmanagerMaxAlertsSizeBytes(tenant string) int
+}
+
+// allIntegrations are all the supported receiver integration types.
+var allIntegrations = []string{
+	integrationEmail, integrationPagerDuty, integrationSlack, integrationWebhook,
+	integrationOpsGenie, integrationWeChat, integrationPushover, integrationVictorOps,
+}
+
+// privateNetworks are the private IPv4 and IPv6 networks, including the carrier-grade NAT shared address space.
+var privateNetworks = mustParseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7")
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// validateUserConfigSize checks the size of the raw configuration and templates of a tenant against its limits.
+// It's run before parsing the configuration, so that very large configurations are rejected early.
+func validateUserConfigSize(cfg alerts.AlertConfigDesc, limits Limits) error {
+	if maxSize := limits.AlertmanagerMaxConfigSize(cfg.User); maxSize > 0 && len(cfg.RawConfig) > maxSize {
+		return fmt.Errorf("Alertmanager configuration is too big, limit: %d bytes, actual: %d bytes", maxSize, len(cfg.RawConfig))
+	}
+
+	if maxCount := limits.AlertmanagerMaxTemplatesCount(cfg.User); maxCount > 0 && len(cfg.Templates) > maxCount {
+		return fmt.Errorf("too many templates in the configuration, limit: %d, actual: %d", maxCount, len(cfg.Templates))
+	}
+
+	if maxSize := limits.AlertmanagerMaxTemplateSize(cfg.User); maxSize > 0 {
+		for _, tmpl := range cfg.Templates {
+			if len(tmpl.Body) > maxSize {
+				return fmt.Errorf("template %s is too big, limit: %d bytes, actual: %d bytes", tmpl.Filename, maxSize, len(tmpl.Body))
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateUserConfigReceivers checks the receivers of the parsed configuration of a tenant against its limits.
+func validateUserConfigReceivers(userID string, amCfg *amconfig.Config, limits Limits) error {
+	if maxCount := limits.AlertmanagerMaxReceiversCount(userID); maxCount > 0 && len(amCfg.Receivers) > maxCount {
+		return fmt.Errorf("too many receivers in the configuration, limit: %d, actual: %d", maxCount, len(amCfg.Receivers))
+	}
+
+	allowed := toSet(limits.AlertmanagerReceiversAllowedIntegrations(userID))
+	denied := toSet(limits.AlertmanagerReceiversDeniedIntegrations(userID))
+	blockPrivate := limits.AlertmanagerReceiversBlockPrivateAddresses(userID)
+
+	for _, rcv := range amCfg.Receivers {
+		for _, integration := range receiverIntegrations(rcv) {
+			if _, ok := allowed[integration.name]; len(allowed) > 0 && !ok {
+				return fmt.Errorf("receiver %s: integration %s is not allowed", rcv.Name, integration.name)
+			}
+			if _, ok := denied[integration.name]; ok {
+				return fmt.Errorf("receiver %s: integration %s is not allowed", rcv.Name, integration.name)
+			}
+
+			if !blockPrivate {
+				continue
+			}
+			// The notifications are sent through the firewall proxy, which can't be chained
+			// to the proxy configured in the receiver.
+			if integration.proxyURL != "" {
+				return fmt.Errorf("receiver %s: integration %s configures the proxy URL %s, which is not allowed", rcv.Name, integration.name, integration.proxyURL)
+			}
+			for _, host := range integration.hosts {
+				if isPrivateHost(host) {
+					return fmt.Errorf("receiver %s: integration %s targets the private address %s, which is not allowed", rcv.Name, integration.name, host)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+type receiverIntegration struct {
+	name string
+	// The hosts the integration sends requests to.
+	hosts []string
+	// The proxy URL configured in the integration, if any.
+	proxyURL string
+}
+
+// receiverIntegrations returns the integrations configured in the receiver.
+func receiverIntegrations(rcv *amconfig.Receiver) []receiverIntegration {
+	var integrations []receiverIntegration
+
+	add := func(name string, httpCfg *commoncfg.HTTPClientConfig, hosts ...string) {
+		integrations = append(integrations, receiverIntegration{name: name, hosts: hosts, proxyURL: proxyURL(httpCfg)})
+	}
+
+	for _, c := range rcv.EmailConfigs {
+		add(integrationEmail, nil, c.Smarthost.Host)
+	}
+	for _, c := range rcv.PagerdutyConfigs {
+		add(integrationPagerDuty, c.HTTPConfig, urlHost(c.URL))
+	}
+	for _, c := range rcv.SlackConfigs {
+		add(integrationSlack, c.HTTPConfig, secretURLHost(c.APIURL))
+	}
+	for _, c := range rcv.WebhookConfigs {
+		add(integrationWebhook, c.HTTPConfig, urlHost(c.URL))
+	}
+	for _, c := range rcv.OpsGenieConfigs {
+		add(integrationOpsGenie, c.HTTPConfig, urlHost(c.APIURL))
+	}
+	for _, c := range rcv.WechatConfigs {
+		add(integrationWeChat, c.HTTPConfig, urlHost(c.APIURL))
+	}
+	for _, c := range rcv.PushoverConfigs {
+		add(integrationPushover, c.HTTPConfig)
+	}
+	for _, c := range rcv.VictorOpsConfigs {
+		add(integrationVictorOps, c.HTTPConfig, urlHost(c.APIURL))
+	}
+
+	return integrations
+}
+
+func urlHost(u *amconfig.URL) string {
+	if u == nil || u.URL == nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+func secretURLHost(u *amconfig.SecretURL) string {
+	if u == nil {
+		return ""
+	}
+	return urlHost((*amconfig.URL)(u))
+}
+
+func proxyURL(cfg *commoncfg.HTTPClientConfig) string {
+	if cfg == nil || cfg.ProxyURL.URL == nil {
+		return ""
+	}
+	return cfg.ProxyURL.Redacted()
+}
+
+// isPrivateHost returns whether the host is localhost or a private, loopback, link-local
+// or unspecified IP address. Host names other than localhost are not resolved here: the
+// resolved addresses are checked when dialing, by the firewall proxy.
+func isPrivateHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return isPrivateIP(ip)
+}
+
+// isPrivateIP returns whether the IP address is a private, loopback, link-local or unspecified one.
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,20 @@
+package merger
+
+// Merger represents logic for merging response bodies.
+type Merger interface {
+	// MergeResponses merges the bodies of the responses to the same request,
+	// returned by the replicas of a tenant.
+	MergeResponses([][]byte) ([]byte, error)
+}
+
+// Noop is an implementation of the Merger interface which does not actually merge
+// responses, but just returns an arbitrary response (the first in the list).
+type Noop struct{}
+
+// MergeResponses implements Merger.
+func (Noop) MergeResponses(in [][]byte) ([]byte, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	return in[0], nil
+}
@@ -0,0 +1,63 @@
+package merger
+
+import (
+	"encoding/json"
+	"sort"
+
+	v1 "github.com/prometheus/alertmanager/api/v1"
+)
+
+const (
+	statusSuccess = "success"
+)
+
+// V1Alerts implements the Merger interface for GET /v1/alerts. It returns the union of the
+// alerts over all the responses. The v1 API doesn't expose when an alert has been updated,
+// so when the same alert exists in multiple responses, the instance with the latest
+// end time is returned.
+type V1Alerts struct{}
+
+// MergeResponses implements Merger.
+func (V1Alerts) MergeResponses(in [][]byte) ([]byte, error) {
+	type bodyType struct {
+		Status string      `json:"status"`
+		Data   []*v1.Alert `json:"data"`
+	}
+
+	alerts := make([]*v1.Alert, 0)
+	for _, body := range in {
+		parsed := bodyType{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, parsed.Data...)
+	}
+
+	return json.Marshal(bodyType{
+		Status: statusSuccess,
+		Data:   mergeV1Alerts(alerts),
+	})
+}
+
+func mergeV1Alerts(in []*v1.Alert) []*v1.Alert {
+	// Select an alert for each distinct alert fingerprint.
+	byFingerprint := make(map[string]*v1.Alert, len(in))
+	for _, alert := range in {
+		current, ok := byFingerprint[alert.Fingerprint]
+		if !ok || alert.EndsAt.After(current.EndsAt) {
+			byFingerprint[alert.Fingerprint] = alert
+		}
+	}
+
+	result := make([]*v1.Alert, 0, len(byFingerprint))
+	for _, alert := range byFingerprint {
+		result = append(result, alert)
+	}
+
+	// Mimic Alertmanager which returns the alerts ordered by fingerprint.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Fingerprint < result[j].Fingerprint
+	})
+
+	return result
+}
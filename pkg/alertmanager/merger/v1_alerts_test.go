@@ -0,0 +1,56 @@
+package merger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestV1Alerts(t *testing.T) {
+	alert1 := `{"labels":{"a":"b"},"annotations":{},"startsAt":"2021-01-01T00:00:00Z","endsAt":"2021-01-01T01:00:00Z","generatorURL":"","status":{"state":"active","silencedBy":[],"inhibitedBy":[]},"receivers":["dummy"],"fingerprint":"c4b6b79a607b6ba0"}`
+	alert1Newer := `{"labels":{"a":"b"},"annotations":{},"startsAt":"2021-01-01T00:00:00Z","endsAt":"2021-01-01T02:00:00Z","generatorURL":"","status":{"state":"active","silencedBy":[],"inhibitedBy":[]},"receivers":["dummy"],"fingerprint":"c4b6b79a607b6ba0"}`
+	alert2 := `{"labels":{"c":"d"},"annotations":{},"startsAt":"2021-01-01T00:00:00Z","endsAt":"2021-01-01T01:00:00Z","generatorURL":"","status":{"state":"active","silencedBy":[],"inhibitedBy":[]},"receivers":["dummy"],"fingerprint":"0a1b2c3d4e5f6789"}`
+
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			out:  []byte(`{"status":"success","data":[]}`),
+		},
+		{
+			name: "empty response",
+			in:   [][]byte{[]byte(`{"status":"success","data":[]}`)},
+			out:  []byte(`{"status":"success","data":[]}`),
+		},
+		{
+			name: "union of the alerts, ordered by fingerprint",
+			in: [][]byte{
+				[]byte(`{"status":"success","data":[` + alert1 + `]}`),
+				[]byte(`{"status":"success","data":[` + alert2 + `]}`),
+			},
+			out: []byte(`{"status":"success","data":[` + alert2 + `,` + alert1 + `]}`),
+		},
+		{
+			name: "duplicated alerts, the one with the latest end time is returned",
+			in: [][]byte{
+				[]byte(`{"status":"success","data":[` + alert1 + `]}`),
+				[]byte(`{"status":"success","data":[` + alert1Newer + `]}`),
+				[]byte(`{"status":"success","data":[` + alert1 + `]}`),
+			},
+			out: []byte(`{"status":"success","data":[` + alert1Newer + `]}`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V1Alerts{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			require.JSONEq(t, string(c.out), string(out))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package merger
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// V1Silences implements the Merger interface for GET /v1/silences. It returns the union of
+// the silences over all the responses. When the same silence exists in multiple responses,
+// the instance with the most recent UpdatedAt timestamp is returned.
+type V1Silences struct{}
+
+// MergeResponses implements Merger.
+func (V1Silences) MergeResponses(in [][]byte) ([]byte, error) {
+	type bodyType struct {
+		Status string           `json:"status"`
+		Data   []*types.Silence `json:"data"`
+	}
+
+	silences := make([]*types.Silence, 0)
+	for _, body := range in {
+		parsed := bodyType{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, err
+		}
+		silences = append(silences, parsed.Data...)
+	}
+
+	merged := mergeV1Silences(silences)
+	sortV1Silences(merged)
+
+	return json.Marshal(bodyType{
+		Status: statusSuccess,
+		Data:   merged,
+	})
+}
+
+func mergeV1Silences(in []*types.Silence) []*types.Silence {
+	// Select a silence for each distinct silence ID.
+	byID := make(map[string]*types.Silence, len(in))
+	for _, sil := range in {
+		if current, ok := byID[sil.ID]; !ok || sil.UpdatedAt.After(current.UpdatedAt) {
+			byID[sil.ID] = sil
+		}
+	}
+
+	result := make([]*types.Silence, 0, len(byID))
+	for _, sil := range byID {
+		result = append(result, sil)
+	}
+	return result
+}
+
+// sortV1Silences sorts the silences like Alertmanager does: first by state (active,
+// pending, expired), then by end time or start time depending on the state.
+func sortV1Silences(sils []*types.Silence) {
+	sort.Slice(sils, func(i, j int) bool {
+		return silenceLess(sils[i].Status.State, sils[i].StartsAt, sils[i].EndsAt, sils[j].Status.State, sils[j].StartsAt, sils[j].EndsAt)
+	})
+}
+
+// V1SilenceID implements the Merger interface for GET /v1/silence/{id}. It returns the
+// silence with the most recent UpdatedAt timestamp over all the responses.
+type V1SilenceID struct{}
+
+// MergeResponses implements Merger.
+func (V1SilenceID) MergeResponses(in [][]byte) ([]byte, error) {
+	type bodyType struct {
+		Status string         `json:"status"`
+		Data   *types.Silence `json:"data"`
+	}
+
+	silences := make([]*types.Silence, 0)
+	for _, body := range in {
+		parsed := bodyType{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, err
+		}
+		if parsed.Data != nil {
+			silences = append(silences, parsed.Data)
+		}
+	}
+
+	merged := mergeV1Silences(silences)
+	if len(merged) != 1 {
+		return nil, errors.New("unexpected mismatched silence IDs")
+	}
+
+	return json.Marshal(bodyType{
+		Status: statusSuccess,
+		Data:   merged[0],
+	})
+}
@@ -0,0 +1,100 @@
+package merger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	v1Silence1        = `{"id":"id1","matchers":[{"name":"a","value":"b","isRegex":false}],"startsAt":"2021-01-01T00:00:00Z","endsAt":"2021-01-01T02:00:00Z","updatedAt":"2021-01-01T00:00:00Z","createdBy":"user","comment":"test","status":{"state":"active"}}`
+	v1Silence1Expired = `{"id":"id1","matchers":[{"name":"a","value":"b","isRegex":false}],"startsAt":"2021-01-01T00:00:00Z","endsAt":"2021-01-01T00:30:00Z","updatedAt":"2021-01-01T00:30:00Z","createdBy":"user","comment":"test","status":{"state":"expired"}}`
+	v1Silence2        = `{"id":"id2","matchers":[{"name":"c","value":"d","isRegex":false}],"startsAt":"2021-01-01T00:00:00Z","endsAt":"2021-01-01T01:00:00Z","updatedAt":"2021-01-01T00:00:00Z","createdBy":"user","comment":"test","status":{"state":"active"}}`
+	v1Silence3        = `{"id":"id3","matchers":[{"name":"e","value":"f","isRegex":false}],"startsAt":"2021-01-01T03:00:00Z","endsAt":"2021-01-01T04:00:00Z","updatedAt":"2021-01-01T00:00:00Z","createdBy":"user","comment":"test","status":{"state":"pending"}}`
+)
+
+func TestV1Silences(t *testing.T) {
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			out:  []byte(`{"status":"success","data":[]}`),
+		},
+		{
+			name: "empty response",
+			in:   [][]byte{[]byte(`{"status":"success","data":[]}`)},
+			out:  []byte(`{"status":"success","data":[]}`),
+		},
+		{
+			name: "union of the silences, ordered by state and time",
+			in: [][]byte{
+				[]byte(`{"status":"success","data":[` + v1Silence3 + `,` + v1Silence1 + `]}`),
+				[]byte(`{"status":"success","data":[` + v1Silence2 + `]}`),
+			},
+			out: []byte(`{"status":"success","data":[` + v1Silence2 + `,` + v1Silence1 + `,` + v1Silence3 + `]}`),
+		},
+		{
+			name: "duplicated silences, the most recently updated one is returned",
+			in: [][]byte{
+				[]byte(`{"status":"success","data":[` + v1Silence1 + `]}`),
+				[]byte(`{"status":"success","data":[` + v1Silence1Expired + `]}`),
+			},
+			out: []byte(`{"status":"success","data":[` + v1Silence1Expired + `]}`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V1Silences{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			require.JSONEq(t, string(c.out), string(out))
+		})
+	}
+}
+
+func TestV1SilenceID(t *testing.T) {
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			err:  errors.New("unexpected mismatched silence IDs"),
+		},
+		{
+			name: "same silence, the most recently updated one is returned",
+			in: [][]byte{
+				[]byte(`{"status":"success","data":` + v1Silence1Expired + `}`),
+				[]byte(`{"status":"success","data":` + v1Silence1 + `}`),
+			},
+			out: []byte(`{"status":"success","data":` + v1Silence1Expired + `}`),
+		},
+		{
+			name: "different silences",
+			in: [][]byte{
+				[]byte(`{"status":"success","data":` + v1Silence1 + `}`),
+				[]byte(`{"status":"success","data":` + v1Silence2 + `}`),
+			},
+			err: errors.New("unexpected mismatched silence IDs"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V1SilenceID{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			if err == nil {
+				require.JSONEq(t, string(c.out), string(out))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package merger
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	v2_models "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/common/model"
+)
+
+// V2AlertGroups implements the Merger interface for GET /v2/alerts/groups. It returns
+// the union of the alert groups over all the responses. When the same alert group exists
+// in multiple responses, the alerts of the group are merged like in V2Alerts.
+type V2AlertGroups struct{}
+
+// MergeResponses implements Merger.
+func (V2AlertGroups) MergeResponses(in [][]byte) ([]byte, error) {
+	groups := make(v2_models.AlertGroups, 0)
+	for _, body := range in {
+		parsed := make(v2_models.AlertGroups, 0)
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, err
+		}
+		groups = append(groups, parsed...)
+	}
+
+	merged, err := mergeV2AlertGroups(groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merged)
+}
+
+func mergeV2AlertGroups(in v2_models.AlertGroups) (v2_models.AlertGroups, error) {
+	// Gather the groups by receiver and labels.
+	type groupKey struct {
+		receiver string
+		labels   model.Fingerprint
+	}
+
+	byKey := make(map[groupKey]*v2_models.AlertGroup, len(in))
+	for _, group := range in {
+		if group.Receiver == nil || group.Receiver.Name == nil {
+			return nil, errors.New("unexpected nil receiver")
+		}
+
+		key := groupKey{
+			receiver: *group.Receiver.Name,
+			labels:   labelSetToModel(group.Labels).Fingerprint(),
+		}
+		if current, ok := byKey[key]; ok {
+			current.Alerts = append(current.Alerts, group.Alerts...)
+		} else {
+			byKey[key] = group
+		}
+	}
+
+	result := make(v2_models.AlertGroups, 0, len(byKey))
+	for _, group := range byKey {
+		alerts, err := mergeV2Alerts(group.Alerts)
+		if err != nil {
+			return nil, err
+		}
+		group.Alerts = alerts
+		result = append(result, group)
+	}
+
+	// Return the groups in a deterministic order.
+	sort.Slice(result, func(i, j int) bool {
+		if ri, rj := *result[i].Receiver.Name, *result[j].Receiver.Name; ri != rj {
+			return ri < rj
+		}
+		return labelSetToModel(result[i].Labels).String() < labelSetToModel(result[j].Labels).String()
+	})
+
+	return result, nil
+}
+
+func labelSetToModel(ls v2_models.LabelSet) model.LabelSet {
+	result := make(model.LabelSet, len(ls))
+	for name, value := range ls {
+		result[model.LabelName(name)] = model.LabelValue(value)
+	}
+	return result
+}
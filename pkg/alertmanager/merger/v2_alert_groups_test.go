@@ -0,0 +1,57 @@
+package merger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2AlertGroups(t *testing.T) {
+	group := func(receiver, labels string, alerts ...string) string {
+		out := `{"alerts":[`
+		for i, a := range alerts {
+			if i > 0 {
+				out += ","
+			}
+			out += a
+		}
+		return out + `],"labels":` + labels + `,"receiver":{"name":"` + receiver + `"}}`
+	}
+
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			out:  []byte(`[]`),
+		},
+		{
+			name: "distinct groups are all returned, ordered by receiver and labels",
+			in: [][]byte{
+				[]byte(`[` + group("dummy", `{"group":"b"}`, v2Alert1) + `]`),
+				[]byte(`[` + group("dummy", `{"group":"a"}`, v2Alert2) + `,` + group("another", `{"group":"b"}`, v2Alert1) + `]`),
+			},
+			out: []byte(`[` + group("another", `{"group":"b"}`, v2Alert1) + `,` + group("dummy", `{"group":"a"}`, v2Alert2) + `,` + group("dummy", `{"group":"b"}`, v2Alert1) + `]`),
+		},
+		{
+			name: "the alerts of the same group are merged",
+			in: [][]byte{
+				[]byte(`[` + group("dummy", `{"group":"a"}`, v2Alert1) + `]`),
+				[]byte(`[` + group("dummy", `{"group":"a"}`, v2Alert1Newer, v2Alert2) + `]`),
+			},
+			out: []byte(`[` + group("dummy", `{"group":"a"}`, v2Alert2, v2Alert1Newer) + `]`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V2AlertGroups{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			require.JSONEq(t, string(c.out), string(out))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package merger
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	v2_models "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// V2Alerts implements the Merger interface for GET /v2/alerts. It returns the union of the
+// alerts over all the responses. When the same alert exists in multiple responses, the
+// instance with the most recent UpdatedAt timestamp is returned.
+type V2Alerts struct{}
+
+// MergeResponses implements Merger.
+func (V2Alerts) MergeResponses(in [][]byte) ([]byte, error) {
+	alerts := make(v2_models.GettableAlerts, 0)
+	for _, body := range in {
+		parsed := make(v2_models.GettableAlerts, 0)
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, parsed...)
+	}
+
+	merged, err := mergeV2Alerts(alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merged)
+}
+
+func mergeV2Alerts(in v2_models.GettableAlerts) (v2_models.GettableAlerts, error) {
+	// Select an alert for each distinct alert fingerprint.
+	byFingerprint := make(map[string]*v2_models.GettableAlert, len(in))
+	for _, alert := range in {
+		if alert.Fingerprint == nil {
+			return nil, errors.New("unexpected nil fingerprint")
+		}
+		if alert.UpdatedAt == nil {
+			return nil, errors.New("unexpected nil updatedAt")
+		}
+
+		key := *alert.Fingerprint
+		if current, ok := byFingerprint[key]; !ok || time.Time(*alert.UpdatedAt).After(time.Time(*current.UpdatedAt)) {
+			byFingerprint[key] = alert
+		}
+	}
+
+	result := make(v2_models.GettableAlerts, 0, len(byFingerprint))
+	for _, alert := range byFingerprint {
+		result = append(result, alert)
+	}
+
+	// Mimic Alertmanager which returns the alerts ordered by fingerprint.
+	sort.Slice(result, func(i, j int) bool {
+		return *result[i].Fingerprint < *result[j].Fingerprint
+	})
+
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package merger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	v2Alert1      = `{"annotations":{},"endsAt":"2021-01-01T01:00:00.000Z","fingerprint":"c4b6b79a607b6ba0","receivers":[{"name":"dummy"}],"startsAt":"2021-01-01T00:00:00.000Z","status":{"inhibitedBy":[],"silencedBy":[],"state":"active"},"updatedAt":"2021-01-01T00:00:00.000Z","labels":{"a":"b"}}`
+	v2Alert1Newer = `{"annotations":{},"endsAt":"2021-01-01T01:00:00.000Z","fingerprint":"c4b6b79a607b6ba0","receivers":[{"name":"dummy"}],"startsAt":"2021-01-01T00:00:00.000Z","status":{"inhibitedBy":[],"silencedBy":["id1"],"state":"suppressed"},"updatedAt":"2021-01-01T00:10:00.000Z","labels":{"a":"b"}}`
+	v2Alert2      = `{"annotations":{},"endsAt":"2021-01-01T01:00:00.000Z","fingerprint":"0a1b2c3d4e5f6789","receivers":[{"name":"dummy"}],"startsAt":"2021-01-01T00:00:00.000Z","status":{"inhibitedBy":[],"silencedBy":[],"state":"active"},"updatedAt":"2021-01-01T00:00:00.000Z","labels":{"c":"d"}}`
+)
+
+func TestV2Alerts(t *testing.T) {
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			out:  []byte(`[]`),
+		},
+		{
+			name: "empty response",
+			in:   [][]byte{[]byte(`[]`)},
+			out:  []byte(`[]`),
+		},
+		{
+			name: "union of the alerts, ordered by fingerprint",
+			in:   [][]byte{[]byte(`[` + v2Alert1 + `]`), []byte(`[` + v2Alert2 + `]`)},
+			out:  []byte(`[` + v2Alert2 + `,` + v2Alert1 + `]`),
+		},
+		{
+			name: "duplicated alerts, the most recently updated one is returned",
+			in:   [][]byte{[]byte(`[` + v2Alert1 + `]`), []byte(`[` + v2Alert1Newer + `]`), []byte(`[` + v2Alert1 + `]`)},
+			out:  []byte(`[` + v2Alert1Newer + `]`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V2Alerts{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			require.JSONEq(t, string(c.out), string(out))
+		})
+	}
+}
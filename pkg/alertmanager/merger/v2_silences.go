@@ -0,0 +1,137 @@
+package merger
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	v2_models "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// V2Silences implements the Merger interface for GET /v2/silences. It returns the union of
+// the silences over all the responses. When the same silence exists in multiple responses,
+// the instance with the most recent UpdatedAt timestamp is returned.
+type V2Silences struct{}
+
+// MergeResponses implements Merger.
+func (V2Silences) MergeResponses(in [][]byte) ([]byte, error) {
+	silences := make(v2_models.GettableSilences, 0)
+	for _, body := range in {
+		parsed := make(v2_models.GettableSilences, 0)
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, err
+		}
+		silences = append(silences, parsed...)
+	}
+
+	merged, err := mergeV2Silences(silences)
+	if err != nil {
+		return nil, err
+	}
+	sortV2Silences(merged)
+
+	return json.Marshal(merged)
+}
+
+func mergeV2Silences(in v2_models.GettableSilences) (v2_models.GettableSilences, error) {
+	// Select a silence for each distinct silence ID.
+	byID := make(map[string]*v2_models.GettableSilence, len(in))
+	for _, sil := range in {
+		if sil.ID == nil {
+			return nil, errors.New("unexpected nil id")
+		}
+		if sil.UpdatedAt == nil {
+			return nil, errors.New("unexpected nil updatedAt")
+		}
+
+		key := *sil.ID
+		if current, ok := byID[key]; !ok || time.Time(*sil.UpdatedAt).After(time.Time(*current.UpdatedAt)) {
+			byID[key] = sil
+		}
+	}
+
+	result := make(v2_models.GettableSilences, 0, len(byID))
+	for _, sil := range byID {
+		result = append(result, sil)
+	}
+	return result, nil
+}
+
+// sortV2Silences sorts the silences like Alertmanager does: first by state (active,
+// pending, expired), then by end time or start time depending on the state.
+func sortV2Silences(sils v2_models.GettableSilences) {
+	state := func(s *v2_models.GettableSilence) types.SilenceState {
+		if s.Status == nil || s.Status.State == nil {
+			return ""
+		}
+		return types.SilenceState(*s.Status.State)
+	}
+	startsAt := func(s *v2_models.GettableSilence) time.Time {
+		if s.StartsAt == nil {
+			return time.Time{}
+		}
+		return time.Time(*s.StartsAt)
+	}
+	endsAt := func(s *v2_models.GettableSilence) time.Time {
+		if s.EndsAt == nil {
+			return time.Time{}
+		}
+		return time.Time(*s.EndsAt)
+	}
+
+	sort.Slice(sils, func(i, j int) bool {
+		return silenceLess(state(sils[i]), startsAt(sils[i]), endsAt(sils[i]), state(sils[j]), startsAt(sils[j]), endsAt(sils[j]))
+	})
+}
+
+var silenceStateOrder = map[types.SilenceState]int{
+	types.SilenceStateActive:  1,
+	types.SilenceStatePending: 2,
+	types.SilenceStateExpired: 3,
+}
+
+// silenceLess returns whether the first silence should be sorted before the second one:
+// active silences that expire next come first, then pending silences that start next, and
+// finally the most recently expired silences.
+func silenceLess(state1 types.SilenceState, startsAt1, endsAt1 time.Time, state2 types.SilenceState, startsAt2, endsAt2 time.Time) bool {
+	if state1 != state2 {
+		return silenceStateOrder[state1] < silenceStateOrder[state2]
+	}
+	switch state1 {
+	case types.SilenceStateActive:
+		return endsAt1.Before(endsAt2)
+	case types.SilenceStatePending:
+		return startsAt1.Before(startsAt2)
+	case types.SilenceStateExpired:
+		return endsAt1.After(endsAt2)
+	}
+	return false
+}
+
+// V2SilenceID implements the Merger interface for GET /v2/silence/{id}. It returns the
+// silence with the most recent UpdatedAt timestamp over all the responses.
+type V2SilenceID struct{}
+
+// MergeResponses implements Merger.
+func (V2SilenceID) MergeResponses(in [][]byte) ([]byte, error) {
+	silences := make(v2_models.GettableSilences, 0)
+	for _, body := range in {
+		parsed := &v2_models.GettableSilence{}
+		if err := json.Unmarshal(body, parsed); err != nil {
+			return nil, err
+		}
+		silences = append(silences, parsed)
+	}
+
+	merged, err := mergeV2Silences(silences)
+	if err != nil {
+		return nil, err
+	}
+	if len(merged) != 1 {
+		return nil, errors.New("unexpected mismatched silence IDs")
+	}
+
+	return json.Marshal(merged[0])
+}
@@ -0,0 +1,94 @@
+package merger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	v2Silence1        = `{"id":"id1","matchers":[{"name":"a","value":"b","isRegex":false}],"startsAt":"2021-01-01T00:00:00.000Z","endsAt":"2021-01-01T02:00:00.000Z","updatedAt":"2021-01-01T00:00:00.000Z","createdBy":"user","comment":"test","status":{"state":"active"}}`
+	v2Silence1Expired = `{"id":"id1","matchers":[{"name":"a","value":"b","isRegex":false}],"startsAt":"2021-01-01T00:00:00.000Z","endsAt":"2021-01-01T00:30:00.000Z","updatedAt":"2021-01-01T00:30:00.000Z","createdBy":"user","comment":"test","status":{"state":"expired"}}`
+	v2Silence2        = `{"id":"id2","matchers":[{"name":"c","value":"d","isRegex":false}],"startsAt":"2021-01-01T00:00:00.000Z","endsAt":"2021-01-01T01:00:00.000Z","updatedAt":"2021-01-01T00:00:00.000Z","createdBy":"user","comment":"test","status":{"state":"active"}}`
+	v2Silence3        = `{"id":"id3","matchers":[{"name":"e","value":"f","isRegex":false}],"startsAt":"2021-01-01T03:00:00.000Z","endsAt":"2021-01-01T04:00:00.000Z","updatedAt":"2021-01-01T00:00:00.000Z","createdBy":"user","comment":"test","status":{"state":"pending"}}`
+)
+
+func TestV2Silences(t *testing.T) {
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			out:  []byte(`[]`),
+		},
+		{
+			name: "empty response",
+			in:   [][]byte{[]byte(`[]`)},
+			out:  []byte(`[]`),
+		},
+		{
+			name: "union of the silences, ordered by state and time",
+			in: [][]byte{
+				[]byte(`[` + v2Silence3 + `,` + v2Silence1 + `]`),
+				[]byte(`[` + v2Silence2 + `]`),
+			},
+			out: []byte(`[` + v2Silence2 + `,` + v2Silence1 + `,` + v2Silence3 + `]`),
+		},
+		{
+			name: "duplicated silences, the most recently updated one is returned",
+			in: [][]byte{
+				[]byte(`[` + v2Silence1 + `]`),
+				[]byte(`[` + v2Silence1Expired + `]`),
+			},
+			out: []byte(`[` + v2Silence1Expired + `]`),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V2Silences{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			require.JSONEq(t, string(c.out), string(out))
+		})
+	}
+}
+
+func TestV2SilenceID(t *testing.T) {
+	cases := []struct {
+		name string
+		in   [][]byte
+		err  error
+		out  []byte
+	}{
+		{
+			name: "no responses",
+			in:   [][]byte{},
+			err:  errors.New("unexpected mismatched silence IDs"),
+		},
+		{
+			name: "same silence, the most recently updated one is returned",
+			in:   [][]byte{[]byte(v2Silence1Expired), []byte(v2Silence1)},
+			out:  []byte(v2Silence1Expired),
+		},
+		{
+			name: "different silences",
+			in:   [][]byte{[]byte(v2Silence1), []byte(v2Silence2)},
+			err:  errors.New("unexpected mismatched silence IDs"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, err := V2SilenceID{}.MergeResponses(c.in)
+			require.Equal(t, c.err, err)
+			if err == nil {
+				require.JSONEq(t, string(c.out), string(out))
+			}
+		})
+	}
+}
@@ -18,14 +18,18 @@ import (
 	"github.com/go-kit/kit/log/level"
 	"github.com/pkg/errors"
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
 	amconfig "github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/httpgrpc/server"
+	"github.com/weaveworks/common/user"
 
+	"github.com/cortexproject/cortex/pkg/alertmanager/alertmanagerpb"
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
 	"github.com/cortexproject/cortex/pkg/ring"
+	"github.com/cortexproject/cortex/pkg/ring/client"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
@@ -111,8 +115,9 @@ type MultitenantAlertmanagerConfig struct {
 	FallbackConfigFile string `yaml:"fallback_config_file"`
 	AutoWebhookRoot    string `yaml:"auto_webhook_root"`
 
-	Store   AlertStoreConfig `yaml:"storage"`
-	Cluster ClusterConfig    `yaml:"cluster"`
+	Store     AlertStoreConfig `yaml:"storage"`
+	Persister PersisterConfig  `yaml:",inline"`
+	Cluster   ClusterConfig    `yaml:"cluster"`
 
 	EnableAPI bool `yaml:"enable_api"`
 
@@ -161,6 +166,7 @@ func (cfg *MultitenantAlertmanagerConfig) RegisterFlags(f *flag.FlagSet) {
 
 	cfg.ShardingRing.RegisterFlags(f)
 	cfg.Store.RegisterFlags(f)
+	cfg.Persister.RegisterFlagsWithPrefix("alertmanager", f)
 	cfg.Cluster.RegisterFlags(f)
 }
 
@@ -250,11 +256,16 @@ type MultitenantAlertmanager struct {
 	distributor    *Distributor
 	grpcServer     *server.Server
 
+	// Pool of clients used to replicate the tenants state and forward the requests to
+	// the other alertmanagers, when sharding is enabled.
+	alertmanagerClientsPool ClientsPool
+
 	// Subservices manager (ring, lifecycler)
 	subservices        *services.Manager
 	subservicesWatcher *services.FailureWatcher
 
-	store AlertStore
+	store  AlertStore
+	limits Limits
 
 	// The fallback config is stored as a string and parsed every time it's needed
 	// because we mutate the parsed results and don't want those changes to take
@@ -282,7 +293,7 @@ type MultitenantAlertmanager struct {
 }
 
 // NewMultitenantAlertmanager creates a new MultitenantAlertmanager.
-func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
+func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, limits Limits, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
 	err := os.MkdirAll(cfg.DataDir, 0777)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Alertmanager data directory %q: %s", cfg.DataDir, err)
@@ -306,8 +317,18 @@ func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, logger log.L
 
 	cfg.Cluster.SupportDeprecatedFlagset(cfg, logger)
 
+	// The configdb and local storages can't store the alertmanager state.
+	if (cfg.Store.Type == "configdb" || cfg.Store.Type == "local") && cfg.Persister.Interval > 0 {
+		level.Info(logger).Log("msg", "the "+cfg.Store.Type+" alertmanager storage doesn't support persisting the alertmanager state, the state won't be persisted")
+		cfg.Persister.Interval = 0
+	}
+
 	var peer *cluster.Peer
-	if cfg.Cluster.ListenAddr != "" {
+	// When sharding is enabled, the state is replicated across the tenant replicas
+	// in the ring, so there's no need to join the gossip mesh.
+	if cfg.ShardingEnabled {
+		level.Info(logger).Log("msg", "sharding is enabled, the alertmanager state is replicated via the ring and the gossip cluster is not used")
+	} else if cfg.Cluster.ListenAddr != "" {
 		peer, err = cluster.Create(
 			log.With(logger, "component", "cluster"),
 			registerer,
@@ -348,10 +369,10 @@ func NewMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, logger log.L
 		}
 	}
 
-	return createMultitenantAlertmanager(cfg, fallbackConfig, peer, store, ringStore, logger, registerer)
+	return createMultitenantAlertmanager(cfg, fallbackConfig, peer, store, ringStore, limits, logger, registerer)
 }
 
-func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackConfig []byte, peer *cluster.Peer, store AlertStore, ringStore kv.Client, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
+func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackConfig []byte, peer *cluster.Peer, store AlertStore, ringStore kv.Client, limits Limits, logger log.Logger, registerer prometheus.Registerer) (*MultitenantAlertmanager, error) {
 	am := &MultitenantAlertmanager{
 		cfg:                 cfg,
 		fallbackConfig:      string(fallbackConfig),
@@ -361,6 +382,7 @@ func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackC
 		multitenantMetrics:  newMultitenantAlertmanagerMetrics(registerer),
 		peer:                peer,
 		store:               store,
+		limits:              limits,
 		logger:              log.With(logger, "component", "MultiTenantAlertmanager"),
 		registry:            registerer,
 		ringCheckErrors: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
@@ -419,7 +441,8 @@ func createMultitenantAlertmanager(cfg *MultitenantAlertmanagerConfig, fallbackC
 
 		am.grpcServer = server.NewServer(&handlerForGRPCServer{am: am})
 
-		am.distributor, err = NewDistributor(cfg.AlertmanagerClient, cfg.MaxRecvMsgSize, am.ring, nil, log.With(logger, "component", "AlertmanagerDistributor"), am.registry)
+		am.alertmanagerClientsPool = newAlertmanagerClientsPool(client.NewRingServiceDiscovery(am.ring), cfg.AlertmanagerClient, logger, am.registry)
+		am.distributor, err = NewDistributor(cfg.AlertmanagerClient, cfg.MaxRecvMsgSize, am.ring, am.alertmanagerClientsPool, log.With(logger, "component", "AlertmanagerDistributor"), am.registry)
 		if err != nil {
 			return nil, errors.Wrap(err, "create distributor")
 		}
@@ -542,16 +565,50 @@ func (am *MultitenantAlertmanager) loadAndSyncConfigs(ctx context.Context, syncR
 	level.Info(am.logger).Log("msg", "synchronizing alertmanager configs for users")
 	am.syncTotal.WithLabelValues(syncReason).Inc()
 
-	cfgs, err := am.loadAlertmanagerConfigs(ctx)
+	allUsers, cfgs, err := am.loadAlertmanagerConfigs(ctx)
 	if err != nil {
 		am.syncFailures.WithLabelValues(syncReason).Inc()
 		return err
 	}
 
 	am.syncConfigs(cfgs)
+
+	// Note when cleaning up remote state, remember that the user may not necessarily be configured
+	// in this instance. Therefore, pass the list of _all_ configured users to filter by.
+	// The remote state is left untouched when not persisted by this instance.
+	if am.cfg.Persister.Interval > 0 {
+		am.deleteUnusedRemoteUserState(ctx, allUsers)
+	}
 	return nil
 }
 
+// deleteUnusedRemoteUserState deletes the state persisted in the store of the users
+// which don't have a configuration anymore.
+func (am *MultitenantAlertmanager) deleteUnusedRemoteUserState(ctx context.Context, allUsers []string) {
+	users := make(map[string]struct{}, len(allUsers))
+	for _, userID := range allUsers {
+		users[userID] = struct{}{}
+	}
+
+	usersWithState, err := am.store.ListUsersWithFullState(ctx)
+	if err != nil {
+		level.Warn(am.logger).Log("msg", "failed to list users with state", "err", err)
+		return
+	}
+
+	for _, userID := range usersWithState {
+		if _, ok := users[userID]; ok {
+			continue
+		}
+
+		if err := am.store.DeleteFullState(ctx, userID); err != nil {
+			level.Warn(am.logger).Log("msg", "failed to delete remote state for user", "user", userID, "err", err)
+		} else {
+			level.Info(am.logger).Log("msg", "deleted remote state for user", "user", userID)
+		}
+	}
+}
+
 // stopping runs when MultitenantAlertmanager transitions to Stopping state.
 func (am *MultitenantAlertmanager) stopping(_ error) error {
 	am.alertmanagersMtx.Lock()
@@ -574,17 +631,23 @@ func (am *MultitenantAlertmanager) stopping(_ error) error {
 }
 
 // loadAlertmanagerConfigs Loads (and filters) the alertmanagers configuration from object storage, taking into consideration the sharding strategy.
-func (am *MultitenantAlertmanager) loadAlertmanagerConfigs(ctx context.Context) (map[string]alerts.AlertConfigDesc, error) {
+// Returns the list of all users with a configuration, and the set of configurations owned by this instance.
+func (am *MultitenantAlertmanager) loadAlertmanagerConfigs(ctx context.Context) ([]string, map[string]alerts.AlertConfigDesc, error) {
 	configs, err := am.store.ListAlertConfigs(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	allUsers := make([]string, 0, len(configs))
+	for userID := range configs {
+		allUsers = append(allUsers, userID)
 	}
 
 	// Without any sharding, we return _all_ the configs and there's nothing else for us to do.
 	if !am.cfg.ShardingEnabled {
 		am.tenantsDiscovered.Set(float64(len(configs)))
 		am.tenantsOwned.Set(float64(len(configs)))
-		return configs, nil
+		return allUsers, configs, nil
 	}
 
 	ownedConfigs := map[string]alerts.AlertConfigDesc{}
@@ -606,7 +669,7 @@ func (am *MultitenantAlertmanager) loadAlertmanagerConfigs(ctx context.Context)
 
 	am.tenantsDiscovered.Set(float64(len(configs)))
 	am.tenantsOwned.Set(float64(len(ownedConfigs)))
-	return ownedConfigs, nil
+	return allUsers, ownedConfigs, nil
 }
 
 func (am *MultitenantAlertmanager) isConfigOwned(userID string) (bool, error) {
@@ -659,6 +722,13 @@ func (am *MultitenantAlertmanager) setConfig(cfg alerts.AlertConfigDesc) error {
 	var err error
 	var hasTemplateChanges bool
 
+	// The fallback configuration is provided by the operator, so it's not subject to the tenant limits.
+	if cfg.RawConfig != "" {
+		if err := validateUserConfigSize(cfg, am.limits); err != nil {
+			return fmt.Errorf("invalid Cortex configuration for %v: %v", cfg.User, err)
+		}
+	}
+
 	for _, tmpl := range cfg.Templates {
 		hasChanged, err := createTemplateFile(am.cfg.DataDir, cfg.User, tmpl.Filename, tmpl.Body)
 		if err != nil {
@@ -695,6 +765,11 @@ func (am *MultitenantAlertmanager) setConfig(cfg alerts.AlertConfigDesc) error {
 			// working configuration.
 			return fmt.Errorf("invalid Cortex configuration for %v: %v", cfg.User, err)
 		}
+		if err == nil {
+			if err := validateUserConfigReceivers(cfg.User, userAmConfig, am.limits); err != nil {
+				return fmt.Errorf("invalid Cortex configuration for %v: %v", cfg.User, err)
+			}
+		}
 	}
 
 	// We can have an empty configuration here if:
@@ -743,12 +818,23 @@ func (am *MultitenantAlertmanager) setConfig(cfg alerts.AlertConfigDesc) error {
 
 func (am *MultitenantAlertmanager) newAlertmanager(userID string, amConfig *amconfig.Config, rawCfg string) (*Alertmanager, error) {
 	reg := prometheus.NewRegistry()
+
+	// When sharding is enabled, the state is replicated to the other replicas of the tenant.
+	var replicator Replicator
+	if am.cfg.ShardingEnabled {
+		replicator = am
+	}
+
 	newAM, err := New(&Config{
 		UserID:      userID,
 		DataDir:     am.cfg.DataDir,
 		Logger:      util_log.Logger,
 		Peer:        am.peer,
 		PeerTimeout: am.cfg.Cluster.PeerTimeout,
+		Replicator:  replicator,
+		Store:       am.store,
+		Persister:   am.cfg.Persister,
+		Limits:      am.limits,
 		Retention:   am.cfg.Retention,
 		ExternalURL: am.cfg.ExternalURL.URL,
 	}, reg)
@@ -786,6 +872,233 @@ func (am *MultitenantAlertmanager) HandleRequest(ctx context.Context, in *httpgr
 	return am.grpcServer.Handle(ctx, in)
 }
 
+// ReplicateStateForUser implements Replicator. It writes the partial state of the tenant
+// to the other alertmanagers owning the tenant in the ring.
+func (am *MultitenantAlertmanager) ReplicateStateForUser(ctx context.Context, userID string, part *clusterpb.Part) error {
+	level.Debug(am.logger).Log("msg", "message received for replication", "user", userID, "key", part.Key)
+
+	replicationSet, err := am.ring.Get(shardByUser(userID), RingOp, nil, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get replication set from the ring")
+	}
+
+	selfAddress := am.ringLifecycler.GetInstanceAddr()
+	ctx = user.InjectOrgID(ctx, userID)
+
+	var (
+		wg      sync.WaitGroup
+		mtx     sync.Mutex
+		lastErr error
+	)
+
+	for _, desc := range replicationSet.Ingesters {
+		if desc.GetAddr() == selfAddress {
+			continue
+		}
+
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+
+			if err := am.updateStateOnReplica(ctx, addr, part); err != nil {
+				mtx.Lock()
+				lastErr = err
+				mtx.Unlock()
+			}
+		}(desc.GetAddr())
+	}
+
+	wg.Wait()
+	return lastErr
+}
+
+// updateStateOnReplica writes the partial state of the tenant in the context to the input replica.
+func (am *MultitenantAlertmanager) updateStateOnReplica(ctx context.Context, addr string, part *clusterpb.Part) error {
+	c, err := am.alertmanagerClientsPool.GetClientFor(addr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, am.cfg.AlertmanagerClient.RemoteTimeout)
+	defer cancel()
+
+	resp, err := c.UpdateState(ctx, part)
+	if err != nil {
+		return errors.Wrapf(err, "failed to replicate state to %s", addr)
+	}
+
+	switch resp.Status {
+	case alertmanagerpb.MERGE_ERROR:
+		level.Error(am.logger).Log("msg", "state replication failed", "replica", addr, "key", part.Key, "err", resp.Error)
+	case alertmanagerpb.USER_NOT_FOUND:
+		level.Debug(am.logger).Log("msg", "user not found while trying to replicate state", "replica", addr, "key", part.Key)
+	}
+	return nil
+}
+
+// ReadFullStateForUser implements Replicator. It reads the full state of the tenant from
+// the other alertmanagers owning the tenant in the ring. No state is returned if there's
+// no other replica, while an error is returned if no replica could be read.
+func (am *MultitenantAlertmanager) ReadFullStateForUser(ctx context.Context, userID string) ([]*clusterpb.FullState, error) {
+	replicationSet, err := am.ring.Get(shardByUser(userID), RingOp, nil, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get replication set from the ring")
+	}
+
+	selfAddress := am.ringLifecycler.GetInstanceAddr()
+	ctx = user.InjectOrgID(ctx, userID)
+
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		results  []*clusterpb.FullState
+		replicas int
+		lastErr  error
+	)
+
+	for _, desc := range replicationSet.Ingesters {
+		if desc.GetAddr() == selfAddress {
+			continue
+		}
+
+		replicas++
+		wg.Add(1)
+		go func(addr string) {
+			defer wg.Done()
+
+			fs, err := am.readFullStateFromReplica(ctx, addr)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				level.Warn(am.logger).Log("msg", "failed to read state from replica", "user", userID, "replica", addr, "err", err)
+				lastErr = err
+				return
+			}
+			if fs != nil {
+				results = append(results, fs)
+			}
+		}(desc.GetAddr())
+	}
+
+	wg.Wait()
+
+	if replicas > 0 && len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return results, nil
+}
+
+// readFullStateFromReplica reads the full state of the tenant in the context from the
+// input replica. No state is returned if the replica doesn't have the tenant.
+func (am *MultitenantAlertmanager) readFullStateFromReplica(ctx context.Context, addr string) (*clusterpb.FullState, error) {
+	c, err := am.alertmanagerClientsPool.GetClientFor(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, am.cfg.AlertmanagerClient.RemoteTimeout)
+	defer cancel()
+
+	resp, err := c.ReadState(ctx, &alertmanagerpb.ReadStateRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.Status {
+	case alertmanagerpb.READ_OK:
+		return resp.State, nil
+	case alertmanagerpb.READ_USER_NOT_FOUND:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("read state failed: %s", resp.Error)
+	}
+}
+
+// GetPositionForUser implements Replicator. It returns the position of this instance among
+// the alertmanagers owning the tenant in the ring, which is the same on all replicas.
+func (am *MultitenantAlertmanager) GetPositionForUser(userID string) int {
+	if am.cfg.ShardingRing.ReplicationFactor <= 1 {
+		return 0
+	}
+
+	replicationSet, err := am.ring.Get(shardByUser(userID), RingOp, nil, nil, nil)
+	if err != nil {
+		level.Error(am.logger).Log("msg", "unable to read the ring while computing the position of the instance", "user", userID, "err", err)
+		return 0
+	}
+
+	selfAddress := am.ringLifecycler.GetInstanceAddr()
+	for i, desc := range replicationSet.Ingesters {
+		if desc.GetAddr() == selfAddress {
+			return i
+		}
+	}
+	return 0
+}
+
+// UpdateState implements the Alertmanager gRPC service. It merges a partial state
+// received from another replica into the state of the tenant in the context.
+func (am *MultitenantAlertmanager) UpdateState(ctx context.Context, part *clusterpb.Part) (*alertmanagerpb.UpdateStateResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	am.alertmanagersMtx.Lock()
+	userAM, ok := am.alertmanagers[userID]
+	am.alertmanagersMtx.Unlock()
+
+	if !ok {
+		return &alertmanagerpb.UpdateStateResponse{
+			Status: alertmanagerpb.USER_NOT_FOUND,
+			Error:  "alertmanager for this user does not exist",
+		}, nil
+	}
+
+	if err = userAM.mergePartialExternalState(part); err != nil {
+		return &alertmanagerpb.UpdateStateResponse{
+			Status: alertmanagerpb.MERGE_ERROR,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	return &alertmanagerpb.UpdateStateResponse{Status: alertmanagerpb.OK}, nil
+}
+
+// ReadState implements the Alertmanager gRPC service. It returns the full state
+// of the tenant in the context.
+func (am *MultitenantAlertmanager) ReadState(ctx context.Context, _ *alertmanagerpb.ReadStateRequest) (*alertmanagerpb.ReadStateResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	am.alertmanagersMtx.Lock()
+	userAM, ok := am.alertmanagers[userID]
+	am.alertmanagersMtx.Unlock()
+
+	if !ok {
+		return &alertmanagerpb.ReadStateResponse{
+			Status: alertmanagerpb.READ_USER_NOT_FOUND,
+			Error:  "alertmanager for this user does not exist",
+		}, nil
+	}
+
+	fs, err := userAM.getFullState()
+	if err != nil {
+		return &alertmanagerpb.ReadStateResponse{
+			Status: alertmanagerpb.READ_ERROR,
+			Error:  err.Error(),
+		}, nil
+	}
+
+	return &alertmanagerpb.ReadStateResponse{
+		Status: alertmanagerpb.READ_OK,
+		State:  fs,
+	}, nil
+}
+
 // serveRequest serves the Alertmanager's web UI and API.
 func (am *MultitenantAlertmanager) serveRequest(w http.ResponseWriter, req *http.Request) {
 	userID, err := tenant.TenantID(req.Context())
@@ -852,6 +1165,12 @@ type StatusHandler struct {
 
 // ServeHTTP serves the status of the alertmanager.
 func (s StatusHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// The gossip cluster is not used when sharding is enabled.
+	if s.am.peer == nil {
+		util.WriteTextResponse(w, "Alertmanager gossip cluster is disabled, the state is replicated via the ring.")
+		return
+	}
+
 	err := statusTemplate.Execute(w, s.am.peer.Info())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
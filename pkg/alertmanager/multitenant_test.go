@@ -10,16 +10,22 @@ import (
 	"net/http/pprof"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/alertmanager/silence/silencepb"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
 
+	"github.com/cortexproject/cortex/pkg/alertmanager/alertmanagerpb"
 	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
@@ -46,8 +52,11 @@ receivers:
 // basic easily configurable mock
 type mockAlertStore struct {
 	configs     map[string]alerts.AlertConfigDesc
+	fullStates  map[string]alerts.FullStateDesc
 	OnList      func()
 	WithListErr error
+
+	mtx sync.Mutex
 }
 
 func (m *mockAlertStore) ListAlertConfigs(_ context.Context) (map[string]alerts.AlertConfigDesc, error) {
@@ -75,6 +84,51 @@ func (m *mockAlertStore) DeleteAlertConfig(_ context.Context, _ string) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (m *mockAlertStore) ListUsersWithFullState(_ context.Context) ([]string, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var users []string
+	for user := range m.fullStates {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (m *mockAlertStore) GetFullState(ctx context.Context, user string) (alerts.FullStateDesc, error) {
+	if err := ctx.Err(); err != nil {
+		return alerts.FullStateDesc{}, err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	fs, ok := m.fullStates[user]
+	if !ok {
+		return alerts.FullStateDesc{}, alerts.ErrFullStateNotFound
+	}
+	return fs, nil
+}
+
+func (m *mockAlertStore) SetFullState(_ context.Context, user string, fs alerts.FullStateDesc) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.fullStates == nil {
+		m.fullStates = map[string]alerts.FullStateDesc{}
+	}
+	m.fullStates[user] = fs
+	return nil
+}
+
+func (m *mockAlertStore) DeleteFullState(_ context.Context, user string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.fullStates, user)
+	return nil
+}
+
 func mockAlertmanagerConfig(t *testing.T) *MultitenantAlertmanagerConfig {
 	t.Helper()
 
@@ -120,7 +174,7 @@ func TestLoadAllConfigs(t *testing.T) {
 
 	reg := prometheus.NewPedanticRegistry()
 	cfg := mockAlertmanagerConfig(t)
-	am, err := createMultitenantAlertmanager(cfg, nil, nil, mockStore, nil, log.NewNopLogger(), reg)
+	am, err := createMultitenantAlertmanager(cfg, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 	require.NoError(t, err)
 
 	// Ensure the configs are synced correctly
@@ -216,13 +270,66 @@ func TestLoadAllConfigs(t *testing.T) {
 	`), "cortex_alertmanager_config_last_reload_successful"))
 }
 
+func TestLoadAllConfigs_ShouldRejectConfigsExceedingLimits(t *testing.T) {
+	mockStore := &mockAlertStore{
+		configs: map[string]alerts.AlertConfigDesc{
+			"user1": {
+				User:      "user1",
+				RawConfig: simpleConfigOne,
+				Templates: []*alerts.TemplateDesc{},
+			},
+		},
+	}
+
+	limits := &mockAlertManagerLimits{}
+	reg := prometheus.NewPedanticRegistry()
+	am, err := createMultitenantAlertmanager(mockAlertmanagerConfig(t), nil, nil, mockStore, nil, limits, log.NewNopLogger(), reg)
+	require.NoError(t, err)
+
+	require.NoError(t, am.loadAndSyncConfigs(context.Background(), reasonPeriodic))
+	require.Len(t, am.alertmanagers, 1)
+
+	// Upload a config with an integration the tenant is not allowed to use. The config
+	// must be rejected and the previous one should keep running.
+	limits.deniedIntegrations = []string{"webhook"}
+	mockStore.configs["user1"] = alerts.AlertConfigDesc{
+		User: "user1",
+		RawConfig: simpleConfigOne + `
+    webhook_configs:
+      - url: http://example.com/hook`,
+		Templates: []*alerts.TemplateDesc{},
+	}
+
+	require.NoError(t, am.loadAndSyncConfigs(context.Background(), reasonPeriodic))
+	require.Len(t, am.alertmanagers, 1)
+	require.Equal(t, simpleConfigOne, am.cfgs["user1"].RawConfig)
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, bytes.NewBufferString(`
+		# HELP cortex_alertmanager_config_last_reload_successful Boolean set to 1 whenever the last configuration reload attempt was successful.
+		# TYPE cortex_alertmanager_config_last_reload_successful gauge
+		cortex_alertmanager_config_last_reload_successful{user="user1"} 0
+	`), "cortex_alertmanager_config_last_reload_successful"))
+
+	// A new tenant exceeding the limits should not get an Alertmanager.
+	limits.maxConfigSize = len(simpleConfigOne)
+	mockStore.configs["user2"] = alerts.AlertConfigDesc{
+		User:      "user2",
+		RawConfig: simpleConfigOne + "\n",
+		Templates: []*alerts.TemplateDesc{},
+	}
+
+	require.NoError(t, am.loadAndSyncConfigs(context.Background(), reasonPeriodic))
+	require.Len(t, am.alertmanagers, 1)
+	require.NotContains(t, am.alertmanagers, "user2")
+}
+
 func TestAlertmanager_NoExternalURL(t *testing.T) {
 	amConfig := mockAlertmanagerConfig(t)
 	amConfig.ExternalURL = flagext.URLValue{} // no external URL
 
 	// Create the Multitenant Alertmanager.
 	reg := prometheus.NewPedanticRegistry()
-	_, err := NewMultitenantAlertmanager(amConfig, log.NewNopLogger(), reg)
+	_, err := NewMultitenantAlertmanager(amConfig, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 
 	require.EqualError(t, err, "unable to create Alertmanager because the external URL has not been configured")
 }
@@ -241,7 +348,7 @@ func TestAlertmanager_ServeHTTP(t *testing.T) {
 
 	// Create the Multitenant Alertmanager.
 	reg := prometheus.NewPedanticRegistry()
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, log.NewNopLogger(), reg)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 	require.NoError(t, err)
 
 	require.NoError(t, services.StartAndAwaitRunning(context.Background(), am))
@@ -321,6 +428,57 @@ func TestAlertmanager_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestAlertmanager_ServeHTTP_ShouldRejectAlertsExceedingTheLimits(t *testing.T) {
+	amConfig := mockAlertmanagerConfig(t)
+	mockStore := &mockAlertStore{
+		configs: map[string]alerts.AlertConfigDesc{
+			"user1": {User: "user1", RawConfig: simpleConfigTwo, Templates: []*alerts.TemplateDesc{}},
+		},
+	}
+
+	externalURL := flagext.URLValue{}
+	require.NoError(t, externalURL.Set("http://localhost:8080/alertmanager"))
+	amConfig.ExternalURL = externalURL
+
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{maxAlertsCount: 2}, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), am))
+	defer services.StopAndAwaitTerminated(context.Background(), am) //nolint:errcheck
+
+	ctx := user.InjectOrgID(context.Background(), "user1")
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", externalURL.String()+path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		am.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	// Alerts exceeding the limits are rejected with a client error, while the other ones are stored.
+	w := post("/api/v1/alerts", `[{"labels":{"alertname":"a"}},{"labels":{"alertname":"b"}},{"labels":{"alertname":"c"}}]`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `"errorType":"bad_data"`)
+	require.Contains(t, w.Body.String(), "1 of 3 alerts rejected because of the limits")
+
+	w = post("/api/v2/alerts", `[{"labels":{"alertname":"d"}}]`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "1 of 1 alerts rejected because of the limits")
+
+	// Updating an existing alert is still allowed.
+	w = post("/api/v2/alerts", `[{"labels":{"alertname":"a"}}]`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req := httptest.NewRequest("GET", externalURL.String()+"/api/v2/alerts", nil)
+	w = httptest.NewRecorder()
+	am.ServeHTTP(w, req.WithContext(ctx))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"alertname":"a"`)
+	require.Contains(t, w.Body.String(), `"alertname":"b"`)
+	require.NotContains(t, w.Body.String(), `"alertname":"c"`)
+	require.NotContains(t, w.Body.String(), `"alertname":"d"`)
+}
+
 func verify404(ctx context.Context, t *testing.T, am *MultitenantAlertmanager, method string, url string) {
 	metricsReq := httptest.NewRequest(method, url, strings.NewReader("Hello")) // Body for POST Request.
 	w := httptest.NewRecorder()
@@ -353,7 +511,7 @@ receivers:
 	amConfig.ExternalURL = externalURL
 
 	// Create the Multitenant Alertmanager.
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, log.NewNopLogger(), nil)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 	require.NoError(t, err)
 	am.fallbackConfig = fallbackCfg
 
@@ -453,7 +611,7 @@ func TestAlertmanager_InitialSyncWithSharding(t *testing.T) {
 				}))
 			}
 
-			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), nil)
+			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 			require.NoError(t, err)
 			defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
 
@@ -491,6 +649,7 @@ func TestAlertmanager_PerTenantSharding(t *testing.T) {
 		configs           int
 		expectedTenants   int
 		withSharding      bool
+		zones             int
 	}{
 		{
 			name:            "sharding disabled, 1 instance",
@@ -536,6 +695,15 @@ func TestAlertmanager_PerTenantSharding(t *testing.T) {
 			configs:           10,
 			expectedTenants:   30, // configs * replication factor
 		},
+		{
+			name:              "sharding enabled, 6 instances in 3 zones, RF = 3",
+			withSharding:      true,
+			instances:         6,
+			replicationFactor: 3,
+			zones:             3,
+			configs:           10,
+			expectedTenants:   30, // configs * replication factor
+		},
 	}
 
 	for _, tt := range tc {
@@ -576,9 +744,13 @@ func TestAlertmanager_PerTenantSharding(t *testing.T) {
 				if tt.withSharding {
 					amConfig.ShardingEnabled = true
 				}
+				if tt.zones > 0 {
+					amConfig.ShardingRing.ZoneAwarenessEnabled = true
+					amConfig.ShardingRing.InstanceZone = fmt.Sprintf("zone-%d", i%tt.zones)
+				}
 
 				reg := prometheus.NewPedanticRegistry()
-				am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), reg)
+				am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 				require.NoError(t, err)
 				defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
 
@@ -616,6 +788,24 @@ func TestAlertmanager_PerTenantSharding(t *testing.T) {
 			assert.Equal(t, tt.expectedTenants, numInstances)
 			assert.Equal(t, float64(tt.expectedTenants), metrics.GetSumOfGauges("cortex_alertmanager_tenants_owned"))
 			assert.Equal(t, float64(tt.configs*tt.instances), metrics.GetSumOfGauges("cortex_alertmanager_tenants_discovered"))
+
+			// With zone-awareness enabled, each tenant must be replicated across different zones.
+			if tt.zones > 0 {
+				tenantZones := map[string]map[string]struct{}{}
+				for _, am := range instances {
+					for userID := range am.cfgs {
+						if tenantZones[userID] == nil {
+							tenantZones[userID] = map[string]struct{}{}
+						}
+						tenantZones[userID][am.cfg.ShardingRing.InstanceZone] = struct{}{}
+					}
+				}
+
+				require.Len(t, tenantZones, tt.configs)
+				for userID, zones := range tenantZones {
+					assert.Len(t, zones, tt.replicationFactor, "tenant %s", userID)
+				}
+			}
 		})
 	}
 }
@@ -736,7 +926,7 @@ func TestAlertmanager_SyncOnRingTopologyChanges(t *testing.T) {
 			}
 
 			reg := prometheus.NewPedanticRegistry()
-			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), reg)
+			am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), reg)
 			require.NoError(t, err)
 
 			require.NoError(t, ringStore.CAS(ctx, RingKey, func(in interface{}) (interface{}, bool, error) {
@@ -791,7 +981,7 @@ func TestAlertmanager_RingLifecyclerShouldAutoForgetUnhealthyInstances(t *testin
 		configs: map[string]alerts.AlertConfigDesc{},
 	}
 
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), nil)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 	require.NoError(t, err)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, am))
 	defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
@@ -826,7 +1016,7 @@ func TestAlertmanager_InitialSyncFailureWithSharding(t *testing.T) {
 		WithListErr: fmt.Errorf("a fetch list failure"),
 	}
 
-	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, log.NewNopLogger(), nil)
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), nil)
 	require.NoError(t, err)
 	defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
 
@@ -837,3 +1027,225 @@ func TestAlertmanager_InitialSyncFailureWithSharding(t *testing.T) {
 	require.False(t, am.ringLifecycler.IsRegistered())
 	require.NotNil(t, am.ring)
 }
+
+func TestAlertmanager_StateReplicationWithSharding(t *testing.T) {
+	ctx := context.Background()
+	ringStore := consul.NewInMemoryClient(ring.GetCodec())
+	mockStore := &mockAlertStore{
+		configs: map[string]alerts.AlertConfigDesc{
+			"user-1": {User: "user-1", RawConfig: simpleConfigOne, Templates: []*alerts.TemplateDesc{}},
+		},
+	}
+
+	clientsPool := &passthroughAlertmanagerClientPool{clients: map[string]Client{}}
+
+	var instances []*MultitenantAlertmanager
+	var instanceIDs []string
+	for i := 1; i <= 2; i++ {
+		instanceID := fmt.Sprintf("alertmanager-%d", i)
+
+		amConfig := mockAlertmanagerConfig(t)
+		amConfig.ShardingEnabled = true
+		amConfig.ShardingRing.ReplicationFactor = 2
+		amConfig.ShardingRing.InstanceID = instanceID
+		amConfig.ShardingRing.InstanceAddr = fmt.Sprintf("127.0.0.%d", i)
+		amConfig.PollInterval = time.Hour
+		amConfig.ShardingRing.RingCheckPeriod = time.Hour
+
+		am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, ringStore, &mockAlertManagerLimits{}, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+		require.NoError(t, err)
+		defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
+
+		am.alertmanagerClientsPool = clientsPool
+		clientsPool.setServer(am.ringLifecycler.GetInstanceAddr(), am)
+
+		require.NoError(t, services.StartAndAwaitRunning(ctx, am))
+		instances = append(instances, am)
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	for _, am := range instances {
+		for _, id := range instanceIDs {
+			require.NoError(t, ring.WaitInstanceState(waitCtx, am.ring, id, ring.ACTIVE))
+		}
+	}
+
+	for _, am := range instances {
+		require.NoError(t, am.loadAndSyncConfigs(ctx, reasonRingChange))
+		require.Contains(t, am.alertmanagers, "user-1")
+	}
+
+	// Each replica has a different position for the tenant.
+	assert.ElementsMatch(t, []int{0, 1}, []int{instances[0].GetPositionForUser("user-1"), instances[1].GetPositionForUser("user-1")})
+
+	// The full state is read from the other replica only.
+	fullStates, err := instances[1].ReadFullStateForUser(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, fullStates, 1)
+
+	var keys []string
+	for _, p := range fullStates[0].Parts {
+		keys = append(keys, p.Key)
+	}
+	assert.ElementsMatch(t, []string{nflogStateKey, silencesStateKey}, keys)
+
+	// A partial state is replicated to the other replica only.
+	part := &fullStates[0].Parts[0]
+	mergesCount := func(am *MultitenantAlertmanager) float64 {
+		return testutil.ToFloat64(am.alertmanagers["user-1"].state.partialStateMergesTotal.WithLabelValues(part.Key))
+	}
+	before := []float64{mergesCount(instances[0]), mergesCount(instances[1])}
+
+	require.NoError(t, instances[0].ReplicateStateForUser(ctx, "user-1", part))
+	assert.Equal(t, before[0], mergesCount(instances[0]))
+	assert.Equal(t, before[1]+1, mergesCount(instances[1]))
+
+	// Requests for a tenant not owned are rejected.
+	updateResp, err := instances[0].UpdateState(user.InjectOrgID(ctx, "user-2"), part)
+	require.NoError(t, err)
+	assert.Equal(t, alertmanagerpb.USER_NOT_FOUND, updateResp.Status)
+
+	readResp, err := instances[0].ReadState(user.InjectOrgID(ctx, "user-2"), &alertmanagerpb.ReadStateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, alertmanagerpb.READ_USER_NOT_FOUND, readResp.Status)
+
+	// Invalid partial states are not merged.
+	updateResp, err = instances[0].UpdateState(user.InjectOrgID(ctx, "user-1"), &clusterpb.Part{Key: "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, alertmanagerpb.MERGE_ERROR, updateResp.Status)
+}
+
+// passthroughAlertmanagerClientPool is a ClientsPool calling the alertmanagers gRPC server methods directly.
+type passthroughAlertmanagerClientPool struct {
+	mtx     sync.Mutex
+	clients map[string]Client
+}
+
+func (p *passthroughAlertmanagerClientPool) setServer(addr string, server alertmanagerpb.AlertmanagerServer) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.clients[addr] = &passthroughAlertmanagerClient{addr: addr, server: server}
+}
+
+func (p *passthroughAlertmanagerClientPool) GetClientFor(addr string) (Client, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	c, ok := p.clients[addr]
+	if !ok {
+		return nil, fmt.Errorf("client not found for address: %v", addr)
+	}
+	return c, nil
+}
+
+type passthroughAlertmanagerClient struct {
+	addr   string
+	server alertmanagerpb.AlertmanagerServer
+}
+
+func (c *passthroughAlertmanagerClient) HandleRequest(ctx context.Context, in *httpgrpc.HTTPRequest, _ ...grpc.CallOption) (*httpgrpc.HTTPResponse, error) {
+	return c.server.HandleRequest(ctx, in)
+}
+
+func (c *passthroughAlertmanagerClient) UpdateState(ctx context.Context, in *clusterpb.Part, _ ...grpc.CallOption) (*alertmanagerpb.UpdateStateResponse, error) {
+	return c.server.UpdateState(ctx, in)
+}
+
+func (c *passthroughAlertmanagerClient) ReadState(ctx context.Context, in *alertmanagerpb.ReadStateRequest, _ ...grpc.CallOption) (*alertmanagerpb.ReadStateResponse, error) {
+	return c.server.ReadState(ctx, in)
+}
+
+func (c *passthroughAlertmanagerClient) RemoteAddress() string {
+	return c.addr
+}
+
+func TestAlertmanager_StatePersistedAndRestoredFromStore(t *testing.T) {
+	ctx := context.Background()
+	mockStore := &mockAlertStore{
+		configs: map[string]alerts.AlertConfigDesc{
+			"user-1": {User: "user-1", RawConfig: simpleConfigOne, Templates: []*alerts.TemplateDesc{}},
+		},
+	}
+
+	// Persist the state of a tenant without config, which is expected to be cleaned up.
+	require.NoError(t, mockStore.SetFullState(ctx, "user-2", alerts.FullStateDesc{State: &clusterpb.FullState{}}))
+
+	// Run a first alertmanager, create a silence and wait until the state is persisted.
+	{
+		amConfig := mockAlertmanagerConfig(t)
+		amConfig.Persister.Interval = 10 * time.Millisecond
+
+		am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+		require.NoError(t, err)
+		require.NoError(t, services.StartAndAwaitRunning(ctx, am))
+
+		_, err = am.alertmanagers["user-1"].silences.Set(&silencepb.Silence{
+			Matchers: []*silencepb.Matcher{{Name: "instance", Pattern: "prometheus-one", Type: silencepb.Matcher_EQUAL}},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		test.Poll(t, 5*time.Second, true, func() interface{} {
+			fs, err := mockStore.GetFullState(ctx, "user-1")
+			if err != nil {
+				return false
+			}
+			for _, part := range fs.State.Parts {
+				if part.Key == silencesStateKey && len(part.Data) > 0 {
+					return true
+				}
+			}
+			return false
+		})
+
+		// The state of the tenant without config has been deleted.
+		users, err := mockStore.ListUsersWithFullState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-1"}, users)
+
+		require.NoError(t, services.StopAndAwaitTerminated(ctx, am))
+	}
+
+	// Run a second alertmanager with an empty local disk, which is expected to restore the state.
+	{
+		amConfig := mockAlertmanagerConfig(t)
+		amConfig.Persister.Interval = time.Hour
+
+		am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+		require.NoError(t, err)
+		require.NoError(t, services.StartAndAwaitRunning(ctx, am))
+		defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
+
+		silences, _, err := am.alertmanagers["user-1"].silences.Query()
+		require.NoError(t, err)
+		require.Len(t, silences, 1)
+		assert.Equal(t, "prometheus-one", silences[0].Matchers[0].Pattern)
+	}
+}
+
+func TestAlertmanager_StateNotDeletedFromStoreIfPersistenceIsDisabled(t *testing.T) {
+	ctx := context.Background()
+	mockStore := &mockAlertStore{
+		configs: map[string]alerts.AlertConfigDesc{
+			"user-1": {User: "user-1", RawConfig: simpleConfigOne, Templates: []*alerts.TemplateDesc{}},
+		},
+	}
+	require.NoError(t, mockStore.SetFullState(ctx, "user-2", alerts.FullStateDesc{State: &clusterpb.FullState{}}))
+
+	amConfig := mockAlertmanagerConfig(t)
+	amConfig.Persister.Interval = 0
+
+	am, err := createMultitenantAlertmanager(amConfig, nil, nil, mockStore, nil, &mockAlertManagerLimits{}, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(ctx, am))
+	defer services.StopAndAwaitTerminated(ctx, am) //nolint:errcheck
+
+	// The state of the tenant without config is left untouched.
+	users, err := mockStore.ListUsersWithFullState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-2"}, users)
+}
@@ -0,0 +1,92 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// How frequently the rate limits are re-read from the tenant limits.
+	notificationLimitsRecheckInterval = 10 * time.Second
+)
+
+// errRateLimited is returned if a notification has been dropped because of the rate limit.
+var errRateLimited = errors.New("failed to notify due to rate limits")
+
+// notificationLimiter rate limits the notifications of a tenant for a single integration type.
+// It's shared by all the notifiers of the same integration type, and across config reloads.
+type notificationLimiter struct {
+	userID      string
+	integration string
+	limits      Limits
+	limited     prometheus.Counter
+
+	mtx       sync.Mutex
+	limiter   *rate.Limiter
+	recheckAt time.Time
+}
+
+func newNotificationLimiter(userID, integration string, limits Limits, limited prometheus.Counter) *notificationLimiter {
+	return &notificationLimiter{
+		userID:      userID,
+		integration: integration,
+		limits:      limits,
+		limited:     limited,
+		limiter:     rate.NewLimiter(limits.NotificationRateLimit(userID, integration), limits.NotificationBurstSize(userID, integration)),
+		recheckAt:   time.Now().Add(notificationLimitsRecheckInterval),
+	}
+}
+
+// allow returns whether a notification can be sent at the given time.
+func (l *notificationLimiter) allow(now time.Time) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	// The limits can change at runtime, so they're periodically re-read.
+	if !now.Before(l.recheckAt) {
+		if limit := l.limits.NotificationRateLimit(l.userID, l.integration); limit != l.limiter.Limit() {
+			l.limiter.SetLimitAt(now, limit)
+		}
+		if burst := l.limits.NotificationBurstSize(l.userID, l.integration); burst != l.limiter.Burst() {
+			l.limiter.SetBurstAt(now, burst)
+		}
+		l.recheckAt = now.Add(notificationLimitsRecheckInterval)
+	}
+
+	if !l.limiter.AllowN(now, 1) {
+		l.limited.Inc()
+		return false
+	}
+	return true
+}
+
+// rateLimitedNotifier drops the notifications exceeding the rate limit of its integration type.
+type rateLimitedNotifier struct {
+	upstream notify.Notifier
+	limiter  *notificationLimiter
+}
+
+func newRateLimitedNotifier(upstream notify.Notifier, limiter *notificationLimiter) *rateLimitedNotifier {
+	return &rateLimitedNotifier{
+		upstream: upstream,
+		limiter:  limiter,
+	}
+}
+
+// Notify implements notify.Notifier.
+func (r *rateLimitedNotifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	if !r.limiter.allow(time.Now()) {
+		// Don't retry the notification: retrying would just add more pressure
+		// on an integration whose limit has already been reached.
+		return false, errRateLimited
+	}
+
+	return r.upstream.Notify(ctx, alerts...)
+}
@@ -0,0 +1,70 @@
+package alertmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+	"golang.org/x/time/rate"
+)
+
+type notifierMock struct {
+	calls atomic.Int32
+}
+
+func (n *notifierMock) Notify(context.Context, ...*types.Alert) (bool, error) {
+	n.calls.Inc()
+	return true, nil
+}
+
+func TestRateLimitedNotifier(t *testing.T) {
+	limits := &mockAlertManagerLimits{
+		notificationRate:  map[string]rate.Limit{integrationEmail: 0.0001},
+		notificationBurst: map[string]int{integrationEmail: 2},
+	}
+	counter := prometheus.NewCounter(prometheus.CounterOpts{})
+
+	upstream := &notifierMock{}
+	n := newRateLimitedNotifier(upstream, newNotificationLimiter("user-1", integrationEmail, limits, counter))
+
+	// The burst is allowed, then the notifications are dropped and not retried.
+	for i := 0; i < 2; i++ {
+		retry, err := n.Notify(context.Background())
+		assert.True(t, retry)
+		assert.NoError(t, err)
+	}
+
+	retry, err := n.Notify(context.Background())
+	assert.False(t, retry)
+	assert.Equal(t, errRateLimited, err)
+	assert.Equal(t, int32(2), upstream.calls.Load())
+	assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+}
+
+func TestNotificationLimiter_ShouldReloadLimits(t *testing.T) {
+	limits := &mockAlertManagerLimits{
+		notificationRate:  map[string]rate.Limit{integrationSlack: 0.0001},
+		notificationBurst: map[string]int{integrationSlack: 1},
+	}
+	counter := prometheus.NewCounter(prometheus.CounterOpts{})
+	l := newNotificationLimiter("user-1", integrationSlack, limits, counter)
+
+	now := time.Now()
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now))
+
+	// The new limits are not applied until the recheck interval has elapsed.
+	limits.notificationRate = map[string]rate.Limit{}
+	assert.False(t, l.allow(now))
+
+	now = now.Add(notificationLimitsRecheckInterval)
+	for i := 0; i < 10; i++ {
+		assert.True(t, l.allow(now))
+	}
+	assert.Equal(t, float64(2), testutil.ToFloat64(counter))
+}
@@ -0,0 +1,111 @@
+package alertmanager
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
+)
+
+const (
+	defaultPersistTimeout = 30 * time.Second
+)
+
+// PersisterConfig configures the periodic upload of the tenants state to the alerts store.
+type PersisterConfig struct {
+	Interval time.Duration `yaml:"persist_interval"`
+}
+
+// RegisterFlagsWithPrefix registers the flags with the given prefix.
+func (cfg *PersisterConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.DurationVar(&cfg.Interval, prefix+".persist-interval", 15*time.Minute, "The interval between persisting the current alertmanager state (notification log and silences) of each tenant to the alerts storage. The state is restored from the storage when a tenant alertmanager is started and the state can't be read from the other replicas. Not supported by the configdb and local storages. 0 to disable.")
+}
+
+// persistableState is the state of a tenant Alertmanager which can be persisted.
+type persistableState interface {
+	// getFullState returns the full state of the tenant.
+	getFullState() (*clusterpb.FullState, error)
+	// shouldPersist returns whether this instance is the one in charge of persisting the state.
+	shouldPersist() bool
+}
+
+// statePersister periodically writes the full state of a tenant Alertmanager to the alerts store,
+// so that the silences and notification log survive the loss of the local disk.
+type statePersister struct {
+	services.Service
+
+	state  persistableState
+	store  AlertStore
+	userID string
+	logger log.Logger
+
+	timeout time.Duration
+
+	persistTotal  prometheus.Counter
+	persistFailed prometheus.Counter
+}
+
+// newStatePersister creates a new state persister.
+func newStatePersister(cfg PersisterConfig, userID string, state persistableState, store AlertStore, logger log.Logger, reg prometheus.Registerer) *statePersister {
+	s := &statePersister{
+		state:   state,
+		store:   store,
+		userID:  userID,
+		logger:  logger,
+		timeout: defaultPersistTimeout,
+		persistTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_persist_total",
+			Help: "Number of times we have tried to persist the running state to remote storage.",
+		}),
+		persistFailed: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_state_persist_failed_total",
+			Help: "Number of times we have failed to persist the running state to remote storage.",
+		}),
+	}
+
+	s.Service = services.NewTimerService(cfg.Interval, nil, s.iteration, nil)
+	return s
+}
+
+func (s *statePersister) iteration(ctx context.Context) error {
+	if err := s.persist(ctx); err != nil {
+		level.Error(s.logger).Log("msg", "failed to persist state", "user", s.userID, "err", err)
+	}
+
+	// Failing to persist the state should not stop the persister.
+	return nil
+}
+
+func (s *statePersister) persist(ctx context.Context) error {
+	// Only one replica per tenant persists the state.
+	if !s.state.shouldPersist() {
+		return nil
+	}
+
+	s.persistTotal.Inc()
+
+	fs, err := s.state.getFullState()
+	if err != nil {
+		s.persistFailed.Inc()
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := s.store.SetFullState(ctx, s.userID, alerts.FullStateDesc{State: fs}); err != nil {
+		s.persistFailed.Inc()
+		return err
+	}
+
+	level.Debug(s.logger).Log("msg", "state persisted", "user", s.userID)
+	return nil
+}
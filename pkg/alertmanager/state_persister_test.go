@@ -0,0 +1,95 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+type fakePersistableState struct {
+	fullState    *clusterpb.FullState
+	fullStateErr error
+	persist      atomic.Bool
+}
+
+func (s *fakePersistableState) getFullState() (*clusterpb.FullState, error) {
+	return s.fullState, s.fullStateErr
+}
+
+func (s *fakePersistableState) shouldPersist() bool {
+	return s.persist.Load()
+}
+
+func TestStatePersister(t *testing.T) {
+	fullState := &clusterpb.FullState{Parts: []clusterpb.Part{{Key: silencesStateKey, Data: []byte("silences")}}}
+
+	tests := map[string]struct {
+		shouldPersist   bool
+		fullStateErr    error
+		expectedStored  bool
+		expectedFailure bool
+	}{
+		"should persist the state": {
+			shouldPersist:  true,
+			expectedStored: true,
+		},
+		"should not persist the state if another replica is in charge of it": {
+			shouldPersist: false,
+		},
+		"should count a failure if the state can't be read": {
+			shouldPersist:   true,
+			fullStateErr:    errors.New("failed to encode"),
+			expectedFailure: true,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			store := &mockAlertStore{}
+			state := &fakePersistableState{fullState: fullState, fullStateErr: testData.fullStateErr}
+			state.persist.Store(testData.shouldPersist)
+
+			p := newStatePersister(PersisterConfig{Interval: 10 * time.Millisecond}, "user-1", state, store, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+			require.NoError(t, services.StartAndAwaitRunning(context.Background(), p))
+			defer services.StopAndAwaitTerminated(context.Background(), p) //nolint:errcheck
+
+			switch {
+			case testData.expectedStored:
+				test.Poll(t, time.Second, nil, func() interface{} {
+					_, err := store.GetFullState(context.Background(), "user-1")
+					return err
+				})
+			case testData.expectedFailure:
+				test.Poll(t, time.Second, true, func() interface{} {
+					return testutil.ToFloat64(p.persistFailed) > 0
+				})
+			default:
+				time.Sleep(100 * time.Millisecond)
+				assert.Equal(t, float64(0), testutil.ToFloat64(p.persistTotal))
+			}
+
+			stored, err := store.GetFullState(context.Background(), "user-1")
+			if testData.expectedStored {
+				require.NoError(t, err)
+				assert.Equal(t, fullState, stored.State)
+			} else {
+				assert.Equal(t, alerts.ErrFullStateNotFound, err)
+			}
+
+			assert.Equal(t, testData.expectedFailure, testutil.ToFloat64(p.persistFailed) > 0)
+		})
+	}
+}
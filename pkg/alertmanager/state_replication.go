@@ -0,0 +1,299 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
+)
+
+const (
+	defaultSettleReadTimeout = 15 * time.Second
+
+	// Keys of the states replicated across the alertmanager replicas of a tenant.
+	nflogStateKey    = "nfl"
+	silencesStateKey = "sil"
+
+	// Size of the queue of the partial state updates to replicate.
+	replicationQueueSize = 1024
+
+	// Outcomes of the initial state sync.
+	syncFromReplica  = "from-replica"
+	syncFromStorage  = "from-storage"
+	syncUserNotFound = "user-not-found"
+	syncFailed       = "failed"
+)
+
+// Replicator is used to exchange the state of a tenant (silences and notification log)
+// with the other alertmanager replicas owning the tenant in the ring.
+type Replicator interface {
+	// ReplicateStateForUser writes the given partial state to the other replicas of the tenant.
+	ReplicateStateForUser(ctx context.Context, userID string, part *clusterpb.Part) error
+	// ReadFullStateForUser reads the full state of the tenant from the other replicas.
+	ReadFullStateForUser(ctx context.Context, userID string) ([]*clusterpb.FullState, error)
+	// GetPositionForUser returns the position of this instance within the replicas of the tenant.
+	GetPositionForUser(userID string) int
+}
+
+// state holds the per-tenant Alertmanager states (silences and notification log) and replicates
+// them through the Replicator, in place of the gossip-based cluster.Peer.
+type state struct {
+	services.Service
+
+	userID            string
+	logger            log.Logger
+	replicator        Replicator
+	store             AlertStore
+	settleReadTimeout time.Duration
+
+	mtx    sync.Mutex
+	states map[string]cluster.State
+
+	msgc chan *clusterpb.Part
+
+	partialStateMergesTotal  *prometheus.CounterVec
+	partialStateMergesFailed *prometheus.CounterVec
+	stateReplicationTotal    *prometheus.CounterVec
+	stateReplicationFailed   *prometheus.CounterVec
+	initialSyncCompleted     *prometheus.CounterVec
+	initialSyncDuration      prometheus.Histogram
+}
+
+// newReplicatedStates creates a new state struct, which manages the state of a tenant
+// and replicates it to the other replicas of the tenant. The store is used to restore the
+// state when it can't be read from the other replicas, and can be nil.
+func newReplicatedStates(userID string, replicator Replicator, store AlertStore, logger log.Logger, reg prometheus.Registerer) *state {
+	s := &state{
+		userID:            userID,
+		logger:            logger,
+		replicator:        replicator,
+		store:             store,
+		settleReadTimeout: defaultSettleReadTimeout,
+		states:            make(map[string]cluster.State, 2),
+		msgc:              make(chan *clusterpb.Part, replicationQueueSize),
+		partialStateMergesTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_partial_state_merges_total",
+			Help: "Number of times we have received a partial state to merge for a key.",
+		}, []string{"key"}),
+		partialStateMergesFailed: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_partial_state_merges_failed_total",
+			Help: "Number of times we have failed to merge a partial state received for a key.",
+		}, []string{"key"}),
+		stateReplicationTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_state_replication_total",
+			Help: "Number of times we have tried to replicate a state to other alertmanagers.",
+		}, []string{"key"}),
+		stateReplicationFailed: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_state_replication_failed_total",
+			Help: "Number of times we have failed to replicate a state to other alertmanagers.",
+		}, []string{"key"}),
+		initialSyncCompleted: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_state_initial_sync_completed_total",
+			Help: "Number of times we have completed syncing initial state for each possible outcome.",
+		}, []string{"outcome"}),
+		initialSyncDuration: promauto.With(reg).NewHistogram(prometheus.HistogramOpts{
+			Name:    "alertmanager_state_initial_sync_duration_seconds",
+			Help:    "Time spent syncing initial state from the other replicas.",
+			Buckets: prometheus.ExponentialBuckets(0.008, 4, 7),
+		}),
+	}
+
+	for _, outcome := range []string{syncFromReplica, syncFromStorage, syncUserNotFound, syncFailed} {
+		s.initialSyncCompleted.WithLabelValues(outcome)
+	}
+
+	s.Service = services.NewBasicService(s.starting, s.running, nil)
+	return s
+}
+
+// AddState adds a new state that will be replicated, returning the function to call to broadcast
+// the partial state updates. It must be called before the service is started.
+func (s *state) AddState(key string, cs cluster.State) func([]byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.states[key] = cs
+
+	s.partialStateMergesTotal.WithLabelValues(key)
+	s.partialStateMergesFailed.WithLabelValues(key)
+	s.stateReplicationTotal.WithLabelValues(key)
+	s.stateReplicationFailed.WithLabelValues(key)
+
+	return func(b []byte) {
+		s.broadcast(key, b)
+	}
+}
+
+// MergePartialState merges a partial state received from another replica.
+func (s *state) MergePartialState(p *clusterpb.Part) error {
+	s.partialStateMergesTotal.WithLabelValues(p.Key).Inc()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	st, ok := s.states[p.Key]
+	if !ok {
+		s.partialStateMergesFailed.WithLabelValues(p.Key).Inc()
+		return fmt.Errorf("key not found while merging: %s", p.Key)
+	}
+
+	if err := st.Merge(p.Data); err != nil {
+		s.partialStateMergesFailed.WithLabelValues(p.Key).Inc()
+		return err
+	}
+
+	return nil
+}
+
+// GetFullState returns the full state of the tenant, made of all the replicated states.
+func (s *state) GetFullState() (*clusterpb.FullState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	all := &clusterpb.FullState{
+		Parts: make([]clusterpb.Part, 0, len(s.states)),
+	}
+
+	for key, st := range s.states {
+		b, err := st.MarshalBinary()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode state for key: %v", key)
+		}
+		all.Parts = append(all.Parts, clusterpb.Part{Key: key, Data: b})
+	}
+
+	return all, nil
+}
+
+// Position returns the position of this instance within the replicas of the tenant.
+func (s *state) Position() int {
+	return s.replicator.GetPositionForUser(s.userID)
+}
+
+// WaitReady waits until the initial state has been synced from the other replicas.
+func (s *state) WaitReady(ctx context.Context) error {
+	return s.Service.AwaitRunning(ctx)
+}
+
+// starting syncs the initial state from the other replicas of the tenant, falling back to the
+// state persisted in the store. Failing to read the state doesn't prevent the tenant Alertmanager
+// from running.
+func (s *state) starting(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		s.initialSyncDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	level.Info(s.logger).Log("msg", "synchronizing alertmanager state with replicas")
+
+	readCtx, cancel := context.WithTimeout(ctx, s.settleReadTimeout)
+	defer cancel()
+
+	fullStates, err := s.replicator.ReadFullStateForUser(readCtx, s.userID)
+	if err == nil && len(fullStates) > 0 {
+		for _, fs := range fullStates {
+			s.mergeFullState(fs)
+		}
+
+		level.Info(s.logger).Log("msg", "alertmanager state synchronized with replicas")
+		s.initialSyncCompleted.WithLabelValues(syncFromReplica).Inc()
+		return nil
+	}
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to read the state from replicas, falling back to the store", "err", err)
+	}
+
+	// The state can't be read from the other replicas, so we try the one persisted in the store.
+	// The read from the replicas may have used up the whole timeout, so a new one is used.
+	var stored alerts.FullStateDesc
+	storeErr := alerts.ErrFullStateNotFound
+	if s.store != nil {
+		storeCtx, storeCancel := context.WithTimeout(ctx, restoreStateTimeout)
+		stored, storeErr = s.store.GetFullState(storeCtx, s.userID)
+		storeCancel()
+	}
+
+	switch {
+	case storeErr == nil:
+		if stored.State != nil {
+			s.mergeFullState(stored.State)
+		}
+		level.Info(s.logger).Log("msg", "alertmanager state restored from the store")
+		s.initialSyncCompleted.WithLabelValues(syncFromStorage).Inc()
+	case errors.Is(storeErr, alerts.ErrFullStateNotFound) && err == nil:
+		level.Info(s.logger).Log("msg", "no alertmanager state found for the user")
+		s.initialSyncCompleted.WithLabelValues(syncUserNotFound).Inc()
+	default:
+		if !errors.Is(storeErr, alerts.ErrFullStateNotFound) {
+			level.Warn(s.logger).Log("msg", "failed to read the state from the store, continuing with the local state", "err", storeErr)
+		}
+		s.initialSyncCompleted.WithLabelValues(syncFailed).Inc()
+	}
+
+	return nil
+}
+
+func (s *state) mergeFullState(fs *clusterpb.FullState) {
+	for i := range fs.Parts {
+		if err := s.MergePartialState(&fs.Parts[i]); err != nil {
+			level.Warn(s.logger).Log("msg", "failed to merge the state", "key", fs.Parts[i].Key, "err", err)
+		}
+	}
+}
+
+func (s *state) running(ctx context.Context) error {
+	for {
+		select {
+		case p := <-s.msgc:
+			s.stateReplicationTotal.WithLabelValues(p.Key).Inc()
+			if err := s.replicator.ReplicateStateForUser(ctx, s.userID, p); err != nil {
+				s.stateReplicationFailed.WithLabelValues(p.Key).Inc()
+				level.Error(s.logger).Log("msg", "failed to replicate state to other alertmanagers", "key", p.Key, "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// broadcast enqueues the partial state update to be replicated. Updates are not replicated
+// while the initial state is synced from the other replicas, and dropped if the queue is full.
+func (s *state) broadcast(key string, b []byte) {
+	if s.State() != services.Running {
+		return
+	}
+
+	select {
+	case s.msgc <- &clusterpb.Part{Key: key, Data: b}:
+	default:
+		s.stateReplicationFailed.WithLabelValues(key).Inc()
+		level.Warn(s.logger).Log("msg", "dropped state replication to other alertmanagers because the queue is full", "key", key)
+	}
+}
+
+// waitReadyStage is a notification pipeline stage waiting until the tenant state has been
+// synced from the other replicas, so that notifications already sent by other replicas are
+// not sent again. It replaces the gossip settle stage when the state is replicated via the ring.
+type waitReadyStage struct {
+	state *state
+}
+
+// Exec implements the notify.Stage interface.
+func (s waitReadyStage) Exec(ctx context.Context, _ log.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if err := s.state.WaitReady(ctx); err != nil {
+		return ctx, nil, err
+	}
+	return ctx, alerts, nil
+}
@@ -0,0 +1,190 @@
+package alertmanager
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/alertmanager/cluster/clusterpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/alertmanager/alerts"
+	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+type fakeState struct {
+	mtx    sync.Mutex
+	merges [][]byte
+}
+
+func (s *fakeState) MarshalBinary() ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	parts := make([]string, 0, len(s.merges))
+	for _, m := range s.merges {
+		parts = append(parts, string(m))
+	}
+	sort.Strings(parts)
+	return []byte(strings.Join(parts, ",")), nil
+}
+
+func (s *fakeState) Merge(b []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.merges = append(s.merges, b)
+	return nil
+}
+
+type fakeReplicator struct {
+	mtx        sync.Mutex
+	replicated []*clusterpb.Part
+
+	fullStates  []*clusterpb.FullState
+	readErr     error
+	readTimeout bool
+	position    int
+}
+
+func (r *fakeReplicator) ReplicateStateForUser(_ context.Context, _ string, p *clusterpb.Part) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.replicated = append(r.replicated, p)
+	return nil
+}
+
+func (r *fakeReplicator) ReadFullStateForUser(ctx context.Context, _ string) ([]*clusterpb.FullState, error) {
+	if r.readTimeout {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return r.fullStates, r.readErr
+}
+
+func (r *fakeReplicator) GetPositionForUser(string) int {
+	return r.position
+}
+
+func (r *fakeReplicator) getReplicated() []*clusterpb.Part {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]*clusterpb.Part(nil), r.replicated...)
+}
+
+func TestStateReplication(t *testing.T) {
+	tests := map[string]struct {
+		fullStates      []*clusterpb.FullState
+		readErr         error
+		readTimeout     bool
+		storedState     *clusterpb.FullState
+		expectedState   string
+		expectedOutcome string
+	}{
+		"no replicas": {
+			expectedState:   "",
+			expectedOutcome: syncUserNotFound,
+		},
+		"state read from replicas": {
+			fullStates: []*clusterpb.FullState{
+				{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("a")}}},
+				{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("b")}, {Key: "unknown", Data: []byte("c")}}},
+			},
+			expectedState:   "a,b",
+			expectedOutcome: syncFromReplica,
+		},
+		"failed to read the state from replicas": {
+			readErr:         errors.New("read failed"),
+			expectedState:   "",
+			expectedOutcome: syncFailed,
+		},
+		"state read from storage if there are no replicas": {
+			storedState:     &clusterpb.FullState{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("s")}}},
+			expectedState:   "s",
+			expectedOutcome: syncFromStorage,
+		},
+		"state read from storage if failed to read the state from replicas": {
+			readErr:         errors.New("read failed"),
+			storedState:     &clusterpb.FullState{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("s")}}},
+			expectedState:   "s",
+			expectedOutcome: syncFromStorage,
+		},
+		"state read from storage if the read from replicas timed out": {
+			readTimeout:     true,
+			storedState:     &clusterpb.FullState{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("s")}}},
+			expectedState:   "s",
+			expectedOutcome: syncFromStorage,
+		},
+		"state read from replicas is preferred over the storage": {
+			fullStates:      []*clusterpb.FullState{{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("a")}}}},
+			storedState:     &clusterpb.FullState{Parts: []clusterpb.Part{{Key: "nflog", Data: []byte("s")}}},
+			expectedState:   "a",
+			expectedOutcome: syncFromReplica,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			reg := prometheus.NewPedanticRegistry()
+			replicator := &fakeReplicator{fullStates: testData.fullStates, readErr: testData.readErr, readTimeout: testData.readTimeout, position: 1}
+
+			store := &mockAlertStore{}
+			if testData.storedState != nil {
+				require.NoError(t, store.SetFullState(context.Background(), "user-1", alerts.FullStateDesc{State: testData.storedState}))
+			}
+
+			s := newReplicatedStates("user-1", replicator, store, log.NewNopLogger(), reg)
+			s.settleReadTimeout = 100 * time.Millisecond
+			st := &fakeState{}
+			broadcast := s.AddState("nflog", st)
+
+			// Updates are not replicated until the initial state has been synced.
+			broadcast([]byte("not-replicated"))
+
+			require.NoError(t, services.StartAndAwaitRunning(context.Background(), s))
+			defer services.StopAndAwaitTerminated(context.Background(), s) //nolint:errcheck
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			require.NoError(t, s.WaitReady(ctx))
+
+			assert.Equal(t, 1, s.Position())
+			assert.Equal(t, float64(1), testutil.ToFloat64(s.initialSyncCompleted.WithLabelValues(testData.expectedOutcome)))
+
+			full, err := s.GetFullState()
+			require.NoError(t, err)
+			assert.Equal(t, []clusterpb.Part{{Key: "nflog", Data: []byte(testData.expectedState)}}, full.Parts)
+
+			broadcast([]byte("replicated"))
+			test.Poll(t, time.Second, 1, func() interface{} {
+				return len(replicator.getReplicated())
+			})
+			assert.Equal(t, &clusterpb.Part{Key: "nflog", Data: []byte("replicated")}, replicator.getReplicated()[0])
+			assert.Equal(t, float64(1), testutil.ToFloat64(s.stateReplicationTotal.WithLabelValues("nflog")))
+		})
+	}
+}
+
+func TestStateReplication_MergePartialState(t *testing.T) {
+	s := newReplicatedStates("user-1", &fakeReplicator{}, nil, log.NewNopLogger(), prometheus.NewPedanticRegistry())
+	st := &fakeState{}
+	s.AddState("sil", st)
+
+	require.NoError(t, s.MergePartialState(&clusterpb.Part{Key: "sil", Data: []byte("a")}))
+	require.EqualError(t, s.MergePartialState(&clusterpb.Part{Key: "unknown", Data: []byte("b")}), "key not found while merging: unknown")
+
+	assert.Equal(t, [][]byte{[]byte("a")}, st.merges)
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.partialStateMergesTotal.WithLabelValues("sil")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(s.partialStateMergesFailed.WithLabelValues("unknown")))
+}
@@ -24,6 +24,15 @@ type AlertStore interface {
 	GetAlertConfig(ctx context.Context, user string) (alerts.AlertConfigDesc, error)
 	SetAlertConfig(ctx context.Context, cfg alerts.AlertConfigDesc) error
 	DeleteAlertConfig(ctx context.Context, user string) error
+
+	// ListUsersWithFullState returns the list of users which have had state written.
+	ListUsersWithFullState(ctx context.Context) ([]string, error)
+	// GetFullState loads and returns the alertmanager state for the given user.
+	GetFullState(ctx context.Context, user string) (alerts.FullStateDesc, error)
+	// SetFullState stores the alertmanager state for the given user.
+	SetFullState(ctx context.Context, user string, fs alerts.FullStateDesc) error
+	// DeleteFullState deletes the alertmanager state for the given user.
+	DeleteFullState(ctx context.Context, user string) error
 }
 
 // AlertStoreConfig configures the alertmanager backend
@@ -42,6 +42,7 @@ type DistributorPushWrapper func(next push.Func) push.Func
 
 type Config struct {
 	ResponseCompression bool `yaml:"response_compression_enabled"`
+	RingAdminEnabled    bool `yaml:"ring_admin_enabled"`
 
 	AlertmanagerHTTPPrefix string `yaml:"alertmanager_http_prefix"`
 	PrometheusHTTPPrefix   string `yaml:"prometheus_http_prefix"`
@@ -59,6 +60,7 @@ type Config struct {
 // RegisterFlags adds the flags required to config this to the given FlagSet.
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.BoolVar(&cfg.ResponseCompression, "api.response-compression-enabled", false, "Use GZIP compression for API responses. Some endpoints serve large YAML or JSON blobs which can benefit from compression.")
+	f.BoolVar(&cfg.RingAdminEnabled, "api.ring-admin-enabled", false, "Enable the ring admin API, exposed both via HTTP and gRPC, to mark ring instances as read-only and request them to leave the ring. The API requires authentication.")
 	cfg.RegisterFlagsWithPrefix("", f)
 }
 
@@ -85,6 +87,7 @@ type API struct {
 	logger    log.Logger
 	sourceIPs *middleware.SourceIPExtractor
 	indexPage *IndexPageContent
+	ringAdmin *ring.AdminServer
 }
 
 func New(cfg Config, serverCfg server.Config, s *server.Server, logger log.Logger) (*API, error) {
@@ -167,6 +170,7 @@ func (a *API) RegisterAlertmanager(am *alertmanager.MultitenantAlertmanager, amC
 	// Ensure this route is registered before the prefixed AM route
 	a.RegisterRoute("/multitenant_alertmanager/status", am.GetStatusHandler(), false, "GET")
 	a.RegisterRoute("/multitenant_alertmanager/ring", http.HandlerFunc(am.RingHandler), false, "GET", "POST")
+	a.registerRingAdmin("alertmanager", am.Ring)
 
 	// UI components lead to a large number of routes to support, utilize a path prefix instead
 	a.RegisterRoutesWithPrefix(a.cfg.AlertmanagerHTTPPrefix, am, true)
@@ -210,6 +214,7 @@ func (a *API) RegisterDistributor(d *distributor.Distributor, pushConfig distrib
 	distributorpb.RegisterDistributorServer(a.server.GRPC, d)
 
 	a.RegisterRoute("/api/v1/push", push.Handler(pushConfig.MaxRecvMsgSize, a.sourceIPs, a.cfg.wrapDistributorPush(d)), true, "POST")
+	a.RegisterRoute("/otlp/v1/metrics", push.OTLPHandler(pushConfig.MaxRecvMsgSize, pushConfig.OTLP.PromoteResourceAttributes, a.sourceIPs, a.cfg.wrapDistributorPush(d)), true, "POST")
 
 	a.indexPage.AddLink(SectionAdminEndpoints, "/distributor/all_user_stats", "Usage Statistics")
 	a.indexPage.AddLink(SectionAdminEndpoints, "/distributor/ha_tracker", "HA Tracking Status")
@@ -252,6 +257,16 @@ func (a *API) RegisterIngester(i Ingester, pushConfig distributor.Config) {
 // match the Prometheus API but mirror it closely enough to justify their routing under the Prometheus
 // component/
 func (a *API) RegisterChunksPurger(store *purger.DeleteStore, deleteRequestCancelPeriod time.Duration) {
+	a.registerDeleteRequestsRoutes(store, deleteRequestCancelPeriod)
+}
+
+// RegisterBlocksPurger registers the delete series endpoints for the blocks storage. The endpoints are
+// the same exposed by the chunks storage purger.
+func (a *API) RegisterBlocksPurger(store *purger.BlocksDeleteStore, deleteRequestCancelPeriod time.Duration) {
+	a.registerDeleteRequestsRoutes(store, deleteRequestCancelPeriod)
+}
+
+func (a *API) registerDeleteRequestsRoutes(store purger.DeleteRequestsStore, deleteRequestCancelPeriod time.Duration) {
 	deleteRequestHandler := purger.NewDeleteRequestHandler(store, deleteRequestCancelPeriod, prometheus.DefaultRegisterer)
 
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/admin/tsdb/delete_series", http.HandlerFunc(deleteRequestHandler.AddDeleteRequestHandler), true, "PUT", "POST")
@@ -277,6 +292,8 @@ func (a *API) RegisterRuler(r *ruler.Ruler) {
 	// Legacy Ring Route
 	a.RegisterRoute("/ruler_ring", r, false, "GET", "POST")
 
+	a.registerRingAdmin("ruler", r.Ring)
+
 	ruler.RegisterRulerServer(a.server.GRPC, r)
 }
 
@@ -314,6 +331,8 @@ func (a *API) RegisterRing(r *ring.Ring) {
 
 	// Legacy Route
 	a.RegisterRoute("/ring", r, false, "GET", "POST")
+
+	a.registerRingAdmin("ingester", func() *ring.Ring { return r })
 }
 
 // RegisterStoreGateway registers the ring UI page associated with the store-gateway.
@@ -322,12 +341,33 @@ func (a *API) RegisterStoreGateway(s *storegateway.StoreGateway) {
 
 	a.indexPage.AddLink(SectionAdminEndpoints, "/store-gateway/ring", "Store Gateway Ring")
 	a.RegisterRoute("/store-gateway/ring", http.HandlerFunc(s.RingHandler), false, "GET", "POST")
+	a.registerRingAdmin("store-gateway", s.Ring)
 }
 
 // RegisterCompactor registers the ring UI page associated with the compactor.
 func (a *API) RegisterCompactor(c *compactor.Compactor) {
 	a.indexPage.AddLink(SectionAdminEndpoints, "/compactor/ring", "Compactor Ring Status")
 	a.RegisterRoute("/compactor/ring", http.HandlerFunc(c.RingHandler), false, "GET", "POST")
+	a.registerRingAdmin("compactor", c.Ring)
+}
+
+// registerRingAdmin makes the ring administrable through the ring admin API (both HTTP and
+// gRPC), if enabled, registering the API itself the first time it's called.
+func (a *API) registerRingAdmin(name string, getRing func() *ring.Ring) {
+	if !a.cfg.RingAdminEnabled {
+		return
+	}
+
+	if a.ringAdmin == nil {
+		a.ringAdmin = ring.NewAdminServer()
+		ring.RegisterRingAdminServer(a.server.GRPC, a.ringAdmin)
+
+		a.RegisterRoute("/ring/admin/{ring}/ownership", http.HandlerFunc(a.ringAdmin.GetTokensOwnershipHandler), true, "GET")
+		a.RegisterRoute("/ring/admin/{ring}/instances/{instance}/read-only", http.HandlerFunc(a.ringAdmin.SetInstanceReadOnlyHandler), true, "POST", "DELETE")
+		a.RegisterRoute("/ring/admin/{ring}/instances/{instance}/leave", http.HandlerFunc(a.ringAdmin.RequestInstanceLeaveHandler), true, "POST")
+	}
+
+	a.ringAdmin.RegisterRing(name, getRing)
 }
 
 // RegisterQueryable registers the the default routes associated with the querier
@@ -349,6 +389,9 @@ func (a *API) RegisterQueryAPI(handler http.Handler) {
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/read", handler, true, "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/query", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/query_range", handler, true, "GET", "POST")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/query_exemplars", handler, true, "GET", "POST")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/cardinality/label_names", handler, true, "GET")
+	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/cardinality/label_values", handler, true, "GET")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/labels", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/label/{name}/values", handler, true, "GET")
 	a.RegisterRoute(a.cfg.PrometheusHTTPPrefix+"/api/v1/series", handler, true, "GET", "POST", "DELETE")
@@ -358,6 +401,9 @@ func (a *API) RegisterQueryAPI(handler http.Handler) {
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/read", handler, true, "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/query", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/query_range", handler, true, "GET", "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/query_exemplars", handler, true, "GET", "POST")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/cardinality/label_names", handler, true, "GET")
+	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/cardinality/label_values", handler, true, "GET")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/labels", handler, true, "GET", "POST")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/label/{name}/values", handler, true, "GET")
 	a.RegisterRoute(a.cfg.LegacyHTTPPrefix+"/api/v1/series", handler, true, "GET", "POST", "DELETE")
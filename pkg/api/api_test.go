@@ -1,11 +1,18 @@
 package api
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/server"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+
+	"github.com/cortexproject/cortex/pkg/ring"
 )
 
 type FakeLogger struct{}
@@ -59,3 +66,39 @@ func TestNewApiWithInvalidSourceIPExtractor(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, api)
 }
+
+func TestApiRingAdmin(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		cfg := Config{RingAdminEnabled: enabled}
+		serverCfg := server.Config{
+			MetricsNamespace: "ring_admin",
+		}
+		s := &server.Server{
+			HTTP: mux.NewRouter(),
+			GRPC: grpc.NewServer(),
+		}
+
+		api, err := New(cfg, serverCfg, s, &FakeLogger{})
+		require.NoError(t, err)
+		api.registerRingAdmin("ingester", func() *ring.Ring { return nil })
+
+		// The ring admin API is only registered if enabled, both via HTTP and gRPC.
+		var match mux.RouteMatch
+		assert.Equal(t, enabled, s.HTTP.Match(httptest.NewRequest("POST", "/ring/admin/ingester/instances/ing-1/leave", nil), &match))
+		_, registered := s.GRPC.GetServiceInfo()["ring.RingAdmin"]
+		assert.Equal(t, enabled, registered)
+
+		if enabled {
+			// The HTTP API requires authentication.
+			rec := httptest.NewRecorder()
+			s.HTTP.ServeHTTP(rec, httptest.NewRequest("GET", "/ring/admin/ingester/ownership", nil))
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+			rec = httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/ring/admin/ingester/ownership", nil)
+			req.Header.Set(user.OrgIDHeaderName, "admin")
+			s.HTTP.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		}
+	}
+}
@@ -222,7 +222,7 @@ func NewQuerierHandler(
 		InflightRequests: inflightRequests,
 	}
 	cacheGenHeaderMiddleware := getHTTPCacheGenNumberHeaderSetterMiddleware(tombstonesLoader)
-	middlewares := middleware.Merge(inst, cacheGenHeaderMiddleware)
+	middlewares := middleware.Merge(inst, cacheGenHeaderMiddleware, getHTTPChunkBytesBudgetMiddleware())
 	router.Use(middlewares.Wrap)
 
 	// Define the prefixes for all routes
@@ -239,10 +239,13 @@ func NewQuerierHandler(
 	// https://github.com/prometheus/prometheus/pull/7125/files
 	router.Path(prefix + "/api/v1/metadata").Handler(querier.MetadataHandler(distributor))
 	router.Path(prefix + "/api/v1/read").Handler(querier.RemoteReadHandler(queryable))
+	router.Path(prefix+"/api/v1/query_exemplars").Methods("GET", "POST").Handler(querier.ExemplarsHandler(distributor))
+	router.Path(prefix + "/api/v1/cardinality/label_names").Methods("GET").Handler(querier.LabelNamesCardinalityHandler(distributor))
+	router.Path(prefix + "/api/v1/cardinality/label_values").Methods("GET").Handler(querier.LabelValuesCardinalityHandler(distributor))
 	router.Path(prefix + "/api/v1/read").Methods("POST").Handler(promRouter)
 	router.Path(prefix+"/api/v1/query").Methods("GET", "POST").Handler(promRouter)
 	router.Path(prefix+"/api/v1/query_range").Methods("GET", "POST").Handler(promRouter)
-	router.Path(prefix+"/api/v1/labels").Methods("GET", "POST").Handler(promRouter)
+	router.Path(prefix+"/api/v1/labels").Methods("GET", "POST").Handler(querier.LabelNamesHandler(errorTranslateQueryable{queryable}, promRouter))
 	router.Path(prefix + "/api/v1/label/{name}/values").Methods("GET").Handler(promRouter)
 	router.Path(prefix+"/api/v1/series").Methods("GET", "POST", "DELETE").Handler(promRouter)
 	router.Path(prefix + "/api/v1/metadata").Methods("GET").Handler(promRouter)
@@ -251,10 +254,13 @@ func NewQuerierHandler(
 	// https://github.com/prometheus/prometheus/pull/7125/files
 	router.Path(legacyPrefix + "/api/v1/metadata").Handler(querier.MetadataHandler(distributor))
 	router.Path(legacyPrefix + "/api/v1/read").Handler(querier.RemoteReadHandler(queryable))
+	router.Path(legacyPrefix+"/api/v1/query_exemplars").Methods("GET", "POST").Handler(querier.ExemplarsHandler(distributor))
+	router.Path(legacyPrefix + "/api/v1/cardinality/label_names").Methods("GET").Handler(querier.LabelNamesCardinalityHandler(distributor))
+	router.Path(legacyPrefix + "/api/v1/cardinality/label_values").Methods("GET").Handler(querier.LabelValuesCardinalityHandler(distributor))
 	router.Path(legacyPrefix + "/api/v1/read").Methods("POST").Handler(legacyPromRouter)
 	router.Path(legacyPrefix+"/api/v1/query").Methods("GET", "POST").Handler(legacyPromRouter)
 	router.Path(legacyPrefix+"/api/v1/query_range").Methods("GET", "POST").Handler(legacyPromRouter)
-	router.Path(legacyPrefix+"/api/v1/labels").Methods("GET", "POST").Handler(legacyPromRouter)
+	router.Path(legacyPrefix+"/api/v1/labels").Methods("GET", "POST").Handler(querier.LabelNamesHandler(errorTranslateQueryable{queryable}, legacyPromRouter))
 	router.Path(legacyPrefix + "/api/v1/label/{name}/values").Methods("GET").Handler(legacyPromRouter)
 	router.Path(legacyPrefix+"/api/v1/series").Methods("GET", "POST", "DELETE").Handler(legacyPromRouter)
 	router.Path(legacyPrefix + "/api/v1/metadata").Methods("GET").Handler(legacyPromRouter)
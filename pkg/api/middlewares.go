@@ -2,12 +2,14 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/weaveworks/common/middleware"
 
 	"github.com/cortexproject/cortex/pkg/chunk/purger"
 	"github.com/cortexproject/cortex/pkg/querier/queryrange"
 	"github.com/cortexproject/cortex/pkg/tenant"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 )
 
 // middleware for setting cache gen header to let consumer of response know all previous responses could be invalid due to delete operation
@@ -27,3 +29,22 @@ func getHTTPCacheGenNumberHeaderSetterMiddleware(cacheGenNumbersLoader *purger.T
 		})
 	})
 }
+
+// middleware for lowering the chunk bytes limit of a query to the budget left to the query it belongs to, when split or sharded by the query-frontend
+func getHTTPChunkBytesBudgetMiddleware() middleware.Interface {
+	return middleware.Func(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if value := r.Header.Get(queryrange.ChunkBytesBudgetHeaderName); value != "" {
+				budget, err := strconv.Atoi(value)
+				if err != nil || budget <= 0 {
+					http.Error(w, "invalid "+queryrange.ChunkBytesBudgetHeaderName+" header", http.StatusBadRequest)
+					return
+				}
+
+				r = r.WithContext(limiter.AddChunkBytesBudgetToContext(r.Context(), budget))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	})
+}
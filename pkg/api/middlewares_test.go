@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cortexproject/cortex/pkg/querier/queryrange"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
+)
+
+func TestChunkBytesBudgetMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		header         string
+		expectedStatus int
+		expectedBudget int
+	}{
+		"no header": {
+			expectedStatus: http.StatusOK,
+		},
+		"valid budget": {
+			header:         "1024",
+			expectedStatus: http.StatusOK,
+			expectedBudget: 1024,
+		},
+		"invalid budget": {
+			header:         "foo",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"exhausted budget": {
+			header:         "0",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var budget int
+			handler := getHTTPChunkBytesBudgetMiddleware().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				budget, _ = limiter.ChunkBytesBudgetFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/api/v1/query_range", nil)
+			if testData.header != "" {
+				req.Header.Set(queryrange.ChunkBytesBudgetHeaderName, testData.header)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, testData.expectedStatus, recorder.Code)
+			assert.Equal(t, testData.expectedBudget, budget)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/prometheus/storage"
 
 	"github.com/cortexproject/cortex/pkg/chunk"
+	"github.com/cortexproject/cortex/pkg/querier"
 	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
@@ -64,6 +65,9 @@ type errorTranslateQueryable struct {
 
 func (e errorTranslateQueryable) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
 	q, err := e.q.Querier(ctx, mint, maxt)
+	if _, ok := q.(querier.LabelNamesWithMatchersQuerier); ok {
+		return errorTranslateLabelNamesQuerier{errorTranslateQuerier{q: q}}, translateError(err)
+	}
 	return errorTranslateQuerier{q: q}, translateError(err)
 }
 
@@ -95,6 +99,17 @@ func (e errorTranslateQuerier) Select(sortSeries bool, hints *storage.SelectHint
 	return errorTranslateSeriesSet{s}
 }
 
+// errorTranslateLabelNamesQuerier is an errorTranslateQuerier wrapping a querier
+// which implements querier.LabelNamesWithMatchersQuerier.
+type errorTranslateLabelNamesQuerier struct {
+	errorTranslateQuerier
+}
+
+func (e errorTranslateLabelNamesQuerier) LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	values, warnings, err := e.q.(querier.LabelNamesWithMatchersQuerier).LabelNamesWithMatchers(matchers...)
+	return values, warnings, translateError(err)
+}
+
 type errorTranslateChunkQuerier struct {
 	q storage.ChunkQuerier
 }
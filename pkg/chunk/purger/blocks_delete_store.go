@@ -0,0 +1,184 @@
+package purger
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/cortexproject/cortex/pkg/storage/bucket"
+	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
+)
+
+// BlocksDeleteStore manages the lifecycle of delete requests for the blocks storage.
+// Delete requests are stored in the bucket as per-tenant tombstones, which are applied
+// at query time by queriers and store-gateways and permanently applied to the blocks
+// by the compactor.
+type BlocksDeleteStore struct {
+	bucketClient objstore.Bucket
+	cfgProvider  bucket.TenantConfigProvider
+}
+
+// NewBlocksDeleteStore creates a BlocksDeleteStore backed by the blocks storage bucket.
+func NewBlocksDeleteStore(storageCfg cortex_tsdb.BlocksStorageConfig, cfgProvider bucket.TenantConfigProvider, logger log.Logger, reg prometheus.Registerer) (*BlocksDeleteStore, error) {
+	bucketClient, err := createBucketClient(storageCfg, logger, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlocksDeleteStore(bucketClient, cfgProvider), nil
+}
+
+func newBlocksDeleteStore(bkt objstore.Bucket, cfgProvider bucket.TenantConfigProvider) *BlocksDeleteStore {
+	return &BlocksDeleteStore{
+		bucketClient: bkt,
+		cfgProvider:  cfgProvider,
+	}
+}
+
+// AddDeleteRequest creates a new pending tombstone.
+func (ds *BlocksDeleteStore) AddDeleteRequest(ctx context.Context, userID string, startTime, endTime model.Time, selectors []string) error {
+	return ds.addDeleteRequest(ctx, userID, model.Now(), startTime, endTime, selectors)
+}
+
+// addDeleteRequest is also used for tests to create delete requests with different createdAt time.
+func (ds *BlocksDeleteStore) addDeleteRequest(ctx context.Context, userID string, createdAt, startTime, endTime model.Time, selectors []string) error {
+	requestID := generateUniqueID(userID, selectors)
+
+	for {
+		existing, err := cortex_tsdb.ReadTombstone(ctx, ds.bucketClient, userID, string(requestID))
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			break
+		}
+
+		// we have a collision here, lets recreate a new requestID and check for collision
+		time.Sleep(time.Millisecond)
+		requestID = generateUniqueID(userID, selectors)
+	}
+
+	tombstone := cortex_tsdb.NewTombstone(string(requestID), int64(createdAt), int64(createdAt), int64(startTime), int64(endTime), selectors, cortex_tsdb.TombstonePending)
+	return cortex_tsdb.WriteTombstone(ctx, ds.bucketClient, userID, ds.cfgProvider, tombstone)
+}
+
+// GetAllDeleteRequestsForUser returns all delete requests for a user, except the cancelled ones.
+func (ds *BlocksDeleteStore) GetAllDeleteRequestsForUser(ctx context.Context, userID string) ([]DeleteRequest, error) {
+	return ds.getDeleteRequests(ctx, userID, cortex_tsdb.TombstonePending, cortex_tsdb.TombstoneProcessed)
+}
+
+// GetPendingDeleteRequestsForUser returns the delete requests which should be applied at query time.
+// Processed requests are returned too, because blocks rewritten by the compactor co-exist with the
+// original ones until the latter are deleted.
+func (ds *BlocksDeleteStore) GetPendingDeleteRequestsForUser(ctx context.Context, userID string) ([]DeleteRequest, error) {
+	return ds.getDeleteRequests(ctx, userID, cortex_tsdb.TombstonePending, cortex_tsdb.TombstoneProcessed)
+}
+
+// GetDeleteRequest returns delete request with given requestID.
+func (ds *BlocksDeleteStore) GetDeleteRequest(ctx context.Context, userID, requestID string) (*DeleteRequest, error) {
+	tombstone, err := cortex_tsdb.ReadTombstone(ctx, ds.bucketClient, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tombstone == nil || tombstone.State == cortex_tsdb.TombstoneCancelled {
+		return nil, nil
+	}
+
+	deleteRequest := tombstoneToDeleteRequest(userID, tombstone)
+	return &deleteRequest, nil
+}
+
+// RemoveDeleteRequest cancels a delete request. The tombstone is kept in the cancelled
+// state, so that the change is detected by the tombstones loaders.
+func (ds *BlocksDeleteStore) RemoveDeleteRequest(ctx context.Context, userID, requestID string, _, _, _ model.Time) error {
+	tombstone, err := cortex_tsdb.ReadTombstone(ctx, ds.bucketClient, userID, requestID)
+	if err != nil {
+		return err
+	}
+
+	if tombstone == nil {
+		return ErrDeleteRequestNotFound
+	}
+
+	tombstone.State = cortex_tsdb.TombstoneCancelled
+	tombstone.StateCreatedAt = int64(model.Now())
+
+	return cortex_tsdb.WriteTombstone(ctx, ds.bucketClient, userID, ds.cfgProvider, tombstone)
+}
+
+// getCacheGenerationNumbers returns the cache gen numbers for a user. Both store and results
+// cache gen numbers are the timestamp of the most recent tombstone state change. Tombstones
+// deleted from the bucket are taken into account too, so that the gen numbers never go backwards.
+func (ds *BlocksDeleteStore) getCacheGenerationNumbers(ctx context.Context, userID string) (*cacheGenNumbers, error) {
+	tombstones, err := cortex_tsdb.ListTombstones(ctx, ds.bucketClient, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tombstones")
+	}
+
+	latest, err := cortex_tsdb.ReadTombstonesCacheGeneration(ctx, ds.bucketClient, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tombstone := range tombstones {
+		if tombstone.StateCreatedAt > latest {
+			latest = tombstone.StateCreatedAt
+		}
+	}
+
+	if latest == 0 {
+		return &cacheGenNumbers{}, nil
+	}
+
+	genNumber := strconv.FormatInt(latest, 10)
+	return &cacheGenNumbers{store: genNumber, results: genNumber}, nil
+}
+
+func (ds *BlocksDeleteStore) getDeleteRequests(ctx context.Context, userID string, states ...cortex_tsdb.TombstoneState) ([]DeleteRequest, error) {
+	tombstones, err := cortex_tsdb.ListTombstones(ctx, ds.bucketClient, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tombstones")
+	}
+
+	deleteRequests := []DeleteRequest{}
+	for _, tombstone := range tombstones {
+		for _, state := range states {
+			if tombstone.State == state {
+				deleteRequests = append(deleteRequests, tombstoneToDeleteRequest(userID, tombstone))
+				break
+			}
+		}
+	}
+
+	sort.Slice(deleteRequests, func(i, j int) bool {
+		return deleteRequests[i].CreatedAt < deleteRequests[j].CreatedAt
+	})
+
+	return deleteRequests, nil
+}
+
+func tombstoneToDeleteRequest(userID string, tombstone *cortex_tsdb.Tombstone) DeleteRequest {
+	status := StatusReceived
+	if tombstone.State == cortex_tsdb.TombstoneProcessed {
+		status = StatusProcessed
+	}
+
+	return DeleteRequest{
+		RequestID: tombstone.RequestID,
+		UserID:    userID,
+		StartTime: model.Time(tombstone.StartTime),
+		EndTime:   model.Time(tombstone.EndTime),
+		Selectors: tombstone.Selectors,
+		Status:    status,
+		Matchers:  tombstone.Matchers,
+		CreatedAt: model.Time(tombstone.RequestCreatedAt),
+	}
+}
@@ -0,0 +1,143 @@
+package purger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+	"github.com/weaveworks/common/user"
+
+	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
+)
+
+func TestBlocksDeleteStore_DeleteRequestsLifecycle(t *testing.T) {
+	const userID = "user"
+
+	ctx := context.Background()
+	store := newBlocksDeleteStore(objstore.NewInMemBucket(), nil)
+
+	// No tombstones, no cache gen numbers.
+	genNumbers, err := store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, cacheGenNumbers{}, *genNumbers)
+
+	now := model.Now()
+	require.NoError(t, store.addDeleteRequest(ctx, userID, now.Add(-time.Hour), 0, 100, []string{`{job="a"}`}))
+	require.NoError(t, store.addDeleteRequest(ctx, userID, now, 50, 150, []string{`{job="b"}`}))
+
+	requests, err := store.GetAllDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, []string{`{job="a"}`}, requests[0].Selectors)
+	assert.Equal(t, StatusReceived, requests[0].Status)
+	assert.Equal(t, model.Time(0), requests[0].StartTime)
+	assert.Equal(t, model.Time(100), requests[0].EndTime)
+	assert.Equal(t, []string{`{job="b"}`}, requests[1].Selectors)
+
+	genNumbers, err = store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, genNumbers.store)
+	assert.Equal(t, genNumbers.store, genNumbers.results)
+
+	// Processed requests should still be returned, because they have to be applied at query time
+	// until the original blocks are deleted.
+	tombstone, err := cortex_tsdb.ReadTombstone(ctx, store.bucketClient, userID, requests[0].RequestID)
+	require.NoError(t, err)
+	tombstone.State = cortex_tsdb.TombstoneProcessed
+	require.NoError(t, cortex_tsdb.WriteTombstone(ctx, store.bucketClient, userID, nil, tombstone))
+
+	pending, err := store.GetPendingDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, StatusProcessed, pending[0].Status)
+
+	// Cancelled requests should not be returned anymore.
+	require.NoError(t, store.RemoveDeleteRequest(ctx, userID, requests[1].RequestID, 0, 0, 0))
+
+	req, err := store.GetDeleteRequest(ctx, userID, requests[1].RequestID)
+	require.NoError(t, err)
+	assert.Nil(t, req)
+
+	pending, err = store.GetPendingDeleteRequestsForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, requests[0].RequestID, pending[0].RequestID)
+
+	assert.Equal(t, ErrDeleteRequestNotFound, store.RemoveDeleteRequest(ctx, userID, "unknown", 0, 0, 0))
+
+	// The cache gen numbers should never go backwards, even once the tombstones have been deleted.
+	genNumbers, err = store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+
+	tombstones, err := cortex_tsdb.ListTombstones(ctx, store.bucketClient, userID)
+	require.NoError(t, err)
+	require.Len(t, tombstones, 2)
+
+	var latest int64
+	for _, tombstone := range tombstones {
+		if tombstone.StateCreatedAt > latest {
+			latest = tombstone.StateCreatedAt
+		}
+	}
+	require.NoError(t, cortex_tsdb.WriteTombstonesCacheGeneration(ctx, store.bucketClient, userID, nil, latest))
+
+	for _, tombstone := range tombstones {
+		require.NoError(t, cortex_tsdb.DeleteTombstone(ctx, store.bucketClient, userID, nil, tombstone.RequestID))
+	}
+
+	tombstones, err = cortex_tsdb.ListTombstones(ctx, store.bucketClient, userID)
+	require.NoError(t, err)
+	assert.Empty(t, tombstones)
+
+	afterDeletion, err := store.getCacheGenerationNumbers(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, genNumbers, afterDeletion)
+}
+
+func TestBlocksDeleteStore_DeleteRequestHandler(t *testing.T) {
+	const userID = "user"
+
+	store := newBlocksDeleteStore(objstore.NewInMemBucket(), nil)
+	handler := NewDeleteRequestHandler(store, time.Hour, nil)
+	ctx := user.InjectOrgID(context.Background(), userID)
+
+	// Add a delete request.
+	req := httptest.NewRequest(http.MethodPost, `/api/v1/admin/tsdb/delete_series?match[]={job="a"}&start=0&end=100`, nil)
+	resp := httptest.NewRecorder()
+	handler.AddDeleteRequestHandler(resp, req.WithContext(ctx))
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	// Get the status of the delete requests.
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/tsdb/delete_series", nil)
+	resp = httptest.NewRecorder()
+	handler.GetAllDeleteRequestsHandler(resp, req.WithContext(ctx))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var requests []DeleteRequest
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &requests))
+	require.Len(t, requests, 1)
+	assert.Equal(t, StatusReceived, requests[0].Status)
+	assert.Equal(t, model.Time(100000), requests[0].EndTime)
+
+	// Cancel the delete request.
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/tsdb/cancel_delete_request?request_id="+requests[0].RequestID, nil)
+	resp = httptest.NewRecorder()
+	handler.CancelDeleteRequestHandler(resp, req.WithContext(ctx))
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	// Cancelling it again should fail.
+	resp = httptest.NewRecorder()
+	handler.CancelDeleteRequestHandler(resp, req.WithContext(ctx))
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	tombstone, err := cortex_tsdb.ReadTombstone(context.Background(), store.bucketClient, userID, requests[0].RequestID)
+	require.NoError(t, err)
+	assert.Equal(t, cortex_tsdb.TombstoneCancelled, tombstone.State)
+}
@@ -1,6 +1,7 @@
 package purger
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -34,15 +35,23 @@ func newDeleteRequestHandlerMetrics(r prometheus.Registerer) *deleteRequestHandl
 	return &m
 }
 
+// DeleteRequestsStore is the store of delete requests used by the DeleteRequestHandler.
+type DeleteRequestsStore interface {
+	AddDeleteRequest(ctx context.Context, userID string, startTime, endTime model.Time, selectors []string) error
+	GetAllDeleteRequestsForUser(ctx context.Context, userID string) ([]DeleteRequest, error)
+	GetDeleteRequest(ctx context.Context, userID, requestID string) (*DeleteRequest, error)
+	RemoveDeleteRequest(ctx context.Context, userID, requestID string, createdAt, startTime, endTime model.Time) error
+}
+
 // DeleteRequestHandler provides handlers for delete requests
 type DeleteRequestHandler struct {
-	deleteStore               *DeleteStore
+	deleteStore               DeleteRequestsStore
 	metrics                   *deleteRequestHandlerMetrics
 	deleteRequestCancelPeriod time.Duration
 }
 
 // NewDeleteRequestHandler creates a DeleteRequestHandler
-func NewDeleteRequestHandler(deleteStore *DeleteStore, deleteRequestCancelPeriod time.Duration, registerer prometheus.Registerer) *DeleteRequestHandler {
+func NewDeleteRequestHandler(deleteStore DeleteRequestsStore, deleteRequestCancelPeriod time.Duration, registerer prometheus.Registerer) *DeleteRequestHandler {
 	deleteMgr := DeleteRequestHandler{
 		deleteStore:               deleteStore,
 		deleteRequestCancelPeriod: deleteRequestCancelPeriod,
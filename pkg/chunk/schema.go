@@ -13,8 +13,8 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/pkg/labels"
 
-	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 const (
@@ -50,7 +50,7 @@ type BaseSchema interface {
 	GetReadQueriesForMetric(from, through model.Time, userID string, metricName string) ([]IndexQuery, error)
 	GetReadQueriesForMetricLabel(from, through model.Time, userID string, metricName string, labelName string) ([]IndexQuery, error)
 	GetReadQueriesForMetricLabelValue(from, through model.Time, userID string, metricName string, labelName string, labelValue string) ([]IndexQuery, error)
-	FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery
+	FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery
 }
 
 // StoreSchema is a schema used by store
@@ -342,7 +342,7 @@ func (s seriesStoreSchema) GetLabelNamesForSeries(from, through model.Time, user
 	return result, nil
 }
 
-func (s baseSchema) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery {
+func (s baseSchema) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery {
 	return s.entries.FilterReadQueries(queries, shard)
 }
 
@@ -350,7 +350,7 @@ type baseEntries interface {
 	GetReadMetricQueries(bucket Bucket, metricName string) ([]IndexQuery, error)
 	GetReadMetricLabelQueries(bucket Bucket, metricName string, labelName string) ([]IndexQuery, error)
 	GetReadMetricLabelValueQueries(bucket Bucket, metricName string, labelName string, labelValue string) ([]IndexQuery, error)
-	FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery
+	FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery
 }
 
 // used by storeSchema
@@ -429,7 +429,7 @@ func (originalEntries) GetReadMetricLabelValueQueries(bucket Bucket, metricName
 	}, nil
 }
 
-func (originalEntries) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery {
+func (originalEntries) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery {
 	return queries
 }
 
@@ -530,7 +530,7 @@ func (labelNameInHashKeyEntries) GetReadMetricLabelValueQueries(bucket Bucket, m
 	}, nil
 }
 
-func (labelNameInHashKeyEntries) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery {
+func (labelNameInHashKeyEntries) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery {
 	return queries
 }
 
@@ -593,7 +593,7 @@ func (v5Entries) GetReadMetricLabelValueQueries(bucket Bucket, metricName string
 	}, nil
 }
 
-func (v5Entries) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery {
+func (v5Entries) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery {
 	return queries
 }
 
@@ -662,7 +662,7 @@ func (v6Entries) GetReadMetricLabelValueQueries(bucket Bucket, metricName string
 	}, nil
 }
 
-func (v6Entries) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery {
+func (v6Entries) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery {
 	return queries
 }
 
@@ -761,7 +761,7 @@ func (v9Entries) GetLabelNamesForSeries(_ Bucket, _ []byte) ([]IndexQuery, error
 	return nil, ErrNotSupported
 }
 
-func (v9Entries) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) []IndexQuery {
+func (v9Entries) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) []IndexQuery {
 	return queries
 }
 
@@ -873,7 +873,7 @@ func (v10Entries) GetLabelNamesForSeries(_ Bucket, _ []byte) ([]IndexQuery, erro
 }
 
 // FilterReadQueries will return only queries that match a certain shard
-func (v10Entries) FilterReadQueries(queries []IndexQuery, shard *astmapper.ShardAnnotation) (matches []IndexQuery) {
+func (v10Entries) FilterReadQueries(queries []IndexQuery, shard *sharding.ShardAnnotation) (matches []IndexQuery) {
 	if shard == nil {
 		return queries
 	}
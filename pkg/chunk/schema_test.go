@@ -15,7 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/test"
 
-	"github.com/cortexproject/cortex/pkg/querier/astmapper"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 type ByHashRangeKey []IndexEntry
@@ -419,7 +419,7 @@ func TestV10IndexQueries(t *testing.T) {
 	var testExprs = []struct {
 		name     string
 		queries  []IndexQuery
-		shard    *astmapper.ShardAnnotation
+		shard    *sharding.ShardAnnotation
 		expected []IndexQuery
 	}{
 		{
@@ -431,7 +431,7 @@ func TestV10IndexQueries(t *testing.T) {
 		{
 			name:    "out of bounds shard returns 0 matches",
 			queries: fromShards(2),
-			shard: &astmapper.ShardAnnotation{
+			shard: &sharding.ShardAnnotation{
 				Shard: 3,
 			},
 			expected: nil,
@@ -439,7 +439,7 @@ func TestV10IndexQueries(t *testing.T) {
 		{
 			name:    "return correct shard",
 			queries: fromShards(3),
-			shard: &astmapper.ShardAnnotation{
+			shard: &sharding.ShardAnnotation{
 				Shard: 1,
 			},
 			expected: []IndexQuery{fromShards(2)[1]},
@@ -14,8 +14,8 @@ import (
 	"github.com/prometheus/prometheus/pkg/labels"
 
 	"github.com/cortexproject/cortex/pkg/chunk/cache"
-	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 	"github.com/cortexproject/cortex/pkg/util/spanlogger"
 )
 
@@ -128,7 +128,7 @@ func (c *seriesStore) Get(ctx context.Context, userID string, from, through mode
 	}
 
 	// inject artificial __cortex_shard__ labels if present in the query. GetChunkRefs guarantees any chunk refs match the shard.
-	shard, _, err := astmapper.ShardFromMatchers(allMatchers)
+	shard, _, err := sharding.ShardFromMatchers(allMatchers)
 	if err != nil {
 		return nil, err
 	}
@@ -264,7 +264,7 @@ func (c *seriesStore) lookupSeriesByMetricNameMatchers(ctx context.Context, from
 
 	// Check if one of the labels is a shard annotation, pass that information to lookupSeriesByMetricNameMatcher,
 	// and remove the label.
-	shard, shardLabelIndex, err := astmapper.ShardFromMatchers(matchers)
+	shard, shardLabelIndex, err := sharding.ShardFromMatchers(matchers)
 	if err != nil {
 		return nil, err
 	}
@@ -343,7 +343,7 @@ func (c *seriesStore) lookupSeriesByMetricNameMatchers(ctx context.Context, from
 	return ids, nil
 }
 
-func (c *seriesStore) lookupSeriesByMetricNameMatcher(ctx context.Context, from, through model.Time, userID, metricName string, matcher *labels.Matcher, shard *astmapper.ShardAnnotation) ([]string, error) {
+func (c *seriesStore) lookupSeriesByMetricNameMatcher(ctx context.Context, from, through model.Time, userID, metricName string, matcher *labels.Matcher, shard *sharding.ShardAnnotation) ([]string, error) {
 	return c.lookupIdsByMetricNameMatcher(ctx, from, through, userID, metricName, matcher, func(queries []IndexQuery) []IndexQuery {
 		return c.schema.FilterReadQueries(queries, shard)
 	})
@@ -524,7 +524,7 @@ func (c *seriesStore) calculateIndexEntries(ctx context.Context, from, through m
 	return result, missing, nil
 }
 
-func injectShardLabels(chunks []Chunk, shard astmapper.ShardAnnotation) {
+func injectShardLabels(chunks []Chunk, shard sharding.ShardAnnotation) {
 	for i, chunk := range chunks {
 		b := labels.NewBuilder(chunk.Metric)
 		l := shard.Label()
@@ -2,6 +2,7 @@ package compactor
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-kit/kit/log"
@@ -35,7 +36,7 @@ type BlocksCleaner struct {
 	services.Service
 
 	cfg          BlocksCleanerConfig
-	cfgProvider  bucket.TenantConfigProvider
+	cfgProvider  ConfigProvider
 	logger       log.Logger
 	bucketClient objstore.Bucket
 	usersScanner *cortex_tsdb.UsersScanner
@@ -50,13 +51,14 @@ type BlocksCleaner struct {
 	runsLastSuccess             prometheus.Gauge
 	blocksCleanedTotal          prometheus.Counter
 	blocksFailedTotal           prometheus.Counter
+	blocksMarkedForDeletion     prometheus.Counter
 	tenantBlocks                *prometheus.GaugeVec
 	tenantMarkedBlocks          *prometheus.GaugeVec
 	tenantPartialBlocks         *prometheus.GaugeVec
 	tenantBucketIndexLastUpdate *prometheus.GaugeVec
 }
 
-func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, usersScanner *cortex_tsdb.UsersScanner, cfgProvider bucket.TenantConfigProvider, logger log.Logger, reg prometheus.Registerer) *BlocksCleaner {
+func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, usersScanner *cortex_tsdb.UsersScanner, cfgProvider ConfigProvider, logger log.Logger, reg prometheus.Registerer) *BlocksCleaner {
 	c := &BlocksCleaner{
 		cfg:          cfg,
 		bucketClient: bucketClient,
@@ -87,6 +89,10 @@ func NewBlocksCleaner(cfg BlocksCleanerConfig, bucketClient objstore.Bucket, use
 			Name: "cortex_compactor_block_cleanup_failures_total",
 			Help: "Total number of blocks failed to be deleted.",
 		}),
+		blocksMarkedForDeletion: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_blocks_marked_for_deletion_by_retention_total",
+			Help: "Total number of blocks marked for deletion because exceeding the tenant's retention period.",
+		}),
 
 		// The following metrics don't have the "cortex_compactor" prefix because not strictly related to
 		// the compactor. They're just tracked by the compactor because it's the most logical place where these
@@ -310,6 +316,14 @@ func (c *BlocksCleaner) cleanUser(ctx context.Context, userID string, firstRun b
 		return err
 	}
 
+	// Mark blocks for deletion based on the tenant's retention period. This is done before
+	// updating the index, so that the new deletion marks are picked up by the updated index.
+	// The trade-off is that the retention isn't applied while the index has to be built from
+	// scratch, but it will be at the next run.
+	if idx != nil {
+		c.applyUserRetentionPeriod(ctx, idx, c.cfgProvider.CompactorBlocksRetentionPeriod(userID), userBucket, userLogger)
+	}
+
 	// Generate an updated in-memory version of the bucket index.
 	w := bucketindex.NewUpdater(c.bucketClient, userID, c.cfgProvider, c.logger)
 	idx, partials, err := w.UpdateIndex(ctx, idx)
@@ -356,6 +370,47 @@ func (c *BlocksCleaner) cleanUser(ctx context.Context, userID string, firstRun b
 	return nil
 }
 
+// applyUserRetentionPeriod marks for deletion the blocks whose max time is older than the
+// retention period. A retention period of 0 disables it. Failing to mark a block is not
+// critical, given the retention will be applied again at the next run.
+func (c *BlocksCleaner) applyUserRetentionPeriod(ctx context.Context, idx *bucketindex.Index, retention time.Duration, userBucket objstore.Bucket, userLogger log.Logger) {
+	if retention <= 0 {
+		return
+	}
+
+	level.Debug(userLogger).Log("msg", "applying retention", "retention", retention.String())
+	blocks := listBlocksOutsideRetentionPeriod(idx, time.Now().Add(-retention))
+
+	for _, b := range blocks {
+		level.Info(userLogger).Log("msg", "applied retention: marking block for deletion", "block", b.ID, "maxTime", b.MaxTime)
+		if err := block.MarkForDeletion(ctx, userLogger, userBucket, b.ID, fmt.Sprintf("block exceeding retention of %v", retention), c.blocksMarkedForDeletion); err != nil {
+			level.Warn(userLogger).Log("msg", "failed to mark block for deletion", "block", b.ID, "err", err)
+		}
+	}
+}
+
+// listBlocksOutsideRetentionPeriod returns the blocks whose max time is before the threshold
+// and which are not already marked for deletion.
+func listBlocksOutsideRetentionPeriod(idx *bucketindex.Index, threshold time.Time) (result bucketindex.Blocks) {
+	// Re-marking a block is not harmful, but it's wasteful and logs a warning.
+	marked := make(map[ulid.ULID]struct{}, len(idx.BlockDeletionMarks))
+	for _, d := range idx.BlockDeletionMarks {
+		marked[d.ID] = struct{}{}
+	}
+
+	for _, b := range idx.Blocks {
+		if _, isMarked := marked[b.ID]; isMarked {
+			continue
+		}
+
+		if util.TimeFromMillis(b.MaxTime).Before(threshold) {
+			result = append(result, b)
+		}
+	}
+
+	return
+}
+
 // cleanUserPartialBlocks delete partial blocks which are safe to be deleted. The provided partials map
 // is updated accordingly.
 func (c *BlocksCleaner) cleanUserPartialBlocks(ctx context.Context, partials map[ulid.ULID]error, idx *bucketindex.Index, userBucket *bucket.UserBucketClient, userLogger log.Logger) {
@@ -116,7 +116,7 @@ func testBlocksCleanerWithOptions(t *testing.T, options testBlocksCleanerOptions
 	logger := log.NewNopLogger()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, nil, logger, reg)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, reg)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, cleaner))
 	defer services.StopAndAwaitTerminated(ctx, cleaner) //nolint:errcheck
 
@@ -249,7 +249,7 @@ func TestBlocksCleaner_ShouldContinueOnBlockDeletionFailure(t *testing.T) {
 	logger := log.NewNopLogger()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, nil, logger, nil)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, nil)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, cleaner))
 	defer services.StopAndAwaitTerminated(ctx, cleaner) //nolint:errcheck
 
@@ -308,7 +308,7 @@ func TestBlocksCleaner_ShouldRebuildBucketIndexOnCorruptedOne(t *testing.T) {
 	logger := log.NewNopLogger()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, nil, logger, nil)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, nil)
 	require.NoError(t, services.StartAndAwaitRunning(ctx, cleaner))
 	defer services.StopAndAwaitTerminated(ctx, cleaner) //nolint:errcheck
 
@@ -358,7 +358,7 @@ func TestBlocksCleaner_ShouldRemoveMetricsForTenantsNotBelongingAnymoreToTheShar
 	reg := prometheus.NewPedanticRegistry()
 	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
 
-	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, nil, logger, reg)
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, newMockConfigProvider(), logger, reg)
 	require.NoError(t, cleaner.cleanUsers(ctx, true))
 
 	assert.NoError(t, prom_testutil.GatherAndCompare(reg, strings.NewReader(`
@@ -406,6 +406,64 @@ func TestBlocksCleaner_ShouldRemoveMetricsForTenantsNotBelongingAnymoreToTheShar
 	))
 }
 
+func TestBlocksCleaner_ShouldMarkBlocksOutsideRetentionPeriodForDeletion(t *testing.T) {
+	bucketClient, _ := cortex_testutil.PrepareFilesystemBucket(t)
+	bucketClient = bucketindex.BucketWithGlobalMarkers(bucketClient)
+
+	// Create blocks.
+	ctx := context.Background()
+	now := time.Now()
+	oldBlock1 := createTSDBBlock(t, bucketClient, "user-1", 10, 20, nil)
+	oldBlock2 := createTSDBBlock(t, bucketClient, "user-1", 20, 30, nil)
+	recentBlock := createTSDBBlock(t, bucketClient, "user-1", now.Add(-2*time.Hour).UnixNano()/int64(time.Millisecond), now.Add(-time.Hour).UnixNano()/int64(time.Millisecond), nil)
+	otherBlock := createTSDBBlock(t, bucketClient, "user-2", 10, 20, nil)
+
+	cfg := BlocksCleanerConfig{
+		DeletionDelay:      time.Hour,
+		CleanupInterval:    time.Minute,
+		CleanupConcurrency: 1,
+	}
+
+	logger := log.NewNopLogger()
+	reg := prometheus.NewPedanticRegistry()
+	scanner := tsdb.NewUsersScanner(bucketClient, tsdb.AllUsers, logger)
+	cfgProvider := newMockConfigProvider()
+
+	// The first run builds the bucket index, which is required to apply the retention.
+	cleaner := NewBlocksCleaner(cfg, bucketClient, scanner, cfgProvider, logger, reg)
+	require.NoError(t, cleaner.cleanUsers(ctx, true))
+
+	// Configure the retention only for user-1.
+	cfgProvider.userRetentionPeriods["user-1"] = 24 * time.Hour
+	require.NoError(t, cleaner.cleanUsers(ctx, false))
+
+	idx, err := bucketindex.ReadIndex(ctx, bucketClient, "user-1", nil, logger)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ulid.ULID{oldBlock1, oldBlock2, recentBlock}, idx.Blocks.GetULIDs())
+	assert.ElementsMatch(t, []ulid.ULID{oldBlock1, oldBlock2}, idx.BlockDeletionMarks.GetULIDs())
+
+	idx, err = bucketindex.ReadIndex(ctx, bucketClient, "user-2", nil, logger)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ulid.ULID{otherBlock}, idx.Blocks.GetULIDs())
+	assert.Empty(t, idx.BlockDeletionMarks)
+
+	// Blocks already marked for deletion should not be marked again.
+	require.NoError(t, cleaner.cleanUsers(ctx, false))
+
+	assert.NoError(t, prom_testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_bucket_blocks_marked_for_deletion_count Total number of blocks marked for deletion in the bucket.
+		# TYPE cortex_bucket_blocks_marked_for_deletion_count gauge
+		cortex_bucket_blocks_marked_for_deletion_count{user="user-1"} 2
+		cortex_bucket_blocks_marked_for_deletion_count{user="user-2"} 0
+		# HELP cortex_compactor_blocks_marked_for_deletion_by_retention_total Total number of blocks marked for deletion because exceeding the tenant's retention period.
+		# TYPE cortex_compactor_blocks_marked_for_deletion_by_retention_total counter
+		cortex_compactor_blocks_marked_for_deletion_by_retention_total 2
+	`),
+		"cortex_bucket_blocks_marked_for_deletion_count",
+		"cortex_compactor_blocks_marked_for_deletion_by_retention_total",
+	))
+}
+
 type mockBucketFailure struct {
 	objstore.Bucket
 
@@ -418,3 +476,32 @@ func (m *mockBucketFailure) Delete(ctx context.Context, name string) error {
 	}
 	return m.Bucket.Delete(ctx, name)
 }
+
+type mockConfigProvider struct {
+	userRetentionPeriods map[string]time.Duration
+}
+
+func newMockConfigProvider() *mockConfigProvider {
+	return &mockConfigProvider{
+		userRetentionPeriods: make(map[string]time.Duration),
+	}
+}
+
+func (m *mockConfigProvider) CompactorBlocksRetentionPeriod(user string) time.Duration {
+	if result, ok := m.userRetentionPeriods[user]; ok {
+		return result
+	}
+	return 0
+}
+
+func (m *mockConfigProvider) S3SSEType(user string) string {
+	return ""
+}
+
+func (m *mockConfigProvider) S3SSEKMSKeyID(userID string) string {
+	return ""
+}
+
+func (m *mockConfigProvider) S3SSEKMSEncryptionContext(userID string) string {
+	return ""
+}
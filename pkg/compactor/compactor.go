@@ -32,8 +32,9 @@ import (
 )
 
 var (
-	errInvalidBlockRanges = "compactor block range periods should be divisible by the previous one, but %s is not divisible by %s"
-	RingOp                = ring.NewOp([]ring.IngesterState{ring.ACTIVE}, nil)
+	errInvalidBlockRanges             = "compactor block range periods should be divisible by the previous one, but %s is not divisible by %s"
+	errInvalidTombstonesDeletionDelay = "compactor tombstones deletion delay (%s) should be greater than or equal to the blocks deletion delay (%s)"
+	RingOp                            = ring.NewOp([]ring.IngesterState{ring.ACTIVE}, nil)
 
 	DefaultBlocksGrouperFactory = func(ctx context.Context, cfg Config, bkt objstore.Bucket, logger log.Logger, reg prometheus.Registerer, blocksMarkedForDeletion prometheus.Counter, garbageCollectedBlocks prometheus.Counter) compact.Grouper {
 		return compact.NewDefaultGrouper(
@@ -91,6 +92,9 @@ type Config struct {
 	DeletionDelay         time.Duration            `yaml:"deletion_delay"`
 	TenantCleanupDelay    time.Duration            `yaml:"tenant_cleanup_delay"`
 
+	// Time after which processed tombstones are deleted from the bucket.
+	TombstonesDeletionDelay time.Duration `yaml:"tombstones_deletion_delay"`
+
 	// Whether the migration of block deletion marks to the global markers location is enabled.
 	BlockDeletionMarksMigrationEnabled bool `yaml:"block_deletion_marks_migration_enabled"`
 
@@ -101,6 +105,11 @@ type Config struct {
 	ShardingEnabled bool       `yaml:"sharding_enabled"`
 	ShardingRing    RingConfig `yaml:"sharding_ring"`
 
+	// Series deletion is enabled through the purger config. When enabled, the compactor
+	// permanently deletes the series matching the tenant's delete requests from the blocks.
+	SeriesDeletionEnabled     bool          `yaml:"-"`
+	DeleteRequestCancelPeriod time.Duration `yaml:"-"`
+
 	// No need to add options to customize the retry backoff,
 	// given the defaults should be fine, but allow to override
 	// it in tests.
@@ -135,6 +144,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 		"If not 0, blocks will be marked for deletion and compactor component will permanently delete blocks marked for deletion from the bucket. "+
 		"If 0, blocks will be deleted straight away. Note that deleting blocks immediately can cause query failures.")
 	f.DurationVar(&cfg.TenantCleanupDelay, "compactor.tenant-cleanup-delay", 6*time.Hour, "For tenants marked for deletion, this is time between deleting of last block, and doing final cleanup (marker files, debug files) of the tenant.")
+	f.DurationVar(&cfg.TombstonesDeletionDelay, "compactor.tombstones-deletion-delay", 24*time.Hour, "Time after which tombstones permanently applied to the blocks are deleted from the bucket. Processed tombstones are still applied at query time until the rewritten blocks replace the original ones, so this value must be greater than or equal to -compactor.deletion-delay.")
 	f.BoolVar(&cfg.BlockDeletionMarksMigrationEnabled, "compactor.block-deletion-marks-migration-enabled", true, "When enabled, at compactor startup the bucket will be scanned and all found deletion marks inside the block location will be copied to the markers global location too. This option can (and should) be safely disabled as soon as the compactor has successfully run at least once.")
 
 	f.Var(&cfg.EnabledTenants, "compactor.enabled-tenants", "Comma separated list of tenants that can be compacted. If specified, only these tenants will be compacted by compactor, otherwise all tenants can be compacted. Subject to sharding.")
@@ -149,16 +159,26 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.TombstonesDeletionDelay < cfg.DeletionDelay {
+		return errors.Errorf(errInvalidTombstonesDeletionDelay, cfg.TombstonesDeletionDelay.String(), cfg.DeletionDelay.String())
+	}
+
 	return nil
 }
 
+// ConfigProvider defines the per-tenant config provider for the Compactor.
+type ConfigProvider interface {
+	bucket.TenantConfigProvider
+	CompactorBlocksRetentionPeriod(user string) time.Duration
+}
+
 // Compactor is a multi-tenant TSDB blocks compactor based on Thanos.
 type Compactor struct {
 	services.Service
 
 	compactorCfg Config
 	storageCfg   cortex_tsdb.BlocksStorageConfig
-	cfgProvider  bucket.TenantConfigProvider
+	cfgProvider  ConfigProvider
 	logger       log.Logger
 	parentLogger log.Logger
 	registerer   prometheus.Registerer
@@ -205,13 +225,16 @@ type Compactor struct {
 	compactionRunFailedTenants     prometheus.Gauge
 	blocksMarkedForDeletion        prometheus.Counter
 	garbageCollectedBlocks         prometheus.Counter
+	blocksRewrittenByTombstones    prometheus.Counter
+	tombstonesProcessed            prometheus.Counter
+	tombstonesDeleted              prometheus.Counter
 
 	// TSDB syncer metrics
 	syncerMetrics *syncerMetrics
 }
 
 // NewCompactor makes a new Compactor.
-func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfig, cfgProvider bucket.TenantConfigProvider, logger log.Logger, registerer prometheus.Registerer) (*Compactor, error) {
+func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfig, cfgProvider ConfigProvider, logger log.Logger, registerer prometheus.Registerer) (*Compactor, error) {
 	bucketClientFactory := func(ctx context.Context) (objstore.Bucket, error) {
 		return bucket.NewClient(ctx, storageCfg.Bucket, "compactor", logger, registerer)
 	}
@@ -237,7 +260,7 @@ func NewCompactor(compactorCfg Config, storageCfg cortex_tsdb.BlocksStorageConfi
 func newCompactor(
 	compactorCfg Config,
 	storageCfg cortex_tsdb.BlocksStorageConfig,
-	cfgProvider bucket.TenantConfigProvider,
+	cfgProvider ConfigProvider,
 	logger log.Logger,
 	registerer prometheus.Registerer,
 	bucketClientFactory func(ctx context.Context) (objstore.Bucket, error),
@@ -296,6 +319,18 @@ func newCompactor(
 			Name: "cortex_compactor_garbage_collected_blocks_total",
 			Help: "Total number of blocks marked for deletion by compactor.",
 		}),
+		blocksRewrittenByTombstones: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_blocks_rewritten_by_tombstones_total",
+			Help: "Total number of blocks rewritten by compactor to permanently delete series matching tombstones.",
+		}),
+		tombstonesProcessed: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_tombstones_processed_total",
+			Help: "Total number of tombstones permanently applied to the blocks by compactor.",
+		}),
+		tombstonesDeleted: promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_compactor_tombstones_deleted_total",
+			Help: "Total number of processed tombstones deleted from the bucket by compactor.",
+		}),
 	}
 
 	if len(compactorCfg.EnabledTenants) > 0 {
@@ -587,6 +622,12 @@ func (c *Compactor) compactUser(ctx context.Context, userID string) error {
 		return err
 	}
 
+	if c.compactorCfg.SeriesDeletionEnabled {
+		if err := c.applyUserTombstones(ctx, userID, bucket, fetcher, ulogger); err != nil {
+			return errors.Wrap(err, "failed to apply tombstones")
+		}
+	}
+
 	syncer, err := compact.NewSyncer(
 		ulogger,
 		reg,
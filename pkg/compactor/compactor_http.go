@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kit/kit/log/level"
 
+	"github.com/cortexproject/cortex/pkg/ring"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/services"
 )
@@ -51,3 +52,13 @@ func (c *Compactor) RingHandler(w http.ResponseWriter, req *http.Request) {
 
 	c.ring.ServeHTTP(w, req)
 }
+
+// Ring returns the compactor ring, or nil if sharding is disabled or the compactor is not
+// running yet.
+func (c *Compactor) Ring() *ring.Ring {
+	if !c.compactorCfg.ShardingEnabled || c.State() != services.Running {
+		return nil
+	}
+
+	return c.ring
+}
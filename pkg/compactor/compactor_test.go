@@ -105,6 +105,13 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expected: errors.Errorf(errInvalidBlockRanges, 30*time.Hour, 24*time.Hour).Error(),
 		},
+		"should fail with tombstones deletion delay lower than blocks deletion delay": {
+			setup: func(cfg *Config) {
+				cfg.DeletionDelay = 48 * time.Hour
+				cfg.TombstonesDeletionDelay = 24 * time.Hour
+			},
+			expected: errors.Errorf(errInvalidTombstonesDeletionDelay, 24*time.Hour, 48*time.Hour).Error(),
+		},
 	}
 
 	for testName, testData := range tests {
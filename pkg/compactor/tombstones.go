@@ -0,0 +1,236 @@
+package compactor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/tsdb"
+	tsdb_tombstones "github.com/prometheus/prometheus/tsdb/tombstones"
+	"github.com/thanos-io/thanos/pkg/block"
+	"github.com/thanos-io/thanos/pkg/block/metadata"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+// applyUserTombstones permanently deletes the series matching the user's pending tombstones
+// from the blocks in the storage. Tombstones are applied only once they can't be cancelled
+// anymore. Each block overlapping a tombstone is rewritten and the original one is marked
+// for deletion. Finally, the applied tombstones are marked as processed. Processed tombstones
+// are deleted from the bucket once the original blocks have been deleted too.
+func (c *Compactor) applyUserTombstones(ctx context.Context, userID string, userBucket objstore.Bucket, fetcher block.MetadataFetcher, logger log.Logger) error {
+	all, err := cortex_tsdb.ListTombstones(ctx, c.bucketClient, userID)
+	if err != nil {
+		return errors.Wrap(err, "list tombstones")
+	}
+
+	now := time.Now()
+	cancellationDeadline := now.Add(-c.compactorCfg.DeleteRequestCancelPeriod)
+	deletionDeadline := now.Add(-c.compactorCfg.TombstonesDeletionDelay)
+
+	var tombstones, deletable []*cortex_tsdb.Tombstone
+	for _, t := range all {
+		switch {
+		case t.State == cortex_tsdb.TombstonePending && !util.TimeFromMillis(t.RequestCreatedAt).After(cancellationDeadline):
+			tombstones = append(tombstones, t)
+
+		case t.State == cortex_tsdb.TombstoneProcessed && util.TimeFromMillis(t.StateCreatedAt).Before(deletionDeadline):
+			deletable = append(deletable, t)
+		}
+	}
+
+	if err := c.deleteProcessedTombstones(ctx, userID, deletable, logger); err != nil {
+		return err
+	}
+
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	metas, _, err := fetcher.Fetch(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch blocks metadata")
+	}
+
+	for _, meta := range metas {
+		// The block max time is exclusive.
+		var overlapping []*cortex_tsdb.Tombstone
+		for _, t := range tombstones {
+			if t.Overlaps(meta.MinTime, meta.MaxTime-1) {
+				overlapping = append(overlapping, t)
+			}
+		}
+
+		if len(overlapping) == 0 {
+			continue
+		}
+
+		if err := c.rewriteBlockWithTombstones(ctx, userID, userBucket, meta.ULID, overlapping, logger); err != nil {
+			return errors.Wrapf(err, "rewrite block %s", meta.ULID.String())
+		}
+	}
+
+	for _, t := range tombstones {
+		t.State = cortex_tsdb.TombstoneProcessed
+		t.StateCreatedAt = util.TimeToMillis(time.Now())
+
+		if err := cortex_tsdb.WriteTombstone(ctx, c.bucketClient, userID, c.cfgProvider, t); err != nil {
+			return errors.Wrapf(err, "mark tombstone %s as processed", t.RequestID)
+		}
+
+		c.tombstonesProcessed.Inc()
+		level.Info(logger).Log("msg", "tombstone processed", "request_id", t.RequestID)
+	}
+
+	return nil
+}
+
+// deleteProcessedTombstones deletes the input tombstones from the bucket. The cache generation number
+// is derived from the tombstones state change timestamps, so before deleting them the most recent one
+// is stored in the bucket, otherwise the cache generation number could go backwards and results cached
+// before the tombstones were created would become valid again.
+func (c *Compactor) deleteProcessedTombstones(ctx context.Context, userID string, tombstones []*cortex_tsdb.Tombstone, logger log.Logger) error {
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	gen, err := cortex_tsdb.ReadTombstonesCacheGeneration(ctx, c.bucketClient, userID)
+	if err != nil {
+		return errors.Wrap(err, "read tombstones cache generation")
+	}
+
+	latest := gen
+	for _, t := range tombstones {
+		if t.StateCreatedAt > latest {
+			latest = t.StateCreatedAt
+		}
+	}
+
+	if latest > gen {
+		if err := cortex_tsdb.WriteTombstonesCacheGeneration(ctx, c.bucketClient, userID, c.cfgProvider, latest); err != nil {
+			return errors.Wrap(err, "write tombstones cache generation")
+		}
+	}
+
+	for _, t := range tombstones {
+		if err := cortex_tsdb.DeleteTombstone(ctx, c.bucketClient, userID, c.cfgProvider, t.RequestID); err != nil {
+			return errors.Wrapf(err, "delete processed tombstone %s", t.RequestID)
+		}
+
+		c.tombstonesDeleted.Inc()
+		level.Info(logger).Log("msg", "deleted processed tombstone", "request_id", t.RequestID)
+	}
+
+	return nil
+}
+
+// rewriteBlockWithTombstones downloads the block, deletes the series matching the input tombstones
+// and uploads the rewritten block, which replaces the original one.
+func (c *Compactor) rewriteBlockWithTombstones(ctx context.Context, userID string, userBucket objstore.Bucket, blockID ulid.ULID, tombstones []*cortex_tsdb.Tombstone, logger log.Logger) error {
+	workDir := filepath.Join(c.compactorCfg.DataDir, "tombstones", userID)
+	if err := os.RemoveAll(workDir); err != nil {
+		return errors.Wrap(err, "clean working directory")
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			level.Warn(logger).Log("msg", "failed to remove working directory", "dir", workDir, "err", err)
+		}
+	}()
+
+	blockDir := filepath.Join(workDir, blockID.String())
+	if err := block.Download(ctx, logger, userBucket, blockID, blockDir); err != nil {
+		return errors.Wrap(err, "download block")
+	}
+
+	// Read the original meta, before the block is opened and its meta rewritten by the TSDB.
+	origMeta, err := metadata.ReadFromDir(blockDir)
+	if err != nil {
+		return errors.Wrap(err, "read block meta")
+	}
+
+	b, err := tsdb.OpenBlock(logger, blockDir, nil)
+	if err != nil {
+		return errors.Wrap(err, "open block")
+	}
+
+	var deletions []metadata.DeletionRequest
+	for _, t := range tombstones {
+		for _, matchers := range t.Matchers {
+			if err := b.Delete(t.StartTime, t.EndTime, matchers...); err != nil {
+				_ = b.Close()
+				return errors.Wrap(err, "delete series")
+			}
+
+			deletions = append(deletions, metadata.DeletionRequest{
+				Matchers:  matchers,
+				Intervals: tsdb_tombstones.Intervals{{Mint: t.StartTime, Maxt: t.EndTime}},
+			})
+		}
+	}
+
+	compactor, err := tsdb.NewLeveledCompactor(ctx, nil, logger, c.compactorCfg.BlockRanges.ToMilliseconds(), nil)
+	if err != nil {
+		_ = b.Close()
+		return errors.Wrap(err, "create compactor")
+	}
+
+	newID, err := b.CleanTombstones(workDir, compactor)
+	if closeErr := b.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "rewrite block")
+	}
+
+	// No series matched the tombstones, so the block doesn't need to be replaced.
+	if newID == nil {
+		return nil
+	}
+
+	// An empty ULID means all the block series have been deleted, so there's nothing to upload.
+	if *newID != (ulid.ULID{}) {
+		newBlockDir := filepath.Join(workDir, newID.String())
+
+		newMeta, err := metadata.InjectThanos(logger, newBlockDir, metadata.Thanos{
+			Labels:     origMeta.Thanos.Labels,
+			Downsample: origMeta.Thanos.Downsample,
+			Source:     metadata.CompactorSource,
+			Rewrites: append(origMeta.Thanos.Rewrites, metadata.Rewrite{
+				Sources:          origMeta.Compaction.Sources,
+				DeletionsApplied: deletions,
+			}),
+		}, nil)
+		if err != nil {
+			return errors.Wrap(err, "inject thanos meta")
+		}
+
+		// The rewritten block replaces the original one, so it should keep its compaction level.
+		// Sources are a superset of the original ones so that the original block gets filtered
+		// out by the deduplicate filter until it's deleted.
+		newMeta.Compaction.Level = origMeta.Compaction.Level
+		newMeta.Compaction.Sources = append(append([]ulid.ULID{}, origMeta.Compaction.Sources...), *newID)
+		if err := newMeta.WriteToDir(logger, newBlockDir); err != nil {
+			return errors.Wrap(err, "write block meta")
+		}
+
+		if err := block.Upload(ctx, logger, userBucket, newBlockDir); err != nil {
+			return errors.Wrap(err, "upload block")
+		}
+
+		c.blocksRewrittenByTombstones.Inc()
+		level.Info(logger).Log("msg", "uploaded block rewritten by tombstones", "original_block", blockID.String(), "new_block", newID.String())
+	}
+
+	if err := block.MarkForDeletion(ctx, logger, userBucket, blockID, "series deleted by tombstones", c.blocksMarkedForDeletion); err != nil {
+		return errors.Wrap(err, "mark block for deletion")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package compactor
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/oklog/ulid"
+	prom_testutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/block"
+	"github.com/thanos-io/thanos/pkg/block/metadata"
+
+	"github.com/cortexproject/cortex/pkg/storage/bucket"
+	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
+	cortex_testutil "github.com/cortexproject/cortex/pkg/storage/tsdb/testutil"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+func TestCompactor_ShouldRewriteBlocksOverlappingTombstones(t *testing.T) {
+	const userID = "user-1"
+
+	ctx := context.Background()
+	bucketClient, _ := cortex_testutil.PrepareFilesystemBucket(t)
+	externalLabels := map[string]string{cortex_tsdb.TenantIDExternalLabel: userID}
+
+	// Each block contains two series: series_id="0" with a sample at minT and series_id="1" with a sample at maxT-1.
+	block1 := createTSDBBlock(t, bucketClient, userID, 10, 20, externalLabels)
+	block2 := createTSDBBlock(t, bucketClient, userID, 30, 40, externalLabels)
+
+	// The first tombstone can't be cancelled anymore, while the second one was just created.
+	now := time.Now()
+	expired := cortex_tsdb.NewTombstone("expired", util.TimeToMillis(now.Add(-2*time.Hour)), util.TimeToMillis(now.Add(-2*time.Hour)), 0, 25, []string{`{series_id="0"}`}, cortex_tsdb.TombstonePending)
+	recent := cortex_tsdb.NewTombstone("recent", util.TimeToMillis(now), util.TimeToMillis(now), 0, 100, []string{`{series_id="1"}`}, cortex_tsdb.TombstonePending)
+	require.NoError(t, cortex_tsdb.WriteTombstone(ctx, bucketClient, userID, nil, expired))
+	require.NoError(t, cortex_tsdb.WriteTombstone(ctx, bucketClient, userID, nil, recent))
+
+	// Processed tombstones should be deleted only once the deletion delay has elapsed.
+	oldProcessed := cortex_tsdb.NewTombstone("old-processed", util.TimeToMillis(now.Add(-72*time.Hour)), util.TimeToMillis(now.Add(-48*time.Hour)), 0, 5, []string{`{series_id="0"}`}, cortex_tsdb.TombstoneProcessed)
+	recentProcessed := cortex_tsdb.NewTombstone("recent-processed", util.TimeToMillis(now.Add(-72*time.Hour)), util.TimeToMillis(now.Add(-time.Hour)), 0, 5, []string{`{series_id="0"}`}, cortex_tsdb.TombstoneProcessed)
+	require.NoError(t, cortex_tsdb.WriteTombstone(ctx, bucketClient, userID, nil, oldProcessed))
+	require.NoError(t, cortex_tsdb.WriteTombstone(ctx, bucketClient, userID, nil, recentProcessed))
+
+	cfg := prepareConfig()
+	cfg.SeriesDeletionEnabled = true
+	cfg.DeleteRequestCancelPeriod = time.Hour
+	cfg.TombstonesDeletionDelay = 24 * time.Hour
+
+	c, _, _, _, registry, cleanup := prepare(t, cfg, bucketClient)
+	defer cleanup()
+	c.bucketClient = bucketClient
+
+	userBucket := bucket.NewUserBucketClient(userID, bucketClient, nil)
+	fetcher, err := block.NewMetaFetcher(log.NewNopLogger(), 1, userBucket, "", nil, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, c.applyUserTombstones(ctx, userID, userBucket, fetcher, log.NewNopLogger()))
+
+	// The block overlapping the expired tombstone should be marked for deletion.
+	exists, err := userBucket.Exists(ctx, path.Join(block1.String(), metadata.DeletionMarkFilename))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = userBucket.Exists(ctx, path.Join(block2.String(), metadata.DeletionMarkFilename))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// The rewritten block should replace the original one.
+	var newBlocks []ulid.ULID
+	require.NoError(t, userBucket.Iter(ctx, "", func(name string) error {
+		if id, ok := block.IsBlockDir(name); ok && id != block1 && id != block2 {
+			newBlocks = append(newBlocks, id)
+		}
+		return nil
+	}))
+	require.Len(t, newBlocks, 1)
+
+	newMeta, err := block.DownloadMeta(ctx, log.NewNopLogger(), userBucket, newBlocks[0])
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), newMeta.MinTime)
+	assert.Equal(t, int64(20), newMeta.MaxTime)
+	assert.Equal(t, uint64(1), newMeta.Stats.NumSeries)
+	assert.Equal(t, []ulid.ULID{block1, newBlocks[0]}, newMeta.Compaction.Sources)
+	assert.Equal(t, externalLabels, newMeta.Thanos.Labels)
+	require.Len(t, newMeta.Thanos.Rewrites, 1)
+	assert.Equal(t, []ulid.ULID{block1}, newMeta.Thanos.Rewrites[0].Sources)
+
+	// Only the expired tombstone should be marked as processed.
+	tombstone, err := cortex_tsdb.ReadTombstone(ctx, bucketClient, userID, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, cortex_tsdb.TombstoneProcessed, tombstone.State)
+
+	tombstone, err = cortex_tsdb.ReadTombstone(ctx, bucketClient, userID, "recent")
+	require.NoError(t, err)
+	assert.Equal(t, cortex_tsdb.TombstonePending, tombstone.State)
+
+	tombstone, err = cortex_tsdb.ReadTombstone(ctx, bucketClient, userID, "old-processed")
+	require.NoError(t, err)
+	assert.Nil(t, tombstone)
+
+	// The cache generation number of the deleted tombstone should be kept.
+	gen, err := cortex_tsdb.ReadTombstonesCacheGeneration(ctx, bucketClient, userID)
+	require.NoError(t, err)
+	assert.Equal(t, oldProcessed.StateCreatedAt, gen)
+
+	tombstone, err = cortex_tsdb.ReadTombstone(ctx, bucketClient, userID, "recent-processed")
+	require.NoError(t, err)
+	require.NotNil(t, tombstone)
+	assert.Equal(t, cortex_tsdb.TombstoneProcessed, tombstone.State)
+
+	assert.NoError(t, prom_testutil.GatherAndCompare(registry, strings.NewReader(`
+		# HELP cortex_compactor_blocks_marked_for_deletion_total Total number of blocks marked for deletion in compactor.
+		# TYPE cortex_compactor_blocks_marked_for_deletion_total counter
+		cortex_compactor_blocks_marked_for_deletion_total 1
+
+		# HELP cortex_compactor_blocks_rewritten_by_tombstones_total Total number of blocks rewritten by compactor to permanently delete series matching tombstones.
+		# TYPE cortex_compactor_blocks_rewritten_by_tombstones_total counter
+		cortex_compactor_blocks_rewritten_by_tombstones_total 1
+
+		# HELP cortex_compactor_tombstones_processed_total Total number of tombstones permanently applied to the blocks by compactor.
+		# TYPE cortex_compactor_tombstones_processed_total counter
+		cortex_compactor_tombstones_processed_total 1
+
+		# HELP cortex_compactor_tombstones_deleted_total Total number of processed tombstones deleted from the bucket by compactor.
+		# TYPE cortex_compactor_tombstones_deleted_total counter
+		cortex_compactor_tombstones_deleted_total 1
+	`),
+		"cortex_compactor_blocks_marked_for_deletion_total",
+		"cortex_compactor_blocks_rewritten_by_tombstones_total",
+		"cortex_compactor_tombstones_processed_total",
+		"cortex_compactor_tombstones_deleted_total",
+	))
+}
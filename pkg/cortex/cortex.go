@@ -225,6 +225,9 @@ func (c *Config) Validate(log log.Logger) error {
 	if err := c.QueryRange.Validate(); err != nil {
 		return errors.Wrap(err, "invalid query_range config")
 	}
+	if err := c.QueryScheduler.Validate(); err != nil {
+		return errors.Wrap(err, "invalid query_scheduler config")
+	}
 	if err := c.TableManager.Validate(); err != nil {
 		return errors.Wrap(err, "invalid table-manager config")
 	}
@@ -294,6 +297,7 @@ type Cortex struct {
 	Flusher                  *flusher.Flusher
 	Store                    chunk.Store
 	DeletesStore             *purger.DeleteStore
+	BlocksDeletesStore       *purger.BlocksDeleteStore
 	Frontend                 *frontendv1.Frontend
 	TableManager             *chunk.TableManager
 	RuntimeConfig            *runtimeconfig.Manager
@@ -413,7 +417,7 @@ func (t *Cortex) Run() error {
 		// let's find out which module failed
 		for m, s := range t.ServiceMap {
 			if s == service {
-				if service.FailureCase() == util.ErrStopProcess {
+				if errors.Is(service.FailureCase(), util.ErrStopProcess) {
 					level.Info(util_log.Logger).Log("msg", "received stop signal via return error", "module", m, "err", service.FailureCase())
 				} else {
 					level.Error(util_log.Logger).Log("msg", "module failed", "module", m, "err", service.FailureCase())
@@ -449,7 +453,7 @@ func (t *Cortex) Run() error {
 	if err == nil {
 		if failed := sm.ServicesByState()[services.Failed]; len(failed) > 0 {
 			for _, f := range failed {
-				if f.FailureCase() != util.ErrStopProcess {
+				if !errors.Is(f.FailureCase(), util.ErrStopProcess) {
 					// Details were reported via failure listener before
 					err = errors.New("failed services")
 					break
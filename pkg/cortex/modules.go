@@ -77,6 +77,7 @@ const (
 	StoreGateway             string = "store-gateway"
 	MemberlistKV             string = "memberlist-kv"
 	ChunksPurger             string = "chunks-purger"
+	BlocksPurger             string = "blocks-purger"
 	TenantDeletion           string = "tenant-deletion"
 	Purger                   string = "purger"
 	QueryScheduler           string = "query-scheduler"
@@ -470,6 +471,17 @@ func (t *Cortex) initChunkStore() (serv services.Service, err error) {
 }
 
 func (t *Cortex) initDeleteRequestsStore() (serv services.Service, err error) {
+	if t.Cfg.Storage.Engine == storage.StorageEngineBlocks && t.Cfg.PurgerConfig.Enable {
+		t.BlocksDeletesStore, err = purger.NewBlocksDeleteStore(t.Cfg.BlocksStorage, t.Overrides, util_log.Logger,
+			prometheus.WrapRegistererWith(prometheus.Labels{"component": DeleteRequestsStore}, prometheus.DefaultRegisterer))
+		if err != nil {
+			return
+		}
+
+		t.TombstonesLoader = purger.NewTombstonesLoader(t.BlocksDeletesStore, prometheus.DefaultRegisterer)
+		return
+	}
+
 	if t.Cfg.Storage.Engine != storage.StorageEngineChunks || !t.Cfg.PurgerConfig.Enable {
 		// until we need to explicitly enable delete series support we need to do create TombstonesLoader without DeleteStore which acts as noop
 		t.TombstonesLoader = purger.NewTombstonesLoader(nil, nil)
@@ -498,8 +510,11 @@ func (t *Cortex) initDeleteRequestsStore() (serv services.Service, err error) {
 // initQueryFrontendTripperware instantiates the tripperware used by the query frontend
 // to optimize Prometheus query requests.
 func (t *Cortex) initQueryFrontendTripperware() (serv services.Service, err error) {
-	// Load the schema only if sharded queries is set.
-	if t.Cfg.QueryRange.ShardedQueries {
+	t.Cfg.QueryRange.BlocksStorageEnabled = t.Cfg.Storage.Engine == storage.StorageEngineBlocks
+
+	// Load the schema only if sharded queries is set. The blocks storage doesn't need it,
+	// because the number of shards is configured in the query-frontend.
+	if t.Cfg.QueryRange.ShardedQueries && !t.Cfg.QueryRange.BlocksStorageEnabled {
 		err := t.Cfg.Schema.Load()
 		if err != nil {
 			return nil, err
@@ -552,7 +567,12 @@ func (t *Cortex) initQueryFrontend() (serv services.Service, err error) {
 	// Wrap roundtripper into Tripperware.
 	roundTripper = t.QueryFrontendTripperware(roundTripper)
 
-	handler := transport.NewHandler(t.Cfg.Frontend.Handler, roundTripper, util_log.Logger, prometheus.DefaultRegisterer)
+	var queryRules transport.QueryRulesProvider
+	if t.RuntimeConfig != nil {
+		queryRules = newQueryRules(t.RuntimeConfig)
+	}
+
+	handler := transport.NewHandler(t.Cfg.Frontend.Handler, roundTripper, queryRules, util_log.Logger, prometheus.DefaultRegisterer)
 	if t.Cfg.Frontend.CompressResponses {
 		handler = gziphandler.GzipHandler(handler)
 	}
@@ -664,7 +684,20 @@ func (t *Cortex) initRuler() (serv services.Service, err error) {
 	rulerRegisterer := prometheus.WrapRegistererWith(prometheus.Labels{"engine": "ruler"}, prometheus.DefaultRegisterer)
 	queryable, engine := querier.New(t.Cfg.Querier, t.Overrides, t.Distributor, t.StoreQueryables, t.TombstonesLoader, rulerRegisterer)
 
-	managerFactory := ruler.DefaultTenantManagerFactory(t.Cfg.Ruler, t.Distributor, queryable, engine, t.Overrides)
+	var frontendClient *ruler.FrontendClient
+	if t.Cfg.Ruler.FrontendAddress != "" {
+		frontendClient, err = ruler.NewFrontendClient(t.Cfg.Ruler, t.Cfg.API.PrometheusHTTPPrefix, prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		if err != nil && frontendClient != nil {
+			_ = frontendClient.Close()
+		}
+	}()
+
+	managerFactory := ruler.DefaultTenantManagerFactory(t.Cfg.Ruler, t.Distributor, queryable, engine, frontendClient, t.Overrides)
 	manager, err := ruler.NewDefaultMultiTenantManager(t.Cfg.Ruler, managerFactory, prometheus.DefaultRegisterer, util_log.Logger)
 	if err != nil {
 		return nil, err
@@ -682,6 +715,15 @@ func (t *Cortex) initRuler() (serv services.Service, err error) {
 		return
 	}
 
+	// Close the connection to the query-frontend once the ruler has stopped evaluating the rules.
+	if frontendClient != nil {
+		t.Ruler.AddListener(services.NewListener(nil, nil, nil, func(_ services.State) {
+			_ = frontendClient.Close()
+		}, func(_ services.State, _ error) {
+			_ = frontendClient.Close()
+		}))
+	}
+
 	// Expose HTTP/GRPC endpoints for the Ruler service
 	t.API.RegisterRuler(t.Ruler)
 
@@ -710,7 +752,7 @@ func (t *Cortex) initConfig() (serv services.Service, err error) {
 func (t *Cortex) initAlertManager() (serv services.Service, err error) {
 	t.Cfg.Alertmanager.ShardingRing.ListenPort = t.Cfg.Server.GRPCListenPort
 
-	t.Alertmanager, err = alertmanager.NewMultitenantAlertmanager(&t.Cfg.Alertmanager, util_log.Logger, prometheus.DefaultRegisterer)
+	t.Alertmanager, err = alertmanager.NewMultitenantAlertmanager(&t.Cfg.Alertmanager, t.Overrides, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
 		return
 	}
@@ -721,6 +763,8 @@ func (t *Cortex) initAlertManager() (serv services.Service, err error) {
 
 func (t *Cortex) initCompactor() (serv services.Service, err error) {
 	t.Cfg.Compactor.ShardingRing.ListenPort = t.Cfg.Server.GRPCListenPort
+	t.Cfg.Compactor.SeriesDeletionEnabled = t.Cfg.PurgerConfig.Enable
+	t.Cfg.Compactor.DeleteRequestCancelPeriod = t.Cfg.PurgerConfig.DeleteRequestCancelPeriod
 
 	t.Compactor, err = compactor.NewCompactor(t.Cfg.Compactor, t.Cfg.BlocksStorage, t.Overrides, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
@@ -742,7 +786,7 @@ func (t *Cortex) initStoreGateway() (serv services.Service, err error) {
 
 	t.Cfg.StoreGateway.ShardingRing.ListenPort = t.Cfg.Server.GRPCListenPort
 
-	t.StoreGateway, err = storegateway.NewStoreGateway(t.Cfg.StoreGateway, t.Cfg.BlocksStorage, t.Overrides, t.Cfg.Server.LogLevel, util_log.Logger, prometheus.DefaultRegisterer)
+	t.StoreGateway, err = storegateway.NewStoreGateway(t.Cfg.StoreGateway, t.Cfg.BlocksStorage, t.Overrides, t.TombstonesLoader, t.Cfg.Server.LogLevel, util_log.Logger, prometheus.DefaultRegisterer)
 	if err != nil {
 		return nil, err
 	}
@@ -757,12 +801,14 @@ func (t *Cortex) initMemberlistKV() (services.Service, error) {
 	t.Cfg.MemberlistKV.MetricsRegisterer = prometheus.DefaultRegisterer
 	t.Cfg.MemberlistKV.Codecs = []codec.Codec{
 		ring.GetCodec(),
+		distributor.GetReplicaDescCodec(),
 	}
 	t.MemberlistKV = memberlist.NewKVInitService(&t.Cfg.MemberlistKV, util_log.Logger)
 	t.API.RegisterMemberlistKV(t.MemberlistKV)
 
 	// Update the config.
 	t.Cfg.Distributor.DistributorRing.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
+	t.Cfg.Distributor.HATrackerConfig.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
 	t.Cfg.Ingester.LifecyclerConfig.RingConfig.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
 	t.Cfg.StoreGateway.ShardingRing.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
 	t.Cfg.Compactor.ShardingRing.KVStore.MemberlistKV = t.MemberlistKV.GetMemberlistKV
@@ -792,6 +838,18 @@ func (t *Cortex) initChunksPurger() (services.Service, error) {
 	return t.Purger, nil
 }
 
+func (t *Cortex) initBlocksPurger() (services.Service, error) {
+	if t.Cfg.Storage.Engine != storage.StorageEngineBlocks || !t.Cfg.PurgerConfig.Enable {
+		return nil, nil
+	}
+
+	// Delete requests are permanently applied to the blocks by the compactor,
+	// so the blocks purger only exposes the API to manage them.
+	t.API.RegisterBlocksPurger(t.BlocksDeletesStore, t.Cfg.PurgerConfig.DeleteRequestCancelPeriod)
+
+	return nil, nil
+}
+
 func (t *Cortex) initTenantDeletionAPI() (services.Service, error) {
 	if t.Cfg.Storage.Engine != storage.StorageEngineBlocks {
 		return nil, nil
@@ -849,6 +907,7 @@ func (t *Cortex) setupModuleManager() error {
 	mm.RegisterModule(Compactor, t.initCompactor)
 	mm.RegisterModule(StoreGateway, t.initStoreGateway)
 	mm.RegisterModule(ChunksPurger, t.initChunksPurger, modules.UserInvisibleModule)
+	mm.RegisterModule(BlocksPurger, t.initBlocksPurger, modules.UserInvisibleModule)
 	mm.RegisterModule(TenantDeletion, t.initTenantDeletionAPI, modules.UserInvisibleModule)
 	mm.RegisterModule(Purger, nil)
 	mm.RegisterModule(QueryScheduler, t.initQueryScheduler)
@@ -866,6 +925,7 @@ func (t *Cortex) setupModuleManager() error {
 		Distributor:              {DistributorService, API},
 		DistributorService:       {Ring, Overrides},
 		Store:                    {Overrides, DeleteRequestsStore},
+		DeleteRequestsStore:      {Overrides},
 		Ingester:                 {IngesterService, API},
 		IngesterService:          {Overrides, Store, RuntimeConfig, MemberlistKV},
 		Flusher:                  {Store, API},
@@ -879,12 +939,13 @@ func (t *Cortex) setupModuleManager() error {
 		Ruler:                    {DistributorService, Store, StoreQueryable, RulerStorage},
 		RulerStorage:             {Overrides},
 		Configs:                  {API},
-		AlertManager:             {API, MemberlistKV},
+		AlertManager:             {API, MemberlistKV, Overrides},
 		Compactor:                {API, MemberlistKV, Overrides},
-		StoreGateway:             {API, Overrides, MemberlistKV},
+		StoreGateway:             {API, Overrides, MemberlistKV, DeleteRequestsStore},
 		ChunksPurger:             {Store, DeleteRequestsStore, API},
+		BlocksPurger:             {DeleteRequestsStore, API},
 		TenantDeletion:           {Store, API, Overrides, RulerStorage},
-		Purger:                   {ChunksPurger, TenantDeletion},
+		Purger:                   {ChunksPurger, BlocksPurger, TenantDeletion},
 		TenantFederation:         {Queryable},
 		All:                      {QueryFrontend, Querier, Ingester, Distributor, TableManager, Purger, StoreGateway, Ruler},
 	}
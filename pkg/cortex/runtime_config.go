@@ -7,6 +7,7 @@ import (
 
 	"gopkg.in/yaml.v2"
 
+	"github.com/cortexproject/cortex/pkg/frontend/transport"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/runtimeconfig"
@@ -24,6 +25,8 @@ type runtimeConfigValues struct {
 	TenantLimits map[string]*validation.Limits `yaml:"overrides"`
 
 	Multi kv.MultiRuntimeConfig `yaml:"multi_kv_config"`
+
+	QueryRules map[string]*transport.QueryRules `yaml:"query_rules"`
 }
 
 // runtimeConfigTenantLimits provides per-tenant limit overrides based on a runtimeconfig.Manager
@@ -53,6 +56,29 @@ func (l *runtimeConfigTenantLimits) AllByUserID() map[string]*validation.Limits
 	return nil
 }
 
+// runtimeConfigQueryRules provides per-tenant query-frontend query rules based on a
+// runtimeconfig.Manager.
+type runtimeConfigQueryRules struct {
+	manager *runtimeconfig.Manager
+}
+
+// newQueryRules creates a new transport.QueryRulesProvider that loads per-tenant query rules
+// from a runtimeconfig.Manager.
+func newQueryRules(manager *runtimeconfig.Manager) transport.QueryRulesProvider {
+	return &runtimeConfigQueryRules{
+		manager: manager,
+	}
+}
+
+func (r *runtimeConfigQueryRules) QueryRules(userID string) *transport.QueryRules {
+	cfg, ok := r.manager.GetConfig().(*runtimeConfigValues)
+	if cfg != nil && ok {
+		return cfg.QueryRules[userID]
+	}
+
+	return nil
+}
+
 func loadRuntimeConfig(r io.Reader) (interface{}, error) {
 	var overrides = &runtimeConfigValues{}
 
@@ -97,3 +97,28 @@ overrides:
 		assert.Nil(t, actual)
 	}
 }
+
+func TestLoadRuntimeConfig_ShouldLoadQueryRules(t *testing.T) {
+	yamlFile := strings.NewReader(`
+query_rules:
+  '1234':
+    blocked_queries:
+      - pattern: 'up'
+`)
+	runtimeCfg, err := loadRuntimeConfig(yamlFile)
+	require.NoError(t, err)
+
+	loadedRules := runtimeCfg.(*runtimeConfigValues).QueryRules
+	require.Equal(t, 1, len(loadedRules))
+	require.Equal(t, 1, len(loadedRules["1234"].BlockedQueries))
+	assert.True(t, loadedRules["1234"].BlockedQueries[0].Matches("up", 0))
+
+	_, err = loadRuntimeConfig(strings.NewReader(`
+query_rules:
+  '1234':
+    blocked_queries:
+      - pattern: 'up('
+        regex: true
+`))
+	require.Error(t, err)
+}
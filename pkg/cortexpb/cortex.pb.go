@@ -85,7 +85,7 @@ var MetricMetadata_MetricType_value = map[string]int32{
 }
 
 func (MetricMetadata_MetricType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{5, 0}
+	return fileDescriptor_893a47d0a749d749, []int{6, 0}
 }
 
 type WriteRequest struct {
@@ -187,6 +187,8 @@ type TimeSeries struct {
 	Labels []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
 	// Sorted by time, oldest sample first.
 	Samples []Sample `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples"`
+	// Sorted by time, oldest exemplar first.
+	Exemplars []Exemplar `protobuf:"bytes,3,rep,name=exemplars,proto3" json:"exemplars"`
 }
 
 func (m *TimeSeries) Reset()      { *m = TimeSeries{} }
@@ -228,6 +230,13 @@ func (m *TimeSeries) GetSamples() []Sample {
 	return nil
 }
 
+func (m *TimeSeries) GetExemplars() []Exemplar {
+	if m != nil {
+		return m.Exemplars
+	}
+	return nil
+}
+
 type LabelPair struct {
 	Name  []byte `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -330,6 +339,59 @@ func (m *Sample) GetTimestampMs() int64 {
 	return 0
 }
 
+type Exemplar struct {
+	// Exemplar labels, different than series labels
+	Labels      []LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=LabelAdapter" json:"labels"`
+	Value       float64        `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	TimestampMs int64          `protobuf:"varint,3,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+}
+
+func (m *Exemplar) Reset()      { *m = Exemplar{} }
+func (*Exemplar) ProtoMessage() {}
+func (*Exemplar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_893a47d0a749d749, []int{5}
+}
+func (m *Exemplar) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Exemplar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Exemplar.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Exemplar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Exemplar.Merge(m, src)
+}
+func (m *Exemplar) XXX_Size() int {
+	return m.Size()
+}
+func (m *Exemplar) XXX_DiscardUnknown() {
+	xxx_messageInfo_Exemplar.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Exemplar proto.InternalMessageInfo
+
+func (m *Exemplar) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Exemplar) GetTimestampMs() int64 {
+	if m != nil {
+		return m.TimestampMs
+	}
+	return 0
+}
+
 type MetricMetadata struct {
 	Type             MetricMetadata_MetricType `protobuf:"varint,1,opt,name=type,proto3,enum=cortexpb.MetricMetadata_MetricType" json:"type,omitempty"`
 	MetricFamilyName string                    `protobuf:"bytes,2,opt,name=metric_family_name,json=metricFamilyName,proto3" json:"metric_family_name,omitempty"`
@@ -340,7 +402,7 @@ type MetricMetadata struct {
 func (m *MetricMetadata) Reset()      { *m = MetricMetadata{} }
 func (*MetricMetadata) ProtoMessage() {}
 func (*MetricMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{5}
+	return fileDescriptor_893a47d0a749d749, []int{6}
 }
 func (m *MetricMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -404,7 +466,7 @@ type Metric struct {
 func (m *Metric) Reset()      { *m = Metric{} }
 func (*Metric) ProtoMessage() {}
 func (*Metric) Descriptor() ([]byte, []int) {
-	return fileDescriptor_893a47d0a749d749, []int{6}
+	return fileDescriptor_893a47d0a749d749, []int{7}
 }
 func (m *Metric) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -441,6 +503,7 @@ func init() {
 	proto.RegisterType((*TimeSeries)(nil), "cortexpb.TimeSeries")
 	proto.RegisterType((*LabelPair)(nil), "cortexpb.LabelPair")
 	proto.RegisterType((*Sample)(nil), "cortexpb.Sample")
+	proto.RegisterType((*Exemplar)(nil), "cortexpb.Exemplar")
 	proto.RegisterType((*MetricMetadata)(nil), "cortexpb.MetricMetadata")
 	proto.RegisterType((*Metric)(nil), "cortexpb.Metric")
 }
@@ -448,48 +511,51 @@ func init() {
 func init() { proto.RegisterFile("cortex.proto", fileDescriptor_893a47d0a749d749) }
 
 var fileDescriptor_893a47d0a749d749 = []byte{
-	// 656 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcd, 0x6e, 0xd3, 0x4a,
-	0x14, 0xf6, 0xe4, 0x3f, 0xa7, 0x6e, 0xae, 0x35, 0xb7, 0xd2, 0xb5, 0xba, 0x70, 0x52, 0xdf, 0x4d,
-	0x16, 0x90, 0xa2, 0x22, 0x84, 0x40, 0x08, 0xc9, 0x41, 0x69, 0xa9, 0xda, 0xfc, 0x68, 0xec, 0x50,
-	0xc1, 0x26, 0x9a, 0xa4, 0xd3, 0xd6, 0xc2, 0x8e, 0x8d, 0x3d, 0xae, 0xc8, 0x0e, 0xde, 0x80, 0x35,
-	0x4f, 0xc0, 0x13, 0xf0, 0x0c, 0x5d, 0x76, 0x59, 0xb1, 0xa8, 0xa8, 0xbb, 0xe9, 0xb2, 0x8f, 0x80,
-	0x3c, 0x76, 0xe3, 0xb2, 0x60, 0xc7, 0xee, 0x9c, 0xf3, 0x9d, 0xef, 0x3b, 0x67, 0xe6, 0x1b, 0x1b,
-	0xe4, 0x99, 0x17, 0x70, 0xf6, 0xb1, 0xe3, 0x07, 0x1e, 0xf7, 0x70, 0x2d, 0xcd, 0xfc, 0xe9, 0xfa,
-	0xc3, 0x63, 0x9b, 0x9f, 0x44, 0xd3, 0xce, 0xcc, 0x73, 0x37, 0x8f, 0xbd, 0x63, 0x6f, 0x53, 0x34,
-	0x4c, 0xa3, 0x23, 0x91, 0x89, 0x44, 0x44, 0x29, 0x51, 0xff, 0x5e, 0x00, 0xf9, 0x20, 0xb0, 0x39,
-	0x23, 0xec, 0x43, 0xc4, 0x42, 0x8e, 0x47, 0x00, 0xdc, 0x76, 0x59, 0xc8, 0x02, 0x9b, 0x85, 0x2a,
-	0x6a, 0x15, 0xdb, 0x2b, 0x5b, 0x6b, 0x9d, 0x3b, 0xf9, 0x8e, 0x65, 0xbb, 0xcc, 0x14, 0x58, 0x77,
-	0xfd, 0xec, 0xb2, 0x29, 0xfd, 0xb8, 0x6c, 0xe2, 0x51, 0xc0, 0xa8, 0xe3, 0x78, 0x33, 0x6b, 0xc9,
-	0x23, 0xf7, 0x34, 0xf0, 0x33, 0xa8, 0x98, 0x5e, 0x14, 0xcc, 0x98, 0x5a, 0x68, 0xa1, 0x76, 0x63,
-	0x6b, 0x23, 0x57, 0xbb, 0x3f, 0xb9, 0x93, 0x36, 0xf5, 0xe6, 0x91, 0x4b, 0x32, 0x02, 0x7e, 0x0e,
-	0x35, 0x97, 0x71, 0x7a, 0x48, 0x39, 0x55, 0x8b, 0x62, 0x15, 0x35, 0x27, 0xf7, 0x19, 0x0f, 0xec,
-	0x59, 0x3f, 0xc3, 0xbb, 0xa5, 0xb3, 0xcb, 0x26, 0x22, 0xcb, 0x7e, 0xfc, 0x02, 0xd6, 0xc3, 0xf7,
-	0xb6, 0x3f, 0x71, 0xe8, 0x94, 0x39, 0x93, 0x39, 0x75, 0xd9, 0xe4, 0x94, 0x3a, 0xf6, 0x21, 0xe5,
-	0xb6, 0x37, 0x57, 0x6f, 0xaa, 0x2d, 0xd4, 0xae, 0x91, 0xff, 0x92, 0x96, 0xfd, 0xa4, 0x63, 0x40,
-	0x5d, 0xf6, 0x66, 0x89, 0xeb, 0x4d, 0x80, 0x7c, 0x1f, 0x5c, 0x85, 0xa2, 0x31, 0xda, 0x55, 0x24,
-	0x5c, 0x83, 0x12, 0x19, 0xef, 0xf7, 0x14, 0xa4, 0xff, 0x03, 0xab, 0xd9, 0xf6, 0xa1, 0xef, 0xcd,
-	0x43, 0xa6, 0x7f, 0x46, 0x00, 0xf9, 0xed, 0x60, 0x03, 0x2a, 0x62, 0xf2, 0xdd, 0x1d, 0xfe, 0x9b,
-	0x2f, 0x2e, 0xe6, 0x8d, 0xa8, 0x1d, 0x74, 0xd7, 0xb2, 0x2b, 0x94, 0x45, 0xc9, 0x38, 0xa4, 0x3e,
-	0x67, 0x01, 0xc9, 0x88, 0xf8, 0x11, 0x54, 0x43, 0xea, 0xfa, 0x0e, 0x0b, 0xd5, 0x82, 0xd0, 0x50,
-	0x72, 0x0d, 0x53, 0x00, 0xe2, 0xd0, 0x12, 0xb9, 0x6b, 0xd3, 0x9f, 0x40, 0x7d, 0x29, 0x8e, 0x31,
-	0x94, 0x92, 0x53, 0xab, 0xa8, 0x85, 0xda, 0x32, 0x11, 0x31, 0x5e, 0x83, 0xf2, 0x29, 0x75, 0xa2,
-	0xd4, 0x0a, 0x99, 0xa4, 0x89, 0x6e, 0x40, 0x25, 0xd5, 0xcb, 0xf1, 0x84, 0x84, 0x32, 0x1c, 0x6f,
-	0x80, 0x2c, 0xfc, 0xe4, 0xd4, 0xf5, 0x27, 0x6e, 0x28, 0xc8, 0x45, 0xb2, 0xb2, 0xac, 0xf5, 0x43,
-	0xfd, 0x6b, 0x01, 0x1a, 0xbf, 0x1b, 0x82, 0x9f, 0x42, 0x89, 0x2f, 0xfc, 0x54, 0xaa, 0xb1, 0xf5,
-	0xff, 0x9f, 0x8c, 0xcb, 0x52, 0x6b, 0xe1, 0x33, 0x22, 0x08, 0xf8, 0x01, 0x60, 0x57, 0xd4, 0x26,
-	0x47, 0xd4, 0xb5, 0x9d, 0x85, 0x30, 0x4f, 0x0c, 0xad, 0x13, 0x25, 0x45, 0xb6, 0x05, 0x90, 0x78,
-	0x96, 0x1c, 0xf3, 0x84, 0x39, 0xbe, 0x5a, 0x12, 0xb8, 0x88, 0x93, 0x5a, 0x34, 0xb7, 0xb9, 0x5a,
-	0x4e, 0x6b, 0x49, 0xac, 0x2f, 0x00, 0xf2, 0x49, 0x78, 0x05, 0xaa, 0xe3, 0xc1, 0xde, 0x60, 0x78,
-	0x30, 0x50, 0xa4, 0x24, 0x79, 0x35, 0x1c, 0x0f, 0xac, 0x1e, 0x51, 0x10, 0xae, 0x43, 0x79, 0xc7,
-	0x18, 0xef, 0xf4, 0x94, 0x02, 0x5e, 0x85, 0xfa, 0xeb, 0x5d, 0xd3, 0x1a, 0xee, 0x10, 0xa3, 0xaf,
-	0x14, 0x31, 0x86, 0x86, 0x40, 0xf2, 0x5a, 0x29, 0xa1, 0x9a, 0xe3, 0x7e, 0xdf, 0x20, 0x6f, 0x95,
-	0x72, 0xf2, 0x3a, 0x76, 0x07, 0xdb, 0x43, 0xa5, 0x82, 0x65, 0xa8, 0x99, 0x96, 0x61, 0xf5, 0xcc,
-	0x9e, 0xa5, 0x54, 0xf5, 0x3d, 0xa8, 0xa4, 0xa3, 0xff, 0xc2, 0xab, 0xe8, 0xbe, 0x3c, 0xbf, 0xd2,
-	0xa4, 0x8b, 0x2b, 0x4d, 0xba, 0xbd, 0xd2, 0xd0, 0xa7, 0x58, 0x43, 0xdf, 0x62, 0x0d, 0x9d, 0xc5,
-	0x1a, 0x3a, 0x8f, 0x35, 0xf4, 0x33, 0xd6, 0xd0, 0x4d, 0xac, 0x49, 0xb7, 0xb1, 0x86, 0xbe, 0x5c,
-	0x6b, 0xd2, 0xf9, 0xb5, 0x26, 0x5d, 0x5c, 0x6b, 0xd2, 0xbb, 0xe5, 0x0f, 0x62, 0x5a, 0x11, 0x1f,
-	0xfe, 0xe3, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x6a, 0xdb, 0xcb, 0x25, 0x41, 0x04, 0x00, 0x00,
+	// 693 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcd, 0x4e, 0xdb, 0x4a,
+	0x14, 0xf6, 0xe4, 0x3f, 0x87, 0x90, 0x6b, 0xcd, 0x45, 0xba, 0x16, 0x0b, 0x27, 0xf8, 0x6e, 0xb2,
+	0xb8, 0x37, 0x54, 0x54, 0x6d, 0xd5, 0xaa, 0xaa, 0xe4, 0x54, 0x81, 0x22, 0xc8, 0x8f, 0x26, 0x4e,
+	0x51, 0xbb, 0x89, 0x26, 0x61, 0x00, 0xab, 0x76, 0xec, 0xda, 0x63, 0x44, 0x76, 0x5d, 0x75, 0xdd,
+	0x75, 0x9f, 0xa0, 0x4f, 0x50, 0xa9, 0x6f, 0xc0, 0x92, 0x25, 0xea, 0x02, 0x15, 0xb3, 0x61, 0xc9,
+	0x23, 0x54, 0x1e, 0x3b, 0x31, 0xa8, 0x62, 0xc7, 0xee, 0x9c, 0xf3, 0x9d, 0xef, 0x9c, 0xcf, 0x9f,
+	0x8f, 0x06, 0x2a, 0x13, 0xc7, 0xe3, 0xec, 0xa4, 0xe9, 0x7a, 0x0e, 0x77, 0x70, 0x29, 0xce, 0xdc,
+	0xf1, 0xea, 0xff, 0x87, 0x26, 0x3f, 0x0a, 0xc6, 0xcd, 0x89, 0x63, 0xaf, 0x1f, 0x3a, 0x87, 0xce,
+	0xba, 0x68, 0x18, 0x07, 0x07, 0x22, 0x13, 0x89, 0x88, 0x62, 0xa2, 0xf6, 0x3d, 0x03, 0x95, 0x3d,
+	0xcf, 0xe4, 0x8c, 0xb0, 0x8f, 0x01, 0xf3, 0x39, 0xee, 0x03, 0x70, 0xd3, 0x66, 0x3e, 0xf3, 0x4c,
+	0xe6, 0x2b, 0xa8, 0x9e, 0x6d, 0x2c, 0x6d, 0xac, 0x34, 0xe7, 0xe3, 0x9b, 0x86, 0x69, 0xb3, 0x81,
+	0xc0, 0x5a, 0xab, 0xa7, 0x17, 0x35, 0xe9, 0xe7, 0x45, 0x0d, 0xf7, 0x3d, 0x46, 0x2d, 0xcb, 0x99,
+	0x18, 0x0b, 0x1e, 0xb9, 0x35, 0x03, 0x3f, 0x87, 0xc2, 0xc0, 0x09, 0xbc, 0x09, 0x53, 0x32, 0x75,
+	0xd4, 0xa8, 0x6e, 0xac, 0xa5, 0xd3, 0x6e, 0x6f, 0x6e, 0xc6, 0x4d, 0xed, 0x69, 0x60, 0x93, 0x84,
+	0x80, 0x5f, 0x40, 0xc9, 0x66, 0x9c, 0xee, 0x53, 0x4e, 0x95, 0xac, 0x90, 0xa2, 0xa4, 0xe4, 0x0e,
+	0xe3, 0x9e, 0x39, 0xe9, 0x24, 0x78, 0x2b, 0x77, 0x7a, 0x51, 0x43, 0x64, 0xd1, 0x8f, 0x5f, 0xc2,
+	0xaa, 0xff, 0xc1, 0x74, 0x47, 0x16, 0x1d, 0x33, 0x6b, 0x34, 0xa5, 0x36, 0x1b, 0x1d, 0x53, 0xcb,
+	0xdc, 0xa7, 0xdc, 0x74, 0xa6, 0xca, 0x75, 0xb1, 0x8e, 0x1a, 0x25, 0xf2, 0x4f, 0xd4, 0xb2, 0x1b,
+	0x75, 0x74, 0xa9, 0xcd, 0xde, 0x2e, 0x70, 0xad, 0x06, 0x90, 0xea, 0xc1, 0x45, 0xc8, 0xea, 0xfd,
+	0x6d, 0x59, 0xc2, 0x25, 0xc8, 0x91, 0xe1, 0x6e, 0x5b, 0x46, 0xda, 0x5f, 0xb0, 0x9c, 0xa8, 0xf7,
+	0x5d, 0x67, 0xea, 0x33, 0xed, 0x07, 0x02, 0x48, 0xdd, 0xc1, 0x3a, 0x14, 0xc4, 0xe6, 0xb9, 0x87,
+	0x7f, 0xa7, 0xc2, 0xc5, 0xbe, 0x3e, 0x35, 0xbd, 0xd6, 0x4a, 0x62, 0x61, 0x45, 0x94, 0xf4, 0x7d,
+	0xea, 0x72, 0xe6, 0x91, 0x84, 0x88, 0x1f, 0x41, 0xd1, 0xa7, 0xb6, 0x6b, 0x31, 0x5f, 0xc9, 0x88,
+	0x19, 0x72, 0x3a, 0x63, 0x20, 0x00, 0xf1, 0xd1, 0x12, 0x99, 0xb7, 0xe1, 0xa7, 0x50, 0x66, 0x27,
+	0xcc, 0x76, 0x2d, 0xea, 0xf9, 0x89, 0x61, 0x38, 0xe5, 0xb4, 0x13, 0x28, 0x61, 0xa5, 0xad, 0xda,
+	0x13, 0x28, 0x2f, 0x44, 0x61, 0x0c, 0xb9, 0xc8, 0x2d, 0x05, 0xd5, 0x51, 0xa3, 0x42, 0x44, 0x8c,
+	0x57, 0x20, 0x7f, 0x4c, 0xad, 0x20, 0xfe, 0x85, 0x15, 0x12, 0x27, 0x9a, 0x0e, 0x85, 0x58, 0x47,
+	0x8a, 0x47, 0x24, 0x94, 0xe0, 0x78, 0x0d, 0x2a, 0xe2, 0x0e, 0x38, 0xb5, 0xdd, 0x91, 0xed, 0x0b,
+	0x72, 0x96, 0x2c, 0x2d, 0x6a, 0x1d, 0x5f, 0xfb, 0x8c, 0xa0, 0x34, 0xd7, 0xf5, 0x10, 0x9e, 0xdd,
+	0x11, 0x7a, 0xaf, 0x90, 0xec, 0x9f, 0x42, 0xbe, 0x66, 0xa0, 0x7a, 0xf7, 0xa2, 0xf0, 0x33, 0xc8,
+	0xf1, 0x99, 0x1b, 0x7f, 0x53, 0x75, 0xe3, 0xdf, 0xfb, 0x2e, 0x2f, 0x49, 0x8d, 0x99, 0xcb, 0x88,
+	0x20, 0xe0, 0xff, 0x00, 0xdb, 0xa2, 0x36, 0x3a, 0xa0, 0xb6, 0x69, 0xcd, 0xc4, 0xf5, 0x09, 0x45,
+	0x65, 0x22, 0xc7, 0xc8, 0xa6, 0x00, 0xa2, 0xa3, 0x8b, 0xfc, 0x3e, 0x62, 0x96, 0xab, 0xe4, 0x04,
+	0x2e, 0xe2, 0xa8, 0x16, 0x4c, 0x4d, 0xae, 0xe4, 0xe3, 0x5a, 0x14, 0x6b, 0x33, 0x80, 0x74, 0x13,
+	0x5e, 0x82, 0xe2, 0xb0, 0xbb, 0xd3, 0xed, 0xed, 0x75, 0x65, 0x29, 0x4a, 0x5e, 0xf7, 0x86, 0x5d,
+	0xa3, 0x4d, 0x64, 0x84, 0xcb, 0x90, 0xdf, 0xd2, 0x87, 0x5b, 0x6d, 0x39, 0x83, 0x97, 0xa1, 0xfc,
+	0x66, 0x7b, 0x60, 0xf4, 0xb6, 0x88, 0xde, 0x91, 0xb3, 0x18, 0x43, 0x55, 0x20, 0x69, 0x2d, 0x17,
+	0x51, 0x07, 0xc3, 0x4e, 0x47, 0x27, 0xef, 0xe4, 0x7c, 0x74, 0xde, 0xdb, 0xdd, 0xcd, 0x9e, 0x5c,
+	0xc0, 0x15, 0x28, 0x0d, 0x0c, 0xdd, 0x68, 0x0f, 0xda, 0x86, 0x5c, 0xd4, 0x76, 0xa0, 0x10, 0xaf,
+	0x7e, 0x80, 0x5f, 0xd4, 0x7a, 0x75, 0x76, 0xa9, 0x4a, 0xe7, 0x97, 0xaa, 0x74, 0x73, 0xa9, 0xa2,
+	0x4f, 0xa1, 0x8a, 0xbe, 0x85, 0x2a, 0x3a, 0x0d, 0x55, 0x74, 0x16, 0xaa, 0xe8, 0x57, 0xa8, 0xa2,
+	0xeb, 0x50, 0x95, 0x6e, 0x42, 0x15, 0x7d, 0xb9, 0x52, 0xa5, 0xb3, 0x2b, 0x55, 0x3a, 0xbf, 0x52,
+	0xa5, 0xf7, 0x8b, 0x17, 0x6e, 0x5c, 0x10, 0x2f, 0xd7, 0xe3, 0xdf, 0x03, 0x00, 0x78, 0x97, 0x49,
+	0xfd, 0x02, 0x05, 0x00, 0x00,
 }
 
 func (x WriteRequest_SourceEnum) String() string {
@@ -605,6 +671,14 @@ func (this *TimeSeries) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.Exemplars) != len(that1.Exemplars) {
+		return false
+	}
+	for i := range this.Exemplars {
+		if !this.Exemplars[i].Equal(&that1.Exemplars[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *LabelPair) Equal(that interface{}) bool {
@@ -661,6 +735,41 @@ func (this *Sample) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *Exemplar) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Exemplar)
+	if !ok {
+		that2, ok := that.(Exemplar)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	if this.TimestampMs != that1.TimestampMs {
+		return false
+	}
+	return true
+}
 func (this *MetricMetadata) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -751,7 +860,7 @@ func (this *TimeSeries) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 7)
 	s = append(s, "&cortexpb.TimeSeries{")
 	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
 	if this.Samples != nil {
@@ -761,6 +870,13 @@ func (this *TimeSeries) GoString() string {
 		}
 		s = append(s, "Samples: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
+	if this.Exemplars != nil {
+		vs := make([]*Exemplar, len(this.Exemplars))
+		for i := range vs {
+			vs[i] = &this.Exemplars[i]
+		}
+		s = append(s, "Exemplars: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -786,6 +902,18 @@ func (this *Sample) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *Exemplar) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&cortexpb.Exemplar{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "TimestampMs: "+fmt.Sprintf("%#v", this.TimestampMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *MetricMetadata) GoString() string {
 	if this == nil {
 		return "nil"
@@ -928,6 +1056,20 @@ func (m *TimeSeries) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Exemplars) > 0 {
+		for iNdEx := len(m.Exemplars) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Exemplars[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Samples) > 0 {
 		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1030,6 +1172,54 @@ func (m *Sample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Exemplar) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Exemplar) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Exemplar) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimestampMs != 0 {
+		i = encodeVarintCortex(dAtA, i, uint64(m.TimestampMs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Value != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintCortex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *MetricMetadata) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1181,6 +1371,12 @@ func (m *TimeSeries) Size() (n int) {
 			n += 1 + l + sovCortex(uint64(l))
 		}
 	}
+	if len(m.Exemplars) > 0 {
+		for _, e := range m.Exemplars {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1216,6 +1412,27 @@ func (m *Sample) Size() (n int) {
 	return n
 }
 
+func (m *Exemplar) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovCortex(uint64(l))
+		}
+	}
+	if m.Value != 0 {
+		n += 9
+	}
+	if m.TimestampMs != 0 {
+		n += 1 + sovCortex(uint64(m.TimestampMs))
+	}
+	return n
+}
+
 func (m *MetricMetadata) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1297,9 +1514,15 @@ func (this *TimeSeries) String() string {
 		repeatedStringForSamples += strings.Replace(strings.Replace(f.String(), "Sample", "Sample", 1), `&`, ``, 1) + ","
 	}
 	repeatedStringForSamples += "}"
+	repeatedStringForExemplars := "[]Exemplar{"
+	for _, f := range this.Exemplars {
+		repeatedStringForExemplars += strings.Replace(strings.Replace(f.String(), "Exemplar", "Exemplar", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForExemplars += "}"
 	s := strings.Join([]string{`&TimeSeries{`,
 		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
 		`Samples:` + repeatedStringForSamples + `,`,
+		`Exemplars:` + repeatedStringForExemplars + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1326,6 +1549,18 @@ func (this *Sample) String() string {
 	}, "")
 	return s
 }
+func (this *Exemplar) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Exemplar{`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`TimestampMs:` + fmt.Sprintf("%v", this.TimestampMs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *MetricMetadata) String() string {
 	if this == nil {
 		return "nil"
@@ -1667,6 +1902,40 @@ func (m *TimeSeries) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exemplars", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Exemplars = append(m.Exemplars, Exemplar{})
+			if err := m.Exemplars[len(m.Exemplars)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCortex(dAtA[iNdEx:])
@@ -1895,6 +2164,123 @@ func (m *Sample) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Exemplar) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCortex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Exemplar: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Exemplar: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCortex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, LabelAdapter{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampMs", wireType)
+			}
+			m.TimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCortex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCortex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthCortex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *MetricMetadata) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
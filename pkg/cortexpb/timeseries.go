@@ -12,9 +12,10 @@ import (
 )
 
 var (
-	expectedTimeseries       = 100
-	expectedLabels           = 20
-	expectedSamplesPerSeries = 10
+	expectedTimeseries         = 100
+	expectedLabels             = 20
+	expectedSamplesPerSeries   = 10
+	expectedExemplarsPerSeries = 1
 
 	/*
 		We cannot pool these as pointer-to-slice because the place we use them is in WriteRequest which is generated from Protobuf
@@ -30,8 +31,9 @@ var (
 	timeSeriesPool = sync.Pool{
 		New: func() interface{} {
 			return &TimeSeries{
-				Labels:  make([]LabelAdapter, 0, expectedLabels),
-				Samples: make([]Sample, 0, expectedSamplesPerSeries),
+				Labels:    make([]LabelAdapter, 0, expectedLabels),
+				Samples:   make([]Sample, 0, expectedSamplesPerSeries),
+				Exemplars: make([]Exemplar, 0, expectedExemplarsPerSeries),
 			}
 		},
 	}
@@ -283,5 +285,19 @@ func ReuseTimeseries(ts *TimeSeries) {
 	}
 	ts.Labels = ts.Labels[:0]
 	ts.Samples = ts.Samples[:0]
+	ts.ClearExemplars()
 	timeSeriesPool.Put(ts)
 }
+
+// ClearExemplars clears the exemplars of the timeseries, including their labels
+// which may point into a large gRPC buffer.
+func (m *TimeSeries) ClearExemplars() {
+	for i := range m.Exemplars {
+		for j := range m.Exemplars[i].Labels {
+			m.Exemplars[i].Labels[j].Name = ""
+			m.Exemplars[i].Labels[j].Value = ""
+		}
+		m.Exemplars[i].Labels = m.Exemplars[i].Labels[:0]
+	}
+	m.Exemplars = m.Exemplars[:0]
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/weaveworks/common/instrument"
 	"github.com/weaveworks/common/user"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	ingester_client "github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/prom1/storage/metric"
 	"github.com/cortexproject/cortex/pkg/ring"
@@ -30,6 +31,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/extract"
+	"github.com/cortexproject/cortex/pkg/util/flagext"
 	"github.com/cortexproject/cortex/pkg/util/limiter"
 	"github.com/cortexproject/cortex/pkg/util/math"
 	"github.com/cortexproject/cortex/pkg/util/services"
@@ -44,6 +46,8 @@ var (
 	// Validation errors.
 	errInvalidShardingStrategy = errors.New("invalid sharding strategy")
 	errInvalidTenantShardSize  = errors.New("invalid tenant shard size, the value must be greater than 0")
+
+	errLabelCardinalityUnhealthyIngesters = errors.New("the label cardinality can't be computed while some ingesters are unhealthy")
 )
 
 const (
@@ -81,8 +85,10 @@ type Distributor struct {
 	// Metrics
 	queryDuration                    *instrument.HistogramCollector
 	receivedSamples                  *prometheus.CounterVec
+	receivedExemplars                *prometheus.CounterVec
 	receivedMetadata                 *prometheus.CounterVec
 	incomingSamples                  *prometheus.CounterVec
+	incomingExemplars                *prometheus.CounterVec
 	incomingMetadata                 *prometheus.CounterVec
 	nonHASamples                     *prometheus.CounterVec
 	dedupedSamples                   *prometheus.CounterVec
@@ -113,6 +119,8 @@ type Config struct {
 	// Distributors ring
 	DistributorRing RingConfig `yaml:"ring"`
 
+	OTLP OTLPConfig `yaml:"otlp"`
+
 	// for testing and for extending the ingester by adding calls to the client
 	IngesterClientFactory ring_client.PoolFactory `yaml:"-"`
 
@@ -129,6 +137,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	cfg.PoolConfig.RegisterFlags(f)
 	cfg.HATrackerConfig.RegisterFlags(f)
 	cfg.DistributorRing.RegisterFlags(f)
+	cfg.OTLP.RegisterFlags(f)
 
 	f.IntVar(&cfg.MaxRecvMsgSize, "distributor.max-recv-msg-size", 100<<20, "remote_write API max receive message size (bytes).")
 	f.DurationVar(&cfg.RemoteTimeout, "distributor.remote-timeout", 2*time.Second, "Timeout for downstream ingesters.")
@@ -138,6 +147,16 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.BoolVar(&cfg.ExtendWrites, "distributor.extend-writes", true, "Try writing to an additional ingester in the presence of an ingester not in the ACTIVE state. It is useful to disable this along with -ingester.unregister-on-shutdown=false in order to not spread samples to extra ingesters during rolling restarts with consistent naming.")
 }
 
+// OTLPConfig configures how the metrics received through the OTLP endpoint are translated.
+type OTLPConfig struct {
+	PromoteResourceAttributes flagext.StringSliceCSV `yaml:"promote_resource_attributes"`
+}
+
+// RegisterFlags adds the flags required to config this to the given FlagSet.
+func (cfg *OTLPConfig) RegisterFlags(f *flag.FlagSet) {
+	f.Var(&cfg.PromoteResourceAttributes, "distributor.otlp.promote-resource-attributes", "Comma-separated list of OTLP resource attributes to add as labels to all the series of the resource. The job and instance labels are always set from the service.namespace, service.name and service.instance.id attributes.")
+}
+
 // Validate config and returns error on failure
 func (cfg *Config) Validate(limits validation.Limits) error {
 	if !util.StringsContain(supportedShardingStrategies, cfg.ShardingStrategy) {
@@ -211,6 +230,11 @@ func New(cfg Config, clientConfig ingester_client.Config, limits *validation.Ove
 			Name:      "distributor_received_samples_total",
 			Help:      "The total number of received samples, excluding rejected and deduped samples.",
 		}, []string{"user"}),
+		receivedExemplars: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "distributor_received_exemplars_total",
+			Help:      "The total number of received exemplars, excluding rejected and deduped exemplars.",
+		}, []string{"user"}),
 		receivedMetadata: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
 			Namespace: "cortex",
 			Name:      "distributor_received_metadata_total",
@@ -221,6 +245,11 @@ func New(cfg Config, clientConfig ingester_client.Config, limits *validation.Ove
 			Name:      "distributor_samples_in_total",
 			Help:      "The total number of samples that have come in to the distributor, including rejected or deduped samples.",
 		}, []string{"user"}),
+		incomingExemplars: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: "cortex",
+			Name:      "distributor_exemplars_in_total",
+			Help:      "The total number of exemplars that have come in to the distributor, including rejected or deduped exemplars.",
+		}, []string{"user"}),
 		incomingMetadata: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
 			Namespace: "cortex",
 			Name:      "distributor_metadata_in_total",
@@ -310,8 +339,10 @@ func (d *Distributor) cleanupInactiveUser(userID string) {
 	d.HATracker.cleanupHATrackerMetricsForUser(userID)
 
 	d.receivedSamples.DeleteLabelValues(userID)
+	d.receivedExemplars.DeleteLabelValues(userID)
 	d.receivedMetadata.DeleteLabelValues(userID)
 	d.incomingSamples.DeleteLabelValues(userID)
+	d.incomingExemplars.DeleteLabelValues(userID)
 	d.incomingMetadata.DeleteLabelValues(userID)
 	d.nonHASamples.DeleteLabelValues(userID)
 	d.latestSeenSampleTimestampPerUser.DeleteLabelValues(userID)
@@ -424,10 +455,23 @@ func (d *Distributor) validateSeries(ts ingester_client.PreallocTimeseries, user
 		samples = append(samples, s)
 	}
 
+	// Exemplars are dropped if the tenant has no exemplars storage in the ingesters.
+	var exemplars []cortexpb.Exemplar
+	if d.limits.MaxGlobalExemplarsPerUser(userID) > 0 && len(ts.Exemplars) > 0 {
+		exemplars = make([]cortexpb.Exemplar, 0, len(ts.Exemplars))
+		for _, e := range ts.Exemplars {
+			if err := validation.ValidateExemplar(d.limits, userID, ts.Labels, e); err != nil {
+				return emptyPreallocSeries, err
+			}
+			exemplars = append(exemplars, e)
+		}
+	}
+
 	return ingester_client.PreallocTimeseries{
 			TimeSeries: &ingester_client.TimeSeries{
-				Labels:  ts.Labels,
-				Samples: samples,
+				Labels:    ts.Labels,
+				Samples:   samples,
+				Exemplars: exemplars,
 			},
 		},
 		nil
@@ -449,11 +493,14 @@ func (d *Distributor) Push(ctx context.Context, req *ingester_client.WriteReques
 	removeReplica := false
 
 	numSamples := 0
+	numExemplars := 0
 	for _, ts := range req.Timeseries {
 		numSamples += len(ts.Samples)
+		numExemplars += len(ts.Exemplars)
 	}
-	// Count the total samples in, prior to validation or deduplication, for comparison with other metrics.
+	// Count the total samples and exemplars in, prior to validation or deduplication, for comparison with other metrics.
 	d.incomingSamples.WithLabelValues(userID).Add(float64(numSamples))
+	d.incomingExemplars.WithLabelValues(userID).Add(float64(numExemplars))
 	// Count the total number of metadata in.
 	d.incomingMetadata.WithLabelValues(userID).Add(float64(len(req.Metadata)))
 
@@ -465,6 +512,7 @@ func (d *Distributor) Push(ctx context.Context, req *ingester_client.WriteReques
 	metadataKeys := make([]uint32, 0, len(req.Metadata))
 	seriesKeys := make([]uint32, 0, len(req.Timeseries))
 	validatedSamples := 0
+	validatedExemplars := 0
 
 	if d.limits.AcceptHASamples(userID) && len(req.Timeseries) > 0 {
 		cluster, replica := findHALabels(d.limits.HAReplicaLabel(userID), d.limits.HAClusterLabel(userID), req.Timeseries[0].Labels)
@@ -558,7 +606,8 @@ func (d *Distributor) Push(ctx context.Context, req *ingester_client.WriteReques
 
 		seriesKeys = append(seriesKeys, key)
 		validatedTimeseries = append(validatedTimeseries, validatedSeries)
-		validatedSamples += len(ts.Samples)
+		validatedSamples += len(validatedSeries.Samples)
+		validatedExemplars += len(validatedSeries.Exemplars)
 	}
 
 	for _, m := range req.Metadata {
@@ -577,6 +626,7 @@ func (d *Distributor) Push(ctx context.Context, req *ingester_client.WriteReques
 	}
 
 	d.receivedSamples.WithLabelValues(userID).Add(float64(validatedSamples))
+	d.receivedExemplars.WithLabelValues(userID).Add(float64(validatedExemplars))
 	d.receivedMetadata.WithLabelValues(userID).Add(float64(len(validatedMetadata)))
 
 	if len(seriesKeys) == 0 && len(metadataKeys) == 0 {
@@ -586,7 +636,7 @@ func (d *Distributor) Push(ctx context.Context, req *ingester_client.WriteReques
 		return &ingester_client.WriteResponse{}, firstPartialErr
 	}
 
-	totalN := validatedSamples + len(validatedMetadata)
+	totalN := validatedSamples + validatedExemplars + len(validatedMetadata)
 	rateOK, rateReservation := d.ingestionRateLimiter.AllowN(now, userID, totalN)
 	if !rateOK {
 		// Ensure the request slice is reused if the request is rate limited.
@@ -595,8 +645,9 @@ func (d *Distributor) Push(ctx context.Context, req *ingester_client.WriteReques
 		// Return a 4xx here to have the client discard the data and not retry. If a client
 		// is sending too much data consistently we will unlikely ever catch up otherwise.
 		validation.DiscardedSamples.WithLabelValues(validation.RateLimited, userID).Add(float64(validatedSamples))
+		validation.DiscardedExemplars.WithLabelValues(validation.RateLimited, userID).Add(float64(validatedExemplars))
 		validation.DiscardedMetadata.WithLabelValues(validation.RateLimited, userID).Add(float64(len(validatedMetadata)))
-		return nil, httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (%v) exceeded while adding %d samples and %d metadata", d.ingestionRateLimiter.Limit(now, userID), validatedSamples, len(validatedMetadata))
+		return nil, httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (%v) exceeded while adding %d samples, %d exemplars and %d metadata", d.ingestionRateLimiter.Limit(now, userID), validatedSamples, validatedExemplars, len(validatedMetadata))
 	}
 
 	subRing := d.ingestersRing
@@ -748,17 +799,18 @@ func (d *Distributor) LabelValuesForLabelName(ctx context.Context, from, to mode
 	return values, nil
 }
 
-// LabelNames returns all of the label names.
-func (d *Distributor) LabelNames(ctx context.Context, from, to model.Time) ([]string, error) {
+// LabelNames returns all of the label names of the series matching the input matchers, if any.
+func (d *Distributor) LabelNames(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) ([]string, error) {
 	replicationSet, err := d.GetIngestersForMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	req := &ingester_client.LabelNamesRequest{
-		StartTimestampMs: int64(from),
-		EndTimestampMs:   int64(to),
+	req, err := ingester_client.ToLabelNamesRequest(from, to, matchers)
+	if err != nil {
+		return nil, err
 	}
+
 	resps, err := d.ForReplicationSet(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
 		return client.LabelNames(ctx, req)
 	})
@@ -783,6 +835,127 @@ func (d *Distributor) LabelNames(ctx context.Context, from, to model.Time) ([]st
 	return values, nil
 }
 
+// LabelCardinality returns the number of in-memory series for each label name and value pair
+// of the series matching the input matchers, if any. If no label names are given, all label names
+// are returned, sorted by name. If labelNamesOnly is true, only the number of values and series of
+// each label name are returned, without the values, and label names are sorted by their number of
+// values in descending order. Otherwise the values of each label name are sorted by their number
+// of series in descending order. The limit, if greater than 0, is applied to the sorted label names
+// (if labelNamesOnly) or to the sorted values of each label name, once the responses of all the
+// ingesters have been merged.
+//
+// Each ingester returns all its values, so the distinct values are exact. Since each series is
+// replicated across multiple ingesters, the series counts are computed dividing the sum of the
+// series counted by all ingesters by the number of replicas of each series, so every ingester
+// is required to be healthy and to respond.
+func (d *Distributor) LabelCardinality(ctx context.Context, labelNames []string, limit int, labelNamesOnly bool, matchers ...*labels.Matcher) ([]*ingester_client.LabelCardinality, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ingestersRing := d.getIngestersRingForMetadata(userID)
+	replicationSet, err := ingestersRing.GetReplicationSetForOperation(ring.Read)
+	if err != nil {
+		return nil, err
+	}
+	if len(replicationSet.Ingesters) < ingestersRing.InstancesCount() {
+		return nil, errLabelCardinalityUnhealthyIngesters
+	}
+
+	// Wait for the responses of all ingesters, instead of a quorum of them.
+	replicationSet.MaxErrors = 0
+	replicationSet.MaxUnavailableZones = 0
+
+	req, err := ingester_client.ToLabelCardinalityRequest(labelNames, labelNamesOnly, matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	resps, err := d.ForReplicationSet(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
+		return client.LabelCardinality(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type labelCounts struct {
+		seriesCount uint64
+		values      map[string]uint64
+	}
+
+	counts := map[string]*labelCounts{}
+	for _, resp := range resps {
+		for _, item := range resp.(*ingester_client.LabelCardinalityResponse).Items {
+			c, ok := counts[item.LabelName]
+			if !ok {
+				c = &labelCounts{values: map[string]uint64{}}
+				counts[item.LabelName] = c
+			}
+
+			c.seriesCount += item.SeriesCount
+			for _, v := range item.Values {
+				c.values[v.LabelValue] += v.SeriesCount
+			}
+		}
+	}
+
+	numReplicas := uint64(math.Min(ingestersRing.ReplicationFactor(), len(replicationSet.Ingesters)))
+
+	// Round up, so that a series is never reported as zero series.
+	dedupe := func(count uint64) uint64 {
+		return (count + numReplicas - 1) / numReplicas
+	}
+
+	result := make([]*ingester_client.LabelCardinality, 0, len(counts))
+	for name, c := range counts {
+		item := &ingester_client.LabelCardinality{
+			LabelName:        name,
+			Values:           []ingester_client.LabelValueCardinality{},
+			LabelValuesCount: uint64(len(c.values)),
+			SeriesCount:      dedupe(c.seriesCount),
+		}
+
+		if !labelNamesOnly {
+			for value, count := range c.values {
+				item.Values = append(item.Values, ingester_client.LabelValueCardinality{
+					LabelValue:  value,
+					SeriesCount: dedupe(count),
+				})
+			}
+
+			sort.Slice(item.Values, func(i, j int) bool {
+				if item.Values[i].SeriesCount != item.Values[j].SeriesCount {
+					return item.Values[i].SeriesCount > item.Values[j].SeriesCount
+				}
+				return item.Values[i].LabelValue < item.Values[j].LabelValue
+			})
+			if limit > 0 && len(item.Values) > limit {
+				item.Values = item.Values[:limit]
+			}
+		}
+
+		result = append(result, item)
+	}
+
+	if !labelNamesOnly {
+		sort.Slice(result, func(i, j int) bool { return result[i].LabelName < result[j].LabelName })
+		return result, nil
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LabelValuesCount != result[j].LabelValuesCount {
+			return result[i].LabelValuesCount > result[j].LabelValuesCount
+		}
+		return result[i].LabelName < result[j].LabelName
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
 // MetricsForLabelMatchers gets the metrics that match said matchers
 func (d *Distributor) MetricsForLabelMatchers(ctx context.Context, from, through model.Time, matchers ...*labels.Matcher) ([]metric.Metric, error) {
 	replicationSet, err := d.GetIngestersForMetadata(ctx)
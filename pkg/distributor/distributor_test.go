@@ -32,6 +32,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/prom1/storage/metric"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/ring"
 	ring_client "github.com/cortexproject/cortex/pkg/ring/client"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
@@ -40,6 +41,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/chunkcompat"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	util_math "github.com/cortexproject/cortex/pkg/util/math"
 	"github.com/cortexproject/cortex/pkg/util/services"
 	"github.com/cortexproject/cortex/pkg/util/test"
@@ -184,7 +186,7 @@ func TestDistributor_Push(t *testing.T) {
 			happyIngesters: 3,
 			samples:        samplesIn{num: 25, startTimestampMs: 123456789000},
 			metadata:       5,
-			expectedError:  httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (20) exceeded while adding 25 samples and 5 metadata"),
+			expectedError:  httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (20) exceeded while adding 25 samples, 0 exemplars and 5 metadata"),
 			metricNames:    []string{lastSeenTimestamp},
 			expectedMetrics: `
 				# HELP cortex_distributor_latest_seen_sample_timestamp_seconds Unix timestamp of latest received sample per user.
@@ -374,10 +376,10 @@ func TestDistributor_PushIngestionRateLimiter(t *testing.T) {
 			pushes: []testPush{
 				{samples: 4, expectedError: nil},
 				{metadata: 1, expectedError: nil},
-				{samples: 6, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (10) exceeded while adding 6 samples and 0 metadata")},
+				{samples: 6, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (10) exceeded while adding 6 samples, 0 exemplars and 0 metadata")},
 				{samples: 4, metadata: 1, expectedError: nil},
-				{samples: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (10) exceeded while adding 1 samples and 0 metadata")},
-				{metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (10) exceeded while adding 0 samples and 1 metadata")},
+				{samples: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (10) exceeded while adding 1 samples, 0 exemplars and 0 metadata")},
+				{metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (10) exceeded while adding 0 samples, 0 exemplars and 1 metadata")},
 			},
 		},
 		"global strategy: limit should be evenly shared across distributors": {
@@ -388,10 +390,10 @@ func TestDistributor_PushIngestionRateLimiter(t *testing.T) {
 			pushes: []testPush{
 				{samples: 2, expectedError: nil},
 				{samples: 1, expectedError: nil},
-				{samples: 2, metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 2 samples and 1 metadata")},
+				{samples: 2, metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 2 samples, 0 exemplars and 1 metadata")},
 				{samples: 2, expectedError: nil},
-				{samples: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 1 samples and 0 metadata")},
-				{metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 0 samples and 1 metadata")},
+				{samples: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 1 samples, 0 exemplars and 0 metadata")},
+				{metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 0 samples, 0 exemplars and 1 metadata")},
 			},
 		},
 		"global strategy: burst should set to each distributor": {
@@ -402,10 +404,10 @@ func TestDistributor_PushIngestionRateLimiter(t *testing.T) {
 			pushes: []testPush{
 				{samples: 10, expectedError: nil},
 				{samples: 5, expectedError: nil},
-				{samples: 5, metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 5 samples and 1 metadata")},
+				{samples: 5, metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 5 samples, 0 exemplars and 1 metadata")},
 				{samples: 5, expectedError: nil},
-				{samples: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 1 samples and 0 metadata")},
-				{metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 0 samples and 1 metadata")},
+				{samples: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 1 samples, 0 exemplars and 0 metadata")},
+				{metadata: 1, expectedError: httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit (5) exceeded while adding 0 samples, 0 exemplars and 1 metadata")},
 			},
 		},
 		"unhappy ingesters: rate limit should be unaffected when ingestion fails": {
@@ -738,6 +740,98 @@ func TestDistributor_PushQuery(t *testing.T) {
 	}
 }
 
+func TestDistributor_QueryStream_ShouldReturnErrorIfQueryLimitsAreReached(t *testing.T) {
+	const numSeries = 10
+
+	tests := map[string]struct {
+		maxSeries     int
+		maxChunkBytes int
+		expectedErr   string
+	}{
+		"no limits": {},
+		"series limit not reached": {
+			maxSeries: numSeries,
+		},
+		"series limit reached": {
+			maxSeries:   numSeries - 1,
+			expectedErr: "the query hit the max number of series limit",
+		},
+		"chunk bytes limit reached": {
+			maxChunkBytes: 1,
+			expectedErr:   "the query hit the aggregated chunks size limit",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ds, _, r, _ := prepare(t, prepConfig{
+				numIngesters:     3,
+				happyIngesters:   3,
+				numDistributors:  1,
+				shardByAllLabels: true,
+			})
+			defer stopAll(ds, r)
+
+			ctx := user.InjectOrgID(context.Background(), "user")
+			ctx = limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(testData.maxSeries, testData.maxChunkBytes))
+
+			_, err := ds[0].Push(ctx, makeWriteRequest(0, numSeries, 0))
+			require.NoError(t, err)
+
+			series, err := ds[0].QueryStream(ctx, 0, 10, mustEqualMatcher(model.MetricNameLabel, "foo"))
+			if testData.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Len(t, series.Chunkseries, numSeries)
+				return
+			}
+
+			require.Error(t, err)
+			assert.IsType(t, validation.LimitError(""), err)
+			assert.Contains(t, err.Error(), testData.expectedErr)
+		})
+	}
+}
+
+func TestDistributor_QueryStream_ShouldChargeTheChunkBytesOfEachSeriesOnceAcrossReplicas(t *testing.T) {
+	const numSeries = 10
+
+	ds, _, r, _ := prepare(t, prepConfig{
+		numIngesters:     3,
+		happyIngesters:   3,
+		numDistributors:  1,
+		shardByAllLabels: true,
+	})
+	defer stopAll(ds, r)
+
+	ctx := user.InjectOrgID(context.Background(), "user")
+	_, err := ds[0].Push(ctx, makeWriteRequest(0, numSeries, 0))
+	require.NoError(t, err)
+
+	// Each series is replicated to all ingesters, and each replica has the same chunks.
+	reqStats, statsCtx := stats.ContextWithEmptyStats(ctx)
+	series, err := ds[0].QueryStream(statsCtx, 0, 10, mustEqualMatcher(model.MetricNameLabel, "foo"))
+	require.NoError(t, err)
+	require.Len(t, series.Chunkseries, numSeries)
+
+	expectedChunkBytes := 0
+	for _, s := range series.Chunkseries {
+		require.True(t, len(s.Chunks) > 1)
+		expectedChunkBytes += len(s.Chunks[0].Data)
+	}
+	assert.Equal(t, uint64(numSeries), reqStats.LoadFetchedSeriesCount())
+	assert.Equal(t, uint64(expectedChunkBytes), reqStats.LoadFetchedChunkBytes())
+
+	// The query shouldn't fail if the limit is equal to the size of the chunks of a single replica.
+	limitCtx := limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(0, expectedChunkBytes))
+	_, err = ds[0].QueryStream(limitCtx, 0, 10, mustEqualMatcher(model.MetricNameLabel, "foo"))
+	require.NoError(t, err)
+
+	limitCtx = limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(0, expectedChunkBytes-1))
+	_, err = ds[0].QueryStream(limitCtx, 0, 10, mustEqualMatcher(model.MetricNameLabel, "foo"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the query hit the aggregated chunks size limit")
+}
+
 func TestDistributor_Push_LabelRemoval(t *testing.T) {
 	ctx = user.InjectOrgID(context.Background(), "user")
 
@@ -1139,6 +1233,153 @@ func TestDistributor_MetricsForLabelMatchers(t *testing.T) {
 	}
 }
 
+func TestDistributor_LabelNames(t *testing.T) {
+	const numIngesters = 5
+
+	fixtures := []labels.Labels{
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "200"}},
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "500"}, {Name: "reason", Value: "broken"}},
+		{{Name: labels.MetricName, Value: "test_2"}, {Name: "job", Value: "test"}},
+	}
+
+	tests := map[string]struct {
+		matchers []*labels.Matcher
+		expected []string
+	}{
+		"should return all label names if no matcher is given": {
+			expected: []string{labels.MetricName, "job", "reason", "status"},
+		},
+		"should return the label names of the series matching the matchers": {
+			matchers: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, labels.MetricName, "test_1")},
+			expected: []string{labels.MetricName, "reason", "status"},
+		},
+		"should return an empty response if no series match": {
+			matchers: []*labels.Matcher{mustNewMatcher(labels.MatchEqual, labels.MetricName, "unknown")},
+			expected: []string{},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			now := model.Now()
+
+			ds, _, r, _ := prepare(t, prepConfig{
+				numIngesters:     numIngesters,
+				happyIngesters:   numIngesters,
+				numDistributors:  1,
+				shardByAllLabels: true,
+			})
+			defer stopAll(ds, r)
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			for _, lbls := range fixtures {
+				_, err := ds[0].Push(ctx, mockWriteRequest(lbls, 1, 100000))
+				require.NoError(t, err)
+			}
+
+			names, err := ds[0].LabelNames(ctx, now, now, testData.matchers...)
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, names)
+		})
+	}
+}
+
+func TestDistributor_LabelCardinality(t *testing.T) {
+	const numIngesters = 5
+
+	fixtures := []labels.Labels{
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "200"}},
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "500"}},
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "500"}, {Name: "route", Value: "get_user"}},
+		{{Name: labels.MetricName, Value: "test_2"}, {Name: "status", Value: "500"}},
+	}
+
+	tests := map[string]struct {
+		labelNames     []string
+		limit          int
+		labelNamesOnly bool
+		matchers       []*labels.Matcher
+		happyIngesters int
+		expected       []*client.LabelCardinality
+		expectedErr    error
+	}{
+		"should return all label names if no label name is given": {
+			happyIngesters: numIngesters,
+			expected: []*client.LabelCardinality{
+				{LabelName: labels.MetricName, Values: []client.LabelValueCardinality{{LabelValue: "test_1", SeriesCount: 3}, {LabelValue: "test_2", SeriesCount: 1}}, LabelValuesCount: 2, SeriesCount: 4},
+				{LabelName: "route", Values: []client.LabelValueCardinality{{LabelValue: "get_user", SeriesCount: 1}}, LabelValuesCount: 1, SeriesCount: 1},
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "500", SeriesCount: 3}, {LabelValue: "200", SeriesCount: 1}}, LabelValuesCount: 2, SeriesCount: 4},
+			},
+		},
+		"should return only the requested label names of the series matching the matchers": {
+			labelNames:     []string{"status"},
+			matchers:       []*labels.Matcher{mustNewMatcher(labels.MatchEqual, labels.MetricName, "test_2")},
+			happyIngesters: numIngesters,
+			expected: []*client.LabelCardinality{
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "500", SeriesCount: 1}}, LabelValuesCount: 1, SeriesCount: 1},
+			},
+		},
+		"should apply the limit to the values of each label name after merging the responses of all ingesters": {
+			limit:          1,
+			happyIngesters: numIngesters,
+			expected: []*client.LabelCardinality{
+				{LabelName: labels.MetricName, Values: []client.LabelValueCardinality{{LabelValue: "test_1", SeriesCount: 3}}, LabelValuesCount: 2, SeriesCount: 4},
+				{LabelName: "route", Values: []client.LabelValueCardinality{{LabelValue: "get_user", SeriesCount: 1}}, LabelValuesCount: 1, SeriesCount: 1},
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "500", SeriesCount: 3}}, LabelValuesCount: 2, SeriesCount: 4},
+			},
+		},
+		"should return only the label names statistics if label names only are requested": {
+			labelNamesOnly: true,
+			happyIngesters: numIngesters,
+			expected: []*client.LabelCardinality{
+				{LabelName: labels.MetricName, Values: []client.LabelValueCardinality{}, LabelValuesCount: 2, SeriesCount: 4},
+				{LabelName: "status", Values: []client.LabelValueCardinality{}, LabelValuesCount: 2, SeriesCount: 4},
+				{LabelName: "route", Values: []client.LabelValueCardinality{}, LabelValuesCount: 1, SeriesCount: 1},
+			},
+		},
+		"should apply the limit to the label names after merging the responses of all ingesters if label names only are requested": {
+			limit:          2,
+			labelNamesOnly: true,
+			happyIngesters: numIngesters,
+			expected: []*client.LabelCardinality{
+				{LabelName: labels.MetricName, Values: []client.LabelValueCardinality{}, LabelValuesCount: 2, SeriesCount: 4},
+				{LabelName: "status", Values: []client.LabelValueCardinality{}, LabelValuesCount: 2, SeriesCount: 4},
+			},
+		},
+		"should fail if any ingester fails, even if a quorum of ingesters succeeded": {
+			happyIngesters: numIngesters - 1,
+			expectedErr:    errFail,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ds, _, r, _ := prepare(t, prepConfig{
+				numIngesters:     numIngesters,
+				happyIngesters:   testData.happyIngesters,
+				numDistributors:  1,
+				shardByAllLabels: true,
+			})
+			defer stopAll(ds, r)
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			for _, lbls := range fixtures {
+				_, err := ds[0].Push(ctx, mockWriteRequest(lbls, 1, 100000))
+				require.NoError(t, err)
+			}
+
+			items, err := ds[0].LabelCardinality(ctx, testData.labelNames, testData.limit, testData.labelNamesOnly, testData.matchers...)
+			if testData.expectedErr != nil {
+				require.Equal(t, testData.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, items)
+		})
+	}
+}
+
 func TestDistributor_MetricsMetadata(t *testing.T) {
 	const numIngesters = 5
 
@@ -1643,6 +1884,85 @@ func (i *mockIngester) MetricsForLabelMatchers(ctx context.Context, req *client.
 	return &response, nil
 }
 
+func (i *mockIngester) LabelNames(ctx context.Context, req *client.LabelNamesRequest, opts ...grpc.CallOption) (*client.LabelNamesResponse, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("LabelNames")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	_, _, matchers, err := client.FromLabelNamesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]struct{}{}
+	for _, ts := range i.timeseries {
+		if match(ts.Labels, matchers) {
+			for _, l := range ts.Labels {
+				names[l.Name] = struct{}{}
+			}
+		}
+	}
+
+	response := &client.LabelNamesResponse{}
+	for name := range names {
+		response.LabelNames = append(response.LabelNames, name)
+	}
+	sort.Strings(response.LabelNames)
+	return response, nil
+}
+
+func (i *mockIngester) LabelCardinality(ctx context.Context, req *client.LabelCardinalityRequest, opts ...grpc.CallOption) (*client.LabelCardinalityResponse, error) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.trackCall("LabelCardinality")
+
+	if !i.happy {
+		return nil, errFail
+	}
+
+	labelNames, matchers, err := client.FromLabelCardinalityRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]map[string]uint64{}
+	for _, ts := range i.timeseries {
+		if !match(ts.Labels, matchers) {
+			continue
+		}
+
+		for _, l := range ts.Labels {
+			if len(labelNames) > 0 && !util.StringsContain(labelNames, l.Name) {
+				continue
+			}
+			if counts[l.Name] == nil {
+				counts[l.Name] = map[string]uint64{}
+			}
+			counts[l.Name][l.Value]++
+		}
+	}
+
+	response := &client.LabelCardinalityResponse{}
+	for name, values := range counts {
+		item := &client.LabelCardinality{LabelName: name, LabelValuesCount: uint64(len(values))}
+		for value, count := range values {
+			item.SeriesCount += count
+			if req.LabelNamesOnly {
+				count = 0
+			}
+			item.Values = append(item.Values, client.LabelValueCardinality{LabelValue: value, SeriesCount: count})
+		}
+		response.Items = append(response.Items, item)
+	}
+	return response, nil
+}
+
 func (i *mockIngester) MetricsMetadata(ctx context.Context, req *client.MetricsMetadataRequest, opts ...grpc.CallOption) (*client.MetricsMetadataResponse, error) {
 	i.Lock()
 	defer i.Unlock()
@@ -20,6 +20,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
+	"github.com/cortexproject/cortex/pkg/ring/kv/memberlist"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/services"
 )
@@ -45,6 +46,67 @@ func NewReplicaDesc() *ReplicaDesc {
 	return &ReplicaDesc{}
 }
 
+// Merge merges the other ReplicaDesc into this one. The conflict resolution is deterministic,
+// regardless of the order changes are received: the most recent election (by received-at
+// timestamp) wins, ties are broken by the replica name and, finally, an election marked
+// for deletion wins over the same election not marked for deletion. The received-at
+// timestamp of a new election is always greater than the one of the deletion tombstone,
+// so a cleaned-up cluster is brought back as soon as a new sample is received for it.
+//
+// This method is part of memberlist.Mergeable interface, and is only used by gossiping HA tracker.
+func (r *ReplicaDesc) Merge(mergeable memberlist.Mergeable, _ bool) (memberlist.Mergeable, error) {
+	if mergeable == nil {
+		return nil, nil
+	}
+
+	other, ok := mergeable.(*ReplicaDesc)
+	if !ok {
+		return nil, fmt.Errorf("expected *distributor.ReplicaDesc, got %T", mergeable)
+	}
+
+	if other == nil || !other.supersedes(r) {
+		return nil, nil
+	}
+
+	*r = *other
+
+	change := *other
+	return &change, nil
+}
+
+// supersedes returns whether r wins over the other ReplicaDesc when merged.
+func (r *ReplicaDesc) supersedes(other *ReplicaDesc) bool {
+	if r.ReceivedAt != other.ReceivedAt {
+		return r.ReceivedAt > other.ReceivedAt
+	}
+	if r.Replica != other.Replica {
+		return r.Replica > other.Replica
+	}
+	return r.DeletedAt > other.DeletedAt
+}
+
+// MergeContent describes content of this Mergeable.
+// ReplicaDesc simply returns the elected replica.
+func (r *ReplicaDesc) MergeContent() []string {
+	return []string{r.Replica}
+}
+
+// RemoveTombstones clears the ReplicaDesc if it has been marked for deletion before limit, so
+// that the gossiping KV store can remove it (see Expired). The ReplicaDesc marked for deletion is
+// itself the tombstone, and the HA tracker needs to see it in order to remove the elected replica
+// from memory, so a zero limit (used by the KV store to hide the tombstones from the clients) is ignored.
+func (r *ReplicaDesc) RemoveTombstones(limit time.Time) {
+	if r.DeletedAt > 0 && !limit.IsZero() && timestamp.Time(r.DeletedAt).Before(limit) {
+		*r = ReplicaDesc{}
+	}
+}
+
+// Expired returns whether the ReplicaDesc has been cleared by RemoveTombstones.
+// This method is part of memberlist.Expirable interface.
+func (r *ReplicaDesc) Expired() bool {
+	return r.Replica == "" && r.ReceivedAt == 0
+}
+
 // HATrackerConfig contains the configuration require to
 // create a HA Tracker.
 type HATrackerConfig struct {
@@ -60,7 +122,7 @@ type HATrackerConfig struct {
 	// more than this duration
 	FailoverTimeout time.Duration `yaml:"ha_tracker_failover_timeout"`
 
-	KVStore kv.Config `yaml:"kvstore" doc:"description=Backend storage to use for the ring. Please be aware that memberlist is not supported by the HA tracker since gossip propagation is too slow for HA purposes."`
+	KVStore kv.Config `yaml:"kvstore" doc:"description=Backend storage to use for the ring. Please be aware that when using memberlist, changes to the elected replicas are propagated via gossip, so distributors may briefly disagree on the elected replica after a failover."`
 }
 
 // RegisterFlags adds the flags required to config this to the given FlagSet.
@@ -310,6 +372,12 @@ func (c *haTracker) cleanupOldReplicas(ctx context.Context, deadline time.Time)
 			// while distributors *without* replica in memory will try to write it to KV store -- which will update *all*
 			// watching distributors.
 			err = c.client.Delete(ctx, key)
+			if errors.Is(err, memberlist.ErrDeleteNotSupported) {
+				// The KV store (or the primary one, when using multi KV) is memberlist, which
+				// doesn't support deleting keys. The tombstone is removed by the KV store itself
+				// once expired.
+				continue
+			}
 			if err != nil {
 				level.Error(c.logger).Log("msg", "cleanup: failed to delete old replica", "key", key, "err", err)
 				c.markingOrDeletionsFailed.Inc()
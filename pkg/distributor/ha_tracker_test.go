@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -19,7 +20,10 @@ import (
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
+	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
+	"github.com/cortexproject/cortex/pkg/ring/kv/memberlist"
+	"github.com/cortexproject/cortex/pkg/ring/kv/redis"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
@@ -140,6 +144,37 @@ func TestWatchPrefixAssignment(t *testing.T) {
 	checkReplicaTimestamp(t, time.Second, c, "user", cluster, replica, now)
 }
 
+// Test that the HATracker works on top of the Redis KV store.
+func TestWatchPrefixAssignment_Redis(t *testing.T) {
+	cluster := "c1"
+	replica := "r1"
+
+	codec := GetReplicaDescCodec()
+	redisClient, closer, err := redis.Mock(codec)
+	require.NoError(t, err)
+	defer closer.Close() //nolint:errcheck
+
+	c, err := newHATracker(HATrackerConfig{
+		EnableHATracker:        true,
+		KVStore:                kv.Config{Mock: kv.PrefixClient(redisClient, "prefix/")},
+		UpdateTimeout:          time.Millisecond,
+		UpdateTimeoutJitterMax: 0,
+		FailoverTimeout:        time.Millisecond * 2,
+	}, trackerLimits{maxClusters: 100}, nil, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), c))
+	defer services.StopAndAwaitTerminated(context.Background(), c) //nolint:errcheck
+
+	// Write the first time.
+	now := time.Now()
+
+	err = c.checkReplica(context.Background(), "user", cluster, replica, now)
+	assert.NoError(t, err)
+
+	// Check to see if the value in the trackers cache is correct.
+	checkReplicaTimestamp(t, time.Second, c, "user", cluster, replica, now)
+}
+
 func TestCheckReplicaOverwriteTimeout(t *testing.T) {
 	replica1 := "replica1"
 	replica2 := "replica2"
@@ -723,6 +758,274 @@ func TestCheckReplicaCleanup(t *testing.T) {
 	))
 }
 
+func TestCheckReplicaCleanup_Memberlist(t *testing.T) {
+	replica := "r1"
+	cluster := "c1"
+	user := "user"
+
+	reg := prometheus.NewPedanticRegistry()
+
+	mkv := memberlist.NewKV(memberlist.KVConfig{
+		TCPTransport: memberlist.TCPTransportConfig{
+			BindAddrs: []string{"localhost"},
+		},
+		Codecs: []codec.Codec{GetReplicaDescCodec()},
+	}, log.NewNopLogger())
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), mkv))
+	defer services.StopAndAwaitTerminated(context.Background(), mkv) //nolint:errcheck
+
+	c, err := newHATracker(HATrackerConfig{
+		EnableHATracker: true,
+		KVStore: kv.Config{
+			Store: "memberlist",
+			StoreConfig: kv.StoreConfig{
+				MemberlistKV: func() (*memberlist.KV, error) { return mkv, nil },
+			},
+		},
+		UpdateTimeout:          1 * time.Second,
+		UpdateTimeoutJitterMax: 0,
+		FailoverTimeout:        time.Second,
+	}, trackerLimits{maxClusters: 100}, reg, util_log.Logger)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), c))
+	defer services.StopAndAwaitTerminated(context.Background(), c) //nolint:errcheck
+
+	now := time.Now()
+
+	err = c.checkReplica(context.Background(), user, cluster, replica, now)
+	assert.NoError(t, err)
+	checkReplicaTimestamp(t, time.Second, c, user, cluster, replica, now)
+	checkReplicaDeletionState(t, time.Second, c, user, cluster, true, true, false)
+
+	// This will mark replica for deletion (with time.Now())
+	c.cleanupOldReplicas(ctx, now.Add(1*time.Second))
+	checkReplicaDeletionState(t, time.Second, c, user, cluster, false, true, true)
+
+	// This will "revive" the replica, because the new election wins over the tombstone.
+	now = time.Now()
+	err = c.checkReplica(context.Background(), user, cluster, replica, now)
+	assert.NoError(t, err)
+	checkReplicaTimestamp(t, time.Second, c, user, cluster, replica, now)
+
+	// Mark the replica for deletion again. Memberlist doesn't support deletion and the tombstones
+	// expiry is disabled in the KV store, so the tombstone is kept.
+	c.cleanupOldReplicas(ctx, now.Add(1*time.Second))
+	checkReplicaDeletionState(t, time.Second, c, user, cluster, false, true, true)
+
+	c.cleanupOldReplicas(ctx, time.Now().Add(5*time.Second))
+	checkReplicaDeletionState(t, time.Second, c, user, cluster, false, true, true)
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_ha_tracker_replicas_cleanup_marked_for_deletion_total Number of elected replicas marked for deletion.
+		# TYPE cortex_ha_tracker_replicas_cleanup_marked_for_deletion_total counter
+		cortex_ha_tracker_replicas_cleanup_marked_for_deletion_total 2
+
+		# HELP cortex_ha_tracker_replicas_cleanup_deleted_total Number of elected replicas deleted from KV store.
+		# TYPE cortex_ha_tracker_replicas_cleanup_deleted_total counter
+		cortex_ha_tracker_replicas_cleanup_deleted_total 0
+
+		# HELP cortex_ha_tracker_replicas_cleanup_delete_failed_total Number of elected replicas that failed to be marked for deletion, or deleted.
+		# TYPE cortex_ha_tracker_replicas_cleanup_delete_failed_total counter
+		cortex_ha_tracker_replicas_cleanup_delete_failed_total 0
+	`), "cortex_ha_tracker_replicas_cleanup_marked_for_deletion_total",
+		"cortex_ha_tracker_replicas_cleanup_deleted_total",
+		"cortex_ha_tracker_replicas_cleanup_delete_failed_total",
+	))
+}
+
+func TestCheckReplicaCleanup_MemberlistTombstonesExpiry(t *testing.T) {
+	replica := "r1"
+	cluster := "c1"
+	user := "user"
+
+	mkv := memberlist.NewKV(memberlist.KVConfig{
+		TCPTransport: memberlist.TCPTransportConfig{
+			BindAddrs: []string{"localhost"},
+		},
+		LeftIngestersTimeout: 100 * time.Millisecond,
+		Codecs:               []codec.Codec{GetReplicaDescCodec()},
+	}, log.NewNopLogger())
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), mkv))
+	defer services.StopAndAwaitTerminated(context.Background(), mkv) //nolint:errcheck
+
+	c, err := newHATracker(HATrackerConfig{
+		EnableHATracker: true,
+		KVStore: kv.Config{
+			Store: "memberlist",
+			StoreConfig: kv.StoreConfig{
+				MemberlistKV: func() (*memberlist.KV, error) { return mkv, nil },
+			},
+		},
+		UpdateTimeout:          1 * time.Second,
+		UpdateTimeoutJitterMax: 0,
+		FailoverTimeout:        time.Second,
+	}, trackerLimits{maxClusters: 100}, prometheus.NewPedanticRegistry(), util_log.Logger)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), c))
+	defer services.StopAndAwaitTerminated(context.Background(), c) //nolint:errcheck
+
+	now := time.Now()
+
+	err = c.checkReplica(context.Background(), user, cluster, replica, now)
+	assert.NoError(t, err)
+	checkReplicaTimestamp(t, time.Second, c, user, cluster, replica, now)
+
+	// Mark the replica for deletion. The tombstone is removed from the KV store once expired.
+	c.cleanupOldReplicas(ctx, now.Add(1*time.Second))
+	test.Poll(t, 5*time.Second, []string(nil), func() interface{} {
+		keys, err := c.client.List(context.Background(), "")
+		require.NoError(t, err)
+		return keys
+	})
+	checkReplicaDeletionState(t, time.Second, c, user, cluster, false, false, false)
+
+	// A new sample for the cluster elects the replica again.
+	now = time.Now()
+	err = c.checkReplica(context.Background(), user, cluster, replica, now)
+	assert.NoError(t, err)
+	checkReplicaTimestamp(t, time.Second, c, user, cluster, replica, now)
+}
+
+func TestReplicaDesc_RemoveTombstones(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		desc     *ReplicaDesc
+		limit    time.Time
+		expected *ReplicaDesc
+		expired  bool
+	}{
+		"not marked for deletion": {
+			desc:     &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour))},
+			limit:    now,
+			expected: &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour))},
+		},
+		"marked for deletion after the limit": {
+			desc:     &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour)), DeletedAt: timestamp.FromTime(now)},
+			limit:    now.Add(-time.Minute),
+			expected: &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour)), DeletedAt: timestamp.FromTime(now)},
+		},
+		"marked for deletion before the limit": {
+			desc:     &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour)), DeletedAt: timestamp.FromTime(now.Add(-time.Minute))},
+			limit:    now,
+			expected: &ReplicaDesc{},
+			expired:  true,
+		},
+		"zero limit is ignored": {
+			desc:     &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour)), DeletedAt: timestamp.FromTime(now.Add(-time.Minute))},
+			limit:    time.Time{},
+			expected: &ReplicaDesc{Replica: "r1", ReceivedAt: timestamp.FromTime(now.Add(-time.Hour)), DeletedAt: timestamp.FromTime(now.Add(-time.Minute))},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testData.desc.RemoveTombstones(testData.limit)
+			assert.Equal(t, testData.expected, testData.desc)
+			assert.Equal(t, testData.expired, testData.desc.Expired())
+		})
+	}
+}
+
+func TestReplicaDesc_Merge(t *testing.T) {
+	tests := map[string]struct {
+		local          *ReplicaDesc
+		incoming       *ReplicaDesc
+		expectedResult *ReplicaDesc
+		expectedChange *ReplicaDesc
+	}{
+		"incoming more recent election": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			incoming:       &ReplicaDesc{Replica: "r2", ReceivedAt: 2000},
+			expectedResult: &ReplicaDesc{Replica: "r2", ReceivedAt: 2000},
+			expectedChange: &ReplicaDesc{Replica: "r2", ReceivedAt: 2000},
+		},
+		"incoming older election": {
+			local:          &ReplicaDesc{Replica: "r2", ReceivedAt: 2000},
+			incoming:       &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			expectedResult: &ReplicaDesc{Replica: "r2", ReceivedAt: 2000},
+			expectedChange: nil,
+		},
+		"same received-at timestamp, greater replica name wins": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			incoming:       &ReplicaDesc{Replica: "r2", ReceivedAt: 1000},
+			expectedResult: &ReplicaDesc{Replica: "r2", ReceivedAt: 1000},
+			expectedChange: &ReplicaDesc{Replica: "r2", ReceivedAt: 1000},
+		},
+		"same received-at timestamp, lower replica name loses": {
+			local:          &ReplicaDesc{Replica: "r2", ReceivedAt: 1000},
+			incoming:       &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			expectedResult: &ReplicaDesc{Replica: "r2", ReceivedAt: 1000},
+			expectedChange: nil,
+		},
+		"same election, incoming marked for deletion": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			incoming:       &ReplicaDesc{Replica: "r1", ReceivedAt: 1000, DeletedAt: 3000},
+			expectedResult: &ReplicaDesc{Replica: "r1", ReceivedAt: 1000, DeletedAt: 3000},
+			expectedChange: &ReplicaDesc{Replica: "r1", ReceivedAt: 1000, DeletedAt: 3000},
+		},
+		"same election, local marked for deletion": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000, DeletedAt: 3000},
+			incoming:       &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			expectedResult: &ReplicaDesc{Replica: "r1", ReceivedAt: 1000, DeletedAt: 3000},
+			expectedChange: nil,
+		},
+		"new election wins over the tombstone": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000, DeletedAt: 3000},
+			incoming:       &ReplicaDesc{Replica: "r1", ReceivedAt: 4000},
+			expectedResult: &ReplicaDesc{Replica: "r1", ReceivedAt: 4000},
+			expectedChange: &ReplicaDesc{Replica: "r1", ReceivedAt: 4000},
+		},
+		"same value": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			incoming:       &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			expectedResult: &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			expectedChange: nil,
+		},
+		"incoming nil": {
+			local:          &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			incoming:       nil,
+			expectedResult: &ReplicaDesc{Replica: "r1", ReceivedAt: 1000},
+			expectedChange: nil,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var incoming memberlist.Mergeable
+			if testData.incoming != nil {
+				incoming = proto.Clone(testData.incoming).(*ReplicaDesc)
+			}
+
+			local := proto.Clone(testData.local).(*ReplicaDesc)
+			change, err := local.Merge(incoming, false)
+			require.NoError(t, err)
+			assert.Equal(t, testData.expectedResult, local)
+
+			if testData.expectedChange == nil {
+				assert.Nil(t, change)
+			} else {
+				assert.Equal(t, testData.expectedChange, change)
+			}
+
+			// Merging the other way around must lead to the same result.
+			if testData.incoming != nil {
+				reversed := proto.Clone(testData.incoming).(*ReplicaDesc)
+				_, err := reversed.Merge(proto.Clone(testData.local).(*ReplicaDesc), false)
+				require.NoError(t, err)
+				assert.Equal(t, testData.expectedResult, reversed)
+			}
+
+			// Merging the same value again must not lead to any change.
+			if incoming != nil {
+				change, err = local.Merge(incoming, false)
+				require.NoError(t, err)
+				assert.Nil(t, change)
+			}
+		})
+	}
+}
+
 func checkReplicaDeletionState(t *testing.T, duration time.Duration, c *haTracker, user, cluster string, expectedExistsInMemory, expectedExistsInKV, expectedMarkedForDeletion bool) {
 	key := fmt.Sprintf("%s/%s", user, cluster)
 
@@ -3,6 +3,8 @@ package distributor
 import (
 	"context"
 	"io"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
@@ -10,12 +12,15 @@ import (
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/weaveworks/common/instrument"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	ingester_client "github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/extract"
 	grpc_util "github.com/cortexproject/cortex/pkg/util/grpc"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 )
 
 // Query multiple ingesters and returns a Matrix of samples.
@@ -72,6 +77,35 @@ func (d *Distributor) QueryStream(ctx context.Context, from, to model.Time, matc
 	return result, err
 }
 
+// QueryExemplars queries the ingesters for exemplars of the series matching any of the input matchers sets.
+func (d *Distributor) QueryExemplars(ctx context.Context, from, to model.Time, matchers ...[]*labels.Matcher) (*ingester_client.ExemplarQueryResponse, error) {
+	var result *ingester_client.ExemplarQueryResponse
+	err := instrument.CollectedRequest(ctx, "Distributor.QueryExemplars", d.queryDuration, instrument.ErrorCode, func(ctx context.Context) error {
+		req, err := ingester_client.ToExemplarQueryRequest(from, to, matchers...)
+		if err != nil {
+			return err
+		}
+
+		// Exemplars may be matched by multiple selectors, so we need to query
+		// all the ingesters holding the tenant's series.
+		replicationSet, err := d.GetIngestersForMetadata(ctx)
+		if err != nil {
+			return err
+		}
+
+		result, err = d.queryIngestersExemplars(ctx, replicationSet, req)
+		if err != nil {
+			return err
+		}
+
+		if s := opentracing.SpanFromContext(ctx); s != nil {
+			s.LogKV("series", len(result.Timeseries))
+		}
+		return nil
+	})
+	return result, err
+}
+
 // GetIngestersForQuery returns a replication set including all ingesters that should be queried
 // to fetch series matching input label matchers.
 func (d *Distributor) GetIngestersForQuery(ctx context.Context, matchers ...*labels.Matcher) (ring.ReplicationSet, error) {
@@ -111,6 +145,11 @@ func (d *Distributor) GetIngestersForMetadata(ctx context.Context) (ring.Replica
 		return ring.ReplicationSet{}, err
 	}
 
+	return d.getIngestersRingForMetadata(userID).GetReplicationSetForOperation(ring.Read)
+}
+
+// getIngestersRingForMetadata returns the ring of the ingesters to query for the tenant's metadata.
+func (d *Distributor) getIngestersRingForMetadata(userID string) ring.ReadRing {
 	// If shuffle sharding is enabled we should only query ingesters which are
 	// part of the tenant's subring.
 	if d.cfg.ShardingStrategy == util.ShardingStrategyShuffle {
@@ -118,11 +157,11 @@ func (d *Distributor) GetIngestersForMetadata(ctx context.Context) (ring.Replica
 		lookbackPeriod := d.cfg.ShuffleShardingLookbackPeriod
 
 		if shardSize > 0 && lookbackPeriod > 0 {
-			return d.ingestersRing.ShuffleShardWithLookback(userID, shardSize, lookbackPeriod, time.Now()).GetReplicationSetForOperation(ring.Read)
+			return d.ingestersRing.ShuffleShardWithLookback(userID, shardSize, lookbackPeriod, time.Now())
 		}
 	}
 
-	return d.ingestersRing.GetReplicationSetForOperation(ring.Read)
+	return d.ingestersRing
 }
 
 // queryIngesters queries the ingesters via the older, sample-based API.
@@ -137,11 +176,13 @@ func (d *Distributor) queryIngesters(ctx context.Context, replicationSet ring.Re
 
 		resp, err := client.(ingester_client.IngesterClient).Query(ctx, req)
 		d.ingesterQueries.WithLabelValues(ing.Addr).Inc()
+		stats.FromContext(ctx).AddIngesterRequests(1)
 		if err != nil {
 			d.ingesterQueryFailures.WithLabelValues(ing.Addr).Inc()
 			return nil, err
 		}
 
+		stats.FromContext(ctx).AddFetchedSeriesCount(uint64(len(resp.Timeseries)))
 		return ingester_client.FromQueryResponse(resp), nil
 	})
 	if err != nil {
@@ -173,6 +214,11 @@ func (d *Distributor) queryIngesters(ctx context.Context, replicationSet ring.Re
 
 // queryIngesterStream queries the ingesters using the new streaming API.
 func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ring.ReplicationSet, req *ingester_client.QueryRequest) (*ingester_client.QueryStreamResponse, error) {
+	var (
+		queryLimiter = limiter.QueryLimiterFromContextWithFallback(ctx)
+		chunksSizes  = newReplicatedChunksSizes()
+	)
+
 	// Fetch samples from multiple ingesters
 	results, err := replicationSet.Do(ctx, d.cfg.ExtraQueryDelay, func(ctx context.Context, ing *ring.InstanceDesc) (interface{}, error) {
 		client, err := d.ingesterPool.GetClientFor(ing.Addr)
@@ -180,6 +226,7 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 			return nil, err
 		}
 		d.ingesterQueries.WithLabelValues(ing.Addr).Inc()
+		stats.FromContext(ctx).AddIngesterRequests(1)
 
 		stream, err := client.(ingester_client.IngesterClient).QueryStream(ctx, req)
 		if err != nil {
@@ -189,6 +236,7 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 		defer stream.CloseSend() //nolint:errcheck
 
 		result := &ingester_client.QueryStreamResponse{}
+		fetchedChunksSizes := map[string]int{}
 		for {
 			resp, err := stream.Recv()
 			if err == io.EOF {
@@ -202,9 +250,31 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 				return nil, err
 			}
 
+			// Enforce the per-query limits, which are shared with all the other ingesters and
+			// store-gateways queried by the same query. The chunks of a series are fetched from
+			// each of its replicas, but only the biggest replica is charged to the query.
+			for _, series := range resp.Chunkseries {
+				seriesLabels := cortexpb.FromLabelAdaptersToLabels(series.Labels)
+				if limitErr := queryLimiter.AddSeries(seriesLabels); limitErr != nil {
+					return nil, limitErr
+				}
+
+				key := ingester_client.LabelsToKeyString(seriesLabels)
+				fetchedChunksSizes[key] += chunksSize([]ingester_client.TimeSeriesChunk{series})
+				if limitErr := queryLimiter.AddChunkBytes(chunksSizes.add(key, fetchedChunksSizes[key])); limitErr != nil {
+					return nil, limitErr
+				}
+			}
+			for _, series := range resp.Timeseries {
+				if limitErr := queryLimiter.AddSeries(cortexpb.FromLabelAdaptersToLabels(series.Labels)); limitErr != nil {
+					return nil, limitErr
+				}
+			}
+
 			result.Chunkseries = append(result.Chunkseries, resp.Chunkseries...)
 			result.Timeseries = append(result.Timeseries, resp.Timeseries...)
 		}
+
 		return result, nil
 	})
 	if err != nil {
@@ -251,9 +321,130 @@ func (d *Distributor) queryIngesterStream(ctx context.Context, replicationSet ri
 		resp.Timeseries = append(resp.Timeseries, series)
 	}
 
+	// Track the fetched series and chunk bytes once the responses of the replicas have been merged.
+	reqStats := stats.FromContext(ctx)
+	reqStats.AddFetchedSeriesCount(uint64(len(resp.Chunkseries) + len(resp.Timeseries)))
+	reqStats.AddFetchedChunkBytes(uint64(chunksSizes.total()))
+
 	return resp, nil
 }
 
+// replicatedChunksSizes tracks the size of the chunks fetched for each series from its
+// replicas, counting only the biggest replica of each series.
+type replicatedChunksSizes struct {
+	mtx       sync.Mutex
+	sizes     map[string]int
+	totalSize int
+}
+
+func newReplicatedChunksSizes() *replicatedChunksSizes {
+	return &replicatedChunksSizes{sizes: map[string]int{}}
+}
+
+// add records the size of the chunks fetched so far for the series from one of its
+// replicas, and returns how much the counted size of the series has grown.
+func (r *replicatedChunksSizes) add(key string, size int) int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	grown := size - r.sizes[key]
+	if grown <= 0 {
+		return 0
+	}
+
+	r.sizes[key] = size
+	r.totalSize += grown
+	return grown
+}
+
+// total returns the size of the chunks fetched for all series, counting each series once.
+func (r *replicatedChunksSizes) total() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.totalSize
+}
+
+// queryIngestersExemplars queries the ingesters for exemplars.
+func (d *Distributor) queryIngestersExemplars(ctx context.Context, replicationSet ring.ReplicationSet, req *ingester_client.ExemplarQueryRequest) (*ingester_client.ExemplarQueryResponse, error) {
+	// Fetch exemplars from multiple ingesters in parallel, using the replicationSet
+	// to deal with consistency.
+	results, err := replicationSet.Do(ctx, d.cfg.ExtraQueryDelay, func(ctx context.Context, ing *ring.InstanceDesc) (interface{}, error) {
+		client, err := d.ingesterPool.GetClientFor(ing.Addr)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.(ingester_client.IngesterClient).QueryExemplars(ctx, req)
+		d.ingesterQueries.WithLabelValues(ing.Addr).Inc()
+		if err != nil {
+			d.ingesterQueryFailures.WithLabelValues(ing.Addr).Inc()
+			return nil, err
+		}
+
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeExemplarQueryResponses(results), nil
+}
+
+func mergeExemplarQueryResponses(results []interface{}) *ingester_client.ExemplarQueryResponse {
+	var keys []string
+	exemplarResults := make(map[string]ingester_client.TimeSeries)
+	for _, result := range results {
+		r := result.(*ingester_client.ExemplarQueryResponse)
+		for _, ts := range r.Timeseries {
+			lbls := ingester_client.LabelsToKeyString(ingester_client.FromLabelAdaptersToLabels(ts.Labels))
+			e, ok := exemplarResults[lbls]
+			if !ok {
+				exemplarResults[lbls] = ts
+				keys = append(keys, lbls)
+				continue
+			}
+
+			// Merge in any missing values from another ingesters exemplars for this series.
+			e.Exemplars = mergeExemplars(e.Exemplars, ts.Exemplars)
+			exemplarResults[lbls] = e
+		}
+	}
+
+	// Return a result sorted by series labels, to have a stable output.
+	sort.Strings(keys)
+
+	result := make([]ingester_client.TimeSeries, len(exemplarResults))
+	for i, k := range keys {
+		result[i] = exemplarResults[k]
+	}
+
+	return &ingester_client.ExemplarQueryResponse{Timeseries: result}
+}
+
+// Merges and dedupes two sorted slices with exemplars together.
+func mergeExemplars(a, b []cortexpb.Exemplar) []cortexpb.Exemplar {
+	result := make([]cortexpb.Exemplar, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i].TimestampMs < b[j].TimestampMs {
+			result = append(result, a[i])
+			i++
+		} else if a[i].TimestampMs > b[j].TimestampMs {
+			result = append(result, b[j])
+			j++
+		} else {
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	// Add the rest of a or b. One of them is empty now.
+	result = append(result, a[i:]...)
+	result = append(result, b[j:]...)
+	return result
+}
+
 // Merges and dedupes two sorted slices with samples together.
 func mergeSamples(a, b []ingester_client.Sample) []ingester_client.Sample {
 	if sameSamples(a, b) {
@@ -293,3 +484,13 @@ func sameSamples(a, b []ingester_client.Sample) bool {
 	}
 	return true
 }
+
+// chunksSize returns the size in bytes of the chunks data of the input series.
+func chunksSize(series []ingester_client.TimeSeriesChunk) (size int) {
+	for _, s := range series {
+		for _, c := range s.Chunks {
+			size += len(c.Data)
+		}
+	}
+	return size
+}
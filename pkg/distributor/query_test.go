@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	ingester_client "github.com/cortexproject/cortex/pkg/ingester/client"
 )
 
@@ -106,3 +107,23 @@ func TestMergeSamplesIntoFirstNilB(t *testing.T) {
 
 	require.Equal(t, b, a)
 }
+
+func TestMergeExemplarQueryResponses(t *testing.T) {
+	first := []cortexpb.LabelAdapter{{Name: "__name__", Value: "first"}}
+	second := []cortexpb.LabelAdapter{{Name: "__name__", Value: "second"}}
+
+	responses := []interface{}{
+		&ingester_client.ExemplarQueryResponse{Timeseries: []cortexpb.TimeSeries{
+			{Labels: second, Exemplars: []cortexpb.Exemplar{{Value: 1, TimestampMs: 10}, {Value: 2, TimestampMs: 20}}},
+		}},
+		&ingester_client.ExemplarQueryResponse{Timeseries: []cortexpb.TimeSeries{
+			{Labels: first, Exemplars: []cortexpb.Exemplar{{Value: 3, TimestampMs: 30}}},
+			{Labels: second, Exemplars: []cortexpb.Exemplar{{Value: 2, TimestampMs: 20}, {Value: 4, TimestampMs: 40}}},
+		}},
+	}
+
+	require.Equal(t, &ingester_client.ExemplarQueryResponse{Timeseries: []cortexpb.TimeSeries{
+		{Labels: first, Exemplars: []cortexpb.Exemplar{{Value: 3, TimestampMs: 30}}},
+		{Labels: second, Exemplars: []cortexpb.Exemplar{{Value: 1, TimestampMs: 10}, {Value: 2, TimestampMs: 20}, {Value: 4, TimestampMs: 40}}},
+	}}, mergeExemplarQueryResponses(responses))
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/weaveworks/common/httpgrpc/server"
 
 	querier_stats "github.com/cortexproject/cortex/pkg/querier/stats"
+	"github.com/cortexproject/cortex/pkg/scheduler/queue"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
@@ -30,6 +31,7 @@ const (
 	// StatusClientClosedRequest is the status code for when a client request cancellation of an http request
 	StatusClientClosedRequest = 499
 	ServiceTimingHeaderName   = "Server-Timing"
+	QueryPriorityHeaderName   = "X-Cortex-Query-Priority"
 )
 
 var (
@@ -43,12 +45,15 @@ type HandlerConfig struct {
 	LogQueriesLongerThan time.Duration `yaml:"log_queries_longer_than"`
 	MaxBodySize          int64         `yaml:"max_body_size"`
 	QueryStatsEnabled    bool          `yaml:"query_stats_enabled"`
+
+	QueryPriorityHeaderEnabled bool `yaml:"query_priority_header_enabled"`
 }
 
 func (cfg *HandlerConfig) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.LogQueriesLongerThan, "frontend.log-queries-longer-than", 0, "Log queries that are slower than the specified duration. Set to 0 to disable. Set to < 0 to enable on all queries.")
 	f.Int64Var(&cfg.MaxBodySize, "frontend.max-body-size", 10*1024*1024, "Max body size for downstream prometheus.")
-	f.BoolVar(&cfg.QueryStatsEnabled, "frontend.query-stats-enabled", false, "True to enable query statistics tracking. When enabled, a message with some statistics is logged for every query.")
+	f.BoolVar(&cfg.QueryStatsEnabled, "frontend.query-stats-enabled", false, "True to enable query statistics tracking. When enabled, a message with some statistics is logged for every query and the statistics are returned in the Server-Timing response header.")
+	f.BoolVar(&cfg.QueryPriorityHeaderEnabled, "frontend.query-priority-header-enabled", false, "True to honor the priority of the queries in the tenant queue set by the client in the "+QueryPriorityHeaderName+" request header. When set, the header takes precedence over the query priority rules. The priority must be either 0 or one of the priorities of the tenant query priority rules, otherwise the request is rejected.")
 }
 
 // Handler accepts queries and forwards them to RoundTripper. It can log slow queries,
@@ -57,18 +62,37 @@ type Handler struct {
 	cfg          HandlerConfig
 	log          log.Logger
 	roundTripper http.RoundTripper
+	queryRules   QueryRulesProvider
 
 	// Metrics.
-	querySeconds *prometheus.CounterVec
-	activeUsers  *util.ActiveUsersCleanupService
+	querySeconds     *prometheus.CounterVec
+	querySeries      *prometheus.CounterVec
+	queryBytes       *prometheus.CounterVec
+	blockedQueries   *prometheus.CounterVec
+	rewrittenQueries *prometheus.CounterVec
+	activeUsers      *util.ActiveUsersCleanupService
 }
 
-// NewHandler creates a new frontend handler.
-func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, log log.Logger, reg prometheus.Registerer) http.Handler {
+// NewHandler creates a new frontend handler. The queries are blocked or rewritten according
+// to the per-tenant query rules, if a QueryRulesProvider is given.
+func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, queryRules QueryRulesProvider, log log.Logger, reg prometheus.Registerer) http.Handler {
 	h := &Handler{
 		cfg:          cfg,
 		log:          log,
 		roundTripper: roundTripper,
+		queryRules:   queryRules,
+	}
+
+	if queryRules != nil {
+		h.blockedQueries = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_frontend_blocked_queries_total",
+			Help: "Total number of queries blocked by the query rules, per tenant and pattern.",
+		}, []string{"user", "pattern"})
+
+		h.rewrittenQueries = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_frontend_rewritten_queries_total",
+			Help: "Total number of queries rewritten by the query rules, per tenant and pattern.",
+		}, []string{"user", "pattern"})
 	}
 
 	if cfg.QueryStatsEnabled {
@@ -77,8 +101,20 @@ func NewHandler(cfg HandlerConfig, roundTripper http.RoundTripper, log log.Logge
 			Help: "Total amount of wall clock time spend processing queries.",
 		}, []string{"user"})
 
+		h.querySeries = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_fetched_series_total",
+			Help: "Number of series fetched to execute a query.",
+		}, []string{"user"})
+
+		h.queryBytes = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_query_fetched_chunks_bytes_total",
+			Help: "Size of all chunks fetched to execute a query in bytes.",
+		}, []string{"user"})
+
 		h.activeUsers = util.NewActiveUsersCleanupWithDefaultValues(func(user string) {
 			h.querySeconds.DeleteLabelValues(user)
+			h.querySeries.DeleteLabelValues(user)
+			h.queryBytes.DeleteLabelValues(user)
 		})
 		// If cleaner stops or fail, we will simply not clean the metrics for inactive users.
 		_ = h.activeUsers.StartAsync(context.Background())
@@ -108,6 +144,32 @@ func (f *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Buffer the body for later use to track slow queries.
 	var buf bytes.Buffer
 	r.Body = http.MaxBytesReader(w, r.Body, f.cfg.MaxBodySize)
+
+	priority := 0
+	if f.queryRules != nil {
+		var err error
+		if priority, err = f.applyQueryRules(r); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	if value := r.Header.Get(QueryPriorityHeaderName); value != "" && f.cfg.QueryPriorityHeaderEnabled {
+		var err error
+		if priority, err = strconv.Atoi(value); err != nil {
+			writeError(w, httpgrpc.Errorf(http.StatusBadRequest, "invalid %s header %q: must be an integer", QueryPriorityHeaderName, value))
+			return
+		}
+		if !f.isQueryPriorityAllowed(r, priority) {
+			writeError(w, httpgrpc.Errorf(http.StatusBadRequest, "invalid %s header %q: the priority is not configured in the query priority rules", QueryPriorityHeaderName, value))
+			return
+		}
+	}
+
+	if priority != 0 {
+		r = r.WithContext(queue.ContextWithPriority(r.Context(), priority))
+	}
+
 	r.Body = ioutil.NopCloser(io.TeeReader(r.Body, &buf))
 
 	startTime := time.Now()
@@ -168,6 +230,8 @@ func (f *Handler) reportQueryStats(r *http.Request, queryString url.Values, quer
 
 	// Track stats.
 	f.querySeconds.WithLabelValues(userID).Add(stats.LoadWallTime().Seconds())
+	f.querySeries.WithLabelValues(userID).Add(float64(stats.LoadFetchedSeriesCount()))
+	f.queryBytes.WithLabelValues(userID).Add(float64(stats.LoadFetchedChunkBytes()))
 	f.activeUsers.UpdateUserTimestamp(userID, time.Now())
 
 	// Log stats.
@@ -177,6 +241,12 @@ func (f *Handler) reportQueryStats(r *http.Request, queryString url.Values, quer
 		"path", r.URL.Path,
 		"response_time", queryResponseTime,
 		"query_wall_time_seconds", stats.LoadWallTime().Seconds(),
+		"fetched_series_count", stats.LoadFetchedSeriesCount(),
+		"fetched_chunk_bytes", stats.LoadFetchedChunkBytes(),
+		"samples_processed", stats.LoadSamplesProcessed(),
+		"ingester_requests", stats.LoadIngesterRequests(),
+		"store_gateway_requests", stats.LoadStoreGatewayRequests(),
+		"results_cache_hits", stats.LoadResultsCacheHits(),
 	}, formatQueryString(queryString)...)
 
 	level.Info(util_log.WithContext(r.Context(), f.log)).Log(logMessage...)
@@ -222,6 +292,12 @@ func writeServiceTimingHeader(queryResponseTime time.Duration, headers http.Head
 		parts := make([]string, 0)
 		parts = append(parts, statsValue("querier_wall_time", stats.LoadWallTime()))
 		parts = append(parts, statsValue("response_time", queryResponseTime))
+		parts = append(parts, statsCount("fetched_series_count", stats.LoadFetchedSeriesCount()))
+		parts = append(parts, statsCount("fetched_chunk_bytes", stats.LoadFetchedChunkBytes()))
+		parts = append(parts, statsCount("samples_processed", stats.LoadSamplesProcessed()))
+		parts = append(parts, statsCount("ingester_requests", stats.LoadIngesterRequests()))
+		parts = append(parts, statsCount("store_gateway_requests", stats.LoadStoreGatewayRequests()))
+		parts = append(parts, statsCount("results_cache_hits", stats.LoadResultsCacheHits()))
 		headers.Set(ServiceTimingHeaderName, strings.Join(parts, ", "))
 	}
 }
@@ -230,3 +306,8 @@ func statsValue(name string, d time.Duration) string {
 	durationInMs := strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
 	return name + ";dur=" + durationInMs
 }
+
+// statsCount formats a counter as Server-Timing metric, using the description to carry the value.
+func statsCount(name string, count uint64) string {
+	return name + ";desc=" + strconv.FormatUint(count, 10)
+}
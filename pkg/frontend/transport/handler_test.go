@@ -2,13 +2,23 @@ package transport
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-kit/kit/log"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+
+	querier_stats "github.com/cortexproject/cortex/pkg/querier/stats"
 )
 
 func TestWriteError(t *testing.T) {
@@ -28,3 +38,60 @@ func TestWriteError(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ServeHTTP_ShouldReturnQueryStats(t *testing.T) {
+	roundTripper := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		// Simulate the stats tracked while executing the query.
+		reqStats := querier_stats.FromContext(req.Context())
+		reqStats.AddWallTime(time.Second)
+		reqStats.AddFetchedSeriesCount(10)
+		reqStats.AddFetchedChunkBytes(1024)
+		reqStats.AddSamplesProcessed(100)
+		reqStats.AddIngesterRequests(3)
+		reqStats.AddStoreGatewayRequests(2)
+		reqStats.AddResultsCacheHits(1)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})
+
+	reg := prometheus.NewPedanticRegistry()
+	handler := NewHandler(HandlerConfig{QueryStatsEnabled: true}, roundTripper, nil, log.NewNopLogger(), reg)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/query?query=up", nil)
+	req = req.WithContext(user.InjectOrgID(req.Context(), "user-1"))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	header := resp.Header().Get(ServiceTimingHeaderName)
+	assert.Contains(t, header, "querier_wall_time;dur=1000")
+	assert.Contains(t, header, "fetched_series_count;desc=10")
+	assert.Contains(t, header, "fetched_chunk_bytes;desc=1024")
+	assert.Contains(t, header, "samples_processed;desc=100")
+	assert.Contains(t, header, "ingester_requests;desc=3")
+	assert.Contains(t, header, "store_gateway_requests;desc=2")
+	assert.Contains(t, header, "results_cache_hits;desc=1")
+
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+		# HELP cortex_query_fetched_chunks_bytes_total Size of all chunks fetched to execute a query in bytes.
+		# TYPE cortex_query_fetched_chunks_bytes_total counter
+		cortex_query_fetched_chunks_bytes_total{user="user-1"} 1024
+
+		# HELP cortex_query_fetched_series_total Number of series fetched to execute a query.
+		# TYPE cortex_query_fetched_series_total counter
+		cortex_query_fetched_series_total{user="user-1"} 10
+
+		# HELP cortex_query_seconds_total Total amount of wall clock time spend processing queries.
+		# TYPE cortex_query_seconds_total counter
+		cortex_query_seconds_total{user="user-1"} 1
+	`)))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,349 @@
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/tenant"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+const errQueryBlocked = "the query has been blocked by the query rule with pattern %q"
+
+// QueryRulesProvider provides the per-tenant query rules.
+type QueryRulesProvider interface {
+	// QueryRules returns the query rules of the tenant, or nil if the tenant has no rules.
+	QueryRules(userID string) *QueryRules
+}
+
+// QueryRules are the rules to block, rewrite or prioritise the queries of a tenant in the query-frontend.
+type QueryRules struct {
+	BlockedQueries   []QueryMatcher      `yaml:"blocked_queries"`
+	RewrittenQueries []QueryRewriteRule  `yaml:"rewritten_queries"`
+	QueryPriorities  []QueryPriorityRule `yaml:"query_priorities"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, validating the rules.
+func (r *QueryRules) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain QueryRules
+	if err := unmarshal((*plain)(r)); err != nil {
+		return err
+	}
+
+	for i := range r.BlockedQueries {
+		if err := r.BlockedQueries[i].compile(false); err != nil {
+			return err
+		}
+	}
+	for i := range r.RewrittenQueries {
+		if err := r.RewrittenQueries[i].compile(false); err != nil {
+			return err
+		}
+	}
+	for i := range r.QueryPriorities {
+		if err := r.QueryPriorities[i].compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QueryMatcher matches the PromQL queries, either by exact match or regex. An empty pattern
+// matches all queries, where allowed.
+type QueryMatcher struct {
+	Pattern string `yaml:"pattern"`
+	Regex   bool   `yaml:"regex"`
+
+	// MinTimeRange restricts the matched range queries to the ones with a time range at
+	// least this long.
+	MinTimeRange model.Duration `yaml:"min_time_range"`
+
+	regex *regexp.Regexp
+}
+
+func (m *QueryMatcher) compile(allowEmpty bool) error {
+	if m.Pattern == "" {
+		if allowEmpty {
+			return nil
+		}
+		return errors.New("the query rule pattern must not be empty")
+	}
+
+	if m.Regex {
+		// Like the PromQL regex matchers, the regex is fully anchored.
+		regex, err := regexp.Compile("^(?:" + m.Pattern + ")$")
+		if err != nil {
+			return errors.Wrapf(err, "invalid query rule regex %q", m.Pattern)
+		}
+		m.regex = regex
+	}
+	return nil
+}
+
+// Matches returns whether the query matches, given its time range (0 for instant queries).
+func (m *QueryMatcher) Matches(query string, timeRange time.Duration) bool {
+	if m.MinTimeRange > 0 && timeRange < time.Duration(m.MinTimeRange) {
+		return false
+	}
+	if m.regex != nil {
+		return m.regex.MatchString(query)
+	}
+	return m.Pattern == "" || m.Pattern == query
+}
+
+// QueryRewriteRule rewrites the range queries matching the pattern.
+type QueryRewriteRule struct {
+	QueryMatcher `yaml:",inline"`
+
+	// MaxTimeRange caps the time range of the matched queries, moving their start forward.
+	MaxTimeRange model.Duration `yaml:"max_time_range"`
+	// MinStep raises the step of the matched queries to at least this value.
+	MinStep model.Duration `yaml:"min_step"`
+}
+
+// QueryPriorityRule assigns a priority in the tenant queue to the matching queries.
+type QueryPriorityRule struct {
+	QueryMatcher `yaml:",inline"`
+
+	// UserAgentRegex restricts the matched queries to the ones with a matching User-Agent
+	// header, to tell apart the sources of the queries.
+	UserAgentRegex string `yaml:"user_agent_regex"`
+	Priority       int    `yaml:"priority"`
+
+	userAgentRegex *regexp.Regexp
+}
+
+func (r *QueryPriorityRule) compile() error {
+	if err := r.QueryMatcher.compile(true); err != nil {
+		return err
+	}
+
+	if r.UserAgentRegex != "" {
+		regex, err := regexp.Compile("^(?:" + r.UserAgentRegex + ")$")
+		if err != nil {
+			return errors.Wrapf(err, "invalid query priority user agent regex %q", r.UserAgentRegex)
+		}
+		r.userAgentRegex = regex
+	}
+	return nil
+}
+
+// Matches returns whether the query matches, given its time range (0 for instant queries)
+// and the User-Agent header of the request.
+func (r *QueryPriorityRule) Matches(query string, timeRange time.Duration, userAgent string) bool {
+	if r.userAgentRegex != nil && !r.userAgentRegex.MatchString(userAgent) {
+		return false
+	}
+	return r.QueryMatcher.Matches(query, timeRange)
+}
+
+// applyQueryRules blocks or rewrites the query of the request according to the query rules
+// of the tenants, and returns its priority in the tenant queue. Only the PromQL queries are
+// subject to the rules, and only the range queries can be rewritten. The priority of a query
+// of multiple tenants is the lowest of the tenants' ones.
+func (f *Handler) applyQueryRules(r *http.Request) (int, error) {
+	isRangeQuery := strings.HasSuffix(r.URL.Path, "/query_range")
+	if !isRangeQuery && !strings.HasSuffix(r.URL.Path, "/query") {
+		return 0, nil
+	}
+
+	// Requests without the tenant are rejected downstream.
+	tenantIDs, err := tenant.TenantIDs(r.Context())
+	if err != nil {
+		return 0, nil
+	}
+
+	rulesByTenant := map[string]*QueryRules{}
+	for _, tenantID := range tenantIDs {
+		if rules := f.queryRules.QueryRules(tenantID); rules != nil {
+			rulesByTenant[tenantID] = rules
+		}
+	}
+	if len(rulesByTenant) == 0 {
+		return 0, nil
+	}
+
+	// Read the body, so that the form can be parsed without consuming it.
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return 0, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	parsed := r.Clone(r.Context())
+	parsed.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err := parsed.ParseForm(); err != nil {
+		// Invalid requests are rejected downstream.
+		return 0, nil
+	}
+
+	var (
+		query            = parsed.Form.Get("query")
+		start, end, step int64
+		timeRange        time.Duration
+	)
+	if isRangeQuery {
+		start, err = util.ParseTime(parsed.Form.Get("start"))
+		if err != nil {
+			return 0, nil
+		}
+		end, err = util.ParseTime(parsed.Form.Get("end"))
+		if err != nil {
+			return 0, nil
+		}
+		step, err = parseDurationMs(parsed.Form.Get("step"))
+		if err != nil {
+			return 0, nil
+		}
+		timeRange = time.Duration(end-start) * time.Millisecond
+	}
+
+	for _, tenantID := range tenantIDs {
+		rules, ok := rulesByTenant[tenantID]
+		if !ok {
+			continue
+		}
+
+		for _, matcher := range rules.BlockedQueries {
+			if matcher.Matches(query, timeRange) {
+				f.blockedQueries.WithLabelValues(tenantID, matcher.Pattern).Inc()
+				return 0, httpgrpc.Errorf(http.StatusBadRequest, errQueryBlocked, matcher.Pattern)
+			}
+		}
+	}
+
+	priority := queryPriority(tenantIDs, rulesByTenant, query, timeRange, r.UserAgent())
+	if !isRangeQuery {
+		return priority, nil
+	}
+
+	origStart, origStep := start, step
+	for _, tenantID := range tenantIDs {
+		rules, ok := rulesByTenant[tenantID]
+		if !ok {
+			continue
+		}
+
+		for _, rule := range rules.RewrittenQueries {
+			if !rule.Matches(query, timeRange) {
+				continue
+			}
+
+			if maxTimeRange := time.Duration(rule.MaxTimeRange).Milliseconds(); maxTimeRange > 0 && end-start > maxTimeRange {
+				start = end - maxTimeRange
+			}
+			if minStep := time.Duration(rule.MinStep).Milliseconds(); minStep > 0 && step < minStep {
+				step = minStep
+			}
+			f.rewrittenQueries.WithLabelValues(tenantID, rule.Pattern).Inc()
+		}
+	}
+
+	if start != origStart {
+		setRequestParam(r, parsed.PostForm, "start", formatSeconds(start))
+	}
+	if step != origStep {
+		setRequestParam(r, parsed.PostForm, "step", formatSeconds(step))
+	}
+	return priority, nil
+}
+
+// queryPriority returns the priority of the first matching priority rule of each tenant, and
+// the lowest priority across the tenants. Tenants without matching rules have priority 0.
+func queryPriority(tenantIDs []string, rulesByTenant map[string]*QueryRules, query string, timeRange time.Duration, userAgent string) int {
+	priority := math.MaxInt32
+	for _, tenantID := range tenantIDs {
+		tenantPriority := 0
+		if rules, ok := rulesByTenant[tenantID]; ok {
+			for _, rule := range rules.QueryPriorities {
+				if rule.Matches(query, timeRange, userAgent) {
+					tenantPriority = rule.Priority
+					break
+				}
+			}
+		}
+
+		if tenantPriority < priority {
+			priority = tenantPriority
+		}
+	}
+	return priority
+}
+
+// isQueryPriorityAllowed returns whether the priority requested by the client is either the default
+// priority 0 or one of the priorities of the query priority rules of all the tenants, so that clients
+// can't make up arbitrary priorities.
+func (f *Handler) isQueryPriorityAllowed(r *http.Request, priority int) bool {
+	if priority == 0 {
+		return true
+	}
+	if f.queryRules == nil {
+		return false
+	}
+
+	tenantIDs, err := tenant.TenantIDs(r.Context())
+	if err != nil {
+		return false
+	}
+
+	for _, tenantID := range tenantIDs {
+		rules := f.queryRules.QueryRules(tenantID)
+		if rules == nil || !rules.hasPriority(priority) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasPriority returns whether any of the query priority rules assigns the given priority.
+func (r *QueryRules) hasPriority(priority int) bool {
+	for _, rule := range r.QueryPriorities {
+		if rule.Priority == priority {
+			return true
+		}
+	}
+	return false
+}
+
+// setRequestParam sets the parameter of the request, either in the body or the URL
+// depending on where it has been originally set.
+func setRequestParam(r *http.Request, postForm url.Values, name, value string) {
+	if _, ok := postForm[name]; ok {
+		postForm.Set(name, value)
+		body := postForm.Encode()
+		r.Body = ioutil.NopCloser(strings.NewReader(body))
+		r.ContentLength = int64(len(body))
+		return
+	}
+
+	params := r.URL.Query()
+	params.Set(name, value)
+	r.URL.RawQuery = params.Encode()
+	r.RequestURI = r.URL.RequestURI()
+}
+
+func parseDurationMs(s string) (int64, error) {
+	if d, err := strconv.ParseFloat(s, 64); err == nil {
+		return int64(d * float64(time.Second/time.Millisecond)), nil
+	}
+	if d, err := model.ParseDuration(s); err == nil {
+		return time.Duration(d).Milliseconds(), nil
+	}
+	return 0, fmt.Errorf("cannot parse %q to a valid duration", s)
+}
+
+func formatSeconds(ms int64) string {
+	return strconv.FormatFloat(float64(ms)/float64(time.Second/time.Millisecond), 'f', -1, 64)
+}
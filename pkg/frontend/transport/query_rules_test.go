@@ -0,0 +1,353 @@
+package transport
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cortexproject/cortex/pkg/scheduler/queue"
+)
+
+func TestQueryRules_UnmarshalYAML(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		input       string
+		expectedErr string
+	}{
+		{
+			name: "valid rules",
+			input: `
+blocked_queries:
+  - pattern: 'up{job=~".+"}'
+  - pattern: '.*foo.*'
+    regex: true
+    min_time_range: 1d
+rewritten_queries:
+  - pattern: 'rate\(bar.*'
+    regex: true
+    max_time_range: 7d
+    min_step: 1m
+`,
+		},
+		{
+			name: "empty pattern",
+			input: `
+blocked_queries:
+  - regex: true
+`,
+			expectedErr: "the query rule pattern must not be empty",
+		},
+		{
+			name: "invalid regex",
+			input: `
+rewritten_queries:
+  - pattern: 'foo('
+    regex: true
+`,
+			expectedErr: "invalid query rule regex \"foo(\"",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var rules QueryRules
+			err := yaml.UnmarshalStrict([]byte(tc.input), &rules)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, rules.BlockedQueries[1].Matches("sum(foo)", 48*time.Hour))
+			assert.False(t, rules.BlockedQueries[1].Matches("sum(foo)", time.Hour))
+			assert.True(t, rules.RewrittenQueries[0].Matches("rate(bar[5m])", 0))
+			assert.False(t, rules.RewrittenQueries[0].Matches("sum(rate(bar[5m]))", 0))
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_ShouldApplyQueryRules(t *testing.T) {
+	var rules QueryRules
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+blocked_queries:
+  - pattern: 'up'
+  - pattern: '.*=~"\.\*".*'
+    regex: true
+    min_time_range: 1d
+rewritten_queries:
+  - pattern: 'sum\(rate\(.*'
+    regex: true
+    max_time_range: 1h
+    min_step: 1m
+`), &rules))
+	provider := mockQueryRulesProvider{"user-1": &rules}
+
+	for _, tc := range []struct {
+		name            string
+		method          string
+		path            string
+		params          url.Values
+		userID          string
+		expectedStatus  int
+		expectedParams  url.Values
+		expectedMetrics string
+	}{
+		{
+			name:           "should block an instant query matching exactly",
+			method:         http.MethodGet,
+			path:           "/api/v1/query",
+			params:         url.Values{"query": {"up"}},
+			userID:         "user-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedMetrics: `
+				# HELP cortex_frontend_blocked_queries_total Total number of queries blocked by the query rules, per tenant and pattern.
+				# TYPE cortex_frontend_blocked_queries_total counter
+				cortex_frontend_blocked_queries_total{pattern="up",user="user-1"} 1
+			`,
+		},
+		{
+			name:           "should block a range query matching the regex and the time range",
+			method:         http.MethodPost,
+			path:           "/api/v1/query_range",
+			params:         url.Values{"query": {`count({job=~".*"})`}, "start": {"0"}, "end": {"172800"}, "step": {"60"}},
+			userID:         "user-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedMetrics: `
+				# HELP cortex_frontend_blocked_queries_total Total number of queries blocked by the query rules, per tenant and pattern.
+				# TYPE cortex_frontend_blocked_queries_total counter
+				cortex_frontend_blocked_queries_total{pattern=".*=~\"\\.\\*\".*",user="user-1"} 1
+			`,
+		},
+		{
+			name:           "should not block a range query matching the regex but not the time range",
+			method:         http.MethodGet,
+			path:           "/api/v1/query_range",
+			params:         url.Values{"query": {`count({job=~".*"})`}, "start": {"0"}, "end": {"3600"}, "step": {"60"}},
+			userID:         "user-1",
+			expectedStatus: http.StatusOK,
+			expectedParams: url.Values{"query": {`count({job=~".*"})`}, "start": {"0"}, "end": {"3600"}, "step": {"60"}},
+		},
+		{
+			name:           "should not block the queries of another tenant",
+			method:         http.MethodGet,
+			path:           "/api/v1/query",
+			params:         url.Values{"query": {"up"}},
+			userID:         "user-2",
+			expectedStatus: http.StatusOK,
+			expectedParams: url.Values{"query": {"up"}},
+		},
+		{
+			name:           "should rewrite the time range and step of a GET range query",
+			method:         http.MethodGet,
+			path:           "/api/v1/query_range",
+			params:         url.Values{"query": {"sum(rate(foo[1m]))"}, "start": {"0"}, "end": {"7200"}, "step": {"15"}},
+			userID:         "user-1",
+			expectedStatus: http.StatusOK,
+			expectedParams: url.Values{"query": {"sum(rate(foo[1m]))"}, "start": {"3600"}, "end": {"7200"}, "step": {"60"}},
+			expectedMetrics: `
+				# HELP cortex_frontend_rewritten_queries_total Total number of queries rewritten by the query rules, per tenant and pattern.
+				# TYPE cortex_frontend_rewritten_queries_total counter
+				cortex_frontend_rewritten_queries_total{pattern="sum\\(rate\\(.*",user="user-1"} 1
+			`,
+		},
+		{
+			name:           "should rewrite the time range of a POST range query",
+			method:         http.MethodPost,
+			path:           "/api/v1/query_range",
+			params:         url.Values{"query": {"sum(rate(foo[1m]))"}, "start": {"0"}, "end": {"7200.5"}, "step": {"2m"}},
+			userID:         "user-1",
+			expectedStatus: http.StatusOK,
+			expectedParams: url.Values{"query": {"sum(rate(foo[1m]))"}, "start": {"3600.5"}, "end": {"7200.5"}, "step": {"2m"}},
+			expectedMetrics: `
+				# HELP cortex_frontend_rewritten_queries_total Total number of queries rewritten by the query rules, per tenant and pattern.
+				# TYPE cortex_frontend_rewritten_queries_total counter
+				cortex_frontend_rewritten_queries_total{pattern="sum\\(rate\\(.*",user="user-1"} 1
+			`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var actualParams url.Values
+			roundTripper := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				require.NoError(t, req.ParseForm())
+				actualParams = req.Form
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				}, nil
+			})
+
+			reg := prometheus.NewPedanticRegistry()
+			handler := NewHandler(HandlerConfig{MaxBodySize: 1024}, roundTripper, provider, log.NewNopLogger(), reg)
+
+			var req *http.Request
+			if tc.method == http.MethodPost {
+				req = httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.params.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			} else {
+				req = httptest.NewRequest(tc.method, tc.path+"?"+tc.params.Encode(), nil)
+			}
+			req = req.WithContext(user.InjectOrgID(req.Context(), tc.userID))
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			require.Equal(t, tc.expectedStatus, resp.Code)
+			assert.Equal(t, tc.expectedParams, actualParams)
+
+			if tc.expectedStatus != http.StatusOK {
+				assert.Contains(t, resp.Body.String(), "the query has been blocked by the query rule")
+			}
+
+			assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(tc.expectedMetrics),
+				"cortex_frontend_blocked_queries_total", "cortex_frontend_rewritten_queries_total"))
+		})
+	}
+}
+
+type mockQueryRulesProvider map[string]*QueryRules
+
+func (p mockQueryRulesProvider) QueryRules(userID string) *QueryRules {
+	return p[userID]
+}
+
+func TestHandler_ServeHTTP_ShouldSetQueryPriority(t *testing.T) {
+	var rules QueryRules
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+query_priorities:
+  - user_agent_regex: 'Cortex-Ruler'
+    priority: 10
+  - min_time_range: 7d
+    priority: -1
+`), &rules))
+	provider := mockQueryRulesProvider{"user-1": &rules, "user-2": &rules}
+
+	for _, tc := range []struct {
+		name             string
+		path             string
+		userID           string
+		userAgent        string
+		header           string
+		headerEnabled    bool
+		expectedStatus   int
+		expectedPriority int
+	}{
+		{
+			name:             "should assign the priority of the first matching rule",
+			path:             "/api/v1/query?query=up",
+			userID:           "user-1",
+			userAgent:        "Cortex-Ruler",
+			expectedStatus:   http.StatusOK,
+			expectedPriority: 10,
+		},
+		{
+			name:             "should match the time range of range queries",
+			path:             "/api/v1/query_range?query=up&start=0&end=864000&step=60",
+			userID:           "user-1",
+			expectedStatus:   http.StatusOK,
+			expectedPriority: -1,
+		},
+		{
+			name:             "should assign the default priority without matching rules",
+			path:             "/api/v1/query_range?query=up&start=0&end=3600&step=60",
+			userID:           "user-1",
+			expectedStatus:   http.StatusOK,
+			expectedPriority: 0,
+		},
+		{
+			name:             "should assign the lowest priority of the tenants",
+			path:             "/api/v1/query?query=up",
+			userID:           "user-1|user-3",
+			userAgent:        "Cortex-Ruler",
+			expectedStatus:   http.StatusOK,
+			expectedPriority: 0,
+		},
+		{
+			name:             "should ignore the priority header if disabled",
+			path:             "/api/v1/query?query=up",
+			userID:           "user-1",
+			header:           "5",
+			expectedStatus:   http.StatusOK,
+			expectedPriority: 0,
+		},
+		{
+			name:             "should honor the priority header if enabled",
+			path:             "/api/v1/query?query=up",
+			userID:           "user-1",
+			header:           "10",
+			headerEnabled:    true,
+			expectedStatus:   http.StatusOK,
+			expectedPriority: 10,
+		},
+		{
+			name:             "should honor the default priority header if enabled",
+			path:             "/api/v1/query?query=up",
+			userID:           "user-1",
+			userAgent:        "Cortex-Ruler",
+			header:           "0",
+			headerEnabled:    true,
+			expectedStatus:   http.StatusOK,
+			expectedPriority: 0,
+		},
+		{
+			name:           "should reject a priority header not configured in the query priority rules",
+			path:           "/api/v1/query?query=up",
+			userID:         "user-1",
+			header:         "5",
+			headerEnabled:  true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject a priority header not configured in the query priority rules of all the tenants",
+			path:           "/api/v1/query?query=up",
+			userID:         "user-1|user-3",
+			header:         "10",
+			headerEnabled:  true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject an invalid priority header",
+			path:           "/api/v1/query?query=up",
+			userID:         "user-1",
+			header:         "high",
+			headerEnabled:  true,
+			expectedStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actualPriority := 0
+			roundTripper := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				actualPriority = queue.PriorityFromContext(req.Context())
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				}, nil
+			})
+
+			cfg := HandlerConfig{MaxBodySize: 1024, QueryPriorityHeaderEnabled: tc.headerEnabled}
+			handler := NewHandler(cfg, roundTripper, provider, log.NewNopLogger(), nil)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.Header.Set("User-Agent", tc.userAgent)
+			if tc.header != "" {
+				req.Header.Set(QueryPriorityHeaderName, tc.header)
+			}
+			req = req.WithContext(user.InjectOrgID(req.Context(), tc.userID))
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			require.Equal(t, tc.expectedStatus, resp.Code)
+			assert.Equal(t, tc.expectedPriority, actualPriority)
+		})
+	}
+}
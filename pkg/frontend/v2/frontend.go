@@ -20,6 +20,7 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/frontend/v2/frontendv2pb"
 	"github.com/cortexproject/cortex/pkg/querier/stats"
+	"github.com/cortexproject/cortex/pkg/scheduler/queue"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 	"github.com/cortexproject/cortex/pkg/util/grpcclient"
@@ -77,6 +78,7 @@ type frontendRequest struct {
 	request      *httpgrpc.HTTPRequest
 	userID       string
 	statsEnabled bool
+	priority     int
 
 	cancel context.CancelFunc
 
@@ -176,6 +178,7 @@ func (f *Frontend) RoundTripGRPC(ctx context.Context, req *httpgrpc.HTTPRequest)
 		request:      req,
 		userID:       userID,
 		statsEnabled: stats.IsEnabled(ctx),
+		priority:     queue.PriorityFromContext(ctx),
 
 		cancel: cancel,
 
@@ -72,6 +72,38 @@ func FromQueryResponse(resp *QueryResponse) model.Matrix {
 	return m
 }
 
+// ToExemplarQueryRequest builds an ExemplarQueryRequest proto.
+func ToExemplarQueryRequest(from, to model.Time, matchers ...[]*labels.Matcher) (*ExemplarQueryRequest, error) {
+	var reqMatchers []*LabelMatchers
+	for _, m := range matchers {
+		ms, err := toLabelMatchers(m)
+		if err != nil {
+			return nil, err
+		}
+		reqMatchers = append(reqMatchers, &LabelMatchers{Matchers: ms})
+	}
+
+	return &ExemplarQueryRequest{
+		StartTimestampMs: int64(from),
+		EndTimestampMs:   int64(to),
+		Matchers:         reqMatchers,
+	}, nil
+}
+
+// FromExemplarQueryRequest unpacks an ExemplarQueryRequest proto.
+func FromExemplarQueryRequest(req *ExemplarQueryRequest) (int64, int64, [][]*labels.Matcher, error) {
+	var result [][]*labels.Matcher
+	for _, m := range req.Matchers {
+		matchers, err := fromLabelMatchers(m.Matchers)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		result = append(result, matchers)
+	}
+
+	return req.StartTimestampMs, req.EndTimestampMs, result, nil
+}
+
 // ToMetricsForLabelMatchersRequest builds a MetricsForLabelMatchersRequest proto
 func ToMetricsForLabelMatchersRequest(from, to model.Time, matchers []*labels.Matcher) (*MetricsForLabelMatchersRequest, error) {
 	ms, err := toLabelMatchers(matchers)
@@ -140,6 +172,64 @@ func FromLabelValuesRequest(req *LabelValuesRequest) (string, int64, int64, []*l
 	return req.LabelName, req.StartTimestampMs, req.EndTimestampMs, matchers, nil
 }
 
+// ToLabelNamesRequest builds a LabelNamesRequest proto
+func ToLabelNamesRequest(from, to model.Time, matchers []*labels.Matcher) (*LabelNamesRequest, error) {
+	ms, err := toLabelMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelNamesRequest{
+		StartTimestampMs: int64(from),
+		EndTimestampMs:   int64(to),
+		Matchers:         &LabelMatchers{Matchers: ms},
+	}, nil
+}
+
+// FromLabelNamesRequest unpacks a LabelNamesRequest proto
+func FromLabelNamesRequest(req *LabelNamesRequest) (int64, int64, []*labels.Matcher, error) {
+	var err error
+	var matchers []*labels.Matcher
+
+	if req.Matchers != nil {
+		matchers, err = fromLabelMatchers(req.Matchers.Matchers)
+		if err != nil {
+			return 0, 0, nil, err
+		}
+	}
+
+	return req.StartTimestampMs, req.EndTimestampMs, matchers, nil
+}
+
+// ToLabelCardinalityRequest builds a LabelCardinalityRequest proto
+func ToLabelCardinalityRequest(labelNames []string, labelNamesOnly bool, matchers []*labels.Matcher) (*LabelCardinalityRequest, error) {
+	ms, err := toLabelMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabelCardinalityRequest{
+		LabelNames:     labelNames,
+		Matchers:       &LabelMatchers{Matchers: ms},
+		LabelNamesOnly: labelNamesOnly,
+	}, nil
+}
+
+// FromLabelCardinalityRequest unpacks a LabelCardinalityRequest proto
+func FromLabelCardinalityRequest(req *LabelCardinalityRequest) ([]string, []*labels.Matcher, error) {
+	var err error
+	var matchers []*labels.Matcher
+
+	if req.Matchers != nil {
+		matchers, err = fromLabelMatchers(req.Matchers.Matchers)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return req.LabelNames, matchers, nil
+}
+
 func toLabelMatchers(matchers []*labels.Matcher) ([]*LabelMatcher, error) {
 	result := make([]*LabelMatcher, 0, len(matchers))
 	for _, matcher := range matchers {
@@ -25,6 +25,11 @@ func (m *IngesterServerMock) QueryStream(r *QueryRequest, s Ingester_QueryStream
 	return args.Error(0)
 }
 
+func (m *IngesterServerMock) QueryExemplars(ctx context.Context, r *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*ExemplarQueryResponse), args.Error(1)
+}
+
 func (m *IngesterServerMock) LabelValues(ctx context.Context, r *LabelValuesRequest) (*LabelValuesResponse, error) {
 	args := m.Called(ctx, r)
 	return args.Get(0).(*LabelValuesResponse), args.Error(1)
@@ -35,6 +40,11 @@ func (m *IngesterServerMock) LabelNames(ctx context.Context, r *LabelNamesReques
 	return args.Get(0).(*LabelNamesResponse), args.Error(1)
 }
 
+func (m *IngesterServerMock) LabelCardinality(ctx context.Context, r *LabelCardinalityRequest) (*LabelCardinalityResponse, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0).(*LabelCardinalityResponse), args.Error(1)
+}
+
 func (m *IngesterServerMock) UserStats(ctx context.Context, r *UserStatsRequest) (*UserStatsResponse, error) {
 	args := m.Called(ctx, r)
 	return args.Get(0).(*UserStatsResponse), args.Error(1)
@@ -303,6 +303,108 @@ func (m *QueryStreamResponse) GetTimeseries() []cortexpb.TimeSeries {
 	return nil
 }
 
+type ExemplarQueryRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *ExemplarQueryRequest) Reset()      { *m = ExemplarQueryRequest{} }
+func (*ExemplarQueryRequest) ProtoMessage() {}
+func (*ExemplarQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{5}
+}
+func (m *ExemplarQueryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExemplarQueryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExemplarQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryRequest.Merge(m, src)
+}
+func (m *ExemplarQueryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExemplarQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExemplarQueryRequest proto.InternalMessageInfo
+
+func (m *ExemplarQueryRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
+}
+
+func (m *ExemplarQueryRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
+}
+
+func (m *ExemplarQueryRequest) GetMatchers() []*LabelMatchers {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+type ExemplarQueryResponse struct {
+	Timeseries []cortexpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+}
+
+func (m *ExemplarQueryResponse) Reset()      { *m = ExemplarQueryResponse{} }
+func (*ExemplarQueryResponse) ProtoMessage() {}
+func (*ExemplarQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{6}
+}
+func (m *ExemplarQueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExemplarQueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExemplarQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryResponse.Merge(m, src)
+}
+func (m *ExemplarQueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExemplarQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExemplarQueryResponse proto.InternalMessageInfo
+
+func (m *ExemplarQueryResponse) GetTimeseries() []cortexpb.TimeSeries {
+	if m != nil {
+		return m.Timeseries
+	}
+	return nil
+}
+
 type LabelValuesRequest struct {
 	LabelName        string         `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
 	StartTimestampMs int64          `protobuf:"varint,2,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
@@ -313,7 +415,7 @@ type LabelValuesRequest struct {
 func (m *LabelValuesRequest) Reset()      { *m = LabelValuesRequest{} }
 func (*LabelValuesRequest) ProtoMessage() {}
 func (*LabelValuesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{5}
+	return fileDescriptor_60f6df4f3586b478, []int{7}
 }
 func (m *LabelValuesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -377,7 +479,7 @@ type LabelValuesResponse struct {
 func (m *LabelValuesResponse) Reset()      { *m = LabelValuesResponse{} }
 func (*LabelValuesResponse) ProtoMessage() {}
 func (*LabelValuesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{6}
+	return fileDescriptor_60f6df4f3586b478, []int{8}
 }
 func (m *LabelValuesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -414,14 +516,15 @@ func (m *LabelValuesResponse) GetLabelValues() []string {
 }
 
 type LabelNamesRequest struct {
-	StartTimestampMs int64 `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64 `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	StartTimestampMs int64          `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64          `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         *LabelMatchers `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
 func (m *LabelNamesRequest) Reset()      { *m = LabelNamesRequest{} }
 func (*LabelNamesRequest) ProtoMessage() {}
 func (*LabelNamesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{7}
+	return fileDescriptor_60f6df4f3586b478, []int{9}
 }
 func (m *LabelNamesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -464,6 +567,13 @@ func (m *LabelNamesRequest) GetEndTimestampMs() int64 {
 	return 0
 }
 
+func (m *LabelNamesRequest) GetMatchers() *LabelMatchers {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
 type LabelNamesResponse struct {
 	LabelNames []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
 }
@@ -471,7 +581,7 @@ type LabelNamesResponse struct {
 func (m *LabelNamesResponse) Reset()      { *m = LabelNamesResponse{} }
 func (*LabelNamesResponse) ProtoMessage() {}
 func (*LabelNamesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{8}
+	return fileDescriptor_60f6df4f3586b478, []int{10}
 }
 func (m *LabelNamesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -507,13 +617,237 @@ func (m *LabelNamesResponse) GetLabelNames() []string {
 	return nil
 }
 
+type LabelCardinalityRequest struct {
+	// The label names to return the cardinality for. All label names are returned if empty.
+	LabelNames []string       `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
+	Matchers   *LabelMatchers `protobuf:"bytes,2,opt,name=matchers,proto3" json:"matchers,omitempty"`
+	// Whether to return only the distinct values of each label name and its total number of series,
+	// without the number of series of each value.
+	LabelNamesOnly bool `protobuf:"varint,4,opt,name=label_names_only,json=labelNamesOnly,proto3" json:"label_names_only,omitempty"`
+}
+
+func (m *LabelCardinalityRequest) Reset()      { *m = LabelCardinalityRequest{} }
+func (*LabelCardinalityRequest) ProtoMessage() {}
+func (*LabelCardinalityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{11}
+}
+func (m *LabelCardinalityRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelCardinalityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelCardinalityRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelCardinalityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelCardinalityRequest.Merge(m, src)
+}
+func (m *LabelCardinalityRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelCardinalityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelCardinalityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelCardinalityRequest proto.InternalMessageInfo
+
+func (m *LabelCardinalityRequest) GetLabelNames() []string {
+	if m != nil {
+		return m.LabelNames
+	}
+	return nil
+}
+
+func (m *LabelCardinalityRequest) GetMatchers() *LabelMatchers {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+func (m *LabelCardinalityRequest) GetLabelNamesOnly() bool {
+	if m != nil {
+		return m.LabelNamesOnly
+	}
+	return false
+}
+
+type LabelCardinalityResponse struct {
+	Items []*LabelCardinality `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (m *LabelCardinalityResponse) Reset()      { *m = LabelCardinalityResponse{} }
+func (*LabelCardinalityResponse) ProtoMessage() {}
+func (*LabelCardinalityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{12}
+}
+func (m *LabelCardinalityResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelCardinalityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelCardinalityResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelCardinalityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelCardinalityResponse.Merge(m, src)
+}
+func (m *LabelCardinalityResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelCardinalityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelCardinalityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelCardinalityResponse proto.InternalMessageInfo
+
+func (m *LabelCardinalityResponse) GetItems() []*LabelCardinality {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type LabelCardinality struct {
+	LabelName string                  `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	Values    []LabelValueCardinality `protobuf:"bytes,2,rep,name=values,proto3" json:"values"`
+	// The total number of values and series of the label, regardless of the values returned.
+	LabelValuesCount uint64 `protobuf:"varint,3,opt,name=label_values_count,json=labelValuesCount,proto3" json:"label_values_count,omitempty"`
+	SeriesCount      uint64 `protobuf:"varint,4,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
+}
+
+func (m *LabelCardinality) Reset()      { *m = LabelCardinality{} }
+func (*LabelCardinality) ProtoMessage() {}
+func (*LabelCardinality) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{13}
+}
+func (m *LabelCardinality) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelCardinality) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelCardinality.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelCardinality) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelCardinality.Merge(m, src)
+}
+func (m *LabelCardinality) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelCardinality) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelCardinality.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelCardinality proto.InternalMessageInfo
+
+func (m *LabelCardinality) GetLabelName() string {
+	if m != nil {
+		return m.LabelName
+	}
+	return ""
+}
+
+func (m *LabelCardinality) GetValues() []LabelValueCardinality {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *LabelCardinality) GetLabelValuesCount() uint64 {
+	if m != nil {
+		return m.LabelValuesCount
+	}
+	return 0
+}
+
+func (m *LabelCardinality) GetSeriesCount() uint64 {
+	if m != nil {
+		return m.SeriesCount
+	}
+	return 0
+}
+
+type LabelValueCardinality struct {
+	LabelValue  string `protobuf:"bytes,1,opt,name=label_value,json=labelValue,proto3" json:"label_value,omitempty"`
+	SeriesCount uint64 `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
+}
+
+func (m *LabelValueCardinality) Reset()      { *m = LabelValueCardinality{} }
+func (*LabelValueCardinality) ProtoMessage() {}
+func (*LabelValueCardinality) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{14}
+}
+func (m *LabelValueCardinality) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelValueCardinality) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelValueCardinality.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelValueCardinality) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValueCardinality.Merge(m, src)
+}
+func (m *LabelValueCardinality) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelValueCardinality) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValueCardinality.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelValueCardinality proto.InternalMessageInfo
+
+func (m *LabelValueCardinality) GetLabelValue() string {
+	if m != nil {
+		return m.LabelValue
+	}
+	return ""
+}
+
+func (m *LabelValueCardinality) GetSeriesCount() uint64 {
+	if m != nil {
+		return m.SeriesCount
+	}
+	return 0
+}
+
 type UserStatsRequest struct {
 }
 
 func (m *UserStatsRequest) Reset()      { *m = UserStatsRequest{} }
 func (*UserStatsRequest) ProtoMessage() {}
 func (*UserStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{9}
+	return fileDescriptor_60f6df4f3586b478, []int{15}
 }
 func (m *UserStatsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -552,7 +886,7 @@ type UserStatsResponse struct {
 func (m *UserStatsResponse) Reset()      { *m = UserStatsResponse{} }
 func (*UserStatsResponse) ProtoMessage() {}
 func (*UserStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{10}
+	return fileDescriptor_60f6df4f3586b478, []int{16}
 }
 func (m *UserStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -617,7 +951,7 @@ type UserIDStatsResponse struct {
 func (m *UserIDStatsResponse) Reset()      { *m = UserIDStatsResponse{} }
 func (*UserIDStatsResponse) ProtoMessage() {}
 func (*UserIDStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{11}
+	return fileDescriptor_60f6df4f3586b478, []int{17}
 }
 func (m *UserIDStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -667,7 +1001,7 @@ type UsersStatsResponse struct {
 func (m *UsersStatsResponse) Reset()      { *m = UsersStatsResponse{} }
 func (*UsersStatsResponse) ProtoMessage() {}
 func (*UsersStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{12}
+	return fileDescriptor_60f6df4f3586b478, []int{18}
 }
 func (m *UsersStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -712,7 +1046,7 @@ type MetricsForLabelMatchersRequest struct {
 func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
 func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
 func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{13}
+	return fileDescriptor_60f6df4f3586b478, []int{19}
 }
 func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -769,7 +1103,7 @@ type MetricsForLabelMatchersResponse struct {
 func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
 func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
 func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{14}
+	return fileDescriptor_60f6df4f3586b478, []int{20}
 }
 func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -811,7 +1145,7 @@ type MetricsMetadataRequest struct {
 func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
 func (*MetricsMetadataRequest) ProtoMessage() {}
 func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{15}
+	return fileDescriptor_60f6df4f3586b478, []int{21}
 }
 func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -847,7 +1181,7 @@ type MetricsMetadataResponse struct {
 func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
 func (*MetricsMetadataResponse) ProtoMessage() {}
 func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{16}
+	return fileDescriptor_60f6df4f3586b478, []int{22}
 }
 func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -893,7 +1227,7 @@ type TimeSeriesChunk struct {
 func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
 func (*TimeSeriesChunk) ProtoMessage() {}
 func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{17}
+	return fileDescriptor_60f6df4f3586b478, []int{23}
 }
 func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -953,7 +1287,7 @@ type Chunk struct {
 func (m *Chunk) Reset()      { *m = Chunk{} }
 func (*Chunk) ProtoMessage() {}
 func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{18}
+	return fileDescriptor_60f6df4f3586b478, []int{24}
 }
 func (m *Chunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1016,7 +1350,7 @@ type TransferChunksResponse struct {
 func (m *TransferChunksResponse) Reset()      { *m = TransferChunksResponse{} }
 func (*TransferChunksResponse) ProtoMessage() {}
 func (*TransferChunksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{19}
+	return fileDescriptor_60f6df4f3586b478, []int{25}
 }
 func (m *TransferChunksResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1052,7 +1386,7 @@ type LabelMatchers struct {
 func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
 func (*LabelMatchers) ProtoMessage() {}
 func (*LabelMatchers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{20}
+	return fileDescriptor_60f6df4f3586b478, []int{26}
 }
 func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1097,7 +1431,7 @@ type LabelMatcher struct {
 func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
 func (*LabelMatcher) ProtoMessage() {}
 func (*LabelMatcher) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{21}
+	return fileDescriptor_60f6df4f3586b478, []int{27}
 }
 func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1157,7 +1491,7 @@ type TimeSeriesFile struct {
 func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
 func (*TimeSeriesFile) ProtoMessage() {}
 func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{22}
+	return fileDescriptor_60f6df4f3586b478, []int{28}
 }
 func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1221,10 +1555,16 @@ func init() {
 	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
 	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
+	proto.RegisterType((*ExemplarQueryRequest)(nil), "cortex.ExemplarQueryRequest")
+	proto.RegisterType((*ExemplarQueryResponse)(nil), "cortex.ExemplarQueryResponse")
 	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
 	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
 	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
 	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
+	proto.RegisterType((*LabelCardinalityRequest)(nil), "cortex.LabelCardinalityRequest")
+	proto.RegisterType((*LabelCardinalityResponse)(nil), "cortex.LabelCardinalityResponse")
+	proto.RegisterType((*LabelCardinality)(nil), "cortex.LabelCardinality")
+	proto.RegisterType((*LabelValueCardinality)(nil), "cortex.LabelValueCardinality")
 	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
 	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
 	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
@@ -1244,83 +1584,96 @@ func init() {
 func init() { proto.RegisterFile("ingester.proto", fileDescriptor_60f6df4f3586b478) }
 
 var fileDescriptor_60f6df4f3586b478 = []byte{
-	// 1211 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x4d, 0x6f, 0xdb, 0x46,
-	0x13, 0xe6, 0x5a, 0x1f, 0xb1, 0x46, 0xb2, 0x22, 0xaf, 0xf3, 0xda, 0x0a, 0x83, 0x97, 0x4e, 0x09,
-	0xa4, 0x15, 0xda, 0x46, 0x4e, 0xdc, 0x0f, 0x24, 0x45, 0x8b, 0x40, 0x4e, 0xec, 0xc4, 0x8d, 0x65,
-	0x27, 0xb4, 0xd2, 0x16, 0x05, 0x0a, 0x82, 0x92, 0xd6, 0x32, 0x6b, 0x7e, 0x28, 0xdc, 0x65, 0x51,
-	0xdf, 0x0a, 0xf4, 0x07, 0xb4, 0xe8, 0xa9, 0x3f, 0xa1, 0xe7, 0x5e, 0x7a, 0xeb, 0x39, 0x47, 0x1f,
-	0x83, 0x1e, 0x82, 0x5a, 0xbe, 0xf4, 0x98, 0xa2, 0x7f, 0xa0, 0xe0, 0x72, 0x49, 0x91, 0xb4, 0xd4,
-	0xc6, 0x40, 0x7d, 0xe3, 0xce, 0x3c, 0xf3, 0xec, 0xec, 0xcc, 0xec, 0xcc, 0x12, 0xaa, 0xa6, 0x33,
-	0x20, 0x94, 0x11, 0xaf, 0x39, 0xf4, 0x5c, 0xe6, 0xe2, 0x62, 0xcf, 0xf5, 0x18, 0xf9, 0x5a, 0xbe,
-	0x3e, 0x30, 0xd9, 0xbe, 0xdf, 0x6d, 0xf6, 0x5c, 0x7b, 0x65, 0xe0, 0x0e, 0xdc, 0x15, 0xae, 0xee,
-	0xfa, 0x7b, 0x7c, 0xc5, 0x17, 0xfc, 0x2b, 0x34, 0x93, 0x6f, 0x27, 0xe0, 0x21, 0xc3, 0xd0, 0x73,
-	0xbf, 0x24, 0x3d, 0x26, 0x56, 0x2b, 0xc3, 0x83, 0x41, 0xa4, 0xe8, 0x8a, 0x8f, 0xd0, 0x54, 0xfd,
-	0x08, 0xca, 0x1a, 0x31, 0xfa, 0x1a, 0x79, 0xea, 0x13, 0xca, 0x70, 0x13, 0x2e, 0x3c, 0xf5, 0x89,
-	0x67, 0x12, 0x5a, 0x47, 0x57, 0x73, 0x8d, 0xf2, 0xea, 0xa5, 0xa6, 0x80, 0x3f, 0xf6, 0x89, 0x77,
-	0x28, 0x60, 0x5a, 0x04, 0x52, 0xef, 0x40, 0x25, 0x34, 0xa7, 0x43, 0xd7, 0xa1, 0x04, 0xaf, 0xc0,
-	0x05, 0x8f, 0x50, 0xdf, 0x62, 0x91, 0xfd, 0xff, 0x32, 0xf6, 0x21, 0x4e, 0x8b, 0x50, 0xea, 0x8f,
-	0x08, 0x2a, 0x49, 0x6a, 0xfc, 0x36, 0x60, 0xca, 0x0c, 0x8f, 0xe9, 0xcc, 0xb4, 0x09, 0x65, 0x86,
-	0x3d, 0xd4, 0xed, 0x80, 0x0c, 0x35, 0x72, 0x5a, 0x8d, 0x6b, 0x3a, 0x91, 0xa2, 0x4d, 0x71, 0x03,
-	0x6a, 0xc4, 0xe9, 0xa7, 0xb1, 0x33, 0x1c, 0x5b, 0x25, 0x4e, 0x3f, 0x89, 0xbc, 0x01, 0xb3, 0xb6,
-	0xc1, 0x7a, 0xfb, 0xc4, 0xa3, 0xf5, 0x5c, 0xfa, 0x68, 0x5b, 0x46, 0x97, 0x58, 0xed, 0x50, 0xa9,
-	0xc5, 0x28, 0xf5, 0x21, 0xcc, 0xa5, 0x9c, 0xc6, 0x1f, 0x00, 0xf0, 0x8d, 0x26, 0xc5, 0x67, 0xd8,
-	0x6d, 0x06, 0xbb, 0xed, 0x72, 0xdd, 0x5a, 0xfe, 0xd9, 0x8b, 0x65, 0x49, 0x4b, 0xa0, 0xd5, 0x1f,
-	0x10, 0x2c, 0x70, 0xb6, 0x5d, 0xe6, 0x11, 0xc3, 0x8e, 0x39, 0xef, 0x40, 0xb9, 0xb7, 0xef, 0x3b,
-	0x07, 0x29, 0xd2, 0xa5, 0xc8, 0xb3, 0x31, 0xe5, 0xdd, 0x00, 0x24, 0x78, 0x93, 0x16, 0x19, 0xa7,
-	0x66, 0xce, 0xe4, 0xd4, 0xaf, 0x08, 0x30, 0x3f, 0xfc, 0x27, 0x86, 0xe5, 0x13, 0x1a, 0xa5, 0xe0,
-	0xff, 0x00, 0x56, 0x20, 0xd5, 0x1d, 0xc3, 0x26, 0x3c, 0xf4, 0x25, 0xad, 0xc4, 0x25, 0xdb, 0x86,
-	0x4d, 0xa6, 0x64, 0x68, 0xe6, 0x0c, 0x19, 0xca, 0x4d, 0xcc, 0xd0, 0xcd, 0x44, 0x86, 0xf2, 0x57,
-	0x51, 0xb2, 0x78, 0x92, 0x19, 0xa2, 0x89, 0x14, 0xdd, 0x82, 0x85, 0x94, 0xff, 0x22, 0xa8, 0xaf,
-	0x41, 0x25, 0x3c, 0xc0, 0x57, 0x5c, 0xce, 0xa3, 0x5a, 0xd2, 0xca, 0xd6, 0x18, 0xaa, 0x1e, 0xc0,
-	0xfc, 0x56, 0x74, 0x22, 0x7a, 0xce, 0xb5, 0xa7, 0xbe, 0x27, 0xc2, 0x2c, 0x36, 0x13, 0x5e, 0x2e,
-	0x43, 0x79, 0x1c, 0xe6, 0xc8, 0x49, 0x88, 0xe3, 0x4c, 0x55, 0x0c, 0xb5, 0x27, 0x94, 0x78, 0xbb,
-	0xcc, 0x60, 0x91, 0x8b, 0xea, 0x2f, 0x08, 0xe6, 0x13, 0x42, 0x41, 0x75, 0x2d, 0xea, 0x24, 0xa6,
-	0xeb, 0xe8, 0x9e, 0xc1, 0xc2, 0xac, 0x21, 0x6d, 0x2e, 0x96, 0x6a, 0x06, 0x23, 0x41, 0x62, 0x1d,
-	0xdf, 0xd6, 0xe3, 0x5a, 0x41, 0x8d, 0xbc, 0x56, 0x72, 0x7c, 0x3b, 0x2c, 0x90, 0xe0, 0xf8, 0xc6,
-	0xd0, 0xd4, 0x33, 0x4c, 0x39, 0xce, 0x54, 0x33, 0x86, 0xe6, 0x66, 0x8a, 0xac, 0x09, 0x0b, 0x9e,
-	0x6f, 0x91, 0x2c, 0x3c, 0xcf, 0xe1, 0xf3, 0x81, 0x2a, 0x85, 0x57, 0xbf, 0x80, 0x85, 0xc0, 0xf1,
-	0xcd, 0x7b, 0x69, 0xd7, 0x97, 0xe0, 0x82, 0x4f, 0x89, 0xa7, 0x9b, 0x7d, 0x51, 0x69, 0xc5, 0x60,
-	0xb9, 0xd9, 0xc7, 0xd7, 0x21, 0xdf, 0x37, 0x98, 0xc1, 0xdd, 0x2c, 0xaf, 0x5e, 0x8e, 0x4a, 0xe1,
-	0xd4, 0xe1, 0x35, 0x0e, 0x53, 0xef, 0x03, 0x0e, 0x54, 0x34, 0xcd, 0x7e, 0x13, 0x0a, 0x34, 0x10,
-	0x88, 0x8b, 0x75, 0x25, 0xc9, 0x92, 0xf1, 0x44, 0x0b, 0x91, 0xea, 0xcf, 0x08, 0x94, 0x36, 0x61,
-	0x9e, 0xd9, 0xa3, 0x1b, 0xae, 0x97, 0xae, 0xbc, 0x73, 0xee, 0x51, 0xb7, 0xa0, 0x12, 0x95, 0xb6,
-	0x4e, 0x09, 0x13, 0x7d, 0x6a, 0xca, 0x2d, 0x28, 0x47, 0xd0, 0x5d, 0xc2, 0xd4, 0x87, 0xb0, 0x3c,
-	0xd5, 0x67, 0x11, 0x8a, 0x06, 0x14, 0x6d, 0x0e, 0x11, 0xb1, 0xa8, 0x8d, 0x9b, 0x44, 0x68, 0xaa,
-	0x09, 0xbd, 0x5a, 0x87, 0x45, 0x41, 0xd6, 0x26, 0xcc, 0x08, 0xa2, 0x1b, 0x55, 0xdf, 0x0e, 0x2c,
-	0x9d, 0xd2, 0x08, 0xfa, 0x77, 0x61, 0xd6, 0x16, 0x32, 0xb1, 0x41, 0x3d, 0xbb, 0x41, 0x6c, 0x13,
-	0x23, 0xd5, 0x3f, 0x11, 0x5c, 0xcc, 0x34, 0xb9, 0x20, 0x5e, 0x7b, 0x9e, 0x6b, 0xeb, 0xd1, 0x6c,
-	0x1c, 0x97, 0x46, 0x35, 0x90, 0x6f, 0x0a, 0xf1, 0x66, 0x3f, 0x59, 0x3b, 0x33, 0xa9, 0xda, 0x71,
-	0xa0, 0xc8, 0xef, 0x51, 0xd4, 0xea, 0x17, 0xc6, 0xae, 0xf0, 0xe0, 0x3c, 0x32, 0x4c, 0x6f, 0xad,
-	0x15, 0xf4, 0xc3, 0xdf, 0x5e, 0x2c, 0x9f, 0x69, 0x7a, 0x86, 0xf6, 0xad, 0xbe, 0x31, 0x64, 0xc4,
-	0xd3, 0xc4, 0x2e, 0xf8, 0x2d, 0x28, 0x86, 0x3d, 0xb9, 0x9e, 0xe7, 0xfb, 0xcd, 0x45, 0x29, 0x4b,
-	0xb6, 0x6d, 0x01, 0x51, 0xbf, 0x43, 0x50, 0x08, 0x4f, 0x7a, 0x5e, 0x75, 0x24, 0xc3, 0x2c, 0x71,
-	0x7a, 0x6e, 0xdf, 0x74, 0x06, 0xfc, 0xfa, 0x16, 0xb4, 0x78, 0x8d, 0xb1, 0xb8, 0x56, 0xc1, 0x3d,
-	0xad, 0x88, 0xbb, 0x53, 0x87, 0xc5, 0x8e, 0x67, 0x38, 0x74, 0x8f, 0x78, 0xdc, 0xb1, 0xb8, 0x68,
-	0xd4, 0x16, 0xcc, 0xa5, 0xaa, 0x29, 0x35, 0x46, 0xd1, 0x2b, 0x8d, 0x51, 0x1d, 0x2a, 0x49, 0x0d,
-	0xbe, 0x06, 0x79, 0x76, 0x38, 0x0c, 0x3b, 0x54, 0x75, 0x75, 0x3e, 0xb2, 0xe6, 0xea, 0xce, 0xe1,
-	0x90, 0x68, 0x5c, 0x1d, 0xf8, 0xc9, 0xc7, 0x4f, 0x98, 0x58, 0xfe, 0x8d, 0x2f, 0x41, 0x81, 0x77,
-	0x74, 0x7e, 0xa8, 0x92, 0x16, 0x2e, 0xd4, 0x6f, 0x11, 0x54, 0xc7, 0x35, 0xb4, 0x61, 0x5a, 0xe4,
-	0xbf, 0x28, 0x21, 0x19, 0x66, 0xf7, 0x4c, 0x8b, 0x70, 0x1f, 0xc2, 0xed, 0xe2, 0xf5, 0xa4, 0x18,
-	0xbe, 0xf9, 0x31, 0x94, 0xe2, 0x23, 0xe0, 0x12, 0x14, 0xd6, 0x1f, 0x3f, 0x69, 0x6d, 0xd5, 0x24,
-	0x3c, 0x07, 0xa5, 0xed, 0x9d, 0x8e, 0x1e, 0x2e, 0x11, 0xbe, 0x08, 0x65, 0x6d, 0xfd, 0xfe, 0xfa,
-	0x67, 0x7a, 0xbb, 0xd5, 0xb9, 0xfb, 0xa0, 0x36, 0x83, 0x31, 0x54, 0x43, 0xc1, 0xf6, 0x8e, 0x90,
-	0xe5, 0x56, 0xff, 0x2a, 0xc0, 0x6c, 0xe4, 0x23, 0xbe, 0x0d, 0xf9, 0x47, 0x3e, 0xdd, 0xc7, 0x8b,
-	0xe3, 0x1a, 0xfe, 0xd4, 0x33, 0x19, 0x11, 0x77, 0x52, 0x5e, 0x3a, 0x25, 0x17, 0xb9, 0x93, 0xf0,
-	0xfb, 0x50, 0xe0, 0x6f, 0x0e, 0x3c, 0xf1, 0x15, 0x27, 0x4f, 0x7e, 0x9b, 0xa9, 0x12, 0xbe, 0x07,
-	0xe5, 0xc4, 0x5b, 0x65, 0x8a, 0xf5, 0x95, 0x94, 0x34, 0xfd, 0xac, 0x51, 0xa5, 0x1b, 0x08, 0x3f,
-	0x80, 0x72, 0x62, 0x38, 0x63, 0x39, 0x55, 0x27, 0xa9, 0x17, 0xc7, 0x98, 0x6b, 0xc2, 0x34, 0x57,
-	0x25, 0xbc, 0x0e, 0x30, 0x9e, 0x9f, 0xf8, 0x72, 0x0a, 0x9c, 0x1c, 0xe0, 0xb2, 0x3c, 0x49, 0x15,
-	0xd3, 0xac, 0x41, 0x29, 0x9e, 0x1e, 0xb8, 0x3e, 0x61, 0xa0, 0x84, 0x24, 0xd3, 0x47, 0x8d, 0x2a,
-	0xe1, 0x0d, 0xa8, 0xb4, 0x2c, 0xeb, 0x55, 0x68, 0xe4, 0xa4, 0x86, 0x66, 0x79, 0xac, 0xb8, 0x93,
-	0x66, 0x1b, 0x36, 0x7e, 0x3d, 0xbe, 0x12, 0xff, 0x38, 0x85, 0xe4, 0x37, 0xfe, 0x15, 0x17, 0xef,
-	0xd6, 0x81, 0x8b, 0x99, 0xbe, 0x8d, 0x95, 0x8c, 0x75, 0xa6, 0xd5, 0xcb, 0xcb, 0x53, 0xf5, 0x31,
-	0x6b, 0x1b, 0xaa, 0xe9, 0xb6, 0x81, 0xa7, 0x3d, 0x5c, 0xe5, 0x78, 0xb7, 0x29, 0x7d, 0x46, 0x6a,
-	0xa0, 0xb5, 0x0f, 0x8f, 0x8e, 0x15, 0xe9, 0xf9, 0xb1, 0x22, 0xbd, 0x3c, 0x56, 0xd0, 0x37, 0x23,
-	0x05, 0xfd, 0x34, 0x52, 0xd0, 0xb3, 0x91, 0x82, 0x8e, 0x46, 0x0a, 0xfa, 0x7d, 0xa4, 0xa0, 0x3f,
-	0x46, 0x8a, 0xf4, 0x72, 0xa4, 0xa0, 0xef, 0x4f, 0x14, 0xe9, 0xe8, 0x44, 0x91, 0x9e, 0x9f, 0x28,
-	0xd2, 0xe7, 0xc5, 0x9e, 0x65, 0x12, 0x87, 0x75, 0x8b, 0xfc, 0x7f, 0xe6, 0x9d, 0xbf, 0x03, 0x00,
-	0x00, 0xff, 0xff, 0xf1, 0xa3, 0x32, 0xb1, 0x53, 0x0d, 0x00, 0x00,
+	// 1410 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0x13, 0x47,
+	0x14, 0xdf, 0xf1, 0x57, 0xec, 0x67, 0xc7, 0x38, 0x13, 0x42, 0xcc, 0x52, 0x36, 0x74, 0x25, 0xda,
+	0xa8, 0x2d, 0x0e, 0xd0, 0x0f, 0x41, 0x3f, 0x84, 0x92, 0x10, 0x20, 0x40, 0x08, 0x6c, 0x42, 0xa9,
+	0x5a, 0x55, 0xab, 0x8d, 0x3d, 0x71, 0xb6, 0xec, 0x87, 0xd9, 0x9d, 0xad, 0xc8, 0xad, 0x52, 0xff,
+	0x80, 0x56, 0x3d, 0xf5, 0x42, 0xa5, 0xde, 0x7a, 0xee, 0xa5, 0xb7, 0xf6, 0xca, 0x91, 0x23, 0xea,
+	0x01, 0x15, 0x73, 0xe9, 0x91, 0xfe, 0x07, 0xd5, 0xce, 0xcc, 0xae, 0x77, 0xd7, 0x36, 0x49, 0x24,
+	0xe0, 0xe6, 0x79, 0xef, 0xf7, 0x7e, 0xef, 0xcd, 0x7b, 0x6f, 0x66, 0xde, 0x1a, 0xea, 0xa6, 0xd3,
+	0x25, 0x3e, 0x25, 0x5e, 0xab, 0xe7, 0xb9, 0xd4, 0xc5, 0xa5, 0xb6, 0xeb, 0x51, 0x72, 0x5f, 0x3e,
+	0xd5, 0x35, 0xe9, 0x4e, 0xb0, 0xd5, 0x6a, 0xbb, 0xf6, 0x42, 0xd7, 0xed, 0xba, 0x0b, 0x4c, 0xbd,
+	0x15, 0x6c, 0xb3, 0x15, 0x5b, 0xb0, 0x5f, 0xdc, 0x4c, 0x3e, 0x9f, 0x80, 0x73, 0x86, 0x9e, 0xe7,
+	0x7e, 0x43, 0xda, 0x54, 0xac, 0x16, 0x7a, 0x77, 0xbb, 0x91, 0x62, 0x4b, 0xfc, 0xe0, 0xa6, 0xea,
+	0x67, 0x50, 0xd5, 0x88, 0xd1, 0xd1, 0xc8, 0xbd, 0x80, 0xf8, 0x14, 0xb7, 0x60, 0xe2, 0x5e, 0x40,
+	0x3c, 0x93, 0xf8, 0x4d, 0x74, 0x22, 0x3f, 0x5f, 0x3d, 0x7b, 0xb8, 0x25, 0xe0, 0xb7, 0x02, 0xe2,
+	0xed, 0x0a, 0x98, 0x16, 0x81, 0xd4, 0x0b, 0x50, 0xe3, 0xe6, 0x7e, 0xcf, 0x75, 0x7c, 0x82, 0x17,
+	0x60, 0xc2, 0x23, 0x7e, 0x60, 0xd1, 0xc8, 0x7e, 0x26, 0x63, 0xcf, 0x71, 0x5a, 0x84, 0x52, 0x7f,
+	0x46, 0x50, 0x4b, 0x52, 0xe3, 0xf7, 0x00, 0xfb, 0xd4, 0xf0, 0xa8, 0x4e, 0x4d, 0x9b, 0xf8, 0xd4,
+	0xb0, 0x7b, 0xba, 0x1d, 0x92, 0xa1, 0xf9, 0xbc, 0xd6, 0x60, 0x9a, 0xcd, 0x48, 0xb1, 0xe6, 0xe3,
+	0x79, 0x68, 0x10, 0xa7, 0x93, 0xc6, 0xe6, 0x18, 0xb6, 0x4e, 0x9c, 0x4e, 0x12, 0x79, 0x1a, 0xca,
+	0xb6, 0x41, 0xdb, 0x3b, 0xc4, 0xf3, 0x9b, 0xf9, 0xf4, 0xd6, 0xae, 0x1b, 0x5b, 0xc4, 0x5a, 0xe3,
+	0x4a, 0x2d, 0x46, 0xa9, 0xd7, 0x60, 0x32, 0x15, 0x34, 0xfe, 0x18, 0x80, 0x39, 0x1a, 0x95, 0x9f,
+	0xde, 0x56, 0x2b, 0xf4, 0xb6, 0xc1, 0x74, 0x4b, 0x85, 0x87, 0x4f, 0xe6, 0x24, 0x2d, 0x81, 0x56,
+	0x7f, 0x42, 0x30, 0xcd, 0xd8, 0x36, 0xa8, 0x47, 0x0c, 0x3b, 0xe6, 0xbc, 0x00, 0xd5, 0xf6, 0x4e,
+	0xe0, 0xdc, 0x4d, 0x91, 0xce, 0x46, 0x91, 0x0d, 0x28, 0x97, 0x43, 0x90, 0xe0, 0x4d, 0x5a, 0x64,
+	0x82, 0xca, 0x1d, 0x28, 0xa8, 0x5f, 0x11, 0x1c, 0x5e, 0xb9, 0x4f, 0xec, 0x9e, 0x65, 0x78, 0xaf,
+	0xa5, 0x08, 0x67, 0x86, 0x8a, 0x30, 0x33, 0xaa, 0x08, 0x7e, 0xa2, 0x0a, 0x1b, 0x30, 0x93, 0x09,
+	0xf1, 0x25, 0x54, 0xe3, 0x4f, 0x04, 0x98, 0x39, 0xfc, 0xdc, 0xb0, 0x02, 0xe2, 0x47, 0xdb, 0x3e,
+	0x0e, 0x60, 0x85, 0x52, 0xdd, 0x31, 0x6c, 0xc2, 0xb6, 0x5b, 0xd1, 0x2a, 0x4c, 0x72, 0xc3, 0xb0,
+	0xc9, 0x98, 0xac, 0xe4, 0x0e, 0x90, 0x95, 0xfc, 0x9e, 0x59, 0x29, 0x9c, 0x40, 0xfb, 0xc9, 0xca,
+	0x39, 0x98, 0x4e, 0xc5, 0x2f, 0x72, 0xf2, 0x26, 0xd4, 0xf8, 0x06, 0xbe, 0x65, 0x72, 0x96, 0x95,
+	0x8a, 0x56, 0xb5, 0x06, 0x50, 0xf5, 0x17, 0x04, 0x53, 0xd7, 0xa3, 0x2d, 0xf9, 0xaf, 0xb7, 0xe0,
+	0xfb, 0xda, 0xda, 0x87, 0x80, 0x93, 0xf1, 0x89, 0x9d, 0xcd, 0x41, 0x75, 0x50, 0x9a, 0x68, 0x63,
+	0x10, 0xd7, 0xc6, 0x57, 0x1f, 0x20, 0x98, 0x65, 0x76, 0xcb, 0x86, 0xd7, 0x31, 0x1d, 0xc3, 0x32,
+	0x69, 0xdc, 0xce, 0x7b, 0x19, 0xa7, 0xc2, 0xcc, 0xed, 0x2b, 0xcc, 0x30, 0x07, 0x09, 0x4e, 0xdd,
+	0x75, 0xac, 0x5d, 0x56, 0xbc, 0xb2, 0x56, 0x1f, 0x10, 0xaf, 0x3b, 0xd6, 0xee, 0xd5, 0x42, 0x39,
+	0xdf, 0x28, 0xa8, 0x57, 0xa1, 0x39, 0x1c, 0x9e, 0xd8, 0x5c, 0x0b, 0x8a, 0x26, 0x25, 0x76, 0xd4,
+	0xc5, 0xcd, 0x94, 0xef, 0xa4, 0x01, 0x87, 0xa9, 0x7f, 0x21, 0x68, 0x64, 0x75, 0x7b, 0x35, 0xef,
+	0x27, 0x50, 0x12, 0x4d, 0xc1, 0xef, 0x88, 0xe3, 0x29, 0x27, 0xac, 0x39, 0x12, 0x6c, 0xe2, 0xcc,
+	0x08, 0x93, 0xb0, 0x3d, 0x92, 0x7d, 0xa5, 0xb7, 0xdd, 0xc0, 0xa1, 0xac, 0xa0, 0x05, 0xad, 0x91,
+	0xe8, 0xae, 0xe5, 0x50, 0x1e, 0x76, 0x21, 0x3f, 0x67, 0x02, 0x57, 0x60, 0xb8, 0x2a, 0x97, 0x31,
+	0x88, 0xfa, 0x15, 0xcc, 0x8c, 0xf4, 0x3b, 0x28, 0x15, 0xf3, 0x24, 0xb6, 0x01, 0x03, 0x17, 0x43,
+	0xe4, 0xb9, 0x61, 0x72, 0x0c, 0x8d, 0xdb, 0x3e, 0xf1, 0x36, 0xa8, 0x41, 0xa3, 0x06, 0x57, 0xff,
+	0x40, 0x30, 0x95, 0x10, 0x8a, 0xc4, 0x9f, 0x8c, 0x5e, 0x60, 0xd3, 0x75, 0x74, 0xcf, 0xa0, 0xdc,
+	0x21, 0xd2, 0x26, 0x63, 0xa9, 0x66, 0x50, 0x12, 0xa6, 0xd6, 0x09, 0x6c, 0x3d, 0xbe, 0x63, 0x43,
+	0x8f, 0x15, 0x27, 0xb0, 0xf9, 0xfd, 0x12, 0x66, 0xc7, 0xe8, 0x99, 0x7a, 0x86, 0x29, 0xcf, 0x98,
+	0x1a, 0x46, 0xcf, 0x5c, 0x4d, 0x91, 0xb5, 0x60, 0xda, 0x0b, 0x2c, 0x92, 0x85, 0x17, 0x18, 0x7c,
+	0x2a, 0x54, 0xa5, 0xf0, 0xea, 0xd7, 0x30, 0x1d, 0x06, 0xbe, 0x7a, 0x31, 0x1d, 0xfa, 0x2c, 0x4c,
+	0x04, 0x3e, 0xf1, 0x74, 0xb3, 0x23, 0x92, 0x54, 0x0a, 0x97, 0xab, 0x1d, 0x7c, 0x0a, 0x0a, 0x1d,
+	0x83, 0x1a, 0xa2, 0x8f, 0x8f, 0x46, 0x65, 0x1e, 0xda, 0xbc, 0xc6, 0x60, 0xea, 0x65, 0xc0, 0xa1,
+	0xca, 0x4f, 0xb3, 0x9f, 0x81, 0xa2, 0x1f, 0x0a, 0x44, 0x47, 0x1e, 0x4b, 0xb2, 0x64, 0x22, 0xd1,
+	0x38, 0x52, 0xfd, 0x1d, 0x81, 0xb2, 0x46, 0xa8, 0x67, 0xb6, 0xfd, 0x4b, 0xae, 0x97, 0x3e, 0x36,
+	0xaf, 0xf8, 0x96, 0x39, 0x07, 0xb5, 0xe8, 0x5c, 0xea, 0x3e, 0xa1, 0x2f, 0x7e, 0x5a, 0xaa, 0x11,
+	0x74, 0x83, 0x50, 0xf5, 0x1a, 0xcc, 0x8d, 0x8d, 0x59, 0xa4, 0x62, 0x1e, 0x4a, 0x36, 0x83, 0x88,
+	0x5c, 0x34, 0x06, 0x6f, 0x0c, 0x37, 0xd5, 0x84, 0x5e, 0x6d, 0xc2, 0x11, 0x41, 0xb6, 0x46, 0xa8,
+	0x11, 0x66, 0x37, 0xea, 0xbe, 0x75, 0x98, 0x1d, 0xd2, 0x08, 0xfa, 0x0f, 0xa0, 0x6c, 0x0b, 0x59,
+	0xe6, 0xf8, 0xc7, 0x0e, 0x62, 0x9b, 0x18, 0xa9, 0xfe, 0x87, 0xe0, 0x50, 0x66, 0x38, 0x08, 0xf3,
+	0xb5, 0xed, 0xb9, 0xb6, 0x1e, 0xcd, 0x94, 0x83, 0xd6, 0xa8, 0x87, 0xf2, 0x55, 0x21, 0x5e, 0xed,
+	0x24, 0x7b, 0x27, 0x97, 0xea, 0x1d, 0x07, 0x4a, 0xec, 0xa8, 0x45, 0xaf, 0xf3, 0xf4, 0x20, 0x14,
+	0x96, 0x9c, 0x9b, 0x86, 0xe9, 0x2d, 0x2d, 0x86, 0x57, 0xc3, 0xdf, 0x4f, 0xe6, 0x0e, 0x34, 0x75,
+	0x72, 0xfb, 0xc5, 0x8e, 0xd1, 0xa3, 0xc4, 0xd3, 0x84, 0x17, 0xfc, 0x2e, 0x94, 0xf8, 0x2c, 0xd3,
+	0x2c, 0x30, 0x7f, 0x93, 0x51, 0xc9, 0x92, 0xe3, 0x8e, 0x80, 0xa8, 0x3f, 0x20, 0x28, 0xf2, 0x9d,
+	0xbe, 0xaa, 0x3e, 0x92, 0xa1, 0x4c, 0x9c, 0xb6, 0xdb, 0x31, 0x9d, 0x2e, 0x3b, 0xbe, 0x45, 0x2d,
+	0x5e, 0x63, 0x2c, 0x8e, 0x55, 0x78, 0x4e, 0x6b, 0xe2, 0xec, 0x34, 0xe1, 0xc8, 0xa6, 0x67, 0x38,
+	0xfe, 0x36, 0xf1, 0x58, 0x60, 0x71, 0xd3, 0xa8, 0x8b, 0x30, 0x99, 0xea, 0xa6, 0xd4, 0xf8, 0x89,
+	0xf6, 0x35, 0x7e, 0xea, 0x50, 0x4b, 0x6a, 0xf0, 0x49, 0x28, 0xd0, 0xdd, 0x1e, 0xbf, 0xa1, 0xea,
+	0x67, 0xa7, 0x22, 0x6b, 0xa6, 0xde, 0xdc, 0xed, 0x11, 0x8d, 0xa9, 0xc3, 0x38, 0xd9, 0x03, 0xc0,
+	0x0b, 0xcb, 0x7e, 0xe3, 0xc3, 0x50, 0xe4, 0xd7, 0x69, 0x9e, 0x09, 0xf9, 0x42, 0xfd, 0x1e, 0x41,
+	0x7d, 0xd0, 0x43, 0x97, 0x4c, 0x8b, 0xbc, 0x8c, 0x16, 0x92, 0xa1, 0xbc, 0x6d, 0x5a, 0x84, 0xc5,
+	0xc0, 0xdd, 0xc5, 0xeb, 0x51, 0x39, 0x7c, 0xe7, 0x2a, 0x54, 0xe2, 0x2d, 0xe0, 0x0a, 0x14, 0x57,
+	0x6e, 0xdd, 0x5e, 0xbc, 0xde, 0x90, 0xf0, 0x24, 0x54, 0x6e, 0xac, 0x6f, 0xea, 0x7c, 0x89, 0xf0,
+	0x21, 0xa8, 0x6a, 0x2b, 0x97, 0x57, 0xbe, 0xd0, 0xd7, 0x16, 0x37, 0x97, 0xaf, 0x34, 0x72, 0x18,
+	0x43, 0x9d, 0x0b, 0x6e, 0xac, 0x0b, 0x59, 0xfe, 0xec, 0x83, 0x09, 0x28, 0x47, 0x31, 0xe2, 0xf3,
+	0x50, 0xb8, 0x19, 0xf8, 0x3b, 0xf8, 0xc8, 0xa0, 0x87, 0xef, 0x78, 0x26, 0x25, 0xe2, 0x4c, 0xca,
+	0xb3, 0x43, 0x72, 0x51, 0x3b, 0x09, 0x7f, 0x04, 0x45, 0x36, 0x6b, 0xe2, 0x91, 0x5f, 0x3f, 0xf2,
+	0xe8, 0x6f, 0x1a, 0x55, 0xc2, 0x17, 0xa1, 0x9a, 0x98, 0xf1, 0xc7, 0x58, 0x1f, 0x4b, 0x49, 0xd3,
+	0x9f, 0x03, 0xaa, 0x74, 0x1a, 0xe1, 0x75, 0xa8, 0x33, 0x55, 0x34, 0xf6, 0xfa, 0xf8, 0x8d, 0xc8,
+	0x64, 0xd4, 0xb0, 0x2e, 0x1f, 0x1f, 0xa3, 0x8d, 0xc3, 0xba, 0x02, 0xd5, 0xc4, 0xb0, 0x88, 0xe5,
+	0xe1, 0x97, 0xdf, 0x1f, 0x0a, 0x6e, 0xc4, 0x74, 0xa9, 0x4a, 0x78, 0x05, 0x60, 0x30, 0x9b, 0xe1,
+	0xa3, 0x29, 0x70, 0x72, 0x9e, 0x94, 0xe5, 0x51, 0xaa, 0x98, 0xe6, 0xce, 0x88, 0xf1, 0x65, 0x6e,
+	0xec, 0xd0, 0x23, 0x28, 0x4f, 0x8c, 0x07, 0xc4, 0xc4, 0x4b, 0x50, 0x89, 0xdf, 0x39, 0xdc, 0x1c,
+	0xf1, 0xf4, 0x71, 0xaa, 0xf1, 0x8f, 0xa2, 0x2a, 0xe1, 0x4b, 0x50, 0x5b, 0xb4, 0xac, 0xfd, 0xd0,
+	0xc8, 0x49, 0x8d, 0x9f, 0xe5, 0xb1, 0x60, 0x76, 0xcc, 0xd3, 0x82, 0xdf, 0x8a, 0x0f, 0xef, 0x0b,
+	0xdf, 0x4b, 0xf9, 0xed, 0x3d, 0x71, 0xb1, 0xb7, 0x4d, 0x38, 0x94, 0x79, 0x61, 0xb0, 0x92, 0xb1,
+	0xce, 0x3c, 0x4a, 0xf2, 0xdc, 0x58, 0x7d, 0xcc, 0xba, 0x06, 0xf5, 0xf4, 0x05, 0x87, 0xc7, 0x7d,
+	0x9a, 0xca, 0xb1, 0xb7, 0x31, 0x37, 0xa2, 0x34, 0x8f, 0x96, 0x3e, 0x7d, 0xf4, 0x54, 0x91, 0x1e,
+	0x3f, 0x55, 0xa4, 0xe7, 0x4f, 0x15, 0xf4, 0x5d, 0x5f, 0x41, 0xbf, 0xf5, 0x15, 0xf4, 0xb0, 0xaf,
+	0xa0, 0x47, 0x7d, 0x05, 0xfd, 0xd3, 0x57, 0xd0, 0xbf, 0x7d, 0x45, 0x7a, 0xde, 0x57, 0xd0, 0x8f,
+	0xcf, 0x14, 0xe9, 0xd1, 0x33, 0x45, 0x7a, 0xfc, 0x4c, 0x91, 0xbe, 0x2c, 0xb5, 0x2d, 0x93, 0x38,
+	0x74, 0xab, 0xc4, 0xfe, 0xb1, 0x78, 0xff, 0xff, 0x01, 0x00, 0xdb, 0x9b, 0xa8, 0x8a, 0x35, 0x11,
+	0x00, 0x00,
 }
 
 func (x MatchType) String() string {
@@ -1489,14 +1842,14 @@ func (this *QueryStreamResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *LabelValuesRequest) Equal(that interface{}) bool {
+func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelValuesRequest)
+	that1, ok := that.(*ExemplarQueryRequest)
 	if !ok {
-		that2, ok := that.(LabelValuesRequest)
+		that2, ok := that.(ExemplarQueryRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -1508,19 +1861,83 @@ func (this *LabelValuesRequest) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
 	if this.StartTimestampMs != that1.StartTimestampMs {
 		return false
 	}
 	if this.EndTimestampMs != that1.EndTimestampMs {
 		return false
 	}
-	if !this.Matchers.Equal(that1.Matchers) {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	return true
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExemplarQueryResponse)
+	if !ok {
+		that2, ok := that.(ExemplarQueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
+	}
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LabelValuesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelValuesRequest)
+	if !ok {
+		that2, ok := that.(LabelValuesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LabelName != that1.LabelName {
+		return false
+	}
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
+	}
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
+	}
+	return true
 }
 func (this *LabelValuesResponse) Equal(that interface{}) bool {
 	if that == nil {
@@ -1576,6 +1993,9 @@ func (this *LabelNamesRequest) Equal(that interface{}) bool {
 	if this.EndTimestampMs != that1.EndTimestampMs {
 		return false
 	}
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
+	}
 	return true
 }
 func (this *LabelNamesResponse) Equal(that interface{}) bool {
@@ -1607,6 +2027,135 @@ func (this *LabelNamesResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *LabelCardinalityRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelCardinalityRequest)
+	if !ok {
+		that2, ok := that.(LabelCardinalityRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.LabelNames) != len(that1.LabelNames) {
+		return false
+	}
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
+			return false
+		}
+	}
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
+	}
+	if this.LabelNamesOnly != that1.LabelNamesOnly {
+		return false
+	}
+	return true
+}
+func (this *LabelCardinalityResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelCardinalityResponse)
+	if !ok {
+		that2, ok := that.(LabelCardinalityResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Items) != len(that1.Items) {
+		return false
+	}
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *LabelCardinality) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelCardinality)
+	if !ok {
+		that2, ok := that.(LabelCardinality)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LabelName != that1.LabelName {
+		return false
+	}
+	if len(this.Values) != len(that1.Values) {
+		return false
+	}
+	for i := range this.Values {
+		if !this.Values[i].Equal(&that1.Values[i]) {
+			return false
+		}
+	}
+	if this.LabelValuesCount != that1.LabelValuesCount {
+		return false
+	}
+	if this.SeriesCount != that1.SeriesCount {
+		return false
+	}
+	return true
+}
+func (this *LabelValueCardinality) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelValueCardinality)
+	if !ok {
+		that2, ok := that.(LabelValueCardinality)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.LabelValue != that1.LabelValue {
+		return false
+	}
+	if this.SeriesCount != that1.SeriesCount {
+		return false
+	}
+	return true
+}
 func (this *UserStatsRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -2097,6 +2646,36 @@ func (this *QueryStreamResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *ExemplarQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&client.ExemplarQueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ExemplarQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ExemplarQueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*cortexpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *LabelValuesRequest) GoString() string {
 	if this == nil {
 		return "nil"
@@ -2126,10 +2705,13 @@ func (this *LabelNamesRequest) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 6)
+	s := make([]string, 0, 7)
 	s = append(s, "&client.LabelNamesRequest{")
 	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
 	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -2143,6 +2725,62 @@ func (this *LabelNamesResponse) GoString() string {
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
+func (this *LabelCardinalityRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelCardinalityRequest{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "LabelNamesOnly: "+fmt.Sprintf("%#v", this.LabelNamesOnly)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelCardinalityResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelCardinalityResponse{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelCardinality) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&client.LabelCardinality{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	if this.Values != nil {
+		vs := make([]*LabelValueCardinality, len(this.Values))
+		for i := range vs {
+			vs[i] = &this.Values[i]
+		}
+		s = append(s, "Values: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "LabelValuesCount: "+fmt.Sprintf("%#v", this.LabelValuesCount)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelValueCardinality) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValueCardinality{")
+	s = append(s, "LabelValue: "+fmt.Sprintf("%#v", this.LabelValue)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
 func (this *UserStatsRequest) GoString() string {
 	if this == nil {
 		return "nil"
@@ -2339,8 +2977,10 @@ type IngesterClient interface {
 	Push(ctx context.Context, in *cortexpb.WriteRequest, opts ...grpc.CallOption) (*cortexpb.WriteResponse, error)
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
+	QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error)
 	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
 	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
+	LabelCardinality(ctx context.Context, in *LabelCardinalityRequest, opts ...grpc.CallOption) (*LabelCardinalityResponse, error)
 	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
 	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
 	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
@@ -2407,6 +3047,15 @@ func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
 	return m, nil
 }
 
+func (c *ingesterClient) QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error) {
+	out := new(ExemplarQueryResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/QueryExemplars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
 	out := new(LabelValuesResponse)
 	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
@@ -2425,6 +3074,15 @@ func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest,
 	return out, nil
 }
 
+func (c *ingesterClient) LabelCardinality(ctx context.Context, in *LabelCardinalityRequest, opts ...grpc.CallOption) (*LabelCardinalityResponse, error) {
+	out := new(LabelCardinalityResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelCardinality", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
 	out := new(UserStatsResponse)
 	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
@@ -2500,8 +3158,10 @@ type IngesterServer interface {
 	Push(context.Context, *cortexpb.WriteRequest) (*cortexpb.WriteResponse, error)
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
+	QueryExemplars(context.Context, *ExemplarQueryRequest) (*ExemplarQueryResponse, error)
 	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
 	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
+	LabelCardinality(context.Context, *LabelCardinalityRequest) (*LabelCardinalityResponse, error)
 	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
 	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
 	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
@@ -2523,12 +3183,18 @@ func (*UnimplementedIngesterServer) Query(ctx context.Context, req *QueryRequest
 func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
 }
+func (*UnimplementedIngesterServer) QueryExemplars(ctx context.Context, req *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryExemplars not implemented")
+}
 func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
 }
 func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
 }
+func (*UnimplementedIngesterServer) LabelCardinality(ctx context.Context, req *LabelCardinalityRequest) (*LabelCardinalityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelCardinality not implemented")
+}
 func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
 }
@@ -2606,6 +3272,24 @@ func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Ingester_QueryExemplars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemplarQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).QueryExemplars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/QueryExemplars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).QueryExemplars(ctx, req.(*ExemplarQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LabelValuesRequest)
 	if err := dec(in); err != nil {
@@ -2642,6 +3326,24 @@ func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ingester_LabelCardinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelCardinalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelCardinality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelCardinality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelCardinality(ctx, req.(*LabelCardinalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserStatsRequest)
 	if err := dec(in); err != nil {
@@ -2752,6 +3454,10 @@ var _Ingester_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Query",
 			Handler:    _Ingester_Query_Handler,
 		},
+		{
+			MethodName: "QueryExemplars",
+			Handler:    _Ingester_QueryExemplars_Handler,
+		},
 		{
 			MethodName: "LabelValues",
 			Handler:    _Ingester_LabelValues_Handler,
@@ -2760,6 +3466,10 @@ var _Ingester_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LabelNames",
 			Handler:    _Ingester_LabelNames_Handler,
 		},
+		{
+			MethodName: "LabelCardinality",
+			Handler:    _Ingester_LabelCardinality_Handler,
+		},
 		{
 			MethodName: "UserStats",
 			Handler:    _Ingester_UserStats_Handler,
@@ -3001,6 +3711,90 @@ func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ExemplarQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExemplarQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3105,6 +3899,18 @@ func (m *LabelNamesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Matchers != nil {
+		{
+			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.EndTimestampMs != 0 {
 		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
 		i--
@@ -3150,30 +3956,7 @@ func (m *LabelNamesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *UserStatsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *UserStatsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *UserStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
-}
-
-func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
+func (m *LabelCardinalityRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3183,65 +3966,29 @@ func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UserStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UserStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.RuleIngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RuleIngestionRate))))
-		i--
-		dAtA[i] = 0x21
-	}
-	if m.ApiIngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ApiIngestionRate))))
-		i--
-		dAtA[i] = 0x19
-	}
-	if m.NumSeries != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
+	if m.LabelNamesOnly {
 		i--
-		dAtA[i] = 0x10
-	}
-	if m.IngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.IngestionRate))))
+		if m.LabelNamesOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i--
-		dAtA[i] = 0x9
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *UserIDStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+		dAtA[i] = 0x20
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UserIDStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Data != nil {
+	if m.Matchers != nil {
 		{
-			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3251,17 +3998,19 @@ func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelNames[iNdEx])
+			copy(dAtA[i:], m.LabelNames[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
+func (m *LabelCardinalityResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3271,20 +4020,20 @@ func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UsersStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Stats) > 0 {
-		for iNdEx := len(m.Stats) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Stats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -3298,7 +4047,7 @@ func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
+func (m *LabelCardinality) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3308,20 +4057,30 @@ func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelCardinality) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelCardinality) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.MatchersSet) > 0 {
-		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.LabelValuesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.LabelValuesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Values[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -3329,23 +4088,20 @@ func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int,
 				i = encodeVarintIngester(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x1a
+			dAtA[i] = 0x12
 		}
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
+func (m *LabelValueCardinality) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3355,28 +4111,258 @@ func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MetricsForLabelMatchersResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelValueCardinality) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MetricsForLabelMatchersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LabelValueCardinality) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Metric) > 0 {
-		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LabelValue) > 0 {
+		i -= len(m.LabelValue)
+		copy(dAtA[i:], m.LabelValue)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelValue)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RuleIngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RuleIngestionRate))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.ApiIngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ApiIngestionRate))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.NumSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.IngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.IngestionRate))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserIDStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserIDStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Data != nil {
+		{
+			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UsersStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Stats) > 0 {
+		for iNdEx := len(m.Stats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Stats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MatchersSet) > 0 {
+		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsForLabelMatchersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsForLabelMatchersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metric) > 0 {
+		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
 	return len(dAtA) - i, nil
@@ -3803,38 +4789,74 @@ func (m *QueryStreamResponse) Size() (n int) {
 	return n
 }
 
-func (m *LabelValuesRequest) Size() (n int) {
+func (m *ExemplarQueryRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
 	if m.StartTimestampMs != 0 {
 		n += 1 + sovIngester(uint64(m.StartTimestampMs))
 	}
 	if m.EndTimestampMs != 0 {
 		n += 1 + sovIngester(uint64(m.EndTimestampMs))
 	}
-	if m.Matchers != nil {
-		l = m.Matchers.Size()
-		n += 1 + l + sovIngester(uint64(l))
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
 	}
 	return n
 }
 
-func (m *LabelValuesResponse) Size() (n int) {
+func (m *ExemplarQueryResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.LabelValues) > 0 {
-		for _, s := range m.LabelValues {
-			l = len(s)
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelValuesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelValues) > 0 {
+		for _, s := range m.LabelValues {
+			l = len(s)
 			n += 1 + l + sovIngester(uint64(l))
 		}
 	}
@@ -3853,6 +4875,10 @@ func (m *LabelNamesRequest) Size() (n int) {
 	if m.EndTimestampMs != 0 {
 		n += 1 + sovIngester(uint64(m.EndTimestampMs))
 	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
 	return n
 }
 
@@ -3871,6 +4897,84 @@ func (m *LabelNamesResponse) Size() (n int) {
 	return n
 }
 
+func (m *LabelCardinalityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, s := range m.LabelNames {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.LabelNamesOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *LabelCardinalityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelCardinality) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.LabelValuesCount != 0 {
+		n += 1 + sovIngester(uint64(m.LabelValuesCount))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	return n
+}
+
+func (m *LabelValueCardinality) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelValue)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	return n
+}
+
 func (m *UserStatsRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4202,6 +5306,38 @@ func (this *QueryStreamResponse) String() string {
 	}, "")
 	return s
 }
+func (this *ExemplarQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatchers{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&ExemplarQueryRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExemplarQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&ExemplarQueryResponse{`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *LabelValuesRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -4232,6 +5368,7 @@ func (this *LabelNamesRequest) String() string {
 	s := strings.Join([]string{`&LabelNamesRequest{`,
 		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
 		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4246,6 +5383,62 @@ func (this *LabelNamesResponse) String() string {
 	}, "")
 	return s
 }
+func (this *LabelCardinalityRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelCardinalityRequest{`,
+		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
+		`LabelNamesOnly:` + fmt.Sprintf("%v", this.LabelNamesOnly) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelCardinalityResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*LabelCardinality{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "LabelCardinality", "LabelCardinality", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&LabelCardinalityResponse{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelCardinality) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForValues := "[]LabelValueCardinality{"
+	for _, f := range this.Values {
+		repeatedStringForValues += strings.Replace(strings.Replace(f.String(), "LabelValueCardinality", "LabelValueCardinality", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForValues += "}"
+	s := strings.Join([]string{`&LabelCardinality{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`Values:` + repeatedStringForValues + `,`,
+		`LabelValuesCount:` + fmt.Sprintf("%v", this.LabelValuesCount) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValueCardinality) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValueCardinality{`,
+		`LabelValue:` + fmt.Sprintf("%v", this.LabelValue) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *UserStatsRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -4708,8 +5901,799 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Matchers = append(m.Matchers, &LabelMatcher{})
-			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, cortexpb.TimeSeries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryStreamResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunkseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chunkseries = append(m.Chunkseries, TimeSeriesChunk{})
+			if err := m.Chunkseries[len(m.Chunkseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, cortexpb.TimeSeries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExemplarQueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExemplarQueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExemplarQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+			}
+			m.StartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			}
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatchers{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExemplarQueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExemplarQueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExemplarQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timeseries = append(m.Timeseries, cortexpb.TimeSeries{})
+			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+			}
+			m.StartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			}
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Matchers == nil {
+				m.Matchers = &LabelMatchers{}
+			}
+			if err := m.Matchers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValues", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelValues = append(m.LabelValues, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelNamesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelNamesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+			}
+			m.StartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			}
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Matchers == nil {
+				m.Matchers = &LabelMatchers{}
+			}
+			if err := m.Matchers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4737,7 +6721,7 @@ func (m *QueryRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4760,17 +6744,17 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelNamesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelNamesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -4780,25 +6764,23 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngester
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Timeseries = append(m.Timeseries, cortexpb.TimeSeries{})
-			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4824,7 +6806,7 @@ func (m *QueryResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelCardinalityRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4847,17 +6829,17 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryStreamResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelCardinalityRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Chunkseries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -4867,29 +6849,27 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngester
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Chunkseries = append(m.Chunkseries, TimeSeriesChunk{})
-			if err := m.Chunkseries[len(m.Chunkseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeseries", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4916,11 +6896,33 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Timeseries = append(m.Timeseries, cortexpb.TimeSeries{})
-			if err := m.Timeseries[len(m.Timeseries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Matchers == nil {
+				m.Matchers = &LabelMatchers{}
+			}
+			if err := m.Matchers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNamesOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LabelNamesOnly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -4945,7 +6947,7 @@ func (m *QueryStreamResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
+func (m *LabelCardinalityResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4968,85 +6970,15 @@ func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelCardinalityResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIngester
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthIngester
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.LabelName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
-			}
-			m.StartTimestampMs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIngester
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StartTimestampMs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
-			}
-			m.EndTimestampMs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIngester
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.EndTimestampMs |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5073,10 +7005,8 @@ func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Matchers == nil {
-				m.Matchers = &LabelMatchers{}
-			}
-			if err := m.Matchers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Items = append(m.Items, &LabelCardinality{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -5104,7 +7034,7 @@ func (m *LabelValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelCardinality) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5127,15 +7057,15 @@ func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelCardinality: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelCardinality: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelValues", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5163,66 +7093,47 @@ func (m *LabelValuesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelValues = append(m.LabelValues, string(dAtA[iNdEx:postIndex]))
+			m.LabelName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipIngester(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthIngester
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowIngester
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Values = append(m.Values, LabelValueCardinality{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValuesCount", wireType)
 			}
-			m.StartTimestampMs = 0
+			m.LabelValuesCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -5232,16 +7143,16 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTimestampMs |= int64(b&0x7F) << shift
+				m.LabelValuesCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
 			}
-			m.EndTimestampMs = 0
+			m.SeriesCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -5251,7 +7162,7 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndTimestampMs |= int64(b&0x7F) << shift
+				m.SeriesCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5280,7 +7191,7 @@ func (m *LabelNamesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
+func (m *LabelValueCardinality) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5303,15 +7214,15 @@ func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValueCardinality: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValueCardinality: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValue", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5339,8 +7250,27 @@ func (m *LabelNamesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			m.LabelValue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -0,0 +1,243 @@
+package ingester
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/prometheus/pkg/exemplar"
+	"github.com/prometheus/prometheus/pkg/labels"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+)
+
+const (
+	exemplarOutOfOrder = "exemplar-out-of-order"
+)
+
+var (
+	errOutOfOrderExemplar = errors.New("out of order exemplar")
+	errDuplicateExemplar  = errors.New("duplicate exemplar")
+)
+
+// exemplarStorage is an in-memory, fixed size, circular buffer of exemplars
+// shared by all series of a tenant. Once the buffer is full, the oldest
+// exemplar is overwritten by the newest one, regardless of the series
+// it belongs to. Exemplars of the same series are linked together, from
+// the oldest to the newest, so that they can be looked up without scanning
+// the whole buffer. Exemplars are not written to the WAL, so they are lost
+// when the ingester restarts.
+type exemplarStorage struct {
+	mtx       sync.RWMutex
+	exemplars []*exemplarEntry
+	nextIndex int
+
+	// Map of series labels to the index entry of the series.
+	index map[string]*exemplarIndexEntry
+}
+
+type exemplarIndexEntry struct {
+	key          string
+	seriesLabels labels.Labels
+	oldest       int
+	newest       int
+}
+
+type exemplarEntry struct {
+	exemplar exemplar.Exemplar
+	series   *exemplarIndexEntry
+	next     int // Index of the next exemplar of the same series, or -1 if none.
+}
+
+func newExemplarStorage(size int) *exemplarStorage {
+	if size < 0 {
+		size = 0
+	}
+
+	return &exemplarStorage{
+		exemplars: make([]*exemplarEntry, size),
+		index:     map[string]*exemplarIndexEntry{},
+	}
+}
+
+// Size returns the maximum number of exemplars the storage can hold.
+func (s *exemplarStorage) Size() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return len(s.exemplars)
+}
+
+// Resize changes the maximum number of exemplars the storage can hold. If the storage
+// shrinks, the oldest exemplars are discarded.
+func (s *exemplarStorage) Resize(size int) {
+	if size < 0 {
+		size = 0
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if size == len(s.exemplars) {
+		return
+	}
+
+	old := s.exemplars
+	oldNext := s.nextIndex
+
+	s.exemplars = make([]*exemplarEntry, size)
+	s.nextIndex = 0
+	s.index = map[string]*exemplarIndexEntry{}
+
+	if size == 0 {
+		return
+	}
+
+	// Re-add the exemplars from the oldest to the newest, skipping the ones which
+	// wouldn't fit in the new buffer.
+	count := 0
+	for i := 0; i < len(old); i++ {
+		if old[(oldNext+i)%len(old)] != nil {
+			count++
+		}
+	}
+
+	skip := count - size
+	for i := 0; i < len(old); i++ {
+		entry := old[(oldNext+i)%len(old)]
+		if entry == nil {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		s.append(entry.series.key, entry.series.seriesLabels, entry.exemplar)
+	}
+}
+
+// AddExemplar adds an exemplar for the input series. The series labels and exemplar
+// labels are retained, so the caller must not reuse their memory.
+func (s *exemplarStorage) AddExemplar(seriesLabels labels.Labels, e exemplar.Exemplar) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if len(s.exemplars) == 0 {
+		return nil
+	}
+
+	key := seriesLabels.String()
+
+	// Exemplars of a series are expected to be appended in order.
+	if idx, ok := s.index[key]; ok {
+		newest := s.exemplars[idx.newest].exemplar
+		if newest.Ts == e.Ts && newest.Value == e.Value && labels.Equal(newest.Labels, e.Labels) {
+			return errDuplicateExemplar
+		}
+		if e.Ts <= newest.Ts {
+			return errOutOfOrderExemplar
+		}
+	}
+
+	s.append(key, seriesLabels, e)
+	return nil
+}
+
+// append must be called with the lock held.
+func (s *exemplarStorage) append(key string, seriesLabels labels.Labels, e exemplar.Exemplar) {
+	// Evict the oldest exemplar, if the buffer is full.
+	if prev := s.exemplars[s.nextIndex]; prev != nil {
+		if prev.next == -1 {
+			delete(s.index, prev.series.key)
+		} else {
+			prev.series.oldest = prev.next
+		}
+	}
+
+	idx, ok := s.index[key]
+	if !ok {
+		idx = &exemplarIndexEntry{key: key, seriesLabels: seriesLabels, oldest: s.nextIndex}
+		s.index[key] = idx
+	} else {
+		s.exemplars[idx.newest].next = s.nextIndex
+	}
+
+	s.exemplars[s.nextIndex] = &exemplarEntry{exemplar: e, series: idx, next: -1}
+	idx.newest = s.nextIndex
+	s.nextIndex = (s.nextIndex + 1) % len(s.exemplars)
+}
+
+// Select returns the exemplars within the input time range (both inclusive) of
+// all series matching at least one of the input matchers sets. The returned
+// series are sorted by labels and their exemplars are sorted by timestamp.
+func (s *exemplarStorage) Select(start, end int64, matchers ...[]*labels.Matcher) []cortexpb.TimeSeries {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var result []cortexpb.TimeSeries
+
+	for _, idx := range s.index {
+		if !matchesAnySet(idx.seriesLabels, matchers) {
+			continue
+		}
+
+		var exemplars []cortexpb.Exemplar
+		for i := idx.oldest; i != -1; i = s.exemplars[i].next {
+			e := s.exemplars[i].exemplar
+			if e.Ts < start {
+				continue
+			}
+			if e.Ts > end {
+				break
+			}
+
+			exemplars = append(exemplars, cortexpb.Exemplar{
+				Labels:      cortexpb.FromLabelsToLabelAdapters(e.Labels),
+				Value:       e.Value,
+				TimestampMs: e.Ts,
+			})
+		}
+
+		if len(exemplars) == 0 {
+			continue
+		}
+
+		result = append(result, cortexpb.TimeSeries{
+			Labels:    cortexpb.FromLabelsToLabelAdapters(idx.seriesLabels),
+			Exemplars: exemplars,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return labels.Compare(cortexpb.FromLabelAdaptersToLabels(result[i].Labels), cortexpb.FromLabelAdaptersToLabels(result[j].Labels)) < 0
+	})
+
+	return result
+}
+
+func matchesAnySet(lbls labels.Labels, matchersSets [][]*labels.Matcher) bool {
+	for _, matchers := range matchersSets {
+		if matchesAll(lbls, matchers) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAll(lbls labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(lbls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasExemplars(series []cortexpb.PreallocTimeseries) bool {
+	for _, ts := range series {
+		if len(ts.Exemplars) > 0 {
+			return true
+		}
+	}
+	return false
+}
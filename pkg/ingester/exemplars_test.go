@@ -0,0 +1,157 @@
+package ingester
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/exemplar"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+)
+
+func TestExemplarStorage_AddExemplar(t *testing.T) {
+	series := labels.FromStrings(labels.MetricName, "test")
+	traceID := labels.FromStrings("trace_id", "123")
+
+	s := newExemplarStorage(3)
+	require.NoError(t, s.AddExemplar(series, exemplar.Exemplar{Labels: traceID, Value: 1, Ts: 10}))
+
+	assert.Equal(t, errDuplicateExemplar, s.AddExemplar(series, exemplar.Exemplar{Labels: traceID, Value: 1, Ts: 10}))
+	assert.Equal(t, errOutOfOrderExemplar, s.AddExemplar(series, exemplar.Exemplar{Labels: traceID, Value: 2, Ts: 10}))
+	assert.Equal(t, errOutOfOrderExemplar, s.AddExemplar(series, exemplar.Exemplar{Labels: traceID, Value: 2, Ts: 5}))
+
+	// Exemplars of other series are not affected.
+	require.NoError(t, s.AddExemplar(labels.FromStrings(labels.MetricName, "other"), exemplar.Exemplar{Labels: traceID, Value: 2, Ts: 5}))
+}
+
+func TestExemplarStorage_AddExemplarShouldBeNoopIfDisabled(t *testing.T) {
+	s := newExemplarStorage(0)
+	require.NoError(t, s.AddExemplar(labels.FromStrings(labels.MetricName, "test"), exemplar.Exemplar{Value: 1, Ts: 10}))
+	assert.Empty(t, s.Select(0, 100, []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+")}))
+}
+
+func TestExemplarStorage_ShouldEvictOldestExemplarWhenFull(t *testing.T) {
+	first := labels.FromStrings(labels.MetricName, "first")
+	second := labels.FromStrings(labels.MetricName, "second")
+	all := []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+")}
+
+	s := newExemplarStorage(3)
+	require.NoError(t, s.AddExemplar(first, exemplar.Exemplar{Value: 1, Ts: 10}))
+	require.NoError(t, s.AddExemplar(second, exemplar.Exemplar{Value: 2, Ts: 10}))
+	require.NoError(t, s.AddExemplar(first, exemplar.Exemplar{Value: 3, Ts: 20}))
+	require.NoError(t, s.AddExemplar(second, exemplar.Exemplar{Value: 4, Ts: 20}))
+
+	assert.Equal(t, []cortexpb.TimeSeries{
+		{
+			Labels:    cortexpb.FromLabelsToLabelAdapters(first),
+			Exemplars: []cortexpb.Exemplar{{Value: 3, TimestampMs: 20}},
+		}, {
+			Labels: cortexpb.FromLabelsToLabelAdapters(second),
+			Exemplars: []cortexpb.Exemplar{
+				{Value: 2, TimestampMs: 10},
+				{Value: 4, TimestampMs: 20},
+			},
+		},
+	}, s.Select(0, 100, all))
+
+	// Once all exemplars of a series have been evicted, the series is removed from the index.
+	require.NoError(t, s.AddExemplar(second, exemplar.Exemplar{Value: 5, Ts: 30}))
+	require.NoError(t, s.AddExemplar(second, exemplar.Exemplar{Value: 6, Ts: 40}))
+
+	res := s.Select(0, 100, all)
+	require.Len(t, res, 1)
+	assert.Equal(t, cortexpb.FromLabelsToLabelAdapters(second), res[0].Labels)
+	assert.Len(t, res[0].Exemplars, 3)
+	assert.Len(t, s.index, 1)
+}
+
+func TestExemplarStorage_Select(t *testing.T) {
+	first := labels.FromStrings(labels.MetricName, "first", "job", "a")
+	second := labels.FromStrings(labels.MetricName, "second", "job", "b")
+
+	s := newExemplarStorage(10)
+	for ts := int64(10); ts <= 50; ts += 10 {
+		require.NoError(t, s.AddExemplar(first, exemplar.Exemplar{Value: float64(ts), Ts: ts}))
+		require.NoError(t, s.AddExemplar(second, exemplar.Exemplar{Value: float64(ts), Ts: ts}))
+	}
+
+	tests := map[string]struct {
+		start, end int64
+		matchers   [][]*labels.Matcher
+		expected   map[string][]int64
+	}{
+		"should return exemplars of the matching series within the time range": {
+			start:    20,
+			end:      40,
+			matchers: [][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, "job", "a")}},
+			expected: map[string][]int64{first.String(): {20, 30, 40}},
+		},
+		"should return exemplars of series matching any of the matchers sets": {
+			start: 50,
+			end:   100,
+			matchers: [][]*labels.Matcher{
+				{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "first")},
+				{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "second")},
+			},
+			expected: map[string][]int64{first.String(): {50}, second.String(): {50}},
+		},
+		"should return no series if no exemplar is within the time range": {
+			start:    60,
+			end:      100,
+			matchers: [][]*labels.Matcher{{labels.MustNewMatcher(labels.MatchEqual, "job", "a")}},
+			expected: map[string][]int64{},
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual := map[string][]int64{}
+			for _, series := range s.Select(testData.start, testData.end, testData.matchers...) {
+				key := cortexpb.FromLabelAdaptersToLabels(series.Labels).String()
+				for _, e := range series.Exemplars {
+					actual[key] = append(actual[key], e.TimestampMs)
+				}
+			}
+
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
+
+func TestExemplarStorage_Resize(t *testing.T) {
+	series := labels.FromStrings(labels.MetricName, "test")
+	all := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")}
+
+	s := newExemplarStorage(5)
+	for ts := int64(1); ts <= 7; ts++ {
+		require.NoError(t, s.AddExemplar(series, exemplar.Exemplar{Value: float64(ts), Ts: ts}))
+	}
+
+	timestamps := func() []int64 {
+		var out []int64
+		for _, series := range s.Select(0, 100, all) {
+			for _, e := range series.Exemplars {
+				out = append(out, e.TimestampMs)
+			}
+		}
+		return out
+	}
+
+	require.Equal(t, []int64{3, 4, 5, 6, 7}, timestamps())
+
+	// Shrinking should keep the newest exemplars.
+	s.Resize(2)
+	assert.Equal(t, 2, s.Size())
+	assert.Equal(t, []int64{6, 7}, timestamps())
+
+	// Growing should keep all the exemplars.
+	s.Resize(4)
+	require.NoError(t, s.AddExemplar(series, exemplar.Exemplar{Value: 8, Ts: 8}))
+	assert.Equal(t, []int64{6, 7, 8}, timestamps())
+
+	// Resizing to 0 should drop all the exemplars.
+	s.Resize(0)
+	assert.Empty(t, timestamps())
+}
@@ -842,6 +842,20 @@ func (i *Ingester) QueryStream(req *client.QueryRequest, stream client.Ingester_
 	return err
 }
 
+// QueryExemplars returns the exemplars of the series matching the input request.
+// Exemplars are only supported by the blocks storage.
+func (i *Ingester) QueryExemplars(ctx context.Context, req *client.ExemplarQueryRequest) (*client.ExemplarQueryResponse, error) {
+	if err := i.checkRunningOrStopping(); err != nil {
+		return nil, err
+	}
+
+	if i.cfg.BlocksStorageEnabled {
+		return i.v2QueryExemplars(ctx, req)
+	}
+
+	return &client.ExemplarQueryResponse{}, nil
+}
+
 // LabelValues returns all label values that are associated with a given label name.
 func (i *Ingester) LabelValues(ctx context.Context, req *client.LabelValuesRequest) (*client.LabelValuesResponse, error) {
 	if err := i.checkRunningOrStopping(); err != nil {
@@ -886,12 +900,45 @@ func (i *Ingester) LabelNames(ctx context.Context, req *client.LabelNamesRequest
 		return &client.LabelNamesResponse{}, nil
 	}
 
+	// TODO Right now we ignore start and end.
+	_, _, matchers, err := client.FromLabelNamesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := &client.LabelNamesResponse{}
-	resp.LabelNames = append(resp.LabelNames, state.index.LabelNames()...)
+	if len(matchers) == 0 {
+		resp.LabelNames = append(resp.LabelNames, state.index.LabelNames()...)
+		return resp, nil
+	}
 
+	names := map[string]struct{}{}
+	if err := state.forSeriesMatching(ctx, matchers, func(ctx context.Context, fp model.Fingerprint, series *memorySeries) error {
+		for _, l := range series.metric {
+			names[l.Name] = struct{}{}
+		}
+		return nil
+	}, nil, 0); err != nil {
+		return nil, err
+	}
+
+	resp.LabelNames = sortedKeys(names)
 	return resp, nil
 }
 
+// LabelCardinality returns the number of series for each label name and value pair.
+func (i *Ingester) LabelCardinality(ctx context.Context, req *client.LabelCardinalityRequest) (*client.LabelCardinalityResponse, error) {
+	if err := i.checkRunningOrStopping(); err != nil {
+		return nil, err
+	}
+
+	if i.cfg.BlocksStorageEnabled {
+		return i.v2LabelCardinality(ctx, req)
+	}
+
+	return &client.LabelCardinalityResponse{}, nil
+}
+
 // MetricsForLabelMatchers returns all the metrics which match a set of matchers.
 func (i *Ingester) MetricsForLabelMatchers(ctx context.Context, req *client.MetricsForLabelMatchersRequest) (*client.MetricsForLabelMatchersResponse, error) {
 	if err := i.checkRunningOrStopping(); err != nil {
@@ -18,9 +18,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/exemplar"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
 	"github.com/thanos-io/thanos/pkg/block/metadata"
 	"github.com/thanos-io/thanos/pkg/objstore"
 	"github.com/thanos-io/thanos/pkg/shipper"
@@ -38,6 +40,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/util/extract"
 	logutil "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 	"github.com/cortexproject/cortex/pkg/util/spanlogger"
 	"github.com/cortexproject/cortex/pkg/util/validation"
 )
@@ -45,6 +48,7 @@ import (
 const (
 	errTSDBCreateIncompatibleState = "cannot create a new TSDB while the ingester is not in active state (current state: %s)"
 	errTSDBIngest                  = "err: %v. timestamp=%s, series=%s" // Using error.Wrap puts the message before the error and if the series is too long, its truncated.
+	errTSDBIngestExemplar          = "err: %v. timestamp=%s, series=%s, exemplar=%s"
 )
 
 // Shipper interface is used to have an easy way to mock it in tests.
@@ -90,6 +94,15 @@ type userTSDB struct {
 	activeSeries   *ActiveSeries
 	seriesInMetric *metricCounter
 	limiter        *Limiter
+	exemplars      *exemplarStorage
+	outOfOrder     *outOfOrderHead
+
+	// Out-of-order samples are ingested only if the TSDB has been opened allowing overlapping blocks.
+	outOfOrderAllowed bool
+
+	// Blocks written from out-of-order samples, which are queried from here until the TSDB loads them.
+	outOfOrderBlocksMtx sync.RWMutex
+	outOfOrderBlocks    []*tsdb.Block
 
 	stateMtx       sync.RWMutex
 	state          tsdbState
@@ -124,7 +137,39 @@ func (u *userTSDB) Appender(ctx context.Context) storage.Appender {
 }
 
 func (u *userTSDB) Querier(ctx context.Context, mint, maxt int64) (storage.Querier, error) {
-	return u.db.Querier(ctx, mint, maxt)
+	q, err := u.db.Querier(ctx, mint, maxt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Out-of-order samples are queryable as soon as they're ingested, so we merge them with the TSDB ones.
+	queriers := []storage.Querier{q}
+	if !u.outOfOrder.empty() {
+		queriers = append(queriers, u.outOfOrder.querier(mint, maxt))
+	}
+
+	u.outOfOrderBlocksMtx.RLock()
+	defer u.outOfOrderBlocksMtx.RUnlock()
+
+	for _, b := range u.outOfOrderBlocks {
+		if !b.OverlapsClosedInterval(mint, maxt) {
+			continue
+		}
+
+		bq, err := tsdb.NewBlockQuerier(b, mint, maxt)
+		if err != nil {
+			for _, q := range queriers {
+				_ = q.Close()
+			}
+			return nil, err
+		}
+		queriers = append(queriers, bq)
+	}
+
+	if len(queriers) == 1 {
+		return q, nil
+	}
+	return storage.NewMergeQuerier(queriers, nil, storage.ChainedSeriesMerge), nil
 }
 
 func (u *userTSDB) Head() *tsdb.Head {
@@ -136,11 +181,117 @@ func (u *userTSDB) Blocks() []*tsdb.Block {
 }
 
 func (u *userTSDB) Close() error {
-	return u.db.Close()
+	// Out-of-order samples are written to blocks before closing, so that they're loaded
+	// by the TSDB once opened again, while their WAL is replayed only after a crash.
+	_, err := u.outOfOrder.writeBlocks(math.MaxInt64)
+
+	if closeErr := u.closeOutOfOrderBlocks(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if closeErr := u.outOfOrder.close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if closeErr := u.db.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return err
 }
 
 func (u *userTSDB) Compact() error {
-	return u.db.Compact()
+	minTime := u.Head().MinTime()
+
+	if err := u.db.Compact(); err != nil {
+		return err
+	}
+
+	if err := u.releaseOutOfOrderBlocks(); err != nil {
+		return err
+	}
+
+	// Out-of-order samples older than the head are written to blocks only when the head
+	// has been compacted, so that we don't create a block at every compaction interval.
+	if u.Head().MinTime() == minTime {
+		return nil
+	}
+
+	return u.compactOutOfOrder(u.Head().MinTime())
+}
+
+// compactOutOfOrder writes the out-of-order samples with timestamp lower than maxt to blocks.
+// The TSDB loads new blocks only when reloading them after a compaction, so the written blocks
+// are opened and queried by the userTSDB until then. Overlapping blocks are vertically compacted
+// by the TSDB.
+func (u *userTSDB) compactOutOfOrder(maxt int64) error {
+	dirs, err := u.outOfOrder.writeBlocks(maxt)
+
+	u.outOfOrderBlocksMtx.Lock()
+	defer u.outOfOrderBlocksMtx.Unlock()
+
+	for _, dir := range dirs {
+		b, openErr := tsdb.OpenBlock(u.outOfOrder.logger, dir, nil)
+		if openErr != nil {
+			if err == nil {
+				err = errors.Wrapf(openErr, "open block written from out-of-order samples: %s", dir)
+			}
+			continue
+		}
+		u.outOfOrderBlocks = append(u.outOfOrderBlocks, b)
+	}
+
+	return err
+}
+
+// releaseOutOfOrderBlocks closes the blocks written from out-of-order samples which have been
+// loaded by the TSDB, or which have been already compacted by the TSDB and deleted.
+func (u *userTSDB) releaseOutOfOrderBlocks() error {
+	u.outOfOrderBlocksMtx.Lock()
+	defer u.outOfOrderBlocksMtx.Unlock()
+
+	if len(u.outOfOrderBlocks) == 0 {
+		return nil
+	}
+
+	loaded := map[ulid.ULID]struct{}{}
+	for _, b := range u.db.Blocks() {
+		loaded[b.Meta().ULID] = struct{}{}
+	}
+
+	var err error
+	kept := u.outOfOrderBlocks[:0]
+
+	for _, b := range u.outOfOrderBlocks {
+		if _, ok := loaded[b.Meta().ULID]; !ok {
+			if _, statErr := os.Stat(b.Dir()); statErr == nil {
+				kept = append(kept, b)
+				continue
+			}
+		}
+
+		// Closing the block waits until in-flight queries are done.
+		if closeErr := b.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	u.outOfOrderBlocks = kept
+
+	return err
+}
+
+// closeOutOfOrderBlocks closes all the blocks written from out-of-order samples.
+func (u *userTSDB) closeOutOfOrderBlocks() error {
+	u.outOfOrderBlocksMtx.Lock()
+	defer u.outOfOrderBlocksMtx.Unlock()
+
+	var err error
+	for _, b := range u.outOfOrderBlocks {
+		if closeErr := b.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	u.outOfOrderBlocks = nil
+
+	return err
 }
 
 func (u *userTSDB) StartTime() (int64, error) {
@@ -172,21 +323,28 @@ func (u *userTSDB) compactHead(blockDuration int64) error {
 
 	h := u.Head()
 
-	minTime, maxTime := h.MinTime(), h.MaxTime()
+	if h.NumSeries() > 0 {
+		minTime, maxTime := h.MinTime(), h.MaxTime()
 
-	for (minTime/blockDuration)*blockDuration != (maxTime/blockDuration)*blockDuration {
-		// Data in Head spans across multiple block ranges, so we break it into blocks here.
-		// Block max time is exclusive, so we do a -1 here.
-		blockMaxTime := ((minTime/blockDuration)+1)*blockDuration - 1
-		if err := u.db.CompactHead(tsdb.NewRangeHead(h, minTime, blockMaxTime)); err != nil {
-			return err
+		for (minTime/blockDuration)*blockDuration != (maxTime/blockDuration)*blockDuration {
+			// Data in Head spans across multiple block ranges, so we break it into blocks here.
+			// Block max time is exclusive, so we do a -1 here.
+			blockMaxTime := ((minTime/blockDuration)+1)*blockDuration - 1
+			if err := u.db.CompactHead(tsdb.NewRangeHead(h, minTime, blockMaxTime)); err != nil {
+				return err
+			}
+
+			// Get current min/max times after compaction.
+			minTime, maxTime = h.MinTime(), h.MaxTime()
 		}
 
-		// Get current min/max times after compaction.
-		minTime, maxTime = h.MinTime(), h.MaxTime()
+		if err := u.db.CompactHead(tsdb.NewRangeHead(h, minTime, maxTime)); err != nil {
+			return err
+		}
 	}
 
-	return u.db.CompactHead(tsdb.NewRangeHead(h, minTime, maxTime))
+	// All out-of-order samples are written to blocks too, so that nothing is left in memory.
+	return u.compactOutOfOrder(math.MaxInt64)
 }
 
 // PreCreation implements SeriesLifecycleCallback interface.
@@ -212,6 +370,43 @@ func (u *userTSDB) PreCreation(metric labels.Labels) error {
 	return nil
 }
 
+// assertMaxOutOfOrderSeries implements outOfOrderLimiter. Out-of-order series are counted together
+// with the TSDB head ones, so series in both heads are counted twice to err on the safe side.
+func (u *userTSDB) assertMaxOutOfOrderSeries(metric labels.Labels, numSeries, numSeriesForMetric int) error {
+	if u.limiter == nil {
+		return nil
+	}
+
+	// Total series limit.
+	if err := u.limiter.AssertMaxSeriesPerUser(u.userID, int(u.Head().NumSeries())+numSeries); err != nil {
+		return makeLimitError(perUserSeriesLimit, err)
+	}
+
+	// Series per metric name limit.
+	metricName, err := extract.MetricNameFromLabels(metric)
+	if err != nil {
+		return err
+	}
+	if err := u.limiter.AssertMaxSeriesPerMetric(u.userID, u.seriesInMetric.seriesForMetric(metricName)+numSeriesForMetric); err != nil {
+		return makeMetricLimitError(perMetricSeriesLimit, metric, err)
+	}
+
+	return nil
+}
+
+// assertMaxOutOfOrderSamples implements outOfOrderLimiter.
+func (u *userTSDB) assertMaxOutOfOrderSamples(numSamples int) error {
+	if u.limiter == nil {
+		return nil
+	}
+
+	if err := u.limiter.AssertMaxOutOfOrderSamplesPerUser(u.userID, numSamples); err != nil {
+		return makeLimitError(perUserOutOfOrderSamplesLimit, err)
+	}
+
+	return nil
+}
+
 // PostCreation implements SeriesLifecycleCallback interface.
 func (u *userTSDB) PostCreation(metric labels.Labels) {
 	metricName, err := extract.MetricNameFromLabels(metric)
@@ -307,6 +502,17 @@ func (u *userTSDB) getOldestUnshippedBlockTime() uint64 {
 	return oldestTs
 }
 
+// isWithinOutOfOrderTimeWindow returns true if a sample with timestamp t can be ingested as out-of-order
+// sample, given the input out-of-order time window.
+func (u *userTSDB) isWithinOutOfOrderTimeWindow(t int64, window time.Duration) bool {
+	if window <= 0 || !u.outOfOrderAllowed {
+		return false
+	}
+
+	maxTime := u.Head().MaxTime()
+	return maxTime != math.MinInt64 && t >= maxTime-window.Milliseconds()
+}
+
 func (u *userTSDB) isIdle(now time.Time, idle time.Duration) bool {
 	lu := u.lastUpdate.Load()
 
@@ -328,7 +534,7 @@ func (u *userTSDB) shouldCloseTSDB(idleTimeout time.Duration) (tsdbCloseCheckRes
 	}
 
 	// If head is not compacted, we cannot close this yet.
-	if u.Head().NumSeries() > 0 {
+	if u.Head().NumSeries() > 0 || !u.outOfOrder.empty() {
 		return tsdbNotCompacted, nil
 	}
 
@@ -696,10 +902,23 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 	// successfully committed
 	succeededSamplesCount := 0
 	failedSamplesCount := 0
+	succeededExemplarsCount := 0
+	failedExemplarsCount := 0
+	outOfOrderSamplesCount := 0
+	outOfOrderSamplesFailCount := 0
+	outOfOrderTimeWindow := i.limits.OutOfOrderTimeWindow(userID)
 	startAppend := time.Now()
 
-	// Walk the samples, appending them to the users database
+	// The exemplars storage is resized on demand, in order to honor
+	// the per-tenant limit changes and ingesters scaling.
+	if hasExemplars(req.Timeseries) {
+		db.exemplars.Resize(i.limiter.maxExemplarsPerUser(userID))
+	}
+
+	// Walk the samples, appending them to the users database. Out-of-order samples
+	// are appended to the out-of-order head, once the first one is found.
 	app := db.Appender(ctx)
+	var outOfOrderApp *outOfOrderAppender
 	for _, ts := range req.Timeseries {
 		// Keeps a reference to labels copy, if it was needed. This is to avoid making a copy twice,
 		// once for TSDB/refcache, and second time for activeSeries map.
@@ -747,6 +966,30 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 				}
 			}
 
+			// Samples rejected by the TSDB because out of order are appended to the out-of-order
+			// head, as long as they're within the tenant's out-of-order time window.
+			if cause := errors.Cause(err); cause == storage.ErrOutOfOrderSample || cause == storage.ErrOutOfBounds {
+				if db.isWithinOutOfOrderTimeWindow(s.TimestampMs, outOfOrderTimeWindow) {
+					if copiedLabels == nil {
+						copiedLabels = client.FromLabelAdaptersToLabelsWithCopy(ts.Labels)
+					}
+					if outOfOrderApp == nil {
+						outOfOrderApp = db.outOfOrder.appender()
+					}
+
+					// The TSDB head rejects a sample as out-of-order only if the series exists.
+					inHead := cachedRefExists || cause == storage.ErrOutOfOrderSample
+
+					if err = outOfOrderApp.add(copiedLabels, s.TimestampMs, s.Value, inHead); err == nil {
+						succeededSamplesCount++
+						outOfOrderSamplesCount++
+						continue
+					}
+				}
+
+				outOfOrderSamplesFailCount++
+			}
+
 			failedSamplesCount++
 
 			// Check if the error is a soft error we can proceed on. If so, we keep track
@@ -785,10 +1028,49 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 			if rollbackErr := app.Rollback(); rollbackErr != nil {
 				level.Warn(i.logger).Log("msg", "failed to rollback on error", "user", userID, "err", rollbackErr)
 			}
+			if outOfOrderApp != nil {
+				outOfOrderApp.rollback()
+			}
 
 			return nil, wrapWithUser(err, userID)
 		}
 
+		// Exemplars are stored only if at least one sample of the series has been
+		// successfully appended (or the series has no samples at all), so that we
+		// don't store exemplars for series rejected by limits.
+		if len(ts.Exemplars) > 0 && (len(ts.Samples) == 0 || succeededSamplesCount > oldSucceededSamplesCount) {
+			// Copy the label sets because the exemplars storage retains them.
+			if copiedLabels == nil {
+				copiedLabels = client.FromLabelAdaptersToLabelsWithCopy(ts.Labels)
+			}
+
+			for _, ex := range ts.Exemplars {
+				e := exemplar.Exemplar{
+					Labels: client.FromLabelAdaptersToLabelsWithCopy(ex.Labels),
+					Value:  ex.Value,
+					Ts:     ex.TimestampMs,
+					HasTs:  true,
+				}
+
+				err := db.exemplars.AddExemplar(copiedLabels, e)
+				if err == nil {
+					succeededExemplarsCount++
+					continue
+				}
+
+				// Duplicate exemplars are silently ignored, as it happens in Prometheus.
+				if errors.Is(err, errDuplicateExemplar) {
+					continue
+				}
+
+				failedExemplarsCount++
+				validation.DiscardedExemplars.WithLabelValues(exemplarOutOfOrder, userID).Inc()
+				if firstPartialErr == nil {
+					firstPartialErr = wrappedTSDBIngestExemplarErr(err, model.Time(ex.TimestampMs), ts.Labels, ex.Labels)
+				}
+			}
+		}
+
 		if i.cfg.ActiveSeriesMetricsEnabled && succeededSamplesCount > oldSucceededSamplesCount {
 			db.activeSeries.UpdateSeries(client.FromLabelAdaptersToLabels(ts.Labels), startAppend, func(l labels.Labels) labels.Labels {
 				// If we have already made a copy during this push, no need to create new one.
@@ -805,8 +1087,16 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 
 	startCommit := time.Now()
 	if err := app.Commit(); err != nil {
+		if outOfOrderApp != nil {
+			outOfOrderApp.rollback()
+		}
 		return nil, wrapWithUser(err, userID)
 	}
+	if outOfOrderApp != nil {
+		if err := outOfOrderApp.commit(); err != nil {
+			return nil, wrapWithUser(err, userID)
+		}
+	}
 	i.TSDBState.appenderCommitDuration.Observe(time.Since(startCommit).Seconds())
 
 	// If only invalid samples are pushed, don't change "last update", as TSDB was not modified.
@@ -819,6 +1109,10 @@ func (i *Ingester) v2Push(ctx context.Context, req *client.WriteRequest) (*clien
 	// which will be converted into an HTTP 5xx and the client should/will retry.
 	i.metrics.ingestedSamples.Add(float64(succeededSamplesCount))
 	i.metrics.ingestedSamplesFail.Add(float64(failedSamplesCount))
+	i.metrics.ingestedExemplars.Add(float64(succeededExemplarsCount))
+	i.metrics.ingestedExemplarsFail.Add(float64(failedExemplarsCount))
+	i.metrics.outOfOrderSamples.Add(float64(outOfOrderSamplesCount))
+	i.metrics.outOfOrderSamplesFail.Add(float64(outOfOrderSamplesFailCount))
 
 	switch req.Source {
 	case client.RULE:
@@ -876,6 +1170,12 @@ func (i *Ingester) v2Query(ctx context.Context, req *client.QueryRequest) (*clie
 		return nil, err
 	}
 
+	// Series don't have the shard label, so the shard matcher is replaced by filtering series by shard.
+	shard, matchers, err := sharding.RemoveShardFromMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+
 	i.metrics.queries.Inc()
 
 	db := i.getTSDB(userID)
@@ -900,6 +1200,9 @@ func (i *Ingester) v2Query(ctx context.Context, req *client.QueryRequest) (*clie
 	result := &client.QueryResponse{}
 	for ss.Next() {
 		series := ss.At()
+		if shard != nil && !shard.Matches(series.Labels()) {
+			continue
+		}
 
 		ts := client.TimeSeries{
 			Labels: client.FromLabelsToLabelAdapters(series.Labels()),
@@ -921,6 +1224,29 @@ func (i *Ingester) v2Query(ctx context.Context, req *client.QueryRequest) (*clie
 	return result, ss.Err()
 }
 
+func (i *Ingester) v2QueryExemplars(ctx context.Context, req *client.ExemplarQueryRequest) (*client.ExemplarQueryResponse, error) {
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, through, matchers, err := client.FromExemplarQueryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	i.metrics.queries.Inc()
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return &client.ExemplarQueryResponse{}, nil
+	}
+
+	return &client.ExemplarQueryResponse{
+		Timeseries: db.exemplars.Select(from, through, matchers...),
+	}, nil
+}
+
 func (i *Ingester) v2LabelValues(ctx context.Context, req *client.LabelValuesRequest) (*client.LabelValuesResponse, error) {
 	labelName, startTimestampMs, endTimestampMs, matchers, err := client.FromLabelValuesRequest(req)
 	if err != nil {
@@ -969,7 +1295,12 @@ func (i *Ingester) v2LabelNames(ctx context.Context, req *client.LabelNamesReque
 		return &client.LabelNamesResponse{}, nil
 	}
 
-	mint, maxt, err := metadataQueryRange(req.StartTimestampMs, req.EndTimestampMs, db)
+	startTimestampMs, endTimestampMs, matchers, err := client.FromLabelNamesRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	mint, maxt, err := metadataQueryRange(startTimestampMs, endTimestampMs, db)
 	if err != nil {
 		return nil, err
 	}
@@ -980,16 +1311,137 @@ func (i *Ingester) v2LabelNames(ctx context.Context, req *client.LabelNamesReque
 	}
 	defer q.Close()
 
-	names, _, err := q.LabelNames()
-	if err != nil {
+	if len(matchers) == 0 {
+		names, _, err := q.LabelNames()
+		if err != nil {
+			return nil, err
+		}
+
+		return &client.LabelNamesResponse{
+			LabelNames: names,
+		}, nil
+	}
+
+	// The TSDB querier doesn't support matchers when looking up label names,
+	// so we look them up from the matching series, without reading chunks.
+	hints := &storage.SelectHints{
+		Start: mint,
+		End:   maxt,
+		Func:  "series", // There is no series function, this token is used for lookups that don't need samples.
+	}
+
+	names := map[string]struct{}{}
+	set := q.Select(false, hints, matchers...)
+	for set.Next() {
+		for _, l := range set.At().Labels() {
+			names[l.Name] = struct{}{}
+		}
+	}
+	if err := set.Err(); err != nil {
 		return nil, err
 	}
 
 	return &client.LabelNamesResponse{
-		LabelNames: names,
+		LabelNames: sortedKeys(names),
 	}, nil
 }
 
+func (i *Ingester) v2LabelCardinality(ctx context.Context, req *client.LabelCardinalityRequest) (*client.LabelCardinalityResponse, error) {
+	labelNames, matchers, err := client.FromLabelCardinalityRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db := i.getTSDB(userID)
+	if db == nil {
+		return &client.LabelCardinalityResponse{}, nil
+	}
+
+	idx, err := db.db.Head().Index()
+	if err != nil {
+		return nil, err
+	}
+	defer idx.Close()
+
+	// Find the series matching the input matchers, if any. A nil list means all series.
+	var matching []uint64
+	if len(matchers) > 0 {
+		p, err := tsdb.PostingsForMatchers(idx, matchers...)
+		if err != nil {
+			return nil, err
+		}
+
+		if matching, err = index.ExpandPostings(p); err != nil {
+			return nil, err
+		}
+		if len(matching) == 0 {
+			return &client.LabelCardinalityResponse{}, nil
+		}
+	}
+
+	if len(labelNames) == 0 {
+		if labelNames, err = idx.LabelNames(); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &client.LabelCardinalityResponse{}
+	for _, name := range labelNames {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		values, err := idx.SortedLabelValues(name)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &client.LabelCardinality{LabelName: name}
+		for _, value := range values {
+			p, err := idx.Postings(name, value)
+			if err != nil {
+				return nil, err
+			}
+			if matching != nil {
+				p = index.Intersect(p, index.NewListPostings(matching))
+			}
+
+			count := uint64(0)
+			for p.Next() {
+				count++
+			}
+			if err := p.Err(); err != nil {
+				return nil, err
+			}
+
+			if count == 0 {
+				continue
+			}
+
+			item.LabelValuesCount++
+			item.SeriesCount += count
+
+			// All values are returned, so that the distributor can merge the distinct values across
+			// ingesters, but their series count isn't when only label names have been requested.
+			if req.LabelNamesOnly {
+				count = 0
+			}
+			item.Values = append(item.Values, client.LabelValueCardinality{LabelValue: value, SeriesCount: count})
+		}
+
+		if item.LabelValuesCount > 0 {
+			resp.Items = append(resp.Items, item)
+		}
+	}
+
+	return resp, nil
+}
+
 func (i *Ingester) v2MetricsForLabelMatchers(ctx context.Context, req *client.MetricsForLabelMatchersRequest) (*client.MetricsForLabelMatchersResponse, error) {
 	userID, err := tenant.TenantID(ctx)
 	if err != nil {
@@ -1111,6 +1563,12 @@ func (i *Ingester) v2QueryStream(req *client.QueryRequest, stream client.Ingeste
 		return err
 	}
 
+	// Series don't have the shard label, so the shard matcher is replaced by filtering series by shard.
+	shard, matchers, err := sharding.RemoveShardFromMatchers(matchers)
+	if err != nil {
+		return err
+	}
+
 	i.metrics.queries.Inc()
 
 	db := i.getTSDB(userID)
@@ -1136,6 +1594,9 @@ func (i *Ingester) v2QueryStream(req *client.QueryRequest, stream client.Ingeste
 	numSeries := 0
 	for ss.Next() {
 		series := ss.At()
+		if shard != nil && !shard.Matches(series.Labels()) {
+			continue
+		}
 
 		// convert labels to LabelAdapter
 		ts := client.TimeSeries{
@@ -1263,9 +1724,18 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 		refCache:            cortex_tsdb.NewRefCache(),
 		activeSeries:        NewActiveSeries(),
 		seriesInMetric:      newMetricCounter(i.limiter),
+		exemplars:           newExemplarStorage(0),
 		ingestedAPISamples:  newEWMARate(0.2, i.cfg.RateUpdatePeriod),
 		ingestedRuleSamples: newEWMARate(0.2, i.cfg.RateUpdatePeriod),
 	}
+	userDB.outOfOrder = newOutOfOrderHead(udir, blockRanges[0], userDB, userLogger)
+
+	// Out-of-order samples are written to blocks overlapping with the other ones, so overlapping
+	// blocks are allowed if the tenant can ingest them, or has done it in the past.
+	outOfOrderWALDir := filepath.Join(udir, outOfOrderWALDir)
+	if _, err := os.Stat(outOfOrderWALDir); err == nil || i.limits.OutOfOrderTimeWindow(userID) > 0 {
+		userDB.outOfOrderAllowed = true
+	}
 
 	// Create a new user database
 	db, err := tsdb.Open(udir, userLogger, tsdbPromReg, &tsdb.Options{
@@ -1279,6 +1749,7 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 		WALSegmentSize:            i.cfg.BlocksStorageConfig.TSDB.WALSegmentSizeBytes,
 		SeriesLifecycleCallback:   userDB,
 		BlocksToDelete:            userDB.blocksToDelete,
+		AllowOverlappingBlocks:    userDB.outOfOrderAllowed,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open TSDB: %s", udir)
@@ -1294,6 +1765,13 @@ func (i *Ingester) createTSDB(userID string) (*userTSDB, error) {
 		return nil, errors.Wrapf(err, "failed to compact TSDB: %s", udir)
 	}
 
+	if userDB.outOfOrderAllowed {
+		if err := userDB.outOfOrder.openWAL(outOfOrderWALDir, i.cfg.BlocksStorageConfig.TSDB.WALSegmentSizeBytes, i.cfg.BlocksStorageConfig.TSDB.WALCompressionEnabled); err != nil {
+			_ = db.Close()
+			return nil, errors.Wrapf(err, "failed to open out-of-order WAL: %s", outOfOrderWALDir)
+		}
+	}
+
 	userDB.db = db
 	// We set the limiter here because we don't want to limit
 	// series during WAL replay.
@@ -1652,7 +2130,7 @@ func (i *Ingester) compactBlocks(ctx context.Context, force bool) {
 
 		// Don't do anything, if there is nothing to compact.
 		h := userDB.Head()
-		if h.NumSeries() == 0 {
+		if h.NumSeries() == 0 && userDB.outOfOrder.empty() {
 			return nil
 		}
 
@@ -1878,6 +2356,17 @@ func metadataQueryRange(queryStart, queryEnd int64, db *userTSDB) (mint, maxt in
 	return
 }
 
+func wrappedTSDBIngestExemplarErr(ingestErr error, timestamp model.Time, seriesLabels, exemplarLabels []client.LabelAdapter) error {
+	if ingestErr == nil {
+		return nil
+	}
+
+	return fmt.Errorf(errTSDBIngestExemplar, ingestErr, timestamp.Time().UTC().Format(time.RFC3339Nano),
+		client.FromLabelAdaptersToLabels(seriesLabels).String(),
+		client.FromLabelAdaptersToLabels(exemplarLabels).String(),
+	)
+}
+
 func wrappedTSDBIngestErr(ingestErr error, timestamp model.Time, labels []client.LabelAdapter) error {
 	if ingestErr == nil {
 		return nil
@@ -37,12 +37,14 @@ import (
 	"github.com/weaveworks/common/user"
 	"google.golang.org/grpc"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/ring"
 	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
 	"github.com/cortexproject/cortex/pkg/util"
 	util_math "github.com/cortexproject/cortex/pkg/util/math"
 	"github.com/cortexproject/cortex/pkg/util/services"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 	"github.com/cortexproject/cortex/pkg/util/test"
 	"github.com/cortexproject/cortex/pkg/util/validation"
 )
@@ -706,6 +708,14 @@ func Test_Ingester_v2LabelNames(t *testing.T) {
 	res, err := i.v2LabelNames(ctx, &client.LabelNamesRequest{})
 	require.NoError(t, err)
 	assert.ElementsMatch(t, expected, res.LabelNames)
+
+	// Get label names of the series matching the matchers.
+	req, err := client.ToLabelNamesRequest(0, 0, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test_2")})
+	require.NoError(t, err)
+
+	res, err = i.v2LabelNames(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"__name__"}, res.LabelNames)
 }
 
 func Test_Ingester_v2LabelValues(t *testing.T) {
@@ -755,6 +765,87 @@ func Test_Ingester_v2LabelValues(t *testing.T) {
 	}
 }
 
+func Test_Ingester_v2LabelCardinality(t *testing.T) {
+	series := []labels.Labels{
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "200"}, {Name: "route", Value: "get_user"}},
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "500"}, {Name: "route", Value: "get_user"}},
+		{{Name: labels.MetricName, Value: "test_1"}, {Name: "status", Value: "500"}, {Name: "route", Value: "get_users"}},
+		{{Name: labels.MetricName, Value: "test_2"}},
+	}
+
+	// Create ingester
+	i, err := prepareIngesterWithBlocksStorage(t, defaultIngesterTestConfig(), nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	// Push series
+	ctx := user.InjectOrgID(context.Background(), "test")
+
+	for _, lbls := range series {
+		req, _, _ := mockWriteRequest(lbls, 1, 100000)
+		_, err := i.v2Push(ctx, req)
+		require.NoError(t, err)
+	}
+
+	tests := map[string]struct {
+		labelNames     []string
+		labelNamesOnly bool
+		matchers       []*labels.Matcher
+		expected       []*client.LabelCardinality
+	}{
+		"all label names": {
+			expected: []*client.LabelCardinality{
+				{LabelName: labels.MetricName, Values: []client.LabelValueCardinality{{LabelValue: "test_1", SeriesCount: 3}, {LabelValue: "test_2", SeriesCount: 1}}, LabelValuesCount: 2, SeriesCount: 4},
+				{LabelName: "route", Values: []client.LabelValueCardinality{{LabelValue: "get_user", SeriesCount: 2}, {LabelValue: "get_users", SeriesCount: 1}}, LabelValuesCount: 2, SeriesCount: 3},
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "200", SeriesCount: 1}, {LabelValue: "500", SeriesCount: 2}}, LabelValuesCount: 2, SeriesCount: 3},
+			},
+		},
+		"selected label names": {
+			labelNames: []string{"status", "unknown"},
+			expected: []*client.LabelCardinality{
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "200", SeriesCount: 1}, {LabelValue: "500", SeriesCount: 2}}, LabelValuesCount: 2, SeriesCount: 3},
+			},
+		},
+		"selected label names with matchers": {
+			labelNames: []string{"status"},
+			matchers:   []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "route", "get_user")},
+			expected: []*client.LabelCardinality{
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "200", SeriesCount: 1}, {LabelValue: "500", SeriesCount: 1}}, LabelValuesCount: 2, SeriesCount: 2},
+			},
+		},
+		"label names only": {
+			labelNamesOnly: true,
+			matchers:       []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test_1")},
+			expected: []*client.LabelCardinality{
+				{LabelName: labels.MetricName, Values: []client.LabelValueCardinality{{LabelValue: "test_1"}}, LabelValuesCount: 1, SeriesCount: 3},
+				{LabelName: "route", Values: []client.LabelValueCardinality{{LabelValue: "get_user"}, {LabelValue: "get_users"}}, LabelValuesCount: 2, SeriesCount: 3},
+				{LabelName: "status", Values: []client.LabelValueCardinality{{LabelValue: "200"}, {LabelValue: "500"}}, LabelValuesCount: 2, SeriesCount: 3},
+			},
+		},
+		"no series matching the matchers": {
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "route", "unknown")},
+			expected: nil,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req, err := client.ToLabelCardinalityRequest(testData.labelNames, testData.labelNamesOnly, testData.matchers)
+			require.NoError(t, err)
+
+			res, err := i.v2LabelCardinality(ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, res.Items)
+		})
+	}
+}
+
 func Test_Ingester_v2Query(t *testing.T) {
 	series := []struct {
 		lbls      labels.Labels
@@ -900,6 +991,270 @@ func TestIngester_v2Query_ShouldNotCreateTSDBIfDoesNotExists(t *testing.T) {
 	assert.False(t, tsdbCreated)
 }
 
+func TestIngester_v2Query_ShouldFilterSeriesByShard(t *testing.T) {
+	const (
+		numSeries = 100
+		numShards = 3
+	)
+
+	i, err := prepareIngesterWithBlocksStorage(t, defaultIngesterTestConfig(), nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE.
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	// Push series.
+	ctx := user.InjectOrgID(context.Background(), userID)
+	for n := 0; n < numSeries; n++ {
+		req, _, _ := mockWriteRequest(labels.Labels{{Name: labels.MetricName, Value: "foo"}, {Name: "series", Value: fmt.Sprint(n)}}, 1, 100000)
+		_, err := i.v2Push(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// Query each shard and ensure each series is returned by exactly one shard.
+	seen := map[string]int{}
+
+	for shardIndex := 0; shardIndex < numShards; shardIndex++ {
+		shard := sharding.ShardAnnotation{Shard: shardIndex, Of: numShards}
+
+		res, err := i.v2Query(ctx, &client.QueryRequest{
+			StartTimestampMs: 0,
+			EndTimestampMs:   200000,
+			Matchers: []*client.LabelMatcher{
+				{Type: client.EQUAL, Name: labels.MetricName, Value: "foo"},
+				{Type: client.EQUAL, Name: sharding.ShardLabel, Value: shard.String()},
+			},
+		})
+		require.NoError(t, err)
+
+		for _, series := range res.Timeseries {
+			lbls := client.FromLabelAdaptersToLabels(series.Labels)
+			assert.True(t, shard.Matches(lbls))
+			seen[lbls.String()]++
+		}
+	}
+
+	require.Len(t, seen, numSeries)
+	for _, count := range seen {
+		assert.Equal(t, 1, count)
+	}
+}
+
+func TestIngester_v2QueryExemplars(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint:errcheck
+
+	limits := defaultLimitsTestConfig()
+	limits.MaxGlobalExemplarsPerUser = 10
+
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, defaultIngesterTestConfig(), limits, dataDir, nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	// The write request is returned to the pool once pushed, so labels can't be shared across requests.
+	ctx := user.InjectOrgID(context.Background(), "test")
+	series := func() labels.Labels { return labels.FromStrings(labels.MetricName, "test") }
+	traceID := func() []cortexpb.LabelAdapter { return []cortexpb.LabelAdapter{{Name: "trace_id", Value: "123"}} }
+
+	req := writeRequestSingleSeries(series(), []client.Sample{{Value: 1, TimestampMs: 9}, {Value: 2, TimestampMs: 10}})
+	req.Timeseries[0].Exemplars = []cortexpb.Exemplar{
+		{Labels: traceID(), Value: 1, TimestampMs: 9},
+		{Labels: traceID(), Value: 2, TimestampMs: 10},
+	}
+	_, err = i.v2Push(ctx, req)
+	require.NoError(t, err)
+
+	// Pushing an out of order exemplar should fail, while a duplicated one is ignored.
+	req = writeRequestSingleSeries(series(), []client.Sample{{Value: 3, TimestampMs: 11}})
+	req.Timeseries[0].Exemplars = []cortexpb.Exemplar{
+		{Labels: traceID(), Value: 2, TimestampMs: 10},
+		{Labels: traceID(), Value: 3, TimestampMs: 5},
+	}
+	_, err = i.v2Push(ctx, req)
+	require.Equal(t, httpgrpc.Errorf(http.StatusBadRequest, wrapWithUser(wrappedTSDBIngestExemplarErr(errOutOfOrderExemplar, model.Time(5), cortexpb.FromLabelsToLabelAdapters(series()), traceID()), "test").Error()), err)
+
+	queryReq, err := client.ToExemplarQueryRequest(0, 100, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")})
+	require.NoError(t, err)
+
+	res, err := i.QueryExemplars(ctx, queryReq)
+	require.NoError(t, err)
+	assert.Equal(t, &client.ExemplarQueryResponse{Timeseries: []cortexpb.TimeSeries{{
+		Labels: cortexpb.FromLabelsToLabelAdapters(series()),
+		Exemplars: []cortexpb.Exemplar{
+			{Labels: traceID(), Value: 1, TimestampMs: 9},
+			{Labels: traceID(), Value: 2, TimestampMs: 10},
+		},
+	}}}, res)
+
+	// Querying a tenant without TSDB should return an empty response.
+	res, err = i.QueryExemplars(user.InjectOrgID(context.Background(), "other"), queryReq)
+	require.NoError(t, err)
+	assert.Equal(t, &client.ExemplarQueryResponse{}, res)
+}
+
+func TestIngester_v2Push_ShouldIngestOutOfOrderSamplesWithinTimeWindow(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint:errcheck
+
+	limits := defaultLimitsTestConfig()
+	limits.OutOfOrderTimeWindow = 10 * time.Second
+
+	registry := prometheus.NewRegistry()
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, defaultIngesterTestConfig(), limits, dataDir, registry)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), userID)
+	series := func() labels.Labels { return labels.FromStrings(labels.MetricName, "test") }
+
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(series(), []client.Sample{{Value: 1, TimestampMs: 10000}, {Value: 3, TimestampMs: 20000}}))
+	require.NoError(t, err)
+
+	// The out-of-order sample within the time window is accepted, while the one outside is rejected.
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(series(), []client.Sample{{Value: 2, TimestampMs: 15000}}))
+	require.NoError(t, err)
+
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(series(), []client.Sample{{Value: 0, TimestampMs: 5000}}))
+	require.Equal(t, httpgrpc.Errorf(http.StatusBadRequest, wrapWithUser(wrappedTSDBIngestErr(storage.ErrOutOfOrderSample, model.Time(5000), cortexpb.FromLabelsToLabelAdapters(series())), userID).Error()), err)
+
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+		# HELP cortex_ingester_out_of_order_samples_appended_total The total number of out-of-order samples ingested within the out-of-order time window.
+		# TYPE cortex_ingester_out_of_order_samples_appended_total counter
+		cortex_ingester_out_of_order_samples_appended_total 1
+		# HELP cortex_ingester_out_of_order_samples_rejected_total The total number of out-of-order samples rejected, because outside of the out-of-order time window, because of limits or because of a different value for the same timestamp.
+		# TYPE cortex_ingester_out_of_order_samples_rejected_total counter
+		cortex_ingester_out_of_order_samples_rejected_total 1
+	`), "cortex_ingester_out_of_order_samples_appended_total", "cortex_ingester_out_of_order_samples_rejected_total"))
+
+	expected := &client.QueryResponse{Timeseries: []cortexpb.TimeSeries{{
+		Labels:  cortexpb.FromLabelsToLabelAdapters(series()),
+		Samples: []cortexpb.Sample{{Value: 1, TimestampMs: 10000}, {Value: 2, TimestampMs: 15000}, {Value: 3, TimestampMs: 20000}},
+	}}}
+
+	queryReq := &client.QueryRequest{
+		StartTimestampMs: 0,
+		EndTimestampMs:   30000,
+		Matchers:         []*client.LabelMatcher{{Type: client.EQUAL, Name: labels.MetricName, Value: "test"}},
+	}
+
+	// The out-of-order sample is queryable right after being ingested.
+	res, err := i.v2Query(ctx, queryReq)
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+
+	// Once the head is compacted, the out-of-order sample is written to a block, which
+	// is queried by the userTSDB until the TSDB loads it.
+	i.compactBlocks(context.Background(), true)
+
+	db := i.getTSDB(userID)
+	assert.True(t, db.outOfOrder.empty())
+	assert.Equal(t, uint64(0), db.Head().NumSeries())
+	assert.Len(t, db.Blocks(), 1)
+	assert.Len(t, db.outOfOrderBlocks, 1)
+
+	res, err = i.v2Query(ctx, queryReq)
+	require.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestIngester_v2Push_ShouldApplyLimitsToOutOfOrderSamples(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint:errcheck
+
+	limits := defaultLimitsTestConfig()
+	limits.OutOfOrderTimeWindow = 2 * time.Hour
+	limits.MaxLocalSeriesPerUser = 1
+	limits.MaxLocalOutOfOrderSamplesPerUser = 1
+
+	registry := prometheus.NewRegistry()
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, defaultIngesterTestConfig(), limits, dataDir, registry)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), userID)
+	first := func() labels.Labels { return labels.FromStrings(labels.MetricName, "first") }
+	second := func() labels.Labels { return labels.FromStrings(labels.MetricName, "second") }
+	hour := time.Hour.Milliseconds()
+
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(first(), []client.Sample{{Value: 1, TimestampMs: 2 * hour}}))
+	require.NoError(t, err)
+
+	// Out-of-order samples of a new series count against the series limits.
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(second(), []client.Sample{{Value: 1, TimestampMs: hour / 2}}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "per-user series limit of 1 exceeded")
+
+	// Out-of-order samples in memory are limited.
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(first(), []client.Sample{{Value: 1, TimestampMs: hour}}))
+	require.NoError(t, err)
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(first(), []client.Sample{{Value: 1, TimestampMs: hour + 1}}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "per-user out-of-order samples limit of 1 exceeded")
+
+	assert.Equal(t, 1, i.getTSDB(userID).outOfOrder.numSeries)
+	assert.Equal(t, 1, i.getTSDB(userID).outOfOrder.numSamples)
+
+	// Samples rejected because of limits or duplicated timestamps are tracked as rejected.
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(first(), []client.Sample{{Value: 2, TimestampMs: hour}}))
+	require.Error(t, err)
+
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+		# HELP cortex_ingester_out_of_order_samples_rejected_total The total number of out-of-order samples rejected, because outside of the out-of-order time window, because of limits or because of a different value for the same timestamp.
+		# TYPE cortex_ingester_out_of_order_samples_rejected_total counter
+		cortex_ingester_out_of_order_samples_rejected_total 3
+	`), "cortex_ingester_out_of_order_samples_rejected_total"))
+}
+
+func TestIngester_v2Push_ShouldNotAllowOverlappingBlocksWithoutOutOfOrderTimeWindow(t *testing.T) {
+	dataDir, err := ioutil.TempDir("", "ingester")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint:errcheck
+
+	i, err := prepareIngesterWithBlocksStorageAndLimits(t, defaultIngesterTestConfig(), defaultLimitsTestConfig(), dataDir, nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), i))
+	defer services.StopAndAwaitTerminated(context.Background(), i) //nolint:errcheck
+
+	// Wait until it's ACTIVE
+	test.Poll(t, 1*time.Second, ring.ACTIVE, func() interface{} {
+		return i.lifecycler.GetState()
+	})
+
+	ctx := user.InjectOrgID(context.Background(), userID)
+	_, err = i.v2Push(ctx, writeRequestSingleSeries(labels.FromStrings(labels.MetricName, "test"), []client.Sample{{Value: 1, TimestampMs: 10000}}))
+	require.NoError(t, err)
+
+	// Neither overlapping blocks nor the out-of-order WAL are enabled for the tenant.
+	assert.False(t, i.getTSDB(userID).outOfOrderAllowed)
+	_, err = os.Stat(filepath.Join(i.cfg.BlocksStorageConfig.TSDB.BlocksDir(userID), outOfOrderWALDir))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestIngester_v2LabelValues_ShouldNotCreateTSDBIfDoesNotExists(t *testing.T) {
 	i, err := prepareIngesterWithBlocksStorage(t, defaultIngesterTestConfig(), nil)
 	require.NoError(t, err)
@@ -14,6 +14,7 @@ const (
 	errMaxSeriesPerUserLimitExceeded     = "per-user series limit of %d exceeded, please contact administrator to raise it. (local limit: %d global limit: %d actual local limit: %d)"
 	errMaxMetadataPerMetricLimitExceeded = "per-metric metadata limit of %d exceeded, please contact administrator to raise it. (local limit: %d global limit: %d actual local limit: %d)"
 	errMaxMetadataPerUserLimitExceeded   = "per-user metric metadata limit of %d exceeded, please contact administrator to raise it. (local limit: %d global limit: %d actual local limit: %d)"
+	errMaxOutOfOrderSamplesLimitExceeded = "per-user out-of-order samples limit of %d exceeded, please contact administrator to raise it."
 )
 
 // RingCount is the interface exposed by a ring implementation which allows
@@ -111,6 +112,17 @@ func (l *Limiter) AssertMaxMetricsWithMetadataPerUser(userID string, metrics int
 	return fmt.Errorf(errMaxMetadataPerUserLimitExceeded, minNonZero(localLimit, globalLimit), localLimit, globalLimit, actualLimit)
 }
 
+// AssertMaxOutOfOrderSamplesPerUser limit has not been reached compared to the current
+// number of out-of-order samples in memory in input and returns an error if so.
+func (l *Limiter) AssertMaxOutOfOrderSamplesPerUser(userID string, samples int) error {
+	limit := l.limits.MaxLocalOutOfOrderSamplesPerUser(userID)
+	if limit <= 0 || samples < limit {
+		return nil
+	}
+
+	return fmt.Errorf(errMaxOutOfOrderSamplesLimitExceeded, limit)
+}
+
 // MaxSeriesPerQuery returns the maximum number of series a query is allowed to hit.
 func (l *Limiter) MaxSeriesPerQuery(userID string) int {
 	return l.limits.MaxSeriesPerQuery(userID)
@@ -177,6 +189,19 @@ func (l *Limiter) maxMetadataPerUser(userID string) int {
 	)
 }
 
+// maxExemplarsPerUser returns the maximum number of exemplars an ingester can hold
+// in memory for the given tenant, or 0 if exemplars are disabled.
+func (l *Limiter) maxExemplarsPerUser(userID string) int {
+	globalLimit := l.limits.MaxGlobalExemplarsPerUser(userID)
+	if localLimit := l.convertGlobalToLocalLimit(userID, globalLimit); localLimit > 0 {
+		return localLimit
+	}
+
+	// The number of ingesters may not be known yet, in which case we
+	// temporarily fallback to the global limit.
+	return globalLimit
+}
+
 func (l *Limiter) maxByLocalAndGlobal(userID string, localLimitFn, globalLimitFn func(string) int) int {
 	localLimit := localLimitFn(userID)
 
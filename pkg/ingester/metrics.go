@@ -18,9 +18,13 @@ const (
 type ingesterMetrics struct {
 	flushQueueLength        prometheus.Gauge
 	ingestedSamples         prometheus.Counter
+	ingestedExemplars       prometheus.Counter
 	ingestedMetadata        prometheus.Counter
 	ingestedSamplesFail     prometheus.Counter
+	ingestedExemplarsFail   prometheus.Counter
 	ingestedMetadataFail    prometheus.Counter
+	outOfOrderSamples       prometheus.Counter
+	outOfOrderSamplesFail   prometheus.Counter
 	queries                 prometheus.Counter
 	queriedSamples          prometheus.Histogram
 	queriedSeries           prometheus.Histogram
@@ -65,6 +69,10 @@ func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSD
 			Name: "cortex_ingester_ingested_samples_total",
 			Help: "The total number of samples ingested.",
 		}),
+		ingestedExemplars: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_ingested_exemplars_total",
+			Help: "The total number of exemplars ingested.",
+		}),
 		ingestedMetadata: promauto.With(r).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_ingester_ingested_metadata_total",
 			Help: "The total number of metadata ingested.",
@@ -73,10 +81,22 @@ func newIngesterMetrics(r prometheus.Registerer, createMetricsConflictingWithTSD
 			Name: "cortex_ingester_ingested_samples_failures_total",
 			Help: "The total number of samples that errored on ingestion.",
 		}),
+		ingestedExemplarsFail: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_ingested_exemplars_failures_total",
+			Help: "The total number of exemplars that errored on ingestion.",
+		}),
 		ingestedMetadataFail: promauto.With(r).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_ingester_ingested_metadata_failures_total",
 			Help: "The total number of metadata that errored on ingestion.",
 		}),
+		outOfOrderSamples: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_out_of_order_samples_appended_total",
+			Help: "The total number of out-of-order samples ingested within the out-of-order time window.",
+		}),
+		outOfOrderSamplesFail: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_out_of_order_samples_rejected_total",
+			Help: "The total number of out-of-order samples rejected, because outside of the out-of-order time window, because of limits or because of a different value for the same timestamp.",
+		}),
 		queries: promauto.With(r).NewCounter(prometheus.CounterOpts{
 			Name: "cortex_ingester_queries_total",
 			Help: "The total number of queries the ingester has handled.",
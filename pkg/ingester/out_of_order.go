@@ -0,0 +1,722 @@
+package ingester
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/record"
+	"github.com/prometheus/prometheus/tsdb/tsdbutil"
+	"github.com/prometheus/prometheus/tsdb/wal"
+	"github.com/thanos-io/thanos/pkg/runutil"
+)
+
+// outOfOrderWALDir is the directory, within the tenant's TSDB directory, where the WAL
+// of the out-of-order samples is stored.
+const outOfOrderWALDir = "wal_out_of_order"
+
+// outOfOrderLimiter enforces the tenant limits on the outOfOrderHead.
+type outOfOrderLimiter interface {
+	// assertMaxOutOfOrderSeries is called before creating a new series, with the number of series
+	// currently in the head, both overall and with the same metric name of the new series.
+	assertMaxOutOfOrderSeries(metric labels.Labels, numSeries, numSeriesForMetric int) error
+
+	// assertMaxOutOfOrderSamples is called before appending a sample, with the number of samples
+	// currently in the head, including the ones appended but not committed yet.
+	assertMaxOutOfOrderSamples(numSamples int) error
+}
+
+// outOfOrderHead is an in-memory storage for the samples which have been rejected by the
+// TSDB head because out of order, but are within the tenant's out-of-order time window.
+// Samples are kept in memory, and logged to a dedicated WAL once opened, until they're
+// written to blocks, which happens when the TSDB head is compacted.
+type outOfOrderHead struct {
+	dir        string
+	blockRange int64
+	limiter    outOfOrderLimiter
+	logger     log.Logger
+
+	mtx             sync.RWMutex
+	series          map[uint64][]*outOfOrderSeries
+	seriesForMetric map[string]int
+	numSeries       int
+	numSamples      int
+	numPending      int // Samples appended but not committed yet.
+	lastRef         uint64
+	wal             *wal.WAL
+}
+
+type outOfOrderSeries struct {
+	ref     uint64 // Identifies the series in the WAL.
+	logged  bool   // Whether the series has been logged to the WAL.
+	lbls    labels.Labels
+	samples []outOfOrderSample // Sorted by timestamp.
+	pending int                // Samples appended but not committed yet.
+}
+
+type outOfOrderSample struct {
+	t int64
+	v float64
+}
+
+func (s outOfOrderSample) T() int64   { return s.t }
+func (s outOfOrderSample) V() float64 { return s.v }
+
+// lookup returns the value of the sample with timestamp t, if any.
+func (s *outOfOrderSeries) lookup(t int64) (float64, bool) {
+	i := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].t >= t })
+	if i < len(s.samples) && s.samples[i].t == t {
+		return s.samples[i].v, true
+	}
+	return 0, false
+}
+
+// insert adds the sample to the series, keeping samples sorted. Returns false if
+// the series already contains a sample with the same timestamp.
+func (s *outOfOrderSeries) insert(t int64, v float64) bool {
+	i := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].t >= t })
+	if i < len(s.samples) && s.samples[i].t == t {
+		return false
+	}
+
+	s.samples = append(s.samples, outOfOrderSample{})
+	copy(s.samples[i+1:], s.samples[i:])
+	s.samples[i] = outOfOrderSample{t: t, v: v}
+	return true
+}
+
+// newOutOfOrderHead makes a new outOfOrderHead, writing blocks to dir. The limiter is optional.
+func newOutOfOrderHead(dir string, blockRange int64, limiter outOfOrderLimiter, logger log.Logger) *outOfOrderHead {
+	return &outOfOrderHead{
+		dir:             dir,
+		blockRange:      blockRange,
+		limiter:         limiter,
+		logger:          logger,
+		series:          map[uint64][]*outOfOrderSeries{},
+		seriesForMetric: map[string]int{},
+	}
+}
+
+// openWAL replays the WAL in dir, if any, and opens it to log the samples committed from now on.
+func (h *outOfOrderHead) openWAL(dir string, segmentSize int, compress bool) error {
+	if err := h.replayWAL(dir); err != nil {
+		return err
+	}
+
+	w, err := wal.NewSize(h.logger, nil, dir, segmentSize, compress)
+	if err != nil {
+		return errors.Wrap(err, "open out-of-order WAL")
+	}
+
+	h.mtx.Lock()
+	h.wal = w
+	h.mtx.Unlock()
+
+	return nil
+}
+
+// replayWAL adds the samples logged to the WAL in dir to the head.
+func (h *outOfOrderHead) replayWAL(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	segments, err := wal.NewSegmentsReader(dir)
+	if err != nil {
+		return errors.Wrap(err, "open out-of-order WAL segments")
+	}
+	defer runutil.CloseWithLogOnErr(h.logger, segments, "close out-of-order WAL segments")
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var (
+		dec     record.Decoder
+		series  []record.RefSeries
+		samples []record.RefSample
+		refs    = map[uint64]*outOfOrderSeries{}
+	)
+
+	r := wal.NewReader(segments)
+	for r.Next() {
+		rec := r.Record()
+
+		switch dec.Type(rec) {
+		case record.Series:
+			if series, err = dec.Series(rec, series[:0]); err != nil {
+				return errors.Wrap(err, "decode out-of-order WAL series")
+			}
+
+			for _, s := range series {
+				hash := s.Labels.Hash()
+				if refs[s.Ref] = h.getSeries(hash, s.Labels); refs[s.Ref] == nil {
+					refs[s.Ref] = h.createSeries(hash, s.Labels, s.Ref)
+				}
+				refs[s.Ref].logged = true
+				if s.Ref > h.lastRef {
+					h.lastRef = s.Ref
+				}
+			}
+
+		case record.Samples:
+			if samples, err = dec.Samples(rec, samples[:0]); err != nil {
+				return errors.Wrap(err, "decode out-of-order WAL samples")
+			}
+
+			for _, s := range samples {
+				// Series are always logged before their samples.
+				if series, ok := refs[s.Ref]; ok && series.insert(s.T, s.V) {
+					h.numSamples++
+				}
+			}
+		}
+	}
+
+	// The samples replayed so far are kept, and the corrupted segment is deleted at the next WAL truncation.
+	if err := r.Err(); err != nil {
+		level.Warn(h.logger).Log("msg", "failed to replay out-of-order WAL, some out-of-order samples may be lost", "err", err)
+	}
+
+	// Series may be logged without samples, eg. when the WAL is truncated while their first samples are being appended.
+	for _, s := range refs {
+		if len(s.samples) == 0 {
+			h.deleteSeries(s)
+		}
+	}
+
+	level.Info(h.logger).Log("msg", "out-of-order WAL replayed", "series", h.numSeries, "samples", h.numSamples)
+	return nil
+}
+
+// close closes the WAL, if opened.
+func (h *outOfOrderHead) close() error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.wal == nil {
+		return nil
+	}
+
+	err := h.wal.Close()
+	h.wal = nil
+	return err
+}
+
+// appender returns a new appender to add samples to the head.
+func (h *outOfOrderHead) appender() *outOfOrderAppender {
+	return &outOfOrderAppender{head: h, pending: map[*outOfOrderSeries][]outOfOrderSample{}}
+}
+
+// empty returns true if the head contains no samples.
+func (h *outOfOrderHead) empty() bool {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	return h.numSamples == 0
+}
+
+// querier returns a storage.Querier over the samples in the [mint, maxt] time range.
+// Samples are copied, so the returned querier is not affected by subsequent changes.
+func (h *outOfOrderHead) querier(mint, maxt int64) storage.Querier {
+	return &outOfOrderQuerier{head: h, mint: mint, maxt: maxt}
+}
+
+// writeBlocks writes the samples with timestamp lower than maxt to blocks, one for each
+// block range, and removes them from the head and the WAL. Returns the directories of
+// the written blocks.
+func (h *outOfOrderHead) writeBlocks(maxt int64) ([]string, error) {
+	ranges := h.snapshot(maxt)
+	if len(ranges) == 0 {
+		return nil, nil
+	}
+
+	starts := make([]int64, 0, len(ranges))
+	for start := range ranges {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	var (
+		dirs []string
+		err  error
+	)
+
+	for _, start := range starts {
+		series := ranges[start]
+
+		// The TSDB appender used to write the block rejects samples older than the first appended one
+		// minus half of the block range, so we make sure the earliest sample is appended first.
+		sort.SliceStable(series, func(i, j int) bool {
+			return series[i].samples[0].t < series[j].samples[0].t
+		})
+
+		list := make([]storage.Series, 0, len(series))
+		for _, s := range series {
+			samples := make([]tsdbutil.Sample, 0, len(s.samples))
+			for _, smpl := range s.samples {
+				samples = append(samples, smpl)
+			}
+			list = append(list, storage.NewListSeries(s.lbls, samples))
+		}
+
+		var dir string
+		if dir, err = tsdb.CreateBlock(list, h.dir, h.blockRange, h.logger); err != nil {
+			err = errors.Wrap(err, "create block from out-of-order samples")
+			break
+		}
+		dirs = append(dirs, dir)
+
+		// Samples are removed only once they've been written, so that they're not lost on failure.
+		h.remove(series)
+		level.Info(h.logger).Log("msg", "written block from out-of-order samples", "mint", start, "maxt", start+h.blockRange, "series", len(series))
+	}
+
+	if len(dirs) > 0 {
+		if truncateErr := h.truncateWAL(); truncateErr != nil && err == nil {
+			err = truncateErr
+		}
+	}
+
+	return dirs, err
+}
+
+// snapshot returns a copy of the samples with timestamp lower than maxt, grouped by block range start.
+func (h *outOfOrderHead) snapshot(maxt int64) map[int64][]*outOfOrderSeries {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	ranges := map[int64][]*outOfOrderSeries{}
+	for _, list := range h.series {
+		for _, s := range list {
+			var curr *outOfOrderSeries
+			var currStart int64
+
+			for _, smpl := range s.samples {
+				if smpl.t >= maxt {
+					break
+				}
+
+				start := smpl.t - smpl.t%h.blockRange
+				if curr == nil || start != currStart {
+					curr = &outOfOrderSeries{lbls: s.lbls}
+					currStart = start
+					ranges[start] = append(ranges[start], curr)
+				}
+				curr.samples = append(curr.samples, smpl)
+			}
+		}
+	}
+
+	return ranges
+}
+
+// remove deletes the input samples from the head. Samples are matched by timestamp, because the
+// head can't contain two samples with the same timestamp for the same series.
+func (h *outOfOrderHead) remove(removed []*outOfOrderSeries) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	for _, r := range removed {
+		s := h.getSeries(r.lbls.Hash(), r.lbls)
+		if s == nil {
+			continue
+		}
+
+		toRemove := make(map[int64]struct{}, len(r.samples))
+		for _, smpl := range r.samples {
+			toRemove[smpl.t] = struct{}{}
+		}
+
+		kept := s.samples[:0]
+		for _, smpl := range s.samples {
+			if _, ok := toRemove[smpl.t]; !ok {
+				kept = append(kept, smpl)
+			}
+		}
+		h.numSamples -= len(s.samples) - len(kept)
+		s.samples = kept
+
+		// Series with samples not committed yet are kept, because they're referenced by an appender.
+		if len(s.samples) == 0 && s.pending == 0 {
+			h.deleteSeries(s)
+		}
+	}
+}
+
+// truncateWAL removes the samples not in the head anymore from the WAL. The samples still in
+// the head are logged to a new segment, and then all the previous segments are deleted. If the
+// process crashes in the meanwhile, some samples may be replayed and written to blocks twice,
+// which is fine because overlapping blocks are vertically compacted.
+func (h *outOfOrderHead) truncateWAL() error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.wal == nil {
+		return nil
+	}
+
+	if err := h.wal.NextSegment(); err != nil {
+		return errors.Wrap(err, "create out-of-order WAL segment")
+	}
+
+	var series []*outOfOrderSeries
+	samples := map[*outOfOrderSeries][]outOfOrderSample{}
+	for _, list := range h.series {
+		for _, s := range list {
+			series = append(series, s)
+			samples[s] = s.samples
+		}
+	}
+
+	if err := h.logWAL(series, samples); err != nil {
+		return errors.Wrap(err, "log out-of-order samples to WAL")
+	}
+	for _, s := range series {
+		s.logged = true
+	}
+
+	_, last, err := wal.Segments(h.wal.Dir())
+	if err != nil {
+		return errors.Wrap(err, "list out-of-order WAL segments")
+	}
+
+	return errors.Wrap(h.wal.Truncate(last), "truncate out-of-order WAL")
+}
+
+// logWAL logs the input series and samples to the WAL. Must be called with the lock held.
+func (h *outOfOrderHead) logWAL(series []*outOfOrderSeries, samples map[*outOfOrderSeries][]outOfOrderSample) error {
+	var (
+		enc  record.Encoder
+		recs [][]byte
+	)
+
+	if len(series) > 0 {
+		refSeries := make([]record.RefSeries, 0, len(series))
+		for _, s := range series {
+			refSeries = append(refSeries, record.RefSeries{Ref: s.ref, Labels: s.lbls})
+		}
+		recs = append(recs, enc.Series(refSeries, nil))
+	}
+
+	var refSamples []record.RefSample
+	for s, list := range samples {
+		for _, smpl := range list {
+			refSamples = append(refSamples, record.RefSample{Ref: s.ref, T: smpl.t, V: smpl.v})
+		}
+	}
+	if len(refSamples) > 0 {
+		recs = append(recs, enc.Samples(refSamples, nil))
+	}
+
+	return h.wal.Log(recs...)
+}
+
+// getSeries returns the series with the input labels, or nil if it doesn't exist.
+// Must be called with the lock held.
+func (h *outOfOrderHead) getSeries(hash uint64, lbls labels.Labels) *outOfOrderSeries {
+	for _, s := range h.series[hash] {
+		if labels.Equal(s.lbls, lbls) {
+			return s
+		}
+	}
+	return nil
+}
+
+// createSeries adds a new series to the head. Must be called with the lock held.
+func (h *outOfOrderHead) createSeries(hash uint64, lbls labels.Labels, ref uint64) *outOfOrderSeries {
+	s := &outOfOrderSeries{ref: ref, lbls: lbls}
+	h.series[hash] = append(h.series[hash], s)
+	h.seriesForMetric[lbls.Get(labels.MetricName)]++
+	h.numSeries++
+	return s
+}
+
+// deleteSeries removes the series from the head, if it exists. Must be called with the lock held.
+func (h *outOfOrderHead) deleteSeries(series *outOfOrderSeries) {
+	hash := series.lbls.Hash()
+	list := h.series[hash]
+
+	for idx, s := range list {
+		if s != series {
+			continue
+		}
+
+		if list = append(list[:idx], list[idx+1:]...); len(list) == 0 {
+			delete(h.series, hash)
+		} else {
+			h.series[hash] = list
+		}
+
+		metric := s.lbls.Get(labels.MetricName)
+		if h.seriesForMetric[metric]--; h.seriesForMetric[metric] == 0 {
+			delete(h.seriesForMetric, metric)
+		}
+		h.numSeries--
+		return
+	}
+}
+
+// outOfOrderAppender appends samples to an outOfOrderHead. Appended samples are buffered,
+// and added to the head and logged to the WAL only once committed.
+type outOfOrderAppender struct {
+	head       *outOfOrderHead
+	created    []*outOfOrderSeries
+	pending    map[*outOfOrderSeries][]outOfOrderSample
+	numPending int
+}
+
+// add appends a sample to the series. The input labels are retained, so the caller must not
+// modify them afterwards. The series limits are checked when creating a new series, unless
+// inHead is true because the series is known to exist in the TSDB head.
+func (a *outOfOrderAppender) add(lbls labels.Labels, t int64, v float64, inHead bool) error {
+	h := a.head
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	hash := lbls.Hash()
+	series := h.getSeries(hash, lbls)
+
+	if series != nil {
+		curr, ok := series.lookup(t)
+		for _, smpl := range a.pending[series] {
+			if smpl.t == t {
+				curr, ok = smpl.v, true
+			}
+		}
+
+		if ok {
+			// Re-pushing the same sample is a no-op, as it happens in the TSDB head.
+			if curr == v {
+				return nil
+			}
+			return storage.ErrDuplicateSampleForTimestamp
+		}
+	}
+
+	if h.limiter != nil {
+		if err := h.limiter.assertMaxOutOfOrderSamples(h.numSamples + h.numPending); err != nil {
+			return err
+		}
+
+		if series == nil && !inHead {
+			if err := h.limiter.assertMaxOutOfOrderSeries(lbls, h.numSeries, h.seriesForMetric[lbls.Get(labels.MetricName)]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if series == nil {
+		h.lastRef++
+		series = h.createSeries(hash, lbls, h.lastRef)
+		a.created = append(a.created, series)
+	}
+
+	a.pending[series] = append(a.pending[series], outOfOrderSample{t: t, v: v})
+	a.numPending++
+	series.pending++
+	h.numPending++
+
+	return nil
+}
+
+// commit logs the appended samples to the WAL, if opened, and adds them to the head.
+// The appended samples are discarded if logging them fails.
+func (a *outOfOrderAppender) commit() error {
+	if a.numPending == 0 {
+		return nil
+	}
+
+	h := a.head
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	defer a.release()
+
+	if h.wal != nil {
+		// The series record is logged along with the first samples committed for the series, which
+		// may have been created by another appender, so that it precedes its samples in the WAL.
+		var series []*outOfOrderSeries
+		for s := range a.pending {
+			if !s.logged {
+				series = append(series, s)
+			}
+		}
+
+		if err := h.logWAL(series, a.pending); err != nil {
+			return errors.Wrap(err, "log out-of-order samples to WAL")
+		}
+		for _, s := range series {
+			s.logged = true
+		}
+	}
+
+	for series, samples := range a.pending {
+		for _, smpl := range samples {
+			// If another appender committed a sample with the same timestamp in the meanwhile, the first one is kept.
+			if series.insert(smpl.t, smpl.v) {
+				h.numSamples++
+			}
+		}
+	}
+
+	return nil
+}
+
+// rollback discards the appended samples.
+func (a *outOfOrderAppender) rollback() {
+	if a.numPending == 0 {
+		return
+	}
+
+	a.head.mtx.Lock()
+	defer a.head.mtx.Unlock()
+
+	a.release()
+}
+
+// release resets the appender, deleting the series it created which have no samples.
+// Must be called with the head lock held.
+func (a *outOfOrderAppender) release() {
+	h := a.head
+
+	for series, samples := range a.pending {
+		series.pending -= len(samples)
+	}
+	h.numPending -= a.numPending
+
+	for _, series := range a.created {
+		if len(series.samples) == 0 && series.pending == 0 {
+			h.deleteSeries(series)
+		}
+	}
+
+	a.created = nil
+	a.pending = map[*outOfOrderSeries][]outOfOrderSample{}
+	a.numPending = 0
+}
+
+// outOfOrderQuerier implements storage.Querier for the outOfOrderHead.
+type outOfOrderQuerier struct {
+	head       *outOfOrderHead
+	mint, maxt int64
+}
+
+// Select implements storage.Querier.
+func (q *outOfOrderQuerier) Select(sortSeries bool, _ *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	q.head.mtx.RLock()
+	defer q.head.mtx.RUnlock()
+
+	var result []storage.Series
+	for _, list := range q.head.series {
+		for _, s := range list {
+			if !matchesAll(s.lbls, matchers) {
+				continue
+			}
+
+			var samples []tsdbutil.Sample
+			for _, smpl := range s.samples {
+				if smpl.t >= q.mint && smpl.t <= q.maxt {
+					samples = append(samples, smpl)
+				}
+			}
+
+			if len(samples) > 0 {
+				result = append(result, storage.NewListSeries(s.lbls, samples))
+			}
+		}
+	}
+
+	// Series are always sorted, because the merge querier requires it.
+	sort.Slice(result, func(i, j int) bool {
+		return labels.Compare(result[i].Labels(), result[j].Labels()) < 0
+	})
+
+	return &outOfOrderSeriesSet{series: result, idx: -1}
+}
+
+// LabelValues implements storage.Querier.
+func (q *outOfOrderQuerier) LabelValues(name string, matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	values := map[string]struct{}{}
+	q.forEachSeries(matchers, func(lbls labels.Labels) {
+		if v := lbls.Get(name); v != "" {
+			values[v] = struct{}{}
+		}
+	})
+
+	return sortedKeys(values), nil, nil
+}
+
+// LabelNames implements storage.Querier.
+func (q *outOfOrderQuerier) LabelNames() ([]string, storage.Warnings, error) {
+	names := map[string]struct{}{}
+	q.forEachSeries(nil, func(lbls labels.Labels) {
+		for _, l := range lbls {
+			names[l.Name] = struct{}{}
+		}
+	})
+
+	return sortedKeys(names), nil, nil
+}
+
+// Close implements storage.Querier.
+func (q *outOfOrderQuerier) Close() error {
+	return nil
+}
+
+// forEachSeries calls fn for each series matching the input matchers and having at least
+// one sample in the querier time range.
+func (q *outOfOrderQuerier) forEachSeries(matchers []*labels.Matcher, fn func(lbls labels.Labels)) {
+	q.head.mtx.RLock()
+	defer q.head.mtx.RUnlock()
+
+	for _, list := range q.head.series {
+		for _, s := range list {
+			if !matchesAll(s.lbls, matchers) {
+				continue
+			}
+
+			for _, smpl := range s.samples {
+				if smpl.t >= q.mint && smpl.t <= q.maxt {
+					fn(s.lbls)
+					break
+				}
+			}
+		}
+	}
+}
+
+type outOfOrderSeriesSet struct {
+	series []storage.Series
+	idx    int
+}
+
+func (s *outOfOrderSeriesSet) Next() bool {
+	s.idx++
+	return s.idx < len(s.series)
+}
+
+func (s *outOfOrderSeriesSet) At() storage.Series {
+	return s.series[s.idx]
+}
+
+func (s *outOfOrderSeriesSet) Err() error {
+	return nil
+}
+
+func (s *outOfOrderSeriesSet) Warnings() storage.Warnings {
+	return nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,273 @@
+package ingester
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/wal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutOfOrderHead_Append(t *testing.T) {
+	series := labels.FromStrings(labels.MetricName, "test")
+
+	h := newOutOfOrderHead("", 100, nil, log.NewNopLogger())
+	assert.True(t, h.empty())
+
+	require.NoError(t, appendOutOfOrderSample(h, series, 30, 3))
+	require.NoError(t, appendOutOfOrderSample(h, series, 10, 1))
+	require.NoError(t, appendOutOfOrderSample(h, series, 20, 2))
+	assert.False(t, h.empty())
+
+	// Re-appending the same sample is a no-op, while a different value for the same timestamp fails.
+	require.NoError(t, appendOutOfOrderSample(h, series, 20, 2))
+	assert.Equal(t, storage.ErrDuplicateSampleForTimestamp, appendOutOfOrderSample(h, series, 20, 5))
+
+	assert.Equal(t, []outOfOrderSample{{t: 10, v: 1}, {t: 20, v: 2}, {t: 30, v: 3}}, h.series[series.Hash()][0].samples)
+}
+
+func TestOutOfOrderAppender_CommitAndRollback(t *testing.T) {
+	first := labels.FromStrings(labels.MetricName, "first")
+	second := labels.FromStrings(labels.MetricName, "second")
+
+	h := newOutOfOrderHead("", 100, nil, log.NewNopLogger())
+	require.NoError(t, appendOutOfOrderSample(h, first, 10, 1))
+
+	// Samples are added to the head only once committed.
+	app := h.appender()
+	require.NoError(t, app.add(first, 20, 2, false))
+	require.NoError(t, app.add(second, 20, 2, false))
+	assert.Equal(t, storage.ErrDuplicateSampleForTimestamp, app.add(second, 20, 3, false))
+	assert.Equal(t, 1, h.numSamples)
+	assert.Equal(t, 2, h.numPending)
+
+	require.NoError(t, app.commit())
+	assert.Equal(t, 3, h.numSamples)
+	assert.Equal(t, 0, h.numPending)
+	assert.Equal(t, 2, h.numSeries)
+
+	// On rollback, samples are discarded and the created series are deleted.
+	third := labels.FromStrings(labels.MetricName, "third")
+
+	app = h.appender()
+	require.NoError(t, app.add(first, 30, 3, false))
+	require.NoError(t, app.add(third, 30, 3, false))
+	assert.Equal(t, 3, h.numSeries)
+
+	app.rollback()
+	assert.Equal(t, 3, h.numSamples)
+	assert.Equal(t, 0, h.numPending)
+	assert.Equal(t, 2, h.numSeries)
+	assert.Equal(t, []outOfOrderSample{{t: 10, v: 1}, {t: 20, v: 2}}, h.series[first.Hash()][0].samples)
+	assert.Nil(t, h.getSeries(third.Hash(), third))
+}
+
+func TestOutOfOrderAppender_Limits(t *testing.T) {
+	limiter := &outOfOrderLimiterMock{maxSeries: 2, maxSeriesForMetric: 1, maxSamples: 3}
+	h := newOutOfOrderHead("", 100, limiter, log.NewNopLogger())
+
+	require.NoError(t, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "first"), 10, 1))
+	require.NoError(t, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "second"), 10, 1))
+
+	// The series limits are not checked for series existing in the TSDB head.
+	assert.Equal(t, errOutOfOrderSeriesLimit, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "third"), 10, 1))
+	assert.Equal(t, errOutOfOrderSeriesLimit, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "first", "job", "a"), 10, 1))
+
+	app := h.appender()
+	require.NoError(t, app.add(labels.FromStrings(labels.MetricName, "first", "job", "a"), 10, 1, true))
+
+	// Samples not committed yet count against the samples limit.
+	assert.Equal(t, errOutOfOrderSamplesLimit, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "first"), 20, 2))
+	app.rollback()
+	require.NoError(t, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "first"), 20, 2))
+	assert.Equal(t, errOutOfOrderSamplesLimit, appendOutOfOrderSample(h, labels.FromStrings(labels.MetricName, "first"), 30, 3))
+}
+
+func TestOutOfOrderHead_WAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "out-of-order")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	walDir := filepath.Join(dir, outOfOrderWALDir)
+	first := labels.FromStrings(labels.MetricName, "first")
+	second := labels.FromStrings(labels.MetricName, "second")
+
+	h := newOutOfOrderHead(dir, 100, nil, log.NewNopLogger())
+	require.NoError(t, h.openWAL(walDir, wal.DefaultSegmentSize, false))
+	require.NoError(t, appendOutOfOrderSample(h, first, 50, 1))
+	require.NoError(t, appendOutOfOrderSample(h, second, 250, 2))
+
+	// Rolled back samples are not logged.
+	app := h.appender()
+	require.NoError(t, app.add(second, 150, 3, false))
+	app.rollback()
+
+	// The series record is logged by the first appender committing samples for the series,
+	// even if the series has been created by another one.
+	third := labels.FromStrings(labels.MetricName, "third")
+	creator, committer := h.appender(), h.appender()
+	require.NoError(t, creator.add(third, 150, 5, false))
+	require.NoError(t, committer.add(third, 160, 6, false))
+	require.NoError(t, committer.commit())
+	creator.rollback()
+	require.NoError(t, h.close())
+
+	// Committed samples are replayed when the WAL is opened again.
+	h = newOutOfOrderHead(dir, 100, nil, log.NewNopLogger())
+	require.NoError(t, h.openWAL(walDir, wal.DefaultSegmentSize, false))
+	assert.Equal(t, 3, h.numSeries)
+	assert.Equal(t, 3, h.numSamples)
+	assert.Equal(t, []outOfOrderSample{{t: 50, v: 1}}, h.series[first.Hash()][0].samples)
+	assert.Equal(t, []outOfOrderSample{{t: 250, v: 2}}, h.series[second.Hash()][0].samples)
+	assert.Equal(t, []outOfOrderSample{{t: 160, v: 6}}, h.series[third.Hash()][0].samples)
+
+	// Samples written to blocks are removed from the WAL.
+	written, err := h.writeBlocks(200)
+	require.NoError(t, err)
+	assert.Len(t, written, 2)
+	require.NoError(t, appendOutOfOrderSample(h, second, 240, 4))
+	require.NoError(t, h.close())
+
+	h = newOutOfOrderHead(dir, 100, nil, log.NewNopLogger())
+	require.NoError(t, h.openWAL(walDir, wal.DefaultSegmentSize, false))
+	defer h.close() //nolint:errcheck
+
+	assert.Equal(t, 1, h.numSeries)
+	assert.Equal(t, 2, h.numSamples)
+	assert.Equal(t, []outOfOrderSample{{t: 240, v: 4}, {t: 250, v: 2}}, h.series[second.Hash()][0].samples)
+}
+
+func TestOutOfOrderHead_Querier(t *testing.T) {
+	first := labels.FromStrings(labels.MetricName, "first", "job", "a")
+	second := labels.FromStrings(labels.MetricName, "second", "job", "b")
+
+	h := newOutOfOrderHead("", 100, nil, log.NewNopLogger())
+	for ts := int64(10); ts <= 50; ts += 10 {
+		require.NoError(t, appendOutOfOrderSample(h, second, ts, float64(ts)))
+		require.NoError(t, appendOutOfOrderSample(h, first, ts, float64(ts)))
+	}
+
+	q := h.querier(20, 30)
+
+	set := q.Select(false, nil, labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+"))
+	actual := map[string][]outOfOrderSample{}
+	var order []string
+	for set.Next() {
+		s := set.At()
+		order = append(order, s.Labels().String())
+
+		it := s.Iterator()
+		for it.Next() {
+			ts, v := it.At()
+			actual[s.Labels().String()] = append(actual[s.Labels().String()], outOfOrderSample{t: ts, v: v})
+		}
+		require.NoError(t, it.Err())
+	}
+	require.NoError(t, set.Err())
+
+	assert.Equal(t, []string{first.String(), second.String()}, order)
+	assert.Equal(t, []outOfOrderSample{{t: 20, v: 20}, {t: 30, v: 30}}, actual[first.String()])
+	assert.Equal(t, []outOfOrderSample{{t: 20, v: 20}, {t: 30, v: 30}}, actual[second.String()])
+
+	values, _, err := q.LabelValues("job", labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "second"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, values)
+
+	names, _, err := q.LabelNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{labels.MetricName, "job"}, names)
+
+	// Series without samples in the time range are not returned.
+	set = h.querier(100, 200).Select(false, nil, labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+"))
+	assert.False(t, set.Next())
+}
+
+func TestOutOfOrderHead_WriteBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "out-of-order")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	first := labels.FromStrings(labels.MetricName, "first")
+	second := labels.FromStrings(labels.MetricName, "second")
+
+	h := newOutOfOrderHead(dir, 100, nil, log.NewNopLogger())
+	require.NoError(t, appendOutOfOrderSample(h, first, 50, 1))
+	require.NoError(t, appendOutOfOrderSample(h, second, 10, 2))
+	require.NoError(t, appendOutOfOrderSample(h, first, 150, 3))
+	require.NoError(t, appendOutOfOrderSample(h, second, 250, 4))
+
+	// Only samples older than maxt are written, one block for each block range.
+	written, err := h.writeBlocks(200)
+	require.NoError(t, err)
+	assert.Len(t, written, 2)
+	assert.False(t, h.empty())
+
+	db, err := tsdb.Open(dir, log.NewNopLogger(), nil, tsdb.DefaultOptions())
+	require.NoError(t, err)
+	defer db.Close() //nolint:errcheck
+
+	require.Len(t, db.Blocks(), 2)
+
+	q, err := db.Querier(context.Background(), 0, 300)
+	require.NoError(t, err)
+	defer q.Close() //nolint:errcheck
+
+	set := q.Select(true, nil, labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+"))
+	var actual []outOfOrderSample
+	for set.Next() {
+		it := set.At().Iterator()
+		for it.Next() {
+			ts, v := it.At()
+			actual = append(actual, outOfOrderSample{t: ts, v: v})
+		}
+	}
+	require.NoError(t, set.Err())
+	assert.Equal(t, []outOfOrderSample{{t: 50, v: 1}, {t: 150, v: 3}, {t: 10, v: 2}}, actual)
+
+	// Written samples are removed from the head.
+	assert.Len(t, h.series, 1)
+	assert.Equal(t, []outOfOrderSample{{t: 250, v: 4}}, h.series[second.Hash()][0].samples)
+}
+
+// appendOutOfOrderSample appends a single sample to the head and commits it.
+func appendOutOfOrderSample(h *outOfOrderHead, lbls labels.Labels, t int64, v float64) error {
+	app := h.appender()
+	if err := app.add(lbls, t, v, false); err != nil {
+		return err
+	}
+	return app.commit()
+}
+
+var (
+	errOutOfOrderSeriesLimit  = errors.New("series limit reached")
+	errOutOfOrderSamplesLimit = errors.New("samples limit reached")
+)
+
+type outOfOrderLimiterMock struct {
+	maxSeries          int
+	maxSeriesForMetric int
+	maxSamples         int
+}
+
+func (m *outOfOrderLimiterMock) assertMaxOutOfOrderSeries(_ labels.Labels, numSeries, numSeriesForMetric int) error {
+	if numSeries >= m.maxSeries || numSeriesForMetric >= m.maxSeriesForMetric {
+		return errOutOfOrderSeriesLimit
+	}
+	return nil
+}
+
+func (m *outOfOrderLimiterMock) assertMaxOutOfOrderSamples(numSamples int) error {
+	if numSamples >= m.maxSamples {
+		return errOutOfOrderSamplesLimit
+	}
+	return nil
+}
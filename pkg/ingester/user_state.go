@@ -60,8 +60,9 @@ type userState struct {
 
 // DiscardedSamples metric labels
 const (
-	perUserSeriesLimit   = "per_user_series_limit"
-	perMetricSeriesLimit = "per_metric_series_limit"
+	perUserSeriesLimit            = "per_user_series_limit"
+	perMetricSeriesLimit          = "per_metric_series_limit"
+	perUserOutOfOrderSamplesLimit = "per_user_out_of_order_samples_limit"
 )
 
 func newUserStates(limiter *Limiter, cfg Config, metrics *ingesterMetrics, logger log.Logger) *userStates {
@@ -400,6 +401,14 @@ func (m *metricCounter) canAddSeriesFor(userID, metric string) error {
 	return m.limiter.AssertMaxSeriesPerMetric(userID, shard.m[metric])
 }
 
+func (m *metricCounter) seriesForMetric(metric string) int {
+	shard := m.getShard(metric)
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	return shard.m[metric]
+}
+
 func (m *metricCounter) increaseSeriesForMetric(metric string) {
 	shard := m.getShard(metric)
 	shard.mtx.Lock()
@@ -0,0 +1,175 @@
+package astmapper
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// SplitLabel is a reserved label referencing the time split of a range vector selector.
+const SplitLabel = "__cortex_split__"
+
+// splitCombiners are the aggregations used to combine the results of the splits of each
+// splittable function.
+var splitCombiners = map[string]parser.ItemType{
+	"sum_over_time":   parser.SUM,
+	"count_over_time": parser.SUM,
+	"min_over_time":   parser.MIN,
+	"max_over_time":   parser.MAX,
+}
+
+type instantSplitter struct {
+	interval time.Duration
+	squash   squasher
+
+	// Metrics.
+	splitQueries prometheus.Counter
+}
+
+// NewInstantSplitter instantiates an ASTMapper which splits the range vector selectors
+// longer than the interval into multiple selectors, each one selecting a different time
+// range, and recombines their results. Only the functions whose result can be computed
+// from the results over the sub-ranges are split: sum_over_time, count_over_time,
+// min_over_time, max_over_time and avg_over_time.
+func NewInstantSplitter(interval time.Duration, squasher squasher, splitQueries prometheus.Counter) (ASTMapper, error) {
+	if squasher == nil {
+		return nil, errors.Errorf("squasher required and not passed")
+	}
+	if interval <= 0 {
+		return nil, errors.Errorf("split interval must be positive")
+	}
+
+	return NewASTNodeMapper(&instantSplitter{
+		interval:     interval,
+		squash:       squasher,
+		splitQueries: splitQueries,
+	}), nil
+}
+
+// MapNode implements NodeMapper.
+func (s *instantSplitter) MapNode(node parser.Node) (parser.Node, bool, error) {
+	switch n := node.(type) {
+	case *parser.Call:
+		selector, ok := s.splittableSelector(n)
+		if !ok {
+			return n, false, nil
+		}
+
+		if n.Func.Name == "avg_over_time" {
+			mapped, err := s.splitAvg(selector)
+			return mapped, true, err
+		}
+
+		mapped, err := s.split(n.Func, splitCombiners[n.Func.Name], selector)
+		return mapped, true, err
+
+	case *parser.SubqueryExpr:
+		// Subqueries are evaluated at multiple steps, while the splits are evaluated at
+		// the query time only, so we can't split anything within a subquery.
+		return n, true, nil
+
+	default:
+		return n, false, nil
+	}
+}
+
+// splittableSelector returns the range vector selector of the function call, if the
+// call can be split.
+func (s *instantSplitter) splittableSelector(call *parser.Call) (*parser.MatrixSelector, bool) {
+	if _, ok := splitCombiners[call.Func.Name]; !ok && call.Func.Name != "avg_over_time" {
+		return nil, false
+	}
+	if len(call.Args) != 1 {
+		return nil, false
+	}
+
+	selector, ok := call.Args[0].(*parser.MatrixSelector)
+	if !ok || selector.Range <= s.interval {
+		return nil, false
+	}
+	return selector, true
+}
+
+// split splits the range vector selector of the function call. For example, with a 10d
+// interval, sum_over_time(foo[30d]) is split into:
+//   sum without(__cortex_split__) (
+//     label_replace(sum_over_time(foo[9d23h59m59s999ms]), "__cortex_split__", "0", "", "") or
+//     label_replace(sum_over_time(foo[9d23h59m59s999ms] offset 10d), "__cortex_split__", "1", "", "") or
+//     label_replace(sum_over_time(foo[10d] offset 20d), "__cortex_split__", "2", "", "")
+//   )
+// The range of a selector includes both its start and end, so all the splits but the
+// oldest one are shortened by 1ms to not select the same samples twice.
+func (s *instantSplitter) split(fn *parser.Function, combiner parser.ItemType, selector *parser.MatrixSelector) (parser.Expr, error) {
+	vs, ok := selector.VectorSelector.(*parser.VectorSelector)
+	if !ok {
+		return nil, errors.Errorf("unexpected selector type %T", selector.VectorSelector)
+	}
+
+	var legs []parser.Node
+	for offset := time.Duration(0); offset < selector.Range; offset += s.interval {
+		rng := selector.Range - offset
+		if rng > s.interval {
+			rng = s.interval - time.Millisecond
+		}
+
+		legSelector := *vs
+		legSelector.OriginalOffset += offset
+		legSelector.Offset += offset
+
+		legs = append(legs, &parser.Call{
+			Func: parser.Functions["label_replace"],
+			Args: parser.Expressions{
+				&parser.Call{
+					Func: fn,
+					Args: parser.Expressions{&parser.MatrixSelector{VectorSelector: &legSelector, Range: rng}},
+				},
+				&parser.StringLiteral{Val: SplitLabel},
+				&parser.StringLiteral{Val: strconv.Itoa(len(legs))},
+				&parser.StringLiteral{Val: ""},
+				&parser.StringLiteral{Val: ""},
+			},
+		})
+	}
+
+	if s.splitQueries != nil {
+		s.splitQueries.Add(float64(len(legs)))
+	}
+
+	combined, err := s.squash(legs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.AggregateExpr{
+		Op:       combiner,
+		Expr:     combined,
+		Grouping: []string{SplitLabel},
+		Without:  true,
+	}, nil
+}
+
+// splitAvg splits an avg_over_time as the ratio between the split sum_over_time and the
+// split count_over_time.
+func (s *instantSplitter) splitAvg(selector *parser.MatrixSelector) (parser.Expr, error) {
+	sum, err := s.split(parser.Functions["sum_over_time"], parser.SUM, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.split(parser.Functions["count_over_time"], parser.SUM, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.ParenExpr{
+		Expr: &parser.BinaryExpr{
+			Op:             parser.DIV,
+			LHS:            sum,
+			RHS:            count,
+			VectorMatching: &parser.VectorMatching{Card: parser.CardOneToOne},
+		},
+	}, nil
+}
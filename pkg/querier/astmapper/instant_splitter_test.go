@@ -0,0 +1,96 @@
+package astmapper
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstantSplitter(t *testing.T) {
+	var testExpr = []struct {
+		interval time.Duration
+		input    string
+		expected string
+	}{
+		{
+			interval: time.Hour,
+			input:    `sum_over_time(foo[3h])`,
+			expected: `sum without(__cortex_split__) (
+			  label_replace(sum_over_time(foo[59m59s999ms]), "__cortex_split__", "0", "", "") or
+			  label_replace(sum_over_time(foo[59m59s999ms] offset 1h), "__cortex_split__", "1", "", "") or
+			  label_replace(sum_over_time(foo[1h] offset 2h), "__cortex_split__", "2", "", "")
+			)`,
+		},
+		{
+			interval: time.Hour,
+			input:    `max by(bar) (max_over_time(foo{baz="blip"}[90m] offset 1d))`,
+			expected: `max by(bar) (
+			  max without(__cortex_split__) (
+			    label_replace(max_over_time(foo{baz="blip"}[59m59s999ms] offset 1d), "__cortex_split__", "0", "", "") or
+			    label_replace(max_over_time(foo{baz="blip"}[30m] offset 1d1h), "__cortex_split__", "1", "", "")
+			  )
+			)`,
+		},
+		{
+			interval: time.Hour,
+			input:    `count_over_time(foo[2h]) / min_over_time(bar[2h])`,
+			expected: `sum without(__cortex_split__) (
+			  label_replace(count_over_time(foo[59m59s999ms]), "__cortex_split__", "0", "", "") or
+			  label_replace(count_over_time(foo[1h] offset 1h), "__cortex_split__", "1", "", "")
+			) / min without(__cortex_split__) (
+			  label_replace(min_over_time(bar[59m59s999ms]), "__cortex_split__", "0", "", "") or
+			  label_replace(min_over_time(bar[1h] offset 1h), "__cortex_split__", "1", "", "")
+			)`,
+		},
+		{
+			interval: time.Hour,
+			input:    `avg_over_time(foo[2h])`,
+			expected: `(
+			  sum without(__cortex_split__) (
+			    label_replace(sum_over_time(foo[59m59s999ms]), "__cortex_split__", "0", "", "") or
+			    label_replace(sum_over_time(foo[1h] offset 1h), "__cortex_split__", "1", "", "")
+			  ) / sum without(__cortex_split__) (
+			    label_replace(count_over_time(foo[59m59s999ms]), "__cortex_split__", "0", "", "") or
+			    label_replace(count_over_time(foo[1h] offset 1h), "__cortex_split__", "1", "", "")
+			  )
+			)`,
+		},
+		// Ranges not longer than the interval are not split.
+		{
+			interval: time.Hour,
+			input:    `sum_over_time(foo[1h])`,
+			expected: `sum_over_time(foo[1h])`,
+		},
+		// Functions which can't be computed from the sub-ranges are not split.
+		{
+			interval: time.Hour,
+			input:    `rate(foo[3h])`,
+			expected: `rate(foo[3h])`,
+		},
+		// Subqueries are not split.
+		{
+			interval: time.Hour,
+			input:    `max_over_time(sum_over_time(foo[3h])[6h:1h])`,
+			expected: `max_over_time(sum_over_time(foo[3h])[6h:1h])`,
+		},
+	}
+
+	for i, c := range testExpr {
+		t.Run(fmt.Sprintf("[%d]", i), func(t *testing.T) {
+			splitter, err := NewInstantSplitter(c.interval, orSquasher, nil)
+			require.Nil(t, err)
+			expr, err := parser.ParseExpr(c.input)
+			require.Nil(t, err)
+			res, err := splitter.Map(expr)
+			require.Nil(t, err)
+
+			expected, err := parser.ParseExpr(c.expected)
+			require.Nil(t, err)
+
+			require.Equal(t, expected.String(), res.String())
+		})
+	}
+}
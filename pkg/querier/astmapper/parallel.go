@@ -16,6 +16,7 @@ var summableAggregates = map[parser.ItemType]struct{}{
 	parser.TOPK:    {},
 	parser.BOTTOMK: {},
 	parser.COUNT:   {},
+	parser.AVG:     {},
 }
 
 var nonParallelFuncs = []string{
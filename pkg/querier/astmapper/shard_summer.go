@@ -2,26 +2,13 @@ package astmapper
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/promql/parser"
-)
-
-const (
-	// ShardLabel is a reserved label referencing a cortex shard
-	ShardLabel = "__cortex_shard__"
-	// ShardLabelFmt is the fmt of the ShardLabel key.
-	ShardLabelFmt = "%d_of_%d"
-)
 
-var (
-	// ShardLabelRE matches a value in ShardLabelFmt
-	ShardLabelRE = regexp.MustCompile("^[0-9]+_of_[0-9]+$")
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 type squasher = func(...parser.Node) (parser.Expr, error)
@@ -61,9 +48,20 @@ func (summer *shardSummer) MapNode(node parser.Node) (parser.Node, bool, error)
 
 	switch n := node.(type) {
 	case *parser.AggregateExpr:
-		if CanParallelize(n) && n.Op == parser.SUM {
+		if !CanParallelize(n) {
+			return n, false, nil
+		}
+
+		switch n.Op {
+		case parser.SUM, parser.MIN, parser.MAX, parser.COUNT:
 			result, err := summer.shardSum(n)
 			return result, true, err
+		case parser.TOPK, parser.BOTTOMK:
+			result, err := summer.shardTopK(n)
+			return result, true, err
+		case parser.AVG:
+			result, err := summer.shardAvg(n)
+			return result, true, err
 		}
 
 		return n, false, nil
@@ -105,6 +103,91 @@ func (summer *shardSummer) shardSum(expr *parser.AggregateExpr) (parser.Node, er
 	return parent, nil
 }
 
+// shardTopK contains the logic for how we split/stitch legs of a parallelized topk/bottomk query
+func (summer *shardSummer) shardTopK(expr *parser.AggregateExpr) (parser.Node, error) {
+	/*
+		each shard computes its own topk/bottomk and the parent picks the topk/bottomk among them:
+		label_replace(
+		  topk by(foo) (5,
+		    topk by(foo) (5, rate(bar1{__cortex_shard__="0_of_2",baz="blip"}[1m])) or
+		    topk by(foo) (5, rate(bar1{__cortex_shard__="1_of_2",baz="blip"}[1m]))
+		  ),
+		  "__cortex_shard__", "", "", ""
+		)
+		the outer label_replace() removes the shard label from the selected series.
+	*/
+	parent := &parser.AggregateExpr{
+		Op:       expr.Op,
+		Param:    expr.Param,
+		Grouping: expr.Grouping,
+		Without:  expr.Without,
+	}
+
+	// Series returned by each shard have the shard label, so it must be excluded from the grouping.
+	if expr.Without {
+		parent.Grouping = append(append(make([]string, 0, len(expr.Grouping)+1), expr.Grouping...), sharding.ShardLabel)
+	}
+
+	children, err := summer.shardChildren(expr, func(sharded *parser.AggregateExpr) parser.Expr {
+		sharded.Param = expr.Param
+		sharded.Grouping = expr.Grouping
+		sharded.Without = expr.Without
+		return sharded
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parent.Expr, err = summer.squash(children...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.Call{
+		Func: parser.Functions["label_replace"],
+		Args: parser.Expressions{
+			parent,
+			&parser.StringLiteral{Val: sharding.ShardLabel},
+			&parser.StringLiteral{Val: ""},
+			&parser.StringLiteral{Val: ""},
+			&parser.StringLiteral{Val: ""},
+		},
+	}, nil
+}
+
+// shardAvg parallelizes an avg query by rewriting it as the ratio between the sharded
+// sum and the sharded count, using the same grouping of the original query.
+func (summer *shardSummer) shardAvg(expr *parser.AggregateExpr) (parser.Node, error) {
+	sum, err := summer.shardSum(&parser.AggregateExpr{
+		Op:       parser.SUM,
+		Expr:     expr.Expr,
+		Grouping: expr.Grouping,
+		Without:  expr.Without,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := summer.shardSum(&parser.AggregateExpr{
+		Op:       parser.COUNT,
+		Expr:     expr.Expr,
+		Grouping: expr.Grouping,
+		Without:  expr.Without,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &parser.ParenExpr{
+		Expr: &parser.BinaryExpr{
+			Op:             parser.DIV,
+			LHS:            sum.(parser.Expr),
+			RHS:            count.(parser.Expr),
+			VectorMatching: &parser.VectorMatching{Card: parser.CardOneToOne},
+		},
+	}, nil
+}
+
 // splitSum forms the parent and child legs of a parallel query
 func (summer *shardSummer) splitSum(
 	expr *parser.AggregateExpr,
@@ -117,6 +200,12 @@ func (summer *shardSummer) splitSum(
 		Op:    expr.Op,
 		Param: expr.Param,
 	}
+
+	// The count of each shard must be summed up to get the total count.
+	if expr.Op == parser.COUNT {
+		parent.Op = parser.SUM
+	}
+
 	var mkChild func(sharded *parser.AggregateExpr) parser.Expr
 
 	if expr.Without {
@@ -133,7 +222,7 @@ func (summer *shardSummer) splitSum(
 			)
 
 		*/
-		parent.Grouping = []string{ShardLabel}
+		parent.Grouping = []string{sharding.ShardLabel}
 		parent.Without = true
 		mkChild = func(sharded *parser.AggregateExpr) parser.Expr {
 			sharded.Grouping = expr.Grouping
@@ -152,7 +241,7 @@ func (summer *shardSummer) splitSum(
 		mkChild = func(sharded *parser.AggregateExpr) parser.Expr {
 			groups := make([]string, 0, len(expr.Grouping)+1)
 			groups = append(groups, expr.Grouping...)
-			groups = append(groups, ShardLabel)
+			groups = append(groups, sharding.ShardLabel)
 			sharded.Grouping = groups
 			return sharded
 		}
@@ -169,25 +258,31 @@ func (summer *shardSummer) splitSum(
 			  sum by(__cortex_shard__) (rate(bar1{__cortex_shard__="1_of_2",baz="blip"}[1m]))
 			)
 		*/
-		parent.Grouping = []string{ShardLabel}
+		parent.Grouping = []string{sharding.ShardLabel}
 		parent.Without = true
 		mkChild = func(sharded *parser.AggregateExpr) parser.Expr {
-			sharded.Grouping = []string{ShardLabel}
+			sharded.Grouping = []string{sharding.ShardLabel}
 			return sharded
 		}
 	}
 
+	children, err = summer.shardChildren(expr, mkChild)
+	return parent, children, err
+}
+
+// shardChildren creates a child leg of a parallel query for each shard.
+func (summer *shardSummer) shardChildren(expr *parser.AggregateExpr, mkChild func(sharded *parser.AggregateExpr) parser.Expr) (children []parser.Node, err error) {
 	// iterate across shardFactor to create children
 	for i := 0; i < summer.shards; i++ {
 		cloned, err := CloneNode(expr.Expr)
 		if err != nil {
-			return parent, children, err
+			return children, err
 		}
 
 		subSummer := NewASTNodeMapper(summer.CopyWithCurShard(i))
 		sharded, err := subSummer.Map(cloned)
 		if err != nil {
-			return parent, children, err
+			return children, err
 		}
 
 		subSum := mkChild(&parser.AggregateExpr{
@@ -202,7 +297,7 @@ func (summer *shardSummer) splitSum(
 
 	summer.recordShards(float64(summer.shards))
 
-	return parent, children, nil
+	return children, nil
 }
 
 // ShardSummer is explicitly passed a prometheus.Counter during construction
@@ -215,7 +310,7 @@ func (summer *shardSummer) recordShards(n float64) {
 }
 
 func shardVectorSelector(curshard, shards int, selector *parser.VectorSelector) (parser.Node, error) {
-	shardMatcher, err := labels.NewMatcher(labels.MatchEqual, ShardLabel, fmt.Sprintf(ShardLabelFmt, curshard, shards))
+	shardMatcher, err := labels.NewMatcher(labels.MatchEqual, sharding.ShardLabel, fmt.Sprintf(sharding.ShardLabelFmt, curshard, shards))
 	if err != nil {
 		return nil, err
 	}
@@ -231,7 +326,7 @@ func shardVectorSelector(curshard, shards int, selector *parser.VectorSelector)
 }
 
 func shardMatrixSelector(curshard, shards int, selector *parser.MatrixSelector) (parser.Node, error) {
-	shardMatcher, err := labels.NewMatcher(labels.MatchEqual, ShardLabel, fmt.Sprintf(ShardLabelFmt, curshard, shards))
+	shardMatcher, err := labels.NewMatcher(labels.MatchEqual, sharding.ShardLabel, fmt.Sprintf(sharding.ShardLabelFmt, curshard, shards))
 	if err != nil {
 		return nil, err
 	}
@@ -254,61 +349,3 @@ func shardMatrixSelector(curshard, shards int, selector *parser.MatrixSelector)
 
 	return nil, fmt.Errorf("invalid selector type: %T", selector.VectorSelector)
 }
-
-// ParseShard will extract the shard information encoded in ShardLabelFmt
-func ParseShard(input string) (parsed ShardAnnotation, err error) {
-	if !ShardLabelRE.MatchString(input) {
-		return parsed, errors.Errorf("Invalid ShardLabel value: [%s]", input)
-	}
-
-	matches := strings.Split(input, "_")
-	x, err := strconv.Atoi(matches[0])
-	if err != nil {
-		return parsed, err
-	}
-	of, err := strconv.Atoi(matches[2])
-	if err != nil {
-		return parsed, err
-	}
-
-	if x >= of {
-		return parsed, errors.Errorf("Shards out of bounds: [%d] >= [%d]", x, of)
-	}
-	return ShardAnnotation{
-		Shard: x,
-		Of:    of,
-	}, err
-}
-
-// ShardAnnotation is a convenience struct which holds data from a parsed shard label
-type ShardAnnotation struct {
-	Shard int
-	Of    int
-}
-
-// String encodes a shardAnnotation into a label value
-func (shard ShardAnnotation) String() string {
-	return fmt.Sprintf(ShardLabelFmt, shard.Shard, shard.Of)
-}
-
-// Label generates the ShardAnnotation as a label
-func (shard ShardAnnotation) Label() labels.Label {
-	return labels.Label{
-		Name:  ShardLabel,
-		Value: shard.String(),
-	}
-}
-
-// ShardFromMatchers extracts a ShardAnnotation and the index it was pulled from in the matcher list
-func ShardFromMatchers(matchers []*labels.Matcher) (shard *ShardAnnotation, idx int, err error) {
-	for i, matcher := range matchers {
-		if matcher.Name == ShardLabel && matcher.Type == labels.MatchEqual {
-			shard, err := ParseShard(matcher.Value)
-			if err != nil {
-				return nil, i, err
-			}
-			return &shard, i, nil
-		}
-	}
-	return nil, 0, nil
-}
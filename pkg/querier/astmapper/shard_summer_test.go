@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/stretchr/testify/require"
 )
@@ -110,9 +109,80 @@ func TestShardSummer(t *testing.T) {
 		},
 		{
 			// Disallow sharding nested aggregations as they may merge series in a non-associative manner.
-			shards:   2,
-			input:    `sum(count(foo{}))`,
-			expected: `sum(count(foo{}))`,
+			// Instead it only maps the subAggregation but not the outer one.
+			shards: 2,
+			input:  `sum(count(foo{}))`,
+			expected: `sum(
+			  sum without(__cortex_shard__) (
+			    count by(__cortex_shard__) (foo{__cortex_shard__="0_of_2"}) or
+			    count by(__cortex_shard__) (foo{__cortex_shard__="1_of_2"})
+			  )
+			)`,
+		},
+		// min/max
+		{
+			shards: 2,
+			input:  `max by(foo) (rate(bar1{baz="blip"}[1m]))`,
+			expected: `max by(foo) (
+			  max by(foo, __cortex_shard__) (rate(bar1{__cortex_shard__="0_of_2",baz="blip"}[1m])) or
+			  max by(foo, __cortex_shard__) (rate(bar1{__cortex_shard__="1_of_2",baz="blip"}[1m]))
+			)`,
+		},
+		{
+			shards: 2,
+			input:  `min without(foo) (bar1{baz="blip"})`,
+			expected: `min without(__cortex_shard__) (
+			  min without(foo) (bar1{__cortex_shard__="0_of_2",baz="blip"}) or
+			  min without(foo) (bar1{__cortex_shard__="1_of_2",baz="blip"})
+			)`,
+		},
+		// count
+		{
+			shards: 2,
+			input:  `count by(foo) (bar1{baz="blip"})`,
+			expected: `sum by(foo) (
+			  count by(foo, __cortex_shard__) (bar1{__cortex_shard__="0_of_2",baz="blip"}) or
+			  count by(foo, __cortex_shard__) (bar1{__cortex_shard__="1_of_2",baz="blip"})
+			)`,
+		},
+		// avg
+		{
+			shards: 2,
+			input:  `avg by(foo) (rate(bar1{baz="blip"}[1m]))`,
+			expected: `(
+			  sum by(foo) (
+			    sum by(foo, __cortex_shard__) (rate(bar1{__cortex_shard__="0_of_2",baz="blip"}[1m])) or
+			    sum by(foo, __cortex_shard__) (rate(bar1{__cortex_shard__="1_of_2",baz="blip"}[1m]))
+			  )
+			  /
+			  sum by(foo) (
+			    count by(foo, __cortex_shard__) (rate(bar1{__cortex_shard__="0_of_2",baz="blip"}[1m])) or
+			    count by(foo, __cortex_shard__) (rate(bar1{__cortex_shard__="1_of_2",baz="blip"}[1m]))
+			  )
+			)`,
+		},
+		// topk/bottomk
+		{
+			shards: 2,
+			input:  `topk by(foo) (5, rate(bar1{baz="blip"}[1m]))`,
+			expected: `label_replace(
+			  topk by(foo) (5,
+			    topk by(foo) (5, rate(bar1{__cortex_shard__="0_of_2",baz="blip"}[1m])) or
+			    topk by(foo) (5, rate(bar1{__cortex_shard__="1_of_2",baz="blip"}[1m]))
+			  ),
+			  "__cortex_shard__", "", "", ""
+			)`,
+		},
+		{
+			shards: 2,
+			input:  `bottomk without(foo) (3, bar1{baz="blip"})`,
+			expected: `label_replace(
+			  bottomk without(foo, __cortex_shard__) (3,
+			    bottomk without(foo) (3, bar1{__cortex_shard__="0_of_2",baz="blip"}) or
+			    bottomk without(foo) (3, bar1{__cortex_shard__="1_of_2",baz="blip"})
+			  ),
+			  "__cortex_shard__", "", "", ""
+			)`,
 		},
 	}
 
@@ -161,109 +231,3 @@ func TestShardSummerWithEncoding(t *testing.T) {
 		})
 	}
 }
-
-func TestParseShard(t *testing.T) {
-	var testExpr = []struct {
-		input  string
-		output ShardAnnotation
-		err    bool
-	}{
-		{
-			input:  "lsdjf",
-			output: ShardAnnotation{},
-			err:    true,
-		},
-		{
-			input:  "a_of_3",
-			output: ShardAnnotation{},
-			err:    true,
-		},
-		{
-			input:  "3_of_3",
-			output: ShardAnnotation{},
-			err:    true,
-		},
-		{
-			input: "1_of_2",
-			output: ShardAnnotation{
-				Shard: 1,
-				Of:    2,
-			},
-		},
-	}
-
-	for _, c := range testExpr {
-		t.Run(fmt.Sprint(c.input), func(t *testing.T) {
-			shard, err := ParseShard(c.input)
-			if c.err {
-				require.NotNil(t, err)
-			} else {
-				require.Nil(t, err)
-				require.Equal(t, c.output, shard)
-			}
-		})
-	}
-
-}
-
-func TestShardFromMatchers(t *testing.T) {
-	var testExpr = []struct {
-		input []*labels.Matcher
-		shard *ShardAnnotation
-		idx   int
-		err   bool
-	}{
-		{
-			input: []*labels.Matcher{
-				{},
-				{
-					Name: ShardLabel,
-					Type: labels.MatchEqual,
-					Value: ShardAnnotation{
-						Shard: 10,
-						Of:    16,
-					}.String(),
-				},
-				{},
-			},
-			shard: &ShardAnnotation{
-				Shard: 10,
-				Of:    16,
-			},
-			idx: 1,
-			err: false,
-		},
-		{
-			input: []*labels.Matcher{
-				{
-					Name:  ShardLabel,
-					Type:  labels.MatchEqual,
-					Value: "invalid-fmt",
-				},
-			},
-			shard: nil,
-			idx:   0,
-			err:   true,
-		},
-		{
-			input: []*labels.Matcher{},
-			shard: nil,
-			idx:   0,
-			err:   false,
-		},
-	}
-
-	for i, c := range testExpr {
-		t.Run(fmt.Sprint(i), func(t *testing.T) {
-			shard, idx, err := ShardFromMatchers(c.input)
-			if c.err {
-				require.NotNil(t, err)
-			} else {
-				require.Nil(t, err)
-				require.Equal(t, c.shard, shard)
-				require.Equal(t, c.idx, idx)
-			}
-		})
-	}
-
-}
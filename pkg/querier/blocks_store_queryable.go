@@ -28,6 +28,7 @@ import (
 	grpc_metadata "google.golang.org/grpc/metadata"
 
 	"github.com/cortexproject/cortex/pkg/querier/series"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/ring"
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/storage/bucket"
@@ -37,6 +38,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/storegateway/storegatewaypb"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/math"
 	"github.com/cortexproject/cortex/pkg/util/services"
@@ -347,6 +349,38 @@ func (q *blocksStoreQuerier) LabelNames() ([]string, storage.Warnings, error) {
 	return strutil.MergeSlices(resNameSets...), resWarnings, nil
 }
 
+// LabelNamesWithMatchers implements LabelNamesWithMatchersQuerier. The store-gateway LabelNames API
+// doesn't support matchers, so the label names of the series matching the matchers are looked up
+// fetching the series from the store-gateways without chunks.
+func (q *blocksStoreQuerier) LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	if len(matchers) == 0 {
+		return q.LabelNames()
+	}
+
+	spanLog, _ := spanlogger.New(q.ctx, "blocksStoreQuerier.LabelNamesWithMatchers")
+	defer spanLog.Span.Finish()
+
+	set := q.selectSorted(&storage.SelectHints{Start: q.minT, End: q.maxT, Func: "series"}, matchers...)
+
+	names := map[string]struct{}{}
+	for set.Next() {
+		for _, l := range set.At().Labels() {
+			names[l.Name] = struct{}{}
+		}
+	}
+	if err := set.Err(); err != nil {
+		return nil, set.Warnings(), err
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result, set.Warnings(), nil
+}
+
 func (q *blocksStoreQuerier) LabelValues(name string, matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
 	spanLog, spanCtx := spanlogger.New(q.ctx, "blocksStoreQuerier.LabelValues")
 	defer spanLog.Span.Finish()
@@ -562,6 +596,7 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 		queriedBlocks = []ulid.ULID(nil)
 		numChunks     = atomic.NewInt32(0)
 		spanLog       = spanlogger.FromContext(ctx)
+		queryLimiter  = limiter.QueryLimiterFromContextWithFallback(ctx)
 	)
 
 	// Concurrently fetch series from all clients.
@@ -617,6 +652,14 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 							return fmt.Errorf(errMaxChunksPerQueryLimit, convertMatchersToString(matchers), maxChunksLimit)
 						}
 					}
+
+					// Ensure the per-query limits, shared with ingesters and the other store-gateways, haven't been reached.
+					if limitErr := queryLimiter.AddSeries(s.PromLabels()); limitErr != nil {
+						return limitErr
+					}
+					if limitErr := queryLimiter.AddChunkBytes(int(countSeriesBytes([]*storepb.Series{s}))); limitErr != nil {
+						return limitErr
+					}
 				}
 
 				if w := resp.GetWarning(); w != "" {
@@ -638,13 +681,19 @@ func (q *blocksStoreQuerier) fetchSeriesFromStores(
 				}
 			}
 
+			numSeriesBytes := countSeriesBytes(mySeries)
 			level.Debug(spanLog).Log("msg", "received series from store-gateway",
 				"instance", c.RemoteAddress(),
 				"num series", len(mySeries),
-				"bytes series", countSeriesBytes(mySeries),
+				"bytes series", numSeriesBytes,
 				"requested blocks", strings.Join(convertULIDsToString(blockIDs), " "),
 				"queried blocks", strings.Join(convertULIDsToString(myQueriedBlocks), " "))
 
+			reqStats := stats.FromContext(ctx)
+			reqStats.AddStoreGatewayRequests(1)
+			reqStats.AddFetchedSeriesCount(uint64(len(mySeries)))
+			reqStats.AddFetchedChunkBytes(numSeriesBytes)
+
 			// Store the result.
 			mtx.Lock()
 			seriesSets = append(seriesSets, &blockQuerierSeriesSet{series: mySeries})
@@ -31,6 +31,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/storage/tsdb/bucketindex"
 	"github.com/cortexproject/cortex/pkg/storegateway/storegatewaypb"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	"github.com/cortexproject/cortex/pkg/util/services"
 )
 
@@ -66,6 +67,7 @@ func TestBlocksStoreQuerier_Select(t *testing.T) {
 		finderErr         error
 		storeSetResponses []interface{}
 		limits            BlocksStoreLimits
+		queryLimiter      *limiter.QueryLimiter
 		expectedSeries    []seriesResult
 		expectedErr       string
 		expectedMetrics   string
@@ -474,11 +476,53 @@ func TestBlocksStoreQuerier_Select(t *testing.T) {
 			limits:      &blocksStoreLimitsMock{maxChunksPerQuery: 3},
 			expectedErr: fmt.Sprintf(errMaxChunksPerQueryLimit, fmt.Sprintf("{__name__=%q}", metricName), 3),
 		},
+		"max fetched series per query limit hit across multiple store-gateways": {
+			finderResult: bucketindex.Blocks{
+				{ID: block1},
+				{ID: block2},
+			},
+			storeSetResponses: []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{remoteAddr: "1.1.1.1", mockedSeriesResponses: []*storepb.SeriesResponse{
+						mockSeriesResponse(labels.Labels{metricNameLabel, series1Label}, minT, 1),
+						mockHintsResponse(block1),
+					}}: {block1},
+					&storeGatewayClientMock{remoteAddr: "2.2.2.2", mockedSeriesResponses: []*storepb.SeriesResponse{
+						mockSeriesResponse(labels.Labels{metricNameLabel, series2Label}, minT, 2),
+						mockHintsResponse(block2),
+					}}: {block2},
+				},
+			},
+			limits:       &blocksStoreLimitsMock{},
+			queryLimiter: limiter.NewQueryLimiter(1, 0),
+			expectedErr:  "the query hit the max number of series limit while fetching series (limit: 1 series)",
+		},
+		"max fetched chunk bytes per query limit hit": {
+			finderResult: bucketindex.Blocks{
+				{ID: block1},
+				{ID: block2},
+			},
+			storeSetResponses: []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{remoteAddr: "1.1.1.1", mockedSeriesResponses: []*storepb.SeriesResponse{
+						mockSeriesResponse(labels.Labels{metricNameLabel, series1Label}, minT, 1),
+						mockSeriesResponse(labels.Labels{metricNameLabel, series1Label}, minT+1, 2),
+						mockHintsResponse(block1, block2),
+					}}: {block1, block2},
+				},
+			},
+			limits:       &blocksStoreLimitsMock{},
+			queryLimiter: limiter.NewQueryLimiter(0, 1),
+			expectedErr:  "the query hit the aggregated chunks size limit while fetching chunks (limit: 1 bytes)",
+		},
 	}
 
 	for testName, testData := range tests {
 		t.Run(testName, func(t *testing.T) {
 			ctx := context.Background()
+			if testData.queryLimiter != nil {
+				ctx = limiter.AddQueryLimiterToContext(ctx, testData.queryLimiter)
+			}
 			reg := prometheus.NewPedanticRegistry()
 			stores := &blocksStoreSetMock{mockedResponses: testData.storeSetResponses}
 			finder := &blocksFinderMock{}
@@ -1012,6 +1056,75 @@ func TestBlocksStoreQuerier_Labels(t *testing.T) {
 	}
 }
 
+func TestBlocksStoreQuerier_LabelNamesWithMatchers(t *testing.T) {
+	const (
+		minT = int64(10)
+		maxT = int64(20)
+	)
+
+	var (
+		block1  = ulid.MustNew(1, nil)
+		series1 = labels.FromStrings(labels.MetricName, "test_metric_1", "series1", "1")
+		series2 = labels.FromStrings(labels.MetricName, "test_metric_2", "series2", "1")
+	)
+
+	tests := map[string]struct {
+		matchers           []*labels.Matcher
+		expectedLabelNames []string
+	}{
+		"no matchers should query the store-gateway label names": {
+			expectedLabelNames: namesFromSeries(series1, series2),
+		},
+		"matchers should only return the label names of the matching series": {
+			matchers:           []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test_metric_1")},
+			expectedLabelNames: namesFromSeries(series1),
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// The store-gateway mock returns only the series matching the matchers,
+			// while the label names API returns the names of all the series.
+			stores := &blocksStoreSetMock{mockedResponses: []interface{}{
+				map[BlocksStoreClient][]ulid.ULID{
+					&storeGatewayClientMock{
+						remoteAddr: "1.1.1.1",
+						mockedSeriesResponses: []*storepb.SeriesResponse{
+							mockSeriesResponse(series1, minT, 1),
+							mockHintsResponse(block1),
+						},
+						mockedLabelNamesResponse: &storepb.LabelNamesResponse{
+							Names:    namesFromSeries(series1, series2),
+							Warnings: []string{},
+							Hints:    mockNamesHints(block1),
+						},
+					}: {block1},
+				},
+			}}
+			finder := &blocksFinderMock{}
+			finder.On("GetBlocks", mock.Anything, "user-1", minT, maxT).Return(bucketindex.Blocks{{ID: block1}}, map[ulid.ULID]*bucketindex.BlockDeletionMark(nil), error(nil))
+
+			q := &blocksStoreQuerier{
+				ctx:         context.Background(),
+				minT:        minT,
+				maxT:        maxT,
+				userID:      "user-1",
+				finder:      finder,
+				stores:      stores,
+				consistency: NewBlocksConsistencyChecker(0, 0, log.NewNopLogger(), nil),
+				logger:      log.NewNopLogger(),
+				metrics:     newBlocksStoreQueryableMetrics(prometheus.NewPedanticRegistry()),
+				limits:      &blocksStoreLimitsMock{},
+			}
+
+			names, warnings, err := q.LabelNamesWithMatchers(testData.matchers...)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(warnings))
+			require.Equal(t, testData.expectedLabelNames, names)
+		})
+	}
+}
+
 func TestBlocksStoreQuerier_SelectSortedShouldHonorQueryStoreAfter(t *testing.T) {
 	now := time.Now()
 
@@ -0,0 +1,143 @@
+package querier
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+const defaultCardinalityLimit = 20
+
+var errNoLabelNames = errors.New("at least one label name must be specified in the label_names[] parameter")
+
+type labelNameCardinality struct {
+	LabelName        string `json:"label_name"`
+	LabelValuesCount int    `json:"label_values_count"`
+	SeriesCount      uint64 `json:"series_count"`
+}
+
+type labelValueCardinality struct {
+	LabelValue  string `json:"label_value"`
+	SeriesCount uint64 `json:"series_count"`
+}
+
+type labelValuesCardinality struct {
+	LabelName        string                  `json:"label_name"`
+	LabelValuesCount int                     `json:"label_values_count"`
+	SeriesCount      uint64                  `json:"series_count"`
+	Cardinality      []labelValueCardinality `json:"cardinality"`
+}
+
+type cardinalityResult struct {
+	Status string      `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// LabelNamesCardinalityHandler returns, for each label name of the in-memory series matching the
+// optional selector, the number of series and distinct values. Label names are sorted by the number
+// of distinct values, in descending order, and at most limit label names are returned.
+func LabelNamesCardinalityHandler(d Distributor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		matchers, limit, err := parseCardinalityParams(r)
+		if err != nil {
+			writeCardinalityError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		items, err := d.LabelCardinality(r.Context(), nil, limit, true, matchers...)
+		if err != nil {
+			writeCardinalityError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		result := make([]labelNameCardinality, 0, len(items))
+		for _, item := range items {
+			result = append(result, labelNameCardinality{
+				LabelName:        item.LabelName,
+				LabelValuesCount: int(item.LabelValuesCount),
+				SeriesCount:      item.SeriesCount,
+			})
+		}
+
+		util.WriteJSONResponse(w, cardinalityResult{Status: statusSuccess, Data: result})
+	})
+}
+
+// LabelValuesCardinalityHandler returns, for each label name in the label_names[] parameter, the number
+// of in-memory series matching the optional selector for each label value. Label values are sorted by
+// the number of series, in descending order, and at most limit label values are returned for each label.
+func LabelValuesCardinalityHandler(d Distributor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		matchers, limit, err := parseCardinalityParams(r)
+		if err != nil {
+			writeCardinalityError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		labelNames := r.Form["label_names[]"]
+		if len(labelNames) == 0 {
+			writeCardinalityError(w, http.StatusBadRequest, errNoLabelNames)
+			return
+		}
+
+		items, err := d.LabelCardinality(r.Context(), labelNames, limit, false, matchers...)
+		if err != nil {
+			writeCardinalityError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		result := make([]labelValuesCardinality, 0, len(items))
+		for _, item := range items {
+			values := make([]labelValueCardinality, 0, len(item.Values))
+			for _, v := range item.Values {
+				values = append(values, labelValueCardinality{LabelValue: v.LabelValue, SeriesCount: v.SeriesCount})
+			}
+
+			result = append(result, labelValuesCardinality{
+				LabelName:        item.LabelName,
+				LabelValuesCount: int(item.LabelValuesCount),
+				SeriesCount:      item.SeriesCount,
+				Cardinality:      values,
+			})
+		}
+
+		util.WriteJSONResponse(w, cardinalityResult{Status: statusSuccess, Data: result})
+	})
+}
+
+// parseCardinalityParams parses the optional selector and limit parameters.
+func parseCardinalityParams(r *http.Request) ([]*labels.Matcher, int, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, 0, err
+	}
+
+	var matchers []*labels.Matcher
+	if selector := r.FormValue("selector"); selector != "" {
+		var err error
+		if matchers, err = parser.ParseMetricSelector(selector); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid selector")
+		}
+	}
+
+	limit := defaultCardinalityLimit
+	if value := r.FormValue("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return nil, 0, fmt.Errorf("invalid limit %q: must be a positive integer", value)
+		}
+	}
+
+	return matchers, limit, nil
+}
+
+func writeCardinalityError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+	util.WriteJSONResponse(w, cardinalityResult{Status: statusError, Error: err.Error()})
+}
@@ -0,0 +1,127 @@
+package querier
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+func TestLabelNamesCardinalityHandler(t *testing.T) {
+	d := &mockDistributor{}
+	d.On("LabelCardinality", mock.Anything, []string(nil), 2, true, []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "test")}).Return(
+		[]*client.LabelCardinality{
+			{LabelName: "pod", LabelValuesCount: 3, SeriesCount: 3},
+			{LabelName: "instance", LabelValuesCount: 2, SeriesCount: 2},
+		},
+		nil)
+
+	handler := LabelNamesCardinalityHandler(d)
+
+	request, err := http.NewRequest("GET", `/api/v1/cardinality/label_names?selector={__name__="test"}&limit=2`, nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+
+	expectedJSON := `
+	{
+		"status": "success",
+		"data": [
+			{"label_name": "pod", "label_values_count": 3, "series_count": 3},
+			{"label_name": "instance", "label_values_count": 2, "series_count": 2}
+		]
+	}
+	`
+
+	require.JSONEq(t, expectedJSON, string(responseBody))
+}
+
+func TestLabelValuesCardinalityHandler(t *testing.T) {
+	d := &mockDistributor{}
+	d.On("LabelCardinality", mock.Anything, []string{"pod"}, 2, false, []*labels.Matcher(nil)).Return(
+		[]*client.LabelCardinality{
+			{LabelName: "pod", Values: []client.LabelValueCardinality{{LabelValue: "b", SeriesCount: 5}, {LabelValue: "c", SeriesCount: 2}}, LabelValuesCount: 3, SeriesCount: 8},
+		},
+		nil)
+
+	handler := LabelValuesCardinalityHandler(d)
+
+	request, err := http.NewRequest("GET", "/api/v1/cardinality/label_values?label_names[]=pod&limit=2", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+
+	expectedJSON := `
+	{
+		"status": "success",
+		"data": [
+			{
+				"label_name": "pod",
+				"label_values_count": 3,
+				"series_count": 8,
+				"cardinality": [
+					{"label_value": "b", "series_count": 5},
+					{"label_value": "c", "series_count": 2}
+				]
+			}
+		]
+	}
+	`
+
+	require.JSONEq(t, expectedJSON, string(responseBody))
+}
+
+func TestCardinalityHandlers_InvalidParams(t *testing.T) {
+	tests := map[string]struct {
+		handler       http.Handler
+		url           string
+		expectedError string
+	}{
+		"invalid selector": {
+			handler:       LabelNamesCardinalityHandler(&mockDistributor{}),
+			url:           "/api/v1/cardinality/label_names?selector=}",
+			expectedError: "invalid selector",
+		},
+		"invalid limit": {
+			handler:       LabelNamesCardinalityHandler(&mockDistributor{}),
+			url:           "/api/v1/cardinality/label_names?limit=0",
+			expectedError: "must be a positive integer",
+		},
+		"missing label names": {
+			handler:       LabelValuesCardinalityHandler(&mockDistributor{}),
+			url:           "/api/v1/cardinality/label_values",
+			expectedError: errNoLabelNames.Error(),
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			request, err := http.NewRequest("GET", testData.url, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testData.handler.ServeHTTP(recorder, request)
+
+			require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+			responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+			require.NoError(t, err)
+			require.Contains(t, string(responseBody), testData.expectedError)
+		})
+	}
+}
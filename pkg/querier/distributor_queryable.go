@@ -26,8 +26,10 @@ import (
 type Distributor interface {
 	Query(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) (model.Matrix, error)
 	QueryStream(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) (*client.QueryStreamResponse, error)
+	QueryExemplars(ctx context.Context, from, to model.Time, matchers ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error)
 	LabelValuesForLabelName(ctx context.Context, from, to model.Time, label model.LabelName, matchers ...*labels.Matcher) ([]string, error)
-	LabelNames(context.Context, model.Time, model.Time) ([]string, error)
+	LabelNames(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) ([]string, error)
+	LabelCardinality(ctx context.Context, labelNames []string, limit int, labelNamesOnly bool, matchers ...*labels.Matcher) ([]*client.LabelCardinality, error)
 	MetricsForLabelMatchers(ctx context.Context, from, through model.Time, matchers ...*labels.Matcher) ([]metric.Metric, error)
 	MetricsMetadata(ctx context.Context) ([]scrape.MetricMetadata, error)
 }
@@ -190,7 +192,12 @@ func (q *distributorQuerier) LabelValues(name string, matchers ...*labels.Matche
 }
 
 func (q *distributorQuerier) LabelNames() ([]string, storage.Warnings, error) {
-	ln, err := q.distributor.LabelNames(q.ctx, model.Time(q.mint), model.Time(q.maxt))
+	return q.LabelNamesWithMatchers()
+}
+
+// LabelNamesWithMatchers implements LabelNamesWithMatchersQuerier.
+func (q *distributorQuerier) LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	ln, err := q.distributor.LabelNames(q.ctx, model.Time(q.mint), model.Time(q.maxt), matchers...)
 	return ln, nil, err
 }
 
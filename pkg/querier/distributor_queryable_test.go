@@ -338,14 +338,22 @@ func (m *mockDistributor) QueryStream(ctx context.Context, from, to model.Time,
 	args := m.Called(ctx, from, to, matchers)
 	return args.Get(0).(*client.QueryStreamResponse), args.Error(1)
 }
+func (m *mockDistributor) QueryExemplars(ctx context.Context, from, to model.Time, matchers ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error) {
+	args := m.Called(ctx, from, to, matchers)
+	return args.Get(0).(*client.ExemplarQueryResponse), args.Error(1)
+}
 func (m *mockDistributor) LabelValuesForLabelName(ctx context.Context, from, to model.Time, lbl model.LabelName, matchers ...*labels.Matcher) ([]string, error) {
 	args := m.Called(ctx, from, to, lbl, matchers)
 	return args.Get(0).([]string), args.Error(1)
 }
-func (m *mockDistributor) LabelNames(ctx context.Context, from, to model.Time) ([]string, error) {
-	args := m.Called(ctx, from, to)
+func (m *mockDistributor) LabelNames(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) ([]string, error) {
+	args := m.Called(ctx, from, to, matchers)
 	return args.Get(0).([]string), args.Error(1)
 }
+func (m *mockDistributor) LabelCardinality(ctx context.Context, labelNames []string, limit int, labelNamesOnly bool, matchers ...*labels.Matcher) ([]*client.LabelCardinality, error) {
+	args := m.Called(ctx, labelNames, limit, labelNamesOnly, matchers)
+	return args.Get(0).([]*client.LabelCardinality), args.Error(1)
+}
 func (m *mockDistributor) MetricsForLabelMatchers(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) ([]metric.Metric, error) {
 	args := m.Called(ctx, from, to, matchers)
 	return args.Get(0).([]metric.Metric), args.Error(1)
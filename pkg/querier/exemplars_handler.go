@@ -0,0 +1,141 @@
+package querier
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/weaveworks/common/httpgrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+var errEndBeforeStart = errors.New("end timestamp must not be before start time")
+
+type exemplar struct {
+	Labels    labels.Labels     `json:"labels"`
+	Value     model.SampleValue `json:"value"`
+	Timestamp model.Time        `json:"timestamp"`
+}
+
+type exemplarQueryResult struct {
+	SeriesLabels labels.Labels `json:"seriesLabels"`
+	Exemplars    []exemplar    `json:"exemplars"`
+}
+
+type exemplarsResult struct {
+	Status    string                `json:"status"`
+	Data      []exemplarQueryResult `json:"data"`
+	ErrorType string                `json:"errorType,omitempty"`
+	Error     string                `json:"error,omitempty"`
+}
+
+// ExemplarsHandler returns the exemplars of the series selected by the input PromQL
+// query, in the same format of the Prometheus /api/v1/query_exemplars endpoint.
+// Exemplars are only held in the ingesters' memory.
+func ExemplarsHandler(d Distributor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, err := parseTimeParam(r, "start", math.MinInt64)
+		if err != nil {
+			writeExemplarsError(w, http.StatusBadRequest, "bad_data", err)
+			return
+		}
+
+		end, err := parseTimeParam(r, "end", math.MaxInt64)
+		if err != nil {
+			writeExemplarsError(w, http.StatusBadRequest, "bad_data", err)
+			return
+		}
+
+		if end < start {
+			writeExemplarsError(w, http.StatusBadRequest, "bad_data", errEndBeforeStart)
+			return
+		}
+
+		expr, err := parser.ParseExpr(r.FormValue("query"))
+		if err != nil {
+			writeExemplarsError(w, http.StatusBadRequest, "bad_data", err)
+			return
+		}
+
+		resp, err := d.QueryExemplars(r.Context(), model.Time(start), model.Time(end), extractSelectors(expr)...)
+		if err != nil {
+			writeExemplarsQueryError(w, err)
+			return
+		}
+
+		data := make([]exemplarQueryResult, 0, len(resp.Timeseries))
+		for _, ts := range resp.Timeseries {
+			result := exemplarQueryResult{
+				SeriesLabels: cortexpb.FromLabelAdaptersToLabels(ts.Labels),
+				Exemplars:    make([]exemplar, 0, len(ts.Exemplars)),
+			}
+			for _, e := range ts.Exemplars {
+				result.Exemplars = append(result.Exemplars, exemplar{
+					Labels:    cortexpb.FromLabelAdaptersToLabels(e.Labels),
+					Value:     model.SampleValue(e.Value),
+					Timestamp: model.Time(e.TimestampMs),
+				})
+			}
+			data = append(data, result)
+		}
+
+		util.WriteJSONResponse(w, exemplarsResult{Status: statusSuccess, Data: data})
+	})
+}
+
+// writeExemplarsQueryError writes the error returned by the distributor, mapping it to the
+// same status code returned by the Prometheus API. Errors carrying an HTTP status code,
+// like the ones returned by the ingesters because of limits, keep their status code.
+func writeExemplarsQueryError(w http.ResponseWriter, err error) {
+	if resp, ok := httpgrpc.HTTPResponseFromError(err); ok {
+		errorType := "internal"
+		if resp.Code/100 == 4 {
+			errorType = "bad_data"
+		}
+		writeExemplarsError(w, int(resp.Code), errorType, errors.New(string(resp.Body)))
+		return
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled:
+		writeExemplarsError(w, http.StatusServiceUnavailable, "canceled", err)
+	case errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded:
+		writeExemplarsError(w, http.StatusServiceUnavailable, "timeout", err)
+	default:
+		writeExemplarsError(w, http.StatusInternalServerError, "internal", err)
+	}
+}
+
+func writeExemplarsError(w http.ResponseWriter, status int, errorType string, err error) {
+	w.WriteHeader(status)
+	util.WriteJSONResponse(w, exemplarsResult{Status: statusError, ErrorType: errorType, Error: err.Error()})
+}
+
+func parseTimeParam(r *http.Request, name string, defaultValue int64) (int64, error) {
+	value := r.FormValue(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	return util.ParseTime(value)
+}
+
+// extractSelectors returns the label matchers of all the vector selectors in the input expression.
+func extractSelectors(expr parser.Expr) [][]*labels.Matcher {
+	var selectors [][]*labels.Matcher
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if vs, ok := node.(*parser.VectorSelector); ok {
+			selectors = append(selectors, vs.LabelMatchers)
+		}
+		return nil
+	})
+	return selectors
+}
@@ -0,0 +1,78 @@
+package querier
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+)
+
+func TestExemplarsHandler_Errors(t *testing.T) {
+	tests := map[string]struct {
+		url            string
+		distributorErr error
+		expectedStatus int
+		expectedType   string
+	}{
+		"invalid query": {
+			url:            `/api/v1/query_exemplars?query={`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "bad_data",
+		},
+		"end before start": {
+			url:            `/api/v1/query_exemplars?query=test&start=10&end=5`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "bad_data",
+		},
+		"error with HTTP status code": {
+			url:            `/api/v1/query_exemplars?query=test`,
+			distributorErr: httpgrpc.Errorf(http.StatusTooManyRequests, "limit exceeded"),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedType:   "bad_data",
+		},
+		"canceled": {
+			url:            `/api/v1/query_exemplars?query=test`,
+			distributorErr: errors.Wrap(context.Canceled, "query ingesters"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedType:   "canceled",
+		},
+		"timeout": {
+			url:            `/api/v1/query_exemplars?query=test`,
+			distributorErr: status.Error(codes.DeadlineExceeded, "deadline exceeded"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedType:   "timeout",
+		},
+		"backend error": {
+			url:            `/api/v1/query_exemplars?query=test`,
+			distributorErr: status.Error(codes.Unavailable, "ingester unavailable"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   "internal",
+		},
+	}
+
+	for name, testData := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &mockDistributor{}
+			d.On("QueryExemplars", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&client.ExemplarQueryResponse{}, testData.distributorErr)
+
+			request, err := http.NewRequest("GET", testData.url, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			ExemplarsHandler(d).ServeHTTP(recorder, request)
+
+			assert.Equal(t, testData.expectedStatus, recorder.Result().StatusCode)
+			assert.Contains(t, recorder.Body.String(), `"errorType":"`+testData.expectedType+`"`)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package querier
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/thanos-io/thanos/pkg/strutil"
+
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+type labelNamesResult struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Warnings  []string    `json:"warnings,omitempty"`
+}
+
+// LabelNamesHandler returns the label names of the series matching the match[] selectors,
+// in the same format of the Prometheus /api/v1/labels endpoint. Differently than Prometheus,
+// the label names are looked up passing the selectors down to the ingesters, instead of
+// fetching all the matching series. Requests without selectors, or whose querier doesn't
+// support the lookup with matchers, are served by the next handler.
+func LabelNamesHandler(queryable storage.Queryable, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeLabelNamesError(w, http.StatusBadRequest, "bad_data", err)
+			return
+		}
+
+		if len(r.Form["match[]"]) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start, err := parseTimeParam(r, "start", math.MinInt64)
+		if err != nil {
+			writeLabelNamesError(w, http.StatusBadRequest, "bad_data", err)
+			return
+		}
+
+		end, err := parseTimeParam(r, "end", math.MaxInt64)
+		if err != nil {
+			writeLabelNamesError(w, http.StatusBadRequest, "bad_data", err)
+			return
+		}
+
+		if end < start {
+			writeLabelNamesError(w, http.StatusBadRequest, "bad_data", errEndBeforeStart)
+			return
+		}
+
+		matcherSets := make([][]*labels.Matcher, 0, len(r.Form["match[]"]))
+		for _, s := range r.Form["match[]"] {
+			matchers, err := parser.ParseMetricSelector(s)
+			if err != nil {
+				writeLabelNamesError(w, http.StatusBadRequest, "bad_data", err)
+				return
+			}
+			matcherSets = append(matcherSets, matchers)
+		}
+
+		q, err := queryable.Querier(r.Context(), start, end)
+		if err != nil {
+			writeLabelNamesQueryError(w, err)
+			return
+		}
+		defer q.Close()
+
+		lq, ok := q.(LabelNamesWithMatchersQuerier)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var (
+			sets     [][]string
+			warnings storage.Warnings
+		)
+
+		for _, matchers := range matcherSets {
+			names, ws, err := lq.LabelNamesWithMatchers(matchers...)
+			if err != nil {
+				writeLabelNamesQueryError(w, err)
+				return
+			}
+
+			sets = append(sets, names)
+			warnings = append(warnings, ws...)
+		}
+
+		names := strutil.MergeSlices(sets...)
+		if names == nil {
+			names = []string{}
+		}
+
+		result := labelNamesResult{Status: statusSuccess, Data: names}
+		for _, warning := range warnings {
+			result.Warnings = append(result.Warnings, warning.Error())
+		}
+
+		util.WriteJSONResponse(w, result)
+	})
+}
+
+// writeLabelNamesQueryError writes the error returned by the querier, mapping it to the
+// same status code returned by the Prometheus API.
+func writeLabelNamesQueryError(w http.ResponseWriter, err error) {
+	switch errors.Cause(err).(type) {
+	case promql.ErrQueryCanceled:
+		writeLabelNamesError(w, http.StatusServiceUnavailable, "canceled", err)
+	case promql.ErrQueryTimeout:
+		writeLabelNamesError(w, http.StatusServiceUnavailable, "timeout", err)
+	case promql.ErrStorage:
+		writeLabelNamesError(w, http.StatusInternalServerError, "internal", err)
+	default:
+		writeLabelNamesError(w, http.StatusUnprocessableEntity, "execution", err)
+	}
+}
+
+func writeLabelNamesError(w http.ResponseWriter, status int, errorType string, err error) {
+	w.WriteHeader(status)
+	util.WriteJSONResponse(w, labelNamesResult{Status: statusError, ErrorType: errorType, Error: err.Error()})
+}
@@ -0,0 +1,115 @@
+package querier
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelNamesHandler(t *testing.T) {
+	names := map[string][]string{
+		`{__name__="test_1"}`: {labels.MetricName, "status"},
+		`{__name__="test_2"}`: {labels.MetricName, "job"},
+	}
+
+	tests := map[string]struct {
+		queryable          storage.Queryable
+		url                string
+		expectedStatusCode int
+		expectedJSON       string
+		expectedNext       bool
+	}{
+		"should forward the request to the next handler if no selector is given": {
+			queryable:    &labelNamesQueryableMock{names: names},
+			url:          "/api/v1/labels",
+			expectedNext: true,
+		},
+		"should forward the request to the next handler if the querier doesn't support matchers": {
+			queryable:    storage.QueryableFunc(func(context.Context, int64, int64) (storage.Querier, error) { return storage.NoopQuerier(), nil }),
+			url:          `/api/v1/labels?match[]={__name__="test_1"}`,
+			expectedNext: true,
+		},
+		"should return the merged label names of the series matching the selectors": {
+			queryable:          &labelNamesQueryableMock{names: names},
+			url:                `/api/v1/labels?match[]={__name__="test_1"}&match[]={__name__="test_2"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedJSON:       `{"status": "success", "data": ["__name__", "job", "status"]}`,
+		},
+		"should return an empty list if no series match": {
+			queryable:          &labelNamesQueryableMock{names: names},
+			url:                `/api/v1/labels?match[]={__name__="unknown"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedJSON:       `{"status": "success", "data": []}`,
+		},
+		"should return error on invalid selector": {
+			queryable:          &labelNamesQueryableMock{names: names},
+			url:                `/api/v1/labels?match[]={`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"should return error on storage error": {
+			queryable:          &labelNamesQueryableMock{err: promql.ErrStorage{Err: errors.New("failed")}},
+			url:                `/api/v1/labels?match[]={__name__="test_1"}`,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedJSON:       `{"status": "error", "errorType": "internal", "error": "failed"}`,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+
+			request, err := http.NewRequest("GET", testData.url, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			LabelNamesHandler(testData.queryable, next).ServeHTTP(recorder, request)
+
+			assert.Equal(t, testData.expectedNext, nextCalled)
+			if testData.expectedNext {
+				return
+			}
+
+			require.Equal(t, testData.expectedStatusCode, recorder.Result().StatusCode)
+			if testData.expectedJSON != "" {
+				responseBody, err := ioutil.ReadAll(recorder.Result().Body)
+				require.NoError(t, err)
+				require.JSONEq(t, testData.expectedJSON, string(responseBody))
+			}
+		})
+	}
+}
+
+// labelNamesQueryableMock returns queriers looking up the label names by the string representation of the matchers.
+type labelNamesQueryableMock struct {
+	names map[string][]string
+	err   error
+}
+
+func (m *labelNamesQueryableMock) Querier(context.Context, int64, int64) (storage.Querier, error) {
+	return &labelNamesQuerierMock{Querier: storage.NoopQuerier(), mock: m}, nil
+}
+
+type labelNamesQuerierMock struct {
+	storage.Querier
+	mock *labelNamesQueryableMock
+}
+
+func (q *labelNamesQuerierMock) LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	if q.mock.err != nil {
+		return nil, nil, q.mock.err
+	}
+
+	return q.mock.names[convertMatchersToString(matchers)], nil, nil
+}
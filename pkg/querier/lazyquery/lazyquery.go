@@ -67,6 +67,18 @@ func (l LazyQuerier) LabelNames() ([]string, storage.Warnings, error) {
 	return l.next.LabelNames()
 }
 
+// LabelNamesWithMatchers forwards the label names lookup to the wrapped querier, if supported.
+func (l LazyQuerier) LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	lq, ok := l.next.(interface {
+		LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error)
+	})
+	if !ok {
+		return nil, nil, fmt.Errorf("not supported")
+	}
+
+	return lq.LabelNamesWithMatchers(matchers...)
+}
+
 // Close implements Storage.Querier
 func (l LazyQuerier) Close() error {
 	return l.next.Close()
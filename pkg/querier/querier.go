@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -25,10 +26,13 @@ import (
 	"github.com/cortexproject/cortex/pkg/querier/iterators"
 	"github.com/cortexproject/cortex/pkg/querier/lazyquery"
 	"github.com/cortexproject/cortex/pkg/querier/series"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 	"github.com/cortexproject/cortex/pkg/util/spanlogger"
 	"github.com/cortexproject/cortex/pkg/util/validation"
 )
@@ -231,6 +235,15 @@ func NewQueryable(distributor QueryableWithFilter, stores []QueryableWithFilter,
 			return nil, err
 		}
 
+		// The query limiter is shared by all the ingesters and store-gateways queried by this querier.
+		// When the query-frontend splits or shards a query, each sub-query can fetch up to the chunk
+		// bytes left to the whole query.
+		maxChunkBytes := limits.MaxFetchedChunkBytesPerQuery(userID)
+		if budget, ok := limiter.ChunkBytesBudgetFromContext(ctx); ok && (maxChunkBytes == 0 || budget < maxChunkBytes) {
+			maxChunkBytes = budget
+		}
+		ctx = limiter.AddQueryLimiterToContext(ctx, limiter.NewQueryLimiter(limits.MaxFetchedSeriesPerQuery(userID), maxChunkBytes))
+
 		q := querier{
 			ctx:                 ctx,
 			mint:                mint,
@@ -345,12 +358,24 @@ func (q querier) Select(_ bool, sp *storage.SelectHints, matchers ...*labels.Mat
 		return storage.ErrSeriesSet(err)
 	}
 
+	// Queries sharded by the query-frontend select a single shard of the series.
+	shard, _, err := sharding.ShardFromMatchers(matchers)
+	if err != nil {
+		return storage.ErrSeriesSet(err)
+	}
+
 	if len(q.queriers) == 1 {
 		seriesSet := q.queriers[0].Select(true, sp, matchers...)
 
 		if tombstones.Len() != 0 {
 			seriesSet = series.NewDeletedSeriesSet(seriesSet, tombstones, model.Interval{Start: startTime, End: endTime})
 		}
+		if shard != nil {
+			seriesSet = series.NewShardedSeriesSet(seriesSet, *shard)
+		}
+		if reqStats := stats.FromContext(ctx); reqStats != nil {
+			seriesSet = series.NewStatsSeriesSet(seriesSet, reqStats)
+		}
 
 		return seriesSet
 	}
@@ -380,6 +405,12 @@ func (q querier) Select(_ bool, sp *storage.SelectHints, matchers ...*labels.Mat
 	if tombstones.Len() != 0 {
 		seriesSet = series.NewDeletedSeriesSet(seriesSet, tombstones, model.Interval{Start: startTime, End: endTime})
 	}
+	if shard != nil {
+		seriesSet = series.NewShardedSeriesSet(seriesSet, *shard)
+	}
+	if reqStats := stats.FromContext(ctx); reqStats != nil {
+		seriesSet = series.NewStatsSeriesSet(seriesSet, reqStats)
+	}
 	return seriesSet
 }
 
@@ -429,12 +460,17 @@ func (q querier) LabelValues(name string, matchers ...*labels.Matcher) ([]string
 }
 
 func (q querier) LabelNames() ([]string, storage.Warnings, error) {
+	return q.LabelNamesWithMatchers()
+}
+
+// LabelNamesWithMatchers implements LabelNamesWithMatchersQuerier.
+func (q querier) LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
 	if !q.queryStoreForLabels {
-		return q.metadataQuerier.LabelNames()
+		return labelNamesWithMatchers(q.metadataQuerier, q.mint, q.maxt, matchers...)
 	}
 
 	if len(q.queriers) == 1 {
-		return q.queriers[0].LabelNames()
+		return labelNamesWithMatchers(q.queriers[0], q.mint, q.maxt, matchers...)
 	}
 
 	var (
@@ -450,7 +486,7 @@ func (q querier) LabelNames() ([]string, storage.Warnings, error) {
 		querier := querier
 		g.Go(func() error {
 			// NB: Names are sorted in Cortex already.
-			myNames, myWarnings, err := querier.LabelNames()
+			myNames, myWarnings, err := labelNamesWithMatchers(querier, q.mint, q.maxt, matchers...)
 			if err != nil {
 				return err
 			}
@@ -476,6 +512,51 @@ func (querier) Close() error {
 	return nil
 }
 
+// LabelNamesWithMatchersQuerier is implemented by the queriers which can look up
+// the label names of the series matching the input matchers.
+type LabelNamesWithMatchersQuerier interface {
+	LabelNamesWithMatchers(matchers ...*labels.Matcher) ([]string, storage.Warnings, error)
+}
+
+// labelNamesWithMatchers returns the sorted label names of the series matching the input
+// matchers. If the querier doesn't implement LabelNamesWithMatchersQuerier, label names
+// are looked up from the matching series without fetching samples (eg. the store-gateways
+// only look up the series in the blocks index).
+func labelNamesWithMatchers(q storage.Querier, mint, maxt int64, matchers ...*labels.Matcher) ([]string, storage.Warnings, error) {
+	if lq, ok := q.(LabelNamesWithMatchersQuerier); ok {
+		return lq.LabelNamesWithMatchers(matchers...)
+	}
+
+	if len(matchers) == 0 {
+		return q.LabelNames()
+	}
+
+	hints := &storage.SelectHints{
+		Start: mint,
+		End:   maxt,
+		Func:  "series", // There is no series function, this token is used for lookups that don't need samples.
+	}
+
+	names := map[string]struct{}{}
+	set := q.Select(false, hints, matchers...)
+	for set.Next() {
+		for _, l := range set.At().Labels() {
+			names[l.Name] = struct{}{}
+		}
+	}
+	if err := set.Err(); err != nil {
+		return nil, set.Warnings(), err
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result, set.Warnings(), nil
+}
+
 func (q querier) mergeSeriesSets(sets []storage.SeriesSet) storage.SeriesSet {
 	// Here we deal with sets that are based on chunks and build single set from them.
 	// Remaining sets are merged with chunks-based one using storage.NewMergeSeriesSet
@@ -138,6 +138,7 @@ var (
 func TestQuerier(t *testing.T) {
 	var cfg Config
 	flagext.DefaultValues(&cfg)
+	cfg.ActiveQueryTrackerDir = t.TempDir()
 
 	const chunks = 24
 
@@ -169,14 +170,8 @@ func TestQuerier(t *testing.T) {
 }
 
 func mockTSDB(t *testing.T, mint model.Time, samples int, step, chunkOffset time.Duration, samplesPerChunk int) storage.Queryable {
-	dir, err := ioutil.TempDir("", "tsdb")
-	require.NoError(t, err)
-
-	t.Cleanup(func() {
-		_ = os.RemoveAll(dir)
-	})
-
 	opts := tsdb.DefaultHeadOptions()
+	opts.ChunkDirRoot = t.TempDir()
 	// We use TSDB head only. By using full TSDB DB, and appending samples to it, closing it would cause unnecessary HEAD compaction, which slows down the test.
 	head, err := tsdb.NewHead(nil, nil, nil, opts)
 	require.NoError(t, err)
@@ -353,6 +348,7 @@ func TestQuerier_ValidateQueryTimeRange_MaxQueryIntoFuture(t *testing.T) {
 
 	cfg := Config{}
 	flagext.DefaultValues(&cfg)
+	cfg.ActiveQueryTrackerDir = t.TempDir()
 
 	for _, ingesterStreaming := range []bool{true, false} {
 		cfg.IngesterStreaming = ingesterStreaming
@@ -434,6 +430,7 @@ func TestQuerier_ValidateQueryTimeRange_MaxQueryLength(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			var cfg Config
 			flagext.DefaultValues(&cfg)
+			cfg.ActiveQueryTrackerDir = t.TempDir()
 
 			limits := defaultLimitsConfig()
 			limits.MaxQueryLength = maxQueryLength
@@ -555,6 +552,7 @@ func TestQuerier_ValidateQueryTimeRange_MaxQueryLookback(t *testing.T) {
 
 				var cfg Config
 				flagext.DefaultValues(&cfg)
+				cfg.ActiveQueryTrackerDir = t.TempDir()
 				cfg.IngesterStreaming = ingesterStreaming
 
 				limits := defaultLimitsConfig()
@@ -629,7 +627,7 @@ func TestQuerier_ValidateQueryTimeRange_MaxQueryLookback(t *testing.T) {
 
 				t.Run("label names", func(t *testing.T) {
 					distributor := &mockDistributor{}
-					distributor.On("LabelNames", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
+					distributor.On("LabelNames", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]string{}, nil)
 
 					queryable, _ := New(cfg, overrides, distributor, queryables, purger.NewTombstonesLoader(nil, nil), nil)
 					q, err := queryable.Querier(ctx, util.TimeToMillis(testData.queryStartTime), util.TimeToMillis(testData.queryEndTime))
@@ -743,10 +741,16 @@ func (m *errDistributor) Query(ctx context.Context, from, to model.Time, matcher
 func (m *errDistributor) QueryStream(ctx context.Context, from, to model.Time, matchers ...*labels.Matcher) (*client.QueryStreamResponse, error) {
 	return nil, errDistributorError
 }
+func (m *errDistributor) QueryExemplars(ctx context.Context, from, to model.Time, matchers ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error) {
+	return nil, errDistributorError
+}
 func (m *errDistributor) LabelValuesForLabelName(context.Context, model.Time, model.Time, model.LabelName, ...*labels.Matcher) ([]string, error) {
 	return nil, errDistributorError
 }
-func (m *errDistributor) LabelNames(context.Context, model.Time, model.Time) ([]string, error) {
+func (m *errDistributor) LabelNames(context.Context, model.Time, model.Time, ...*labels.Matcher) ([]string, error) {
+	return nil, errDistributorError
+}
+func (m *errDistributor) LabelCardinality(context.Context, []string, int, bool, ...*labels.Matcher) ([]*client.LabelCardinality, error) {
 	return nil, errDistributorError
 }
 func (m *errDistributor) MetricsForLabelMatchers(ctx context.Context, from, through model.Time, matchers ...*labels.Matcher) ([]metric.Metric, error) {
@@ -785,11 +789,19 @@ func (d *emptyDistributor) QueryStream(ctx context.Context, from, to model.Time,
 	return &client.QueryStreamResponse{}, nil
 }
 
+func (d *emptyDistributor) QueryExemplars(ctx context.Context, from, to model.Time, matchers ...[]*labels.Matcher) (*client.ExemplarQueryResponse, error) {
+	return &client.ExemplarQueryResponse{}, nil
+}
+
 func (d *emptyDistributor) LabelValuesForLabelName(context.Context, model.Time, model.Time, model.LabelName, ...*labels.Matcher) ([]string, error) {
 	return nil, nil
 }
 
-func (d *emptyDistributor) LabelNames(context.Context, model.Time, model.Time) ([]string, error) {
+func (d *emptyDistributor) LabelNames(context.Context, model.Time, model.Time, ...*labels.Matcher) ([]string, error) {
+	return nil, nil
+}
+
+func (d *emptyDistributor) LabelCardinality(context.Context, []string, int, bool, ...*labels.Matcher) ([]*client.LabelCardinality, error) {
 	return nil, nil
 }
 
@@ -853,6 +865,7 @@ func TestShortTermQueryToLTS(t *testing.T) {
 
 	cfg := Config{}
 	flagext.DefaultValues(&cfg)
+	cfg.ActiveQueryTrackerDir = t.TempDir()
 
 	for _, ingesterStreaming := range []bool{true, false} {
 		cfg.IngesterStreaming = ingesterStreaming
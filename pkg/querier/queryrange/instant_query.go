@@ -0,0 +1,403 @@
+package queryrange
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/timestamp"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/spanlogger"
+)
+
+var (
+	// InstantQueryCodec is a codec to encode and decode Prometheus instant query requests and responses.
+	InstantQueryCodec Codec = &instantQueryCodec{}
+)
+
+// GetStart returns the evaluation time of the instant query, since it's evaluated at a single time.
+func (q *PrometheusInstantQueryRequest) GetStart() int64 {
+	return q.GetTime()
+}
+
+// GetEnd returns the evaluation time of the instant query, since it's evaluated at a single time.
+func (q *PrometheusInstantQueryRequest) GetEnd() int64 {
+	return q.GetTime()
+}
+
+// GetStep returns 0, since instant queries have no step.
+func (q *PrometheusInstantQueryRequest) GetStep() int64 {
+	return 0
+}
+
+// WithStartEnd clones the current `PrometheusInstantQueryRequest` with the evaluation time set to `end`.
+func (q *PrometheusInstantQueryRequest) WithStartEnd(_ int64, end int64) Request {
+	new := *q
+	new.Time = end
+	return &new
+}
+
+// WithQuery clones the current `PrometheusInstantQueryRequest` with a new query.
+func (q *PrometheusInstantQueryRequest) WithQuery(query string) Request {
+	new := *q
+	new.Query = query
+	return &new
+}
+
+// LogToSpan logs the current `PrometheusInstantQueryRequest` parameters to the specified span.
+func (q *PrometheusInstantQueryRequest) LogToSpan(sp opentracing.Span) {
+	sp.LogFields(
+		otlog.String("query", q.GetQuery()),
+		otlog.String("time", timestamp.Time(q.GetTime()).String()),
+	)
+}
+
+// NewEmptyPrometheusInstantQueryResponse returns an empty successful Prometheus instant query response.
+func NewEmptyPrometheusInstantQueryResponse() *PrometheusInstantQueryResponse {
+	return &PrometheusInstantQueryResponse{
+		Status: StatusSuccess,
+		Data: PrometheusInstantQueryData{
+			ResultType: model.ValVector.String(),
+			Result:     &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: []Sample{}}},
+		},
+	}
+}
+
+type instantQueryCodec struct{}
+
+// MergeResponse merges the responses of instant queries evaluated at the same time. Only
+// vector responses can be merged, since other types have a single result each.
+func (instantQueryCodec) MergeResponse(responses ...Response) (Response, error) {
+	if len(responses) == 0 {
+		return NewEmptyPrometheusInstantQueryResponse(), nil
+	}
+	if len(responses) == 1 {
+		return responses[0], nil
+	}
+
+	var (
+		samples []Sample
+		// We need to pass on all the headers for results cache gen numbers.
+		resultsCacheGenNumberHeaderValues []string
+	)
+
+	for _, res := range responses {
+		promRes, ok := res.(*PrometheusInstantQueryResponse)
+		if !ok {
+			return nil, errors.Errorf("unexpected response type %T", res)
+		}
+
+		vector := promRes.Data.GetVector()
+		if vector == nil {
+			return nil, errors.Errorf("unable to merge instant query responses of type %q", promRes.Data.ResultType)
+		}
+		samples = append(samples, vector.Samples...)
+		resultsCacheGenNumberHeaderValues = append(resultsCacheGenNumberHeaderValues, getHeaderValuesWithName(res, ResultsCacheGenNumberHeaderName)...)
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return labels.Compare(cortexpb.FromLabelAdaptersToLabels(samples[i].Labels), cortexpb.FromLabelAdaptersToLabels(samples[j].Labels)) < 0
+	})
+
+	response := NewEmptyPrometheusInstantQueryResponse()
+	response.Data.Result = &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: samples}}
+
+	if len(resultsCacheGenNumberHeaderValues) != 0 {
+		response.Headers = []*PrometheusResponseHeader{{
+			Name:   ResultsCacheGenNumberHeaderName,
+			Values: resultsCacheGenNumberHeaderValues,
+		}}
+	}
+
+	return response, nil
+}
+
+func (instantQueryCodec) DecodeRequest(_ context.Context, r *http.Request) (Request, error) {
+	var result PrometheusInstantQueryRequest
+
+	// Like Prometheus, instant queries are evaluated at the current time if not specified.
+	if t := r.FormValue("time"); t != "" {
+		var err error
+		result.Time, err = util.ParseTime(t)
+		if err != nil {
+			return nil, decorateWithParamName(err, "time")
+		}
+	} else {
+		result.Time = util.TimeToMillis(time.Now())
+	}
+
+	result.Query = r.FormValue("query")
+	result.Path = r.URL.Path
+
+	for _, value := range r.Header.Values(cacheControlHeader) {
+		if strings.Contains(value, noStoreValue) {
+			result.CachingOptions.Disabled = true
+			break
+		}
+	}
+
+	return &result, nil
+}
+
+func (instantQueryCodec) EncodeRequest(ctx context.Context, r Request) (*http.Request, error) {
+	promReq, ok := r.(*PrometheusInstantQueryRequest)
+	if !ok {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, "invalid request format")
+	}
+	params := url.Values{
+		"time":  []string{encodeTime(promReq.Time)},
+		"query": []string{promReq.Query},
+	}
+	u := &url.URL{
+		Path:     promReq.Path,
+		RawQuery: params.Encode(),
+	}
+	req := &http.Request{
+		Method:     "GET",
+		RequestURI: u.String(), // This is what the httpgrpc code looks at.
+		URL:        u,
+		Body:       http.NoBody,
+		Header:     http.Header{},
+	}
+
+	return req.WithContext(ctx), nil
+}
+
+func (instantQueryCodec) DecodeResponse(ctx context.Context, r *http.Response, _ Request) (Response, error) {
+	if r.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(r.Body)
+		return nil, httpgrpc.Errorf(r.StatusCode, string(body))
+	}
+	log, ctx := spanlogger.New(ctx, "ParseInstantQueryResponse") //nolint:ineffassign,staticcheck
+	defer log.Finish()
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error(err)
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+	}
+
+	log.LogFields(otlog.Int("bytes", len(buf)))
+
+	var resp PrometheusInstantQueryResponse
+	if err := json.Unmarshal(buf, &resp); err != nil {
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+	}
+
+	for h, hv := range r.Header {
+		resp.Headers = append(resp.Headers, &PrometheusResponseHeader{Name: h, Values: hv})
+	}
+	return &resp, nil
+}
+
+func (instantQueryCodec) EncodeResponse(ctx context.Context, res Response) (*http.Response, error) {
+	sp, _ := opentracing.StartSpanFromContext(ctx, "APIResponse.ToHTTPResponse")
+	defer sp.Finish()
+
+	a, ok := res.(*PrometheusInstantQueryResponse)
+	if !ok {
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid response format")
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error encoding response: %v", err)
+	}
+
+	sp.LogFields(otlog.Int("bytes", len(b)))
+
+	resp := http.Response{
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body:       ioutil.NopCloser(bytes.NewBuffer(b)),
+		StatusCode: http.StatusOK,
+	}
+	return &resp, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *PrometheusInstantQueryData) MarshalJSON() ([]byte, error) {
+	var result interface{}
+
+	switch r := d.Result.(type) {
+	case *PrometheusInstantQueryData_Vector:
+		result = r.Vector.Samples
+		if r.Vector.Samples == nil {
+			result = []Sample{}
+		}
+	case *PrometheusInstantQueryData_Scalar:
+		result = r.Scalar
+	case *PrometheusInstantQueryData_String_:
+		result = model.String{Value: r.String_.Value, Timestamp: model.Time(r.String_.TimestampMs)}
+	case *PrometheusInstantQueryData_Matrix:
+		result = r.Matrix.SampleStreams
+		if r.Matrix.SampleStreams == nil {
+			result = []SampleStream{}
+		}
+	default:
+		return nil, errors.Errorf("unexpected result type %q", d.ResultType)
+	}
+
+	return json.Marshal(struct {
+		ResultType string      `json:"resultType"`
+		Result     interface{} `json:"result"`
+	}{
+		ResultType: d.ResultType,
+		Result:     result,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *PrometheusInstantQueryData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ResultType string              `json:"resultType"`
+		Result     jsoniter.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d.ResultType = raw.ResultType
+
+	switch raw.ResultType {
+	case model.ValVector.String():
+		var samples []Sample
+		if err := json.Unmarshal(raw.Result, &samples); err != nil {
+			return err
+		}
+		d.Result = &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: samples}}
+	case model.ValScalar.String():
+		var sample cortexpb.Sample
+		if err := json.Unmarshal(raw.Result, &sample); err != nil {
+			return err
+		}
+		d.Result = &PrometheusInstantQueryData_Scalar{Scalar: &sample}
+	case model.ValString.String():
+		var str model.String
+		if err := json.Unmarshal(raw.Result, &str); err != nil {
+			return err
+		}
+		d.Result = &PrometheusInstantQueryData_String_{String_: &StringSample{Value: str.Value, TimestampMs: int64(str.Timestamp)}}
+	case model.ValMatrix.String():
+		var streams []SampleStream
+		if err := json.Unmarshal(raw.Result, &streams); err != nil {
+			return err
+		}
+		d.Result = &PrometheusInstantQueryData_Matrix{Matrix: &Matrix{SampleStreams: streams}}
+	default:
+		return errors.Errorf("unexpected result type %q", raw.ResultType)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Sample) UnmarshalJSON(data []byte) error {
+	var sample struct {
+		Metric model.Metric    `json:"metric"`
+		Value  cortexpb.Sample `json:"value"`
+	}
+	if err := json.Unmarshal(data, &sample); err != nil {
+		return err
+	}
+	s.Labels = cortexpb.FromMetricsToLabelAdapters(sample.Metric)
+	s.Sample = sample.Value
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *Sample) MarshalJSON() ([]byte, error) {
+	sample := struct {
+		Metric model.Metric    `json:"metric"`
+		Value  cortexpb.Sample `json:"value"`
+	}{
+		Metric: cortexpb.FromLabelAdaptersToMetric(s.Labels),
+		Value:  s.Sample,
+	}
+	return json.Marshal(sample)
+}
+
+// instantQueryDataFromResult transforms a PromQL instant query result into the response data.
+func instantQueryDataFromResult(res *promql.Result) (PrometheusInstantQueryData, error) {
+	if res.Err != nil {
+		// The error could be wrapped by the PromQL engine. We get the error's cause in order to
+		// correctly parse the error in parent callers (eg. gRPC response status code extraction).
+		return PrometheusInstantQueryData{}, errors.Cause(res.Err)
+	}
+
+	data := PrometheusInstantQueryData{ResultType: string(res.Value.Type())}
+
+	switch v := res.Value.(type) {
+	case promql.Scalar:
+		data.Result = &PrometheusInstantQueryData_Scalar{Scalar: &cortexpb.Sample{TimestampMs: v.T, Value: v.V}}
+	case promql.String:
+		data.Result = &PrometheusInstantQueryData_String_{String_: &StringSample{Value: v.V, TimestampMs: v.T}}
+	case promql.Vector:
+		samples := make([]Sample, 0, len(v))
+		for _, sample := range v {
+			samples = append(samples, Sample{
+				Labels: mapLabels(sample.Metric),
+				Sample: cortexpb.Sample{TimestampMs: sample.T, Value: sample.V},
+			})
+		}
+		data.Result = &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: samples}}
+	case promql.Matrix:
+		streams := make([]SampleStream, 0, len(v))
+		for _, series := range v {
+			streams = append(streams, SampleStream{
+				Labels:  mapLabels(series.Metric),
+				Samples: mapPoints(series.Points...),
+			})
+		}
+		data.Result = &PrometheusInstantQueryData_Matrix{Matrix: &Matrix{SampleStreams: streams}}
+	default:
+		return PrometheusInstantQueryData{}, errors.Errorf("unexpected value type: [%s]", res.Value.Type())
+	}
+
+	return data, nil
+}
+
+// InstantQueryResponseExtractor is the Extractor used to cache instant query responses.
+type InstantQueryResponseExtractor struct{}
+
+// Extract returns the response as is, since an instant query response is always cached
+// for the same evaluation time of the request.
+func (InstantQueryResponseExtractor) Extract(_, _ int64, from Response) Response {
+	return from
+}
+
+// ResponseWithoutHeaders is useful in caching data without headers since
+// we anyways do not need headers for sending back the response so this saves some space by reducing size of the objects.
+func (InstantQueryResponseExtractor) ResponseWithoutHeaders(resp Response) Response {
+	promRes := resp.(*PrometheusInstantQueryResponse)
+	return &PrometheusInstantQueryResponse{
+		Status: StatusSuccess,
+		Data:   promRes.Data,
+	}
+}
+
+// instantQueryCacheSplitter generates the cache keys of instant queries, which are
+// cached for each evaluation time.
+type instantQueryCacheSplitter struct{}
+
+// GenerateCacheKey implements CacheSplitter.
+func (instantQueryCacheSplitter) GenerateCacheKey(userID string, r Request) string {
+	return fmt.Sprintf("instant:%s:%s:%d", userID, r.GetQuery(), r.GetStart())
+}
@@ -0,0 +1,195 @@
+package queryrange
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+)
+
+func TestInstantQueryRequest(t *testing.T) {
+	for i, tc := range []struct {
+		url         string
+		expected    Request
+		expectedErr error
+	}{
+		{
+			url: "/api/v1/query?query=sum_over_time%28foo%5B1h%5D%29&time=1536673680",
+			expected: &PrometheusInstantQueryRequest{
+				Path:  "/api/v1/query",
+				Query: "sum_over_time(foo[1h])",
+				Time:  1536673680 * 1e3,
+			},
+		},
+		{
+			url:         "/api/v1/query?query=foo&time=bar",
+			expectedErr: httpgrpc.Errorf(http.StatusBadRequest, "invalid parameter \"time\"; cannot parse \"bar\" to a valid timestamp"),
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			r, err := http.NewRequest("GET", tc.url, nil)
+			require.NoError(t, err)
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			r = r.WithContext(ctx)
+
+			req, err := InstantQueryCodec.DecodeRequest(ctx, r)
+			if err != nil {
+				require.EqualValues(t, tc.expectedErr, err)
+				return
+			}
+			require.EqualValues(t, tc.expected, req)
+
+			rdash, err := InstantQueryCodec.EncodeRequest(context.Background(), req)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.url, rdash.RequestURI)
+		})
+	}
+}
+
+func TestInstantQueryResponse(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		body     string
+		expected PrometheusInstantQueryData
+	}{
+		{
+			name: "vector",
+			body: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"foo","bar":"baz"},"value":[1536673680,"137"]}]}}`,
+			expected: PrometheusInstantQueryData{
+				ResultType: "vector",
+				Result: &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: []Sample{{
+					Labels: []cortexpb.LabelAdapter{{Name: "__name__", Value: "foo"}, {Name: "bar", Value: "baz"}},
+					Sample: cortexpb.Sample{TimestampMs: 1536673680000, Value: 137},
+				}}}},
+			},
+		},
+		{
+			name: "empty vector",
+			body: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			expected: PrometheusInstantQueryData{
+				ResultType: "vector",
+				Result:     &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: []Sample{}}},
+			},
+		},
+		{
+			name: "scalar",
+			body: `{"status":"success","data":{"resultType":"scalar","result":[1536673680,"1"]}}`,
+			expected: PrometheusInstantQueryData{
+				ResultType: "scalar",
+				Result:     &PrometheusInstantQueryData_Scalar{Scalar: &cortexpb.Sample{TimestampMs: 1536673680000, Value: 1}},
+			},
+		},
+		{
+			name: "string",
+			body: `{"status":"success","data":{"resultType":"string","result":[1536673680,"foo"]}}`,
+			expected: PrometheusInstantQueryData{
+				ResultType: "string",
+				Result:     &PrometheusInstantQueryData_String_{String_: &StringSample{Value: "foo", TimestampMs: 1536673680000}},
+			},
+		},
+		{
+			name: "matrix",
+			body: `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"__name__":"foo"},"values":[[1536673680,"137"],[1536673780,"137"]]}]}}`,
+			expected: PrometheusInstantQueryData{
+				ResultType: "matrix",
+				Result: &PrometheusInstantQueryData_Matrix{Matrix: &Matrix{SampleStreams: []SampleStream{{
+					Labels:  []cortexpb.LabelAdapter{{Name: "__name__", Value: "foo"}},
+					Samples: []cortexpb.Sample{{TimestampMs: 1536673680000, Value: 137}, {TimestampMs: 1536673780000, Value: 137}},
+				}}}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(tc.body))),
+			}
+			resp, err := InstantQueryCodec.DecodeResponse(context.Background(), response, nil)
+			require.NoError(t, err)
+			assert.Equal(t, &PrometheusInstantQueryResponse{
+				Status:  StatusSuccess,
+				Data:    tc.expected,
+				Headers: []*PrometheusResponseHeader{{Name: "Content-Type", Values: []string{"application/json"}}},
+			}, resp)
+
+			encoded, err := InstantQueryCodec.EncodeResponse(context.Background(), resp)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(encoded.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestMergeInstantQueryResponses(t *testing.T) {
+	sample := func(name string, value float64) Sample {
+		return Sample{
+			Labels: []cortexpb.LabelAdapter{{Name: "__name__", Value: name}},
+			Sample: cortexpb.Sample{TimestampMs: 1000, Value: value},
+		}
+	}
+	vector := func(samples ...Sample) *PrometheusInstantQueryResponse {
+		return &PrometheusInstantQueryResponse{
+			Status: StatusSuccess,
+			Data: PrometheusInstantQueryData{
+				ResultType: "vector",
+				Result:     &PrometheusInstantQueryData_Vector{Vector: &Vector{Samples: samples}},
+			},
+		}
+	}
+	scalar := &PrometheusInstantQueryResponse{
+		Status: StatusSuccess,
+		Data: PrometheusInstantQueryData{
+			ResultType: "scalar",
+			Result:     &PrometheusInstantQueryData_Scalar{Scalar: &cortexpb.Sample{TimestampMs: 1000, Value: 1}},
+		},
+	}
+
+	for i, tc := range []struct {
+		input       []Response
+		expected    Response
+		expectedErr bool
+	}{
+		// No responses shouldn't panic and return an empty vector.
+		{
+			input:    []Response{},
+			expected: NewEmptyPrometheusInstantQueryResponse(),
+		},
+		// A single response is returned as is, whatever its type.
+		{
+			input:    []Response{scalar},
+			expected: scalar,
+		},
+		// Vectors are merged and sorted by labels.
+		{
+			input:    []Response{vector(sample("c", 3), sample("a", 1)), vector(sample("b", 2))},
+			expected: vector(sample("a", 1), sample("b", 2), sample("c", 3)),
+		},
+		// Other types can't be merged.
+		{
+			input:       []Response{scalar, scalar},
+			expectedErr: true,
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			output, err := InstantQueryCodec.MergeResponse(tc.input...)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, output)
+		})
+	}
+}
@@ -7,10 +7,13 @@ import (
 
 	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/prometheus/pkg/timestamp"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/weaveworks/common/httpgrpc"
 
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/limiter"
 	"github.com/cortexproject/cortex/pkg/util/spanlogger"
 	"github.com/cortexproject/cortex/pkg/util/validation"
 )
@@ -31,6 +34,54 @@ type Limits interface {
 	// MaxCacheFreshness returns the period after which results are cacheable,
 	// to prevent caching of very recent results.
 	MaxCacheFreshness(string) time.Duration
+
+	// MaxFetchedChunkBytesPerQuery returns the maximum size, in bytes, of the chunks
+	// a query can fetch, shared by all its sub-queries.
+	MaxFetchedChunkBytesPerQuery(string) int
+}
+
+// ChunkBytesBudgetHeaderName is the name of the request header used to pass each sub-query the
+// chunk bytes left to the query it belongs to.
+const ChunkBytesBudgetHeaderName = "X-Cortex-Chunk-Bytes-Budget"
+
+type chunkBytesBudgetCtxKey struct{}
+
+// chunkBytesBudget is the chunk bytes limit of a query received by the query-frontend, shared by
+// all its sub-queries. The chunk bytes fetched by each sub-query are tracked in the query stats
+// returned by the queriers, so the budget is charged once a sub-query completes.
+type chunkBytesBudget struct {
+	limit int
+	stats *stats.Stats
+}
+
+// withChunkBytesBudget returns a context with the chunk bytes budget of the query, enabling the
+// query stats if they're not already enabled.
+func withChunkBytesBudget(ctx context.Context, tenantIDs []string, l Limits) context.Context {
+	limit := validation.SmallestPositiveIntPerTenant(tenantIDs, l.MaxFetchedChunkBytesPerQuery)
+	if limit <= 0 {
+		return ctx
+	}
+
+	reqStats := stats.FromContext(ctx)
+	if reqStats == nil {
+		reqStats, ctx = stats.ContextWithEmptyStats(ctx)
+	}
+
+	return context.WithValue(ctx, chunkBytesBudgetCtxKey{}, &chunkBytesBudget{limit: limit, stats: reqStats})
+}
+
+func chunkBytesBudgetFromContext(ctx context.Context) *chunkBytesBudget {
+	budget, _ := ctx.Value(chunkBytesBudgetCtxKey{}).(*chunkBytesBudget)
+	return budget
+}
+
+// remaining returns the chunk bytes left to the query, which is negative if the limit has been exceeded.
+func (b *chunkBytesBudget) remaining() int {
+	return b.limit - int(b.stats.LoadFetchedChunkBytes())
+}
+
+func (b *chunkBytesBudget) limitError() error {
+	return httpgrpc.Errorf(http.StatusUnprocessableEntity, limiter.ErrMaxChunkBytesHit, b.limit)
 }
 
 type limitsMiddleware struct {
@@ -93,5 +144,87 @@ func (l limitsMiddleware) Do(ctx context.Context, r Request) (Response, error) {
 		}
 	}
 
-	return l.next.Do(ctx, r)
+	return l.next.Do(withChunkBytesBudget(ctx, tenantIDs, l), r)
+}
+
+type instantQueryLimitsMiddleware struct {
+	Limits
+	next Handler
+}
+
+// NewInstantQueryLimitsMiddleware creates a new Middleware that enforces query limits on instant
+// queries. The length of an instant query is the time range selected by its longest range vector
+// selector, including the enclosing subqueries.
+func NewInstantQueryLimitsMiddleware(l Limits) Middleware {
+	return MiddlewareFunc(func(next Handler) Handler {
+		return instantQueryLimitsMiddleware{
+			next:   next,
+			Limits: l,
+		}
+	})
+}
+
+func (l instantQueryLimitsMiddleware) Do(ctx context.Context, r Request) (Response, error) {
+	log, ctx := spanlogger.New(ctx, "limits")
+	defer log.Finish()
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	if maxQueryLookback := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.MaxQueryLookback); maxQueryLookback > 0 {
+		minStartTime := util.TimeToMillis(time.Now().Add(-maxQueryLookback))
+
+		if r.GetEnd() < minStartTime {
+			// The query is evaluated fully outside the allowed range, so we can return an
+			// empty response.
+			level.Debug(log).Log(
+				"msg", "skipping the execution of the query because its time is before the 'max query lookback' setting",
+				"time", util.FormatTimeMillis(r.GetEnd()),
+				"maxQueryLookback", maxQueryLookback)
+
+			return NewEmptyPrometheusInstantQueryResponse(), nil
+		}
+	}
+
+	// Enforce the max query length. Queries which can't be parsed are passed through, so that
+	// the querier returns the parsing error.
+	if maxQueryLength := validation.SmallestPositiveNonZeroDurationPerTenant(tenantIDs, l.MaxQueryLength); maxQueryLength > 0 {
+		if expr, err := parser.ParseExpr(r.GetQuery()); err == nil {
+			if queryLen := instantQueryLength(expr); queryLen > maxQueryLength {
+				return nil, httpgrpc.Errorf(http.StatusBadRequest, validation.ErrQueryTooLong, queryLen, maxQueryLength)
+			}
+		}
+	}
+
+	return l.next.Do(withChunkBytesBudget(ctx, tenantIDs, l), r)
+}
+
+// instantQueryLength returns the longest time range selected by the query.
+func instantQueryLength(expr parser.Expr) time.Duration {
+	var length time.Duration
+
+	parser.Inspect(expr, func(node parser.Node, path []parser.Node) error {
+		var selected time.Duration
+		switch n := node.(type) {
+		case *parser.MatrixSelector:
+			selected = n.Range
+		case *parser.VectorSelector:
+		default:
+			return nil
+		}
+
+		for _, p := range path {
+			if subquery, ok := p.(*parser.SubqueryExpr); ok {
+				selected += subquery.Range
+			}
+		}
+		if selected > length {
+			length = selected
+		}
+		return nil
+	})
+
+	return length
 }
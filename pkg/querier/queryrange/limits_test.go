@@ -2,14 +2,19 @@ package queryrange
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/user"
 
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/util"
 )
 
@@ -186,10 +191,172 @@ func TestLimitsMiddleware_MaxQueryLength(t *testing.T) {
 	}
 }
 
+func TestInstantQueryLimitsMiddleware(t *testing.T) {
+	const (
+		thirtyDays = 30 * 24 * time.Hour
+	)
+
+	now := time.Now()
+
+	tests := map[string]struct {
+		maxQueryLookback time.Duration
+		maxQueryLength   time.Duration
+		query            string
+		reqTime          time.Time
+		expectedEmpty    bool
+		expectedErr      string
+	}{
+		"should not manipulate a query if limits are disabled": {
+			query:   `sum_over_time(foo[60d])`,
+			reqTime: time.Unix(0, 0),
+		},
+		"should return an empty response on a query evaluated before the max lookback": {
+			maxQueryLookback: thirtyDays,
+			query:            `sum_over_time(foo[1h])`,
+			reqTime:          now.Add(-thirtyDays).Add(-time.Hour),
+			expectedEmpty:    true,
+		},
+		"should succeed on a query evaluated within the max lookback": {
+			maxQueryLookback: thirtyDays,
+			query:            `sum_over_time(foo[1h])`,
+			reqTime:          now.Add(-time.Hour),
+		},
+		"should succeed on a query selecting a time range shorter than the max length": {
+			maxQueryLength: thirtyDays,
+			query:          `sum_over_time(foo[7d])`,
+			reqTime:        now,
+		},
+		"should fail on a query selecting a time range longer than the max length": {
+			maxQueryLength: thirtyDays,
+			query:          `sum_over_time(foo[7d]) + sum_over_time(foo[31d])`,
+			reqTime:        now,
+			expectedErr:    "the query time range exceeds the limit",
+		},
+		"should fail on a subquery selecting a time range longer than the max length": {
+			maxQueryLength: thirtyDays,
+			query:          `max_over_time(sum_over_time(foo[1d])[30d:1h])`,
+			reqTime:        now,
+			expectedErr:    "the query time range exceeds the limit",
+		},
+		"should pass through a query which can't be parsed": {
+			maxQueryLength: thirtyDays,
+			query:          `sum_over_time(foo[31d]`,
+			reqTime:        now,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req := &PrometheusInstantQueryRequest{
+				Query: testData.query,
+				Time:  util.TimeToMillis(testData.reqTime),
+			}
+
+			limits := mockLimits{maxQueryLookback: testData.maxQueryLookback, maxQueryLength: testData.maxQueryLength}
+			middleware := NewInstantQueryLimitsMiddleware(limits)
+
+			innerRes := NewEmptyPrometheusInstantQueryResponse()
+			inner := &mockHandler{}
+			inner.On("Do", mock.Anything, mock.Anything).Return(innerRes, nil)
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			outer := middleware.Wrap(inner)
+			res, err := outer.Do(ctx, req)
+
+			switch {
+			case testData.expectedErr != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testData.expectedErr)
+				assert.Nil(t, res)
+				assert.Len(t, inner.Calls, 0)
+			case testData.expectedEmpty:
+				require.NoError(t, err)
+				assert.Equal(t, NewEmptyPrometheusInstantQueryResponse(), res)
+				assert.Len(t, inner.Calls, 0)
+			default:
+				// We expect the response returned by the inner handler.
+				require.NoError(t, err)
+				assert.Same(t, innerRes, res)
+
+				// The request passed to the inner handler should have not been manipulated.
+				require.Len(t, inner.Calls, 1)
+				assert.Equal(t, req, inner.Calls[0].Arguments.Get(1).(Request))
+			}
+		})
+	}
+}
+
+func TestLimitsMiddleware_ShouldShareTheChunkBytesBudgetAcrossSubQueries(t *testing.T) {
+	tests := map[string]struct {
+		fetchedChunkBytes       []int
+		expectedBudgetHeaders   []string
+		expectedErrAtSubQueries int
+	}{
+		"budget not exhausted": {
+			fetchedChunkBytes:     []int{30, 30, 30},
+			expectedBudgetHeaders: []string{"100", "70", "40"},
+		},
+		"budget exceeded by a sub-query": {
+			fetchedChunkBytes:       []int{60, 60, 60},
+			expectedBudgetHeaders:   []string{"100", "40"},
+			expectedErrAtSubQueries: 2,
+		},
+		"budget exhausted before running a sub-query": {
+			fetchedChunkBytes:       []int{50, 50, 50},
+			expectedBudgetHeaders:   []string{"100", "50"},
+			expectedErrAtSubQueries: 3,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var budgetHeaders []string
+
+			// The downstream tracks the chunk bytes fetched by each sub-query in the query stats,
+			// like the query-frontend does with the stats returned by the queriers.
+			downstream := RoundTripFunc(func(r *http.Request) (*http.Response, error) {
+				stats.FromContext(r.Context()).AddFetchedChunkBytes(uint64(testData.fetchedChunkBytes[len(budgetHeaders)]))
+				budgetHeaders = append(budgetHeaders, r.Header.Get(ChunkBytesBudgetHeaderName))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(responseBody)),
+				}, nil
+			})
+
+			rt := roundTripper{next: downstream, codec: PrometheusCodec}
+			subQueries := HandlerFunc(func(ctx context.Context, r Request) (Response, error) {
+				for i := range testData.fetchedChunkBytes {
+					if _, err := rt.Do(ctx, r); err != nil {
+						assert.Equal(t, testData.expectedErrAtSubQueries, i+1)
+						return nil, err
+					}
+				}
+				return NewEmptyPrometheusResponse(), nil
+			})
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			_, err := NewLimitsMiddleware(mockLimits{maxFetchedChunkBytesPerQuery: 100}).Wrap(subQueries).Do(ctx, parsedRequest)
+			assert.Equal(t, testData.expectedBudgetHeaders, budgetHeaders)
+
+			if testData.expectedErrAtSubQueries == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			resp, ok := httpgrpc.HTTPResponseFromError(err)
+			require.True(t, ok)
+			assert.Equal(t, int32(http.StatusUnprocessableEntity), resp.Code)
+			assert.Contains(t, string(resp.Body), "the query hit the aggregated chunks size limit")
+		})
+	}
+}
+
 type mockLimits struct {
-	maxQueryLookback  time.Duration
-	maxQueryLength    time.Duration
-	maxCacheFreshness time.Duration
+	maxQueryLookback             time.Duration
+	maxQueryLength               time.Duration
+	maxCacheFreshness            time.Duration
+	maxFetchedChunkBytesPerQuery int
 }
 
 func (m mockLimits) MaxQueryLookback(string) time.Duration {
@@ -208,6 +375,10 @@ func (m mockLimits) MaxCacheFreshness(string) time.Duration {
 	return m.maxCacheFreshness
 }
 
+func (m mockLimits) MaxFetchedChunkBytesPerQuery(string) int {
+	return m.maxFetchedChunkBytesPerQuery
+}
+
 type mockHandler struct {
 	mock.Mock
 }
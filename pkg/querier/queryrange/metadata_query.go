@@ -0,0 +1,354 @@
+package queryrange
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/timestamp"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/spanlogger"
+)
+
+var (
+	// MetadataQueryCodec is a codec to encode and decode the requests and responses of the
+	// Prometheus series, label names and label values endpoints.
+	MetadataQueryCodec Codec = &metadataQueryCodec{}
+
+	// metadataMinTime and metadataMaxTime are the default start and end of the metadata
+	// requests, the same used by the Prometheus API when they're not specified.
+	metadataMinTime = timestamp.FromTime(time.Unix(math.MinInt64/1000+62135596801, 0).UTC())
+	metadataMaxTime = timestamp.FromTime(time.Unix(math.MaxInt64/1000-62135596801, 999999999).UTC())
+
+	labelValuesPathRegexp = regexp.MustCompile(`/api/v1/label/[^/]+/values$`)
+)
+
+// IsMetadataQuery returns whether the request is a query to the Prometheus series, label names
+// or label values endpoints.
+func IsMetadataQuery(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		return false
+	}
+	return isSeriesQuery(r.URL.Path) || strings.HasSuffix(r.URL.Path, "/api/v1/labels") || labelValuesPathRegexp.MatchString(r.URL.Path)
+}
+
+func isSeriesQuery(path string) bool {
+	return strings.HasSuffix(path, "/api/v1/series")
+}
+
+// GetStep returns 0, since metadata requests have no step.
+func (q *PrometheusMetadataRequest) GetStep() int64 {
+	return 0
+}
+
+// GetQuery returns the series matchers of the request.
+func (q *PrometheusMetadataRequest) GetQuery() string {
+	return strings.Join(q.GetMatchers(), ",")
+}
+
+// WithStartEnd clones the current `PrometheusMetadataRequest` with a new `start` and `end` timestamp.
+func (q *PrometheusMetadataRequest) WithStartEnd(start int64, end int64) Request {
+	new := *q
+	new.Start = start
+	new.End = end
+	return &new
+}
+
+// WithQuery clones the current `PrometheusMetadataRequest`. The query is ignored, since
+// the series matchers of metadata requests are never rewritten.
+func (q *PrometheusMetadataRequest) WithQuery(string) Request {
+	new := *q
+	return &new
+}
+
+// LogToSpan logs the current `PrometheusMetadataRequest` parameters to the specified span.
+func (q *PrometheusMetadataRequest) LogToSpan(sp opentracing.Span) {
+	sp.LogFields(
+		otlog.String("path", q.GetPath()),
+		otlog.String("matchers", q.GetQuery()),
+		otlog.String("start", timestamp.Time(q.GetStart()).String()),
+		otlog.String("end", timestamp.Time(q.GetEnd()).String()),
+	)
+}
+
+// isBounded returns whether both the start and end of the request have been specified.
+func (q *PrometheusMetadataRequest) isBounded() bool {
+	return q.Start != metadataMinTime && q.End != metadataMaxTime
+}
+
+type metadataQueryCodec struct{}
+
+// MergeResponse merges the responses of metadata requests, removing the duplicated series
+// or label names and values.
+func (metadataQueryCodec) MergeResponse(responses ...Response) (Response, error) {
+	if len(responses) == 0 {
+		return nil, errors.New("no metadata responses to merge")
+	}
+
+	// We need to pass on all the headers for results cache gen numbers.
+	var resultsCacheGenNumberHeaderValues []string
+	for _, res := range responses {
+		resultsCacheGenNumberHeaderValues = append(resultsCacheGenNumberHeaderValues, getHeaderValuesWithName(res, ResultsCacheGenNumberHeaderName)...)
+	}
+
+	var headers []*PrometheusResponseHeader
+	if len(resultsCacheGenNumberHeaderValues) != 0 {
+		headers = []*PrometheusResponseHeader{{
+			Name:   ResultsCacheGenNumberHeaderName,
+			Values: resultsCacheGenNumberHeaderValues,
+		}}
+	}
+
+	switch responses[0].(type) {
+	case *PrometheusSeriesResponse:
+		series, err := mergeSeriesResponses(responses)
+		if err != nil {
+			return nil, err
+		}
+		return &PrometheusSeriesResponse{Status: StatusSuccess, Data: series, Headers: headers}, nil
+
+	case *PrometheusLabelsResponse:
+		values, err := mergeLabelsResponses(responses)
+		if err != nil {
+			return nil, err
+		}
+		return &PrometheusLabelsResponse{Status: StatusSuccess, Data: values, Headers: headers}, nil
+
+	default:
+		return nil, errors.Errorf("unexpected response type %T", responses[0])
+	}
+}
+
+func mergeSeriesResponses(responses []Response) ([]SeriesData, error) {
+	var (
+		series = []SeriesData{}
+		seen   = map[string]struct{}{}
+	)
+
+	for _, res := range responses {
+		seriesRes, ok := res.(*PrometheusSeriesResponse)
+		if !ok {
+			return nil, errors.Errorf("unexpected response type %T", res)
+		}
+
+		for _, s := range seriesRes.Data {
+			key := cortexpb.FromLabelAdaptersToLabels(s.Labels).String()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			series = append(series, s)
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return labels.Compare(cortexpb.FromLabelAdaptersToLabels(series[i].Labels), cortexpb.FromLabelAdaptersToLabels(series[j].Labels)) < 0
+	})
+	return series, nil
+}
+
+func mergeLabelsResponses(responses []Response) ([]string, error) {
+	var (
+		values = []string{}
+		seen   = map[string]struct{}{}
+	)
+
+	for _, res := range responses {
+		labelsRes, ok := res.(*PrometheusLabelsResponse)
+		if !ok {
+			return nil, errors.Errorf("unexpected response type %T", res)
+		}
+
+		for _, v := range labelsRes.Data {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			values = append(values, v)
+		}
+	}
+
+	sort.Strings(values)
+	return values, nil
+}
+
+func (metadataQueryCodec) DecodeRequest(_ context.Context, r *http.Request) (Request, error) {
+	var (
+		result = PrometheusMetadataRequest{Start: metadataMinTime, End: metadataMaxTime}
+		err    error
+	)
+
+	if t := r.FormValue("start"); t != "" {
+		result.Start, err = util.ParseTime(t)
+		if err != nil {
+			return nil, decorateWithParamName(err, "start")
+		}
+	}
+
+	if t := r.FormValue("end"); t != "" {
+		result.End, err = util.ParseTime(t)
+		if err != nil {
+			return nil, decorateWithParamName(err, "end")
+		}
+	}
+
+	if result.End < result.Start {
+		return nil, errEndBeforeStart
+	}
+
+	result.Matchers = r.Form["match[]"]
+	result.Path = r.URL.Path
+
+	for _, value := range r.Header.Values(cacheControlHeader) {
+		if strings.Contains(value, noStoreValue) {
+			result.CachingOptions.Disabled = true
+			break
+		}
+	}
+
+	return &result, nil
+}
+
+func (metadataQueryCodec) EncodeRequest(ctx context.Context, r Request) (*http.Request, error) {
+	promReq, ok := r.(*PrometheusMetadataRequest)
+	if !ok {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, "invalid request format")
+	}
+
+	params := url.Values{}
+	if promReq.Start != metadataMinTime {
+		params.Set("start", encodeTime(promReq.Start))
+	}
+	if promReq.End != metadataMaxTime {
+		params.Set("end", encodeTime(promReq.End))
+	}
+	if len(promReq.Matchers) > 0 {
+		params["match[]"] = promReq.Matchers
+	}
+
+	u := &url.URL{
+		Path:     promReq.Path,
+		RawQuery: params.Encode(),
+	}
+	req := &http.Request{
+		Method:     "GET",
+		RequestURI: u.String(), // This is what the httpgrpc code looks at.
+		URL:        u,
+		Body:       http.NoBody,
+		Header:     http.Header{},
+	}
+
+	return req.WithContext(ctx), nil
+}
+
+func (metadataQueryCodec) DecodeResponse(ctx context.Context, r *http.Response, req Request) (Response, error) {
+	if r.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(r.Body)
+		return nil, httpgrpc.Errorf(r.StatusCode, string(body))
+	}
+	log, ctx := spanlogger.New(ctx, "ParseMetadataQueryResponse") //nolint:ineffassign,staticcheck
+	defer log.Finish()
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error(err)
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+	}
+
+	log.LogFields(otlog.Int("bytes", len(buf)))
+
+	var headers []*PrometheusResponseHeader
+	for h, hv := range r.Header {
+		headers = append(headers, &PrometheusResponseHeader{Name: h, Values: hv})
+	}
+
+	promReq, ok := req.(*PrometheusMetadataRequest)
+	if !ok {
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid request format")
+	}
+
+	if isSeriesQuery(promReq.Path) {
+		var resp PrometheusSeriesResponse
+		if err := json.Unmarshal(buf, &resp); err != nil {
+			return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+		}
+		resp.Headers = headers
+		return &resp, nil
+	}
+
+	var resp PrometheusLabelsResponse
+	if err := json.Unmarshal(buf, &resp); err != nil {
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error decoding response: %v", err)
+	}
+	resp.Headers = headers
+	return &resp, nil
+}
+
+func (metadataQueryCodec) EncodeResponse(ctx context.Context, res Response) (*http.Response, error) {
+	sp, _ := opentracing.StartSpanFromContext(ctx, "APIResponse.ToHTTPResponse")
+	defer sp.Finish()
+
+	switch res.(type) {
+	case *PrometheusSeriesResponse, *PrometheusLabelsResponse:
+	default:
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "invalid response format")
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusInternalServerError, "error encoding response: %v", err)
+	}
+
+	sp.LogFields(otlog.Int("bytes", len(b)))
+
+	resp := http.Response{
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Body:       ioutil.NopCloser(bytes.NewBuffer(b)),
+		StatusCode: http.StatusOK,
+	}
+	return &resp, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SeriesData) UnmarshalJSON(data []byte) error {
+	var metric model.Metric
+	if err := json.Unmarshal(data, &metric); err != nil {
+		return err
+	}
+	s.Labels = cortexpb.FromMetricsToLabelAdapters(metric)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *SeriesData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cortexpb.FromLabelAdaptersToMetric(s.Labels))
+}
+
+// metadataResponseWithoutHeaders returns the metadata response without its headers.
+func metadataResponseWithoutHeaders(resp Response) Response {
+	switch r := resp.(type) {
+	case *PrometheusSeriesResponse:
+		return &PrometheusSeriesResponse{Status: r.Status, Data: r.Data}
+	case *PrometheusLabelsResponse:
+		return &PrometheusLabelsResponse{Status: r.Status, Data: r.Data}
+	default:
+		return resp
+	}
+}
@@ -0,0 +1,213 @@
+package queryrange
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+)
+
+func TestIsMetadataQuery(t *testing.T) {
+	for _, tc := range []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{method: "GET", path: "/api/prom/api/v1/series", expected: true},
+		{method: "POST", path: "/prometheus/api/v1/series", expected: true},
+		{method: "DELETE", path: "/prometheus/api/v1/series", expected: false},
+		{method: "GET", path: "/prometheus/api/v1/labels", expected: true},
+		{method: "GET", path: "/prometheus/api/v1/label/foo/values", expected: true},
+		{method: "GET", path: "/prometheus/api/v1/query", expected: false},
+		{method: "GET", path: "/prometheus/api/v1/label/values", expected: false},
+	} {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			r, err := http.NewRequest(tc.method, tc.path, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, IsMetadataQuery(r))
+		})
+	}
+}
+
+func TestMetadataQueryRequest(t *testing.T) {
+	for i, tc := range []struct {
+		url         string
+		expected    Request
+		expectedErr error
+	}{
+		{
+			url: "/api/v1/series?end=1536716880&match%5B%5D=foo&match%5B%5D=bar&start=1536673680",
+			expected: &PrometheusMetadataRequest{
+				Path:     "/api/v1/series",
+				Start:    1536673680 * 1e3,
+				End:      1536716880 * 1e3,
+				Matchers: []string{"foo", "bar"},
+			},
+		},
+		{
+			url: "/api/v1/label/foo/values",
+			expected: &PrometheusMetadataRequest{
+				Path:  "/api/v1/label/foo/values",
+				Start: metadataMinTime,
+				End:   metadataMaxTime,
+			},
+		},
+		{
+			url:         "/api/v1/labels?start=foo",
+			expectedErr: httpgrpc.Errorf(http.StatusBadRequest, "invalid parameter \"start\"; cannot parse \"foo\" to a valid timestamp"),
+		},
+		{
+			url:         "/api/v1/labels?start=123&end=0",
+			expectedErr: errEndBeforeStart,
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			r, err := http.NewRequest("GET", tc.url, nil)
+			require.NoError(t, err)
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			r = r.WithContext(ctx)
+
+			req, err := MetadataQueryCodec.DecodeRequest(ctx, r)
+			if err != nil {
+				require.EqualValues(t, tc.expectedErr, err)
+				return
+			}
+			require.EqualValues(t, tc.expected, req)
+
+			rdash, err := MetadataQueryCodec.EncodeRequest(context.Background(), req)
+			require.NoError(t, err)
+			require.EqualValues(t, tc.url, rdash.RequestURI)
+		})
+	}
+}
+
+func TestMetadataQueryResponse(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		path     string
+		body     string
+		expected Response
+	}{
+		{
+			name: "series",
+			path: "/api/v1/series",
+			body: `{"status":"success","data":[{"__name__":"foo","bar":"baz"},{"__name__":"foo","bar":"qux"}]}`,
+			expected: &PrometheusSeriesResponse{
+				Status: StatusSuccess,
+				Data: []SeriesData{
+					{Labels: []cortexpb.LabelAdapter{{Name: "__name__", Value: "foo"}, {Name: "bar", Value: "baz"}}},
+					{Labels: []cortexpb.LabelAdapter{{Name: "__name__", Value: "foo"}, {Name: "bar", Value: "qux"}}},
+				},
+				Headers: []*PrometheusResponseHeader{{Name: "Content-Type", Values: []string{"application/json"}}},
+			},
+		},
+		{
+			name: "label names",
+			path: "/api/v1/labels",
+			body: `{"status":"success","data":["__name__","bar"]}`,
+			expected: &PrometheusLabelsResponse{
+				Status:  StatusSuccess,
+				Data:    []string{"__name__", "bar"},
+				Headers: []*PrometheusResponseHeader{{Name: "Content-Type", Values: []string{"application/json"}}},
+			},
+		},
+		{
+			name: "label values",
+			path: "/api/v1/label/bar/values",
+			body: `{"status":"success","data":[]}`,
+			expected: &PrometheusLabelsResponse{
+				Status:  StatusSuccess,
+				Data:    []string{},
+				Headers: []*PrometheusResponseHeader{{Name: "Content-Type", Values: []string{"application/json"}}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(bytes.NewBuffer([]byte(tc.body))),
+			}
+			resp, err := MetadataQueryCodec.DecodeResponse(context.Background(), response, &PrometheusMetadataRequest{Path: tc.path})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, resp)
+
+			encoded, err := MetadataQueryCodec.EncodeResponse(context.Background(), resp)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(encoded.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestMergeMetadataQueryResponses(t *testing.T) {
+	series := func(values ...string) SeriesData {
+		var lbls []cortexpb.LabelAdapter
+		for i := 0; i < len(values); i += 2 {
+			lbls = append(lbls, cortexpb.LabelAdapter{Name: values[i], Value: values[i+1]})
+		}
+		return SeriesData{Labels: lbls}
+	}
+
+	for i, tc := range []struct {
+		input       []Response
+		expected    Response
+		expectedErr bool
+	}{
+		{
+			input: []Response{
+				&PrometheusSeriesResponse{Status: StatusSuccess, Data: []SeriesData{series("__name__", "foo", "bar", "c"), series("__name__", "foo", "bar", "a")}},
+				&PrometheusSeriesResponse{Status: StatusSuccess, Data: []SeriesData{series("__name__", "foo", "bar", "b"), series("__name__", "foo", "bar", "a")}},
+			},
+			expected: &PrometheusSeriesResponse{Status: StatusSuccess, Data: []SeriesData{
+				series("__name__", "foo", "bar", "a"),
+				series("__name__", "foo", "bar", "b"),
+				series("__name__", "foo", "bar", "c"),
+			}},
+		},
+		{
+			input: []Response{
+				&PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"c", "a"}},
+				&PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"b", "a"}},
+				&PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{}},
+			},
+			expected: &PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"a", "b", "c"}},
+		},
+		{
+			input: []Response{
+				&PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{}, Headers: []*PrometheusResponseHeader{{Name: ResultsCacheGenNumberHeaderName, Values: []string{"1"}}}},
+				&PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{}, Headers: []*PrometheusResponseHeader{{Name: ResultsCacheGenNumberHeaderName, Values: []string{"1"}}}},
+			},
+			expected: &PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{}, Headers: []*PrometheusResponseHeader{{Name: ResultsCacheGenNumberHeaderName, Values: []string{"1", "1"}}}},
+		},
+		// Different types can't be merged.
+		{
+			input: []Response{
+				&PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"a"}},
+				&PrometheusSeriesResponse{Status: StatusSuccess, Data: []SeriesData{series("__name__", "foo")}},
+			},
+			expectedErr: true,
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			output, err := MetadataQueryCodec.MergeResponse(tc.input...)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, output)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package queryrange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/prometheus/common/model"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/chunk/cache"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
+	"github.com/cortexproject/cortex/pkg/tenant"
+	"github.com/cortexproject/cortex/pkg/util/spanlogger"
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+// metadataResultsCache caches the results of the metadata requests selecting exactly one
+// interval. Unlike query results, metadata results can't be extracted for a shorter time
+// range, so the metadata requests must be split by the same interval upstream for the
+// results to be cached.
+type metadataResultsCache struct {
+	logger   log.Logger
+	next     Handler
+	cache    cache.Cache
+	limits   Limits
+	interval time.Duration
+
+	cacheGenNumberLoader CacheGenNumberLoader
+	shouldCache          ShouldCacheFn
+}
+
+// newMetadataResultsCacheMiddleware creates a middleware caching the results of metadata
+// requests in the given cache, for each interval.
+func newMetadataResultsCacheMiddleware(
+	logger log.Logger,
+	c cache.Cache,
+	interval time.Duration,
+	limits Limits,
+	cacheGenNumberLoader CacheGenNumberLoader,
+	shouldCache ShouldCacheFn,
+) Middleware {
+	return MiddlewareFunc(func(next Handler) Handler {
+		return &metadataResultsCache{
+			logger:               logger,
+			next:                 next,
+			cache:                c,
+			limits:               limits,
+			interval:             interval,
+			cacheGenNumberLoader: cacheGenNumberLoader,
+			shouldCache:          shouldCache,
+		}
+	})
+}
+
+func (s metadataResultsCache) Do(ctx context.Context, r Request) (Response, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	if s.shouldCache != nil && !s.shouldCache(r) {
+		return s.next.Do(ctx, r)
+	}
+
+	// Only the requests selecting exactly one interval are cached.
+	msPerInterval := s.interval.Milliseconds()
+	if r.GetStart()%msPerInterval != 0 || r.GetEnd() != r.GetStart()+msPerInterval-1 {
+		return s.next.Do(ctx, r)
+	}
+
+	// Never cache the results of the latest freshness period.
+	maxCacheFreshness := validation.MaxDurationPerTenant(tenantIDs, s.limits.MaxCacheFreshness)
+	if r.GetEnd() > int64(model.Now().Add(-maxCacheFreshness)) {
+		return s.next.Do(ctx, r)
+	}
+
+	if s.cacheGenNumberLoader != nil {
+		ctx = cache.InjectCacheGenNumber(ctx, s.cacheGenNumberLoader.GetResultsCacheGenNumber(tenantIDs))
+	}
+
+	key := s.generateCacheKey(tenant.JoinTenantIDs(tenantIDs), r)
+	if cached, ok := s.get(ctx, key); ok {
+		stats.FromContext(ctx).AddResultsCacheHits(1)
+		return cached, nil
+	}
+
+	response, err := s.next.Do(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if isResponseCachable(ctx, response, s.cacheGenNumberLoader, s.logger) {
+		s.put(ctx, key, r, metadataResponseWithoutHeaders(response))
+	}
+	return response, nil
+}
+
+func (s metadataResultsCache) generateCacheKey(userID string, r Request) string {
+	return fmt.Sprintf("metadata:%s:%s:%s:%d", userID, r.(*PrometheusMetadataRequest).GetPath(), r.GetQuery(), r.GetStart()/s.interval.Milliseconds())
+}
+
+func (s metadataResultsCache) get(ctx context.Context, key string) (Response, bool) {
+	found, bufs, _ := s.cache.Fetch(ctx, []string{cache.HashKey(key)})
+	if len(found) != 1 {
+		return nil, false
+	}
+
+	var resp CachedResponse
+	log, ctx := spanlogger.New(ctx, "unmarshal-extent") //nolint:ineffassign,staticcheck
+	defer log.Finish()
+
+	log.LogFields(otlog.Int("bytes", len(bufs[0])))
+
+	if err := proto.Unmarshal(bufs[0], &resp); err != nil {
+		level.Error(log).Log("msg", "error unmarshalling cached value", "err", err)
+		log.Error(err)
+		return nil, false
+	}
+
+	if resp.Key != key || len(resp.Extents) != 1 || resp.Extents[0].Response == nil {
+		return nil, false
+	}
+
+	res, err := resp.Extents[0].toResponse()
+	if err != nil {
+		level.Error(log).Log("msg", "error unmarshalling cached response", "err", err)
+		return nil, false
+	}
+	return res, true
+}
+
+func (s metadataResultsCache) put(ctx context.Context, key string, r Request, res Response) {
+	any, err := types.MarshalAny(res)
+	if err != nil {
+		level.Error(s.logger).Log("msg", "error marshalling cached response", "err", err)
+		return
+	}
+
+	buf, err := proto.Marshal(&CachedResponse{
+		Key: key,
+		Extents: []Extent{{
+			Start:    r.GetStart(),
+			End:      r.GetEnd(),
+			Response: any,
+			TraceId:  jaegerTraceID(ctx),
+		}},
+	})
+	if err != nil {
+		level.Error(s.logger).Log("msg", "error marshalling cached value", "err", err)
+		return
+	}
+
+	s.cache.Store(ctx, []string{cache.HashKey(key)}, [][]byte{buf})
+}
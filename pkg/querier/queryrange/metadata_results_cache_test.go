@@ -0,0 +1,103 @@
+package queryrange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/chunk/cache"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+func TestMetadataResultsCache(t *testing.T) {
+	const hour = int64(time.Hour / time.Millisecond)
+
+	// The last whole hour before the max cache freshness.
+	lastHour := (util.TimeToMillis(time.Now().Add(-10*time.Minute))/hour - 1) * hour
+
+	for _, tc := range []struct {
+		name          string
+		req           Request
+		genNumber     string
+		respGenNumber string
+		expectedCalls int
+	}{
+		{
+			name:          "should cache a request selecting a whole interval",
+			req:           &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: lastHour - hour, End: lastHour - 1},
+			expectedCalls: 1,
+		},
+		{
+			name:          "should not cache a request selecting part of an interval",
+			req:           &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: lastHour - hour, End: lastHour - 2},
+			expectedCalls: 2,
+		},
+		{
+			name:          "should not cache a request more recent than the max cache freshness",
+			req:           &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: lastHour + hour, End: lastHour + 2*hour - 1},
+			expectedCalls: 2,
+		},
+		{
+			name:          "should not cache a request with caching disabled",
+			req:           &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: lastHour - hour, End: lastHour - 1, CachingOptions: CachingOptions{Disabled: true}},
+			expectedCalls: 2,
+		},
+		{
+			name:          "should cache a response with the current cache gen number",
+			req:           &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: lastHour - hour, End: lastHour - 1},
+			genNumber:     "1",
+			respGenNumber: "1",
+			expectedCalls: 1,
+		},
+		{
+			name:          "should not cache a response with a different cache gen number",
+			req:           &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: lastHour - hour, End: lastHour - 1},
+			genNumber:     "2",
+			respGenNumber: "1",
+			expectedCalls: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := &PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"foo"}}
+			if tc.respGenNumber != "" {
+				response.Headers = []*PrometheusResponseHeader{{Name: ResultsCacheGenNumberHeaderName, Values: []string{tc.respGenNumber}}}
+			}
+
+			var loader CacheGenNumberLoader
+			c := cache.NewMockCache()
+			if tc.genNumber != "" {
+				loader = constCacheGenNumberLoader(tc.genNumber)
+				c = cache.NewCacheGenNumMiddleware(c)
+			}
+
+			shouldCache := func(r Request) bool {
+				return !r.GetCachingOptions().Disabled
+			}
+			rcm := newMetadataResultsCacheMiddleware(log.NewNopLogger(), c, time.Hour, mockLimits{maxCacheFreshness: 10 * time.Minute}, loader, shouldCache)
+
+			calls := 0
+			rc := rcm.Wrap(HandlerFunc(func(_ context.Context, req Request) (Response, error) {
+				calls++
+				return response, nil
+			}))
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			for i := 0; i < 2; i++ {
+				resp, err := rc.Do(ctx, tc.req)
+				require.NoError(t, err)
+				require.Equal(t, response.Data, resp.(*PrometheusLabelsResponse).Data)
+			}
+			require.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+type constCacheGenNumberLoader string
+
+func (l constCacheGenNumberLoader) GetResultsCacheGenNumber([]string) string {
+	return string(l)
+}
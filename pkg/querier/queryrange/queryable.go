@@ -98,7 +98,7 @@ func (q *ShardedQuerier) handleEmbeddedQuery(encoded string) storage.SeriesSet {
 				errCh <- err
 				return
 			}
-			q.setResponseHeaders(resp.(*PrometheusResponse).Headers)
+			q.setResponseHeaders(resp.GetHeaders())
 			samplesCh <- streams
 		}(query)
 	}
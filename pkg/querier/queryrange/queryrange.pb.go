@@ -506,1191 +506,5324 @@ func (m *CachingOptions) GetDisabled() bool {
 	return false
 }
 
-func init() {
-	proto.RegisterType((*PrometheusRequest)(nil), "queryrange.PrometheusRequest")
-	proto.RegisterType((*PrometheusResponseHeader)(nil), "queryrange.PrometheusResponseHeader")
-	proto.RegisterType((*PrometheusResponse)(nil), "queryrange.PrometheusResponse")
-	proto.RegisterType((*PrometheusData)(nil), "queryrange.PrometheusData")
-	proto.RegisterType((*SampleStream)(nil), "queryrange.SampleStream")
-	proto.RegisterType((*CachedResponse)(nil), "queryrange.CachedResponse")
-	proto.RegisterType((*Extent)(nil), "queryrange.Extent")
-	proto.RegisterType((*CachingOptions)(nil), "queryrange.CachingOptions")
+type PrometheusInstantQueryRequest struct {
+	Path           string         `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Time           int64          `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Timeout        time.Duration  `protobuf:"bytes,3,opt,name=timeout,proto3,stdduration" json:"timeout"`
+	Query          string         `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	CachingOptions CachingOptions `protobuf:"bytes,5,opt,name=cachingOptions,proto3" json:"cachingOptions"`
 }
 
-func init() { proto.RegisterFile("queryrange.proto", fileDescriptor_79b02382e213d0b2) }
-
-var fileDescriptor_79b02382e213d0b2 = []byte{
-	// 827 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x8e, 0x1b, 0x45,
-	0x10, 0x76, 0xaf, 0xed, 0xb1, 0xdd, 0x1b, 0x39, 0x4b, 0x6f, 0x04, 0xe3, 0x95, 0x98, 0xb1, 0x46,
-	0x1c, 0x16, 0x29, 0x99, 0x95, 0x16, 0x71, 0x00, 0x09, 0x94, 0x0c, 0x59, 0x14, 0x7e, 0x04, 0x51,
-	0x6f, 0xc4, 0x81, 0x0b, 0x6a, 0x7b, 0x0a, 0x7b, 0x12, 0xcf, 0x4f, 0x7a, 0x7a, 0xd0, 0xfa, 0x86,
-	0xf2, 0x04, 0x1c, 0x79, 0x04, 0x90, 0x78, 0x0c, 0x0e, 0x39, 0xee, 0x31, 0xe2, 0x30, 0xb0, 0xde,
-	0x0b, 0x9a, 0x53, 0x1e, 0x01, 0xf5, 0xcf, 0x78, 0x66, 0x77, 0xb9, 0xe4, 0x62, 0x55, 0x55, 0xd7,
-	0x57, 0xf5, 0xd5, 0xd7, 0x3d, 0x65, 0xbc, 0xf7, 0xbc, 0x00, 0xbe, 0xe6, 0x2c, 0x59, 0x80, 0x9f,
-	0xf1, 0x54, 0xa4, 0x04, 0x37, 0x91, 0x83, 0x7b, 0x8b, 0x48, 0x2c, 0x8b, 0x99, 0x3f, 0x4f, 0xe3,
-	0xa3, 0x45, 0xba, 0x48, 0x8f, 0x54, 0xca, 0xac, 0xf8, 0x51, 0x79, 0xca, 0x51, 0x96, 0x86, 0x1e,
-	0x38, 0x8b, 0x34, 0x5d, 0xac, 0xa0, 0xc9, 0x0a, 0x0b, 0xce, 0x44, 0x94, 0x26, 0xe6, 0xfc, 0xa3,
-	0x56, 0xb9, 0x79, 0xca, 0x05, 0x9c, 0x65, 0x3c, 0x7d, 0x0a, 0x73, 0x61, 0xbc, 0xa3, 0xec, 0xd9,
-	0xa2, 0x3e, 0x98, 0x19, 0xc3, 0x40, 0x27, 0xd7, 0x4b, 0xb3, 0x64, 0xad, 0x8f, 0xbc, 0x17, 0x3b,
-	0xf8, 0xad, 0xc7, 0x3c, 0x8d, 0x41, 0x2c, 0xa1, 0xc8, 0x29, 0x3c, 0x2f, 0x20, 0x17, 0x84, 0xe0,
-	0x5e, 0xc6, 0xc4, 0xd2, 0x46, 0x53, 0x74, 0x38, 0xa2, 0xca, 0x26, 0x77, 0x70, 0x3f, 0x17, 0x8c,
-	0x0b, 0x7b, 0x67, 0x8a, 0x0e, 0xbb, 0x54, 0x3b, 0x64, 0x0f, 0x77, 0x21, 0x09, 0xed, 0xae, 0x8a,
-	0x49, 0x53, 0x62, 0x73, 0x01, 0x99, 0xdd, 0x53, 0x21, 0x65, 0x93, 0x4f, 0xf0, 0x40, 0x44, 0x31,
-	0xa4, 0x85, 0xb0, 0xfb, 0x53, 0x74, 0xb8, 0x7b, 0x3c, 0xf1, 0x35, 0x25, 0xbf, 0xa6, 0xe4, 0x3f,
-	0x34, 0xd3, 0x06, 0xc3, 0x97, 0xa5, 0xdb, 0xf9, 0xf5, 0x6f, 0x17, 0xd1, 0x1a, 0x23, 0x5b, 0x2b,
-	0x5d, 0x6d, 0x4b, 0xf1, 0xd1, 0x0e, 0x79, 0x84, 0xc7, 0x73, 0x36, 0x5f, 0x46, 0xc9, 0xe2, 0xdb,
-	0x4c, 0x22, 0x73, 0x7b, 0xa0, 0x6a, 0x1f, 0xf8, 0xad, 0x6b, 0xf9, 0xec, 0x4a, 0x46, 0xd0, 0x93,
-	0xc5, 0xe9, 0x35, 0x9c, 0xf7, 0x04, 0xdb, 0x6d, 0x0d, 0xf2, 0x2c, 0x4d, 0x72, 0x78, 0x04, 0x2c,
-	0x04, 0x4e, 0x26, 0xb8, 0xf7, 0x0d, 0x8b, 0x41, 0x4b, 0x11, 0xf4, 0xab, 0xd2, 0x45, 0xf7, 0xa8,
-	0x0a, 0x91, 0x77, 0xb1, 0xf5, 0x1d, 0x5b, 0x15, 0x90, 0xdb, 0x3b, 0xd3, 0x6e, 0x73, 0x68, 0x82,
-	0xde, 0xef, 0x3b, 0x98, 0xdc, 0x2c, 0x4b, 0x3c, 0x6c, 0x9d, 0x0a, 0x26, 0x8a, 0xdc, 0x94, 0xc4,
-	0x55, 0xe9, 0x5a, 0xb9, 0x8a, 0x50, 0x73, 0x42, 0x3e, 0xc7, 0xbd, 0x87, 0x4c, 0x30, 0x25, 0xf5,
-	0xb5, 0x81, 0x9a, 0x8a, 0x32, 0x23, 0x78, 0x5b, 0x0e, 0x54, 0x95, 0xee, 0x38, 0x64, 0x82, 0xdd,
-	0x4d, 0xe3, 0x48, 0x40, 0x9c, 0x89, 0x35, 0x55, 0x78, 0xf2, 0x21, 0x1e, 0x9d, 0x70, 0x9e, 0xf2,
-	0x27, 0xeb, 0x0c, 0xd4, 0x1d, 0x8d, 0x82, 0x77, 0xaa, 0xd2, 0xdd, 0x87, 0x3a, 0xd8, 0x42, 0x34,
-	0x99, 0xe4, 0x7d, 0xdc, 0x57, 0x8e, 0xba, 0xc3, 0x51, 0xb0, 0x5f, 0x95, 0xee, 0x6d, 0x05, 0x69,
-	0xa5, 0xeb, 0x0c, 0x72, 0x82, 0x07, 0x5a, 0xa8, 0xdc, 0xee, 0x4f, 0xbb, 0x87, 0xbb, 0xc7, 0xef,
-	0xfd, 0x3f, 0xd9, 0xab, 0xaa, 0xd6, 0x52, 0xd5, 0x58, 0xef, 0x05, 0xc2, 0xe3, 0xab, 0x93, 0x11,
-	0x1f, 0x63, 0x0a, 0x79, 0xb1, 0x12, 0x8a, 0xbc, 0xd6, 0x6a, 0x5c, 0x95, 0x2e, 0xe6, 0xdb, 0x28,
-	0x6d, 0x65, 0x90, 0xfb, 0xd8, 0xd2, 0x9e, 0xba, 0x8d, 0xdd, 0x63, 0xbb, 0x4d, 0xe4, 0x94, 0xc5,
-	0xd9, 0x0a, 0x4e, 0x05, 0x07, 0x16, 0x07, 0x63, 0xa3, 0x99, 0xa5, 0x2b, 0x51, 0x83, 0xf3, 0xfe,
-	0x44, 0xf8, 0x56, 0x3b, 0x91, 0x9c, 0x61, 0x6b, 0xc5, 0x66, 0xb0, 0x92, 0x57, 0x25, 0x4b, 0xee,
-	0xfb, 0xf5, 0xf7, 0xe5, 0x7f, 0x2d, 0xe3, 0x8f, 0x59, 0xc4, 0x83, 0xaf, 0x64, 0xb5, 0xbf, 0x4a,
-	0xf7, 0x8d, 0xbe, 0x4f, 0x8d, 0x7f, 0x10, 0xb2, 0x4c, 0x00, 0x97, 0x54, 0x62, 0x10, 0x3c, 0x9a,
-	0x53, 0xd3, 0x8f, 0x7c, 0x8c, 0x07, 0xb9, 0x62, 0x92, 0x9b, 0x69, 0xf6, 0x9a, 0xd6, 0x9a, 0x62,
-	0x33, 0xc5, 0x4f, 0xea, 0xb9, 0xd1, 0x1a, 0xe0, 0x3d, 0xc5, 0x63, 0xf9, 0xea, 0x21, 0xdc, 0x3e,
-	0xb9, 0x09, 0xee, 0x3e, 0x83, 0xb5, 0xd1, 0x70, 0x50, 0x95, 0xae, 0x74, 0xa9, 0xfc, 0x91, 0x5f,
-	0x26, 0x9c, 0x09, 0x48, 0x44, 0xdd, 0x88, 0xb4, 0x65, 0x3b, 0x51, 0x47, 0xc1, 0x6d, 0xd3, 0xaa,
-	0x4e, 0xa5, 0xb5, 0xe1, 0xfd, 0x81, 0xb0, 0xa5, 0x93, 0x88, 0x5b, 0xef, 0x07, 0xd9, 0xa6, 0x1b,
-	0x8c, 0xaa, 0xd2, 0xd5, 0x81, 0x7a, 0x55, 0x4c, 0xf4, 0xaa, 0x50, 0xeb, 0x43, 0xb3, 0x80, 0x24,
-	0xd4, 0x3b, 0x63, 0x8a, 0x87, 0x82, 0xb3, 0x39, 0xfc, 0x10, 0x85, 0xe6, 0xcd, 0xd5, 0x0f, 0x44,
-	0x85, 0xbf, 0x08, 0xc9, 0xa7, 0x78, 0xc8, 0xcd, 0x38, 0x66, 0x85, 0xdc, 0xb9, 0xb1, 0x42, 0x1e,
-	0x24, 0xeb, 0xe0, 0x56, 0x55, 0xba, 0xdb, 0x4c, 0xba, 0xb5, 0xbe, 0xec, 0x0d, 0xbb, 0x7b, 0x3d,
-	0xef, 0xae, 0x96, 0xa6, 0xf9, 0xf4, 0xc9, 0x01, 0x1e, 0x86, 0x51, 0xce, 0x66, 0x2b, 0x08, 0x15,
-	0xf1, 0x21, 0xdd, 0xfa, 0xc1, 0xfd, 0xf3, 0x0b, 0xa7, 0xf3, 0xea, 0xc2, 0xe9, 0xbc, 0xbe, 0x70,
-	0xd0, 0xcf, 0x1b, 0x07, 0xfd, 0xb6, 0x71, 0xd0, 0xcb, 0x8d, 0x83, 0xce, 0x37, 0x0e, 0xfa, 0x67,
-	0xe3, 0xa0, 0x7f, 0x37, 0x4e, 0xe7, 0xf5, 0xc6, 0x41, 0xbf, 0x5c, 0x3a, 0x9d, 0xf3, 0x4b, 0xa7,
-	0xf3, 0xea, 0xd2, 0xe9, 0x7c, 0xdf, 0xfa, 0x0b, 0x98, 0x59, 0x8a, 0xdb, 0x07, 0xff, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x08, 0x83, 0xd3, 0x7d, 0x29, 0x06, 0x00, 0x00,
+func (m *PrometheusInstantQueryRequest) Reset()      { *m = PrometheusInstantQueryRequest{} }
+func (*PrometheusInstantQueryRequest) ProtoMessage() {}
+func (*PrometheusInstantQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{8}
 }
-
-func (this *PrometheusRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
-
-	that1, ok := that.(*PrometheusRequest)
-	if !ok {
-		that2, ok := that.(PrometheusRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *PrometheusInstantQueryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrometheusInstantQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrometheusInstantQueryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Path != that1.Path {
-		return false
-	}
-	if this.Start != that1.Start {
-		return false
-	}
-	if this.End != that1.End {
-		return false
-	}
-	if this.Step != that1.Step {
-		return false
+}
+func (m *PrometheusInstantQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusInstantQueryRequest.Merge(m, src)
+}
+func (m *PrometheusInstantQueryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrometheusInstantQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusInstantQueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusInstantQueryRequest proto.InternalMessageInfo
+
+func (m *PrometheusInstantQueryRequest) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	if this.Timeout != that1.Timeout {
-		return false
+	return ""
+}
+
+func (m *PrometheusInstantQueryRequest) GetTime() int64 {
+	if m != nil {
+		return m.Time
 	}
-	if this.Query != that1.Query {
-		return false
+	return 0
+}
+
+func (m *PrometheusInstantQueryRequest) GetTimeout() time.Duration {
+	if m != nil {
+		return m.Timeout
 	}
-	if !this.CachingOptions.Equal(&that1.CachingOptions) {
-		return false
+	return 0
+}
+
+func (m *PrometheusInstantQueryRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
 	}
-	return true
+	return ""
 }
-func (this *PrometheusResponseHeader) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PrometheusInstantQueryRequest) GetCachingOptions() CachingOptions {
+	if m != nil {
+		return m.CachingOptions
 	}
+	return CachingOptions{}
+}
 
-	that1, ok := that.(*PrometheusResponseHeader)
-	if !ok {
-		that2, ok := that.(PrometheusResponseHeader)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type PrometheusInstantQueryResponse struct {
+	Status    string                      `protobuf:"bytes,1,opt,name=Status,proto3" json:"status"`
+	Data      PrometheusInstantQueryData  `protobuf:"bytes,2,opt,name=Data,proto3" json:"data,omitempty"`
+	ErrorType string                      `protobuf:"bytes,3,opt,name=ErrorType,proto3" json:"errorType,omitempty"`
+	Error     string                      `protobuf:"bytes,4,opt,name=Error,proto3" json:"error,omitempty"`
+	Headers   []*PrometheusResponseHeader `protobuf:"bytes,5,rep,name=Headers,proto3" json:"-"`
+}
+
+func (m *PrometheusInstantQueryResponse) Reset()      { *m = PrometheusInstantQueryResponse{} }
+func (*PrometheusInstantQueryResponse) ProtoMessage() {}
+func (*PrometheusInstantQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{9}
+}
+func (m *PrometheusInstantQueryResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrometheusInstantQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrometheusInstantQueryResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *PrometheusInstantQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusInstantQueryResponse.Merge(m, src)
+}
+func (m *PrometheusInstantQueryResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrometheusInstantQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusInstantQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusInstantQueryResponse proto.InternalMessageInfo
+
+func (m *PrometheusInstantQueryResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
 	}
-	if this.Name != that1.Name {
-		return false
+	return ""
+}
+
+func (m *PrometheusInstantQueryResponse) GetData() PrometheusInstantQueryData {
+	if m != nil {
+		return m.Data
 	}
-	if len(this.Values) != len(that1.Values) {
-		return false
+	return PrometheusInstantQueryData{}
+}
+
+func (m *PrometheusInstantQueryResponse) GetErrorType() string {
+	if m != nil {
+		return m.ErrorType
 	}
-	for i := range this.Values {
-		if this.Values[i] != that1.Values[i] {
-			return false
-		}
+	return ""
+}
+
+func (m *PrometheusInstantQueryResponse) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	return true
+	return ""
 }
-func (this *PrometheusResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PrometheusInstantQueryResponse) GetHeaders() []*PrometheusResponseHeader {
+	if m != nil {
+		return m.Headers
 	}
+	return nil
+}
 
-	that1, ok := that.(*PrometheusResponse)
-	if !ok {
-		that2, ok := that.(PrometheusResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// PrometheusInstantQueryData is JSON encoded like the Prometheus API does, with the
+// format of the result depending on its type.
+type PrometheusInstantQueryData struct {
+	ResultType string `protobuf:"bytes,1,opt,name=ResultType,proto3" json:"ResultType,omitempty"`
+	// Types that are valid to be assigned to Result:
+	//	*PrometheusInstantQueryData_Vector
+	//	*PrometheusInstantQueryData_Scalar
+	//	*PrometheusInstantQueryData_String_
+	//	*PrometheusInstantQueryData_Matrix
+	Result isPrometheusInstantQueryData_Result `protobuf_oneof:"result"`
+}
+
+func (m *PrometheusInstantQueryData) Reset()      { *m = PrometheusInstantQueryData{} }
+func (*PrometheusInstantQueryData) ProtoMessage() {}
+func (*PrometheusInstantQueryData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{10}
+}
+func (m *PrometheusInstantQueryData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrometheusInstantQueryData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrometheusInstantQueryData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Status != that1.Status {
-		return false
+}
+func (m *PrometheusInstantQueryData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusInstantQueryData.Merge(m, src)
+}
+func (m *PrometheusInstantQueryData) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrometheusInstantQueryData) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusInstantQueryData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusInstantQueryData proto.InternalMessageInfo
+
+type isPrometheusInstantQueryData_Result interface {
+	isPrometheusInstantQueryData_Result()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type PrometheusInstantQueryData_Vector struct {
+	Vector *Vector `protobuf:"bytes,2,opt,name=vector,proto3,oneof"`
+}
+type PrometheusInstantQueryData_Scalar struct {
+	Scalar *cortexpb.Sample `protobuf:"bytes,3,opt,name=scalar,proto3,oneof"`
+}
+type PrometheusInstantQueryData_String_ struct {
+	String_ *StringSample `protobuf:"bytes,4,opt,name=string,proto3,oneof"`
+}
+type PrometheusInstantQueryData_Matrix struct {
+	Matrix *Matrix `protobuf:"bytes,5,opt,name=matrix,proto3,oneof"`
+}
+
+func (*PrometheusInstantQueryData_Vector) isPrometheusInstantQueryData_Result()  {}
+func (*PrometheusInstantQueryData_Scalar) isPrometheusInstantQueryData_Result()  {}
+func (*PrometheusInstantQueryData_String_) isPrometheusInstantQueryData_Result() {}
+func (*PrometheusInstantQueryData_Matrix) isPrometheusInstantQueryData_Result()  {}
+
+func (m *PrometheusInstantQueryData) GetResult() isPrometheusInstantQueryData_Result {
+	if m != nil {
+		return m.Result
 	}
-	if !this.Data.Equal(&that1.Data) {
-		return false
+	return nil
+}
+
+func (m *PrometheusInstantQueryData) GetResultType() string {
+	if m != nil {
+		return m.ResultType
 	}
-	if this.ErrorType != that1.ErrorType {
-		return false
+	return ""
+}
+
+func (m *PrometheusInstantQueryData) GetVector() *Vector {
+	if x, ok := m.GetResult().(*PrometheusInstantQueryData_Vector); ok {
+		return x.Vector
 	}
-	if this.Error != that1.Error {
-		return false
+	return nil
+}
+
+func (m *PrometheusInstantQueryData) GetScalar() *cortexpb.Sample {
+	if x, ok := m.GetResult().(*PrometheusInstantQueryData_Scalar); ok {
+		return x.Scalar
 	}
-	if len(this.Headers) != len(that1.Headers) {
-		return false
+	return nil
+}
+
+func (m *PrometheusInstantQueryData) GetString_() *StringSample {
+	if x, ok := m.GetResult().(*PrometheusInstantQueryData_String_); ok {
+		return x.String_
 	}
-	for i := range this.Headers {
-		if !this.Headers[i].Equal(that1.Headers[i]) {
-			return false
-		}
+	return nil
+}
+
+func (m *PrometheusInstantQueryData) GetMatrix() *Matrix {
+	if x, ok := m.GetResult().(*PrometheusInstantQueryData_Matrix); ok {
+		return x.Matrix
 	}
-	return true
+	return nil
 }
-func (this *PrometheusData) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PrometheusInstantQueryData) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PrometheusInstantQueryData_Vector)(nil),
+		(*PrometheusInstantQueryData_Scalar)(nil),
+		(*PrometheusInstantQueryData_String_)(nil),
+		(*PrometheusInstantQueryData_Matrix)(nil),
 	}
+}
 
-	that1, ok := that.(*PrometheusData)
-	if !ok {
-		that2, ok := that.(PrometheusData)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type Vector struct {
+	Samples []Sample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples"`
+}
+
+func (m *Vector) Reset()      { *m = Vector{} }
+func (*Vector) ProtoMessage() {}
+func (*Vector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{11}
+}
+func (m *Vector) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Vector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Vector.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ResultType != that1.ResultType {
-		return false
-	}
-	if len(this.Result) != len(that1.Result) {
-		return false
+}
+func (m *Vector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Vector.Merge(m, src)
+}
+func (m *Vector) XXX_Size() int {
+	return m.Size()
+}
+func (m *Vector) XXX_DiscardUnknown() {
+	xxx_messageInfo_Vector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Vector proto.InternalMessageInfo
+
+func (m *Vector) GetSamples() []Sample {
+	if m != nil {
+		return m.Samples
 	}
-	for i := range this.Result {
-		if !this.Result[i].Equal(&that1.Result[i]) {
-			return false
+	return nil
+}
+
+type Sample struct {
+	Labels []github_com_cortexproject_cortex_pkg_cortexpb.LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=github.com/cortexproject/cortex/pkg/cortexpb.LabelAdapter" json:"labels"`
+	Sample cortexpb.Sample                                             `protobuf:"bytes,2,opt,name=sample,proto3" json:"sample"`
+}
+
+func (m *Sample) Reset()      { *m = Sample{} }
+func (*Sample) ProtoMessage() {}
+func (*Sample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{12}
+}
+func (m *Sample) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Sample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Sample.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *SampleStream) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *Sample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sample.Merge(m, src)
+}
+func (m *Sample) XXX_Size() int {
+	return m.Size()
+}
+func (m *Sample) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sample.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sample proto.InternalMessageInfo
+
+func (m *Sample) GetSample() cortexpb.Sample {
+	if m != nil {
+		return m.Sample
 	}
+	return cortexpb.Sample{}
+}
 
-	that1, ok := that.(*SampleStream)
-	if !ok {
-		that2, ok := that.(SampleStream)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type StringSample struct {
+	Value       string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	TimestampMs int64  `protobuf:"varint,2,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+}
+
+func (m *StringSample) Reset()      { *m = StringSample{} }
+func (*StringSample) ProtoMessage() {}
+func (*StringSample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{13}
+}
+func (m *StringSample) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StringSample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StringSample.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Labels) != len(that1.Labels) {
-		return false
-	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
-			return false
-		}
+}
+func (m *StringSample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StringSample.Merge(m, src)
+}
+func (m *StringSample) XXX_Size() int {
+	return m.Size()
+}
+func (m *StringSample) XXX_DiscardUnknown() {
+	xxx_messageInfo_StringSample.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StringSample proto.InternalMessageInfo
+
+func (m *StringSample) GetValue() string {
+	if m != nil {
+		return m.Value
 	}
-	if len(this.Samples) != len(that1.Samples) {
-		return false
+	return ""
+}
+
+func (m *StringSample) GetTimestampMs() int64 {
+	if m != nil {
+		return m.TimestampMs
 	}
-	for i := range this.Samples {
-		if !this.Samples[i].Equal(&that1.Samples[i]) {
-			return false
+	return 0
+}
+
+type Matrix struct {
+	SampleStreams []SampleStream `protobuf:"bytes,1,rep,name=sample_streams,json=sampleStreams,proto3" json:"sample_streams"`
+}
+
+func (m *Matrix) Reset()      { *m = Matrix{} }
+func (*Matrix) ProtoMessage() {}
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{14}
+}
+func (m *Matrix) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Matrix) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Matrix.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *CachedResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *Matrix) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Matrix.Merge(m, src)
+}
+func (m *Matrix) XXX_Size() int {
+	return m.Size()
+}
+func (m *Matrix) XXX_DiscardUnknown() {
+	xxx_messageInfo_Matrix.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Matrix proto.InternalMessageInfo
+
+func (m *Matrix) GetSampleStreams() []SampleStream {
+	if m != nil {
+		return m.SampleStreams
 	}
+	return nil
+}
 
-	that1, ok := that.(*CachedResponse)
-	if !ok {
-		that2, ok := that.(CachedResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type PrometheusMetadataRequest struct {
+	Path           string         `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Start          int64          `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End            int64          `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Matchers       []string       `protobuf:"bytes,4,rep,name=matchers,proto3" json:"matchers,omitempty"`
+	CachingOptions CachingOptions `protobuf:"bytes,5,opt,name=cachingOptions,proto3" json:"cachingOptions"`
+}
+
+func (m *PrometheusMetadataRequest) Reset()      { *m = PrometheusMetadataRequest{} }
+func (*PrometheusMetadataRequest) ProtoMessage() {}
+func (*PrometheusMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{15}
+}
+func (m *PrometheusMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrometheusMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrometheusMetadataRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *PrometheusMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusMetadataRequest.Merge(m, src)
+}
+func (m *PrometheusMetadataRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrometheusMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusMetadataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusMetadataRequest proto.InternalMessageInfo
+
+func (m *PrometheusMetadataRequest) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	if this.Key != that1.Key {
-		return false
+	return ""
+}
+
+func (m *PrometheusMetadataRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
 	}
-	if len(this.Extents) != len(that1.Extents) {
-		return false
+	return 0
+}
+
+func (m *PrometheusMetadataRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
 	}
-	for i := range this.Extents {
-		if !this.Extents[i].Equal(&that1.Extents[i]) {
-			return false
-		}
+	return 0
+}
+
+func (m *PrometheusMetadataRequest) GetMatchers() []string {
+	if m != nil {
+		return m.Matchers
 	}
-	return true
+	return nil
 }
-func (this *Extent) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PrometheusMetadataRequest) GetCachingOptions() CachingOptions {
+	if m != nil {
+		return m.CachingOptions
 	}
+	return CachingOptions{}
+}
 
-	that1, ok := that.(*Extent)
-	if !ok {
-		that2, ok := that.(Extent)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type PrometheusSeriesResponse struct {
+	Status    string                      `protobuf:"bytes,1,opt,name=Status,proto3" json:"status"`
+	Data      []SeriesData                `protobuf:"bytes,2,rep,name=Data,proto3" json:"data"`
+	ErrorType string                      `protobuf:"bytes,3,opt,name=ErrorType,proto3" json:"errorType,omitempty"`
+	Error     string                      `protobuf:"bytes,4,opt,name=Error,proto3" json:"error,omitempty"`
+	Headers   []*PrometheusResponseHeader `protobuf:"bytes,5,rep,name=Headers,proto3" json:"-"`
+}
+
+func (m *PrometheusSeriesResponse) Reset()      { *m = PrometheusSeriesResponse{} }
+func (*PrometheusSeriesResponse) ProtoMessage() {}
+func (*PrometheusSeriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{16}
+}
+func (m *PrometheusSeriesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrometheusSeriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrometheusSeriesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Start != that1.Start {
-		return false
-	}
-	if this.End != that1.End {
-		return false
-	}
-	if this.TraceId != that1.TraceId {
-		return false
+}
+func (m *PrometheusSeriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusSeriesResponse.Merge(m, src)
+}
+func (m *PrometheusSeriesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrometheusSeriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusSeriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusSeriesResponse proto.InternalMessageInfo
+
+func (m *PrometheusSeriesResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
 	}
-	if !this.Response.Equal(that1.Response) {
-		return false
+	return ""
+}
+
+func (m *PrometheusSeriesResponse) GetData() []SeriesData {
+	if m != nil {
+		return m.Data
 	}
-	return true
+	return nil
 }
-func (this *CachingOptions) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PrometheusSeriesResponse) GetErrorType() string {
+	if m != nil {
+		return m.ErrorType
 	}
+	return ""
+}
 
-	that1, ok := that.(*CachingOptions)
-	if !ok {
-		that2, ok := that.(CachingOptions)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Disabled != that1.Disabled {
-		return false
+func (m *PrometheusSeriesResponse) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	return true
+	return ""
 }
-func (this *PrometheusRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *PrometheusSeriesResponse) GetHeaders() []*PrometheusResponseHeader {
+	if m != nil {
+		return m.Headers
 	}
-	s := make([]string, 0, 11)
-	s = append(s, "&queryrange.PrometheusRequest{")
-	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
-	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
-	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
-	s = append(s, "Step: "+fmt.Sprintf("%#v", this.Step)+",\n")
-	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
-	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
-	s = append(s, "CachingOptions: "+strings.Replace(this.CachingOptions.GoString(), `&`, ``, 1)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *PrometheusResponseHeader) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&queryrange.PrometheusResponseHeader{")
-	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
-	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+type SeriesData struct {
+	Labels []github_com_cortexproject_cortex_pkg_cortexpb.LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=github.com/cortexproject/cortex/pkg/cortexpb.LabelAdapter" json:"labels"`
 }
-func (this *PrometheusResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 9)
-	s = append(s, "&queryrange.PrometheusResponse{")
-	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
-	s = append(s, "Data: "+strings.Replace(this.Data.GoString(), `&`, ``, 1)+",\n")
-	s = append(s, "ErrorType: "+fmt.Sprintf("%#v", this.ErrorType)+",\n")
-	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
-	if this.Headers != nil {
-		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+func (m *SeriesData) Reset()      { *m = SeriesData{} }
+func (*SeriesData) ProtoMessage() {}
+func (*SeriesData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{17}
 }
-func (this *PrometheusData) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&queryrange.PrometheusData{")
-	s = append(s, "ResultType: "+fmt.Sprintf("%#v", this.ResultType)+",\n")
-	if this.Result != nil {
-		vs := make([]*SampleStream, len(this.Result))
-		for i := range vs {
-			vs[i] = &this.Result[i]
-		}
-		s = append(s, "Result: "+fmt.Sprintf("%#v", vs)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+func (m *SeriesData) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (this *SampleStream) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&queryrange.SampleStream{")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	if this.Samples != nil {
-		vs := make([]*cortexpb.Sample, len(this.Samples))
-		for i := range vs {
-			vs[i] = &this.Samples[i]
+func (m *SeriesData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SeriesData.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		s = append(s, "Samples: "+fmt.Sprintf("%#v", vs)+",\n")
+		return b[:n], nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
 }
-func (this *CachedResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&queryrange.CachedResponse{")
-	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
-	if this.Extents != nil {
-		vs := make([]*Extent, len(this.Extents))
-		for i := range vs {
-			vs[i] = &this.Extents[i]
+func (m *SeriesData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeriesData.Merge(m, src)
+}
+func (m *SeriesData) XXX_Size() int {
+	return m.Size()
+}
+func (m *SeriesData) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeriesData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SeriesData proto.InternalMessageInfo
+
+type PrometheusLabelsResponse struct {
+	Status    string                      `protobuf:"bytes,1,opt,name=Status,proto3" json:"status"`
+	Data      []string                    `protobuf:"bytes,2,rep,name=Data,proto3" json:"data"`
+	ErrorType string                      `protobuf:"bytes,3,opt,name=ErrorType,proto3" json:"errorType,omitempty"`
+	Error     string                      `protobuf:"bytes,4,opt,name=Error,proto3" json:"error,omitempty"`
+	Headers   []*PrometheusResponseHeader `protobuf:"bytes,5,rep,name=Headers,proto3" json:"-"`
+}
+
+func (m *PrometheusLabelsResponse) Reset()      { *m = PrometheusLabelsResponse{} }
+func (*PrometheusLabelsResponse) ProtoMessage() {}
+func (*PrometheusLabelsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79b02382e213d0b2, []int{18}
+}
+func (m *PrometheusLabelsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrometheusLabelsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrometheusLabelsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		s = append(s, "Extents: "+fmt.Sprintf("%#v", vs)+",\n")
+		return b[:n], nil
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
 }
-func (this *Extent) GoString() string {
-	if this == nil {
-		return "nil"
+func (m *PrometheusLabelsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrometheusLabelsResponse.Merge(m, src)
+}
+func (m *PrometheusLabelsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrometheusLabelsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrometheusLabelsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrometheusLabelsResponse proto.InternalMessageInfo
+
+func (m *PrometheusLabelsResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&queryrange.Extent{")
-	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
-	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
-	s = append(s, "TraceId: "+fmt.Sprintf("%#v", this.TraceId)+",\n")
-	if this.Response != nil {
-		s = append(s, "Response: "+fmt.Sprintf("%#v", this.Response)+",\n")
+	return ""
+}
+
+func (m *PrometheusLabelsResponse) GetData() []string {
+	if m != nil {
+		return m.Data
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *CachingOptions) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *PrometheusLabelsResponse) GetErrorType() string {
+	if m != nil {
+		return m.ErrorType
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&queryrange.CachingOptions{")
-	s = append(s, "Disabled: "+fmt.Sprintf("%#v", this.Disabled)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func valueToGoStringQueryrange(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *PrometheusLabelsResponse) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+	return ""
 }
-func (m *PrometheusRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+
+func (m *PrometheusLabelsResponse) GetHeaders() []*PrometheusResponseHeader {
+	if m != nil {
+		return m.Headers
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PrometheusRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func init() {
+	proto.RegisterType((*PrometheusRequest)(nil), "queryrange.PrometheusRequest")
+	proto.RegisterType((*PrometheusResponseHeader)(nil), "queryrange.PrometheusResponseHeader")
+	proto.RegisterType((*PrometheusResponse)(nil), "queryrange.PrometheusResponse")
+	proto.RegisterType((*PrometheusData)(nil), "queryrange.PrometheusData")
+	proto.RegisterType((*SampleStream)(nil), "queryrange.SampleStream")
+	proto.RegisterType((*CachedResponse)(nil), "queryrange.CachedResponse")
+	proto.RegisterType((*Extent)(nil), "queryrange.Extent")
+	proto.RegisterType((*CachingOptions)(nil), "queryrange.CachingOptions")
+	proto.RegisterType((*PrometheusInstantQueryRequest)(nil), "queryrange.PrometheusInstantQueryRequest")
+	proto.RegisterType((*PrometheusInstantQueryResponse)(nil), "queryrange.PrometheusInstantQueryResponse")
+	proto.RegisterType((*PrometheusInstantQueryData)(nil), "queryrange.PrometheusInstantQueryData")
+	proto.RegisterType((*Vector)(nil), "queryrange.Vector")
+	proto.RegisterType((*Sample)(nil), "queryrange.Sample")
+	proto.RegisterType((*StringSample)(nil), "queryrange.StringSample")
+	proto.RegisterType((*Matrix)(nil), "queryrange.Matrix")
+	proto.RegisterType((*PrometheusMetadataRequest)(nil), "queryrange.PrometheusMetadataRequest")
+	proto.RegisterType((*PrometheusSeriesResponse)(nil), "queryrange.PrometheusSeriesResponse")
+	proto.RegisterType((*SeriesData)(nil), "queryrange.SeriesData")
+	proto.RegisterType((*PrometheusLabelsResponse)(nil), "queryrange.PrometheusLabelsResponse")
 }
 
-func (m *PrometheusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.CachingOptions.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
+func init() { proto.RegisterFile("queryrange.proto", fileDescriptor_79b02382e213d0b2) }
+
+var fileDescriptor_79b02382e213d0b2 = []byte{
+	// 1176 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0xf7, 0xfa, 0x63, 0x63, 0xbf, 0x04, 0x37, 0x4c, 0xab, 0xb2, 0xb6, 0xe8, 0xae, 0x59, 0x21,
+	0x14, 0x50, 0xeb, 0x4a, 0x41, 0x48, 0x80, 0x00, 0xb5, 0x4b, 0x03, 0x2d, 0x10, 0x5a, 0x36, 0x55,
+	0x0f, 0x5c, 0xa2, 0xb1, 0x3d, 0x38, 0xdb, 0x7a, 0x3f, 0x3a, 0x33, 0xae, 0x62, 0x89, 0x03, 0xaa,
+	0xc4, 0x9d, 0x23, 0x57, 0x6e, 0x45, 0xe2, 0x5f, 0xe0, 0x80, 0xc4, 0xa1, 0xc7, 0x1c, 0x2b, 0x0e,
+	0x86, 0x38, 0x12, 0x42, 0x3e, 0xf5, 0x4f, 0x40, 0xf3, 0xb1, 0xde, 0x75, 0x9c, 0x54, 0x84, 0x56,
+	0x48, 0xe5, 0x12, 0xcd, 0xfb, 0x9c, 0xf7, 0x7e, 0x6f, 0xde, 0xcf, 0x1b, 0x58, 0xbd, 0x3b, 0x24,
+	0x74, 0x44, 0x71, 0xd4, 0x27, 0xed, 0x84, 0xc6, 0x3c, 0x46, 0x90, 0x69, 0x9a, 0x17, 0xfa, 0x01,
+	0xdf, 0x19, 0x76, 0xda, 0xdd, 0x38, 0xbc, 0xd8, 0x8f, 0xfb, 0xf1, 0x45, 0xe9, 0xd2, 0x19, 0x7e,
+	0x25, 0x25, 0x29, 0xc8, 0x93, 0x0a, 0x6d, 0xda, 0xfd, 0x38, 0xee, 0x0f, 0x48, 0xe6, 0xd5, 0x1b,
+	0x52, 0xcc, 0x83, 0x38, 0xd2, 0xf6, 0x77, 0x72, 0xe9, 0xba, 0x31, 0xe5, 0x64, 0x37, 0xa1, 0xf1,
+	0x6d, 0xd2, 0xe5, 0x5a, 0xba, 0x98, 0xdc, 0xe9, 0xa7, 0x86, 0x8e, 0x3e, 0xe8, 0xd0, 0xc6, 0xe1,
+	0xd4, 0x38, 0x1a, 0x29, 0x93, 0x7b, 0xbf, 0x08, 0x2f, 0xde, 0xa0, 0x71, 0x48, 0xf8, 0x0e, 0x19,
+	0x32, 0x9f, 0xdc, 0x1d, 0x12, 0xc6, 0x11, 0x82, 0x72, 0x82, 0xf9, 0x8e, 0x65, 0xb4, 0x8c, 0xb5,
+	0x9a, 0x2f, 0xcf, 0xe8, 0x0c, 0x54, 0x18, 0xc7, 0x94, 0x5b, 0xc5, 0x96, 0xb1, 0x56, 0xf2, 0x95,
+	0x80, 0x56, 0xa1, 0x44, 0xa2, 0x9e, 0x55, 0x92, 0x3a, 0x71, 0x14, 0xb1, 0x8c, 0x93, 0xc4, 0x2a,
+	0x4b, 0x95, 0x3c, 0xa3, 0xf7, 0x61, 0x89, 0x07, 0x21, 0x89, 0x87, 0xdc, 0xaa, 0xb4, 0x8c, 0xb5,
+	0xe5, 0xf5, 0x46, 0x5b, 0x95, 0xd4, 0x4e, 0x4b, 0x6a, 0x5f, 0xd1, 0xdd, 0x7a, 0xd5, 0x87, 0x63,
+	0xa7, 0xf0, 0xfd, 0xef, 0x8e, 0xe1, 0xa7, 0x31, 0xe2, 0x6a, 0x89, 0xab, 0x65, 0xca, 0x7a, 0x94,
+	0x80, 0xae, 0x42, 0xbd, 0x8b, 0xbb, 0x3b, 0x41, 0xd4, 0xbf, 0x9e, 0x88, 0x48, 0x66, 0x2d, 0xc9,
+	0xdc, 0xcd, 0x76, 0x6e, 0x2c, 0x1f, 0xce, 0x79, 0x78, 0x65, 0x91, 0xdc, 0x3f, 0x14, 0xe7, 0xde,
+	0x04, 0x2b, 0x8f, 0x01, 0x4b, 0xe2, 0x88, 0x91, 0xab, 0x04, 0xf7, 0x08, 0x45, 0x0d, 0x28, 0x7f,
+	0x8e, 0x43, 0xa2, 0xa0, 0xf0, 0x2a, 0xd3, 0xb1, 0x63, 0x5c, 0xf0, 0xa5, 0x0a, 0x9d, 0x03, 0xf3,
+	0x16, 0x1e, 0x0c, 0x09, 0xb3, 0x8a, 0xad, 0x52, 0x66, 0xd4, 0x4a, 0xf7, 0xc7, 0x22, 0xa0, 0xc5,
+	0xb4, 0xc8, 0x05, 0x73, 0x8b, 0x63, 0x3e, 0x64, 0x3a, 0x25, 0x4c, 0xc7, 0x8e, 0xc9, 0xa4, 0xc6,
+	0xd7, 0x16, 0xf4, 0x11, 0x94, 0xaf, 0x60, 0x8e, 0xad, 0xe2, 0x62, 0x43, 0x59, 0x46, 0xe1, 0xe1,
+	0x9d, 0x15, 0x0d, 0x4d, 0xc7, 0x4e, 0xbd, 0x87, 0x39, 0x3e, 0x1f, 0x87, 0x01, 0x27, 0x61, 0xc2,
+	0x47, 0xbe, 0x8c, 0x47, 0x6f, 0x41, 0x6d, 0x83, 0xd2, 0x98, 0xde, 0x1c, 0x25, 0x44, 0xce, 0xa8,
+	0xe6, 0xbd, 0x34, 0x1d, 0x3b, 0xa7, 0x49, 0xaa, 0xcc, 0x45, 0x64, 0x9e, 0xe8, 0x75, 0xa8, 0x48,
+	0x41, 0xce, 0xb0, 0xe6, 0x9d, 0x9e, 0x8e, 0x9d, 0x53, 0x32, 0x24, 0xe7, 0xae, 0x3c, 0xd0, 0x06,
+	0x2c, 0x29, 0xa0, 0x98, 0x55, 0x69, 0x95, 0xd6, 0x96, 0xd7, 0x5f, 0x3d, 0xba, 0xd8, 0x79, 0x54,
+	0x53, 0xa8, 0xd2, 0x58, 0xf7, 0xbe, 0x01, 0xf5, 0xf9, 0xce, 0x50, 0x1b, 0xc0, 0x27, 0x6c, 0x38,
+	0xe0, 0xb2, 0x78, 0x85, 0x55, 0x7d, 0x3a, 0x76, 0x80, 0xce, 0xb4, 0x7e, 0xce, 0x03, 0x5d, 0x02,
+	0x53, 0x49, 0x72, 0x1a, 0xcb, 0xeb, 0x56, 0xbe, 0x90, 0x2d, 0x1c, 0x26, 0x03, 0xb2, 0xc5, 0x29,
+	0xc1, 0xa1, 0x57, 0xd7, 0x98, 0x99, 0x2a, 0x93, 0xaf, 0xe3, 0xdc, 0x5f, 0x0d, 0x58, 0xc9, 0x3b,
+	0xa2, 0x5d, 0x30, 0x07, 0xb8, 0x43, 0x06, 0x62, 0x54, 0x22, 0xe5, 0xe9, 0x76, 0xba, 0x5f, 0xed,
+	0xcf, 0x84, 0xfe, 0x06, 0x0e, 0xa8, 0xf7, 0xa9, 0xc8, 0xf6, 0xdb, 0xd8, 0x39, 0xd1, 0x7e, 0xaa,
+	0xf8, 0xcb, 0x3d, 0x9c, 0x70, 0x42, 0x45, 0x29, 0x21, 0xe1, 0x34, 0xe8, 0xfa, 0xfa, 0x3e, 0xf4,
+	0x2e, 0x2c, 0x31, 0x59, 0x09, 0xd3, 0xdd, 0xac, 0x66, 0x57, 0xab, 0x12, 0xb3, 0x2e, 0xee, 0xc9,
+	0xe7, 0xe6, 0xa7, 0x01, 0xee, 0x6d, 0xa8, 0x8b, 0x57, 0x4f, 0x7a, 0xb3, 0x27, 0xd7, 0x80, 0xd2,
+	0x1d, 0x32, 0xd2, 0x18, 0x2e, 0x4d, 0xc7, 0x8e, 0x10, 0x7d, 0xf1, 0x47, 0x6c, 0x26, 0xd9, 0xe5,
+	0x24, 0xe2, 0xe9, 0x45, 0x28, 0x0f, 0xdb, 0x86, 0x34, 0x79, 0xa7, 0xf4, 0x55, 0xa9, 0xab, 0x9f,
+	0x1e, 0xdc, 0x9f, 0x0c, 0x30, 0x95, 0x13, 0x72, 0x52, 0x7e, 0x10, 0xd7, 0x94, 0xbc, 0xda, 0x74,
+	0xec, 0x28, 0x45, 0x4a, 0x15, 0x0d, 0x45, 0x15, 0x92, 0x3e, 0x54, 0x15, 0x24, 0xea, 0x29, 0xce,
+	0x68, 0x41, 0x95, 0x53, 0xdc, 0x25, 0xdb, 0x41, 0x4f, 0xbf, 0xb9, 0xf4, 0x81, 0x48, 0xf5, 0xb5,
+	0x1e, 0xfa, 0x00, 0xaa, 0x54, 0xb7, 0xa3, 0x29, 0xe4, 0xcc, 0x02, 0x85, 0x5c, 0x8e, 0x46, 0xde,
+	0xca, 0x74, 0xec, 0xcc, 0x3c, 0xfd, 0xd9, 0xe9, 0x93, 0x72, 0xb5, 0xb4, 0x5a, 0x76, 0xcf, 0x2b,
+	0x68, 0xb2, 0xd5, 0x47, 0x4d, 0xa8, 0xf6, 0x02, 0x86, 0x3b, 0x03, 0xd2, 0x93, 0x85, 0x57, 0xfd,
+	0x99, 0xec, 0xfe, 0x69, 0xc0, 0xb9, 0xec, 0x51, 0x5e, 0x8b, 0x18, 0xc7, 0x11, 0xff, 0x42, 0xa0,
+	0xf3, 0x24, 0x9e, 0x44, 0x50, 0x16, 0xbc, 0xa5, 0x69, 0x52, 0x9e, 0xf3, 0xfc, 0x57, 0x7a, 0x1a,
+	0xfe, 0x2b, 0x3f, 0x99, 0xff, 0x2a, 0xff, 0x92, 0xff, 0x7e, 0x29, 0x82, 0x7d, 0x5c, 0xa3, 0x27,
+	0x60, 0x2d, 0x7f, 0x8e, 0xb5, 0x5e, 0x3b, 0x9a, 0x08, 0xf2, 0xd9, 0xff, 0x57, 0x0c, 0x56, 0x84,
+	0xe6, 0xf1, 0x5d, 0x22, 0x7b, 0x91, 0xcd, 0xe6, 0xd8, 0xeb, 0x3c, 0x98, 0xf7, 0x48, 0x97, 0xc7,
+	0x54, 0xa3, 0x37, 0xb7, 0x86, 0xb7, 0xa4, 0xe5, 0x6a, 0xc1, 0xd7, 0x3e, 0xe8, 0x0d, 0x30, 0x59,
+	0x17, 0x0f, 0x30, 0xd5, 0xcf, 0x69, 0x81, 0x1d, 0x84, 0xaf, 0xf2, 0x40, 0xeb, 0x60, 0x32, 0x4e,
+	0x83, 0xa8, 0x2f, 0xb1, 0x38, 0xcc, 0x8b, 0xd2, 0x92, 0x8b, 0x91, 0xb2, 0xa8, 0x26, 0xc4, 0x9c,
+	0x06, 0xbb, 0x56, 0x65, 0xb1, 0x9a, 0x4d, 0x69, 0x11, 0xde, 0xca, 0xc7, 0xab, 0x82, 0x66, 0x52,
+	0xf7, 0x3d, 0x30, 0x55, 0xad, 0x68, 0x3d, 0x23, 0x30, 0x63, 0x91, 0x57, 0x34, 0x85, 0xa9, 0xd7,
+	0x38, 0x23, 0xae, 0x07, 0x06, 0x98, 0xca, 0x82, 0xa2, 0x7f, 0xc2, 0xbc, 0x97, 0x9f, 0x9a, 0x79,
+	0x67, 0x7c, 0xdb, 0x06, 0x53, 0x55, 0x61, 0x15, 0x8f, 0x06, 0x54, 0xd7, 0xaa, 0xbd, 0xdc, 0x8f,
+	0x61, 0x25, 0x0f, 0x9d, 0xd8, 0x50, 0x49, 0xc3, 0x7a, 0xb2, 0x4a, 0x40, 0xaf, 0xc0, 0x8a, 0x58,
+	0x61, 0xc6, 0x71, 0x98, 0x6c, 0x87, 0x4c, 0x53, 0xc2, 0xf2, 0x4c, 0xb7, 0xc9, 0xdc, 0xeb, 0x60,
+	0x2a, 0x3c, 0xd1, 0x06, 0xd4, 0x55, 0xf2, 0x6d, 0x26, 0x7f, 0x7d, 0xd2, 0xd6, 0x8f, 0xff, 0x1d,
+	0x53, 0x25, 0xbd, 0xc0, 0x72, 0x3a, 0xe6, 0xfe, 0x6c, 0x40, 0x23, 0x7b, 0x87, 0x9b, 0x84, 0x63,
+	0xb1, 0x57, 0xcf, 0xe2, 0xc3, 0xae, 0x09, 0xd5, 0x10, 0xf3, 0xee, 0x8e, 0xd8, 0x94, 0xb2, 0xf8,
+	0xe0, 0xf1, 0x67, 0xf2, 0x33, 0xe4, 0xa2, 0x1f, 0x8a, 0xf9, 0x8f, 0xb1, 0x2d, 0x42, 0x03, 0x72,
+	0xb2, 0x6f, 0xa7, 0xb7, 0x67, 0x2c, 0x24, 0xd0, 0x3b, 0x3b, 0x87, 0x9e, 0xcc, 0x26, 0xac, 0xde,
+	0x8a, 0x66, 0x9d, 0xb2, 0x44, 0xe7, 0x39, 0xe3, 0x9a, 0xaf, 0x01, 0xb2, 0x56, 0xfe, 0xeb, 0x5d,
+	0x71, 0xbf, 0x9d, 0x9b, 0x90, 0x74, 0x39, 0xd9, 0x84, 0x5e, 0xce, 0x4d, 0xa8, 0xe6, 0x55, 0x9f,
+	0xd3, 0x29, 0x78, 0x97, 0xf6, 0xf6, 0xed, 0xc2, 0xa3, 0x7d, 0xbb, 0xf0, 0x78, 0xdf, 0x36, 0xbe,
+	0x99, 0xd8, 0xc6, 0x83, 0x89, 0x6d, 0x3c, 0x9c, 0xd8, 0xc6, 0xde, 0xc4, 0x36, 0xfe, 0x98, 0xd8,
+	0xc6, 0x5f, 0x13, 0xbb, 0xf0, 0x78, 0x62, 0x1b, 0xdf, 0x1d, 0xd8, 0x85, 0xbd, 0x03, 0xbb, 0xf0,
+	0xe8, 0xc0, 0x2e, 0x7c, 0x99, 0xfb, 0x0f, 0xb1, 0x63, 0xca, 0x5f, 0xff, 0x37, 0xff, 0x1e, 0x00,
+	0x99, 0x38, 0x13, 0x3d, 0x48, 0x0e, 0x00, 0x00,
+}
+
+func (this *PrometheusRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PrometheusRequest)
+	if !ok {
+		that2, ok := that.(PrometheusRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= size
-		i = encodeVarintQueryrange(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0x3a
-	if len(m.Query) > 0 {
-		i -= len(m.Query)
-		copy(dAtA[i:], m.Query)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Query)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout):])
-	if err2 != nil {
-		return 0, err2
+	if this.Path != that1.Path {
+		return false
 	}
-	i -= n2
-	i = encodeVarintQueryrange(dAtA, i, uint64(n2))
-	i--
-	dAtA[i] = 0x2a
-	if m.Step != 0 {
-		i = encodeVarintQueryrange(dAtA, i, uint64(m.Step))
-		i--
-		dAtA[i] = 0x20
+	if this.Start != that1.Start {
+		return false
 	}
-	if m.End != 0 {
-		i = encodeVarintQueryrange(dAtA, i, uint64(m.End))
-		i--
-		dAtA[i] = 0x18
+	if this.End != that1.End {
+		return false
 	}
-	if m.Start != 0 {
-		i = encodeVarintQueryrange(dAtA, i, uint64(m.Start))
-		i--
-		dAtA[i] = 0x10
+	if this.Step != that1.Step {
+		return false
 	}
-	if len(m.Path) > 0 {
-		i -= len(m.Path)
-		copy(dAtA[i:], m.Path)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Path)))
-		i--
-		dAtA[i] = 0xa
+	if this.Timeout != that1.Timeout {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *PrometheusResponseHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Query != that1.Query {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PrometheusResponseHeader) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if !this.CachingOptions.Equal(&that1.CachingOptions) {
+		return false
+	}
+	return true
 }
+func (this *PrometheusResponseHeader) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PrometheusResponseHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Values) > 0 {
-		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Values[iNdEx])
-			copy(dAtA[i:], m.Values[iNdEx])
-			i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Values[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*PrometheusResponseHeader)
+	if !ok {
+		that2, ok := that.(PrometheusResponseHeader)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *PrometheusResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Name != that1.Name {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PrometheusResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if len(this.Values) != len(that1.Values) {
+		return false
+	}
+	for i := range this.Values {
+		if this.Values[i] != that1.Values[i] {
+			return false
+		}
+	}
+	return true
 }
+func (this *PrometheusResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PrometheusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Headers) > 0 {
-		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintQueryrange(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
+	that1, ok := that.(*PrometheusResponse)
+	if !ok {
+		that2, ok := that.(PrometheusResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.Error) > 0 {
-		i -= len(m.Error)
-		copy(dAtA[i:], m.Error)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Error)))
-		i--
-		dAtA[i] = 0x22
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.ErrorType) > 0 {
-		i -= len(m.ErrorType)
-		copy(dAtA[i:], m.ErrorType)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ErrorType)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Status != that1.Status {
+		return false
 	}
-	{
-		size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	if !this.Data.Equal(&that1.Data) {
+		return false
 	}
-	i--
-	dAtA[i] = 0x12
-	if len(m.Status) > 0 {
-		i -= len(m.Status)
-		copy(dAtA[i:], m.Status)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Status)))
-		i--
-		dAtA[i] = 0xa
+	if this.ErrorType != that1.ErrorType {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *PrometheusData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Error != that1.Error {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PrometheusData) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *PrometheusData) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PrometheusData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Result) > 0 {
-		for iNdEx := len(m.Result) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Result[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintQueryrange(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*PrometheusData)
+	if !ok {
+		that2, ok := that.(PrometheusData)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.ResultType) > 0 {
-		i -= len(m.ResultType)
-		copy(dAtA[i:], m.ResultType)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ResultType)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
+	if this.ResultType != that1.ResultType {
+		return false
+	}
+	if len(this.Result) != len(that1.Result) {
+		return false
+	}
+	for i := range this.Result {
+		if !this.Result[i].Equal(&that1.Result[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *SampleStream) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *SampleStream) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *SampleStream) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *SampleStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Samples) > 0 {
-		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Samples[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintQueryrange(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*SampleStream)
+	if !ok {
+		that2, ok := that.(SampleStream)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.Labels) > 0 {
-		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size := m.Labels[iNdEx].Size()
-				i -= size
-				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
-					return 0, err
-				}
-				i = encodeVarintQueryrange(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CachedResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(this.Labels) != len(that1.Labels) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CachedResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *CachedResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Extents) > 0 {
-		for iNdEx := len(m.Extents) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Extents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintQueryrange(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
 		}
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+	if len(this.Samples) != len(that1.Samples) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Extent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	for i := range this.Samples {
+		if !this.Samples[i].Equal(&that1.Samples[i]) {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Extent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
+func (this *CachedResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Extent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Response != nil {
-		{
-			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	that1, ok := that.(*CachedResponse)
+	if !ok {
+		that2, ok := that.(CachedResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x2a
 	}
-	if len(m.TraceId) > 0 {
-		i -= len(m.TraceId)
-		copy(dAtA[i:], m.TraceId)
-		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.TraceId)))
-		i--
-		dAtA[i] = 0x22
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.End != 0 {
-		i = encodeVarintQueryrange(dAtA, i, uint64(m.End))
-		i--
-		dAtA[i] = 0x10
+	if this.Key != that1.Key {
+		return false
 	}
-	if m.Start != 0 {
-		i = encodeVarintQueryrange(dAtA, i, uint64(m.Start))
-		i--
-		dAtA[i] = 0x8
+	if len(this.Extents) != len(that1.Extents) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CachingOptions) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	for i := range this.Extents {
+		if !this.Extents[i].Equal(&that1.Extents[i]) {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CachingOptions) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
+func (this *Extent) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CachingOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Disabled {
-		i--
-		if m.Disabled {
-			dAtA[i] = 1
+	that1, ok := that.(*Extent)
+	if !ok {
+		that2, ok := that.(Extent)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x8
 	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintQueryrange(dAtA []byte, offset int, v uint64) int {
-	offset -= sovQueryrange(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *PrometheusRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if this.Start != that1.Start {
+		return false
 	}
-	var l int
-	_ = l
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if this.End != that1.End {
+		return false
 	}
-	if m.Start != 0 {
-		n += 1 + sovQueryrange(uint64(m.Start))
+	if this.TraceId != that1.TraceId {
+		return false
 	}
-	if m.End != 0 {
-		n += 1 + sovQueryrange(uint64(m.End))
+	if !this.Response.Equal(that1.Response) {
+		return false
 	}
-	if m.Step != 0 {
-		n += 1 + sovQueryrange(uint64(m.Step))
+	return true
+}
+func (this *CachingOptions) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
-	n += 1 + l + sovQueryrange(uint64(l))
-	l = len(m.Query)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
-	}
-	l = m.CachingOptions.Size()
-	n += 1 + l + sovQueryrange(uint64(l))
-	return n
-}
 
-func (m *PrometheusResponseHeader) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*CachingOptions)
+	if !ok {
+		that2, ok := that.(CachingOptions)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Values) > 0 {
-		for _, s := range m.Values {
-			l = len(s)
-			n += 1 + l + sovQueryrange(uint64(l))
-		}
+	if this.Disabled != that1.Disabled {
+		return false
 	}
-	return n
+	return true
 }
+func (this *PrometheusInstantQueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PrometheusResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*PrometheusInstantQueryRequest)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.Status)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = m.Data.Size()
-	n += 1 + l + sovQueryrange(uint64(l))
-	l = len(m.ErrorType)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if this.Path != that1.Path {
+		return false
 	}
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if this.Time != that1.Time {
+		return false
 	}
-	if len(m.Headers) > 0 {
-		for _, e := range m.Headers {
-			l = e.Size()
-			n += 1 + l + sovQueryrange(uint64(l))
-		}
+	if this.Timeout != that1.Timeout {
+		return false
 	}
-	return n
+	if this.Query != that1.Query {
+		return false
+	}
+	if !this.CachingOptions.Equal(&that1.CachingOptions) {
+		return false
+	}
+	return true
 }
+func (this *PrometheusInstantQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PrometheusData) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*PrometheusInstantQueryResponse)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.ResultType)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Result) > 0 {
-		for _, e := range m.Result {
-			l = e.Size()
-			n += 1 + l + sovQueryrange(uint64(l))
-		}
+	if this.Status != that1.Status {
+		return false
 	}
-	return n
-}
-
-func (m *SampleStream) Size() (n int) {
-	if m == nil {
-		return 0
+	if !this.Data.Equal(&that1.Data) {
+		return false
 	}
-	var l int
-	_ = l
-	if len(m.Labels) > 0 {
-		for _, e := range m.Labels {
-			l = e.Size()
-			n += 1 + l + sovQueryrange(uint64(l))
-		}
+	if this.ErrorType != that1.ErrorType {
+		return false
 	}
-	if len(m.Samples) > 0 {
-		for _, e := range m.Samples {
-			l = e.Size()
-			n += 1 + l + sovQueryrange(uint64(l))
+	if this.Error != that1.Error {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
 		}
 	}
-	return n
+	return true
 }
+func (this *PrometheusInstantQueryData) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CachedResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*PrometheusInstantQueryData)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryData)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Extents) > 0 {
-		for _, e := range m.Extents {
-			l = e.Size()
-			n += 1 + l + sovQueryrange(uint64(l))
+	if this.ResultType != that1.ResultType {
+		return false
+	}
+	if that1.Result == nil {
+		if this.Result != nil {
+			return false
 		}
+	} else if this.Result == nil {
+		return false
+	} else if !this.Result.Equal(that1.Result) {
+		return false
 	}
-	return n
+	return true
 }
+func (this *PrometheusInstantQueryData_Vector) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Extent) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*PrometheusInstantQueryData_Vector)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryData_Vector)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if m.Start != 0 {
-		n += 1 + sovQueryrange(uint64(m.Start))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.End != 0 {
-		n += 1 + sovQueryrange(uint64(m.End))
+	if !this.Vector.Equal(that1.Vector) {
+		return false
 	}
-	l = len(m.TraceId)
-	if l > 0 {
-		n += 1 + l + sovQueryrange(uint64(l))
+	return true
+}
+func (this *PrometheusInstantQueryData_Scalar) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Response != nil {
-		l = m.Response.Size()
-		n += 1 + l + sovQueryrange(uint64(l))
+
+	that1, ok := that.(*PrometheusInstantQueryData_Scalar)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryData_Scalar)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Scalar.Equal(that1.Scalar) {
+		return false
+	}
+	return true
 }
+func (this *PrometheusInstantQueryData_String_) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CachingOptions) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*PrometheusInstantQueryData_String_)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryData_String_)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if m.Disabled {
-		n += 2
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return n
+	if !this.String_.Equal(that1.String_) {
+		return false
+	}
+	return true
 }
+func (this *PrometheusInstantQueryData_Matrix) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func sovQueryrange(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozQueryrange(x uint64) (n int) {
-	return sovQueryrange(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *PrometheusRequest) String() string {
-	if this == nil {
-		return "nil"
+	that1, ok := that.(*PrometheusInstantQueryData_Matrix)
+	if !ok {
+		that2, ok := that.(PrometheusInstantQueryData_Matrix)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := strings.Join([]string{`&PrometheusRequest{`,
-		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
-		`Start:` + fmt.Sprintf("%v", this.Start) + `,`,
-		`End:` + fmt.Sprintf("%v", this.End) + `,`,
-		`Step:` + fmt.Sprintf("%v", this.Step) + `,`,
-		`Timeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Timeout), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
-		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
-		`CachingOptions:` + strings.Replace(strings.Replace(this.CachingOptions.String(), "CachingOptions", "CachingOptions", 1), `&`, ``, 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *PrometheusResponseHeader) String() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := strings.Join([]string{`&PrometheusResponseHeader{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
-		`}`,
-	}, "")
-	return s
+	if !this.Matrix.Equal(that1.Matrix) {
+		return false
+	}
+	return true
 }
-func (this *PrometheusResponse) String() string {
-	if this == nil {
-		return "nil"
+func (this *Vector) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	repeatedStringForHeaders := "[]*PrometheusResponseHeader{"
-	for _, f := range this.Headers {
-		repeatedStringForHeaders += strings.Replace(f.String(), "PrometheusResponseHeader", "PrometheusResponseHeader", 1) + ","
+
+	that1, ok := that.(*Vector)
+	if !ok {
+		that2, ok := that.(Vector)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	repeatedStringForHeaders += "}"
-	s := strings.Join([]string{`&PrometheusResponse{`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`Data:` + strings.Replace(strings.Replace(this.Data.String(), "PrometheusData", "PrometheusData", 1), `&`, ``, 1) + `,`,
-		`ErrorType:` + fmt.Sprintf("%v", this.ErrorType) + `,`,
-		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
-		`Headers:` + repeatedStringForHeaders + `,`,
-		`}`,
-	}, "")
-	return s
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Samples) != len(that1.Samples) {
+		return false
+	}
+	for i := range this.Samples {
+		if !this.Samples[i].Equal(&that1.Samples[i]) {
+			return false
+		}
+	}
+	return true
 }
-func (this *PrometheusData) String() string {
-	if this == nil {
-		return "nil"
+func (this *Sample) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	repeatedStringForResult := "[]SampleStream{"
-	for _, f := range this.Result {
-		repeatedStringForResult += strings.Replace(strings.Replace(f.String(), "SampleStream", "SampleStream", 1), `&`, ``, 1) + ","
+
+	that1, ok := that.(*Sample)
+	if !ok {
+		that2, ok := that.(Sample)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	repeatedStringForResult += "}"
-	s := strings.Join([]string{`&PrometheusData{`,
-		`ResultType:` + fmt.Sprintf("%v", this.ResultType) + `,`,
-		`Result:` + repeatedStringForResult + `,`,
-		`}`,
-	}, "")
-	return s
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
+	}
+	if !this.Sample.Equal(&that1.Sample) {
+		return false
+	}
+	return true
 }
-func (this *SampleStream) String() string {
-	if this == nil {
-		return "nil"
+func (this *StringSample) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	repeatedStringForSamples := "[]Sample{"
-	for _, f := range this.Samples {
-		repeatedStringForSamples += fmt.Sprintf("%v", f) + ","
+
+	that1, ok := that.(*StringSample)
+	if !ok {
+		that2, ok := that.(StringSample)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	repeatedStringForSamples += "}"
-	s := strings.Join([]string{`&SampleStream{`,
-		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
-		`Samples:` + repeatedStringForSamples + `,`,
-		`}`,
-	}, "")
-	return s
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	if this.TimestampMs != that1.TimestampMs {
+		return false
+	}
+	return true
 }
-func (this *CachedResponse) String() string {
-	if this == nil {
-		return "nil"
+func (this *Matrix) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	repeatedStringForExtents := "[]Extent{"
-	for _, f := range this.Extents {
-		repeatedStringForExtents += strings.Replace(strings.Replace(f.String(), "Extent", "Extent", 1), `&`, ``, 1) + ","
+
+	that1, ok := that.(*Matrix)
+	if !ok {
+		that2, ok := that.(Matrix)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	repeatedStringForExtents += "}"
-	s := strings.Join([]string{`&CachedResponse{`,
-		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
-		`Extents:` + repeatedStringForExtents + `,`,
-		`}`,
-	}, "")
-	return s
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.SampleStreams) != len(that1.SampleStreams) {
+		return false
+	}
+	for i := range this.SampleStreams {
+		if !this.SampleStreams[i].Equal(&that1.SampleStreams[i]) {
+			return false
+		}
+	}
+	return true
 }
-func (this *Extent) String() string {
-	if this == nil {
-		return "nil"
+func (this *PrometheusMetadataRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := strings.Join([]string{`&Extent{`,
-		`Start:` + fmt.Sprintf("%v", this.Start) + `,`,
-		`End:` + fmt.Sprintf("%v", this.End) + `,`,
-		`TraceId:` + fmt.Sprintf("%v", this.TraceId) + `,`,
-		`Response:` + strings.Replace(fmt.Sprintf("%v", this.Response), "Any", "types.Any", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+
+	that1, ok := that.(*PrometheusMetadataRequest)
+	if !ok {
+		that2, ok := that.(PrometheusMetadataRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if this.Start != that1.Start {
+		return false
+	}
+	if this.End != that1.End {
+		return false
+	}
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
+	}
+	for i := range this.Matchers {
+		if this.Matchers[i] != that1.Matchers[i] {
+			return false
+		}
+	}
+	if !this.CachingOptions.Equal(&that1.CachingOptions) {
+		return false
+	}
+	return true
 }
-func (this *CachingOptions) String() string {
-	if this == nil {
-		return "nil"
+func (this *PrometheusSeriesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PrometheusSeriesResponse)
+	if !ok {
+		that2, ok := that.(PrometheusSeriesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if len(this.Data) != len(that1.Data) {
+		return false
+	}
+	for i := range this.Data {
+		if !this.Data[i].Equal(&that1.Data[i]) {
+			return false
+		}
+	}
+	if this.ErrorType != that1.ErrorType {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *SeriesData) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SeriesData)
+	if !ok {
+		that2, ok := that.(SeriesData)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *PrometheusLabelsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PrometheusLabelsResponse)
+	if !ok {
+		that2, ok := that.(PrometheusLabelsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Status != that1.Status {
+		return false
+	}
+	if len(this.Data) != len(that1.Data) {
+		return false
+	}
+	for i := range this.Data {
+		if this.Data[i] != that1.Data[i] {
+			return false
+		}
+	}
+	if this.ErrorType != that1.ErrorType {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	if len(this.Headers) != len(that1.Headers) {
+		return false
+	}
+	for i := range this.Headers {
+		if !this.Headers[i].Equal(that1.Headers[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *PrometheusRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&queryrange.PrometheusRequest{")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
+	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "Step: "+fmt.Sprintf("%#v", this.Step)+",\n")
+	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
+	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
+	s = append(s, "CachingOptions: "+strings.Replace(this.CachingOptions.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusResponseHeader) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.PrometheusResponseHeader{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusResponse{")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "Data: "+strings.Replace(this.Data.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "ErrorType: "+fmt.Sprintf("%#v", this.ErrorType)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	if this.Headers != nil {
+		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusData) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.PrometheusData{")
+	s = append(s, "ResultType: "+fmt.Sprintf("%#v", this.ResultType)+",\n")
+	if this.Result != nil {
+		vs := make([]*SampleStream, len(this.Result))
+		for i := range vs {
+			vs[i] = &this.Result[i]
+		}
+		s = append(s, "Result: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SampleStream) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.SampleStream{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Samples != nil {
+		vs := make([]*cortexpb.Sample, len(this.Samples))
+		for i := range vs {
+			vs[i] = &this.Samples[i]
+		}
+		s = append(s, "Samples: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CachedResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.CachedResponse{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	if this.Extents != nil {
+		vs := make([]*Extent, len(this.Extents))
+		for i := range vs {
+			vs[i] = &this.Extents[i]
+		}
+		s = append(s, "Extents: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Extent) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&queryrange.Extent{")
+	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
+	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "TraceId: "+fmt.Sprintf("%#v", this.TraceId)+",\n")
+	if this.Response != nil {
+		s = append(s, "Response: "+fmt.Sprintf("%#v", this.Response)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CachingOptions) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&queryrange.CachingOptions{")
+	s = append(s, "Disabled: "+fmt.Sprintf("%#v", this.Disabled)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusInstantQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusInstantQueryRequest{")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "Time: "+fmt.Sprintf("%#v", this.Time)+",\n")
+	s = append(s, "Timeout: "+fmt.Sprintf("%#v", this.Timeout)+",\n")
+	s = append(s, "Query: "+fmt.Sprintf("%#v", this.Query)+",\n")
+	s = append(s, "CachingOptions: "+strings.Replace(this.CachingOptions.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusInstantQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusInstantQueryResponse{")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "Data: "+strings.Replace(this.Data.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "ErrorType: "+fmt.Sprintf("%#v", this.ErrorType)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	if this.Headers != nil {
+		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusInstantQueryData) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusInstantQueryData{")
+	s = append(s, "ResultType: "+fmt.Sprintf("%#v", this.ResultType)+",\n")
+	if this.Result != nil {
+		s = append(s, "Result: "+fmt.Sprintf("%#v", this.Result)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusInstantQueryData_Vector) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&queryrange.PrometheusInstantQueryData_Vector{` +
+		`Vector:` + fmt.Sprintf("%#v", this.Vector) + `}`}, ", ")
+	return s
+}
+func (this *PrometheusInstantQueryData_Scalar) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&queryrange.PrometheusInstantQueryData_Scalar{` +
+		`Scalar:` + fmt.Sprintf("%#v", this.Scalar) + `}`}, ", ")
+	return s
+}
+func (this *PrometheusInstantQueryData_String_) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&queryrange.PrometheusInstantQueryData_String_{` +
+		`String_:` + fmt.Sprintf("%#v", this.String_) + `}`}, ", ")
+	return s
+}
+func (this *PrometheusInstantQueryData_Matrix) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&queryrange.PrometheusInstantQueryData_Matrix{` +
+		`Matrix:` + fmt.Sprintf("%#v", this.Matrix) + `}`}, ", ")
+	return s
+}
+func (this *Vector) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&queryrange.Vector{")
+	if this.Samples != nil {
+		vs := make([]*Sample, len(this.Samples))
+		for i := range vs {
+			vs[i] = &this.Samples[i]
+		}
+		s = append(s, "Samples: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Sample) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.Sample{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "Sample: "+strings.Replace(this.Sample.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *StringSample) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&queryrange.StringSample{")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "TimestampMs: "+fmt.Sprintf("%#v", this.TimestampMs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Matrix) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&queryrange.Matrix{")
+	if this.SampleStreams != nil {
+		vs := make([]*SampleStream, len(this.SampleStreams))
+		for i := range vs {
+			vs[i] = &this.SampleStreams[i]
+		}
+		s = append(s, "SampleStreams: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusMetadataRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusMetadataRequest{")
+	s = append(s, "Path: "+fmt.Sprintf("%#v", this.Path)+",\n")
+	s = append(s, "Start: "+fmt.Sprintf("%#v", this.Start)+",\n")
+	s = append(s, "End: "+fmt.Sprintf("%#v", this.End)+",\n")
+	s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	s = append(s, "CachingOptions: "+strings.Replace(this.CachingOptions.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusSeriesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusSeriesResponse{")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	if this.Data != nil {
+		vs := make([]*SeriesData, len(this.Data))
+		for i := range vs {
+			vs[i] = &this.Data[i]
+		}
+		s = append(s, "Data: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "ErrorType: "+fmt.Sprintf("%#v", this.ErrorType)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	if this.Headers != nil {
+		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SeriesData) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&queryrange.SeriesData{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PrometheusLabelsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&queryrange.PrometheusLabelsResponse{")
+	s = append(s, "Status: "+fmt.Sprintf("%#v", this.Status)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "ErrorType: "+fmt.Sprintf("%#v", this.ErrorType)+",\n")
+	s = append(s, "Error: "+fmt.Sprintf("%#v", this.Error)+",\n")
+	if this.Headers != nil {
+		s = append(s, "Headers: "+fmt.Sprintf("%#v", this.Headers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringQueryrange(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *PrometheusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.CachingOptions.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x32
+	}
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintQueryrange(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x2a
+	if m.Step != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.End != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Start != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusResponseHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusResponseHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusResponseHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ErrorType) > 0 {
+		i -= len(m.ErrorType)
+		copy(dAtA[i:], m.ErrorType)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ErrorType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Result) > 0 {
+		for iNdEx := len(m.Result) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Result[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ResultType) > 0 {
+		i -= len(m.ResultType)
+		copy(dAtA[i:], m.ResultType)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ResultType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SampleStream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SampleStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SampleStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Samples) > 0 {
+		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Samples[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CachedResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CachedResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CachedResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Extents) > 0 {
+		for iNdEx := len(m.Extents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Extents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Extent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Extent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Extent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Response != nil {
+		{
+			size, err := m.Response.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.TraceId) > 0 {
+		i -= len(m.TraceId)
+		copy(dAtA[i:], m.TraceId)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.TraceId)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.End != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Start != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CachingOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CachingOptions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CachingOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Disabled {
+		i--
+		if m.Disabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusInstantQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusInstantQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusInstantQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.CachingOptions.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x22
+	}
+	n6, err6 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Timeout, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintQueryrange(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x1a
+	if m.Time != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusInstantQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusInstantQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusInstantQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ErrorType) > 0 {
+		i -= len(m.ErrorType)
+		copy(dAtA[i:], m.ErrorType)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ErrorType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusInstantQueryData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusInstantQueryData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusInstantQueryData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Result != nil {
+		{
+			size := m.Result.Size()
+			i -= size
+			if _, err := m.Result.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if len(m.ResultType) > 0 {
+		i -= len(m.ResultType)
+		copy(dAtA[i:], m.ResultType)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ResultType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusInstantQueryData_Vector) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *PrometheusInstantQueryData_Vector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Vector != nil {
+		{
+			size, err := m.Vector.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PrometheusInstantQueryData_Scalar) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *PrometheusInstantQueryData_Scalar) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Scalar != nil {
+		{
+			size, err := m.Scalar.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PrometheusInstantQueryData_String_) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *PrometheusInstantQueryData_String_) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.String_ != nil {
+		{
+			size, err := m.String_.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *PrometheusInstantQueryData_Matrix) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *PrometheusInstantQueryData_Matrix) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Matrix != nil {
+		{
+			size, err := m.Matrix.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQueryrange(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Vector) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Vector) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Vector) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Samples) > 0 {
+		for iNdEx := len(m.Samples) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Samples[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Sample) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Sample) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Sample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Sample.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StringSample) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StringSample) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StringSample) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TimestampMs != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.TimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Matrix) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Matrix) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Matrix) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.SampleStreams) > 0 {
+		for iNdEx := len(m.SampleStreams) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SampleStreams[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusMetadataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusMetadataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.CachingOptions.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQueryrange(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Matchers[iNdEx])
+			copy(dAtA[i:], m.Matchers[iNdEx])
+			i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Matchers[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.End != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.End))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Start != 0 {
+		i = encodeVarintQueryrange(dAtA, i, uint64(m.Start))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusSeriesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusSeriesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusSeriesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ErrorType) > 0 {
+		i -= len(m.ErrorType)
+		copy(dAtA[i:], m.ErrorType)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ErrorType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Data) > 0 {
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SeriesData) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesData) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SeriesData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrometheusLabelsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrometheusLabelsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrometheusLabelsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQueryrange(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ErrorType) > 0 {
+		i -= len(m.ErrorType)
+		copy(dAtA[i:], m.ErrorType)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.ErrorType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Data) > 0 {
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Data[iNdEx])
+			copy(dAtA[i:], m.Data[iNdEx])
+			i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Data[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintQueryrange(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQueryrange(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQueryrange(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PrometheusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if m.Start != 0 {
+		n += 1 + sovQueryrange(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovQueryrange(uint64(m.End))
+	}
+	if m.Step != 0 {
+		n += 1 + sovQueryrange(uint64(m.Step))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
+	n += 1 + l + sovQueryrange(uint64(l))
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = m.CachingOptions.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	return n
+}
+
+func (m *PrometheusResponseHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PrometheusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	l = len(m.ErrorType)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PrometheusData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ResultType)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Result) > 0 {
+		for _, e := range m.Result {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SampleStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	if len(m.Samples) > 0 {
+		for _, e := range m.Samples {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CachedResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Extents) > 0 {
+		for _, e := range m.Extents {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Extent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Start != 0 {
+		n += 1 + sovQueryrange(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovQueryrange(uint64(m.End))
+	}
+	l = len(m.TraceId)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if m.Response != nil {
+		l = m.Response.Size()
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	return n
+}
+
+func (m *CachingOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Disabled {
+		n += 2
+	}
+	return n
+}
+
+func (m *PrometheusInstantQueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovQueryrange(uint64(m.Time))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Timeout)
+	n += 1 + l + sovQueryrange(uint64(l))
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = m.CachingOptions.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	return n
+}
+
+func (m *PrometheusInstantQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = m.Data.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	l = len(m.ErrorType)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PrometheusInstantQueryData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ResultType)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if m.Result != nil {
+		n += m.Result.Size()
+	}
+	return n
+}
+
+func (m *PrometheusInstantQueryData_Vector) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vector != nil {
+		l = m.Vector.Size()
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	return n
+}
+func (m *PrometheusInstantQueryData_Scalar) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Scalar != nil {
+		l = m.Scalar.Size()
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	return n
+}
+func (m *PrometheusInstantQueryData_String_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.String_ != nil {
+		l = m.String_.Size()
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	return n
+}
+func (m *PrometheusInstantQueryData_Matrix) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Matrix != nil {
+		l = m.Matrix.Size()
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	return n
+}
+func (m *Vector) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Samples) > 0 {
+		for _, e := range m.Samples {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Sample) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	l = m.Sample.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	return n
+}
+
+func (m *StringSample) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if m.TimestampMs != 0 {
+		n += 1 + sovQueryrange(uint64(m.TimestampMs))
+	}
+	return n
+}
+
+func (m *Matrix) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SampleStreams) > 0 {
+		for _, e := range m.SampleStreams {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PrometheusMetadataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if m.Start != 0 {
+		n += 1 + sovQueryrange(uint64(m.Start))
+	}
+	if m.End != 0 {
+		n += 1 + sovQueryrange(uint64(m.End))
+	}
+	if len(m.Matchers) > 0 {
+		for _, s := range m.Matchers {
+			l = len(s)
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	l = m.CachingOptions.Size()
+	n += 1 + l + sovQueryrange(uint64(l))
+	return n
+}
+
+func (m *PrometheusSeriesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	l = len(m.ErrorType)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SeriesData) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PrometheusLabelsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for _, s := range m.Data {
+			l = len(s)
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	l = len(m.ErrorType)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovQueryrange(uint64(l))
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovQueryrange(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovQueryrange(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQueryrange(x uint64) (n int) {
+	return sovQueryrange(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *PrometheusRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusRequest{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Start:` + fmt.Sprintf("%v", this.Start) + `,`,
+		`End:` + fmt.Sprintf("%v", this.End) + `,`,
+		`Step:` + fmt.Sprintf("%v", this.Step) + `,`,
+		`Timeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Timeout), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
+		`CachingOptions:` + strings.Replace(strings.Replace(this.CachingOptions.String(), "CachingOptions", "CachingOptions", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusResponseHeader) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusResponseHeader{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*PrometheusResponseHeader{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "PrometheusResponseHeader", "PrometheusResponseHeader", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&PrometheusResponse{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Data:` + strings.Replace(strings.Replace(this.Data.String(), "PrometheusData", "PrometheusData", 1), `&`, ``, 1) + `,`,
+		`ErrorType:` + fmt.Sprintf("%v", this.ErrorType) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResult := "[]SampleStream{"
+	for _, f := range this.Result {
+		repeatedStringForResult += strings.Replace(strings.Replace(f.String(), "SampleStream", "SampleStream", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForResult += "}"
+	s := strings.Join([]string{`&PrometheusData{`,
+		`ResultType:` + fmt.Sprintf("%v", this.ResultType) + `,`,
+		`Result:` + repeatedStringForResult + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SampleStream) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSamples := "[]Sample{"
+	for _, f := range this.Samples {
+		repeatedStringForSamples += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForSamples += "}"
+	s := strings.Join([]string{`&SampleStream{`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Samples:` + repeatedStringForSamples + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CachedResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForExtents := "[]Extent{"
+	for _, f := range this.Extents {
+		repeatedStringForExtents += strings.Replace(strings.Replace(f.String(), "Extent", "Extent", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForExtents += "}"
+	s := strings.Join([]string{`&CachedResponse{`,
+		`Key:` + fmt.Sprintf("%v", this.Key) + `,`,
+		`Extents:` + repeatedStringForExtents + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Extent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Extent{`,
+		`Start:` + fmt.Sprintf("%v", this.Start) + `,`,
+		`End:` + fmt.Sprintf("%v", this.End) + `,`,
+		`TraceId:` + fmt.Sprintf("%v", this.TraceId) + `,`,
+		`Response:` + strings.Replace(fmt.Sprintf("%v", this.Response), "Any", "types.Any", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CachingOptions) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CachingOptions{`,
+		`Disabled:` + fmt.Sprintf("%v", this.Disabled) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusInstantQueryRequest{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Time:` + fmt.Sprintf("%v", this.Time) + `,`,
+		`Timeout:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Timeout), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`Query:` + fmt.Sprintf("%v", this.Query) + `,`,
+		`CachingOptions:` + strings.Replace(strings.Replace(this.CachingOptions.String(), "CachingOptions", "CachingOptions", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*PrometheusResponseHeader{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "PrometheusResponseHeader", "PrometheusResponseHeader", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&PrometheusInstantQueryResponse{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Data:` + strings.Replace(strings.Replace(this.Data.String(), "PrometheusInstantQueryData", "PrometheusInstantQueryData", 1), `&`, ``, 1) + `,`,
+		`ErrorType:` + fmt.Sprintf("%v", this.ErrorType) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusInstantQueryData{`,
+		`ResultType:` + fmt.Sprintf("%v", this.ResultType) + `,`,
+		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryData_Vector) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusInstantQueryData_Vector{`,
+		`Vector:` + strings.Replace(fmt.Sprintf("%v", this.Vector), "Vector", "Vector", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryData_Scalar) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusInstantQueryData_Scalar{`,
+		`Scalar:` + strings.Replace(fmt.Sprintf("%v", this.Scalar), "Sample", "cortexpb.Sample", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryData_String_) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusInstantQueryData_String_{`,
+		`String_:` + strings.Replace(fmt.Sprintf("%v", this.String_), "StringSample", "StringSample", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusInstantQueryData_Matrix) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusInstantQueryData_Matrix{`,
+		`Matrix:` + strings.Replace(fmt.Sprintf("%v", this.Matrix), "Matrix", "Matrix", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Vector) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSamples := "[]Sample{"
+	for _, f := range this.Samples {
+		repeatedStringForSamples += strings.Replace(strings.Replace(f.String(), "Sample", "Sample", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForSamples += "}"
+	s := strings.Join([]string{`&Vector{`,
+		`Samples:` + repeatedStringForSamples + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Sample) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Sample{`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Sample:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Sample), "Sample", "cortexpb.Sample", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StringSample) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StringSample{`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`TimestampMs:` + fmt.Sprintf("%v", this.TimestampMs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Matrix) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSampleStreams := "[]SampleStream{"
+	for _, f := range this.SampleStreams {
+		repeatedStringForSampleStreams += strings.Replace(strings.Replace(f.String(), "SampleStream", "SampleStream", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForSampleStreams += "}"
+	s := strings.Join([]string{`&Matrix{`,
+		`SampleStreams:` + repeatedStringForSampleStreams + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusMetadataRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PrometheusMetadataRequest{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`Start:` + fmt.Sprintf("%v", this.Start) + `,`,
+		`End:` + fmt.Sprintf("%v", this.End) + `,`,
+		`Matchers:` + fmt.Sprintf("%v", this.Matchers) + `,`,
+		`CachingOptions:` + strings.Replace(strings.Replace(this.CachingOptions.String(), "CachingOptions", "CachingOptions", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusSeriesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForData := "[]SeriesData{"
+	for _, f := range this.Data {
+		repeatedStringForData += strings.Replace(strings.Replace(f.String(), "SeriesData", "SeriesData", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForData += "}"
+	repeatedStringForHeaders := "[]*PrometheusResponseHeader{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "PrometheusResponseHeader", "PrometheusResponseHeader", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&PrometheusSeriesResponse{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Data:` + repeatedStringForData + `,`,
+		`ErrorType:` + fmt.Sprintf("%v", this.ErrorType) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SeriesData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SeriesData{`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PrometheusLabelsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForHeaders := "[]*PrometheusResponseHeader{"
+	for _, f := range this.Headers {
+		repeatedStringForHeaders += strings.Replace(f.String(), "PrometheusResponseHeader", "PrometheusResponseHeader", 1) + ","
+	}
+	repeatedStringForHeaders += "}"
+	s := strings.Join([]string{`&PrometheusLabelsResponse{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`ErrorType:` + fmt.Sprintf("%v", this.ErrorType) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`Headers:` + repeatedStringForHeaders + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringQueryrange(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrometheusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrometheusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CachingOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CachingOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrometheusResponseHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrometheusResponseHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrometheusResponseHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrometheusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrometheusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &PrometheusResponseHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrometheusData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrometheusData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrometheusData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResultType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Result = append(m.Result, SampleStream{})
+			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SampleStream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SampleStream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SampleStream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, github_com_cortexproject_cortex_pkg_cortexpb.LabelAdapter{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, cortexpb.Sample{})
+			if err := m.Samples[len(m.Samples)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CachedResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CachedResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CachedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extents = append(m.Extents, Extent{})
+			if err := m.Extents[len(m.Extents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Extent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Extent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Extent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Response == nil {
+				m.Response = &types.Any{}
+			}
+			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CachingOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CachingOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CachingOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Disabled = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrometheusInstantQueryRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrometheusInstantQueryRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrometheusInstantQueryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CachingOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CachingOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrometheusInstantQueryResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrometheusInstantQueryResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrometheusInstantQueryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &PrometheusResponseHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&CachingOptions{`,
-		`Disabled:` + fmt.Sprintf("%v", this.Disabled) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringQueryrange(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
+func (m *PrometheusInstantQueryData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1713,15 +5846,15 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PrometheusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrometheusInstantQueryData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PrometheusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrometheusInstantQueryData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1749,13 +5882,13 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.ResultType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vector", wireType)
 			}
-			m.Start = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -1765,16 +5898,32 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Start |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Vector{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &PrometheusInstantQueryData_Vector{v}
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scalar", wireType)
 			}
-			m.End = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -1784,16 +5933,32 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.End |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &cortexpb.Sample{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &PrometheusInstantQueryData_Scalar{v}
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field String_", wireType)
 			}
-			m.Step = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -1803,14 +5968,30 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Step |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StringSample{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Result = &PrometheusInstantQueryData_String_{v}
+			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Matrix", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1837,15 +6018,157 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Timeout, dAtA[iNdEx:postIndex]); err != nil {
+			v := &Matrix{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Result = &PrometheusInstantQueryData_Matrix{v}
 			iNdEx = postIndex
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Vector) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Vector: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Vector: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, Sample{})
+			if err := m.Samples[len(m.Samples)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Sample) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Sample: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Sample: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -1855,27 +6178,29 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthQueryrange
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthQueryrange
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Query = string(dAtA[iNdEx:postIndex])
+			m.Labels = append(m.Labels, github_com_cortexproject_cortex_pkg_cortexpb.LabelAdapter{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CachingOptions", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sample", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1902,7 +6227,7 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CachingOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Sample.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1930,7 +6255,7 @@ func (m *PrometheusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PrometheusResponseHeader) Unmarshal(dAtA []byte) error {
+func (m *StringSample) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1953,15 +6278,15 @@ func (m *PrometheusResponseHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PrometheusResponseHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: StringSample: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PrometheusResponseHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StringSample: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1989,13 +6314,85 @@ func (m *PrometheusResponseHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Value = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampMs", wireType)
+			}
+			m.TimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQueryrange(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Matrix) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQueryrange
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Matrix: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Matrix: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleStreams", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -2005,23 +6402,25 @@ func (m *PrometheusResponseHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthQueryrange
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthQueryrange
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			m.SampleStreams = append(m.SampleStreams, SampleStream{})
+			if err := m.SampleStreams[len(m.SampleStreams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2047,7 +6446,7 @@ func (m *PrometheusResponseHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
+func (m *PrometheusMetadataRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2070,15 +6469,15 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PrometheusResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrometheusMetadataRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PrometheusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrometheusMetadataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2106,13 +6505,13 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = string(dAtA[iNdEx:postIndex])
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var msglen int
+			m.Start = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -2122,30 +6521,16 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Start |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ErrorType", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			var stringLen uint64
+			m.End = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -2155,27 +6540,14 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.End |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ErrorType = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2203,11 +6575,11 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			m.Matchers = append(m.Matchers, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CachingOptions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2234,8 +6606,7 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Headers = append(m.Headers, &PrometheusResponseHeader{})
-			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CachingOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2263,7 +6634,7 @@ func (m *PrometheusResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PrometheusData) Unmarshal(dAtA []byte) error {
+func (m *PrometheusSeriesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2286,15 +6657,113 @@ func (m *PrometheusData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PrometheusData: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrometheusSeriesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PrometheusData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrometheusSeriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ResultType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, SeriesData{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQueryrange
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2322,11 +6791,11 @@ func (m *PrometheusData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ResultType = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2353,8 +6822,8 @@ func (m *PrometheusData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Result = append(m.Result, SampleStream{})
-			if err := m.Result[len(m.Result)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, &PrometheusResponseHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2382,7 +6851,7 @@ func (m *PrometheusData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SampleStream) Unmarshal(dAtA []byte) error {
+func (m *SeriesData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2405,10 +6874,10 @@ func (m *SampleStream) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SampleStream: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeriesData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SampleStream: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeriesData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2445,40 +6914,6 @@ func (m *SampleStream) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQueryrange
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Samples = append(m.Samples, cortexpb.Sample{})
-			if err := m.Samples[len(m.Samples)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQueryrange(dAtA[iNdEx:])
@@ -2503,7 +6938,7 @@ func (m *SampleStream) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CachedResponse) Unmarshal(dAtA []byte) error {
+func (m *PrometheusLabelsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2526,15 +6961,15 @@ func (m *CachedResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CachedResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrometheusLabelsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CachedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrometheusLabelsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2562,13 +6997,13 @@ func (m *CachedResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
+			m.Status = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extents", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -2578,84 +7013,29 @@ func (m *CachedResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthQueryrange
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthQueryrange
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Extents = append(m.Extents, Extent{})
-			if err := m.Extents[len(m.Extents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Data = append(m.Data, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipQueryrange(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Extent) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowQueryrange
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Extent: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Extent: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorType", wireType)
 			}
-			m.Start = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQueryrange
@@ -2665,33 +7045,27 @@ func (m *Extent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Start |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQueryrange
 			}
-			m.End = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQueryrange
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.End |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQueryrange
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.ErrorType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TraceId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2719,11 +7093,11 @@ func (m *Extent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TraceId = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2750,10 +7124,8 @@ func (m *Extent) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Response == nil {
-				m.Response = &types.Any{}
-			}
-			if err := m.Response.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, &PrometheusResponseHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2781,79 +7153,6 @@ func (m *Extent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CachingOptions) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowQueryrange
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CachingOptions: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CachingOptions: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Disabled", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQueryrange
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Disabled = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipQueryrange(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthQueryrange
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
 func skipQueryrange(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -63,6 +63,15 @@ func (confs ShardingConfigs) GetConf(r Request) (chunk.PeriodConfig, error) {
 	return conf, nil
 }
 
+// getShards implements shardsResolver.
+func (confs ShardingConfigs) getShards(r Request) (int, error) {
+	conf, err := confs.GetConf(r)
+	if err != nil {
+		return 0, err
+	}
+	return int(conf.RowShards), nil
+}
+
 func (confs ShardingConfigs) hasShards() bool {
 	for _, conf := range confs {
 		if conf.RowShards > 0 {
@@ -72,6 +81,24 @@ func (confs ShardingConfigs) hasShards() bool {
 	return false
 }
 
+// shardsResolver returns the number of shards a request should be split into,
+// or an error if the request can't be sharded.
+type shardsResolver interface {
+	getShards(r Request) (int, error)
+}
+
+// staticShards is a shardsResolver splitting every request into the same number of shards.
+// It's used by the blocks storage, whose series are sharded by the hash of their labels at
+// query time regardless of the time range.
+type staticShards int
+
+func (shards staticShards) getShards(_ Request) (int, error) {
+	if shards < 2 {
+		return 0, errors.Errorf("shard factor not high enough: [%d]", shards)
+	}
+	return int(shards), nil
+}
+
 func mapQuery(mapper astmapper.ASTMapper, query string) (parser.Node, error) {
 	expr, err := parser.ParseExpr(query)
 	if err != nil {
@@ -108,7 +135,7 @@ func NewQueryShardMiddleware(
 
 	shardingware := MiddlewareFunc(func(next Handler) Handler {
 		return &queryShard{
-			confs:  confs,
+			shards: confs,
 			next:   next,
 			engine: engine,
 		}
@@ -130,8 +157,41 @@ func NewQueryShardMiddleware(
 
 }
 
+// NewBlocksQueryShardMiddleware creates a middleware which downstreams queries after AST mapping and
+// query encoding, splitting each shardable query into totalShards sub-queries. Unlike the chunks storage,
+// the blocks storage doesn't shard series at write time, so queriers select the series belonging to
+// each shard by the hash of their labels. Because of this, queries can be sharded regardless of their
+// time range, including the part of it served by ingesters.
+func NewBlocksQueryShardMiddleware(
+	logger log.Logger,
+	engine *promql.Engine,
+	totalShards int,
+	metrics *InstrumentMiddlewareMetrics,
+	registerer prometheus.Registerer,
+) Middleware {
+	shards := staticShards(totalShards)
+
+	mapperware := MiddlewareFunc(func(next Handler) Handler {
+		return newASTMapperware(shards, next, logger, registerer)
+	})
+
+	shardingware := MiddlewareFunc(func(next Handler) Handler {
+		return &queryShard{
+			shards: shards,
+			next:   next,
+			engine: engine,
+		}
+	})
+
+	return MergeMiddlewares(
+		InstrumentMiddleware("shardingware", metrics),
+		mapperware,
+		shardingware,
+	)
+}
+
 type astMapperware struct {
-	confs  ShardingConfigs
+	shards shardsResolver
 	logger log.Logger
 	next   Handler
 
@@ -141,9 +201,9 @@ type astMapperware struct {
 	shardedQueriesCounter prometheus.Counter
 }
 
-func newASTMapperware(confs ShardingConfigs, next Handler, logger log.Logger, registerer prometheus.Registerer) *astMapperware {
+func newASTMapperware(shards shardsResolver, next Handler, logger log.Logger, registerer prometheus.Registerer) *astMapperware {
 	return &astMapperware{
-		confs:      confs,
+		shards:     shards,
 		logger:     log.With(logger, "middleware", "QueryShard.astMapperware"),
 		next:       next,
 		registerer: registerer,
@@ -161,14 +221,14 @@ func newASTMapperware(confs ShardingConfigs, next Handler, logger log.Logger, re
 }
 
 func (ast *astMapperware) Do(ctx context.Context, r Request) (Response, error) {
-	conf, err := ast.confs.GetConf(r)
+	shards, err := ast.shards.getShards(r)
 	// cannot shard with this timerange
 	if err != nil {
 		level.Warn(ast.logger).Log("err", err.Error(), "msg", "skipped AST mapper for request")
 		return ast.next.Do(ctx, r)
 	}
 
-	shardSummer, err := astmapper.NewShardSummer(int(conf.RowShards), astmapper.VectorSquasher, ast.shardedQueriesCounter)
+	shardSummer, err := astmapper.NewShardSummer(shards, astmapper.VectorSquasher, ast.shardedQueriesCounter)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +257,7 @@ func (ast *astMapperware) Do(ctx context.Context, r Request) (Response, error) {
 }
 
 type queryShard struct {
-	confs  ShardingConfigs
+	shards shardsResolver
 	next   Handler
 	engine *promql.Engine
 }
@@ -205,7 +265,7 @@ type queryShard struct {
 func (qs *queryShard) Do(ctx context.Context, r Request) (Response, error) {
 	// since there's no available sharding configuration for this time range,
 	// no astmapping has been performed, so skip this middleware.
-	if _, err := qs.confs.GetConf(r); err != nil {
+	if _, err := qs.shards.getShards(r); err != nil {
 		return qs.next.Do(ctx, r)
 	}
 
@@ -362,7 +362,32 @@ func TestQueryshardingCorrectness(t *testing.T) {
 			mapped: `histogram_quantile(0.5, sum by(le) (__embedded_queries__{__cortex_queries__="{\"Concat\":[\"sum by(le, __cortex_shard__) (rate(cortex_cache_value_size_bytes_bucket{__cortex_shard__=\\\"0_of_2\\\"}[5m]))\",\"sum by(le, __cortex_shard__) (rate(cortex_cache_value_size_bytes_bucket{__cortex_shard__=\\\"1_of_2\\\"}[5m]))\"]}"}))`,
 		},
 		{
-			desc: "ensure sharding sub aggregations are skipped to avoid non-associative series merging across shards",
+			desc:   "count is sharded and summed up",
+			query:  `count by (bar) (bar1{baz="blip"})`,
+			mapped: `sum by(bar) (__embedded_queries__{__cortex_queries__="{\"Concat\":[\"count by(bar, __cortex_shard__) (bar1{__cortex_shard__=\\\"0_of_2\\\",baz=\\\"blip\\\"})\",\"count by(bar, __cortex_shard__) (bar1{__cortex_shard__=\\\"1_of_2\\\",baz=\\\"blip\\\"})\"]}"})`,
+		},
+		{
+			desc:   "max is sharded",
+			query:  `max without (foo) (rate(bar1{baz="blip"}[1m]))`,
+			mapped: `max without(__cortex_shard__) (__embedded_queries__{__cortex_queries__="{\"Concat\":[\"max without(foo) (rate(bar1{__cortex_shard__=\\\"0_of_2\\\",baz=\\\"blip\\\"}[1m]))\",\"max without(foo) (rate(bar1{__cortex_shard__=\\\"1_of_2\\\",baz=\\\"blip\\\"}[1m]))\"]}"})`,
+		},
+		{
+			desc:   "avg is sharded as sum divided by count",
+			query:  `avg by (bar) (rate(bar1{baz="blip"}[1m]))`,
+			mapped: `(sum by(bar) (__embedded_queries__{__cortex_queries__="{\"Concat\":[\"sum by(bar, __cortex_shard__) (rate(bar1{__cortex_shard__=\\\"0_of_2\\\",baz=\\\"blip\\\"}[1m]))\",\"sum by(bar, __cortex_shard__) (rate(bar1{__cortex_shard__=\\\"1_of_2\\\",baz=\\\"blip\\\"}[1m]))\"]}"}) / sum by(bar) (__embedded_queries__{__cortex_queries__="{\"Concat\":[\"count by(bar, __cortex_shard__) (rate(bar1{__cortex_shard__=\\\"0_of_2\\\",baz=\\\"blip\\\"}[1m]))\",\"count by(bar, __cortex_shard__) (rate(bar1{__cortex_shard__=\\\"1_of_2\\\",baz=\\\"blip\\\"}[1m]))\"]}"}))`,
+		},
+		{
+			desc:   "topk is sharded",
+			query:  `topk by (bar) (1, rate(bar1{baz="blip"}[1m]))`,
+			mapped: `label_replace(topk by(bar) (1, __embedded_queries__{__cortex_queries__="{\"Concat\":[\"topk by(bar) (1, rate(bar1{__cortex_shard__=\\\"0_of_2\\\",baz=\\\"blip\\\"}[1m]))\",\"topk by(bar) (1, rate(bar1{__cortex_shard__=\\\"1_of_2\\\",baz=\\\"blip\\\"}[1m]))\"]}"}), "__cortex_shard__", "", "", "")`,
+		},
+		{
+			desc:   "bottomk without is sharded",
+			query:  `bottomk without (foo) (1, bar1{baz="blip"})`,
+			mapped: `label_replace(bottomk without(foo, __cortex_shard__) (1, __embedded_queries__{__cortex_queries__="{\"Concat\":[\"bottomk without(foo) (1, bar1{__cortex_shard__=\\\"0_of_2\\\",baz=\\\"blip\\\"})\",\"bottomk without(foo) (1, bar1{__cortex_shard__=\\\"1_of_2\\\",baz=\\\"blip\\\"})\"]}"}), "__cortex_shard__", "", "", "")`,
+		},
+		{
+			desc: "ensure only the innermost aggregation is sharded to avoid non-associative series merging across shards",
 			query: `sum(
 				  count(
 				    count(
@@ -370,7 +395,7 @@ func TestQueryshardingCorrectness(t *testing.T) {
 				    )  by (drive,instance)
 				  )  by (instance)
 				)`,
-			mapped: `__embedded_queries__{__cortex_queries__="{\"Concat\":[\"sum(count by(instance) (count by(drive, instance) (bar1)))\"]}"}`,
+			mapped: `sum(count by(instance) (sum by(drive, instance) (__embedded_queries__{__cortex_queries__="{\"Concat\":[\"count by(drive, instance, __cortex_shard__) (bar1{__cortex_shard__=\\\"0_of_2\\\"})\",\"count by(drive, instance, __cortex_shard__) (bar1{__cortex_shard__=\\\"1_of_2\\\"})\"]}"})))`,
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -424,6 +449,41 @@ func TestQueryshardingCorrectness(t *testing.T) {
 	}
 }
 
+func TestBlocksQueryShardMiddleware(t *testing.T) {
+	req := &PrometheusRequest{
+		Path:  "/query_range",
+		Start: util.TimeToMillis(start),
+		End:   util.TimeToMillis(end),
+		Step:  int64(step) / int64(time.Second),
+	}
+
+	for _, query := range []string{
+		`sum by (bar) (rate(bar1{baz="blip"}[1m]))`,
+		`count without (foo) (bar1{baz="blip"})`,
+		`avg(rate(bar1{baz="blip"}[1m]))`,
+		`topk(2, rate(bar1{baz="blip"}[1m]))`,
+		`histogram_quantile(0.5, rate(bar1{baz="blip"}[30s]))`,
+	} {
+		for _, totalShards := range []int{1, 2, 3} {
+			t.Run(fmt.Sprintf("query: %s, shards: %d", query, totalShards), func(t *testing.T) {
+				downstream := &downstreamHandler{
+					engine:    engine,
+					queryable: shardAwareQueryable,
+				}
+
+				r := req.WithQuery(query)
+				shardedRes, err := NewBlocksQueryShardMiddleware(log.NewNopLogger(), engine, totalShards, nil, nil).Wrap(downstream).Do(context.Background(), r)
+				require.Nil(t, err)
+
+				res, err := downstream.Do(context.Background(), r)
+				require.Nil(t, err)
+
+				approximatelyEquals(t, res.(*PrometheusResponse), shardedRes.(*PrometheusResponse))
+			})
+		}
+	}
+}
+
 func TestShardSplitting(t *testing.T) {
 
 	for _, tc := range []struct {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/chunk/cache"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
 	"github.com/cortexproject/cortex/pkg/tenant"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 	"github.com/cortexproject/cortex/pkg/util/spanlogger"
@@ -156,10 +157,20 @@ func NewResultsCacheMiddleware(
 	shouldCache ShouldCacheFn,
 	reg prometheus.Registerer,
 ) (Middleware, cache.Cache, error) {
-	c, err := cache.New(cfg.CacheConfig, reg, logger)
+	c, err := newResultsCache(logger, cfg, cacheGenNumberLoader, reg)
 	if err != nil {
 		return nil, nil, err
 	}
+
+	return newResultsCacheMiddleware(logger, cfg, c, splitter, limits, merger, extractor, cacheGenNumberLoader, shouldCache), c, nil
+}
+
+// newResultsCache creates the cache used to store the query results from config.
+func newResultsCache(logger log.Logger, cfg ResultsCacheConfig, cacheGenNumberLoader CacheGenNumberLoader, reg prometheus.Registerer) (cache.Cache, error) {
+	c, err := cache.New(cfg.CacheConfig, reg, logger)
+	if err != nil {
+		return nil, err
+	}
 	if cfg.Compression == "snappy" {
 		c = cache.NewSnappy(c, logger)
 	}
@@ -167,7 +178,22 @@ func NewResultsCacheMiddleware(
 	if cacheGenNumberLoader != nil {
 		c = cache.NewCacheGenNumMiddleware(c)
 	}
+	return c, nil
+}
 
+// newResultsCacheMiddleware creates results cache middleware storing the results in the
+// given cache, which allows different middlewares to share the same cache.
+func newResultsCacheMiddleware(
+	logger log.Logger,
+	cfg ResultsCacheConfig,
+	c cache.Cache,
+	splitter CacheSplitter,
+	limits Limits,
+	merger Merger,
+	extractor Extractor,
+	cacheGenNumberLoader CacheGenNumberLoader,
+	shouldCache ShouldCacheFn,
+) Middleware {
 	return MiddlewareFunc(func(next Handler) Handler {
 		return &resultsCache{
 			logger:               logger,
@@ -182,7 +208,7 @@ func NewResultsCacheMiddleware(
 			cacheGenNumberLoader: cacheGenNumberLoader,
 			shouldCache:          shouldCache,
 		}
-	}), c, nil
+	})
 }
 
 func (s resultsCache) Do(ctx context.Context, r Request) (Response, error) {
@@ -231,19 +257,24 @@ func (s resultsCache) Do(ctx context.Context, r Request) (Response, error) {
 
 // shouldCacheResponse says whether the response should be cached or not.
 func (s resultsCache) shouldCacheResponse(ctx context.Context, req Request, r Response, maxCacheTime int64) bool {
+	if !s.isAtModifierCachable(req, maxCacheTime) {
+		return false
+	}
+
+	return isResponseCachable(ctx, r, s.cacheGenNumberLoader, s.logger)
+}
+
+// isResponseCachable says whether the response can be cached according to its headers.
+func isResponseCachable(ctx context.Context, r Response, cacheGenNumberLoader CacheGenNumberLoader, logger log.Logger) bool {
 	headerValues := getHeaderValuesWithName(r, cacheControlHeader)
 	for _, v := range headerValues {
 		if v == noStoreValue {
-			level.Debug(s.logger).Log("msg", fmt.Sprintf("%s header in response is equal to %s, not caching the response", cacheControlHeader, noStoreValue))
+			level.Debug(logger).Log("msg", fmt.Sprintf("%s header in response is equal to %s, not caching the response", cacheControlHeader, noStoreValue))
 			return false
 		}
 	}
 
-	if !s.isAtModifierCachable(req, maxCacheTime) {
-		return false
-	}
-
-	if s.cacheGenNumberLoader == nil {
+	if cacheGenNumberLoader == nil {
 		return true
 	}
 
@@ -251,13 +282,13 @@ func (s resultsCache) shouldCacheResponse(ctx context.Context, req Request, r Re
 	genNumberFromCtx := cache.ExtractCacheGenNumber(ctx)
 
 	if len(genNumbersFromResp) == 0 && genNumberFromCtx != "" {
-		level.Debug(s.logger).Log("msg", fmt.Sprintf("we found results cache gen number %s set in store but none in headers", genNumberFromCtx))
+		level.Debug(logger).Log("msg", fmt.Sprintf("we found results cache gen number %s set in store but none in headers", genNumberFromCtx))
 		return false
 	}
 
 	for _, gen := range genNumbersFromResp {
 		if gen != genNumberFromCtx {
-			level.Debug(s.logger).Log("msg", fmt.Sprintf("inconsistency in results cache gen numbers %s (GEN-FROM-RESPONSE) != %s (GEN-FROM-STORE), not caching the response", gen, genNumberFromCtx))
+			level.Debug(logger).Log("msg", fmt.Sprintf("inconsistency in results cache gen numbers %s (GEN-FROM-RESPONSE) != %s (GEN-FROM-STORE), not caching the response", gen, genNumberFromCtx))
 			return false
 		}
 	}
@@ -362,6 +393,9 @@ func (s resultsCache) handleHit(ctx context.Context, r Request, extents []Extent
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(responses) > 0 {
+		stats.FromContext(ctx).AddResultsCacheHits(1)
+	}
 	if len(requests) == 0 {
 		response, err := s.merger.MergeResponse(responses...)
 		// No downstream requests so no need to write back to the cache.
@@ -524,7 +558,11 @@ func (s resultsCache) partition(req Request, extents []Extent) ([]Request, []Res
 }
 
 func (s resultsCache) filterRecentExtents(req Request, maxCacheFreshness time.Duration, extents []Extent) ([]Extent, error) {
-	maxCacheTime := (int64(model.Now().Add(-maxCacheFreshness)) / req.GetStep()) * req.GetStep()
+	maxCacheTime := int64(model.Now().Add(-maxCacheFreshness))
+	// Instant queries have no step.
+	if step := req.GetStep(); step > 0 {
+		maxCacheTime = (maxCacheTime / step) * step
+	}
 	for i := range extents {
 		// Never cache data for the latest freshness period.
 		if extents[i].End > maxCacheTime {
@@ -573,6 +573,51 @@ func TestResultsCache(t *testing.T) {
 	require.Equal(t, 2, calls)
 }
 
+func TestResultsCacheInstantQuery(t *testing.T) {
+	calls := 0
+	rcm := newResultsCacheMiddleware(
+		log.NewNopLogger(),
+		ResultsCacheConfig{},
+		cache.NewMockCache(),
+		instantQueryCacheSplitter{},
+		mockLimits{},
+		InstantQueryCodec,
+		InstantQueryResponseExtractor{},
+		nil,
+		nil,
+	)
+
+	response := &PrometheusInstantQueryResponse{
+		Status: StatusSuccess,
+		Data: PrometheusInstantQueryData{
+			ResultType: "scalar",
+			Result:     &PrometheusInstantQueryData_Scalar{Scalar: &client.Sample{TimestampMs: 1536673680000, Value: 1}},
+		},
+	}
+	rc := rcm.Wrap(HandlerFunc(func(_ context.Context, req Request) (Response, error) {
+		calls++
+		return response, nil
+	}))
+	ctx := user.InjectOrgID(context.Background(), "1")
+	req := &PrometheusInstantQueryRequest{Query: "1", Time: 1536673680000}
+
+	resp, err := rc.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Equal(t, response, resp)
+
+	// Doing same request again shouldn't change anything.
+	resp, err = rc.Do(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Equal(t, response, resp)
+
+	// Doing request at a different time should do one more query.
+	_, err = rc.Do(ctx, req.WithStartEnd(0, req.GetEnd()+100))
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
+
 func TestResultsCacheRecent(t *testing.T) {
 	var cfg ResultsCacheConfig
 	flagext.DefaultValues(&cfg)
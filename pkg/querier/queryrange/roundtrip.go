@@ -19,6 +19,7 @@ import (
 	"context"
 	"flag"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,7 +47,9 @@ var (
 		return next
 	})
 
-	errInvalidMinShardingLookback = errors.New("a non-zero value is required for querier.query-ingesters-within when -querier.parallelise-shardable-queries is enabled")
+	errInvalidMinShardingLookback   = errors.New("a non-zero value is required for querier.query-ingesters-within when -querier.parallelise-shardable-queries is enabled")
+	errInvalidTotalShards           = errors.New("a value greater than 1 is required for -querier.query-sharding-total-shards when -querier.parallelise-shardable-queries is enabled with the blocks storage")
+	errInvalidInstantQueryCacheStep = errors.New("a positive value is required for -querier.instant-query-cache-step when -querier.cache-instant-query-results is enabled")
 )
 
 // Config for query_range middleware chain.
@@ -57,6 +60,17 @@ type Config struct {
 	CacheResults           bool `yaml:"cache_results"`
 	MaxRetries             int  `yaml:"max_retries"`
 	ShardedQueries         bool `yaml:"parallelise_shardable_queries"`
+	TotalShards            int  `yaml:"query_sharding_total_shards"`
+
+	SplitInstantQueriesByInterval time.Duration `yaml:"split_instant_queries_by_interval"`
+	CacheInstantQueryResults      bool          `yaml:"cache_instant_query_results"`
+	InstantQueryCacheStep         time.Duration `yaml:"instant_query_cache_step"`
+
+	SplitMetadataQueriesByInterval time.Duration `yaml:"split_metadata_queries_by_interval"`
+	CacheMetadataQueryResults      bool          `yaml:"cache_metadata_query_results"`
+
+	// Injected internally.
+	BlocksStorageEnabled bool `yaml:"-"`
 }
 
 // RegisterFlags adds the flags required to config this to the given FlagSet.
@@ -65,7 +79,13 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.SplitQueriesByInterval, "querier.split-queries-by-interval", 0, "Split queries by an interval and execute in parallel, 0 disables it. You should use an a multiple of 24 hours (same as the storage bucketing scheme), to avoid queriers downloading and processing the same chunks. This also determines how cache keys are chosen when result caching is enabled")
 	f.BoolVar(&cfg.AlignQueriesWithStep, "querier.align-querier-with-step", false, "Mutate incoming queries to align their start and end with their step.")
 	f.BoolVar(&cfg.CacheResults, "querier.cache-results", false, "Cache query results.")
-	f.BoolVar(&cfg.ShardedQueries, "querier.parallelise-shardable-queries", false, "Perform query parallelisations based on storage sharding configuration and query ASTs. This feature is supported only by the chunks storage engine.")
+	f.BoolVar(&cfg.ShardedQueries, "querier.parallelise-shardable-queries", false, "Perform query parallelisations based on storage sharding configuration and query ASTs. When running the blocks storage, queries are split into the number of shards configured via -querier.query-sharding-total-shards.")
+	f.IntVar(&cfg.TotalShards, "querier.query-sharding-total-shards", 16, "The number of shards each shardable query is split into when query parallelisation is enabled. This option is supported only by the blocks storage engine, while the chunks storage uses the shards configured in the schema.")
+	f.DurationVar(&cfg.SplitInstantQueriesByInterval, "querier.split-instant-queries-by-interval", 0, "Split the range vector selectors of instant queries longer than the interval and execute them in parallel, 0 disables it. Only sum_over_time, count_over_time, min_over_time, max_over_time and avg_over_time are split.")
+	f.BoolVar(&cfg.CacheInstantQueryResults, "querier.cache-instant-query-results", false, "Cache instant query results.")
+	f.DurationVar(&cfg.InstantQueryCacheStep, "querier.instant-query-cache-step", time.Minute, "When caching instant query results, the evaluation time of instant queries older than the max cache freshness is aligned to this step, so that queries issued at slightly different times hit the same cache entry.")
+	f.DurationVar(&cfg.SplitMetadataQueriesByInterval, "querier.split-metadata-queries-by-interval", 0, "Split the series, label names and label values requests by an interval and execute in parallel, 0 disables it. The requests not specifying both the start and end time are not split. This also determines the time range of the cached results when metadata results caching is enabled.")
+	f.BoolVar(&cfg.CacheMetadataQueryResults, "querier.cache-metadata-query-results", false, "Cache the results of the series, label names and label values requests. Only the results of the split requests selecting a whole interval are cached.")
 	cfg.ResultsCacheConfig.RegisterFlags(f)
 }
 
@@ -79,6 +99,22 @@ func (cfg *Config) Validate() error {
 			return errors.Wrap(err, "invalid ResultsCache config")
 		}
 	}
+	if cfg.CacheMetadataQueryResults {
+		if cfg.SplitMetadataQueriesByInterval <= 0 {
+			return errors.New("querier.cache-metadata-query-results may only be enabled in conjunction with querier.split-metadata-queries-by-interval. Please set the latter")
+		}
+		if err := cfg.ResultsCacheConfig.Validate(); err != nil {
+			return errors.Wrap(err, "invalid ResultsCache config")
+		}
+	}
+	if cfg.CacheInstantQueryResults {
+		if cfg.InstantQueryCacheStep <= 0 {
+			return errInvalidInstantQueryCacheStep
+		}
+		if err := cfg.ResultsCacheConfig.Validate(); err != nil {
+			return errors.Wrap(err, "invalid ResultsCache config")
+		}
+	}
 	return nil
 }
 
@@ -168,42 +204,99 @@ func NewTripperware(
 		queryRangeMiddleware = append(queryRangeMiddleware, InstrumentMiddleware("split_by_interval", metrics), SplitByIntervalMiddleware(staticIntervalFn, limits, codec, registerer))
 	}
 
+	// The results cache is shared between range, instant and metadata queries.
 	var c cache.Cache
-	if cfg.CacheResults {
-		shouldCache := func(r Request) bool {
-			return !r.GetCachingOptions().Disabled
-		}
-		queryCacheMiddleware, cache, err := NewResultsCacheMiddleware(log, cfg.ResultsCacheConfig, constSplitter(cfg.SplitQueriesByInterval), limits, codec, cacheExtractor, cacheGenNumberLoader, shouldCache, registerer)
+	if cfg.CacheResults || cfg.CacheInstantQueryResults || cfg.CacheMetadataQueryResults {
+		var err error
+		c, err = newResultsCache(log, cfg.ResultsCacheConfig, cacheGenNumberLoader, registerer)
 		if err != nil {
 			return nil, nil, err
 		}
-		c = cache
+	}
+
+	shouldCache := func(r Request) bool {
+		return !r.GetCachingOptions().Disabled
+	}
+	if cfg.CacheResults {
+		queryCacheMiddleware := newResultsCacheMiddleware(log, cfg.ResultsCacheConfig, c, constSplitter(cfg.SplitQueriesByInterval), limits, codec, cacheExtractor, cacheGenNumberLoader, shouldCache)
 		queryRangeMiddleware = append(queryRangeMiddleware, InstrumentMiddleware("results_cache", metrics), queryCacheMiddleware)
 	}
 
+	// The PromQL engine is shared between the middlewares, since each engine registers its own metrics.
+	var engine *promql.Engine
+	if cfg.ShardedQueries || cfg.SplitInstantQueriesByInterval > 0 {
+		engine = promql.NewEngine(engineOpts)
+	}
+
 	if cfg.ShardedQueries {
-		if minShardingLookback == 0 {
-			return nil, nil, errInvalidMinShardingLookback
+		var shardingware Middleware
+
+		if cfg.BlocksStorageEnabled {
+			if cfg.TotalShards < 2 {
+				return nil, nil, errInvalidTotalShards
+			}
+
+			shardingware = NewBlocksQueryShardMiddleware(
+				log,
+				engine,
+				cfg.TotalShards,
+				metrics,
+				registerer,
+			)
+		} else {
+			if minShardingLookback == 0 {
+				return nil, nil, errInvalidMinShardingLookback
+			}
+
+			shardingware = NewQueryShardMiddleware(
+				log,
+				engine,
+				schema.Configs,
+				codec,
+				minShardingLookback,
+				metrics,
+				registerer,
+			)
 		}
 
-		shardingware := NewQueryShardMiddleware(
-			log,
-			promql.NewEngine(engineOpts),
-			schema.Configs,
-			codec,
-			minShardingLookback,
-			metrics,
-			registerer,
-		)
-
 		queryRangeMiddleware = append(
 			queryRangeMiddleware,
 			shardingware, // instrumentation is included in the sharding middleware
 		)
 	}
 
+	var retryMiddlewareMetrics *RetryMiddlewareMetrics
 	if cfg.MaxRetries > 0 {
-		queryRangeMiddleware = append(queryRangeMiddleware, InstrumentMiddleware("retry", metrics), NewRetryMiddleware(log, cfg.MaxRetries, NewRetryMiddlewareMetrics(registerer)))
+		retryMiddlewareMetrics = NewRetryMiddlewareMetrics(registerer)
+		queryRangeMiddleware = append(queryRangeMiddleware, InstrumentMiddleware("retry", metrics), NewRetryMiddleware(log, cfg.MaxRetries, retryMiddlewareMetrics))
+	}
+
+	instantQueryMiddleware := []Middleware{NewInstantQueryLimitsMiddleware(limits)}
+	if cfg.CacheInstantQueryResults {
+		queryCacheMiddleware := newResultsCacheMiddleware(log, cfg.ResultsCacheConfig, c, instantQueryCacheSplitter{}, limits, InstantQueryCodec, InstantQueryResponseExtractor{}, cacheGenNumberLoader, shouldCache)
+		instantQueryMiddleware = append(
+			instantQueryMiddleware,
+			InstrumentMiddleware("instant_query_step_align", metrics), InstantQueryStepAlignMiddleware(cfg.InstantQueryCacheStep, limits),
+			InstrumentMiddleware("instant_query_results_cache", metrics), queryCacheMiddleware,
+		)
+	}
+	if cfg.SplitInstantQueriesByInterval > 0 {
+		instantQueryMiddleware = append(instantQueryMiddleware, InstrumentMiddleware("split_instant_query_by_interval", metrics), SplitInstantQueryByIntervalMiddleware(cfg.SplitInstantQueriesByInterval, engine, log, registerer))
+	}
+	if cfg.MaxRetries > 0 {
+		instantQueryMiddleware = append(instantQueryMiddleware, InstrumentMiddleware("instant_query_retry", metrics), NewRetryMiddleware(log, cfg.MaxRetries, retryMiddlewareMetrics))
+	}
+
+	var metadataQueryMiddleware []Middleware
+	if cfg.SplitMetadataQueriesByInterval > 0 {
+		metadataQueryMiddleware = append(metadataQueryMiddleware, InstrumentMiddleware("split_metadata_query_by_interval", metrics), SplitMetadataQueryByIntervalMiddleware(cfg.SplitMetadataQueriesByInterval, limits, MetadataQueryCodec, registerer))
+
+		if cfg.CacheMetadataQueryResults {
+			metadataQueryMiddleware = append(metadataQueryMiddleware, InstrumentMiddleware("metadata_query_results_cache", metrics), newMetadataResultsCacheMiddleware(log, c, cfg.SplitMetadataQueriesByInterval, limits, cacheGenNumberLoader, shouldCache))
+		}
+		if cfg.MaxRetries > 0 {
+			metadataQueryMiddleware = append(metadataQueryMiddleware, InstrumentMiddleware("metadata_query_retry", metrics), NewRetryMiddleware(log, cfg.MaxRetries, retryMiddlewareMetrics))
+		}
 	}
 
 	// Start cleanup. If cleaner stops or fail, we will simply not clean the metrics for inactive users.
@@ -212,6 +305,8 @@ func NewTripperware(
 		// Finally, if the user selected any query range middleware, stitch it in.
 		if len(queryRangeMiddleware) > 0 {
 			queryrange := NewRoundTripper(next, codec, queryRangeMiddleware...)
+			instantQuery := NewRoundTripper(next, InstantQueryCodec, instantQueryMiddleware...)
+			metadataQuery := NewRoundTripper(next, MetadataQueryCodec, metadataQueryMiddleware...)
 			return RoundTripFunc(func(r *http.Request) (*http.Response, error) {
 				isQueryRange := strings.HasSuffix(r.URL.Path, "/query_range")
 				op := "query"
@@ -228,10 +323,16 @@ func NewTripperware(
 				activeUsers.UpdateUserTimestamp(userStr, time.Now())
 				queriesPerTenant.WithLabelValues(op, userStr).Inc()
 
-				if !isQueryRange {
-					return next.RoundTrip(r)
+				if isQueryRange {
+					return queryrange.RoundTrip(r)
+				}
+				if strings.HasSuffix(r.URL.Path, "/query") {
+					return instantQuery.RoundTrip(r)
+				}
+				if len(metadataQueryMiddleware) > 0 && IsMetadataQuery(r) {
+					return metadataQuery.RoundTrip(r)
 				}
-				return queryrange.RoundTrip(r)
+				return next.RoundTrip(r)
 			})
 		}
 		return next
@@ -285,11 +386,30 @@ func (q roundTripper) Do(ctx context.Context, r Request) (Response, error) {
 		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
 	}
 
+	// Each sub-query can fetch up to the chunk bytes left to the query.
+	budget := chunkBytesBudgetFromContext(ctx)
+	if budget != nil {
+		remaining := budget.remaining()
+		if remaining <= 0 {
+			return nil, budget.limitError()
+		}
+		request.Header.Set(ChunkBytesBudgetHeaderName, strconv.Itoa(remaining))
+	}
+
 	response, err := q.next.RoundTrip(request)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = response.Body.Close() }()
 
-	return q.codec.DecodeResponse(ctx, response, r)
+	resp, err := q.codec.DecodeResponse(ctx, response, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail fast if the sub-queries completed so far have exceeded the budget.
+	if budget != nil && budget.remaining() < 0 {
+		return nil, budget.limitError()
+	}
+	return resp, nil
 }
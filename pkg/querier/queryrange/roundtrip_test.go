@@ -20,14 +20,22 @@ import (
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 )
 
+const (
+	instantQuery             = "/api/v1/query?query=foo&time=1536673680"
+	instantQueryResponseBody = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"foo","bar":"baz"},"value":[1536673680,"137"]}]}}`
+)
+
 func TestRoundTrip(t *testing.T) {
 	s := httptest.NewServer(
 		middleware.AuthenticateUser.Wrap(
 			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				var err error
-				if r.RequestURI == query {
+				switch r.RequestURI {
+				case query:
 					_, err = w.Write([]byte(responseBody))
-				} else {
+				case instantQuery:
+					_, err = w.Write([]byte(instantQueryResponseBody))
+				default:
 					_, err = w.Write([]byte("bar"))
 				}
 				if err != nil {
@@ -72,6 +80,7 @@ func TestRoundTrip(t *testing.T) {
 	}{
 		{"/foo", "bar"},
 		{query, responseBody},
+		{instantQuery, instantQueryResponseBody},
 	} {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			req, err := http.NewRequest("GET", tc.path, http.NoBody)
@@ -123,3 +132,20 @@ func Test_ShardingConfigError(t *testing.T) {
 
 	require.EqualError(t, err, errInvalidMinShardingLookback.Error())
 }
+
+func Test_BlocksShardingConfigError(t *testing.T) {
+	_, _, err := NewTripperware(
+		Config{ShardedQueries: true, BlocksStorageEnabled: true, TotalShards: 1},
+		log.NewNopLogger(),
+		nil,
+		nil,
+		nil,
+		chunk.SchemaConfig{},
+		promql.EngineOpts{},
+		0,
+		nil,
+		nil,
+	)
+
+	require.EqualError(t, err, errInvalidTotalShards.Error())
+}
@@ -0,0 +1,94 @@
+package queryrange
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/cortexproject/cortex/pkg/querier/astmapper"
+	"github.com/cortexproject/cortex/pkg/querier/lazyquery"
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+// SplitInstantQueryByIntervalMiddleware creates a new Middleware that splits the range vector
+// selectors of instant queries longer than the interval into sub-queries, each one selecting a
+// different time range. The sub-queries are executed in parallel and their results recombined
+// by the PromQL engine.
+func SplitInstantQueryByIntervalMiddleware(interval time.Duration, engine *promql.Engine, logger log.Logger, registerer prometheus.Registerer) Middleware {
+	splitQueriesCounter := promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+		Namespace: "cortex",
+		Name:      "frontend_split_instant_queries_total",
+		Help:      "Total number of underlying instant query requests after the split by interval is applied",
+	})
+
+	return MiddlewareFunc(func(next Handler) Handler {
+		return splitInstantQueryByInterval{
+			next:           next,
+			interval:       interval,
+			engine:         engine,
+			logger:         log.With(logger, "middleware", "SplitInstantQueryByInterval"),
+			splitByCounter: splitQueriesCounter,
+		}
+	})
+}
+
+type splitInstantQueryByInterval struct {
+	next     Handler
+	interval time.Duration
+	engine   *promql.Engine
+	logger   log.Logger
+
+	// Metrics.
+	splitByCounter prometheus.Counter
+}
+
+func (s splitInstantQueryByInterval) Do(ctx context.Context, r Request) (Response, error) {
+	mapper, err := astmapper.NewInstantSplitter(s.interval, astmapper.VectorSquasher, s.splitByCounter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Queries which can't be parsed are passed through, so that the querier returns the parsing error.
+	expr, err := parser.ParseExpr(r.GetQuery())
+	if err != nil {
+		return s.next.Do(ctx, r)
+	}
+
+	original := expr.String()
+	mapped, err := mapper.Map(expr)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "failed to split instant query", "query", r.GetQuery(), "err", err)
+		return s.next.Do(ctx, r)
+	}
+
+	mappedQuery := mapped.String()
+	if mappedQuery == original {
+		return s.next.Do(ctx, r)
+	}
+	level.Debug(s.logger).Log("msg", "split instant query", "original", r.GetQuery(), "mapped", mappedQuery)
+
+	queryable := &ShardedQueryable{Req: r, Handler: s.next}
+
+	qry, err := s.engine.NewInstantQuery(lazyquery.NewLazyQueryable(queryable), mappedQuery, util.TimeFromMillis(r.GetStart()))
+	if err != nil {
+		return nil, err
+	}
+	defer qry.Close()
+
+	data, err := instantQueryDataFromResult(qry.Exec(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusInstantQueryResponse{
+		Status:  StatusSuccess,
+		Data:    data,
+		Headers: queryable.getResponseHeaders(),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package queryrange
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+func TestSplitInstantQueryByIntervalMiddleware(t *testing.T) {
+	for _, tc := range []struct {
+		query          string
+		expectedSplits int
+	}{
+		{query: `sum_over_time(bar1{baz="blip"}[3m])`, expectedSplits: 3},
+		{query: `count_over_time(bar1{baz="blip"}[3m])`, expectedSplits: 3},
+		{query: `min_over_time(bar1{baz="blip"}[3m])`, expectedSplits: 3},
+		{query: `max_over_time(bar1{baz="blip"}[3m])`, expectedSplits: 3},
+		{query: `avg_over_time(bar1{baz="blip"}[3m])`, expectedSplits: 6},
+		{query: `sum by(bar) (sum_over_time(bar1{baz="blip"}[150s]))`, expectedSplits: 3},
+		{query: `sum_over_time(bar1{baz="blip"}[2m] offset 30s)`, expectedSplits: 2},
+		// Not splittable.
+		{query: `sum_over_time(bar1{baz="blip"}[1m])`, expectedSplits: 0},
+		{query: `rate(bar1{baz="blip"}[3m])`, expectedSplits: 0},
+		{query: `max_over_time(sum_over_time(bar1{baz="blip"}[2m])[1m:30s])`, expectedSplits: 0},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			req := &PrometheusInstantQueryRequest{
+				Path:  "/query",
+				Query: tc.query,
+				Time:  util.TimeToMillis(end),
+			}
+
+			downstream := &instantQueryDownstreamHandler{
+				engine:    engine,
+				queryable: shardAwareQueryable,
+			}
+
+			reg := prometheus.NewPedanticRegistry()
+			splitware := SplitInstantQueryByIntervalMiddleware(time.Minute, engine, log.NewNopLogger(), reg)
+
+			splitRes, err := splitware.Wrap(downstream).Do(context.Background(), req)
+			require.NoError(t, err)
+
+			res, err := downstream.Do(context.Background(), req)
+			require.NoError(t, err)
+
+			// The samples of instant vectors are not sorted by the engine.
+			expected, err := InstantQueryCodec.MergeResponse(res, NewEmptyPrometheusInstantQueryResponse())
+			require.NoError(t, err)
+			actual, err := InstantQueryCodec.MergeResponse(splitRes, NewEmptyPrometheusInstantQueryResponse())
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+			require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(fmt.Sprintf(`
+				# HELP cortex_frontend_split_instant_queries_total Total number of underlying instant query requests after the split by interval is applied
+				# TYPE cortex_frontend_split_instant_queries_total counter
+				cortex_frontend_split_instant_queries_total %d
+			`, tc.expectedSplits)), "cortex_frontend_split_instant_queries_total"))
+		})
+	}
+}
+
+type instantQueryDownstreamHandler struct {
+	engine    *promql.Engine
+	queryable storage.Queryable
+}
+
+func (h *instantQueryDownstreamHandler) Do(ctx context.Context, r Request) (Response, error) {
+	qry, err := h.engine.NewInstantQuery(h.queryable, r.GetQuery(), util.TimeFromMillis(r.GetStart()))
+	if err != nil {
+		return nil, err
+	}
+	defer qry.Close()
+
+	data, err := instantQueryDataFromResult(qry.Exec(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusInstantQueryResponse{
+		Status: StatusSuccess,
+		Data:   data,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package queryrange
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// maxMetadataQuerySplits is the max number of requests a metadata request is split into.
+// Requests selecting a longer time range, like the ones without a start time, are not split.
+const maxMetadataQuerySplits = 1000
+
+// SplitMetadataQueryByIntervalMiddleware creates a new Middleware that splits the metadata
+// requests by a given interval, aligning the split requests to the interval boundaries.
+func SplitMetadataQueryByIntervalMiddleware(interval time.Duration, limits Limits, merger Merger, registerer prometheus.Registerer) Middleware {
+	splitQueriesCounter := promauto.With(registerer).NewCounter(prometheus.CounterOpts{
+		Namespace: "cortex",
+		Name:      "frontend_split_metadata_queries_total",
+		Help:      "Total number of underlying metadata query requests after the split by interval is applied",
+	})
+
+	return MiddlewareFunc(func(next Handler) Handler {
+		return splitMetadataQueryByInterval{
+			next:           next,
+			limits:         limits,
+			merger:         merger,
+			interval:       interval,
+			splitByCounter: splitQueriesCounter,
+		}
+	})
+}
+
+type splitMetadataQueryByInterval struct {
+	next     Handler
+	limits   Limits
+	merger   Merger
+	interval time.Duration
+
+	// Metrics.
+	splitByCounter prometheus.Counter
+}
+
+func (s splitMetadataQueryByInterval) Do(ctx context.Context, r Request) (Response, error) {
+	if req, ok := r.(*PrometheusMetadataRequest); !ok || !req.isBounded() {
+		return s.next.Do(ctx, r)
+	}
+
+	reqs := splitMetadataQuery(r, s.interval)
+	if len(reqs) > maxMetadataQuerySplits {
+		return s.next.Do(ctx, r)
+	}
+	s.splitByCounter.Add(float64(len(reqs)))
+
+	reqResps, err := DoRequests(ctx, s.next, reqs, s.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	resps := make([]Response, 0, len(reqResps))
+	for _, reqResp := range reqResps {
+		resps = append(resps, reqResp.Response)
+	}
+
+	return s.merger.MergeResponse(resps...)
+}
+
+// splitMetadataQuery splits the request into requests not crossing the interval boundaries.
+// The start and end of metadata requests are both inclusive, so each split request ends 1ms
+// before the next interval boundary.
+func splitMetadataQuery(r Request, interval time.Duration) []Request {
+	msPerInterval := interval.Milliseconds()
+
+	var reqs []Request
+	for start := r.GetStart(); start <= r.GetEnd(); {
+		next := ((start / msPerInterval) + 1) * msPerInterval
+		end := next - 1
+		if end > r.GetEnd() {
+			end = r.GetEnd()
+		}
+
+		reqs = append(reqs, r.WithStartEnd(start, end))
+		start = next
+
+		if len(reqs) > maxMetadataQuerySplits {
+			break
+		}
+	}
+	return reqs
+}
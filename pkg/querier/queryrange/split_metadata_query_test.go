@@ -0,0 +1,108 @@
+package queryrange
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+)
+
+func TestSplitMetadataQuery(t *testing.T) {
+	const hour = int64(time.Hour / time.Millisecond)
+
+	for i, tc := range []struct {
+		start, end int64
+		expected   [][2]int64
+	}{
+		{
+			start:    0,
+			end:      hour - 1,
+			expected: [][2]int64{{0, hour - 1}},
+		},
+		{
+			start:    hour / 2,
+			end:      hour / 2,
+			expected: [][2]int64{{hour / 2, hour / 2}},
+		},
+		{
+			start:    0,
+			end:      hour,
+			expected: [][2]int64{{0, hour - 1}, {hour, hour}},
+		},
+		{
+			start:    hour / 2,
+			end:      2*hour + hour/2,
+			expected: [][2]int64{{hour / 2, hour - 1}, {hour, 2*hour - 1}, {2 * hour, 2*hour + hour/2}},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			req := &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: tc.start, End: tc.end}
+
+			var actual [][2]int64
+			for _, r := range splitMetadataQuery(req, time.Hour) {
+				actual = append(actual, [2]int64{r.GetStart(), r.GetEnd()})
+			}
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSplitMetadataQueryByIntervalMiddleware(t *testing.T) {
+	const hour = int64(time.Hour / time.Millisecond)
+
+	for _, tc := range []struct {
+		name             string
+		req              *PrometheusMetadataRequest
+		expectedRequests int
+		expected         Response
+	}{
+		{
+			name:             "should split and merge a request selecting multiple intervals",
+			req:              &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: hour / 2, End: 3*hour - 1},
+			expectedRequests: 3,
+			expected:         &PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"0", "1", "2", "common"}},
+		},
+		{
+			name:             "should not split a request without start",
+			req:              &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: metadataMinTime, End: 3*hour - 1},
+			expectedRequests: 1,
+			expected:         &PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"common", strconv.FormatInt(metadataMinTime/hour, 10)}},
+		},
+		{
+			name:             "should not split a request selecting too many intervals",
+			req:              &PrometheusMetadataRequest{Path: "/api/v1/labels", Start: 0, End: (maxMetadataQuerySplits + 1) * hour},
+			expectedRequests: 1,
+			expected:         &PrometheusLabelsResponse{Status: StatusSuccess, Data: []string{"common", "0"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				mtx      sync.Mutex
+				requests int
+			)
+			next := HandlerFunc(func(_ context.Context, r Request) (Response, error) {
+				mtx.Lock()
+				requests++
+				mtx.Unlock()
+
+				// Each interval returns the label "common" and a label named after the interval.
+				return &PrometheusLabelsResponse{
+					Status: StatusSuccess,
+					Data:   []string{"common", strconv.FormatInt(r.GetStart()/hour, 10)},
+				}, nil
+			})
+
+			splitware := SplitMetadataQueryByIntervalMiddleware(time.Hour, mockLimits{}, MetadataQueryCodec, nil)
+
+			ctx := user.InjectOrgID(context.Background(), "1")
+			res, err := splitware.Wrap(next).Do(ctx, tc.req)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res)
+			require.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
@@ -2,6 +2,14 @@ package queryrange
 
 import (
 	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/tenant"
+	"github.com/cortexproject/cortex/pkg/util/validation"
 )
 
 // StepAlignMiddleware aligns the start and end of request to the step to
@@ -21,3 +29,38 @@ func (s stepAlign) Do(ctx context.Context, r Request) (Response, error) {
 	end := (r.GetEnd() / r.GetStep()) * r.GetStep()
 	return s.next.Do(ctx, r.WithStartEnd(start, end))
 }
+
+// InstantQueryStepAlignMiddleware aligns the evaluation time of instant queries to the
+// step, to improve the cacheability of the query results. The evaluation time is not
+// aligned when the query can't be cached anyway, because more recent than the max cache
+// freshness, so that the most recent results are not delayed.
+func InstantQueryStepAlignMiddleware(step time.Duration, limits Limits) Middleware {
+	return MiddlewareFunc(func(next Handler) Handler {
+		return instantQueryStepAlign{
+			next:   next,
+			step:   step.Milliseconds(),
+			limits: limits,
+		}
+	})
+}
+
+type instantQueryStepAlign struct {
+	next   Handler
+	step   int64
+	limits Limits
+}
+
+func (s instantQueryStepAlign) Do(ctx context.Context, r Request) (Response, error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, httpgrpc.Errorf(http.StatusBadRequest, err.Error())
+	}
+
+	maxCacheFreshness := validation.MaxDurationPerTenant(tenantIDs, s.limits.MaxCacheFreshness)
+	maxCacheTime := int64(model.Now().Add(-maxCacheFreshness))
+
+	if aligned := (r.GetEnd() / s.step) * s.step; aligned <= maxCacheTime {
+		r = r.WithStartEnd(aligned, aligned)
+	}
+	return s.next.Do(ctx, r)
+}
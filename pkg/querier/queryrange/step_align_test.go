@@ -4,8 +4,12 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/util"
 )
 
 func TestStepAlign(t *testing.T) {
@@ -52,3 +56,38 @@ func TestStepAlign(t *testing.T) {
 		})
 	}
 }
+
+func TestInstantQueryStepAlign(t *testing.T) {
+	now := time.Now()
+	nowMillis := util.TimeToMillis(now)
+	oneHourAgoMillis := util.TimeToMillis(now.Add(-time.Hour))
+
+	for i, tc := range []struct {
+		input, expected *PrometheusInstantQueryRequest
+	}{
+		// Queries older than the max cache freshness are aligned to the step.
+		{
+			input:    &PrometheusInstantQueryRequest{Time: oneHourAgoMillis},
+			expected: &PrometheusInstantQueryRequest{Time: oneHourAgoMillis - oneHourAgoMillis%time.Minute.Milliseconds()},
+		},
+		// Queries more recent than the max cache freshness are not aligned.
+		{
+			input:    &PrometheusInstantQueryRequest{Time: nowMillis},
+			expected: &PrometheusInstantQueryRequest{Time: nowMillis},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var result *PrometheusInstantQueryRequest
+			next := HandlerFunc(func(_ context.Context, req Request) (Response, error) {
+				result = req.(*PrometheusInstantQueryRequest)
+				return nil, nil
+			})
+
+			ctx := user.InjectOrgID(context.Background(), "test")
+			s := InstantQueryStepAlignMiddleware(time.Minute, mockLimits{maxCacheFreshness: 10 * time.Minute}).Wrap(next)
+			_, err := s.Do(ctx, tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
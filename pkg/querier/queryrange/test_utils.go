@@ -10,8 +10,8 @@ import (
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/storage"
 
-	"github.com/cortexproject/cortex/pkg/querier/astmapper"
 	"github.com/cortexproject/cortex/pkg/querier/series"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 // genLabels will create a slice of labels where each label has an equal chance to occupy a value from [0,labelBuckets]. It returns a slice of length labelBuckets^len(labelSet)
@@ -87,7 +87,7 @@ func (q *MockShardedQueryable) Querier(ctx context.Context, mint, maxt int64) (s
 func (q *MockShardedQueryable) Select(_ bool, _ *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
 	tStart := time.Now()
 
-	shard, _, err := astmapper.ShardFromMatchers(matchers)
+	shard, _, err := sharding.ShardFromMatchers(matchers)
 	if err != nil {
 		return storage.ErrSeriesSet(err)
 	}
@@ -146,7 +146,7 @@ func (q *MockShardedQueryable) Select(_ bool, _ *storage.SelectHints, matchers .
 
 // ShardLabelSeries allows extending a Series with new labels. This is helpful for adding cortex shard labels
 type ShardLabelSeries struct {
-	shard *astmapper.ShardAnnotation
+	shard *sharding.ShardAnnotation
 	name  string
 	storage.Series
 }
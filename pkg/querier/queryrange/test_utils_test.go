@@ -8,7 +8,7 @@ import (
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/stretchr/testify/require"
 
-	"github.com/cortexproject/cortex/pkg/querier/astmapper"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 func TestGenLabelsCorrectness(t *testing.T) {
@@ -110,8 +110,8 @@ func TestNewMockShardedqueryable(t *testing.T) {
 
 			set := q.Select(false, nil, &labels.Matcher{
 				Type: labels.MatchEqual,
-				Name: astmapper.ShardLabel,
-				Value: astmapper.ShardAnnotation{
+				Name: sharding.ShardLabel,
+				Value: sharding.ShardAnnotation{
 					Shard: i,
 					Of:    tc.shards,
 				}.String(),
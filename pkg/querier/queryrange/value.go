@@ -81,6 +81,10 @@ func mapPoints(pts ...promql.Point) []client.Sample {
 
 // ResponseToSamples is needed to map back from api response to the underlying series data
 func ResponseToSamples(resp Response) ([]SampleStream, error) {
+	if instantRes, ok := resp.(*PrometheusInstantQueryResponse); ok {
+		return instantQueryResponseToSamples(instantRes)
+	}
+
 	promRes, ok := resp.(*PrometheusResponse)
 	if !ok {
 		return nil, errors.Errorf("error invalid response type: %T, expected: %T", resp, &PrometheusResponse{})
@@ -101,6 +105,33 @@ func ResponseToSamples(resp Response) ([]SampleStream, error) {
 	)
 }
 
+func instantQueryResponseToSamples(resp *PrometheusInstantQueryResponse) ([]SampleStream, error) {
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	switch r := resp.Data.Result.(type) {
+	case *PrometheusInstantQueryData_Vector:
+		streams := make([]SampleStream, 0, len(r.Vector.Samples))
+		for _, sample := range r.Vector.Samples {
+			streams = append(streams, SampleStream{
+				Labels:  sample.Labels,
+				Samples: []client.Sample{sample.Sample},
+			})
+		}
+		return streams, nil
+	case *PrometheusInstantQueryData_Matrix:
+		return r.Matrix.SampleStreams, nil
+	}
+
+	return nil, errors.Errorf(
+		"Invalid promql.Value type: [%s]. Only %s and %s supported",
+		resp.Data.ResultType,
+		parser.ValueTypeVector,
+		parser.ValueTypeMatrix,
+	)
+}
+
 // NewSeriesSet returns an in memory storage.SeriesSet from a []SampleStream
 // As NewSeriesSet uses NewConcreteSeriesSet to implement SeriesSet, result will be sorted by label names.
 func NewSeriesSet(results []SampleStream) storage.SeriesSet {
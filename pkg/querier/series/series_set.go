@@ -17,6 +17,7 @@
 package series
 
 import (
+	"math"
 	"sort"
 
 	"github.com/prometheus/common/model"
@@ -26,6 +27,8 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/chunk/purger"
 	"github.com/cortexproject/cortex/pkg/prom1/storage/metric"
+	"github.com/cortexproject/cortex/pkg/querier/stats"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 // ConcreteSeriesSet implements storage.SeriesSet.
@@ -383,3 +386,112 @@ func (s seriesSetWithWarnings) Err() error {
 func (s seriesSetWithWarnings) Warnings() storage.Warnings {
 	return append(s.wrapped.Warnings(), s.warnings...)
 }
+
+type shardedSeriesSet struct {
+	wrapped storage.SeriesSet
+	shard   labels.Label
+}
+
+// NewShardedSeriesSet returns a SeriesSet injecting the shard label into each series of the
+// wrapped set. Series selected by a sharded query need to be labelled with their shard, so that
+// series of different shards don't collide once merged by the query-frontend. If the series
+// already have the shard label, they're returned unaltered.
+func NewShardedSeriesSet(wrapped storage.SeriesSet, shard sharding.ShardAnnotation) storage.SeriesSet {
+	return shardedSeriesSet{
+		wrapped: wrapped,
+		shard:   shard.Label(),
+	}
+}
+
+func (s shardedSeriesSet) Next() bool {
+	return s.wrapped.Next()
+}
+
+func (s shardedSeriesSet) At() storage.Series {
+	series := s.wrapped.At()
+	if series.Labels().Has(s.shard.Name) {
+		return series
+	}
+
+	return shardedSeries{
+		Series: series,
+		labels: labels.NewBuilder(series.Labels()).Set(s.shard.Name, s.shard.Value).Labels(),
+	}
+}
+
+func (s shardedSeriesSet) Err() error {
+	return s.wrapped.Err()
+}
+
+func (s shardedSeriesSet) Warnings() storage.Warnings {
+	return s.wrapped.Warnings()
+}
+
+type shardedSeries struct {
+	storage.Series
+	labels labels.Labels
+}
+
+func (s shardedSeries) Labels() labels.Labels {
+	return s.labels
+}
+
+type statsSeriesSet struct {
+	wrapped storage.SeriesSet
+	stats   *stats.Stats
+}
+
+// NewStatsSeriesSet returns a SeriesSet tracking the number of samples iterated
+// by the wrapped set series into the input query stats.
+func NewStatsSeriesSet(wrapped storage.SeriesSet, stats *stats.Stats) storage.SeriesSet {
+	return statsSeriesSet{
+		wrapped: wrapped,
+		stats:   stats,
+	}
+}
+
+func (s statsSeriesSet) Next() bool {
+	return s.wrapped.Next()
+}
+
+func (s statsSeriesSet) At() storage.Series {
+	return statsSeries{Series: s.wrapped.At(), stats: s.stats}
+}
+
+func (s statsSeriesSet) Err() error {
+	return s.wrapped.Err()
+}
+
+func (s statsSeriesSet) Warnings() storage.Warnings {
+	return s.wrapped.Warnings()
+}
+
+type statsSeries struct {
+	storage.Series
+	stats *stats.Stats
+}
+
+func (s statsSeries) Iterator() chunkenc.Iterator {
+	return &statsSeriesIterator{Iterator: s.Series.Iterator(), stats: s.stats, lastT: math.MinInt64}
+}
+
+// statsSeriesIterator tracks the samples read from the wrapped iterator. Samples are
+// counted when read, so that seeking multiple times to the same position (as done by
+// the PromQL engine) counts the sample once.
+type statsSeriesIterator struct {
+	chunkenc.Iterator
+	stats *stats.Stats
+
+	// Timestamp of the last sample counted. Timestamps are strictly increasing
+	// within a series, so a different timestamp means the iterator advanced.
+	lastT int64
+}
+
+func (it *statsSeriesIterator) At() (int64, float64) {
+	t, v := it.Iterator.At()
+	if t != it.lastT {
+		it.lastT = t
+		it.stats.AddSamplesProcessed(1)
+	}
+	return t, v
+}
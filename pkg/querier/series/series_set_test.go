@@ -8,6 +8,9 @@ import (
 	"github.com/prometheus/prometheus/pkg/labels"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/querier/stats"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 func TestConcreteSeriesSet(t *testing.T) {
@@ -54,6 +57,59 @@ func TestMatrixToSeriesSetSortsMetricLabels(t *testing.T) {
 	}, l)
 }
 
+func TestShardedSeriesSet(t *testing.T) {
+	shard := sharding.ShardAnnotation{Shard: 1, Of: 2}
+	series1 := NewConcreteSeries(labels.FromStrings("foo", "bar"), []model.SamplePair{{Value: 1, Timestamp: 2}})
+	series2 := NewConcreteSeries(labels.FromStrings("foo", "baz", sharding.ShardLabel, shard.String()), nil)
+
+	ss := NewShardedSeriesSet(NewConcreteSeriesSet([]storage.Series{series1, series2}), shard)
+
+	// The series already having the shard label should be returned unaltered.
+	require.True(t, ss.Next())
+	require.Equal(t, series2, ss.At())
+
+	// The shard label should be injected in the series not having it.
+	require.True(t, ss.Next())
+	require.Equal(t, labels.FromStrings("foo", "bar", sharding.ShardLabel, "1_of_2"), ss.At().Labels())
+
+	it := ss.At().Iterator()
+	require.True(t, it.Next())
+	ts, v := it.At()
+	require.Equal(t, int64(2), ts)
+	require.Equal(t, float64(1), v)
+
+	require.False(t, ss.Next())
+	require.NoError(t, ss.Err())
+}
+
+func TestStatsSeriesSet(t *testing.T) {
+	series1 := NewConcreteSeries(labels.FromStrings("foo", "bar"), []model.SamplePair{{Value: 1, Timestamp: 1}, {Value: 2, Timestamp: 2}, {Value: 3, Timestamp: 3}})
+	series2 := NewConcreteSeries(labels.FromStrings("foo", "baz"), []model.SamplePair{{Value: 4, Timestamp: 4}})
+
+	reqStats := &stats.Stats{}
+	ss := NewStatsSeriesSet(NewConcreteSeriesSet([]storage.Series{series1, series2}), reqStats)
+
+	// Only the samples actually read should be tracked, once each, even if the
+	// iterator is seeked multiple times to the same position.
+	require.True(t, ss.Next())
+	it := ss.At().Iterator()
+	require.True(t, it.Seek(2))
+	it.At()
+	require.True(t, it.Seek(2))
+	it.At()
+	it.At()
+	require.True(t, it.Next())
+	it.At()
+	require.False(t, it.Next())
+
+	require.True(t, ss.Next())
+	require.Equal(t, series2.Labels(), ss.At().Labels())
+
+	require.False(t, ss.Next())
+	require.NoError(t, ss.Err())
+	require.Equal(t, uint64(2), reqStats.LoadSamplesProcessed())
+}
+
 func TestDeletedSeriesIterator(t *testing.T) {
 	cs := ConcreteSeries{labels: labels.FromStrings("foo", "bar")}
 	// Insert random stuff from (0, 1000).
@@ -54,6 +54,114 @@ func (s *Stats) LoadWallTime() time.Duration {
 	return time.Duration(atomic.LoadInt64((*int64)(&s.WallTime)))
 }
 
+// AddFetchedSeriesCount adds some fetched series to the counter.
+func (s *Stats) AddFetchedSeriesCount(count uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.FetchedSeriesCount, count)
+}
+
+// LoadFetchedSeriesCount returns current fetched series.
+func (s *Stats) LoadFetchedSeriesCount() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.FetchedSeriesCount)
+}
+
+// AddFetchedChunkBytes adds some fetched chunk bytes to the counter.
+func (s *Stats) AddFetchedChunkBytes(count uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.FetchedChunkBytes, count)
+}
+
+// LoadFetchedChunkBytes returns current fetched chunk bytes.
+func (s *Stats) LoadFetchedChunkBytes() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.FetchedChunkBytes)
+}
+
+// AddSamplesProcessed adds some samples processed to the counter.
+func (s *Stats) AddSamplesProcessed(count uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.SamplesProcessed, count)
+}
+
+// LoadSamplesProcessed returns current samples processed.
+func (s *Stats) LoadSamplesProcessed() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.SamplesProcessed)
+}
+
+// AddIngesterRequests adds some requests sent to ingesters to the counter.
+func (s *Stats) AddIngesterRequests(count uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.IngesterRequests, count)
+}
+
+// LoadIngesterRequests returns current requests sent to ingesters.
+func (s *Stats) LoadIngesterRequests() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.IngesterRequests)
+}
+
+// AddStoreGatewayRequests adds some requests sent to store-gateways to the counter.
+func (s *Stats) AddStoreGatewayRequests(count uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.StoreGatewayRequests, count)
+}
+
+// LoadStoreGatewayRequests returns current requests sent to store-gateways.
+func (s *Stats) LoadStoreGatewayRequests() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.StoreGatewayRequests)
+}
+
+// AddResultsCacheHits adds some results cache hits to the counter.
+func (s *Stats) AddResultsCacheHits(count uint64) {
+	if s == nil {
+		return
+	}
+
+	atomic.AddUint64(&s.ResultsCacheHits, count)
+}
+
+// LoadResultsCacheHits returns current results cache hits.
+func (s *Stats) LoadResultsCacheHits() uint64 {
+	if s == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(&s.ResultsCacheHits)
+}
+
 // Merge the provide Stats into this one.
 func (s *Stats) Merge(other *Stats) {
 	if s == nil || other == nil {
@@ -61,6 +169,12 @@ func (s *Stats) Merge(other *Stats) {
 	}
 
 	s.AddWallTime(other.LoadWallTime())
+	s.AddFetchedSeriesCount(other.LoadFetchedSeriesCount())
+	s.AddFetchedChunkBytes(other.LoadFetchedChunkBytes())
+	s.AddSamplesProcessed(other.LoadSamplesProcessed())
+	s.AddIngesterRequests(other.LoadIngesterRequests())
+	s.AddStoreGatewayRequests(other.LoadStoreGatewayRequests())
+	s.AddResultsCacheHits(other.LoadResultsCacheHits())
 }
 
 func ShouldTrackHTTPGRPCResponse(r *httpgrpc.HTTPResponse) bool {
@@ -32,6 +32,18 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type Stats struct {
 	// The sum of all wall time spent in the querier to execute the query.
 	WallTime time.Duration `protobuf:"bytes,1,opt,name=wall_time,json=wallTime,proto3,stdduration" json:"wall_time"`
+	// The number of series fetched from ingesters and store-gateways for the query.
+	FetchedSeriesCount uint64 `protobuf:"varint,2,opt,name=fetched_series_count,json=fetchedSeriesCount,proto3" json:"fetched_series_count,omitempty"`
+	// The number of bytes of the chunks fetched from ingesters and store-gateways for the query.
+	FetchedChunkBytes uint64 `protobuf:"varint,3,opt,name=fetched_chunk_bytes,json=fetchedChunkBytes,proto3" json:"fetched_chunk_bytes,omitempty"`
+	// The number of samples processed to execute the query.
+	SamplesProcessed uint64 `protobuf:"varint,4,opt,name=samples_processed,json=samplesProcessed,proto3" json:"samples_processed,omitempty"`
+	// The number of requests sent to ingesters to execute the query.
+	IngesterRequests uint64 `protobuf:"varint,5,opt,name=ingester_requests,json=ingesterRequests,proto3" json:"ingester_requests,omitempty"`
+	// The number of requests sent to store-gateways to execute the query.
+	StoreGatewayRequests uint64 `protobuf:"varint,6,opt,name=store_gateway_requests,json=storeGatewayRequests,proto3" json:"store_gateway_requests,omitempty"`
+	// The number of requests served, fully or partially, from the query results cache.
+	ResultsCacheHits uint64 `protobuf:"varint,7,opt,name=results_cache_hits,json=resultsCacheHits,proto3" json:"results_cache_hits,omitempty"`
 }
 
 func (m *Stats) Reset()      { *m = Stats{} }
@@ -73,6 +85,48 @@ func (m *Stats) GetWallTime() time.Duration {
 	return 0
 }
 
+func (m *Stats) GetFetchedSeriesCount() uint64 {
+	if m != nil {
+		return m.FetchedSeriesCount
+	}
+	return 0
+}
+
+func (m *Stats) GetFetchedChunkBytes() uint64 {
+	if m != nil {
+		return m.FetchedChunkBytes
+	}
+	return 0
+}
+
+func (m *Stats) GetSamplesProcessed() uint64 {
+	if m != nil {
+		return m.SamplesProcessed
+	}
+	return 0
+}
+
+func (m *Stats) GetIngesterRequests() uint64 {
+	if m != nil {
+		return m.IngesterRequests
+	}
+	return 0
+}
+
+func (m *Stats) GetStoreGatewayRequests() uint64 {
+	if m != nil {
+		return m.StoreGatewayRequests
+	}
+	return 0
+}
+
+func (m *Stats) GetResultsCacheHits() uint64 {
+	if m != nil {
+		return m.ResultsCacheHits
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Stats)(nil), "stats.Stats")
 }
@@ -80,21 +134,31 @@ func init() {
 func init() { proto.RegisterFile("stats.proto", fileDescriptor_b4756a0aec8b9d44) }
 
 var fileDescriptor_b4756a0aec8b9d44 = []byte{
-	// 213 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x2e, 0x49, 0x2c,
-	0x29, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x73, 0xa4, 0x74, 0xd3, 0x33, 0x4b,
-	0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0xd3, 0xf3, 0xd3, 0xf3, 0xf5, 0xc1, 0xb2, 0x49,
-	0xa5, 0x69, 0x60, 0x1e, 0x98, 0x03, 0x66, 0x41, 0x74, 0x49, 0xc9, 0xa5, 0xe7, 0xe7, 0xa7, 0xe7,
-	0xa4, 0x22, 0x54, 0xa5, 0x94, 0x16, 0x25, 0x96, 0x64, 0xe6, 0xe7, 0x41, 0xe4, 0x95, 0x3c, 0xb9,
-	0x58, 0x83, 0x41, 0xe6, 0x0a, 0x39, 0x70, 0x71, 0x96, 0x27, 0xe6, 0xe4, 0xc4, 0x97, 0x64, 0xe6,
-	0xa6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x1b, 0x49, 0xea, 0x41, 0x34, 0xeb, 0xc1, 0x34, 0xeb,
-	0xb9, 0x40, 0x35, 0x3b, 0x71, 0x9c, 0xb8, 0x27, 0xcf, 0x30, 0xe3, 0xbe, 0x3c, 0x63, 0x10, 0x07,
-	0x48, 0x57, 0x48, 0x66, 0x6e, 0xaa, 0x93, 0xf5, 0x85, 0x87, 0x72, 0x0c, 0x37, 0x1e, 0xca, 0x31,
-	0x7c, 0x78, 0x28, 0xc7, 0xd8, 0xf0, 0x48, 0x8e, 0x71, 0xc5, 0x23, 0x39, 0xc6, 0x13, 0x8f, 0xe4,
-	0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0xf1, 0xc5, 0x23, 0x39, 0x86, 0x0f, 0x8f,
-	0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28,
-	0x88, 0xb7, 0x92, 0xd8, 0xc0, 0x76, 0x18, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x2d, 0xc4, 0x26,
-	0x5d, 0xf3, 0x00, 0x00, 0x00,
+	// 377 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0xd1, 0x31, 0x8f, 0xd3, 0x30,
+	0x14, 0xc0, 0xf1, 0xf8, 0xb8, 0x1e, 0x87, 0x6f, 0xe1, 0xcc, 0x09, 0x85, 0x1b, 0xdc, 0x8a, 0xa9,
+	0x12, 0x90, 0x22, 0x60, 0x63, 0x41, 0x2d, 0x12, 0x8c, 0xa8, 0x65, 0x62, 0xb1, 0x92, 0xf4, 0x35,
+	0xb1, 0x48, 0xe2, 0xe2, 0xf7, 0xa2, 0xaa, 0x1b, 0x1f, 0x81, 0x91, 0x8f, 0xc0, 0x47, 0xe9, 0xd8,
+	0xb1, 0x13, 0xd0, 0x74, 0x81, 0xad, 0x1f, 0x01, 0xc5, 0x49, 0xe8, 0x96, 0xe7, 0xdf, 0xfb, 0xc7,
+	0x83, 0xf9, 0x15, 0x52, 0x48, 0x18, 0x2c, 0xad, 0x21, 0x23, 0x7a, 0x6e, 0xb8, 0x7d, 0x96, 0x68,
+	0x4a, 0xcb, 0x28, 0x88, 0x4d, 0x3e, 0x4a, 0x4c, 0x62, 0x46, 0x4e, 0xa3, 0x72, 0xe1, 0x26, 0x37,
+	0xb8, 0xaf, 0xa6, 0xba, 0x95, 0x89, 0x31, 0x49, 0x06, 0xa7, 0xad, 0x79, 0x69, 0x43, 0xd2, 0xa6,
+	0x68, 0xfc, 0xf1, 0xdf, 0x33, 0xde, 0x9b, 0xd5, 0x3f, 0x16, 0x6f, 0xf8, 0xbd, 0x55, 0x98, 0x65,
+	0x8a, 0x74, 0x0e, 0x3e, 0x1b, 0xb0, 0xe1, 0xd5, 0x8b, 0x47, 0x41, 0x53, 0x07, 0x5d, 0x1d, 0xbc,
+	0x6d, 0xeb, 0xf1, 0xe5, 0xe6, 0x67, 0xdf, 0xfb, 0xfe, 0xab, 0xcf, 0xa6, 0x97, 0x75, 0xf5, 0x51,
+	0xe7, 0x20, 0x9e, 0xf3, 0x9b, 0x05, 0x50, 0x9c, 0xc2, 0x5c, 0x21, 0x58, 0x0d, 0xa8, 0x62, 0x53,
+	0x16, 0xe4, 0x9f, 0x0d, 0xd8, 0xf0, 0x7c, 0x2a, 0x5a, 0x9b, 0x39, 0x9a, 0xd4, 0x22, 0x02, 0xfe,
+	0xa0, 0x2b, 0xe2, 0xb4, 0x2c, 0x3e, 0xab, 0x68, 0x4d, 0x80, 0xfe, 0x1d, 0x17, 0x5c, 0xb7, 0x34,
+	0xa9, 0x65, 0x5c, 0x83, 0x78, 0xc2, 0xaf, 0x31, 0xcc, 0x97, 0x19, 0xa0, 0x5a, 0x5a, 0x13, 0x03,
+	0x22, 0xcc, 0xfd, 0x73, 0xb7, 0x7d, 0xbf, 0x85, 0x0f, 0xdd, 0x79, 0xbd, 0xac, 0x8b, 0x04, 0x90,
+	0xc0, 0x2a, 0x0b, 0x5f, 0x4a, 0x40, 0x42, 0xbf, 0xd7, 0x2c, 0x77, 0x30, 0x6d, 0xcf, 0xc5, 0x2b,
+	0xfe, 0x10, 0xc9, 0x58, 0x50, 0x49, 0x48, 0xb0, 0x0a, 0xd7, 0xa7, 0xe2, 0xc2, 0x15, 0x37, 0x4e,
+	0xdf, 0x35, 0xf8, 0xbf, 0x7a, 0xca, 0x85, 0x05, 0x2c, 0x33, 0x42, 0x15, 0x87, 0x71, 0x0a, 0x2a,
+	0xd5, 0x84, 0xfe, 0xdd, 0xe6, 0x8e, 0x56, 0x26, 0x35, 0xbc, 0xd7, 0x84, 0xe3, 0xd7, 0xdb, 0xbd,
+	0xf4, 0x76, 0x7b, 0xe9, 0x1d, 0xf7, 0x92, 0x7d, 0xad, 0x24, 0xfb, 0x51, 0x49, 0xb6, 0xa9, 0x24,
+	0xdb, 0x56, 0x92, 0xfd, 0xae, 0x24, 0xfb, 0x53, 0x49, 0xef, 0x58, 0x49, 0xf6, 0xed, 0x20, 0xbd,
+	0xed, 0x41, 0x7a, 0xbb, 0x83, 0xf4, 0x3e, 0x35, 0xef, 0x1e, 0x5d, 0xb8, 0x37, 0x78, 0xf9, 0x6f,
+	0x00, 0x92, 0x84, 0x06, 0xa5, 0x14, 0x02, 0x00, 0x00,
 }
 
 func (this *Stats) Equal(that interface{}) bool {
@@ -119,15 +183,39 @@ func (this *Stats) Equal(that interface{}) bool {
 	if this.WallTime != that1.WallTime {
 		return false
 	}
+	if this.FetchedSeriesCount != that1.FetchedSeriesCount {
+		return false
+	}
+	if this.FetchedChunkBytes != that1.FetchedChunkBytes {
+		return false
+	}
+	if this.SamplesProcessed != that1.SamplesProcessed {
+		return false
+	}
+	if this.IngesterRequests != that1.IngesterRequests {
+		return false
+	}
+	if this.StoreGatewayRequests != that1.StoreGatewayRequests {
+		return false
+	}
+	if this.ResultsCacheHits != that1.ResultsCacheHits {
+		return false
+	}
 	return true
 }
 func (this *Stats) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 11)
 	s = append(s, "&stats.Stats{")
 	s = append(s, "WallTime: "+fmt.Sprintf("%#v", this.WallTime)+",\n")
+	s = append(s, "FetchedSeriesCount: "+fmt.Sprintf("%#v", this.FetchedSeriesCount)+",\n")
+	s = append(s, "FetchedChunkBytes: "+fmt.Sprintf("%#v", this.FetchedChunkBytes)+",\n")
+	s = append(s, "SamplesProcessed: "+fmt.Sprintf("%#v", this.SamplesProcessed)+",\n")
+	s = append(s, "IngesterRequests: "+fmt.Sprintf("%#v", this.IngesterRequests)+",\n")
+	s = append(s, "StoreGatewayRequests: "+fmt.Sprintf("%#v", this.StoreGatewayRequests)+",\n")
+	s = append(s, "ResultsCacheHits: "+fmt.Sprintf("%#v", this.ResultsCacheHits)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -159,6 +247,36 @@ func (m *Stats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ResultsCacheHits != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.ResultsCacheHits))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.StoreGatewayRequests != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.StoreGatewayRequests))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.IngesterRequests != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.IngesterRequests))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SamplesProcessed != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.SamplesProcessed))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.FetchedChunkBytes != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.FetchedChunkBytes))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FetchedSeriesCount != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.FetchedSeriesCount))
+		i--
+		dAtA[i] = 0x10
+	}
 	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.WallTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime):])
 	if err1 != nil {
 		return 0, err1
@@ -189,6 +307,24 @@ func (m *Stats) Size() (n int) {
 	_ = l
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.WallTime)
 	n += 1 + l + sovStats(uint64(l))
+	if m.FetchedSeriesCount != 0 {
+		n += 1 + sovStats(uint64(m.FetchedSeriesCount))
+	}
+	if m.FetchedChunkBytes != 0 {
+		n += 1 + sovStats(uint64(m.FetchedChunkBytes))
+	}
+	if m.SamplesProcessed != 0 {
+		n += 1 + sovStats(uint64(m.SamplesProcessed))
+	}
+	if m.IngesterRequests != 0 {
+		n += 1 + sovStats(uint64(m.IngesterRequests))
+	}
+	if m.StoreGatewayRequests != 0 {
+		n += 1 + sovStats(uint64(m.StoreGatewayRequests))
+	}
+	if m.ResultsCacheHits != 0 {
+		n += 1 + sovStats(uint64(m.ResultsCacheHits))
+	}
 	return n
 }
 
@@ -204,6 +340,12 @@ func (this *Stats) String() string {
 	}
 	s := strings.Join([]string{`&Stats{`,
 		`WallTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.WallTime), "Duration", "duration.Duration", 1), `&`, ``, 1) + `,`,
+		`FetchedSeriesCount:` + fmt.Sprintf("%v", this.FetchedSeriesCount) + `,`,
+		`FetchedChunkBytes:` + fmt.Sprintf("%v", this.FetchedChunkBytes) + `,`,
+		`SamplesProcessed:` + fmt.Sprintf("%v", this.SamplesProcessed) + `,`,
+		`IngesterRequests:` + fmt.Sprintf("%v", this.IngesterRequests) + `,`,
+		`StoreGatewayRequests:` + fmt.Sprintf("%v", this.StoreGatewayRequests) + `,`,
+		`ResultsCacheHits:` + fmt.Sprintf("%v", this.ResultsCacheHits) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -278,6 +420,120 @@ func (m *Stats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedSeriesCount", wireType)
+			}
+			m.FetchedSeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedSeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedChunkBytes", wireType)
+			}
+			m.FetchedChunkBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedChunkBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SamplesProcessed", wireType)
+			}
+			m.SamplesProcessed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SamplesProcessed |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IngesterRequests", wireType)
+			}
+			m.IngesterRequests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IngesterRequests |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreGatewayRequests", wireType)
+			}
+			m.StoreGatewayRequests = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreGatewayRequests |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultsCacheHits", wireType)
+			}
+			m.ResultsCacheHits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultsCacheHits |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStats(dAtA[iNdEx:])
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats_Merge(t *testing.T) {
+	t.Run("merge two stats objects", func(t *testing.T) {
+		stats1 := &Stats{}
+		stats1.AddWallTime(time.Millisecond)
+		stats1.AddFetchedSeriesCount(50)
+		stats1.AddFetchedChunkBytes(42)
+		stats1.AddSamplesProcessed(100)
+		stats1.AddIngesterRequests(3)
+		stats1.AddResultsCacheHits(1)
+
+		stats2 := &Stats{}
+		stats2.AddWallTime(time.Second)
+		stats2.AddFetchedSeriesCount(60)
+		stats2.AddFetchedChunkBytes(100)
+		stats2.AddSamplesProcessed(10)
+		stats2.AddStoreGatewayRequests(2)
+
+		stats1.Merge(stats2)
+
+		assert.Equal(t, 1001*time.Millisecond, stats1.LoadWallTime())
+		assert.Equal(t, uint64(110), stats1.LoadFetchedSeriesCount())
+		assert.Equal(t, uint64(142), stats1.LoadFetchedChunkBytes())
+		assert.Equal(t, uint64(110), stats1.LoadSamplesProcessed())
+		assert.Equal(t, uint64(3), stats1.LoadIngesterRequests())
+		assert.Equal(t, uint64(2), stats1.LoadStoreGatewayRequests())
+		assert.Equal(t, uint64(1), stats1.LoadResultsCacheHits())
+	})
+
+	t.Run("merge two nil stats objects", func(t *testing.T) {
+		var stats1 *Stats
+		var stats2 *Stats
+
+		stats1.Merge(stats2)
+
+		assert.Equal(t, time.Duration(0), stats1.LoadWallTime())
+		assert.Equal(t, uint64(0), stats1.LoadFetchedSeriesCount())
+		assert.Equal(t, uint64(0), stats1.LoadFetchedChunkBytes())
+	})
+}
+
+func TestStats_ShouldBeNoopIfNotEnabled(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsEnabled(ctx))
+
+	// Stats are safe to be updated even if not initialised in the context.
+	FromContext(ctx).AddFetchedSeriesCount(1)
+	assert.Equal(t, uint64(0), FromContext(ctx).LoadFetchedSeriesCount())
+
+	stats, ctx := ContextWithEmptyStats(ctx)
+	assert.True(t, IsEnabled(ctx))
+
+	FromContext(ctx).AddFetchedSeriesCount(1)
+	assert.Equal(t, uint64(1), stats.LoadFetchedSeriesCount())
+}
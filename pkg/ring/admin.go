@@ -0,0 +1,222 @@
+package ring
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+// SetInstanceReadOnly marks the instance as read-only (or read-write). Read-only instances
+// don't receive any write, while they're still queried.
+func (r *Ring) SetInstanceReadOnly(ctx context.Context, instanceID string, readOnly bool) error {
+	return r.updateInstance(ctx, instanceID, func(instance *InstanceDesc) {
+		instance.ReadOnly = readOnly
+	})
+}
+
+// RequestInstanceLeave requests the instance to gracefully leave the ring. The instance
+// leaves the ring once its lifecycler notices the request, at the next heartbeat.
+func (r *Ring) RequestInstanceLeave(ctx context.Context, instanceID string) error {
+	return r.updateInstance(ctx, instanceID, func(instance *InstanceDesc) {
+		instance.LeaveRequested = true
+	})
+}
+
+func (r *Ring) updateInstance(ctx context.Context, instanceID string, update func(*InstanceDesc)) error {
+	return r.KVClient.CAS(ctx, r.key, func(in interface{}) (out interface{}, retry bool, err error) {
+		ringDesc, _ := in.(*Desc)
+		if ringDesc == nil {
+			return nil, false, ErrInstanceNotFound
+		}
+
+		instance, ok := ringDesc.Ingesters[instanceID]
+		if !ok || instance.State == LEFT {
+			return nil, false, ErrInstanceNotFound
+		}
+
+		update(&instance)
+
+		// Memberlist only propagates the changes to an instance if its timestamp has changed.
+		instance.Timestamp = time.Now().Unix()
+		ringDesc.Ingesters[instanceID] = instance
+		return ringDesc, true, nil
+	})
+}
+
+// GetTokensOwnership returns the number of tokens and the percentage of the ring owned
+// by each instance and by each zone, sorted by name.
+func (r *Ring) GetTokensOwnership() (instances, zones []TokensOwnership) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.ringDesc == nil {
+		return nil, nil
+	}
+
+	numTokens, owned := r.countTokens()
+	zonesNumTokens := map[string]uint32{}
+	zonesOwned := map[string]uint64{}
+
+	for id, instanceOwned := range owned {
+		instances = append(instances, TokensOwnership{
+			Name:      id,
+			NumTokens: numTokens[id],
+			Ownership: (float64(instanceOwned) / float64(math.MaxUint32)) * 100,
+		})
+
+		zone := r.ringDesc.Ingesters[id].Zone
+		zonesNumTokens[zone] += numTokens[id]
+		zonesOwned[zone] += uint64(instanceOwned)
+	}
+
+	for zone, zoneOwned := range zonesOwned {
+		zones = append(zones, TokensOwnership{
+			Name:      zone,
+			NumTokens: zonesNumTokens[zone],
+			Ownership: (float64(zoneOwned) / float64(math.MaxUint32)) * 100,
+		})
+	}
+
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Name < instances[j].Name })
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+	return instances, zones
+}
+
+// AdminServer implements the RingAdmin gRPC service and the equivalent HTTP API, for all
+// the rings registered to it. Both are not tenant-scoped, so they should only be exposed
+// to the operators.
+type AdminServer struct {
+	mtx   sync.RWMutex
+	rings map[string]func() *Ring
+}
+
+// NewAdminServer makes a new AdminServer.
+func NewAdminServer() *AdminServer {
+	return &AdminServer{
+		rings: map[string]func() *Ring{},
+	}
+}
+
+// RegisterRing makes a ring administrable through the AdminServer, under the given name.
+// The getRing function is called on each request, and may return nil if the ring is not
+// available (ie. the component owning it is not running yet).
+func (s *AdminServer) RegisterRing(name string, getRing func() *Ring) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.rings[name] = getRing
+}
+
+func (s *AdminServer) getRing(name string) (*Ring, error) {
+	s.mtx.RLock()
+	getRing, ok := s.rings[name]
+	s.mtx.RUnlock()
+
+	if !ok {
+		return nil, httpgrpc.Errorf(http.StatusNotFound, "ring %q not found", name)
+	}
+
+	r := getRing()
+	if r == nil {
+		return nil, httpgrpc.Errorf(http.StatusServiceUnavailable, "ring %q is not available", name)
+	}
+	return r, nil
+}
+
+// SetInstanceReadOnly implements RingAdminServer.
+func (s *AdminServer) SetInstanceReadOnly(ctx context.Context, req *SetInstanceReadOnlyRequest) (*SetInstanceReadOnlyResponse, error) {
+	r, err := s.getRing(req.Ring)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.SetInstanceReadOnly(ctx, req.InstanceId, req.ReadOnly); err != nil {
+		return nil, wrapAdminError(err)
+	}
+	return &SetInstanceReadOnlyResponse{}, nil
+}
+
+// RequestInstanceLeave implements RingAdminServer.
+func (s *AdminServer) RequestInstanceLeave(ctx context.Context, req *RequestInstanceLeaveRequest) (*RequestInstanceLeaveResponse, error) {
+	r, err := s.getRing(req.Ring)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.RequestInstanceLeave(ctx, req.InstanceId); err != nil {
+		return nil, wrapAdminError(err)
+	}
+	return &RequestInstanceLeaveResponse{}, nil
+}
+
+// GetTokensOwnership implements RingAdminServer.
+func (s *AdminServer) GetTokensOwnership(_ context.Context, req *GetTokensOwnershipRequest) (*GetTokensOwnershipResponse, error) {
+	r, err := s.getRing(req.Ring)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, zones := r.GetTokensOwnership()
+	return &GetTokensOwnershipResponse{Instances: instances, Zones: zones}, nil
+}
+
+func wrapAdminError(err error) error {
+	if errors.Is(err, ErrInstanceNotFound) {
+		return httpgrpc.Errorf(http.StatusNotFound, err.Error())
+	}
+	return httpgrpc.Errorf(http.StatusInternalServerError, err.Error())
+}
+
+// SetInstanceReadOnlyHandler marks the instance as read-only on POST requests, and as
+// read-write on DELETE requests.
+func (s *AdminServer) SetInstanceReadOnlyHandler(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+
+	_, err := s.SetInstanceReadOnly(req.Context(), &SetInstanceReadOnlyRequest{
+		Ring:       vars["ring"],
+		InstanceId: vars["instance"],
+		ReadOnly:   req.Method != http.MethodDelete,
+	})
+	writeAdminResponse(w, &SetInstanceReadOnlyResponse{}, err)
+}
+
+// RequestInstanceLeaveHandler requests the instance to gracefully leave the ring.
+func (s *AdminServer) RequestInstanceLeaveHandler(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+
+	_, err := s.RequestInstanceLeave(req.Context(), &RequestInstanceLeaveRequest{
+		Ring:       vars["ring"],
+		InstanceId: vars["instance"],
+	})
+	writeAdminResponse(w, &RequestInstanceLeaveResponse{}, err)
+}
+
+// GetTokensOwnershipHandler returns the tokens ownership of the instances and zones of the ring.
+func (s *AdminServer) GetTokensOwnershipHandler(w http.ResponseWriter, req *http.Request) {
+	resp, err := s.GetTokensOwnership(req.Context(), &GetTokensOwnershipRequest{
+		Ring: mux.Vars(req)["ring"],
+	})
+	writeAdminResponse(w, resp, err)
+}
+
+func writeAdminResponse(w http.ResponseWriter, resp interface{}, err error) {
+	if err != nil {
+		if errResp, ok := httpgrpc.HTTPResponseFromError(err); ok {
+			http.Error(w, string(errResp.Body), int(errResp.Code))
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	util.WriteJSONResponse(w, resp)
+}
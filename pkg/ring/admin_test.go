@@ -0,0 +1,186 @@
+package ring
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ring/kv"
+	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
+)
+
+func prepareAdminRing(t *testing.T, instances map[string]InstanceDesc) (*Ring, kv.Client) {
+	store := consul.NewInMemoryClient(GetCodec())
+
+	desc := NewDesc()
+	for id, instance := range instances {
+		desc.Ingesters[id] = instance
+	}
+	require.NoError(t, store.CAS(context.Background(), testRingKey, func(_ interface{}) (interface{}, bool, error) {
+		return desc, true, nil
+	}))
+
+	r, err := NewWithStoreClientAndStrategy(Config{HeartbeatTimeout: time.Minute, ReplicationFactor: 1}, testRingName, testRingKey, store, NewDefaultReplicationStrategy())
+	require.NoError(t, err)
+
+	// Load the ring state without starting the ring service.
+	r.ringDesc = desc
+	r.ringTokens = desc.GetTokens()
+	r.ringTokensByZone = desc.getTokensByZone()
+	r.ringInstanceByToken = desc.getTokensInfo()
+	r.ringZones = getZones(desc.getTokensByZone())
+
+	return r, store
+}
+
+func TestRing_SetInstanceReadOnly(t *testing.T) {
+	ctx := context.Background()
+	r, store := prepareAdminRing(t, map[string]InstanceDesc{
+		"instance-1": {Addr: "127.0.0.1", State: ACTIVE, Tokens: []uint32{1}},
+		"instance-2": {Addr: "127.0.0.2", State: LEFT, Tokens: []uint32{2}},
+	})
+
+	require.NoError(t, r.SetInstanceReadOnly(ctx, "instance-1", true))
+	instance, ok := getInstanceFromStore(t, store, "instance-1")
+	require.True(t, ok)
+	assert.True(t, instance.ReadOnly)
+	assert.NotZero(t, instance.Timestamp)
+
+	require.NoError(t, r.SetInstanceReadOnly(ctx, "instance-1", false))
+	instance, ok = getInstanceFromStore(t, store, "instance-1")
+	require.True(t, ok)
+	assert.False(t, instance.ReadOnly)
+
+	assert.Equal(t, ErrInstanceNotFound, r.SetInstanceReadOnly(ctx, "instance-2", true))
+	assert.Equal(t, ErrInstanceNotFound, r.SetInstanceReadOnly(ctx, "unknown", true))
+}
+
+func TestRing_RequestInstanceLeave(t *testing.T) {
+	ctx := context.Background()
+	r, store := prepareAdminRing(t, map[string]InstanceDesc{
+		"instance-1": {Addr: "127.0.0.1", State: ACTIVE, Tokens: []uint32{1}},
+	})
+
+	require.NoError(t, r.RequestInstanceLeave(ctx, "instance-1"))
+	instance, ok := getInstanceFromStore(t, store, "instance-1")
+	require.True(t, ok)
+	assert.True(t, instance.LeaveRequested)
+	assert.Equal(t, ACTIVE, instance.State)
+
+	assert.Equal(t, ErrInstanceNotFound, r.RequestInstanceLeave(ctx, "unknown"))
+}
+
+func TestRing_GetTokensOwnership(t *testing.T) {
+	r, _ := prepareAdminRing(t, map[string]InstanceDesc{
+		"instance-1": {Addr: "127.0.0.1", Zone: "zone-a", State: ACTIVE, Tokens: []uint32{0}},
+		"instance-2": {Addr: "127.0.0.2", Zone: "zone-b", State: ACTIVE, Tokens: []uint32{math.MaxUint32 / 4}},
+		"instance-3": {Addr: "127.0.0.3", Zone: "zone-a", State: ACTIVE, Tokens: []uint32{math.MaxUint32 / 2, (math.MaxUint32 / 4) * 3}},
+	})
+
+	instances, zones := r.GetTokensOwnership()
+
+	require.Len(t, instances, 3)
+	assert.Equal(t, []string{"instance-1", "instance-2", "instance-3"}, []string{instances[0].Name, instances[1].Name, instances[2].Name})
+	assert.Equal(t, []uint32{1, 1, 2}, []uint32{instances[0].NumTokens, instances[1].NumTokens, instances[2].NumTokens})
+	assert.InDelta(t, 25, instances[0].Ownership, 0.01)
+	assert.InDelta(t, 25, instances[1].Ownership, 0.01)
+	assert.InDelta(t, 50, instances[2].Ownership, 0.01)
+
+	require.Len(t, zones, 2)
+	assert.Equal(t, "zone-a", zones[0].Name)
+	assert.Equal(t, uint32(3), zones[0].NumTokens)
+	assert.InDelta(t, 75, zones[0].Ownership, 0.01)
+	assert.Equal(t, "zone-b", zones[1].Name)
+	assert.Equal(t, uint32(1), zones[1].NumTokens)
+	assert.InDelta(t, 25, zones[1].Ownership, 0.01)
+}
+
+func TestAdminServer_Handlers(t *testing.T) {
+	r, store := prepareAdminRing(t, map[string]InstanceDesc{
+		"instance-1": {Addr: "127.0.0.1", Zone: "zone-a", State: ACTIVE, Tokens: []uint32{1}},
+	})
+
+	server := NewAdminServer()
+	server.RegisterRing("available", func() *Ring { return r })
+	server.RegisterRing("unavailable", func() *Ring { return nil })
+
+	tests := map[string]struct {
+		handler        http.HandlerFunc
+		method         string
+		vars           map[string]string
+		expectedStatus int
+	}{
+		"set read-only on unknown ring": {
+			handler:        server.SetInstanceReadOnlyHandler,
+			method:         http.MethodPost,
+			vars:           map[string]string{"ring": "unknown", "instance": "instance-1"},
+			expectedStatus: http.StatusNotFound,
+		},
+		"set read-only on unavailable ring": {
+			handler:        server.SetInstanceReadOnlyHandler,
+			method:         http.MethodPost,
+			vars:           map[string]string{"ring": "unavailable", "instance": "instance-1"},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		"set read-only on unknown instance": {
+			handler:        server.SetInstanceReadOnlyHandler,
+			method:         http.MethodPost,
+			vars:           map[string]string{"ring": "available", "instance": "unknown"},
+			expectedStatus: http.StatusNotFound,
+		},
+		"set read-only": {
+			handler:        server.SetInstanceReadOnlyHandler,
+			method:         http.MethodPost,
+			vars:           map[string]string{"ring": "available", "instance": "instance-1"},
+			expectedStatus: http.StatusOK,
+		},
+		"request leave": {
+			handler:        server.RequestInstanceLeaveHandler,
+			method:         http.MethodPost,
+			vars:           map[string]string{"ring": "available", "instance": "instance-1"},
+			expectedStatus: http.StatusOK,
+		},
+		"get tokens ownership": {
+			handler:        server.GetTokensOwnershipHandler,
+			method:         http.MethodGet,
+			vars:           map[string]string{"ring": "available"},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest(testData.method, "/", nil), testData.vars)
+			rec := httptest.NewRecorder()
+			testData.handler(rec, req)
+
+			assert.Equal(t, testData.expectedStatus, rec.Code)
+		})
+	}
+
+	// Assert on the changes applied to the ring.
+	instance, ok := getInstanceFromStore(t, store, "instance-1")
+	require.True(t, ok)
+	assert.True(t, instance.ReadOnly)
+	assert.True(t, instance.LeaveRequested)
+
+	// Assert on the tokens ownership response.
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), map[string]string{"ring": "available"})
+	rec := httptest.NewRecorder()
+	server.GetTokensOwnershipHandler(rec, req)
+
+	resp := GetTokensOwnershipResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Instances, 1)
+	assert.Equal(t, "instance-1", resp.Instances[0].Name)
+	require.Len(t, resp.Zones, 1)
+	assert.Equal(t, "zone-a", resp.Zones[0].Name)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/cortexproject/cortex/pkg/ring/kv"
+	"github.com/cortexproject/cortex/pkg/util"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/services"
 )
@@ -78,6 +79,10 @@ type BasicLifecycler struct {
 	// The current instance state.
 	currState        sync.RWMutex
 	currInstanceDesc *InstanceDesc
+
+	// Whether the lifecycler is leaving the ring as requested by an operator, in which case
+	// the process is stopped once the instance has left. Only accessed from the service goroutine.
+	leavingAsRequested bool
 }
 
 // NewBasicLifecycler makes a new BasicLifecycler.
@@ -143,6 +148,14 @@ func (l *BasicLifecycler) GetRegisteredAt() time.Time {
 	return l.currInstanceDesc.GetRegisteredAt()
 }
 
+// isLeaveRequested returns whether an operator requested the instance to leave the ring.
+func (l *BasicLifecycler) isLeaveRequested() bool {
+	l.currState.RLock()
+	defer l.currState.RUnlock()
+
+	return l.currInstanceDesc != nil && l.currInstanceDesc.LeaveRequested
+}
+
 // IsRegistered returns whether the instance is currently registered within the ring.
 func (l *BasicLifecycler) IsRegistered() bool {
 	l.currState.RLock()
@@ -190,6 +203,13 @@ func (l *BasicLifecycler) running(ctx context.Context) error {
 		case <-heartbeatTicker.C:
 			l.heartbeat(ctx)
 
+			// Exiting without errors gracefully stops the lifecycler, which leaves the ring.
+			if l.isLeaveRequested() {
+				level.Info(l.logger).Log("msg", "leaving the ring as requested by an operator", "ring", l.ringName)
+				l.leavingAsRequested = true
+				return nil
+			}
+
 		case f := <-l.actorChan:
 			f()
 
@@ -233,6 +253,12 @@ heartbeatLoop:
 	}
 	level.Info(l.logger).Log("msg", "instance removed from the ring", "ring", l.ringName)
 
+	// The instance is not part of the ring anymore, so the process is stopped instead of
+	// serving without receiving any traffic.
+	if l.leavingAsRequested {
+		return util.ErrStopProcess
+	}
+
 	return nil
 }
 
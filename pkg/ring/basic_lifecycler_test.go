@@ -12,6 +12,7 @@ import (
 
 	"github.com/cortexproject/cortex/pkg/ring/kv"
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
+	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/services"
 	"github.com/cortexproject/cortex/pkg/util/test"
 )
@@ -190,6 +191,37 @@ func TestBasicLifecycler_UnregisterOnStop(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestBasicLifecycler_LeaveOnRequest(t *testing.T) {
+	ctx := context.Background()
+	cfg := prepareBasicLifecyclerConfig()
+	cfg.HeartbeatPeriod = 10 * time.Millisecond
+
+	lifecycler, delegate, store, err := prepareBasicLifecycler(cfg)
+	require.NoError(t, err)
+	defer services.StopAndAwaitTerminated(ctx, lifecycler) //nolint:errcheck
+
+	delegate.onRegister = func(_ *BasicLifecycler, _ Desc, _ bool, _ string, _ InstanceDesc) (IngesterState, Tokens) {
+		return ACTIVE, Tokens{1, 2, 3, 4, 5}
+	}
+
+	require.NoError(t, services.StartAndAwaitRunning(ctx, lifecycler))
+
+	// Request the instance to leave the ring, like an operator would do.
+	require.NoError(t, store.CAS(ctx, testRingKey, func(in interface{}) (interface{}, bool, error) {
+		desc := in.(*Desc)
+		instance := desc.Ingesters[testInstanceID]
+		instance.LeaveRequested = true
+		desc.Ingesters[testInstanceID] = instance
+		return desc, true, nil
+	}))
+
+	// Assert the lifecycler terminates, requesting the process to stop, and the instance is removed from the ring.
+	require.Error(t, lifecycler.AwaitTerminated(ctx))
+	assert.Equal(t, util.ErrStopProcess, lifecycler.FailureCase())
+	_, ok := getInstanceFromStore(t, store, testInstanceID)
+	assert.False(t, ok)
+}
+
 func TestBasicLifecycler_HeartbeatWhileRunning(t *testing.T) {
 	ctx := context.Background()
 	cfg := prepareBasicLifecyclerConfig()
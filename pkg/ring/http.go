@@ -33,6 +33,7 @@ const pageContent = `
 						<th>Instance ID</th>
 						<th>Availability Zone</th>
 						<th>State</th>
+						<th>Read-only</th>
 						<th>Address</th>
 						<th>Registered At</th>
 						<th>Last Heartbeat</th>
@@ -50,13 +51,45 @@ const pageContent = `
 					{{ end }}
 						<td>{{ .ID }}</td>
 						<td>{{ .Zone }}</td>
-						<td>{{ .State }}</td>
+						<td>{{ .State }}{{ if .LeaveRequested }} (leave requested){{ end }}</td>
+						<td>{{ if .ReadOnly }}yes{{ else }}no{{ end }}</td>
 						<td>{{ .Address }}</td>
 						<td>{{ .RegisteredTimestamp }}</td>
 						<td>{{ .HeartbeatTimestamp }}</td>
 						<td>{{ .NumTokens }}</td>
 						<td>{{ .Ownership }}%</td>
-						<td><button name="forget" value="{{ .ID }}" type="submit">Forget</button></td>
+						<td>
+							{{ if .ReadOnly }}
+							<button name="read_write" value="{{ .ID }}" type="submit">Set read-write</button>
+							{{ else }}
+							<button name="read_only" value="{{ .ID }}" type="submit">Set read-only</button>
+							{{ end }}
+							<button name="leave" value="{{ .ID }}" type="submit">Leave</button>
+							<button name="forget" value="{{ .ID }}" type="submit">Forget</button>
+						</td>
+					</tr>
+					{{ end }}
+				</tbody>
+			</table>
+			<br>
+			<table border="1">
+				<thead>
+					<tr>
+						<th>Availability Zone</th>
+						<th>Tokens</th>
+						<th>Ownership</th>
+					</tr>
+				</thead>
+				<tbody>
+					{{ range $i, $zone := .Zones }}
+					{{ if mod $i 2 }}
+					<tr>
+					{{ else }}
+					<tr bgcolor="#BEBEBE">
+					{{ end }}
+						<td>{{ .Name }}</td>
+						<td>{{ .NumTokens }}</td>
+						<td>{{ .Ownership }}%</td>
 					</tr>
 					{{ end }}
 				</tbody>
@@ -106,9 +139,26 @@ func (r *Ring) forget(ctx context.Context, id string) error {
 
 func (r *Ring) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if req.Method == http.MethodPost {
-		ingesterID := req.FormValue("forget")
-		if err := r.forget(req.Context(), ingesterID); err != nil {
-			level.Error(log.WithContext(req.Context(), log.Logger)).Log("msg", "error forgetting instance", "err", err)
+		logger := log.WithContext(req.Context(), log.Logger)
+
+		switch {
+		case req.FormValue("read_only") != "":
+			if err := r.SetInstanceReadOnly(req.Context(), req.FormValue("read_only"), true); err != nil {
+				level.Error(logger).Log("msg", "error setting instance read-only", "err", err)
+			}
+		case req.FormValue("read_write") != "":
+			if err := r.SetInstanceReadOnly(req.Context(), req.FormValue("read_write"), false); err != nil {
+				level.Error(logger).Log("msg", "error setting instance read-write", "err", err)
+			}
+		case req.FormValue("leave") != "":
+			if err := r.RequestInstanceLeave(req.Context(), req.FormValue("leave")); err != nil {
+				level.Error(logger).Log("msg", "error requesting instance to leave", "err", err)
+			}
+		default:
+			ingesterID := req.FormValue("forget")
+			if err := r.forget(req.Context(), ingesterID); err != nil {
+				level.Error(logger).Log("msg", "error forgetting instance", "err", err)
+			}
 		}
 
 		// Implement PRG pattern to prevent double-POST and work with CSRF middleware.
@@ -123,6 +173,9 @@ func (r *Ring) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// Must be called before locking the ring.
+	_, zones := r.GetTokensOwnership()
+
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
 
@@ -156,6 +209,8 @@ func (r *Ring) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			HeartbeatTimestamp  string   `json:"timestamp"`
 			RegisteredTimestamp string   `json:"registered_timestamp"`
 			Zone                string   `json:"zone"`
+			ReadOnly            bool     `json:"read_only"`
+			LeaveRequested      bool     `json:"leave_requested"`
 			Tokens              []uint32 `json:"tokens"`
 			NumTokens           int      `json:"-"`
 			Ownership           float64  `json:"-"`
@@ -167,6 +222,8 @@ func (r *Ring) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			RegisteredTimestamp: registeredTimestamp,
 			Tokens:              ing.Tokens,
 			Zone:                ing.Zone,
+			ReadOnly:            ing.ReadOnly,
+			LeaveRequested:      ing.LeaveRequested,
 			NumTokens:           len(ing.Tokens),
 			Ownership:           (float64(owned[id]) / float64(math.MaxUint32)) * 100,
 		})
@@ -175,11 +232,13 @@ func (r *Ring) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	tokensParam := req.URL.Query().Get("tokens")
 
 	util.RenderHTTPResponse(w, struct {
-		Ingesters  []interface{} `json:"shards"`
-		Now        time.Time     `json:"now"`
-		ShowTokens bool          `json:"-"`
+		Ingesters  []interface{}     `json:"shards"`
+		Zones      []TokensOwnership `json:"zones"`
+		Now        time.Time         `json:"now"`
+		ShowTokens bool              `json:"-"`
 	}{
 		Ingesters:  ingesters,
+		Zones:      zones,
 		Now:        now,
 		ShowTokens: tokensParam == "true",
 	}, pageTemplate, req)
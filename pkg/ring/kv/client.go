@@ -12,6 +12,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
 	"github.com/cortexproject/cortex/pkg/ring/kv/etcd"
 	"github.com/cortexproject/cortex/pkg/ring/kv/memberlist"
+	"github.com/cortexproject/cortex/pkg/ring/kv/redis"
 )
 
 const (
@@ -36,11 +37,12 @@ var inmemoryStoreInit sync.Once
 var inmemoryStore Client
 
 // StoreConfig is a configuration used for building single store client, either
-// Consul, Etcd, Memberlist or MultiClient. It was extracted from Config to keep
+// Consul, Etcd, Redis, Memberlist or MultiClient. It was extracted from Config to keep
 // single-client config separate from final client-config (with all the wrappers)
 type StoreConfig struct {
 	Consul consul.Config `yaml:"consul"`
 	Etcd   etcd.Config   `yaml:"etcd"`
+	Redis  redis.Config  `yaml:"redis"`
 	Multi  MultiConfig   `yaml:"multi"`
 
 	// Function that returns memberlist.KV store to use. By using a function, we can delay
@@ -70,13 +72,14 @@ func (cfg *Config) RegisterFlagsWithPrefix(flagsPrefix, defaultPrefix string, f
 	// be easier to have everything under ring, so ring.consul.<flag-name>
 	cfg.Consul.RegisterFlags(f, flagsPrefix)
 	cfg.Etcd.RegisterFlagsWithPrefix(f, flagsPrefix)
+	cfg.Redis.RegisterFlagsWithPrefix(f, flagsPrefix)
 	cfg.Multi.RegisterFlagsWithPrefix(f, flagsPrefix)
 
 	if flagsPrefix == "" {
 		flagsPrefix = "ring."
 	}
 	f.StringVar(&cfg.Prefix, flagsPrefix+"prefix", defaultPrefix, "The prefix for the keys in the store. Should end with a /.")
-	f.StringVar(&cfg.Store, flagsPrefix+"store", "consul", "Backend storage to use for the ring. Supported values are: consul, etcd, inmemory, memberlist, multi.")
+	f.StringVar(&cfg.Store, flagsPrefix+"store", "consul", "Backend storage to use for the ring. Supported values are: consul, etcd, redis, inmemory, memberlist, multi.")
 }
 
 // Client is a high-level client for key-value stores (such as Etcd and
@@ -112,7 +115,7 @@ type Client interface {
 	WatchPrefix(ctx context.Context, prefix string, f func(string, interface{}) bool)
 }
 
-// NewClient creates a new Client (consul, etcd or inmemory) based on the config,
+// NewClient creates a new Client (consul, etcd, redis or inmemory) based on the config,
 // encodes and decodes data for storage using the codec.
 func NewClient(cfg Config, codec codec.Codec, reg prometheus.Registerer) (Client, error) {
 	if cfg.Mock != nil {
@@ -133,6 +136,9 @@ func createClient(backend string, prefix string, cfg StoreConfig, codec codec.Co
 	case "etcd":
 		client, err = etcd.New(cfg.Etcd, codec)
 
+	case "redis":
+		client, err = redis.NewClient(cfg.Redis, codec)
+
 	case "inmemory":
 		// If we use the in-memory store, make sure everyone gets the same instance
 		// within the same process.
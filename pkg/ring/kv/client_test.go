@@ -5,12 +5,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis"
 	"github.com/gogo/protobuf/proto"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
 	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
+	"github.com/cortexproject/cortex/pkg/util/flagext"
 )
 
 func TestParseConfig(t *testing.T) {
@@ -59,6 +61,36 @@ func Test_createClient_multiBackend_withMultiRing(t *testing.T) {
 	}, "Second client for KV store must not panic")
 }
 
+func Test_createClient_multiBackend_withRedis(t *testing.T) {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	defer server.Close()
+
+	storeCfg := StoreConfig{
+		Multi: MultiConfig{
+			Primary:   "redis",
+			Secondary: "mock",
+		},
+	}
+	flagext.DefaultValues(&storeCfg.Redis)
+	storeCfg.Redis.Endpoint = server.Addr()
+
+	client, err := createClient("multi", "/test/", storeCfg, codec.String{}, Primary, prometheus.NewRegistry())
+	require.NoError(t, err)
+	require.NoError(t, client.CAS(context.Background(), "key", func(_ interface{}) (out interface{}, retry bool, err error) {
+		return "value", false, nil
+	}))
+
+	value, err := client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.Equal(t, "value", value)
+
+	// The value has been written to Redis, under the configured prefix.
+	stored, err := server.Get("/test/key")
+	require.NoError(t, err)
+	require.Equal(t, "value", stored)
+}
+
 func Test_createClient_singleBackend_mustContainRoleAndTypeLabels(t *testing.T) {
 	storeCfg, testCodec := newConfigsForTest()
 	reg := prometheus.NewRegistry()
@@ -15,6 +15,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
 	"github.com/cortexproject/cortex/pkg/ring/kv/etcd"
+	"github.com/cortexproject/cortex/pkg/ring/kv/redis"
 )
 
 func withFixtures(t *testing.T, f func(*testing.T, Client)) {
@@ -28,6 +29,9 @@ func withFixtures(t *testing.T, f func(*testing.T, Client)) {
 		{"etcd", func() (Client, io.Closer, error) {
 			return etcd.Mock(codec.String{})
 		}},
+		{"redis", func() (Client, io.Closer, error) {
+			return redis.Mock(codec.String{})
+		}},
 	} {
 		t.Run(fixture.name, func(t *testing.T) {
 			client, closer, err := fixture.factory()
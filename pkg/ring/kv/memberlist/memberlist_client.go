@@ -73,7 +73,7 @@ func (c *Client) Get(ctx context.Context, key string) (interface{}, error) {
 
 // Delete is part of kv.Client interface.
 func (c *Client) Delete(ctx context.Context, key string) error {
-	return errors.New("memberlist does not support Delete")
+	return ErrDeleteNotSupported
 }
 
 // CAS is part of kv.Client interface
@@ -177,7 +177,7 @@ func (cfg *KVConfig) RegisterFlags(f *flag.FlagSet, prefix string) {
 	f.IntVar(&cfg.MaxJoinRetries, prefix+"memberlist.max-join-retries", 10, "Max number of retries to join other cluster members.")
 	f.BoolVar(&cfg.AbortIfJoinFails, prefix+"memberlist.abort-if-join-fails", true, "If this node fails to join memberlist cluster, abort.")
 	f.DurationVar(&cfg.RejoinInterval, prefix+"memberlist.rejoin-interval", 0, "If not 0, how often to rejoin the cluster. Occasional rejoin can help to fix the cluster split issue, and is harmless otherwise. For example when using only few components as a seed nodes (via -memberlist.join), then it's recommended to use rejoin. If -memberlist.join points to dynamic service that resolves to all gossiping nodes (eg. Kubernetes headless service), then rejoin is not needed.")
-	f.DurationVar(&cfg.LeftIngestersTimeout, prefix+"memberlist.left-ingesters-timeout", 5*time.Minute, "How long to keep LEFT ingesters in the ring.")
+	f.DurationVar(&cfg.LeftIngestersTimeout, prefix+"memberlist.left-ingesters-timeout", 5*time.Minute, "How long to keep LEFT ingesters in the ring, and the HA tracker elected replicas marked for deletion.")
 	f.DurationVar(&cfg.LeaveTimeout, prefix+"memberlist.leave-timeout", 5*time.Second, "Timeout for leaving memberlist cluster.")
 	f.DurationVar(&cfg.GossipInterval, prefix+"memberlist.gossip-interval", 0, "How often to gossip. Uses memberlist LAN defaults if 0.")
 	f.IntVar(&cfg.GossipNodes, prefix+"memberlist.gossip-nodes", 0, "How many nodes to gossip to. Uses memberlist LAN defaults if 0.")
@@ -303,6 +303,9 @@ var (
 	errVersionMismatch  = errors.New("version mismatch")
 	errNoChangeDetected = errors.New("no change detected")
 	errTooManyRetries   = errors.New("too many retries")
+
+	// ErrDeleteNotSupported is returned by Delete, because keys can't be deleted from the gossiping KV store.
+	ErrDeleteNotSupported = errors.New("memberlist does not support Delete")
 )
 
 // NewKV creates new gossip-based KV service. Note that service needs to be started, until then it doesn't initialize
@@ -446,8 +449,19 @@ func (m *KV) running(ctx context.Context) error {
 		tickerChan = t.C
 	}
 
+	var expireChan <-chan time.Time = nil
+	if m.cfg.LeftIngestersTimeout > 0 {
+		t := time.NewTicker(m.cfg.LeftIngestersTimeout)
+		defer t.Stop()
+
+		expireChan = t.C
+	}
+
 	for {
 		select {
+		case <-expireChan:
+			m.removeExpiredValues(time.Now().Add(-m.cfg.LeftIngestersTimeout))
+
 		case <-tickerChan:
 			members := m.discoverMembers(ctx, m.cfg.JoinMembers)
 
@@ -465,6 +479,37 @@ func (m *KV) running(ctx context.Context) error {
 	}
 }
 
+// removeExpiredValues removes from the store the Expirable values which have no content left
+// once the tombstones older than limit have been removed.
+func (m *KV) removeExpiredValues(limit time.Time) {
+	m.storeMu.Lock()
+	defer m.storeMu.Unlock()
+
+	for key, v := range m.store {
+		codec := m.GetCodec(v.codecID)
+		if codec == nil || v.value == nil {
+			continue
+		}
+
+		decoded, err := codec.Decode(v.value)
+		if err != nil {
+			level.Warn(m.logger).Log("msg", "failed to decode value while removing expired values", "key", key, "err", err)
+			continue
+		}
+
+		e, ok := decoded.(Expirable)
+		if !ok {
+			continue
+		}
+
+		decoded.(Mergeable).RemoveTombstones(limit)
+		if e.Expired() {
+			level.Debug(m.logger).Log("msg", "removing expired value", "key", key)
+			delete(m.store, key)
+		}
+	}
+}
+
 // GetCodec returns codec for given ID or nil.
 func (m *KV) GetCodec(codecID string) codec.Codec {
 	return m.codecs[codecID]
@@ -1191,6 +1236,12 @@ func (m *KV) mergeValueForKey(key string, incomingValue Mergeable, casVersion ui
 	if m.cfg.LeftIngestersTimeout > 0 {
 		limit := time.Now().Add(-m.cfg.LeftIngestersTimeout)
 		result.RemoveTombstones(limit)
+
+		// Don't store back values that have already expired.
+		if e, ok := result.(Expirable); ok && e.Expired() {
+			delete(m.store, key)
+			return nil, 0, nil
+		}
 	}
 
 	encoded, err := codec.Encode(result)
@@ -40,3 +40,12 @@ type Mergeable interface {
 	// time when client is accessing value from the store. It can be used to hide tombstones from the clients.
 	RemoveTombstones(limit time.Time)
 }
+
+// Expirable is an optional interface of the Mergeable values which can be removed from the store
+// altogether once their tombstones have been removed. Keys can't be deleted from the gossiping
+// KV store, so such values are dropped by each member on its own once expired, and expired values
+// received from other members are not stored back.
+type Expirable interface {
+	// Expired returns whether the value has no content left after its tombstones have been removed.
+	Expired() bool
+}
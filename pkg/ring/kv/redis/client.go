@@ -0,0 +1,370 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
+	"github.com/cortexproject/cortex/pkg/util/flagext"
+	util_log "github.com/cortexproject/cortex/pkg/util/log"
+)
+
+// Config for a new redis.Client.
+type Config struct {
+	Endpoint           string         `yaml:"endpoint"`
+	MasterName         string         `yaml:"master_name"`
+	DB                 int            `yaml:"db"`
+	Password           flagext.Secret `yaml:"password"`
+	Timeout            time.Duration  `yaml:"timeout"`
+	MaxRetries         int            `yaml:"max_retries"`
+	WatchPollInterval  time.Duration  `yaml:"watch_poll_interval"`
+	EnableTLS          bool           `yaml:"tls_enabled"`
+	InsecureSkipVerify bool           `yaml:"tls_insecure_skip_verify"`
+}
+
+// RegisterFlags adds the flags required to config this to the given FlagSet.
+func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
+	cfg.RegisterFlagsWithPrefix(f, "")
+}
+
+// RegisterFlagsWithPrefix adds the flags required to config this to the given FlagSet.
+func (cfg *Config) RegisterFlagsWithPrefix(f *flag.FlagSet, prefix string) {
+	f.StringVar(&cfg.Endpoint, prefix+"redis.endpoint", "", "Redis Server endpoint to connect to. A comma-separated list of endpoints for Redis Cluster or Redis Sentinel.")
+	f.StringVar(&cfg.MasterName, prefix+"redis.master-name", "", "Redis Sentinel master name. An empty string for Redis Server or Redis Cluster.")
+	f.IntVar(&cfg.DB, prefix+"redis.db", 0, "Database index.")
+	f.Var(&cfg.Password, prefix+"redis.password", "Password to use when connecting to Redis.")
+	f.DurationVar(&cfg.Timeout, prefix+"redis.timeout", 5*time.Second, "Maximum time to wait before giving up on Redis requests.")
+	f.IntVar(&cfg.MaxRetries, prefix+"redis.max-retries", 10, "The maximum number of retries to do for failed or conflicting CAS operations.")
+	f.DurationVar(&cfg.WatchPollInterval, prefix+"redis.watch-poll-interval", time.Second, "How frequently the watched keys are polled for changes.")
+	f.BoolVar(&cfg.EnableTLS, prefix+"redis.tls-enabled", false, "Enable connecting to Redis with TLS.")
+	f.BoolVar(&cfg.InsecureSkipVerify, prefix+"redis.tls-insecure-skip-verify", false, "Skip validating server certificate.")
+}
+
+// Client implements kv.Client for Redis. CAS operations are implemented with a Lua script
+// comparing the current value with the one passed to the callback, while watches are
+// implemented polling the keys, given keyspace notifications are not enabled by default on
+// Redis servers.
+type Client struct {
+	cfg   Config
+	codec codec.Codec
+	rdb   redis.UniversalClient
+}
+
+// NewClient makes a new Client.
+func NewClient(cfg Config, codec codec.Codec) (*Client, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("no Redis endpoint configured")
+	}
+
+	opt := &redis.UniversalOptions{
+		Addrs:        strings.Split(cfg.Endpoint, ","),
+		MasterName:   cfg.MasterName,
+		Password:     cfg.Password.Value,
+		DB:           cfg.DB,
+		DialTimeout:  cfg.Timeout,
+		ReadTimeout:  cfg.Timeout,
+		WriteTimeout: cfg.Timeout,
+	}
+	if cfg.EnableTLS {
+		opt.TLSConfig = &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+	}
+
+	return &Client{
+		cfg:   cfg,
+		codec: codec,
+		rdb:   redis.NewUniversalClient(opt),
+	}, nil
+}
+
+// casScript atomically sets the key to the new value (ARGV[2]) only if the SHA1 of the
+// current value matches the expected one (ARGV[1]), or the key doesn't exist and the
+// expected SHA1 is empty. Returns 1 if the value has been set, 0 otherwise.
+var casScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current then
+	if redis.sha1hex(current) ~= ARGV[1] then
+		return 0
+	end
+elseif ARGV[1] ~= "" then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2])
+return 1
+`)
+
+// CAS implements kv.Client.
+func (c *Client) CAS(ctx context.Context, key string, f func(in interface{}) (out interface{}, retry bool, err error)) error {
+	var lastErr error
+
+	for i := 0; i < c.cfg.MaxRetries; i++ {
+		var intermediate interface{}
+		var expected string
+
+		buf, err := c.rdb.Get(ctx, key).Bytes()
+		if err != nil && err != redis.Nil {
+			level.Error(util_log.Logger).Log("msg", "error getting key", "key", key, "err", err)
+			lastErr = err
+			continue
+		}
+		if err == nil {
+			intermediate, err = c.codec.Decode(buf)
+			if err != nil {
+				level.Error(util_log.Logger).Log("msg", "error decoding key", "key", key, "err", err)
+				lastErr = err
+				continue
+			}
+			expected = fmt.Sprintf("%x", sha1.Sum(buf))
+		}
+
+		var retry bool
+		intermediate, retry, err = f(intermediate)
+		if err != nil {
+			if !retry {
+				return err
+			}
+			lastErr = err
+			continue
+		}
+
+		// Callback returning nil means it doesn't want to CAS anymore.
+		if intermediate == nil {
+			return nil
+		}
+
+		buf, err = c.codec.Encode(intermediate)
+		if err != nil {
+			level.Error(util_log.Logger).Log("msg", "error serialising value", "key", key, "err", err)
+			lastErr = err
+			continue
+		}
+
+		set, err := casScript.Run(ctx, c.rdb, []string{key}, expected, buf).Int()
+		if err != nil {
+			level.Error(util_log.Logger).Log("msg", "error CASing", "key", key, "err", err)
+			lastErr = err
+			continue
+		}
+		// The value is not set if the key has been modified in the meanwhile.
+		if set == 0 {
+			level.Debug(util_log.Logger).Log("msg", "failed to CAS, key has been modified concurrently", "key", key)
+			continue
+		}
+
+		return nil
+	}
+
+	if lastErr != nil {
+		return lastErr
+	}
+	return fmt.Errorf("failed to CAS %s", key)
+}
+
+// WatchKey implements kv.Client.
+func (c *Client) WatchKey(ctx context.Context, key string, f func(interface{}) bool) {
+	var prev []byte
+
+	ticker := time.NewTicker(c.cfg.WatchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		buf, err := c.rdb.Get(ctx, key).Bytes()
+		switch {
+		case err == redis.Nil:
+			// The key doesn't exist yet, so there's nothing to notify.
+		case err != nil:
+			if ctx.Err() == nil {
+				level.Error(util_log.Logger).Log("msg", "error getting key", "key", key, "err", err)
+			}
+		case !bytes.Equal(buf, prev):
+			prev = buf
+
+			out, err := c.codec.Decode(buf)
+			if err != nil {
+				level.Error(util_log.Logger).Log("msg", "error decoding key", "key", key, "err", err)
+				break
+			}
+
+			if !f(out) {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchPrefix implements kv.Client.
+func (c *Client) WatchPrefix(ctx context.Context, prefix string, f func(string, interface{}) bool) {
+	prev := map[string][]byte{}
+
+	ticker := time.NewTicker(c.cfg.WatchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		values, err := c.getPrefix(ctx, prefix)
+		if err != nil {
+			if ctx.Err() == nil {
+				level.Error(util_log.Logger).Log("msg", "error getting keys", "prefix", prefix, "err", err)
+			}
+		} else {
+			// Deleted keys are not notified, since not all KV store clients (and
+			// Cortex codecs) support it.
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				buf := values[key]
+				if prevBuf, ok := prev[key]; ok && bytes.Equal(buf, prevBuf) {
+					continue
+				}
+
+				out, err := c.codec.Decode(buf)
+				if err != nil {
+					level.Error(util_log.Logger).Log("msg", "error decoding key", "key", key, "err", err)
+					continue
+				}
+
+				if !f(key, out) {
+					return
+				}
+			}
+
+			prev = values
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// List implements kv.Client.
+func (c *Client) List(ctx context.Context, prefix string) ([]string, error) {
+	return c.scanKeys(ctx, prefix)
+}
+
+// Get implements kv.Client.
+func (c *Client) Get(ctx context.Context, key string) (interface{}, error) {
+	buf, err := c.rdb.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.codec.Decode(buf)
+}
+
+// Delete implements kv.Client.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.rdb.Del(ctx, key).Err()
+}
+
+// getPrefix returns the raw values of all the keys with the given prefix.
+func (c *Client) getPrefix(ctx context.Context, prefix string) (map[string][]byte, error) {
+	keys, err := c.scanKeys(ctx, prefix)
+	if err != nil || len(keys) == 0 {
+		return nil, err
+	}
+
+	// Keys may belong to different slots when running Redis Cluster, so we can't use
+	// MGET. The cluster client splits the pipeline by node.
+	cmds, err := c.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make(map[string][]byte, len(keys))
+	for i, cmd := range cmds {
+		// The key may have been deleted in the meanwhile.
+		buf, err := cmd.(*redis.StringCmd).Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[keys[i]] = buf
+	}
+	return values, nil
+}
+
+// scanKeys returns all the keys with the given prefix. When running Redis Cluster,
+// all the master nodes are scanned.
+func (c *Client) scanKeys(ctx context.Context, prefix string) ([]string, error) {
+	var (
+		mtx  sync.Mutex
+		keys []string
+	)
+
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		iter := client.Scan(ctx, 0, escapeGlob(prefix)+"*", 1000).Iterator()
+		for iter.Next(ctx) {
+			mtx.Lock()
+			keys = append(keys, iter.Val())
+			mtx.Unlock()
+		}
+		return iter.Err()
+	}
+
+	var err error
+	if cluster, ok := c.rdb.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return scan(ctx, client)
+		})
+	} else {
+		err = scan(ctx, c.rdb)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// SCAN may return the same key multiple times.
+	sort.Strings(keys)
+	unique := keys[:0]
+	for i, key := range keys {
+		if i == 0 || key != keys[i-1] {
+			unique = append(unique, key)
+		}
+	}
+	return unique, nil
+}
+
+// escapeGlob escapes the special characters of the glob-style patterns used by SCAN.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
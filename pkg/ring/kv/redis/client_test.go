@@ -0,0 +1,109 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
+)
+
+func TestClient_CAS_ShouldRetryOnConcurrentUpdates(t *testing.T) {
+	const (
+		key         = "counter"
+		concurrency = 10
+		increments  = 10
+	)
+
+	client, closer, err := Mock(codec.String{})
+	require.NoError(t, err)
+	defer closer.Close() //nolint:errcheck
+
+	// Allow enough retries for all the concurrent updates to eventually succeed.
+	client.cfg.MaxRetries = concurrency * increments
+
+	wg := sync.WaitGroup{}
+	wg.Add(concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < increments; j++ {
+				err := client.CAS(context.Background(), key, func(in interface{}) (out interface{}, retry bool, err error) {
+					value := 0
+					if in != nil {
+						value, err = strconv.Atoi(in.(string))
+						if err != nil {
+							return nil, false, err
+						}
+					}
+					return strconv.Itoa(value + 1), true, nil
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	value, err := client.Get(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(concurrency*increments), value)
+}
+
+func TestClient_CAS_ShouldReturnCallbackErrorWithoutRetrying(t *testing.T) {
+	client, closer, err := Mock(codec.String{})
+	require.NoError(t, err)
+	defer closer.Close() //nolint:errcheck
+
+	expectedErr := errors.New("callback error")
+	calls := 0
+
+	err = client.CAS(context.Background(), "key", func(in interface{}) (out interface{}, retry bool, err error) {
+		calls++
+		return nil, false, expectedErr
+	})
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 1, calls)
+
+	value, err := client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClient_ListAndDelete(t *testing.T) {
+	ctx := context.Background()
+	client, closer, err := Mock(codec.String{})
+	require.NoError(t, err)
+	defer closer.Close() //nolint:errcheck
+
+	// The prefix contains characters having a special meaning in the SCAN pattern.
+	for _, key := range []string{"prefix*/a", "prefix*/b", "prefix-other/c"} {
+		require.NoError(t, client.CAS(ctx, key, func(in interface{}) (out interface{}, retry bool, err error) {
+			return key, false, nil
+		}))
+	}
+
+	keys, err := client.List(ctx, "prefix*/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prefix*/a", "prefix*/b"}, keys)
+
+	require.NoError(t, client.Delete(ctx, "prefix*/a"))
+
+	// Deleting a non existing key is not an error.
+	require.NoError(t, client.Delete(ctx, "prefix*/a"))
+
+	keys, err = client.List(ctx, "prefix*/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prefix*/b"}, keys)
+
+	value, err := client.Get(ctx, "prefix*/a")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"io"
+	"time"
+
+	"github.com/alicebob/miniredis"
+
+	"github.com/cortexproject/cortex/pkg/ring/kv/codec"
+	"github.com/cortexproject/cortex/pkg/util/flagext"
+)
+
+// Mock returns a Redis client backed by an in-process Redis server.
+func Mock(codec codec.Codec) (*Client, io.Closer, error) {
+	server, err := miniredis.Run()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cfg Config
+	flagext.DefaultValues(&cfg)
+	cfg.Endpoint = server.Addr()
+	cfg.WatchPollInterval = 5 * time.Millisecond
+
+	client, err := NewClient(cfg, codec)
+	if err != nil {
+		server.Close()
+		return nil, nil, err
+	}
+
+	closer := closerFunc(func() error {
+		err := client.rdb.Close()
+		server.Close()
+		return err
+	})
+
+	return client, closer, nil
+}
+
+type closerFunc func() error
+
+// Close implements io.Closer.
+func (f closerFunc) Close() error {
+	return f()
+}
@@ -17,6 +17,7 @@ import (
 	"go.uber.org/atomic"
 
 	"github.com/cortexproject/cortex/pkg/ring/kv"
+	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 	"github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/services"
@@ -126,6 +127,13 @@ type Lifecycler struct {
 	flushOnShutdown      *atomic.Bool
 	unregisterOnShutdown *atomic.Bool
 
+	// Whether an operator requested the instance to leave the ring. Updated at every
+	// heartbeat, and only accessed from the lifecycler loop.
+	leaveRequested bool
+	// Whether the lifecycler is leaving the ring as requested by an operator, in which case
+	// the process is stopped once the instance has left. Only accessed from the service goroutine.
+	leavingAsRequested bool
+
 	// We need to remember the ingester state, tokens and registered timestamp just in case the KV store
 	// goes away and comes back empty. The state changes during lifecycle of instance.
 	stateMtx     sync.RWMutex
@@ -448,6 +456,16 @@ func (i *Lifecycler) loop(ctx context.Context) error {
 				level.Error(log.Logger).Log("msg", "failed to write to the KV store, sleeping", "ring", i.RingName, "err", err)
 			}
 
+			// Exiting the loop without errors gracefully stops the lifecycler, which
+			// leaves the ring. Given the instance has been requested to leave the ring,
+			// we make sure it's also unregistered.
+			if i.leaveRequested {
+				level.Info(log.Logger).Log("msg", "leaving the ring as requested by an operator", "ring", i.RingName)
+				i.SetUnregisterOnShutdown(true)
+				i.leavingAsRequested = true
+				return nil
+			}
+
 		case f := <-i.actorChan:
 			f()
 
@@ -507,6 +525,12 @@ heartbeatLoop:
 		level.Info(log.Logger).Log("msg", "instance removed from the KV store", "ring", i.RingName)
 	}
 
+	// The instance is not part of the ring anymore, so the process is stopped instead of
+	// serving without receiving any traffic.
+	if i.leavingAsRequested {
+		return util.ErrStopProcess
+	}
+
 	return nil
 }
 
@@ -564,6 +588,14 @@ func (i *Lifecycler) initRing(ctx context.Context) error {
 		// but we need to update the local state accordingly.
 		i.setRegisteredAt(instanceDesc.GetRegisteredAt())
 
+		// Discard any leave request the instance didn't process before being restarted.
+		changed := false
+		if instanceDesc.LeaveRequested {
+			instanceDesc.LeaveRequested = false
+			ringDesc.Ingesters[i.ID] = instanceDesc
+			changed = true
+		}
+
 		// If the ingester is in the JOINING state this means it crashed due to
 		// a failed token transfer or some other reason during startup. We want
 		// to set it back to PENDING in order to start the lifecycle from the
@@ -587,6 +619,10 @@ func (i *Lifecycler) initRing(ctx context.Context) error {
 		i.setTokens(tokens)
 
 		level.Info(log.Logger).Log("msg", "existing entry found in ring", "state", i.GetState(), "tokens", len(tokens), "ring", i.RingName)
+		if changed {
+			return ringDesc, true, nil
+		}
+
 		// we haven't modified the ring, don't try to store it.
 		return nil, true, nil
 	})
@@ -725,6 +761,7 @@ func (i *Lifecycler) updateConsul(ctx context.Context) error {
 			instanceDesc.Zone = i.Zone
 			instanceDesc.RegisteredTimestamp = i.getRegisteredAt().Unix()
 			ringDesc.Ingesters[i.ID] = instanceDesc
+			i.leaveRequested = instanceDesc.LeaveRequested
 		}
 
 		return ringDesc, true, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
+	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 	"github.com/cortexproject/cortex/pkg/util/services"
 	"github.com/cortexproject/cortex/pkg/util/test"
@@ -89,6 +90,43 @@ func TestLifecycler_HealthyInstancesCount(t *testing.T) {
 	})
 }
 
+func TestLifecycler_LeaveOnRequest(t *testing.T) {
+	var ringConfig Config
+	flagext.DefaultValues(&ringConfig)
+	ringConfig.KVStore.Mock = consul.NewInMemoryClient(GetCodec())
+
+	ctx := context.Background()
+
+	r, err := New(ringConfig, "ingester", IngesterRingKey, nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(ctx, r))
+	defer services.StopAndAwaitTerminated(ctx, r) // nolint:errcheck
+
+	lifecyclerConfig := testLifecyclerConfig(ringConfig, "ing1")
+	lifecyclerConfig.JoinAfter = 0
+
+	lifecycler, err := NewLifecycler(lifecyclerConfig, &nopFlushTransferer{}, "ingester", IngesterRingKey, false, nil)
+	require.NoError(t, err)
+	require.NoError(t, services.StartAndAwaitRunning(ctx, lifecycler))
+	defer services.StopAndAwaitTerminated(ctx, lifecycler) // nolint:errcheck
+
+	test.Poll(t, time.Second, true, func() interface{} {
+		return lifecycler.HealthyInstancesCount() == 1
+	})
+
+	// Request the instance to leave the ring, and assert it gets unregistered even if
+	// the lifecycler has been configured to not unregister on shutdown, and the process
+	// is requested to stop.
+	require.NoError(t, r.RequestInstanceLeave(ctx, "ing1"))
+	require.Error(t, lifecycler.AwaitTerminated(ctx))
+	assert.Equal(t, util.ErrStopProcess, lifecycler.FailureCase())
+
+	d, err := ringConfig.KVStore.Mock.Get(ctx, IngesterRingKey)
+	require.NoError(t, err)
+	_, ok := GetOrCreateRingDesc(d).Ingesters["ing1"]
+	assert.False(t, ok)
+}
+
 func TestLifecycler_ZonesCount(t *testing.T) {
 	var ringConfig Config
 	flagext.DefaultValues(&ringConfig)
@@ -37,7 +37,8 @@ func NewDesc() *Desc {
 }
 
 // AddIngester adds the given ingester to the ring. Ingester will only use supplied tokens,
-// any other tokens are removed.
+// any other tokens are removed. If the ingester already exists in the ring, the read-only
+// flag set by an operator is preserved, while any pending leave request is discarded.
 func (d *Desc) AddIngester(id, addr, zone string, tokens []uint32, state IngesterState, registeredAt time.Time) InstanceDesc {
 	if d.Ingesters == nil {
 		d.Ingesters = map[string]InstanceDesc{}
@@ -55,6 +56,7 @@ func (d *Desc) AddIngester(id, addr, zone string, tokens []uint32, state Ingeste
 		State:               state,
 		Tokens:              tokens,
 		Zone:                zone,
+		ReadOnly:            d.Ingesters[id].ReadOnly,
 	}
 
 	d.Ingesters[id] = ingester
@@ -500,6 +502,10 @@ func (d *Desc) RingCompare(o *Desc) CompareResult {
 		if ing.State != oing.State {
 			equalStatesAndTimestamps = false
 		}
+
+		if ing.ReadOnly != oing.ReadOnly || ing.LeaveRequested != oing.LeaveRequested {
+			equalStatesAndTimestamps = false
+		}
 	}
 
 	if equalStatesAndTimestamps {
@@ -111,6 +111,19 @@ func normalizedOutput() *Desc {
 	}
 }
 
+func TestDesc_AddIngester_ShouldPreserveReadOnlyAndDiscardLeaveRequest(t *testing.T) {
+	d := NewDesc()
+	d.Ingesters["instance-1"] = InstanceDesc{Addr: "127.0.0.1", State: ACTIVE, ReadOnly: true, LeaveRequested: true}
+
+	instance := d.AddIngester("instance-1", "127.0.0.2", "zone-a", []uint32{1}, JOINING, time.Now())
+	assert.True(t, instance.ReadOnly)
+	assert.False(t, instance.LeaveRequested)
+	assert.Equal(t, instance, d.Ingesters["instance-1"])
+
+	instance = d.AddIngester("instance-2", "127.0.0.3", "zone-a", []uint32{2}, JOINING, time.Now())
+	assert.False(t, instance.ReadOnly)
+}
+
 func TestClaimTokensFromNormalizedToNormalized(t *testing.T) {
 	r := normalizedSource()
 	result := r.ClaimTokens("first", "second")
@@ -84,7 +84,7 @@ type ReadRing interface {
 
 var (
 	// Write operation that also extends replica set, if instance state is not ACTIVE.
-	Write = NewOp([]IngesterState{ACTIVE}, func(s IngesterState) bool {
+	Write = NewWriteOp([]IngesterState{ACTIVE}, func(s IngesterState) bool {
 		// We do not want to Write to instances that are not ACTIVE, but we do want
 		// to write the extra replica somewhere.  So we increase the size of the set
 		// of replicas for the key.
@@ -93,7 +93,7 @@ var (
 	})
 
 	// WriteNoExtend is like Write, but with no replicaset extension.
-	WriteNoExtend = NewOp([]IngesterState{ACTIVE}, nil)
+	WriteNoExtend = NewWriteOp([]IngesterState{ACTIVE}, nil)
 
 	Read = NewOp([]IngesterState{ACTIVE, PENDING, LEAVING}, func(s IngesterState) bool {
 		// To match Write with extended replica set we have to also increase the
@@ -342,6 +342,22 @@ func (r *Ring) Get(key uint32, op Operation, bufDescs []InstanceDesc, bufHosts,
 			continue
 		}
 
+		instance := r.ringDesc.Ingesters[info.InstanceID]
+
+		// Read-only instances don't receive writes, so they're excluded from the replica set
+		// of write operations, while the replica set of any other operation is extended to
+		// include the instance which received the writes in place of the read-only one. In
+		// both cases, a read-only instance doesn't count as the replica of its zone.
+		if instance.ReadOnly {
+			distinctHosts = append(distinctHosts, info.InstanceID)
+			n++
+
+			if !op.IsWrite() {
+				instances = append(instances, instance)
+			}
+			continue
+		}
+
 		// Ignore if the instances don't have a zone set.
 		if r.cfg.ZoneAwarenessEnabled && info.Zone != "" {
 			if util.StringsContain(distinctZones, info.Zone) {
@@ -351,7 +367,6 @@ func (r *Ring) Get(key uint32, op Operation, bufDescs []InstanceDesc, bufHosts,
 		}
 
 		distinctHosts = append(distinctHosts, info.InstanceID)
-		instance := r.ringDesc.Ingesters[info.InstanceID]
 
 		// Check whether the replica set should be extended given we're including
 		// this instance.
@@ -783,12 +798,14 @@ func (r *Ring) getCachedShuffledSubring(identifier string, size int) *Ring {
 	cached.mtx.Lock()
 	defer cached.mtx.Unlock()
 
-	// Update instance states and timestamps. We know that the topology is the same,
-	// so zones and tokens are equal.
+	// Update instance states, timestamps and flags set by operators. We know that the
+	// topology is the same, so zones and tokens are equal.
 	for name, cachedIng := range cached.ringDesc.Ingesters {
 		ing := r.ringDesc.Ingesters[name]
 		cachedIng.State = ing.State
 		cachedIng.Timestamp = ing.Timestamp
+		cachedIng.ReadOnly = ing.ReadOnly
+		cachedIng.LeaveRequested = ing.LeaveRequested
 		cached.ringDesc.Ingesters[name] = cachedIng
 	}
 	return cached
@@ -828,8 +845,12 @@ func (r *Ring) CleanupShuffleShardCache(identifier string) {
 // Operation describes which instances can be included in the replica set, based on their state.
 //
 // Implemented as bitmap, with upper 16-bits used for encoding extendReplicaSet, and lower 16-bits used for encoding healthy states.
+// The most significant bit is used to mark write operations.
 type Operation uint32
 
+// writeOperation is the bit marking write operations.
+const writeOperation = Operation(1 << 31)
+
 // NewOp constructs new Operation with given "healthy" states for operation, and optional function to extend replica set.
 // Result of calling shouldExtendReplicaSet is cached.
 func NewOp(healthyStates []IngesterState, shouldExtendReplicaSet func(s IngesterState) bool) Operation {
@@ -849,6 +870,17 @@ func NewOp(healthyStates []IngesterState, shouldExtendReplicaSet func(s Ingester
 	return op
 }
 
+// NewWriteOp is like NewOp, but the returned Operation is a write operation, which never
+// includes read-only instances in the replica set.
+func NewWriteOp(healthyStates []IngesterState, shouldExtendReplicaSet func(s IngesterState) bool) Operation {
+	return NewOp(healthyStates, shouldExtendReplicaSet) | writeOperation
+}
+
+// IsWrite returns whether the operation is a write operation.
+func (op Operation) IsWrite() bool {
+	return op&writeOperation > 0
+}
+
 // IsInstanceInStateHealthy is used during "filtering" phase to remove undesired instances based on their state.
 func (op Operation) IsInstanceInStateHealthy(s IngesterState) bool {
 	return op&(1<<s) > 0
@@ -4,10 +4,15 @@
 package ring
 
 import (
+	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -124,6 +129,13 @@ type InstanceDesc struct {
 	// was already registered before "now". If unknown (0), it should be left as is, and the
 	// Cortex code will properly deal with that.
 	RegisteredTimestamp int64 `protobuf:"varint,8,opt,name=registered_timestamp,json=registeredTimestamp,proto3" json:"registered_timestamp,omitempty"`
+	// Whether the instance has been marked as read-only by an operator. Read-only
+	// instances are excluded from the replica set of write operations, so that they
+	// don't receive any new write, while they're still queried.
+	ReadOnly bool `protobuf:"varint,9,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// Whether an operator has requested the instance to gracefully leave the ring.
+	// The instance leaves the ring as soon as its lifecycler notices the request.
+	LeaveRequested bool `protobuf:"varint,10,opt,name=leave_requested,json=leaveRequested,proto3" json:"leave_requested,omitempty"`
 }
 
 func (m *InstanceDesc) Reset()      { *m = InstanceDesc{} }
@@ -200,44 +212,418 @@ func (m *InstanceDesc) GetRegisteredTimestamp() int64 {
 	return 0
 }
 
+func (m *InstanceDesc) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *InstanceDesc) GetLeaveRequested() bool {
+	if m != nil {
+		return m.LeaveRequested
+	}
+	return false
+}
+
+type SetInstanceReadOnlyRequest struct {
+	Ring       string `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`
+	InstanceId string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	ReadOnly   bool   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+}
+
+func (m *SetInstanceReadOnlyRequest) Reset()      { *m = SetInstanceReadOnlyRequest{} }
+func (*SetInstanceReadOnlyRequest) ProtoMessage() {}
+func (*SetInstanceReadOnlyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{2}
+}
+func (m *SetInstanceReadOnlyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetInstanceReadOnlyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetInstanceReadOnlyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetInstanceReadOnlyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetInstanceReadOnlyRequest.Merge(m, src)
+}
+func (m *SetInstanceReadOnlyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetInstanceReadOnlyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetInstanceReadOnlyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetInstanceReadOnlyRequest proto.InternalMessageInfo
+
+func (m *SetInstanceReadOnlyRequest) GetRing() string {
+	if m != nil {
+		return m.Ring
+	}
+	return ""
+}
+
+func (m *SetInstanceReadOnlyRequest) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *SetInstanceReadOnlyRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type SetInstanceReadOnlyResponse struct {
+}
+
+func (m *SetInstanceReadOnlyResponse) Reset()      { *m = SetInstanceReadOnlyResponse{} }
+func (*SetInstanceReadOnlyResponse) ProtoMessage() {}
+func (*SetInstanceReadOnlyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{3}
+}
+func (m *SetInstanceReadOnlyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetInstanceReadOnlyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetInstanceReadOnlyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetInstanceReadOnlyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetInstanceReadOnlyResponse.Merge(m, src)
+}
+func (m *SetInstanceReadOnlyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetInstanceReadOnlyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetInstanceReadOnlyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetInstanceReadOnlyResponse proto.InternalMessageInfo
+
+type RequestInstanceLeaveRequest struct {
+	Ring       string `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`
+	InstanceId string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+}
+
+func (m *RequestInstanceLeaveRequest) Reset()      { *m = RequestInstanceLeaveRequest{} }
+func (*RequestInstanceLeaveRequest) ProtoMessage() {}
+func (*RequestInstanceLeaveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{4}
+}
+func (m *RequestInstanceLeaveRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestInstanceLeaveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestInstanceLeaveRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestInstanceLeaveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestInstanceLeaveRequest.Merge(m, src)
+}
+func (m *RequestInstanceLeaveRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestInstanceLeaveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestInstanceLeaveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestInstanceLeaveRequest proto.InternalMessageInfo
+
+func (m *RequestInstanceLeaveRequest) GetRing() string {
+	if m != nil {
+		return m.Ring
+	}
+	return ""
+}
+
+func (m *RequestInstanceLeaveRequest) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+type RequestInstanceLeaveResponse struct {
+}
+
+func (m *RequestInstanceLeaveResponse) Reset()      { *m = RequestInstanceLeaveResponse{} }
+func (*RequestInstanceLeaveResponse) ProtoMessage() {}
+func (*RequestInstanceLeaveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{5}
+}
+func (m *RequestInstanceLeaveResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestInstanceLeaveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestInstanceLeaveResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestInstanceLeaveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestInstanceLeaveResponse.Merge(m, src)
+}
+func (m *RequestInstanceLeaveResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestInstanceLeaveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestInstanceLeaveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestInstanceLeaveResponse proto.InternalMessageInfo
+
+type GetTokensOwnershipRequest struct {
+	Ring string `protobuf:"bytes,1,opt,name=ring,proto3" json:"ring,omitempty"`
+}
+
+func (m *GetTokensOwnershipRequest) Reset()      { *m = GetTokensOwnershipRequest{} }
+func (*GetTokensOwnershipRequest) ProtoMessage() {}
+func (*GetTokensOwnershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{6}
+}
+func (m *GetTokensOwnershipRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTokensOwnershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTokensOwnershipRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetTokensOwnershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTokensOwnershipRequest.Merge(m, src)
+}
+func (m *GetTokensOwnershipRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTokensOwnershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTokensOwnershipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTokensOwnershipRequest proto.InternalMessageInfo
+
+func (m *GetTokensOwnershipRequest) GetRing() string {
+	if m != nil {
+		return m.Ring
+	}
+	return ""
+}
+
+type GetTokensOwnershipResponse struct {
+	Instances []TokensOwnership `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances"`
+	Zones     []TokensOwnership `protobuf:"bytes,2,rep,name=zones,proto3" json:"zones"`
+}
+
+func (m *GetTokensOwnershipResponse) Reset()      { *m = GetTokensOwnershipResponse{} }
+func (*GetTokensOwnershipResponse) ProtoMessage() {}
+func (*GetTokensOwnershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{7}
+}
+func (m *GetTokensOwnershipResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTokensOwnershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTokensOwnershipResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetTokensOwnershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTokensOwnershipResponse.Merge(m, src)
+}
+func (m *GetTokensOwnershipResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTokensOwnershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTokensOwnershipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTokensOwnershipResponse proto.InternalMessageInfo
+
+func (m *GetTokensOwnershipResponse) GetInstances() []TokensOwnership {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+func (m *GetTokensOwnershipResponse) GetZones() []TokensOwnership {
+	if m != nil {
+		return m.Zones
+	}
+	return nil
+}
+
+type TokensOwnership struct {
+	// The instance ID or the zone name.
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NumTokens uint32 `protobuf:"varint,2,opt,name=num_tokens,json=numTokens,proto3" json:"num_tokens,omitempty"`
+	// Percentage (0-100) of the tokens ring owned.
+	Ownership float64 `protobuf:"fixed64,3,opt,name=ownership,proto3" json:"ownership,omitempty"`
+}
+
+func (m *TokensOwnership) Reset()      { *m = TokensOwnership{} }
+func (*TokensOwnership) ProtoMessage() {}
+func (*TokensOwnership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_26381ed67e202a6e, []int{8}
+}
+func (m *TokensOwnership) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TokensOwnership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokensOwnership.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TokensOwnership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokensOwnership.Merge(m, src)
+}
+func (m *TokensOwnership) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokensOwnership) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokensOwnership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokensOwnership proto.InternalMessageInfo
+
+func (m *TokensOwnership) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TokensOwnership) GetNumTokens() uint32 {
+	if m != nil {
+		return m.NumTokens
+	}
+	return 0
+}
+
+func (m *TokensOwnership) GetOwnership() float64 {
+	if m != nil {
+		return m.Ownership
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("ring.IngesterState", IngesterState_name, IngesterState_value)
 	proto.RegisterType((*Desc)(nil), "ring.Desc")
 	proto.RegisterMapType((map[string]InstanceDesc)(nil), "ring.Desc.IngestersEntry")
 	proto.RegisterType((*InstanceDesc)(nil), "ring.InstanceDesc")
+	proto.RegisterType((*SetInstanceReadOnlyRequest)(nil), "ring.SetInstanceReadOnlyRequest")
+	proto.RegisterType((*SetInstanceReadOnlyResponse)(nil), "ring.SetInstanceReadOnlyResponse")
+	proto.RegisterType((*RequestInstanceLeaveRequest)(nil), "ring.RequestInstanceLeaveRequest")
+	proto.RegisterType((*RequestInstanceLeaveResponse)(nil), "ring.RequestInstanceLeaveResponse")
+	proto.RegisterType((*GetTokensOwnershipRequest)(nil), "ring.GetTokensOwnershipRequest")
+	proto.RegisterType((*GetTokensOwnershipResponse)(nil), "ring.GetTokensOwnershipResponse")
+	proto.RegisterType((*TokensOwnership)(nil), "ring.TokensOwnership")
 }
 
 func init() { proto.RegisterFile("ring.proto", fileDescriptor_26381ed67e202a6e) }
 
 var fileDescriptor_26381ed67e202a6e = []byte{
-	// 427 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0x31, 0x6f, 0xd3, 0x40,
-	0x1c, 0xc5, 0xef, 0x1f, 0x5f, 0x5c, 0xe7, 0x1f, 0x5a, 0x59, 0x57, 0x84, 0x4c, 0x85, 0x0e, 0xab,
-	0x93, 0x41, 0xc2, 0x15, 0x81, 0x01, 0x21, 0x31, 0xb4, 0xd4, 0x20, 0x5b, 0x51, 0xa8, 0x4c, 0xd4,
-	0x15, 0x39, 0xc9, 0x61, 0xac, 0x12, 0xbb, 0xb2, 0x2f, 0x48, 0x65, 0xe2, 0x23, 0xf0, 0x05, 0xd8,
-	0xf9, 0x28, 0x1d, 0x33, 0xa1, 0x4e, 0x88, 0x38, 0x0b, 0x63, 0x3f, 0x02, 0xba, 0x73, 0x23, 0xd3,
-	0xed, 0xfd, 0xfc, 0xde, 0xbd, 0xf7, 0x1f, 0x8c, 0x58, 0x66, 0x79, 0xea, 0x9f, 0x97, 0x85, 0x2c,
-	0x18, 0x55, 0x7a, 0xef, 0x49, 0x9a, 0xc9, 0x4f, 0x8b, 0x89, 0x3f, 0x2d, 0xe6, 0x07, 0x69, 0x91,
-	0x16, 0x07, 0xda, 0x9c, 0x2c, 0x3e, 0x6a, 0xd2, 0xa0, 0x55, 0xf3, 0x68, 0xff, 0x07, 0x20, 0x3d,
-	0x16, 0xd5, 0x94, 0xbd, 0xc2, 0x5e, 0x96, 0xa7, 0xa2, 0x92, 0xa2, 0xac, 0x1c, 0x70, 0x0d, 0xaf,
-	0x3f, 0xb8, 0xef, 0xeb, 0x76, 0x65, 0xfb, 0xe1, 0xc6, 0x0b, 0x72, 0x59, 0x5e, 0x1c, 0xd1, 0xcb,
-	0xdf, 0x0f, 0x49, 0xdc, 0xbe, 0xd8, 0x3b, 0xc1, 0x9d, 0xdb, 0x11, 0x66, 0xa3, 0x71, 0x26, 0x2e,
-	0x1c, 0x70, 0xc1, 0xeb, 0xc5, 0x4a, 0x32, 0x0f, 0xbb, 0x5f, 0x92, 0xcf, 0x0b, 0xe1, 0x74, 0x5c,
-	0xf0, 0xfa, 0x03, 0xd6, 0xd4, 0x87, 0x79, 0x25, 0x93, 0x7c, 0x2a, 0xd4, 0x4c, 0xdc, 0x04, 0x5e,
-	0x76, 0x5e, 0x40, 0x44, 0xad, 0x8e, 0x6d, 0xec, 0xff, 0x02, 0xbc, 0xf3, 0x7f, 0x82, 0x31, 0xa4,
-	0xc9, 0x6c, 0x56, 0xde, 0xf4, 0x6a, 0xcd, 0x1e, 0x60, 0x4f, 0x66, 0x73, 0x51, 0xc9, 0x64, 0x7e,
-	0xae, 0xcb, 0x8d, 0xb8, 0xfd, 0xc0, 0x1e, 0x61, 0xb7, 0x92, 0x89, 0x14, 0x8e, 0xe1, 0x82, 0xb7,
-	0x33, 0xd8, 0xdd, 0xcc, 0x36, 0xd7, 0xbe, 0x57, 0x56, 0xdc, 0x24, 0xd8, 0x3d, 0x34, 0x65, 0x71,
-	0x26, 0xf2, 0xca, 0x31, 0x5d, 0xc3, 0xdb, 0x8e, 0x6f, 0x48, 0x8d, 0x7e, 0x2d, 0x72, 0xe1, 0x6c,
-	0x35, 0xa3, 0x4a, 0xb3, 0xa7, 0x78, 0xb7, 0x14, 0x69, 0xa6, 0x3a, 0xc4, 0xec, 0x43, 0xbb, 0x6f,
-	0xe9, 0xfd, 0xdd, 0xd6, 0x1b, 0x6f, 0xac, 0x88, 0x5a, 0xd4, 0xee, 0x46, 0xd4, 0xea, 0xda, 0xe6,
-	0xe3, 0x21, 0x6e, 0xdf, 0x3a, 0x81, 0x21, 0x9a, 0x87, 0xaf, 0xc7, 0xe1, 0x69, 0x60, 0x13, 0xd6,
-	0xc7, 0xad, 0x61, 0x70, 0x78, 0x1a, 0x8e, 0xde, 0xda, 0xa0, 0xe0, 0x24, 0x18, 0x1d, 0x2b, 0xe8,
-	0x28, 0x88, 0xde, 0x85, 0x23, 0x05, 0x06, 0xb3, 0x90, 0x0e, 0x83, 0x37, 0x63, 0x9b, 0x1e, 0x3d,
-	0x5f, 0xae, 0x38, 0xb9, 0x5a, 0x71, 0x72, 0xbd, 0xe2, 0xf0, 0xad, 0xe6, 0xf0, 0xb3, 0xe6, 0x70,
-	0x59, 0x73, 0x58, 0xd6, 0x1c, 0xfe, 0xd4, 0x1c, 0xfe, 0xd6, 0x9c, 0x5c, 0xd7, 0x1c, 0xbe, 0xaf,
-	0x39, 0x59, 0xae, 0x39, 0xb9, 0x5a, 0x73, 0x32, 0x31, 0xf5, 0x3f, 0xf0, 0xec, 0x5f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x79, 0x5b, 0xe1, 0x8b, 0x46, 0x02, 0x00, 0x00,
+	// 709 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcf, 0x4f, 0x13, 0x41,
+	0x14, 0xde, 0xe9, 0x6e, 0x4b, 0xf7, 0x55, 0xa0, 0x19, 0xd0, 0x2c, 0x05, 0x96, 0x75, 0x2f, 0x56,
+	0x13, 0x4b, 0xa8, 0x1e, 0xd4, 0xc4, 0x03, 0x48, 0x25, 0x6d, 0x1a, 0x20, 0x43, 0x43, 0x62, 0x62,
+	0xd2, 0x6c, 0xd9, 0xb1, 0x6c, 0x68, 0x67, 0xeb, 0xee, 0x16, 0x53, 0x4f, 0x9e, 0x3d, 0xf9, 0x07,
+	0xe8, 0xd1, 0xc4, 0x3f, 0x85, 0x23, 0x47, 0x4e, 0x46, 0x96, 0x8b, 0x47, 0xfe, 0x04, 0x33, 0xb3,
+	0xbb, 0x94, 0x62, 0xa9, 0x89, 0xb7, 0xf7, 0xe3, 0x7b, 0xef, 0xfb, 0x76, 0xe6, 0x9b, 0x05, 0xf0,
+	0x1c, 0xd6, 0x2e, 0xf5, 0x3c, 0x37, 0x70, 0xb1, 0xc2, 0xe3, 0xc2, 0xe3, 0xb6, 0x13, 0x1c, 0xf6,
+	0x5b, 0xa5, 0x03, 0xb7, 0xbb, 0xda, 0x76, 0xdb, 0xee, 0xaa, 0x68, 0xb6, 0xfa, 0xef, 0x44, 0x26,
+	0x12, 0x11, 0x45, 0x43, 0xe6, 0x37, 0x04, 0xca, 0x26, 0xf5, 0x0f, 0xf0, 0x4b, 0x50, 0x1d, 0xd6,
+	0xa6, 0x7e, 0x40, 0x3d, 0x5f, 0x43, 0x86, 0x5c, 0xcc, 0x95, 0x17, 0x4a, 0x62, 0x3b, 0x6f, 0x97,
+	0xaa, 0x49, 0xaf, 0xc2, 0x02, 0x6f, 0xb0, 0xa1, 0x9c, 0xfc, 0x5c, 0x91, 0xc8, 0x70, 0xa2, 0xb0,
+	0x0b, 0x33, 0xa3, 0x10, 0x9c, 0x07, 0xf9, 0x88, 0x0e, 0x34, 0x64, 0xa0, 0xa2, 0x4a, 0x78, 0x88,
+	0x8b, 0x90, 0x3e, 0xb6, 0x3a, 0x7d, 0xaa, 0xa5, 0x0c, 0x54, 0xcc, 0x95, 0x71, 0xb4, 0xbe, 0xca,
+	0xfc, 0xc0, 0x62, 0x07, 0x94, 0xd3, 0x90, 0x08, 0xf0, 0x22, 0xf5, 0x0c, 0xd5, 0x94, 0x6c, 0x2a,
+	0x2f, 0x9b, 0x5f, 0x53, 0x70, 0xe7, 0x3a, 0x02, 0x63, 0x50, 0x2c, 0xdb, 0xf6, 0xe2, 0xbd, 0x22,
+	0xc6, 0x4b, 0xa0, 0x06, 0x4e, 0x97, 0xfa, 0x81, 0xd5, 0xed, 0x89, 0xe5, 0x32, 0x19, 0x16, 0xf0,
+	0x43, 0x48, 0xfb, 0x81, 0x15, 0x50, 0x4d, 0x36, 0x50, 0x71, 0xa6, 0x3c, 0x97, 0xd0, 0x46, 0x6a,
+	0xf7, 0x78, 0x8b, 0x44, 0x08, 0x7c, 0x0f, 0x32, 0x81, 0x7b, 0x44, 0x99, 0xaf, 0x65, 0x0c, 0xb9,
+	0x38, 0x4d, 0xe2, 0x8c, 0x93, 0x7e, 0x74, 0x19, 0xd5, 0xa6, 0x22, 0x52, 0x1e, 0xe3, 0x35, 0x98,
+	0xf7, 0x68, 0xdb, 0xe1, 0x3b, 0xa8, 0xdd, 0x1c, 0xf2, 0x67, 0x05, 0xff, 0xdc, 0xb0, 0xd7, 0xb8,
+	0x52, 0xb2, 0x08, 0xaa, 0x47, 0x2d, 0xbb, 0xe9, 0xb2, 0xce, 0x40, 0x53, 0x0d, 0x54, 0xcc, 0x92,
+	0x2c, 0x2f, 0xec, 0xb0, 0xce, 0x00, 0x3f, 0x80, 0xd9, 0x0e, 0xb5, 0x8e, 0x69, 0xd3, 0xa3, 0xef,
+	0xfb, 0x5c, 0x9a, 0xad, 0x81, 0x80, 0xcc, 0x88, 0x32, 0x49, 0xaa, 0x35, 0x25, 0xab, 0xe4, 0xd3,
+	0x35, 0x25, 0x9b, 0xce, 0x67, 0x4c, 0x06, 0x85, 0x3d, 0x1a, 0x24, 0x07, 0x44, 0xe2, 0x5d, 0x31,
+	0x96, 0xcb, 0xe6, 0xdf, 0x9a, 0x9c, 0x15, 0x8f, 0xf1, 0x0a, 0xe4, 0x9c, 0x18, 0xde, 0x74, 0x6c,
+	0x71, 0x5a, 0x2a, 0x81, 0xa4, 0x54, 0xb5, 0x47, 0x45, 0xca, 0xa3, 0x22, 0xcd, 0x65, 0x58, 0x1c,
+	0xcb, 0xe7, 0xf7, 0x5c, 0xe6, 0x53, 0x93, 0xc0, 0x62, 0xcc, 0x9d, 0x40, 0xea, 0xd7, 0xb4, 0xff,
+	0x97, 0x1e, 0x53, 0x87, 0xa5, 0xf1, 0x3b, 0x63, 0xce, 0x55, 0x58, 0xd8, 0xa2, 0x41, 0x43, 0x5c,
+	0xd4, 0xce, 0x07, 0x46, 0x3d, 0xff, 0xd0, 0xe9, 0x4d, 0x60, 0x34, 0x3f, 0x23, 0x28, 0x8c, 0x9b,
+	0x88, 0xf6, 0xe1, 0xe7, 0xfc, 0x21, 0x44, 0x44, 0xc9, 0x43, 0xb8, 0x1b, 0x59, 0xe6, 0xc6, 0xc4,
+	0xf0, 0x11, 0xc4, 0x68, 0xbc, 0x06, 0x69, 0x6e, 0x0d, 0x5f, 0x4b, 0xfd, 0x7b, 0x2c, 0x42, 0x9a,
+	0x2d, 0x98, 0xbd, 0xd1, 0xe7, 0x9a, 0x99, 0xd5, 0xa5, 0x89, 0x66, 0x1e, 0xe3, 0x65, 0x00, 0xd6,
+	0xef, 0x36, 0x63, 0x73, 0xf2, 0x43, 0x9a, 0x26, 0x2a, 0xeb, 0x77, 0xa3, 0x59, 0xfe, 0x00, 0xdc,
+	0x64, 0x5e, 0xdc, 0x19, 0x22, 0xc3, 0xc2, 0xa3, 0x3a, 0x4c, 0x8f, 0xb8, 0x1d, 0x03, 0x64, 0xd6,
+	0x5f, 0x35, 0xaa, 0xfb, 0x95, 0xbc, 0x84, 0x73, 0x30, 0x55, 0xaf, 0xac, 0xef, 0x57, 0xb7, 0xb7,
+	0xf2, 0x88, 0x27, 0xbb, 0x95, 0xed, 0x4d, 0x9e, 0xa4, 0x78, 0x52, 0xdb, 0xa9, 0x6e, 0xf3, 0x44,
+	0xc6, 0x59, 0x50, 0xea, 0x95, 0xd7, 0x8d, 0xbc, 0x52, 0xfe, 0x9e, 0x02, 0x95, 0x38, 0xac, 0xbd,
+	0x6e, 0x77, 0x1d, 0x86, 0xdf, 0xc2, 0xdc, 0x18, 0x43, 0x60, 0x23, 0xfa, 0xf4, 0xdb, 0xbd, 0x59,
+	0xb8, 0x3f, 0x01, 0x11, 0xdf, 0xac, 0x84, 0x9b, 0x30, 0x3f, 0xee, 0xee, 0x71, 0x3c, 0x3c, 0xc1,
+	0x6b, 0x05, 0x73, 0x12, 0xe4, 0x8a, 0xe0, 0x0d, 0xe0, 0xbf, 0xad, 0x80, 0x57, 0xa2, 0xd9, 0x5b,
+	0x6d, 0x55, 0x30, 0x6e, 0x07, 0x24, 0xab, 0x37, 0x9e, 0x9e, 0x9e, 0xeb, 0xd2, 0xd9, 0xb9, 0x2e,
+	0x5d, 0x9e, 0xeb, 0xe8, 0x53, 0xa8, 0xa3, 0x1f, 0xa1, 0x8e, 0x4e, 0x42, 0x1d, 0x9d, 0x86, 0x3a,
+	0xfa, 0x15, 0xea, 0xe8, 0x77, 0xa8, 0x4b, 0x97, 0xa1, 0x8e, 0xbe, 0x5c, 0xe8, 0xd2, 0xe9, 0x85,
+	0x2e, 0x9d, 0x5d, 0xe8, 0x52, 0x2b, 0x23, 0x7e, 0xcb, 0x4f, 0xfe, 0x0c, 0x00, 0xee, 0xf0, 0x93,
+	0x73, 0xd9, 0x05, 0x00, 0x00,
 }
 
 func (x IngesterState) String() string {
@@ -320,282 +706,1859 @@ func (this *InstanceDesc) Equal(that interface{}) bool {
 	if this.RegisteredTimestamp != that1.RegisteredTimestamp {
 		return false
 	}
+	if this.ReadOnly != that1.ReadOnly {
+		return false
+	}
+	if this.LeaveRequested != that1.LeaveRequested {
+		return false
+	}
 	return true
 }
-func (this *Desc) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *SetInstanceReadOnlyRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&ring.Desc{")
-	keysForIngesters := make([]string, 0, len(this.Ingesters))
-	for k, _ := range this.Ingesters {
-		keysForIngesters = append(keysForIngesters, k)
+
+	that1, ok := that.(*SetInstanceReadOnlyRequest)
+	if !ok {
+		that2, ok := that.(SetInstanceReadOnlyRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForIngesters)
-	mapStringForIngesters := "map[string]InstanceDesc{"
-	for _, k := range keysForIngesters {
-		mapStringForIngesters += fmt.Sprintf("%#v: %#v,", k, this.Ingesters[k])
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	mapStringForIngesters += "}"
-	if this.Ingesters != nil {
-		s = append(s, "Ingesters: "+mapStringForIngesters+",\n")
+	if this.Ring != that1.Ring {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if this.InstanceId != that1.InstanceId {
+		return false
+	}
+	if this.ReadOnly != that1.ReadOnly {
+		return false
+	}
+	return true
 }
-func (this *InstanceDesc) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *SetInstanceReadOnlyResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 10)
-	s = append(s, "&ring.InstanceDesc{")
-	s = append(s, "Addr: "+fmt.Sprintf("%#v", this.Addr)+",\n")
-	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
-	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
-	s = append(s, "Tokens: "+fmt.Sprintf("%#v", this.Tokens)+",\n")
-	s = append(s, "Zone: "+fmt.Sprintf("%#v", this.Zone)+",\n")
-	s = append(s, "RegisteredTimestamp: "+fmt.Sprintf("%#v", this.RegisteredTimestamp)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringRing(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
-	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func (m *Desc) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *Desc) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *Desc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Ingesters) > 0 {
-		for k := range m.Ingesters {
-			v := m.Ingesters[k]
-			baseI := i
-			{
-				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintRing(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintRing(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintRing(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0xa
+	that1, ok := that.(*SetInstanceReadOnlyResponse)
+	if !ok {
+		that2, ok := that.(SetInstanceReadOnlyResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *InstanceDesc) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *InstanceDesc) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
-
-func (m *InstanceDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.RegisteredTimestamp != 0 {
-		i = encodeVarintRing(dAtA, i, uint64(m.RegisteredTimestamp))
-		i--
-		dAtA[i] = 0x40
-	}
-	if len(m.Zone) > 0 {
-		i -= len(m.Zone)
-		copy(dAtA[i:], m.Zone)
-		i = encodeVarintRing(dAtA, i, uint64(len(m.Zone)))
-		i--
-		dAtA[i] = 0x3a
+func (this *RequestInstanceLeaveRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if len(m.Tokens) > 0 {
-		dAtA3 := make([]byte, len(m.Tokens)*10)
-		var j2 int
-		for _, num := range m.Tokens {
-			for num >= 1<<7 {
-				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j2++
-			}
-			dAtA3[j2] = uint8(num)
-			j2++
+
+	that1, ok := that.(*RequestInstanceLeaveRequest)
+	if !ok {
+		that2, ok := that.(RequestInstanceLeaveRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= j2
-		copy(dAtA[i:], dAtA3[:j2])
-		i = encodeVarintRing(dAtA, i, uint64(j2))
-		i--
-		dAtA[i] = 0x32
 	}
-	if m.State != 0 {
-		i = encodeVarintRing(dAtA, i, uint64(m.State))
-		i--
-		dAtA[i] = 0x18
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Timestamp != 0 {
-		i = encodeVarintRing(dAtA, i, uint64(m.Timestamp))
-		i--
-		dAtA[i] = 0x10
+	if this.Ring != that1.Ring {
+		return false
 	}
-	if len(m.Addr) > 0 {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintRing(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0xa
+	if this.InstanceId != that1.InstanceId {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
+func (this *RequestInstanceLeaveResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func encodeVarintRing(dAtA []byte, offset int, v uint64) int {
-	offset -= sovRing(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	that1, ok := that.(*RequestInstanceLeaveResponse)
+	if !ok {
+		that2, ok := that.(RequestInstanceLeaveResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
-func (m *Desc) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *GetTokensOwnershipRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	var l int
-	_ = l
-	if len(m.Ingesters) > 0 {
-		for k, v := range m.Ingesters {
-			_ = k
-			_ = v
-			l = v.Size()
-			mapEntrySize := 1 + len(k) + sovRing(uint64(len(k))) + 1 + l + sovRing(uint64(l))
-			n += mapEntrySize + 1 + sovRing(uint64(mapEntrySize))
+
+	that1, ok := that.(*GetTokensOwnershipRequest)
+	if !ok {
+		that2, ok := that.(GetTokensOwnershipRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Ring != that1.Ring {
+		return false
+	}
+	return true
 }
-
-func (m *InstanceDesc) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *GetTokensOwnershipResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	var l int
-	_ = l
-	l = len(m.Addr)
-	if l > 0 {
-		n += 1 + l + sovRing(uint64(l))
+
+	that1, ok := that.(*GetTokensOwnershipResponse)
+	if !ok {
+		that2, ok := that.(GetTokensOwnershipResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Timestamp != 0 {
-		n += 1 + sovRing(uint64(m.Timestamp))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.State != 0 {
-		n += 1 + sovRing(uint64(m.State))
+	if len(this.Instances) != len(that1.Instances) {
+		return false
 	}
-	if len(m.Tokens) > 0 {
-		l = 0
-		for _, e := range m.Tokens {
-			l += sovRing(uint64(e))
+	for i := range this.Instances {
+		if !this.Instances[i].Equal(&that1.Instances[i]) {
+			return false
 		}
-		n += 1 + sovRing(uint64(l)) + l
 	}
-	l = len(m.Zone)
-	if l > 0 {
-		n += 1 + l + sovRing(uint64(l))
+	if len(this.Zones) != len(that1.Zones) {
+		return false
 	}
-	if m.RegisteredTimestamp != 0 {
-		n += 1 + sovRing(uint64(m.RegisteredTimestamp))
+	for i := range this.Zones {
+		if !this.Zones[i].Equal(&that1.Zones[i]) {
+			return false
+		}
 	}
-	return n
-}
-
-func sovRing(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozRing(x uint64) (n int) {
-	return sovRing(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return true
 }
-func (this *Desc) String() string {
-	if this == nil {
-		return "nil"
+func (this *TokensOwnership) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	keysForIngesters := make([]string, 0, len(this.Ingesters))
-	for k, _ := range this.Ingesters {
-		keysForIngesters = append(keysForIngesters, k)
+
+	that1, ok := that.(*TokensOwnership)
+	if !ok {
+		that2, ok := that.(TokensOwnership)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.NumTokens != that1.NumTokens {
+		return false
+	}
+	if this.Ownership != that1.Ownership {
+		return false
+	}
+	return true
+}
+func (this *Desc) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&ring.Desc{")
+	keysForIngesters := make([]string, 0, len(this.Ingesters))
+	for k, _ := range this.Ingesters {
+		keysForIngesters = append(keysForIngesters, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForIngesters)
+	mapStringForIngesters := "map[string]InstanceDesc{"
+	for _, k := range keysForIngesters {
+		mapStringForIngesters += fmt.Sprintf("%#v: %#v,", k, this.Ingesters[k])
+	}
+	mapStringForIngesters += "}"
+	if this.Ingesters != nil {
+		s = append(s, "Ingesters: "+mapStringForIngesters+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *InstanceDesc) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 12)
+	s = append(s, "&ring.InstanceDesc{")
+	s = append(s, "Addr: "+fmt.Sprintf("%#v", this.Addr)+",\n")
+	s = append(s, "Timestamp: "+fmt.Sprintf("%#v", this.Timestamp)+",\n")
+	s = append(s, "State: "+fmt.Sprintf("%#v", this.State)+",\n")
+	s = append(s, "Tokens: "+fmt.Sprintf("%#v", this.Tokens)+",\n")
+	s = append(s, "Zone: "+fmt.Sprintf("%#v", this.Zone)+",\n")
+	s = append(s, "RegisteredTimestamp: "+fmt.Sprintf("%#v", this.RegisteredTimestamp)+",\n")
+	s = append(s, "ReadOnly: "+fmt.Sprintf("%#v", this.ReadOnly)+",\n")
+	s = append(s, "LeaveRequested: "+fmt.Sprintf("%#v", this.LeaveRequested)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SetInstanceReadOnlyRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&ring.SetInstanceReadOnlyRequest{")
+	s = append(s, "Ring: "+fmt.Sprintf("%#v", this.Ring)+",\n")
+	s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	s = append(s, "ReadOnly: "+fmt.Sprintf("%#v", this.ReadOnly)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SetInstanceReadOnlyResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&ring.SetInstanceReadOnlyResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RequestInstanceLeaveRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&ring.RequestInstanceLeaveRequest{")
+	s = append(s, "Ring: "+fmt.Sprintf("%#v", this.Ring)+",\n")
+	s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RequestInstanceLeaveResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&ring.RequestInstanceLeaveResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetTokensOwnershipRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&ring.GetTokensOwnershipRequest{")
+	s = append(s, "Ring: "+fmt.Sprintf("%#v", this.Ring)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetTokensOwnershipResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&ring.GetTokensOwnershipResponse{")
+	if this.Instances != nil {
+		vs := make([]*TokensOwnership, len(this.Instances))
+		for i := range vs {
+			vs[i] = &this.Instances[i]
+		}
+		s = append(s, "Instances: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Zones != nil {
+		vs := make([]*TokensOwnership, len(this.Zones))
+		for i := range vs {
+			vs[i] = &this.Zones[i]
+		}
+		s = append(s, "Zones: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TokensOwnership) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&ring.TokensOwnership{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "NumTokens: "+fmt.Sprintf("%#v", this.NumTokens)+",\n")
+	s = append(s, "Ownership: "+fmt.Sprintf("%#v", this.Ownership)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringRing(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RingAdminClient is the client API for RingAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RingAdminClient interface {
+	// SetInstanceReadOnly marks an instance as read-only (or read-write).
+	SetInstanceReadOnly(ctx context.Context, in *SetInstanceReadOnlyRequest, opts ...grpc.CallOption) (*SetInstanceReadOnlyResponse, error)
+	// RequestInstanceLeave requests an instance to gracefully leave the ring.
+	RequestInstanceLeave(ctx context.Context, in *RequestInstanceLeaveRequest, opts ...grpc.CallOption) (*RequestInstanceLeaveResponse, error)
+	// GetTokensOwnership returns the percentage of tokens owned by each instance and zone.
+	GetTokensOwnership(ctx context.Context, in *GetTokensOwnershipRequest, opts ...grpc.CallOption) (*GetTokensOwnershipResponse, error)
+}
+
+type ringAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRingAdminClient(cc *grpc.ClientConn) RingAdminClient {
+	return &ringAdminClient{cc}
+}
+
+func (c *ringAdminClient) SetInstanceReadOnly(ctx context.Context, in *SetInstanceReadOnlyRequest, opts ...grpc.CallOption) (*SetInstanceReadOnlyResponse, error) {
+	out := new(SetInstanceReadOnlyResponse)
+	err := c.cc.Invoke(ctx, "/ring.RingAdmin/SetInstanceReadOnly", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ringAdminClient) RequestInstanceLeave(ctx context.Context, in *RequestInstanceLeaveRequest, opts ...grpc.CallOption) (*RequestInstanceLeaveResponse, error) {
+	out := new(RequestInstanceLeaveResponse)
+	err := c.cc.Invoke(ctx, "/ring.RingAdmin/RequestInstanceLeave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ringAdminClient) GetTokensOwnership(ctx context.Context, in *GetTokensOwnershipRequest, opts ...grpc.CallOption) (*GetTokensOwnershipResponse, error) {
+	out := new(GetTokensOwnershipResponse)
+	err := c.cc.Invoke(ctx, "/ring.RingAdmin/GetTokensOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RingAdminServer is the server API for RingAdmin service.
+type RingAdminServer interface {
+	// SetInstanceReadOnly marks an instance as read-only (or read-write).
+	SetInstanceReadOnly(context.Context, *SetInstanceReadOnlyRequest) (*SetInstanceReadOnlyResponse, error)
+	// RequestInstanceLeave requests an instance to gracefully leave the ring.
+	RequestInstanceLeave(context.Context, *RequestInstanceLeaveRequest) (*RequestInstanceLeaveResponse, error)
+	// GetTokensOwnership returns the percentage of tokens owned by each instance and zone.
+	GetTokensOwnership(context.Context, *GetTokensOwnershipRequest) (*GetTokensOwnershipResponse, error)
+}
+
+// UnimplementedRingAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedRingAdminServer struct {
+}
+
+func (*UnimplementedRingAdminServer) SetInstanceReadOnly(ctx context.Context, req *SetInstanceReadOnlyRequest) (*SetInstanceReadOnlyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInstanceReadOnly not implemented")
+}
+func (*UnimplementedRingAdminServer) RequestInstanceLeave(ctx context.Context, req *RequestInstanceLeaveRequest) (*RequestInstanceLeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestInstanceLeave not implemented")
+}
+func (*UnimplementedRingAdminServer) GetTokensOwnership(ctx context.Context, req *GetTokensOwnershipRequest) (*GetTokensOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokensOwnership not implemented")
+}
+
+func RegisterRingAdminServer(s *grpc.Server, srv RingAdminServer) {
+	s.RegisterService(&_RingAdmin_serviceDesc, srv)
+}
+
+func _RingAdmin_SetInstanceReadOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInstanceReadOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RingAdminServer).SetInstanceReadOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ring.RingAdmin/SetInstanceReadOnly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RingAdminServer).SetInstanceReadOnly(ctx, req.(*SetInstanceReadOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RingAdmin_RequestInstanceLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestInstanceLeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RingAdminServer).RequestInstanceLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ring.RingAdmin/RequestInstanceLeave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RingAdminServer).RequestInstanceLeave(ctx, req.(*RequestInstanceLeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RingAdmin_GetTokensOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokensOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RingAdminServer).GetTokensOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ring.RingAdmin/GetTokensOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RingAdminServer).GetTokensOwnership(ctx, req.(*GetTokensOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RingAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ring.RingAdmin",
+	HandlerType: (*RingAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetInstanceReadOnly",
+			Handler:    _RingAdmin_SetInstanceReadOnly_Handler,
+		},
+		{
+			MethodName: "RequestInstanceLeave",
+			Handler:    _RingAdmin_RequestInstanceLeave_Handler,
+		},
+		{
+			MethodName: "GetTokensOwnership",
+			Handler:    _RingAdmin_GetTokensOwnership_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ring.proto",
+}
+
+func (m *Desc) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Desc) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Desc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ingesters) > 0 {
+		for k := range m.Ingesters {
+			v := m.Ingesters[k]
+			baseI := i
+			{
+				size, err := (&v).MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRing(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintRing(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintRing(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InstanceDesc) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstanceDesc) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InstanceDesc) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LeaveRequested {
+		i--
+		if m.LeaveRequested {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.RegisteredTimestamp != 0 {
+		i = encodeVarintRing(dAtA, i, uint64(m.RegisteredTimestamp))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Zone) > 0 {
+		i -= len(m.Zone)
+		copy(dAtA[i:], m.Zone)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.Zone)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Tokens) > 0 {
+		dAtA3 := make([]byte, len(m.Tokens)*10)
+		var j2 int
+		for _, num := range m.Tokens {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintRing(dAtA, i, uint64(j2))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.State != 0 {
+		i = encodeVarintRing(dAtA, i, uint64(m.State))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintRing(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetInstanceReadOnlyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetInstanceReadOnlyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetInstanceReadOnlyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ReadOnly {
+		i--
+		if m.ReadOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.InstanceId) > 0 {
+		i -= len(m.InstanceId)
+		copy(dAtA[i:], m.InstanceId)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.InstanceId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Ring) > 0 {
+		i -= len(m.Ring)
+		copy(dAtA[i:], m.Ring)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.Ring)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetInstanceReadOnlyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetInstanceReadOnlyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetInstanceReadOnlyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestInstanceLeaveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestInstanceLeaveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestInstanceLeaveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.InstanceId) > 0 {
+		i -= len(m.InstanceId)
+		copy(dAtA[i:], m.InstanceId)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.InstanceId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Ring) > 0 {
+		i -= len(m.Ring)
+		copy(dAtA[i:], m.Ring)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.Ring)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestInstanceLeaveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestInstanceLeaveResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestInstanceLeaveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetTokensOwnershipRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetTokensOwnershipRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokensOwnershipRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ring) > 0 {
+		i -= len(m.Ring)
+		copy(dAtA[i:], m.Ring)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.Ring)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetTokensOwnershipResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetTokensOwnershipResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetTokensOwnershipResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Zones) > 0 {
+		for iNdEx := len(m.Zones) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Zones[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRing(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Instances) > 0 {
+		for iNdEx := len(m.Instances) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Instances[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRing(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TokensOwnership) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokensOwnership) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokensOwnership) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Ownership != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Ownership))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.NumTokens != 0 {
+		i = encodeVarintRing(dAtA, i, uint64(m.NumTokens))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintRing(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRing(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRing(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Desc) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ingesters) > 0 {
+		for k, v := range m.Ingesters {
+			_ = k
+			_ = v
+			l = v.Size()
+			mapEntrySize := 1 + len(k) + sovRing(uint64(len(k))) + 1 + l + sovRing(uint64(l))
+			n += mapEntrySize + 1 + sovRing(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *InstanceDesc) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	if m.Timestamp != 0 {
+		n += 1 + sovRing(uint64(m.Timestamp))
+	}
+	if m.State != 0 {
+		n += 1 + sovRing(uint64(m.State))
+	}
+	if len(m.Tokens) > 0 {
+		l = 0
+		for _, e := range m.Tokens {
+			l += sovRing(uint64(e))
+		}
+		n += 1 + sovRing(uint64(l)) + l
+	}
+	l = len(m.Zone)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	if m.RegisteredTimestamp != 0 {
+		n += 1 + sovRing(uint64(m.RegisteredTimestamp))
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	if m.LeaveRequested {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetInstanceReadOnlyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Ring)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	l = len(m.InstanceId)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	if m.ReadOnly {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetInstanceReadOnlyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RequestInstanceLeaveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Ring)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	l = len(m.InstanceId)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestInstanceLeaveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetTokensOwnershipRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Ring)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	return n
+}
+
+func (m *GetTokensOwnershipResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Instances) > 0 {
+		for _, e := range m.Instances {
+			l = e.Size()
+			n += 1 + l + sovRing(uint64(l))
+		}
+	}
+	if len(m.Zones) > 0 {
+		for _, e := range m.Zones {
+			l = e.Size()
+			n += 1 + l + sovRing(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TokensOwnership) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovRing(uint64(l))
+	}
+	if m.NumTokens != 0 {
+		n += 1 + sovRing(uint64(m.NumTokens))
+	}
+	if m.Ownership != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovRing(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRing(x uint64) (n int) {
+	return sovRing(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Desc) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForIngesters := make([]string, 0, len(this.Ingesters))
+	for k, _ := range this.Ingesters {
+		keysForIngesters = append(keysForIngesters, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForIngesters)
+	mapStringForIngesters := "map[string]InstanceDesc{"
+	for _, k := range keysForIngesters {
+		mapStringForIngesters += fmt.Sprintf("%v: %v,", k, this.Ingesters[k])
+	}
+	mapStringForIngesters += "}"
+	s := strings.Join([]string{`&Desc{`,
+		`Ingesters:` + mapStringForIngesters + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *InstanceDesc) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&InstanceDesc{`,
+		`Addr:` + fmt.Sprintf("%v", this.Addr) + `,`,
+		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`Tokens:` + fmt.Sprintf("%v", this.Tokens) + `,`,
+		`Zone:` + fmt.Sprintf("%v", this.Zone) + `,`,
+		`RegisteredTimestamp:` + fmt.Sprintf("%v", this.RegisteredTimestamp) + `,`,
+		`ReadOnly:` + fmt.Sprintf("%v", this.ReadOnly) + `,`,
+		`LeaveRequested:` + fmt.Sprintf("%v", this.LeaveRequested) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetInstanceReadOnlyRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetInstanceReadOnlyRequest{`,
+		`Ring:` + fmt.Sprintf("%v", this.Ring) + `,`,
+		`InstanceId:` + fmt.Sprintf("%v", this.InstanceId) + `,`,
+		`ReadOnly:` + fmt.Sprintf("%v", this.ReadOnly) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetInstanceReadOnlyResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetInstanceReadOnlyResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RequestInstanceLeaveRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RequestInstanceLeaveRequest{`,
+		`Ring:` + fmt.Sprintf("%v", this.Ring) + `,`,
+		`InstanceId:` + fmt.Sprintf("%v", this.InstanceId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RequestInstanceLeaveResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RequestInstanceLeaveResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetTokensOwnershipRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetTokensOwnershipRequest{`,
+		`Ring:` + fmt.Sprintf("%v", this.Ring) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetTokensOwnershipResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForInstances := "[]TokensOwnership{"
+	for _, f := range this.Instances {
+		repeatedStringForInstances += strings.Replace(strings.Replace(f.String(), "TokensOwnership", "TokensOwnership", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForInstances += "}"
+	repeatedStringForZones := "[]TokensOwnership{"
+	for _, f := range this.Zones {
+		repeatedStringForZones += strings.Replace(strings.Replace(f.String(), "TokensOwnership", "TokensOwnership", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForZones += "}"
+	s := strings.Join([]string{`&GetTokensOwnershipResponse{`,
+		`Instances:` + repeatedStringForInstances + `,`,
+		`Zones:` + repeatedStringForZones + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TokensOwnership) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TokensOwnership{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`NumTokens:` + fmt.Sprintf("%v", this.NumTokens) + `,`,
+		`Ownership:` + fmt.Sprintf("%v", this.Ownership) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringRing(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Desc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Desc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Desc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingesters", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRing
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ingesters == nil {
+				m.Ingesters = make(map[string]InstanceDesc)
+			}
+			var mapkey string
+			mapvalue := &InstanceDesc{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRing
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRing
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthRing
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthRing
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRing
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthRing
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthRing
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &InstanceDesc{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipRing(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthRing
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Ingesters[mapkey] = *mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InstanceDesc: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InstanceDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRing
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= IngesterState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRing
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Tokens = append(m.Tokens, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRing
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRing
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRing
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Tokens) == 0 {
+					m.Tokens = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRing
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Tokens = append(m.Tokens, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRing
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Zone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegisteredTimestamp", wireType)
+			}
+			m.RegisteredTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegisteredTimestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaveRequested", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LeaveRequested = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetInstanceReadOnlyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetInstanceReadOnlyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetInstanceReadOnlyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ring", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRing
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ring = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRing
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReadOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForIngesters)
-	mapStringForIngesters := "map[string]InstanceDesc{"
-	for _, k := range keysForIngesters {
-		mapStringForIngesters += fmt.Sprintf("%v: %v,", k, this.Ingesters[k])
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	mapStringForIngesters += "}"
-	s := strings.Join([]string{`&Desc{`,
-		`Ingesters:` + mapStringForIngesters + `,`,
-		`}`,
-	}, "")
-	return s
+	return nil
 }
-func (this *InstanceDesc) String() string {
-	if this == nil {
-		return "nil"
+func (m *SetInstanceReadOnlyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetInstanceReadOnlyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetInstanceReadOnlyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	s := strings.Join([]string{`&InstanceDesc{`,
-		`Addr:` + fmt.Sprintf("%v", this.Addr) + `,`,
-		`Timestamp:` + fmt.Sprintf("%v", this.Timestamp) + `,`,
-		`State:` + fmt.Sprintf("%v", this.State) + `,`,
-		`Tokens:` + fmt.Sprintf("%v", this.Tokens) + `,`,
-		`Zone:` + fmt.Sprintf("%v", this.Zone) + `,`,
-		`RegisteredTimestamp:` + fmt.Sprintf("%v", this.RegisteredTimestamp) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringRing(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *Desc) Unmarshal(dAtA []byte) error {
+func (m *RequestInstanceLeaveRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -618,17 +2581,17 @@ func (m *Desc) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Desc: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestInstanceLeaveRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Desc: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestInstanceLeaveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ingesters", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ring", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRing
@@ -638,121 +2601,109 @@ func (m *Desc) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRing
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRing
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Ingesters == nil {
-				m.Ingesters = make(map[string]InstanceDesc)
+			m.Ring = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
 			}
-			var mapkey string
-			mapvalue := &InstanceDesc{}
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRing
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRing
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRing
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthRing
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthRing
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRing
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthRing
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthRing
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &InstanceDesc{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipRing(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthRing
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Ingesters[mapkey] = *mapvalue
-			iNdEx = postIndex
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRing
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestInstanceLeaveResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestInstanceLeaveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestInstanceLeaveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRing(dAtA[iNdEx:])
@@ -777,7 +2728,7 @@ func (m *Desc) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
+func (m *GetTokensOwnershipRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -800,15 +2751,15 @@ func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InstanceDesc: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetTokensOwnershipRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InstanceDesc: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetTokensOwnershipRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ring", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -836,13 +2787,66 @@ func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = string(dAtA[iNdEx:postIndex])
+			m.Ring = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Timestamp = 0
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetTokensOwnershipResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetTokensOwnershipResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetTokensOwnershipResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instances", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRing
@@ -852,16 +2856,31 @@ func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRing
 			}
-			m.State = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Instances = append(m.Instances, TokensOwnership{})
+			if err := m.Instances[len(m.Instances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Zones", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRing
@@ -871,90 +2890,82 @@ func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= IngesterState(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRing
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Tokens = append(m.Tokens, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRing
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRing
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRing
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Tokens) == 0 {
-					m.Tokens = make([]uint32, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRing
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Tokens = append(m.Tokens, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRing
 			}
-		case 7:
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Zones = append(m.Zones, TokensOwnership{})
+			if err := m.Zones[len(m.Zones)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokensOwnership) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokensOwnership: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokensOwnership: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Zone", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -982,13 +2993,13 @@ func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Zone = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegisteredTimestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NumTokens", wireType)
 			}
-			m.RegisteredTimestamp = 0
+			m.NumTokens = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRing
@@ -998,11 +3009,22 @@ func (m *InstanceDesc) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegisteredTimestamp |= int64(b&0x7F) << shift
+				m.NumTokens |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ownership", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Ownership = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRing(dAtA[iNdEx:])
@@ -246,6 +246,88 @@ func TestRing_Get_ZoneAwareness(t *testing.T) {
 	}
 }
 
+func TestRing_Get_ReadOnlyInstances(t *testing.T) {
+	tests := map[string]struct {
+		zoneAwarenessEnabled bool
+		readOnlyInstances    []string
+		op                   Operation
+		expectedAddresses    []string
+	}{
+		"no read-only instances, write operation": {
+			op:                Write,
+			expectedAddresses: []string{"127.0.0.1", "127.0.0.2", "127.0.0.3"},
+		},
+		"read-only instance, write operation": {
+			readOnlyInstances: []string{"instance-2"},
+			op:                Write,
+			expectedAddresses: []string{"127.0.0.1", "127.0.0.3", "127.0.0.4"},
+		},
+		"read-only instance, read operation": {
+			readOnlyInstances: []string{"instance-2"},
+			op:                Read,
+			expectedAddresses: []string{"127.0.0.1", "127.0.0.2", "127.0.0.3", "127.0.0.4"},
+		},
+		"read-only instance, write operation, zone-awareness enabled": {
+			zoneAwarenessEnabled: true,
+			readOnlyInstances:    []string{"instance-2"},
+			op:                   Write,
+			expectedAddresses:    []string{"127.0.0.1", "127.0.0.3", "127.0.0.4"},
+		},
+		"read-only instance, read operation, zone-awareness enabled": {
+			zoneAwarenessEnabled: true,
+			readOnlyInstances:    []string{"instance-2"},
+			op:                   Read,
+			expectedAddresses:    []string{"127.0.0.1", "127.0.0.2", "127.0.0.3", "127.0.0.4"},
+		},
+		"all instances read-only, write operation": {
+			readOnlyInstances: []string{"instance-1", "instance-2", "instance-3", "instance-4"},
+			op:                Write,
+			expectedAddresses: nil,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			// Instance-2 and instance-4 are in the same zone.
+			r := NewDesc()
+			r.AddIngester("instance-1", "127.0.0.1", "zone-a", []uint32{100}, ACTIVE, time.Now())
+			r.AddIngester("instance-2", "127.0.0.2", "zone-b", []uint32{200}, ACTIVE, time.Now())
+			r.AddIngester("instance-3", "127.0.0.3", "zone-c", []uint32{300}, ACTIVE, time.Now())
+			r.AddIngester("instance-4", "127.0.0.4", "zone-b", []uint32{400}, ACTIVE, time.Now())
+
+			for _, id := range testData.readOnlyInstances {
+				instance := r.Ingesters[id]
+				instance.ReadOnly = true
+				r.Ingesters[id] = instance
+			}
+
+			ring := Ring{
+				cfg: Config{
+					HeartbeatTimeout:     time.Hour,
+					ReplicationFactor:    3,
+					ZoneAwarenessEnabled: testData.zoneAwarenessEnabled,
+				},
+				ringDesc:            r,
+				ringTokens:          r.GetTokens(),
+				ringTokensByZone:    r.getTokensByZone(),
+				ringInstanceByToken: r.getTokensInfo(),
+				ringZones:           getZones(r.getTokensByZone()),
+				strategy:            NewIgnoreUnhealthyInstancesReplicationStrategy(),
+			}
+
+			bufDescs, bufHosts, bufZones := MakeBuffersForGet()
+			set, err := ring.Get(50, testData.op, bufDescs, bufHosts, bufZones)
+			if testData.expectedAddresses == nil {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, testData.expectedAddresses, set.GetAddresses())
+		})
+	}
+}
+
 func TestRing_GetAllHealthy(t *testing.T) {
 	const heartbeatTimeout = time.Minute
 	now := time.Now()
@@ -33,23 +33,24 @@ type pusherAppender struct {
 
 func (a *pusherAppender) Add(l labels.Labels, t int64, v float64) (uint64, error) {
 	a.labels = append(a.labels, l)
-
-	// Adapt staleness markers for ruler evaluation delay. As the upstream code
-	// is using the actual time, when there is a no longer available series.
-	// This then causes 'out of order' append failures once the series is
-	// becoming available again.
-	// see https://github.com/prometheus/prometheus/blob/6c56a1faaaad07317ff585bda75b99bdba0517ad/rules/manager.go#L647-L660
-	if a.evaluationDelay > 0 && value.IsStaleNaN(v) {
-		t -= a.evaluationDelay.Milliseconds()
-	}
-
 	a.samples = append(a.samples, client.Sample{
-		TimestampMs: t,
+		TimestampMs: adjustStaleMarkerTimestamp(t, v, a.evaluationDelay),
 		Value:       v,
 	})
 	return 0, nil
 }
 
+// adjustStaleMarkerTimestamp adapts staleness markers for ruler evaluation delay. As the upstream
+// code is using the actual time, when there is a no longer available series. This then causes
+// 'out of order' append failures once the series is becoming available again.
+// see https://github.com/prometheus/prometheus/blob/6c56a1faaaad07317ff585bda75b99bdba0517ad/rules/manager.go#L647-L660
+func adjustStaleMarkerTimestamp(t int64, v float64, evaluationDelay time.Duration) int64 {
+	if evaluationDelay > 0 && value.IsStaleNaN(v) {
+		return t - evaluationDelay.Milliseconds()
+	}
+	return t
+}
+
 func (a *pusherAppender) AddFast(_ uint64, _ int64, _ float64) error {
 	return storage.ErrNotFound
 }
@@ -122,14 +123,36 @@ type RulesManager interface {
 }
 
 // ManagerFactory is a function that creates new RulesManager for given user and notifier.Manager.
-type ManagerFactory func(ctx context.Context, userID string, notifier *notifier.Manager, logger log.Logger, reg prometheus.Registerer) RulesManager
-
-func DefaultTenantManagerFactory(cfg Config, p Pusher, q storage.Queryable, engine *promql.Engine, overrides RulesLimits) ManagerFactory {
-	return func(ctx context.Context, userID string, notifier *notifier.Manager, logger log.Logger, reg prometheus.Registerer) RulesManager {
-		return rules.NewManager(&rules.ManagerOptions{
-			Appendable:      &PusherAppendable{pusher: p, userID: userID, rulesLimits: overrides},
+type ManagerFactory func(ctx context.Context, userID string, notifier *notifier.Manager, logger log.Logger, reg prometheus.Registerer) (RulesManager, error)
+
+// DefaultTenantManagerFactory returns a ManagerFactory creating Prometheus rules managers which
+// write the recording rules results to the Pusher or, if enabled, to the remote-write endpoint.
+// Rules are evaluated through the query-frontend if the FrontendClient is not nil, otherwise
+// they're evaluated locally with the input engine.
+func DefaultTenantManagerFactory(cfg Config, p Pusher, q storage.Queryable, engine *promql.Engine, frontendClient *FrontendClient, overrides RulesLimits) ManagerFactory {
+	return func(ctx context.Context, userID string, notifier *notifier.Manager, logger log.Logger, reg prometheus.Registerer) (RulesManager, error) {
+		var (
+			appendable storage.Appendable = &PusherAppendable{pusher: p, userID: userID, rulesLimits: overrides}
+			rws        *remoteWriteStorage
+		)
+
+		if cfg.RemoteWrite.Enabled {
+			var err error
+			if rws, err = newRemoteWriteStorage(cfg.RemoteWrite, userID, overrides, log.With(logger, "user", userID), reg); err != nil {
+				return nil, err
+			}
+			appendable = rws
+		}
+
+		queryFunc := engineQueryFunc(engine, q, overrides, userID)
+		if frontendClient != nil {
+			queryFunc = frontendQueryFunc(frontendClient, overrides, userID)
+		}
+
+		manager := rules.NewManager(&rules.ManagerOptions{
+			Appendable:      appendable,
 			Queryable:       q,
-			QueryFunc:       engineQueryFunc(engine, q, overrides, userID),
+			QueryFunc:       queryFunc,
 			Context:         user.InjectOrgID(ctx, userID),
 			ExternalURL:     cfg.ExternalURL.URL,
 			NotifyFunc:      SendAlerts(notifier, cfg.ExternalURL.URL.String()),
@@ -139,5 +162,10 @@ func DefaultTenantManagerFactory(cfg Config, p Pusher, q storage.Queryable, engi
 			ForGracePeriod:  cfg.ForGracePeriod,
 			ResendDelay:     cfg.ResendDelay,
 		})
+
+		if rws == nil {
+			return manager, nil
+		}
+		return &remoteWriteRulesManager{RulesManager: manager, storage: rws, logger: log.With(logger, "user", userID)}, nil
 	}
 }
@@ -0,0 +1,203 @@
+package ruler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+
+	"github.com/cortexproject/cortex/pkg/util/grpcclient"
+)
+
+const (
+	instantQueryPath = "/api/v1/query"
+
+	// FrontendClientUserAgent is the User-Agent of the rules queries sent to the query-frontend,
+	// so that the query-frontend can tell them apart from the user queries.
+	FrontendClientUserAgent = "Cortex-Ruler"
+
+	roundRobinServiceConfig = `{"loadBalancingPolicy":"round_robin"}`
+)
+
+// FrontendClient runs the rules instant queries through the query-frontend, so that the rules
+// evaluation goes through the same results cache, query sharding and splitting, tenant queueing
+// and limits of the user queries. Requests are sent via the httpgrpc service exposed by the
+// query-frontend gRPC server.
+type FrontendClient struct {
+	client  httpgrpc.HTTPClient
+	conn    *grpc.ClientConn
+	path    string
+	timeout time.Duration
+}
+
+// NewFrontendClient dials the query-frontend configured in the ruler config. The prometheusHTTPPrefix
+// is the prefix the query-frontend Prometheus API is exposed at.
+func NewFrontendClient(cfg Config, prometheusHTTPPrefix string, reg prometheus.Registerer) (*FrontendClient, error) {
+	requestDuration := promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cortex_ruler_query_frontend_request_duration_seconds",
+		Help:    "Time spent executing the rules queries through the query-frontend.",
+		Buckets: prometheus.ExponentialBuckets(0.008, 4, 7),
+	}, []string{"operation", "status_code"})
+
+	opts, err := cfg.FrontendClient.DialOption(grpcclient.Instrument(requestDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve all the query-frontend replicas behind the address and balance the queries across them.
+	opts = append(opts, grpc.WithDefaultServiceConfig(roundRobinServiceConfig))
+
+	conn, err := grpc.Dial(frontendTarget(cfg.FrontendAddress), opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial query-frontend %s", cfg.FrontendAddress)
+	}
+
+	return &FrontendClient{
+		client:  httpgrpc.NewHTTPClient(conn),
+		conn:    conn,
+		path:    prometheusHTTPPrefix + instantQueryPath,
+		timeout: cfg.FrontendTimeout,
+	}, nil
+}
+
+// frontendTarget returns the gRPC dial target of the query-frontend address. Addresses without
+// a scheme are resolved via DNS, so that the connection is established to all the query-frontend
+// replicas behind the address and not only the first resolved one.
+func frontendTarget(address string) string {
+	if strings.Contains(address, "://") {
+		return address
+	}
+	return "dns:///" + address
+}
+
+// Close closes the connection to the query-frontend.
+func (c *FrontendClient) Close() error {
+	return c.conn.Close()
+}
+
+// InstantQuery runs the input instant query through the query-frontend, on behalf of the tenant in the context.
+func (c *FrontendClient) InstantQuery(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+	userID, err := user.ExtractOrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	body := url.Values{
+		"query": []string{qs},
+		"time":  []string{strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)},
+	}.Encode()
+
+	resp, err := c.client.Handle(ctx, &httpgrpc.HTTPRequest{
+		Method: http.MethodPost,
+		Url:    c.path,
+		Body:   []byte(body),
+		Headers: []*httpgrpc.Header{
+			{Key: "Content-Type", Values: []string{"application/x-www-form-urlencoded"}},
+			{Key: "Content-Length", Values: []string{strconv.Itoa(len(body))}},
+			{Key: http.CanonicalHeaderKey(user.OrgIDHeaderName), Values: []string{userID}},
+			{Key: "User-Agent", Values: []string{FrontendClientUserAgent}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeInstantQueryResponse(resp)
+}
+
+type instantQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// decodeInstantQueryResponse decodes a Prometheus API instant query response into
+// a promql.Vector, converting scalar results the same way rules.EngineQueryFunc does.
+func decodeInstantQueryResponse(resp *httpgrpc.HTTPResponse) (promql.Vector, error) {
+	var decoded instantQueryResponse
+	if err := json.Unmarshal(resp.Body, &decoded); err != nil {
+		if resp.Code != http.StatusOK {
+			return nil, fmt.Errorf("query-frontend returned status code %d: %s", resp.Code, string(resp.Body))
+		}
+		return nil, errors.Wrap(err, "failed to decode query-frontend response")
+	}
+
+	if resp.Code != http.StatusOK || decoded.Status != "success" {
+		return nil, fmt.Errorf("query-frontend returned status code %d: %s: %s", resp.Code, decoded.ErrorType, decoded.Error)
+	}
+
+	switch decoded.Data.ResultType {
+	case model.ValVector.String():
+		var vector model.Vector
+		if err := json.Unmarshal(decoded.Data.Result, &vector); err != nil {
+			return nil, errors.Wrap(err, "failed to decode vector result")
+		}
+
+		result := make(promql.Vector, 0, len(vector))
+		for _, s := range vector {
+			result = append(result, promql.Sample{
+				Metric: metricToLabels(s.Metric),
+				Point:  promql.Point{T: int64(s.Timestamp), V: float64(s.Value)},
+			})
+		}
+		return result, nil
+
+	case model.ValScalar.String():
+		var scalar model.Scalar
+		if err := json.Unmarshal(decoded.Data.Result, &scalar); err != nil {
+			return nil, errors.Wrap(err, "failed to decode scalar result")
+		}
+
+		return promql.Vector{promql.Sample{
+			Metric: labels.Labels{},
+			Point:  promql.Point{T: int64(scalar.Timestamp), V: float64(scalar.Value)},
+		}}, nil
+
+	default:
+		return nil, fmt.Errorf("rule result is not a vector or scalar: %q", decoded.Data.ResultType)
+	}
+}
+
+func metricToLabels(m model.Metric) labels.Labels {
+	builder := labels.NewBuilder(nil)
+	for name, value := range m {
+		builder.Set(string(name), string(value))
+	}
+	return builder.Labels()
+}
+
+// frontendQueryFunc returns a new query function running the queries through the query-frontend
+// and passing an altered timestamp.
+func frontendQueryFunc(client *FrontendClient, overrides RulesLimits, userID string) rules.QueryFunc {
+	return func(ctx context.Context, qs string, t time.Time) (promql.Vector, error) {
+		// Delay the evaluation of all rules by a set interval to give a buffer
+		// to metric that haven't been forwarded to cortex yet.
+		evaluationDelay := overrides.EvaluationDelay(userID)
+		return client.InstantQuery(user.InjectOrgID(ctx, userID), qs, t.Add(-evaluationDelay))
+	}
+}
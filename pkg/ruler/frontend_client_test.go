@@ -0,0 +1,110 @@
+package ruler
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+	httpgrpc_server "github.com/weaveworks/common/httpgrpc/server"
+	"github.com/weaveworks/common/user"
+	"google.golang.org/grpc"
+)
+
+func TestFrontendClient_InstantQuery(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/prometheus/api/v1/query", r.URL.Path)
+		assert.Equal(t, "user-1", r.Header.Get(user.OrgIDHeaderName))
+		assert.Equal(t, FrontendClientUserAgent, r.UserAgent())
+		assert.Equal(t, `sum(rate(up[1m]))`, r.FormValue("query"))
+		assert.Equal(t, "1614000000.5", r.FormValue("time"))
+
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"job":"test"},"value":[1614000000.5,"2"]}]}}`))
+	})
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	httpgrpc.RegisterHTTPServer(server, httpgrpc_server.NewServer(handler))
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	cfg := Config{FrontendAddress: listener.Addr().String(), FrontendTimeout: time.Second}
+	cfg.FrontendClient.MaxRecvMsgSize = 1024 * 1024
+	cfg.FrontendClient.MaxSendMsgSize = 1024 * 1024
+
+	client, err := NewFrontendClient(cfg, "/prometheus", prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	defer client.Close() //nolint:errcheck
+
+	queryFunc := frontendQueryFunc(client, ruleLimits{evalDelay: time.Minute}, "user-1")
+	vector, err := queryFunc(context.Background(), `sum(rate(up[1m]))`, time.Unix(1614000060, 500*int64(time.Millisecond)))
+	require.NoError(t, err)
+
+	assert.Equal(t, promql.Vector{
+		{Metric: labels.FromStrings("job", "test"), Point: promql.Point{T: 1614000000500, V: 2}},
+	}, vector)
+}
+
+func TestFrontendTarget(t *testing.T) {
+	assert.Equal(t, "dns:///query-frontend:9095", frontendTarget("query-frontend:9095"))
+	assert.Equal(t, "dns:///query-frontend.cortex.svc:9095", frontendTarget("dns:///query-frontend.cortex.svc:9095"))
+	assert.Equal(t, "passthrough:///10.0.0.1:9095", frontendTarget("passthrough:///10.0.0.1:9095"))
+}
+
+func TestDecodeInstantQueryResponse(t *testing.T) {
+	tests := map[string]struct {
+		response      *httpgrpc.HTTPResponse
+		expected      promql.Vector
+		expectedError string
+	}{
+		"vector": {
+			response: &httpgrpc.HTTPResponse{Code: 200, Body: []byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"__name__":"up","job":"a"},"value":[10,"1"]},{"metric":{"__name__":"up","job":"b"},"value":[10,"0"]}]}}`)},
+			expected: promql.Vector{
+				{Metric: labels.FromStrings(labels.MetricName, "up", "job", "a"), Point: promql.Point{T: 10000, V: 1}},
+				{Metric: labels.FromStrings(labels.MetricName, "up", "job", "b"), Point: promql.Point{T: 10000, V: 0}},
+			},
+		},
+		"empty vector": {
+			response: &httpgrpc.HTTPResponse{Code: 200, Body: []byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`)},
+			expected: promql.Vector{},
+		},
+		"scalar": {
+			response: &httpgrpc.HTTPResponse{Code: 200, Body: []byte(`{"status":"success","data":{"resultType":"scalar","result":[10,"5"]}}`)},
+			expected: promql.Vector{{Metric: labels.Labels{}, Point: promql.Point{T: 10000, V: 5}}},
+		},
+		"matrix": {
+			response:      &httpgrpc.HTTPResponse{Code: 200, Body: []byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`)},
+			expectedError: `rule result is not a vector or scalar: "matrix"`,
+		},
+		"error": {
+			response:      &httpgrpc.HTTPResponse{Code: 422, Body: []byte(`{"status":"error","errorType":"execution","error":"failed"}`)},
+			expectedError: "query-frontend returned status code 422: execution: failed",
+		},
+		"non JSON error": {
+			response:      &httpgrpc.HTTPResponse{Code: 429, Body: []byte(`too many outstanding requests`)},
+			expectedError: "query-frontend returned status code 429: too many outstanding requests",
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := decodeInstantQueryResponse(testData.response)
+			if testData.expectedError != "" {
+				require.EqualError(t, err, testData.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, actual)
+		})
+	}
+}
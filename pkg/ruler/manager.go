@@ -173,7 +173,7 @@ func (r *DefaultMultiTenantManager) newManager(ctx context.Context, userID strin
 	reg := prometheus.NewRegistry()
 	r.userManagerMetrics.AddUserRegistry(userID, reg)
 
-	return r.managerFactory(ctx, userID, notifier, r.logger, reg), nil
+	return r.managerFactory(ctx, userID, notifier, r.logger, reg)
 }
 
 func (r *DefaultMultiTenantManager) getOrCreateNotifier(userID string) (*notifier.Manager, error) {
@@ -22,6 +22,12 @@ type ManagerMetrics struct {
 	GroupLastDuration    *prometheus.Desc
 	GroupRules           *prometheus.Desc
 	GroupLastEvalSamples *prometheus.Desc
+
+	RemoteWriteSamples        *prometheus.Desc
+	RemoteWriteSamplesFailed  *prometheus.Desc
+	RemoteWriteSamplesRetried *prometheus.Desc
+	RemoteWriteSamplesDropped *prometheus.Desc
+	RemoteWriteSamplesPending *prometheus.Desc
 }
 
 // NewManagerMetrics returns a ManagerMetrics struct
@@ -95,6 +101,36 @@ func NewManagerMetrics() *ManagerMetrics {
 			[]string{"user", "rule_group"},
 			nil,
 		),
+		RemoteWriteSamples: prometheus.NewDesc(
+			"cortex_ruler_remote_write_samples_total",
+			"Total number of samples sent to the remote-write endpoint.",
+			[]string{"user"},
+			nil,
+		),
+		RemoteWriteSamplesFailed: prometheus.NewDesc(
+			"cortex_ruler_remote_write_samples_failed_total",
+			"Total number of samples which failed on send to the remote-write endpoint, non-recoverable errors.",
+			[]string{"user"},
+			nil,
+		),
+		RemoteWriteSamplesRetried: prometheus.NewDesc(
+			"cortex_ruler_remote_write_samples_retried_total",
+			"Total number of samples which failed on send to the remote-write endpoint, but were retried because the send error was recoverable.",
+			[]string{"user"},
+			nil,
+		),
+		RemoteWriteSamplesDropped: prometheus.NewDesc(
+			"cortex_ruler_remote_write_samples_dropped_total",
+			"Total number of samples which were dropped after being read from the WAL before being sent to the remote-write endpoint.",
+			[]string{"user"},
+			nil,
+		),
+		RemoteWriteSamplesPending: prometheus.NewDesc(
+			"cortex_ruler_remote_write_samples_pending",
+			"The number of samples pending in the queues shards to be sent to the remote-write endpoint.",
+			[]string{"user"},
+			nil,
+		),
 	}
 }
 
@@ -121,6 +157,11 @@ func (m *ManagerMetrics) Describe(out chan<- *prometheus.Desc) {
 	out <- m.GroupLastDuration
 	out <- m.GroupRules
 	out <- m.GroupLastEvalSamples
+	out <- m.RemoteWriteSamples
+	out <- m.RemoteWriteSamplesFailed
+	out <- m.RemoteWriteSamplesRetried
+	out <- m.RemoteWriteSamplesDropped
+	out <- m.RemoteWriteSamplesPending
 }
 
 // Collect implements the Collector interface
@@ -144,4 +185,11 @@ func (m *ManagerMetrics) Collect(out chan<- prometheus.Metric) {
 	data.SendSumOfGaugesPerUserWithLabels(out, m.GroupLastDuration, "prometheus_rule_group_last_duration_seconds", "rule_group")
 	data.SendSumOfGaugesPerUserWithLabels(out, m.GroupRules, "prometheus_rule_group_rules", "rule_group")
 	data.SendSumOfGaugesPerUserWithLabels(out, m.GroupLastEvalSamples, "prometheus_rule_group_last_evaluation_samples", "rule_group")
+
+	// Remote-write metrics are only exported when the ruler remote-write is enabled.
+	data.SendSumOfCountersPerUser(out, m.RemoteWriteSamples, "prometheus_remote_storage_samples_total")
+	data.SendSumOfCountersPerUser(out, m.RemoteWriteSamplesFailed, "prometheus_remote_storage_samples_failed_total")
+	data.SendSumOfCountersPerUser(out, m.RemoteWriteSamplesRetried, "prometheus_remote_storage_samples_retried_total")
+	data.SendSumOfCountersPerUser(out, m.RemoteWriteSamplesDropped, "prometheus_remote_storage_samples_dropped_total")
+	data.SendSumOfGaugesPerUser(out, m.RemoteWriteSamplesPending, "prometheus_remote_storage_samples_pending")
 }
@@ -105,8 +105,8 @@ func getManager(m *DefaultMultiTenantManager, user string) RulesManager {
 	return m.userManagers[user]
 }
 
-func factory(_ context.Context, _ string, _ *notifier.Manager, _ log.Logger, _ prometheus.Registerer) RulesManager {
-	return &mockRulesManager{done: make(chan struct{})}
+func factory(_ context.Context, _ string, _ *notifier.Manager, _ log.Logger, _ prometheus.Registerer) (RulesManager, error) {
+	return &mockRulesManager{done: make(chan struct{})}, nil
 }
 
 type mockRulesManager struct {
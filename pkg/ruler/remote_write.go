@@ -0,0 +1,184 @@
+package ruler
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	config_util "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/util/flagext"
+)
+
+var errRemoteWriteURLRequired = errors.New("the remote-write URL is required when the ruler remote-write is enabled")
+
+// RemoteWriteConfig configures the ruler to write the recording rules results
+// to a remote-write endpoint instead of pushing them to the distributors.
+type RemoteWriteConfig struct {
+	Enabled       bool             `yaml:"enabled"`
+	URL           flagext.URLValue `yaml:"url"`
+	RemoteTimeout time.Duration    `yaml:"remote_timeout"`
+	WALDir        string           `yaml:"wal_dir"`
+	FlushDeadline time.Duration    `yaml:"flush_deadline"`
+
+	QueueCapacity     int           `yaml:"queue_capacity"`
+	MinShards         int           `yaml:"min_shards"`
+	MaxShards         int           `yaml:"max_shards"`
+	MaxSamplesPerSend int           `yaml:"max_samples_per_send"`
+	BatchSendDeadline time.Duration `yaml:"batch_send_deadline"`
+	MinBackoff        time.Duration `yaml:"min_backoff"`
+	MaxBackoff        time.Duration `yaml:"max_backoff"`
+}
+
+// RegisterFlags adds the flags required to config this to the given FlagSet
+func (cfg *RemoteWriteConfig) RegisterFlags(f *flag.FlagSet) {
+	defaults := config.DefaultQueueConfig
+
+	f.BoolVar(&cfg.Enabled, "ruler.remote-write.enabled", false, "Write the recording rules results to the remote-write URL, instead of pushing them to the distributors.")
+	f.Var(&cfg.URL, "ruler.remote-write.url", "URL of the Prometheus remote-write endpoint the recording rules results are written to. The tenant ID is sent in the X-Scope-OrgID header.")
+	f.DurationVar(&cfg.RemoteTimeout, "ruler.remote-write.remote-timeout", 30*time.Second, "Timeout for requests to the remote-write endpoint.")
+	f.StringVar(&cfg.WALDir, "ruler.remote-write.wal-dir", "./ruler-wal", "Directory to store the per-tenant write-ahead log the remote-write queues read from.")
+	f.DurationVar(&cfg.FlushDeadline, "ruler.remote-write.flush-deadline", time.Minute, "How long to wait for the pending samples to be written when the tenant rules manager is stopped.")
+	f.IntVar(&cfg.QueueCapacity, "ruler.remote-write.queue-capacity", defaults.Capacity, "Number of samples to buffer per shard before blocking reading from the write-ahead log.")
+	f.IntVar(&cfg.MinShards, "ruler.remote-write.min-shards", defaults.MinShards, "Minimum number of shards, i.e. amount of concurrency, per tenant.")
+	f.IntVar(&cfg.MaxShards, "ruler.remote-write.max-shards", defaults.MaxShards, "Maximum number of shards, i.e. amount of concurrency, per tenant.")
+	f.IntVar(&cfg.MaxSamplesPerSend, "ruler.remote-write.max-samples-per-send", defaults.MaxSamplesPerSend, "Maximum number of samples per send.")
+	f.DurationVar(&cfg.BatchSendDeadline, "ruler.remote-write.batch-send-deadline", time.Duration(defaults.BatchSendDeadline), "Maximum time a sample will wait in buffer.")
+	f.DurationVar(&cfg.MinBackoff, "ruler.remote-write.min-backoff", time.Duration(defaults.MinBackoff), "Initial retry delay. Gets doubled for every retry.")
+	f.DurationVar(&cfg.MaxBackoff, "ruler.remote-write.max-backoff", time.Duration(defaults.MaxBackoff), "Maximum retry delay.")
+}
+
+// Validate the remote-write config and returns an error if the validation doesn't pass.
+func (cfg *RemoteWriteConfig) Validate() error {
+	if cfg.Enabled && (cfg.URL.URL == nil || cfg.URL.String() == "") {
+		return errRemoteWriteURLRequired
+	}
+	return nil
+}
+
+// remoteWriteStorage is the storage.Appendable used by a tenant rules manager when
+// the remote-write is enabled. Samples are appended to a local TSDB, whose WAL is
+// tailed by the Prometheus remote-write queue which sends them to the remote endpoint.
+type remoteWriteStorage struct {
+	db     *tsdb.DB
+	remote *remote.Storage
+	fanout storage.Storage
+
+	userID      string
+	rulesLimits RulesLimits
+}
+
+func newRemoteWriteStorage(cfg RemoteWriteConfig, userID string, rulesLimits RulesLimits, logger log.Logger, reg prometheus.Registerer) (*remoteWriteStorage, error) {
+	dir := filepath.Join(cfg.WALDir, userID)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "create ruler WAL directory")
+	}
+
+	// The local TSDB is only used as write-ahead log for the remote-write queue,
+	// so the compacted blocks are removed as soon as possible.
+	opts := tsdb.DefaultOptions()
+	opts.RetentionDuration = opts.MaxBlockDuration
+
+	db, err := tsdb.Open(dir, log.With(logger, "component", "ruler-wal"), reg, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "open ruler WAL")
+	}
+
+	rs := remote.NewStorage(log.With(logger, "component", "ruler-remote-write"), reg, db.StartTime, dir, cfg.FlushDeadline, nil)
+	if err := rs.ApplyConfig(buildRemoteWriteConfig(cfg, userID)); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "apply remote-write config")
+	}
+
+	return &remoteWriteStorage{
+		db:          db,
+		remote:      rs,
+		fanout:      storage.NewFanout(logger, db, rs),
+		userID:      userID,
+		rulesLimits: rulesLimits,
+	}, nil
+}
+
+// buildRemoteWriteConfig returns the Prometheus config of the remote-write queue for the input tenant.
+func buildRemoteWriteConfig(cfg RemoteWriteConfig, userID string) *config.Config {
+	return &config.Config{
+		GlobalConfig: config.DefaultGlobalConfig,
+		RemoteWriteConfigs: []*config.RemoteWriteConfig{{
+			URL:           &config_util.URL{URL: cfg.URL.URL},
+			RemoteTimeout: model.Duration(cfg.RemoteTimeout),
+			Headers:       map[string]string{user.OrgIDHeaderName: userID},
+			QueueConfig: config.QueueConfig{
+				Capacity:          cfg.QueueCapacity,
+				MinShards:         cfg.MinShards,
+				MaxShards:         cfg.MaxShards,
+				MaxSamplesPerSend: cfg.MaxSamplesPerSend,
+				BatchSendDeadline: model.Duration(cfg.BatchSendDeadline),
+				MinBackoff:        model.Duration(cfg.MinBackoff),
+				MaxBackoff:        model.Duration(cfg.MaxBackoff),
+			},
+			MetadataConfig: config.MetadataConfig{Send: false},
+		}},
+	}
+}
+
+// Appender returns a storage.Appender
+func (s *remoteWriteStorage) Appender(ctx context.Context) storage.Appender {
+	return &delayedStaleMarkersAppender{
+		Appender:        s.fanout.Appender(ctx),
+		evaluationDelay: s.rulesLimits.EvaluationDelay(s.userID),
+	}
+}
+
+// Close stops the remote-write queue, waiting up to the flush deadline for the
+// pending samples to be sent, and then closes the WAL.
+func (s *remoteWriteStorage) Close() error {
+	if err := s.remote.Close(); err != nil {
+		_ = s.db.Close()
+		return err
+	}
+	return s.db.Close()
+}
+
+// delayedStaleMarkersAppender adapts the staleness markers timestamp for the
+// ruler evaluation delay. See pusherAppender.Add() for more details.
+type delayedStaleMarkersAppender struct {
+	storage.Appender
+	evaluationDelay time.Duration
+}
+
+func (a *delayedStaleMarkersAppender) Add(l labels.Labels, t int64, v float64) (uint64, error) {
+	return a.Appender.Add(l, adjustStaleMarkerTimestamp(t, v, a.evaluationDelay), v)
+}
+
+func (a *delayedStaleMarkersAppender) AddFast(ref uint64, t int64, v float64) error {
+	return a.Appender.AddFast(ref, adjustStaleMarkerTimestamp(t, v, a.evaluationDelay), v)
+}
+
+// remoteWriteRulesManager is a RulesManager closing the tenant remote-write
+// storage once the rules manager has been stopped.
+type remoteWriteRulesManager struct {
+	RulesManager
+	storage *remoteWriteStorage
+	logger  log.Logger
+}
+
+func (m *remoteWriteRulesManager) Stop() {
+	m.RulesManager.Stop()
+
+	if err := m.storage.Close(); err != nil {
+		level.Warn(m.logger).Log("msg", "failed to close ruler remote-write storage", "err", err)
+	}
+}
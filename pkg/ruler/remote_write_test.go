@@ -0,0 +1,116 @@
+package ruler
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+
+	"github.com/cortexproject/cortex/pkg/util/flagext"
+	"github.com/cortexproject/cortex/pkg/util/test"
+)
+
+func TestRemoteWriteConfig_Validate(t *testing.T) {
+	cfg := RemoteWriteConfig{}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	require.Equal(t, errRemoteWriteURLRequired, cfg.Validate())
+
+	require.NoError(t, cfg.URL.Set("http://localhost/api/v1/push"))
+	require.NoError(t, cfg.Validate())
+}
+
+func TestRemoteWriteStorage(t *testing.T) {
+	var (
+		mtx      sync.Mutex
+		received = map[string][]prompb.TimeSeries{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := remote.DecodeWriteRequest(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mtx.Lock()
+		orgID := r.Header.Get(user.OrgIDHeaderName)
+		received[orgID] = append(received[orgID], req.Timeseries...)
+		mtx.Unlock()
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ruler-wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	cfg := RemoteWriteConfig{
+		Enabled:           true,
+		URL:               flagext.URLValue{},
+		RemoteTimeout:     time.Second,
+		WALDir:            dir,
+		FlushDeadline:     time.Second,
+		QueueCapacity:     10,
+		MinShards:         1,
+		MaxShards:         1,
+		MaxSamplesPerSend: 10,
+		BatchSendDeadline: 10 * time.Millisecond,
+		MinBackoff:        10 * time.Millisecond,
+		MaxBackoff:        100 * time.Millisecond,
+	}
+	require.NoError(t, cfg.URL.Set(server.URL))
+
+	evalDelay := time.Minute
+	s, err := newRemoteWriteStorage(cfg, "user-1", ruleLimits{evalDelay: evalDelay}, log.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// The remote-write queue only sends samples newer than its start time.
+	now := time.Now().Add(time.Second).UnixNano() / int64(time.Millisecond)
+	series := labels.FromStrings(labels.MetricName, "test:sum", "job", "test")
+
+	app := s.Appender(context.Background())
+	_, err = app.Add(series, now, 1)
+	require.NoError(t, err)
+	_, err = app.Add(series, now+evalDelay.Milliseconds()+1000, math.Float64frombits(value.StaleNaN))
+	require.NoError(t, err)
+	require.NoError(t, app.Commit())
+
+	receivedSamples := func() []prompb.Sample {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		var samples []prompb.Sample
+		for _, ts := range received["user-1"] {
+			assert.Equal(t, []prompb.Label{{Name: labels.MetricName, Value: "test:sum"}, {Name: "job", Value: "test"}}, ts.Labels)
+			samples = append(samples, ts.Samples...)
+		}
+		return samples
+	}
+
+	test.Poll(t, 5*time.Second, 2, func() interface{} {
+		return len(receivedSamples())
+	})
+	require.NoError(t, s.Close())
+
+	// The stale marker timestamp is expected to be adjusted by the evaluation delay.
+	samples := receivedSamples()
+	assert.Equal(t, prompb.Sample{Value: 1, Timestamp: now}, samples[0])
+	assert.True(t, value.IsStaleNaN(samples[1].Value))
+	assert.Equal(t, now+1000, samples[1].Timestamp)
+}
@@ -105,6 +105,15 @@ type Config struct {
 
 	EnableAPI bool `yaml:"enable_api"`
 
+	RemoteWrite RemoteWriteConfig `yaml:"remote_write"`
+
+	// Address of the query-frontend to evaluate the rules queries through.
+	FrontendAddress string `yaml:"frontend_address"`
+	// GRPC Client configuration for the query-frontend.
+	FrontendClient grpcclient.Config `yaml:"frontend_client"`
+	// Timeout of the rules queries run through the query-frontend.
+	FrontendTimeout time.Duration `yaml:"frontend_timeout"`
+
 	RingCheckPeriod time.Duration `yaml:"-"`
 }
 
@@ -124,6 +133,12 @@ func (cfg *Config) Validate(limits validation.Limits, log log.Logger) error {
 	if err := cfg.ClientTLSConfig.Validate(log); err != nil {
 		return errors.Wrap(err, "invalid ruler gRPC client config")
 	}
+	if err := cfg.FrontendClient.Validate(log); err != nil {
+		return errors.Wrap(err, "invalid ruler query-frontend gRPC client config")
+	}
+	if err := cfg.RemoteWrite.Validate(); err != nil {
+		return errors.Wrap(err, "invalid ruler remote-write config")
+	}
 	return nil
 }
 
@@ -133,6 +148,8 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	cfg.StoreConfig.RegisterFlags(f)
 	cfg.Ring.RegisterFlags(f)
 	cfg.Notifier.RegisterFlags(f)
+	cfg.RemoteWrite.RegisterFlags(f)
+	cfg.FrontendClient.RegisterFlagsWithPrefix("ruler.frontend-client", f)
 
 	// Deprecated Flags that will be maintained to avoid user disruption
 	flagext.DeprecatedFlag(f, "ruler.client-timeout", "This flag has been renamed to ruler.configs.client-timeout")
@@ -157,6 +174,8 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.FlushCheckPeriod, "ruler.flush-period", 1*time.Minute, "Period with which to attempt to flush rule groups.")
 	f.StringVar(&cfg.RulePath, "ruler.rule-path", "/rules", "file path to store temporary rule files for the prometheus rule managers")
 	f.BoolVar(&cfg.EnableAPI, "experimental.ruler.enable-api", false, "Enable the ruler api")
+	f.StringVar(&cfg.FrontendAddress, "ruler.frontend-address", "", "GRPC listen address of the query-frontend(s) to evaluate the rules queries through, benefiting from the query-frontend results cache, query sharding and splitting. The address is resolved via DNS and the queries are balanced across all the resolved query-frontends. If empty, the rules queries are evaluated locally by the ruler.")
+	f.DurationVar(&cfg.FrontendTimeout, "ruler.frontend-timeout", 2*time.Minute, "Timeout of the rules queries evaluated through the query-frontend. 0 to disable.")
 	f.DurationVar(&cfg.OutageTolerance, "ruler.for-outage-tolerance", time.Hour, `Max time to tolerate outage for restoring "for" state of alert.`)
 	f.DurationVar(&cfg.ForGracePeriod, "ruler.for-grace-period", 10*time.Minute, `Minimum duration between alert and restored "for" state. This is maintained only for alerts with configured "for" time greater than grace period.`)
 	f.DurationVar(&cfg.ResendDelay, "ruler.resend-delay", time.Minute, `Minimum amount of time to wait before resending an alert to Alertmanager.`)
@@ -214,6 +233,8 @@ type Ruler struct {
 	manager     MultiTenantManager
 	limits      RulesLimits
 
+	subservicesWatcher *services.FailureWatcher
+
 	ringCheckErrors prometheus.Counter
 	rulerSync       *prometheus.CounterVec
 
@@ -297,6 +318,9 @@ func (r *Ruler) starting(ctx context.Context) error {
 		var err error
 		r.subservices, err = services.NewManager(r.lifecycler, r.ring)
 		if err == nil {
+			r.subservicesWatcher = services.NewFailureWatcher()
+			r.subservicesWatcher.WatchManager(r.subservices)
+
 			err = services.StartManagerAndAwaitHealthy(ctx, r.subservices)
 		}
 		return errors.Wrap(err, "failed to start ruler's services")
@@ -375,6 +399,15 @@ func instanceOwnsRuleGroup(r ring.ReadRing, g *rules.RuleGroupDesc, instanceAddr
 	return rlrs.Ingesters[0].Addr == instanceAddr, nil
 }
 
+// Ring returns the ruler ring, or nil if sharding is disabled.
+func (r *Ruler) Ring() *ring.Ring {
+	if !r.cfg.EnableSharding {
+		return nil
+	}
+
+	return r.ring
+}
+
 func (r *Ruler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	if r.cfg.EnableSharding {
 		r.ring.ServeHTTP(w, req)
@@ -420,6 +453,8 @@ func (r *Ruler) run(ctx context.Context) error {
 		select {
 		case <-ctx.Done():
 			return nil
+		case err := <-r.subservicesWatcher.Chan():
+			return errors.Wrap(err, "ruler subservice failed")
 		case <-tick.C:
 			r.syncRules(ctx, rulerSyncReasonPeriodic)
 		case <-ringTickerChan:
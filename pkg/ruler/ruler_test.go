@@ -117,7 +117,7 @@ func testSetup(t *testing.T, cfg Config) (*promql.Engine, storage.QueryableFunc,
 
 func newManager(t *testing.T, cfg Config) (*DefaultMultiTenantManager, func()) {
 	engine, noopQueryable, pusher, logger, overrides, cleanup := testSetup(t, cfg)
-	manager, err := NewDefaultMultiTenantManager(cfg, DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, overrides), prometheus.NewRegistry(), logger)
+	manager, err := NewDefaultMultiTenantManager(cfg, DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, nil, overrides), prometheus.NewRegistry(), logger)
 	require.NoError(t, err)
 
 	return manager, cleanup
@@ -129,7 +129,7 @@ func newRuler(t *testing.T, cfg Config) (*Ruler, func()) {
 	require.NoError(t, err)
 
 	reg := prometheus.NewRegistry()
-	managerFactory := DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, overrides)
+	managerFactory := DefaultTenantManagerFactory(cfg, pusher, noopQueryable, engine, nil, overrides)
 	manager, err := NewDefaultMultiTenantManager(cfg, managerFactory, reg, util_log.Logger)
 	require.NoError(t, err)
 
@@ -272,6 +272,7 @@ func TestSharding(t *testing.T) {
 		sharding         bool
 		shardingStrategy string
 		shuffleShardSize int
+		zoneAwareness    bool
 		setupRing        func(*ring.Desc)
 
 		expectedRules expectedRulesMap
@@ -499,6 +500,31 @@ func TestSharding(t *testing.T) {
 				},
 			},
 		},
+		"shuffle sharding, three rulers in two zones, shard size 2, zone-awareness enabled": {
+			sharding:         true,
+			shardingStrategy: util.ShardingStrategyShuffle,
+			shuffleShardSize: 2,
+			zoneAwareness:    true,
+
+			setupRing: func(desc *ring.Desc) {
+				// Without zone-awareness, ruler-2 would be selected in the shard of every user.
+				desc.AddIngester(ruler1, ruler1Addr, "zone-a", sortTokens([]uint32{userZoneToken(user1, "zone-a", 0) + 1, userZoneToken(user2, "zone-a", 0) + 1, userZoneToken(user3, "zone-a", 0) + 1, user1Group1Token + 1}), ring.ACTIVE, time.Now())
+				desc.AddIngester(ruler2, ruler2Addr, "zone-a", sortTokens([]uint32{userToken(user1, 0) + 1, userToken(user2, 0) + 1, userToken(user3, 0) + 1}), ring.ACTIVE, time.Now())
+				desc.AddIngester(ruler3, ruler3Addr, "zone-b", sortTokens([]uint32{user1Group2Token + 1, user2Group1Token + 1, user3Group1Token + 1}), ring.ACTIVE, time.Now())
+			},
+
+			expectedRules: expectedRulesMap{
+				ruler1: map[string]rules.RuleGroupList{
+					user1: {user1Group1},
+				},
+				ruler2: noRules, // Ruler-2 is in the same zone as ruler-1, so it's never selected in the users' shard.
+				ruler3: map[string]rules.RuleGroupList{
+					user1: {user1Group2},
+					user2: {user2Group1},
+					user3: {user3Group1},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -517,7 +543,8 @@ func TestSharding(t *testing.T) {
 						KVStore: kv.Config{
 							Mock: kvStore,
 						},
-						HeartbeatTimeout: 1 * time.Minute,
+						HeartbeatTimeout:     1 * time.Minute,
+						ZoneAwarenessEnabled: tc.zoneAwareness,
 					},
 					FlushCheckPeriod: 0,
 				}
@@ -596,7 +623,11 @@ func TestSharding(t *testing.T) {
 
 // User shuffle shard token.
 func userToken(user string, skip int) uint32 {
-	r := rand.New(rand.NewSource(util.ShuffleShardSeed(user, "")))
+	return userZoneToken(user, "", skip)
+}
+
+func userZoneToken(user, zone string, skip int) uint32 {
+	r := rand.New(rand.NewSource(util.ShuffleShardSeed(user, zone)))
 
 	for ; skip > 0; skip-- {
 		_ = r.Uint32()
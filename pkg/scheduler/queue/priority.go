@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextKey int
+
+const priorityContextKey contextKey = 0
+
+// ContextWithPriority returns a new context carrying the priority of the request in the queue.
+func ContextWithPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, priorityContextKey, priority)
+}
+
+// PriorityFromContext returns the priority of the request carried by the context, or the
+// default priority 0 if not set.
+func PriorityFromContext(ctx context.Context) int {
+	priority, _ := ctx.Value(priorityContextKey).(int)
+	return priority
+}
+
+// ValidateReservedQuerierWorkers validates the fractions of querier workers reserved per priority.
+// The reserved fractions must sum up to less than 1, so that there is always some capacity left
+// for the lowest priority requests.
+func ValidateReservedQuerierWorkers(reserved map[int]float64) error {
+	total := 0.0
+	for priority, fraction := range reserved {
+		if fraction < 0 || fraction >= 1 {
+			return fmt.Errorf("the fraction of querier workers reserved to priority %d must be between 0 and 1, got %v", priority, fraction)
+		}
+		total += fraction
+	}
+
+	if total >= 1 {
+		return fmt.Errorf("the fractions of querier workers reserved per priority must sum up to less than 1, got %v", total)
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package queue
 
 import (
 	"context"
+	"math"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,25 +39,52 @@ func FirstUser() UserIndex {
 // Request stored into the queue.
 type Request interface{}
 
+// ChargedRequest is implemented by the requests whose cost is reported with ReportRequestCost. When such
+// a request is dequeued, its user is charged upfront the estimated cost of the request, so that the querier
+// time of the requests still in-flight is accounted too. The charged cost is stored into the request, and
+// must be passed to ReportRequestCost to be corrected with the actual cost.
+type ChargedRequest interface {
+	SetChargedCost(cost float64)
+}
+
 // RequestQueue holds incoming requests in per-user queues. It also assigns each user specified number of queriers,
 // and when querier asks for next request to handle (using GetNextRequestForQuerier), it returns requests
-// in a fair fashion.
+// in a fair fashion: users which consumed the least querier time relative to their weight, as reported by
+// ReportRequestCost, are served first, falling back to round-robin when no cost is reported. Within a user queue, requests with higher priority are returned first.
 type RequestQueue struct {
 	connectedQuerierWorkers *atomic.Int32
 
+	// Fraction of the connected querier workers reserved to the requests of each priority and above.
+	reservedQuerierWorkers map[int]float64
+
 	mtx     sync.Mutex
 	cond    *sync.Cond // Notified when request is enqueued or dequeued, or querier is disconnected.
 	queues  *queues
 	stopped bool
 
-	queueLength *prometheus.GaugeVec // Per user.
+	// Number of querier workers waiting in GetNextRequestForQuerier.
+	waitingQuerierWorkers int
+
+	// Number of queued requests per priority, used to delete the per-priority metric
+	// once no request of the priority is queued anymore.
+	lengthPerPriority map[int]int
+
+	queueLength            *prometheus.GaugeVec // Per user.
+	queueLengthPerPriority *prometheus.GaugeVec // Per priority.
 }
 
-func NewRequestQueue(maxOutstandingPerTenant int, queueLength *prometheus.GaugeVec) *RequestQueue {
+// NewRequestQueue creates a new RequestQueue. The reservedQuerierWorkers is the fraction of the connected
+// querier workers reserved to the requests of each priority and above: a request can only be dequeued if
+// the number of waiting querier workers is greater than the capacity reserved to the higher priorities.
+// The queueLengthPerPriority is optional.
+func NewRequestQueue(maxOutstandingPerTenant int, reservedQuerierWorkers map[int]float64, queueLength, queueLengthPerPriority *prometheus.GaugeVec) *RequestQueue {
 	q := &RequestQueue{
 		queues:                  newUserQueues(maxOutstandingPerTenant),
 		connectedQuerierWorkers: atomic.NewInt32(0),
+		reservedQuerierWorkers:  reservedQuerierWorkers,
+		lengthPerPriority:       map[int]int{},
 		queueLength:             queueLength,
+		queueLengthPerPriority:  queueLengthPerPriority,
 	}
 
 	q.cond = sync.NewCond(&q.mtx)
@@ -64,10 +94,10 @@ func NewRequestQueue(maxOutstandingPerTenant int, queueLength *prometheus.GaugeV
 
 // EnqueueRequest puts the request into the queue. MaxQueries is user-specific value that specifies how many queriers can
 // this user use (zero or negative = all queriers). It is passed to each EnqueueRequest, because it can change
-// between calls.
+// between calls. Requests of the user with higher priority are dequeued first, 0 being the default priority.
 //
 // If request is successfully enqueued, successFn is called with the lock held, before any querier can receive the request.
-func (q *RequestQueue) EnqueueRequest(userID string, req Request, maxQueriers int, successFn func()) error {
+func (q *RequestQueue) EnqueueRequest(userID string, req Request, priority int, maxQueriers int, successFn func()) error {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
 
@@ -81,18 +111,22 @@ func (q *RequestQueue) EnqueueRequest(userID string, req Request, maxQueriers in
 		return errors.New("no queue found")
 	}
 
-	select {
-	case queue <- req:
-		q.queueLength.WithLabelValues(userID).Inc()
-		q.cond.Broadcast()
-		// Call this function while holding a lock. This guarantees that no querier can fetch the request before function returns.
-		if successFn != nil {
-			successFn()
-		}
-		return nil
-	default:
+	if queue.length >= q.queues.maxUserQueueSize {
 		return ErrTooManyRequests
 	}
+
+	queue.enqueue(req, priority)
+	q.queueLength.WithLabelValues(userID).Inc()
+	q.lengthPerPriority[priority]++
+	if q.queueLengthPerPriority != nil {
+		q.queueLengthPerPriority.WithLabelValues(strconv.Itoa(priority)).Inc()
+	}
+	q.cond.Broadcast()
+	// Call this function while holding a lock. This guarantees that no querier can fetch the request before function returns.
+	if successFn != nil {
+		successFn()
+	}
+	return nil
 }
 
 // GetNextRequestForQuerier find next user queue and takes the next request off of it. Will block if there are no requests.
@@ -102,6 +136,16 @@ func (q *RequestQueue) GetNextRequestForQuerier(ctx context.Context, last UserIn
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
 
+	q.waitingQuerierWorkers++
+	defer func() {
+		q.waitingQuerierWorkers--
+	}()
+
+	// With reserved capacity, a waiting querier worker may allow others to dequeue lower priority requests.
+	if len(q.reservedQuerierWorkers) > 0 {
+		q.cond.Broadcast()
+	}
+
 	querierWait := false
 
 FindQueue:
@@ -119,27 +163,59 @@ FindQueue:
 		return nil, last, err
 	}
 
-	for {
-		queue, userID, idx := q.queues.getNextQueueForQuerier(last.last, querierID)
-		last.last = idx
-		if queue == nil {
+	// Among the users handled by this querier, pick the one with the lowest virtual time, that is the
+	// one which consumed the least querier time relative to its weight. Ties are broken in round-robin
+	// order, starting after the last user.
+	var (
+		queue     *userQueue
+		userID    string
+		userIndex int
+	)
+	firstIndex := -1
+	for iters, idx := q.queues.len(), last.last; iters > 0; iters-- {
+		uq, u, ix := q.queues.getNextQueueForQuerier(idx, querierID)
+		if uq == nil || ix == firstIndex {
 			break
 		}
+		if firstIndex < 0 {
+			firstIndex = ix
+		}
+		idx = ix
 
-		// Pick next request from the queue.
-		for {
-			request := <-queue
-			if len(queue) == 0 {
-				q.queues.deleteQueue(userID)
-			}
+		// Skip the user if its next request can only use the capacity reserved to higher priorities.
+		if !q.canDequeue(uq.nextPriority()) {
+			continue
+		}
 
-			q.queueLength.WithLabelValues(userID).Dec()
+		if queue == nil || uq.virtualTime < queue.virtualTime {
+			queue, userID, userIndex = uq, u, ix
+		}
+	}
 
-			// Tell close() we've processed a request.
-			q.cond.Broadcast()
+	if queue != nil {
+		last.last = userIndex
+		if queue.virtualTime > q.queues.virtualTime {
+			q.queues.virtualTime = queue.virtualTime
+		}
 
-			return request, last, nil
+		// Pick next request from the queue.
+		request, priority := queue.dequeue()
+		if r, ok := request.(ChargedRequest); ok {
+			cost := q.queues.estimatedCosts[userID]
+			queue.virtualTime += cost
+			r.SetChargedCost(cost)
+		}
+		if queue.length == 0 {
+			q.queues.deleteQueue(userID)
 		}
+
+		q.queueLength.WithLabelValues(userID).Dec()
+		q.dequeuedPriority(priority)
+
+		// Tell close() we've processed a request.
+		q.cond.Broadcast()
+
+		return request, last, nil
 	}
 
 	// There are no unexpired requests, so we can get back
@@ -148,6 +224,72 @@ FindQueue:
 	goto FindQueue
 }
 
+// ReportRequestCost accounts the querier time consumed by a request of the user, divided by the weight
+// of the user (non-positive weights are treated as 1), correcting the cost charged when the request was
+// dequeued. Users which consumed less querier time relative to their weight are served first. A zero
+// cost refunds the charged cost, for the requests which have not been executed.
+func (q *RequestQueue) ReportRequestCost(userID string, cost time.Duration, weight, charged float64) {
+	if weight <= 0 {
+		weight = 1
+	}
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	actual := 0.0
+	if cost > 0 {
+		actual = cost.Seconds() / weight
+		q.queues.updateEstimatedCost(userID, actual)
+	}
+	q.queues.addCost(userID, actual-charged)
+}
+
+// CleanupInactiveUser forgets the querier time consumed by the user, if it has no pending requests, and
+// the estimated cost of its requests.
+func (q *RequestQueue) CleanupInactiveUser(userID string) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.queues.deleteIdleUser(userID)
+}
+
+// dequeuedPriority accounts a request of the given priority removed from the queue, deleting the
+// per-priority metric once no request of the priority is queued anymore, so that the metric series
+// of the priorities no longer used don't pile up. Must be called with the lock held.
+func (q *RequestQueue) dequeuedPriority(priority int) {
+	q.lengthPerPriority[priority]--
+	drained := q.lengthPerPriority[priority] <= 0
+	if drained {
+		delete(q.lengthPerPriority, priority)
+	}
+
+	if q.queueLengthPerPriority == nil {
+		return
+	}
+	if drained {
+		q.queueLengthPerPriority.DeleteLabelValues(strconv.Itoa(priority))
+	} else {
+		q.queueLengthPerPriority.WithLabelValues(strconv.Itoa(priority)).Dec()
+	}
+}
+
+// canDequeue returns whether a waiting querier worker can dequeue a request of the given priority,
+// without using the capacity reserved to higher priorities. Must be called with the lock held.
+func (q *RequestQueue) canDequeue(priority int) bool {
+	if len(q.reservedQuerierWorkers) == 0 {
+		return true
+	}
+
+	reserved := 0.0
+	for p, fraction := range q.reservedQuerierWorkers {
+		if p > priority {
+			reserved += fraction
+		}
+	}
+
+	reservedWorkers := int(math.Floor(reserved * float64(q.connectedQuerierWorkers.Load())))
+	return q.waitingQuerierWorkers > reservedWorkers
+}
+
 func (q *RequestQueue) Stop() {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func BenchmarkGetNextRequest(b *testing.B) {
@@ -17,7 +22,7 @@ func BenchmarkGetNextRequest(b *testing.B) {
 	queues := make([]*RequestQueue, 0, b.N)
 
 	for n := 0; n < b.N; n++ {
-		queue := NewRequestQueue(maxOutstandingPerTenant, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}))
+		queue := NewRequestQueue(maxOutstandingPerTenant, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
 		queues = append(queues, queue)
 
 		for ix := 0; ix < queriers; ix++ {
@@ -28,7 +33,7 @@ func BenchmarkGetNextRequest(b *testing.B) {
 			for j := 0; j < numTenants; j++ {
 				userID := strconv.Itoa(j)
 
-				err := queue.EnqueueRequest(userID, "request", 0, nil)
+				err := queue.EnqueueRequest(userID, "request", 0, 0, nil)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -71,7 +76,7 @@ func BenchmarkQueueRequest(b *testing.B) {
 	requests := make([]string, 0, numTenants)
 
 	for n := 0; n < b.N; n++ {
-		q := NewRequestQueue(maxOutstandingPerTenant, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}))
+		q := NewRequestQueue(maxOutstandingPerTenant, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
 
 		for ix := 0; ix < queriers; ix++ {
 			q.RegisterQuerierConnection(fmt.Sprintf("querier-%d", ix))
@@ -89,7 +94,7 @@ func BenchmarkQueueRequest(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		for i := 0; i < maxOutstandingPerTenant; i++ {
 			for j := 0; j < numTenants; j++ {
-				err := queues[n].EnqueueRequest(users[j], requests[j], 0, nil)
+				err := queues[n].EnqueueRequest(users[j], requests[j], 0, 0, nil)
 				if err != nil {
 					b.Fatal(err)
 				}
@@ -97,3 +102,246 @@ func BenchmarkQueueRequest(b *testing.B) {
 		}
 	}
 }
+
+func TestRequestQueue_GetNextRequestForQuerier_ShouldServeHigherPriorityFirst(t *testing.T) {
+	queueLengthPerPriority := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_queue_length_per_priority"}, []string{"priority"})
+	queue := NewRequestQueue(10, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), queueLengthPerPriority)
+	queue.RegisterQuerierConnection("querier-1")
+
+	for _, req := range []struct {
+		name     string
+		priority int
+	}{
+		{"low-1", -1},
+		{"default-1", 0},
+		{"high-1", 10},
+		{"default-2", 0},
+		{"high-2", 10},
+		{"low-2", -1},
+	} {
+		require.NoError(t, queue.EnqueueRequest("user-1", req.name, req.priority, 0, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(queueLengthPerPriority.WithLabelValues("10")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(queueLengthPerPriority.WithLabelValues("0")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(queueLengthPerPriority.WithLabelValues("-1")))
+
+	var actual []Request
+	idx := FirstUser()
+	for i := 0; i < 6; i++ {
+		req, nidx, err := queue.GetNextRequestForQuerier(context.Background(), idx, "querier-1")
+		require.NoError(t, err)
+		actual = append(actual, req)
+		idx = nidx
+	}
+
+	assert.Equal(t, []Request{"high-1", "high-2", "default-1", "default-2", "low-1", "low-2"}, actual)
+	assert.Equal(t, 0, queue.queues.len())
+
+	// The per-priority metric series are deleted once the priority queues are drained.
+	assert.Equal(t, 0, testutil.CollectAndCount(queueLengthPerPriority))
+}
+
+func TestRequestQueue_EnqueueRequest_ShouldLimitOutstandingRequestsAcrossPriorities(t *testing.T) {
+	queue := NewRequestQueue(2, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+
+	require.NoError(t, queue.EnqueueRequest("user-1", "request-1", 0, 0, nil))
+	require.NoError(t, queue.EnqueueRequest("user-1", "request-2", 1, 0, nil))
+	assert.Equal(t, ErrTooManyRequests, queue.EnqueueRequest("user-1", "request-3", 2, 0, nil))
+	require.NoError(t, queue.EnqueueRequest("user-2", "request-4", 0, 0, nil))
+}
+
+func TestRequestQueue_GetNextRequestForQuerier_ShouldReserveQuerierWorkersPerPriority(t *testing.T) {
+	// Half of the querier workers are reserved to the requests of priority 10 and above.
+	queue := NewRequestQueue(10, map[int]float64{10: 0.5}, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+	for i := 0; i < 2; i++ {
+		queue.RegisterQuerierConnection("querier-1")
+	}
+
+	require.NoError(t, queue.EnqueueRequest("user-1", "default", 0, 0, nil))
+
+	// A single waiting querier worker can't dequeue the default priority request, because it
+	// would use the capacity reserved to the higher priority.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	go wakeUpOnContextDone(ctx, queue)
+	_, _, err := queue.GetNextRequestForQuerier(ctx, FirstUser(), "querier-1")
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	// A higher priority request can be dequeued.
+	require.NoError(t, queue.EnqueueRequest("user-2", "high", 10, 0, nil))
+	req, _, err := queue.GetNextRequestForQuerier(context.Background(), FirstUser(), "querier-1")
+	require.NoError(t, err)
+	assert.Equal(t, "high", req)
+
+	// Once both querier workers are waiting, the default priority request can be dequeued.
+	results := make(chan Request, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			go wakeUpOnContextDone(ctx, queue)
+
+			req, _, _ := queue.GetNextRequestForQuerier(ctx, FirstUser(), "querier-1")
+			results <- req
+		}()
+	}
+
+	assert.ElementsMatch(t, []Request{"default", nil}, []Request{<-results, <-results})
+}
+
+func TestRequestQueue_GetNextRequestForQuerier_ShouldServeUsersByConsumedQuerierTime(t *testing.T) {
+	queue := NewRequestQueue(10, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+	queue.RegisterQuerierConnection("querier-1")
+
+	for i := 0; i < 3; i++ {
+		for _, userID := range []string{"heavy", "light", "weighted"} {
+			require.NoError(t, queue.EnqueueRequest(userID, fmt.Sprintf("%s-%d", userID, i), 0, 0, nil))
+		}
+	}
+
+	var actual []Request
+	idx := FirstUser()
+	for i := 0; i < 9; i++ {
+		req, nidx, err := queue.GetNextRequestForQuerier(context.Background(), idx, "querier-1")
+		require.NoError(t, err)
+		actual = append(actual, req)
+		idx = nidx
+
+		// The heavy and weighted users consume 10x the querier time of the light one, but the
+		// weighted user has a weight of 10.
+		switch strings.SplitN(req.(string), "-", 2)[0] {
+		case "heavy":
+			queue.ReportRequestCost("heavy", 10*time.Second, 1, 0)
+		case "light":
+			queue.ReportRequestCost("light", time.Second, 1, 0)
+		case "weighted":
+			queue.ReportRequestCost("weighted", 10*time.Second, 10, 0)
+		}
+	}
+
+	// Once the heavy user has been served, it waits for the others to consume as much querier time.
+	assert.Equal(t, []Request{
+		"heavy-0", "light-0", "weighted-0",
+		"light-1", "weighted-1",
+		"light-2", "weighted-2",
+		"heavy-1", "heavy-2",
+	}, actual)
+}
+
+func TestRequestQueue_ReportRequestCost_ShouldNotGiveCreditToIdleUsers(t *testing.T) {
+	queue := NewRequestQueue(10, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+	queue.RegisterQuerierConnection("querier-1")
+
+	// The busy user consumes querier time while the other one is idle.
+	require.NoError(t, queue.EnqueueRequest("busy", "busy-0", 0, 0, nil))
+	req, idx, err := queue.GetNextRequestForQuerier(context.Background(), FirstUser(), "querier-1")
+	require.NoError(t, err)
+	require.Equal(t, "busy-0", req)
+	queue.ReportRequestCost("busy", time.Minute, 1, 0)
+
+	for i := 1; i < 3; i++ {
+		require.NoError(t, queue.EnqueueRequest("busy", fmt.Sprintf("busy-%d", i), 0, 0, nil))
+	}
+	req, idx, err = queue.GetNextRequestForQuerier(context.Background(), idx, "querier-1")
+	require.NoError(t, err)
+	require.Equal(t, "busy-1", req)
+
+	// The user becoming active starts at the current virtual time, so it doesn't starve the busy user.
+	require.NoError(t, queue.EnqueueRequest("idle", "idle-0", 0, 0, nil))
+	require.NoError(t, queue.EnqueueRequest("idle", "idle-1", 0, 0, nil))
+	queue.ReportRequestCost("idle", time.Second, 1, 0)
+
+	req, _, err = queue.GetNextRequestForQuerier(context.Background(), idx, "querier-1")
+	require.NoError(t, err)
+	assert.Equal(t, "busy-2", req)
+
+	// The querier time consumed by the users without pending requests is remembered until cleaned up.
+	queue.ReportRequestCost("busy", time.Minute, 1, 0)
+	assert.Equal(t, 120.0, queue.queues.idleUserVirtualTimes["busy"])
+	queue.CleanupInactiveUser("busy")
+	assert.Empty(t, queue.queues.idleUserVirtualTimes)
+}
+
+func TestRequestQueue_GetNextRequestForQuerier_ShouldChargeInFlightRequests(t *testing.T) {
+	queue := NewRequestQueue(10, nil, prometheus.NewGaugeVec(prometheus.GaugeOpts{}, []string{"user"}), nil)
+	queue.RegisterQuerierConnection("querier-1")
+
+	dequeue := func(idx UserIndex) (*chargedRequest, UserIndex) {
+		req, nidx, err := queue.GetNextRequestForQuerier(context.Background(), idx, "querier-1")
+		require.NoError(t, err)
+		return req.(*chargedRequest), nidx
+	}
+
+	// Both users have run a single request, the heavy one consuming slightly less querier time.
+	idx := FirstUser()
+	for _, r := range []struct {
+		userID string
+		cost   time.Duration
+	}{{"heavy", 10 * time.Second}, {"light", 11 * time.Second}} {
+		require.NoError(t, queue.EnqueueRequest(r.userID, &chargedRequest{id: r.userID + "-0"}, 0, 0, nil))
+		var req *chargedRequest
+		req, idx = dequeue(idx)
+		require.Equal(t, 0.0, req.charged)
+		queue.ReportRequestCost(r.userID, r.cost, 1, req.charged)
+	}
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, queue.EnqueueRequest("heavy", &chargedRequest{id: fmt.Sprintf("heavy-%d", i)}, 0, 0, nil))
+	}
+	for i := 1; i <= 2; i++ {
+		require.NoError(t, queue.EnqueueRequest("light", &chargedRequest{id: fmt.Sprintf("light-%d", i)}, 0, 0, nil))
+	}
+
+	// While the requests of the heavy user are still in-flight, their estimated cost is charged,
+	// so that the light user is not starved.
+	var inFlight []*chargedRequest
+	for i := 0; i < 4; i++ {
+		var req *chargedRequest
+		req, idx = dequeue(idx)
+		inFlight = append(inFlight, req)
+	}
+	assert.Equal(t, "heavy-1", inFlight[0].id)
+	assert.Equal(t, 10.0, inFlight[0].charged)
+	assert.Equal(t, "light-1", inFlight[1].id)
+	assert.Equal(t, 11.0, inFlight[1].charged)
+	assert.Equal(t, "heavy-2", inFlight[2].id)
+	assert.Equal(t, "light-2", inFlight[3].id)
+	assert.Equal(t, 30.0, queue.queues.userQueues["heavy"].virtualTime)
+
+	// The charged cost is corrected with the actual cost once reported, and refunded if the
+	// request was not executed.
+	queue.ReportRequestCost("heavy", 30*time.Second, 1, inFlight[0].charged)
+	assert.Equal(t, 50.0, queue.queues.userQueues["heavy"].virtualTime)
+	queue.ReportRequestCost("heavy", 0, 1, inFlight[2].charged)
+	assert.Equal(t, 40.0, queue.queues.userQueues["heavy"].virtualTime)
+}
+
+type chargedRequest struct {
+	id      string
+	charged float64
+}
+
+func (r *chargedRequest) SetChargedCost(cost float64) {
+	r.charged = cost
+}
+
+// wakeUpOnContextDone unblocks GetNextRequestForQuerier once the context is done, like the
+// schedulers do when the querier disconnects.
+func wakeUpOnContextDone(ctx context.Context, queue *RequestQueue) {
+	<-ctx.Done()
+	queue.QuerierDisconnecting()
+}
+
+func TestValidateReservedQuerierWorkers(t *testing.T) {
+	assert.NoError(t, ValidateReservedQuerierWorkers(nil))
+	assert.NoError(t, ValidateReservedQuerierWorkers(map[int]float64{10: 0.3, 5: 0.2}))
+	assert.Error(t, ValidateReservedQuerierWorkers(map[int]float64{10: -0.1}))
+	assert.Error(t, ValidateReservedQuerierWorkers(map[int]float64{10: 1}))
+	assert.Error(t, ValidateReservedQuerierWorkers(map[int]float64{10: 0.5, 5: 0.5}))
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	assert.Equal(t, 0, PriorityFromContext(context.Background()))
+	assert.Equal(t, -5, PriorityFromContext(ContextWithPriority(context.Background(), -5)))
+}
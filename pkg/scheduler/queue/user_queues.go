@@ -1,12 +1,16 @@
 package queue
 
 import (
+	"math"
 	"math/rand"
 	"sort"
 
 	"github.com/cortexproject/cortex/pkg/util"
 )
 
+// Weight of the last reported cost in the moving average of the cost of the requests of a user.
+const estimatedCostSmoothing = 0.2
+
 // This struct holds user queues for pending requests. It also keeps track of connected queriers,
 // and mapping between users and queriers.
 type queues struct {
@@ -23,10 +27,23 @@ type queues struct {
 	querierConnections map[string]int
 	// Sorted list of querier names, used when creating per-user shard.
 	sortedQueriers []string
+
+	// Virtual time of the last dequeued request, used as the starting virtual time of the users
+	// becoming active, so that idle users can't accumulate credit.
+	virtualTime float64
+	// Virtual times of the users without queue which are ahead of the global virtual time,
+	// so that their consumed querier time is not forgotten when their queue is emptied.
+	idleUserVirtualTimes map[string]float64
+	// Moving average of the cost of the requests of each user, divided by its weight, charged
+	// upfront when a request is dequeued.
+	estimatedCosts map[string]float64
 }
 
 type userQueue struct {
-	ch chan Request
+	// Pending requests grouped by priority, sorted by decreasing priority. Requests of
+	// the same priority are served in FIFO order.
+	priorities []*priorityRequests
+	length     int
 
 	// If not nil, only these queriers can handle user requests. If nil, all queriers can.
 	// We set this to nil if number of available queriers <= maxQueriers.
@@ -39,15 +56,61 @@ type userQueue struct {
 
 	// Points back to 'users' field in queues. Enables quick cleanup.
 	index int
+
+	// Querier time consumed by the user divided by its weight, in seconds. Users with
+	// the lowest virtual time are served first.
+	virtualTime float64
+}
+
+type priorityRequests struct {
+	priority int
+	requests []Request
+}
+
+// enqueue adds the request to the queue of its priority.
+func (uq *userQueue) enqueue(req Request, priority int) {
+	ix := sort.Search(len(uq.priorities), func(i int) bool {
+		return uq.priorities[i].priority <= priority
+	})
+
+	if ix == len(uq.priorities) || uq.priorities[ix].priority != priority {
+		uq.priorities = append(uq.priorities, nil)
+		copy(uq.priorities[ix+1:], uq.priorities[ix:])
+		uq.priorities[ix] = &priorityRequests{priority: priority}
+	}
+
+	uq.priorities[ix].requests = append(uq.priorities[ix].requests, req)
+	uq.length++
+}
+
+// nextPriority returns the priority of the next request to dequeue. The queue must not be empty.
+func (uq *userQueue) nextPriority() int {
+	return uq.priorities[0].priority
+}
+
+// dequeue removes and returns the oldest request of the highest priority. The queue must not be empty.
+func (uq *userQueue) dequeue() (Request, int) {
+	pr := uq.priorities[0]
+	req := pr.requests[0]
+	pr.requests[0] = nil
+	pr.requests = pr.requests[1:]
+	uq.length--
+
+	if len(pr.requests) == 0 {
+		uq.priorities = uq.priorities[1:]
+	}
+	return req, pr.priority
 }
 
 func newUserQueues(maxUserQueueSize int) *queues {
 	return &queues{
-		userQueues:         map[string]*userQueue{},
-		users:              nil,
-		maxUserQueueSize:   maxUserQueueSize,
-		querierConnections: map[string]int{},
-		sortedQueriers:     nil,
+		userQueues:           map[string]*userQueue{},
+		users:                nil,
+		maxUserQueueSize:     maxUserQueueSize,
+		querierConnections:   map[string]int{},
+		sortedQueriers:       nil,
+		idleUserVirtualTimes: map[string]float64{},
+		estimatedCosts:       map[string]float64{},
 	}
 }
 
@@ -64,6 +127,10 @@ func (q *queues) deleteQueue(userID string) {
 	delete(q.userQueues, userID)
 	q.users[uq.index] = ""
 
+	if uq.virtualTime > q.virtualTime {
+		q.idleUserVirtualTimes[userID] = uq.virtualTime
+	}
+
 	// Shrink users list size if possible. This is safe, and no users will be skipped during iteration.
 	for ix := len(q.users) - 1; ix >= 0 && q.users[ix] == ""; ix-- {
 		q.users = q.users[:ix]
@@ -74,7 +141,7 @@ func (q *queues) deleteQueue(userID string) {
 // MaxQueriers is used to compute which queriers should handle requests for this user.
 // If maxQueriers is <= 0, all queriers can handle this user's requests.
 // If maxQueriers has changed since the last call, queriers for this are recomputed.
-func (q *queues) getOrAddQueue(userID string, maxQueriers int) chan Request {
+func (q *queues) getOrAddQueue(userID string, maxQueriers int) *userQueue {
 	// Empty user is not allowed, as that would break our users list ("" is used for free spot).
 	if userID == "" {
 		return nil
@@ -88,11 +155,12 @@ func (q *queues) getOrAddQueue(userID string, maxQueriers int) chan Request {
 
 	if uq == nil {
 		uq = &userQueue{
-			ch:    make(chan Request, q.maxUserQueueSize),
-			seed:  util.ShuffleShardSeed(userID, ""),
-			index: -1,
+			seed:        util.ShuffleShardSeed(userID, ""),
+			index:       -1,
+			virtualTime: math.Max(q.virtualTime, q.idleUserVirtualTimes[userID]),
 		}
 		q.userQueues[userID] = uq
+		delete(q.idleUserVirtualTimes, userID)
 
 		// Add user to the list of users... find first free spot, and put it there.
 		for ix, u := range q.users {
@@ -115,13 +183,13 @@ func (q *queues) getOrAddQueue(userID string, maxQueriers int) chan Request {
 		uq.queriers = shuffleQueriersForUser(uq.seed, maxQueriers, q.sortedQueriers, nil)
 	}
 
-	return uq.ch
+	return uq
 }
 
 // Finds next queue for the querier. To support fair scheduling between users, client is expected
 // to pass last user index returned by this function as argument. Is there was no previous
 // last user index, use -1.
-func (q *queues) getNextQueueForQuerier(lastUserIndex int, querier string) (chan Request, string, int) {
+func (q *queues) getNextQueueForQuerier(lastUserIndex int, querier string) (*userQueue, string, int) {
 	uid := lastUserIndex
 
 	for iters := 0; iters < len(q.users); iters++ {
@@ -147,11 +215,43 @@ func (q *queues) getNextQueueForQuerier(lastUserIndex int, querier string) (chan
 			}
 		}
 
-		return q.ch, u, uid
+		return q, u, uid
 	}
 	return nil, "", uid
 }
 
+// addCost advances the virtual time of the user by the given cost, whether the user has a queue or not.
+// A negative cost moves the virtual time back, but an idle user never gets behind the global virtual time.
+func (q *queues) addCost(userID string, cost float64) {
+	if uq := q.userQueues[userID]; uq != nil {
+		uq.virtualTime += cost
+		return
+	}
+
+	vt := math.Max(q.virtualTime, q.idleUserVirtualTimes[userID]) + cost
+	if vt > q.virtualTime {
+		q.idleUserVirtualTimes[userID] = vt
+	} else {
+		delete(q.idleUserVirtualTimes, userID)
+	}
+}
+
+// updateEstimatedCost updates the moving average of the cost of the requests of the user.
+func (q *queues) updateEstimatedCost(userID string, cost float64) {
+	estimated, ok := q.estimatedCosts[userID]
+	if !ok {
+		q.estimatedCosts[userID] = cost
+		return
+	}
+	q.estimatedCosts[userID] = estimated + estimatedCostSmoothing*(cost-estimated)
+}
+
+// deleteIdleUser forgets the virtual time of the user, if it has no queue, and the estimated cost of its requests.
+func (q *queues) deleteIdleUser(userID string) {
+	delete(q.idleUserVirtualTimes, userID)
+	delete(q.estimatedCosts, userID)
+}
+
 func (q *queues) addQuerierConnection(querier string) {
 	conns := q.querierConnections[querier]
 
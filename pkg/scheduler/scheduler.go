@@ -18,6 +18,7 @@ import (
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/middleware"
 	"github.com/weaveworks/common/user"
+	"go.uber.org/atomic"
 	"google.golang.org/grpc"
 
 	"github.com/cortexproject/cortex/pkg/frontend/v2/frontendv2pb"
@@ -55,9 +56,11 @@ type Scheduler struct {
 
 	// Metrics.
 	queueLength              *prometheus.GaugeVec
+	queueLengthPerPriority   *prometheus.GaugeVec
 	connectedQuerierClients  prometheus.GaugeFunc
 	connectedFrontendClients prometheus.GaugeFunc
 	queueDuration            prometheus.Histogram
+	querierSeconds           *prometheus.CounterVec
 }
 
 type requestKey struct {
@@ -77,6 +80,8 @@ type connectedFrontend struct {
 type Config struct {
 	MaxOutstandingPerTenant int `yaml:"max_outstanding_requests_per_tenant"`
 
+	ReservedQuerierWorkers map[int]float64 `yaml:"reserved_querier_workers_per_priority" doc:"nocli|description=Fraction of the connected querier workers reserved to the requests of each priority and above, keyed by priority. Requests of lower priority are not dequeued when the idle querier workers are not more than the capacity reserved to the higher priorities. The fractions must sum up to less than 1."`
+
 	GRPCClientConfig grpcclient.Config `yaml:"grpc_client_config" doc:"description=This configures the gRPC client used to report errors back to the query-frontend."`
 }
 
@@ -85,6 +90,10 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	cfg.GRPCClientConfig.RegisterFlagsWithPrefix("query-scheduler.grpc-client-config", f)
 }
 
+func (cfg *Config) Validate() error {
+	return queue.ValidateReservedQuerierWorkers(cfg.ReservedQuerierWorkers)
+}
+
 // NewScheduler creates a new Scheduler.
 func NewScheduler(cfg Config, limits Limits, log log.Logger, registerer prometheus.Registerer) (*Scheduler, error) {
 	s := &Scheduler{
@@ -100,13 +109,21 @@ func NewScheduler(cfg Config, limits Limits, log log.Logger, registerer promethe
 		Name: "cortex_query_scheduler_queue_length",
 		Help: "Number of queries in the queue.",
 	}, []string{"user"})
-	s.requestQueue = queue.NewRequestQueue(cfg.MaxOutstandingPerTenant, s.queueLength)
+	s.queueLengthPerPriority = promauto.With(registerer).NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cortex_query_scheduler_queue_length_per_priority",
+		Help: "Number of queries in the queue, per priority.",
+	}, []string{"priority"})
+	s.requestQueue = queue.NewRequestQueue(cfg.MaxOutstandingPerTenant, cfg.ReservedQuerierWorkers, s.queueLength, s.queueLengthPerPriority)
 
 	s.queueDuration = promauto.With(registerer).NewHistogram(prometheus.HistogramOpts{
 		Name:    "cortex_query_scheduler_queue_duration_seconds",
 		Help:    "Time spend by requests in queue before getting picked up by a querier.",
 		Buckets: prometheus.DefBuckets,
 	})
+	s.querierSeconds = promauto.With(registerer).NewCounterVec(prometheus.CounterOpts{
+		Name: "cortex_query_scheduler_querier_seconds_total",
+		Help: "Total querier time consumed by the requests, per tenant.",
+	}, []string{"user"})
 	s.connectedQuerierClients = promauto.With(registerer).NewGaugeFunc(prometheus.GaugeOpts{
 		Name: "cortex_query_scheduler_connected_querier_clients",
 		Help: "Number of querier worker clients currently connected to the query-scheduler.",
@@ -126,6 +143,9 @@ func NewScheduler(cfg Config, limits Limits, log log.Logger, registerer promethe
 type Limits interface {
 	// MaxQueriersPerUser returns max queriers to use per tenant, or 0 if shuffle sharding is disabled.
 	MaxQueriersPerUser(user string) int
+
+	// QuerySchedulerTenantWeight returns the weight of the tenant when sharing the querier time with the other tenants.
+	QuerySchedulerTenantWeight(user string) float64
 }
 
 type schedulerRequest struct {
@@ -135,6 +155,12 @@ type schedulerRequest struct {
 	request         *httpgrpc.HTTPRequest
 	statsEnabled    bool
 
+	// Weight of the tenant, used to account the querier time consumed by the request.
+	weight float64
+	// Estimated cost charged by the queue when the request is dequeued, corrected once the
+	// actual querier time consumed by the request is known.
+	chargedCost float64
+
 	enqueueTime time.Time
 
 	ctx       context.Context
@@ -145,6 +171,11 @@ type schedulerRequest struct {
 	parentSpanContext opentracing.SpanContext
 }
 
+// SetChargedCost implements queue.ChargedRequest.
+func (r *schedulerRequest) SetChargedCost(cost float64) {
+	r.chargedCost = cost
+}
+
 // FrontendLoop handles connection from frontend.
 func (s *Scheduler) FrontendLoop(frontend schedulerpb.SchedulerForFrontend_FrontendLoopServer) error {
 	frontendAddress, frontendCtx, err := s.frontendConnected(frontend)
@@ -288,9 +319,10 @@ func (s *Scheduler) enqueueRequest(frontendContext context.Context, frontendAddr
 		return err
 	}
 	maxQueriers := validation.SmallestPositiveNonZeroIntPerTenant(tenantIDs, s.limits.MaxQueriersPerUser)
+	req.weight = validation.SmallestPositiveNonZeroFloat64PerTenant(tenantIDs, s.limits.QuerySchedulerTenantWeight)
 
 	s.activeUsers.UpdateUserTimestamp(userID, now)
-	return s.requestQueue.EnqueueRequest(userID, req, maxQueriers, func() {
+	return s.requestQueue.EnqueueRequest(userID, req, int(msg.Priority), maxQueriers, func() {
 		shouldCancel = false
 
 		s.pendingRequestsMu.Lock()
@@ -360,8 +392,9 @@ func (s *Scheduler) QuerierLoop(querier schedulerpb.SchedulerForQuerier_QuerierL
 		*/
 
 		if r.ctx.Err() != nil {
-			// Remove from pending requests.
+			// Remove from pending requests, and refund the cost charged when dequeued.
 			s.cancelRequestAndRemoveFromPending(r.frontendAddress, r.queryID)
+			s.requestQueue.ReportRequestCost(r.userID, 0, r.weight, r.chargedCost)
 
 			lastUserIndex = lastUserIndex.ReuseLastUser()
 			continue
@@ -379,6 +412,19 @@ func (s *Scheduler) forwardRequestToQuerier(querier schedulerpb.SchedulerForQuer
 	// Make sure to cancel request at the end to cleanup resources.
 	defer s.cancelRequestAndRemoveFromPending(req.frontendAddress, req.queryID)
 
+	// Account the querier time consumed by the request, as reported by the querier when
+	// signaling that it is ready for the next request, or as measured otherwise.
+	start := time.Now()
+	var processingTime atomic.Duration
+	defer func() {
+		cost := processingTime.Load()
+		if cost <= 0 {
+			cost = time.Since(start)
+		}
+		s.querierSeconds.WithLabelValues(req.userID).Add(cost.Seconds())
+		s.requestQueue.ReportRequestCost(req.userID, cost, req.weight, req.chargedCost)
+	}()
+
 	// Handle the stream sending & receiving on a goroutine so we can
 	// monitoring the contexts in a select and cancel things appropriately.
 	errCh := make(chan error, 1)
@@ -395,7 +441,10 @@ func (s *Scheduler) forwardRequestToQuerier(querier schedulerpb.SchedulerForQuer
 			return
 		}
 
-		_, err = querier.Recv()
+		msg, err := querier.Recv()
+		if err == nil {
+			processingTime.Store(msg.ProcessingTime)
+		}
 		errCh <- err
 	}()
 
@@ -471,6 +520,8 @@ func (s *Scheduler) stopping(_ error) error {
 
 func (s *Scheduler) cleanupMetricsForInactiveUser(user string) {
 	s.queueLength.DeleteLabelValues(user)
+	s.querierSeconds.DeleteLabelValues(user)
+	s.requestQueue.CleanupInactiveUser(user)
 }
 
 func (s *Scheduler) getConnectedFrontendClientsMetric() float64 {
@@ -95,6 +95,37 @@ func TestSchedulerBasicEnqueue(t *testing.T) {
 	verifyNoPendingRequestsLeft(t, scheduler)
 }
 
+func TestSchedulerEnqueueWithPriority(t *testing.T) {
+	scheduler, frontendClient, querierClient := setupScheduler(t, nil)
+
+	frontendLoop := initFrontendLoop(t, frontendClient, "frontend-12345")
+	frontendToScheduler(t, frontendLoop, &schedulerpb.FrontendToScheduler{
+		Type:        schedulerpb.ENQUEUE,
+		QueryID:     1,
+		UserID:      "test",
+		HttpRequest: &httpgrpc.HTTPRequest{Method: "GET", Url: "/low"},
+	})
+	frontendToScheduler(t, frontendLoop, &schedulerpb.FrontendToScheduler{
+		Type:        schedulerpb.ENQUEUE,
+		QueryID:     2,
+		UserID:      "test",
+		HttpRequest: &httpgrpc.HTTPRequest{Method: "GET", Url: "/high"},
+		Priority:    10,
+	})
+
+	querierLoop := initQuerierLoop(t, querierClient, "querier-1")
+
+	// The higher priority request is received first, even if enqueued last.
+	for _, expectedQueryID := range []uint64{2, 1} {
+		msg, err := querierLoop.Recv()
+		require.NoError(t, err)
+		require.Equal(t, expectedQueryID, msg.QueryID)
+		require.NoError(t, querierLoop.Send(&schedulerpb.QuerierToScheduler{}))
+	}
+
+	verifyNoPendingRequestsLeft(t, scheduler)
+}
+
 func TestSchedulerEnqueueWithCancel(t *testing.T) {
 	scheduler, frontendClient, querierClient := setupScheduler(t, nil)
 
@@ -422,6 +453,7 @@ func TestSchedulerMetrics(t *testing.T) {
 		QueryID:     1,
 		UserID:      "another",
 		HttpRequest: &httpgrpc.HTTPRequest{Method: "GET", Url: "/hello"},
+		Priority:    5,
 	})
 
 	require.NoError(t, promtest.GatherAndCompare(reg, strings.NewReader(`
@@ -429,7 +461,12 @@ func TestSchedulerMetrics(t *testing.T) {
 		# TYPE cortex_query_scheduler_queue_length gauge
 		cortex_query_scheduler_queue_length{user="another"} 1
 		cortex_query_scheduler_queue_length{user="test"} 1
-	`), "cortex_query_scheduler_queue_length"))
+
+		# HELP cortex_query_scheduler_queue_length_per_priority Number of queries in the queue, per priority.
+		# TYPE cortex_query_scheduler_queue_length_per_priority gauge
+		cortex_query_scheduler_queue_length_per_priority{priority="0"} 1
+		cortex_query_scheduler_queue_length_per_priority{priority="5"} 1
+	`), "cortex_query_scheduler_queue_length", "cortex_query_scheduler_queue_length_per_priority"))
 
 	scheduler.cleanupMetricsForInactiveUser("test")
 
@@ -440,6 +477,41 @@ func TestSchedulerMetrics(t *testing.T) {
 	`), "cortex_query_scheduler_queue_length"))
 }
 
+func TestSchedulerAccountsQuerierTime(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+
+	scheduler, frontendClient, querierClient := setupScheduler(t, reg)
+
+	frontendLoop := initFrontendLoop(t, frontendClient, "frontend-12345")
+	frontendToScheduler(t, frontendLoop, &schedulerpb.FrontendToScheduler{
+		Type:        schedulerpb.ENQUEUE,
+		QueryID:     1,
+		UserID:      "test",
+		HttpRequest: &httpgrpc.HTTPRequest{Method: "GET", Url: "/hello"},
+	})
+
+	querierLoop := initQuerierLoop(t, querierClient, "querier-1")
+	msg, err := querierLoop.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), msg.QueryID)
+
+	// The querier reports the time spent processing the request when asking for the next one.
+	require.NoError(t, querierLoop.Send(&schedulerpb.QuerierToScheduler{ProcessingTime: 2 * time.Second}))
+	verifyNoPendingRequestsLeft(t, scheduler)
+
+	test.Poll(t, time.Second, nil, func() interface{} {
+		return promtest.GatherAndCompare(reg, strings.NewReader(`
+			# HELP cortex_query_scheduler_querier_seconds_total Total querier time consumed by the requests, per tenant.
+			# TYPE cortex_query_scheduler_querier_seconds_total counter
+			cortex_query_scheduler_querier_seconds_total{user="test"} 2
+		`), "cortex_query_scheduler_querier_seconds_total")
+	})
+
+	scheduler.cleanupMetricsForInactiveUser("test")
+
+	require.NoError(t, promtest.GatherAndCompare(reg, strings.NewReader(""), "cortex_query_scheduler_querier_seconds_total"))
+}
+
 func initFrontendLoop(t *testing.T, client schedulerpb.SchedulerForFrontendClient, frontendAddr string) schedulerpb.SchedulerForFrontend_FrontendLoopClient {
 	loop, err := client.FrontendLoop(context.Background())
 	require.NoError(t, err)
@@ -501,6 +573,10 @@ func (l limits) MaxQueriersPerUser(_ string) int {
 	return l.queriers
 }
 
+func (l limits) QuerySchedulerTenantWeight(_ string) float64 {
+	return 1
+}
+
 type frontendMock struct {
 	mu   sync.Mutex
 	resp map[uint64]*httpgrpc.HTTPResponse
@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	httpgrpc "github.com/weaveworks/common/httpgrpc"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -18,12 +20,14 @@ import (
 	reflect "reflect"
 	strconv "strconv"
 	strings "strings"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -83,9 +87,12 @@ func (SchedulerToFrontendStatus) EnumDescriptor() ([]byte, []int) {
 }
 
 // Querier reports its own clientID when it connects, so that scheduler knows how many *different* queriers are connected.
-// To signal that querier is ready to accept another request, querier sends empty message.
+// To signal that querier is ready to accept another request, querier sends a message without the querierID.
 type QuerierToScheduler struct {
 	QuerierID string `protobuf:"bytes,1,opt,name=querierID,proto3" json:"querierID,omitempty"`
+	// Time spent by the querier processing the previous request, reported when signaling that it is
+	// ready to accept another one. Used by the scheduler to account the querier time consumed by tenants.
+	ProcessingTime time.Duration `protobuf:"bytes,2,opt,name=processingTime,proto3,stdduration" json:"processingTime"`
 }
 
 func (m *QuerierToScheduler) Reset()      { *m = QuerierToScheduler{} }
@@ -127,6 +134,13 @@ func (m *QuerierToScheduler) GetQuerierID() string {
 	return ""
 }
 
+func (m *QuerierToScheduler) GetProcessingTime() time.Duration {
+	if m != nil {
+		return m.ProcessingTime
+	}
+	return 0
+}
+
 type SchedulerToQuerier struct {
 	// Query ID as reported by frontend. When querier sends the response back to frontend (using frontendAddress),
 	// it identifies the query by using this ID.
@@ -219,6 +233,8 @@ type FrontendToScheduler struct {
 	UserID       string                `protobuf:"bytes,4,opt,name=userID,proto3" json:"userID,omitempty"`
 	HttpRequest  *httpgrpc.HTTPRequest `protobuf:"bytes,5,opt,name=httpRequest,proto3" json:"httpRequest,omitempty"`
 	StatsEnabled bool                  `protobuf:"varint,6,opt,name=statsEnabled,proto3" json:"statsEnabled,omitempty"`
+	// Priority of the request in the tenant queue. Higher priority requests are dequeued first.
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
 }
 
 func (m *FrontendToScheduler) Reset()      { *m = FrontendToScheduler{} }
@@ -295,6 +311,13 @@ func (m *FrontendToScheduler) GetStatsEnabled() bool {
 	return false
 }
 
+func (m *FrontendToScheduler) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
 type SchedulerToFrontend struct {
 	Status SchedulerToFrontendStatus `protobuf:"varint,1,opt,name=status,proto3,enum=schedulerpb.SchedulerToFrontendStatus" json:"status,omitempty"`
 	Error  string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
@@ -358,45 +381,49 @@ func init() {
 func init() { proto.RegisterFile("scheduler.proto", fileDescriptor_2b3fc28395a6d9c5) }
 
 var fileDescriptor_2b3fc28395a6d9c5 = []byte{
-	// 598 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcf, 0x4f, 0x13, 0x41,
-	0x14, 0xc7, 0x77, 0x96, 0x76, 0x81, 0x57, 0x94, 0x75, 0x40, 0xad, 0x0d, 0x19, 0x36, 0x8d, 0x31,
-	0x0d, 0x89, 0xad, 0xa9, 0x26, 0x7a, 0x30, 0x26, 0x15, 0x16, 0x69, 0xc4, 0x2d, 0x4c, 0xa7, 0xf1,
-	0xc7, 0xa5, 0xa1, 0xed, 0x50, 0x08, 0xd0, 0x59, 0x66, 0x77, 0x25, 0xdc, 0x3c, 0x7a, 0xf4, 0xcf,
-	0xf0, 0x4f, 0xf1, 0x62, 0xc2, 0x91, 0xa3, 0x6c, 0x2f, 0x1e, 0xf9, 0x13, 0x4c, 0xa7, 0xdb, 0xba,
-	0xad, 0x6d, 0xf0, 0xf6, 0xde, 0xeb, 0xf7, 0xdb, 0xf7, 0xe6, 0xf3, 0x66, 0x07, 0x16, 0xbd, 0xe6,
-	0x01, 0x6f, 0x05, 0xc7, 0x5c, 0xe6, 0x5d, 0x29, 0x7c, 0x81, 0x53, 0xc3, 0x82, 0xdb, 0xc8, 0x3c,
-	0x6e, 0x1f, 0xfa, 0x07, 0x41, 0x23, 0xdf, 0x14, 0x27, 0x85, 0xb6, 0x68, 0x8b, 0x82, 0xd2, 0x34,
-	0x82, 0x7d, 0x95, 0xa9, 0x44, 0x45, 0x7d, 0x6f, 0xe6, 0x59, 0x4c, 0x7e, 0xc6, 0xf7, 0x3e, 0xf3,
-	0x33, 0x21, 0x8f, 0xbc, 0x42, 0x53, 0x9c, 0x9c, 0x88, 0x4e, 0xe1, 0xc0, 0xf7, 0xdd, 0xb6, 0x74,
-	0x9b, 0xc3, 0xa0, 0xef, 0xca, 0x16, 0x01, 0xef, 0x06, 0x5c, 0x1e, 0x72, 0xc9, 0x44, 0x75, 0xd0,
-	0x1c, 0xaf, 0xc0, 0xfc, 0x69, 0xbf, 0x5a, 0xde, 0x48, 0x23, 0x0b, 0xe5, 0xe6, 0xe9, 0xdf, 0x42,
-	0xf6, 0x27, 0x02, 0x3c, 0xd4, 0x32, 0x11, 0xf9, 0x71, 0x1a, 0x66, 0x7b, 0x9a, 0xf3, 0xc8, 0x92,
-	0xa0, 0x83, 0x14, 0x3f, 0x87, 0x54, 0xaf, 0x2d, 0xe5, 0xa7, 0x01, 0xf7, 0xfc, 0xb4, 0x6e, 0xa1,
-	0x5c, 0xaa, 0x78, 0x37, 0x3f, 0x1c, 0x65, 0x8b, 0xb1, 0x9d, 0xe8, 0x47, 0x1a, 0x57, 0xe2, 0x1c,
-	0x2c, 0xee, 0x4b, 0xd1, 0xf1, 0x79, 0xa7, 0x55, 0x6a, 0xb5, 0x24, 0xf7, 0xbc, 0xf4, 0x8c, 0x9a,
-	0x66, 0xbc, 0x8c, 0xef, 0x81, 0x11, 0x78, 0x6a, 0xdc, 0x84, 0x12, 0x44, 0x19, 0xce, 0xc2, 0x82,
-	0xe7, 0xef, 0xf9, 0x9e, 0xdd, 0xd9, 0x6b, 0x1c, 0xf3, 0x56, 0x3a, 0x69, 0xa1, 0xdc, 0x1c, 0x1d,
-	0xa9, 0x65, 0xbf, 0xea, 0xb0, 0xb4, 0x19, 0xfd, 0x5f, 0x9c, 0xc2, 0x0b, 0x48, 0xf8, 0xe7, 0x2e,
-	0x57, 0xa7, 0xb9, 0x5d, 0x7c, 0x98, 0x8f, 0x2d, 0x27, 0x3f, 0x41, 0xcf, 0xce, 0x5d, 0x4e, 0x95,
-	0x63, 0xd2, 0xdc, 0xfa, 0xe4, 0xb9, 0x63, 0xd0, 0x66, 0x46, 0xa1, 0x4d, 0x3b, 0xd1, 0x18, 0xcc,
-	0xe4, 0x7f, 0xc3, 0x1c, 0x47, 0x61, 0x4c, 0x40, 0x71, 0x04, 0x4b, 0xb1, 0xcd, 0x0e, 0x0e, 0x89,
-	0x5f, 0x81, 0xd1, 0x93, 0x05, 0x5e, 0xc4, 0xe2, 0xd1, 0x08, 0x8b, 0x09, 0x8e, 0xaa, 0x52, 0xd3,
-	0xc8, 0x85, 0x97, 0x21, 0xc9, 0xa5, 0x14, 0x32, 0xa2, 0xd0, 0x4f, 0xd6, 0x5e, 0xc2, 0xfd, 0x29,
-	0x18, 0xf1, 0x1c, 0x24, 0xca, 0x4e, 0x99, 0x99, 0x1a, 0x4e, 0xc1, 0xac, 0xed, 0xec, 0xd6, 0xec,
-	0x9a, 0x6d, 0x22, 0x0c, 0x60, 0xac, 0x97, 0x9c, 0x75, 0x7b, 0xdb, 0xd4, 0xd7, 0x9a, 0xf0, 0x60,
-	0x6a, 0x63, 0x6c, 0x80, 0x5e, 0x79, 0x6b, 0x6a, 0xd8, 0x82, 0x15, 0x56, 0xa9, 0xd4, 0xdf, 0x95,
-	0x9c, 0x8f, 0x75, 0x6a, 0xef, 0xd6, 0xec, 0x2a, 0xab, 0xd6, 0x77, 0x6c, 0x5a, 0x67, 0xb6, 0x53,
-	0x72, 0x98, 0x89, 0xf0, 0x3c, 0x24, 0x6d, 0x4a, 0x2b, 0xd4, 0xd4, 0xf1, 0x1d, 0xb8, 0x55, 0xdd,
-	0xaa, 0x31, 0x56, 0x76, 0xde, 0xd4, 0x37, 0x2a, 0xef, 0x1d, 0x73, 0xa6, 0x78, 0x1c, 0xe3, 0xb1,
-	0x29, 0xe4, 0xe0, 0xaa, 0xd7, 0x20, 0x15, 0x85, 0xdb, 0x42, 0xb8, 0x78, 0x75, 0x04, 0xc7, 0xbf,
-	0xdf, 0x53, 0x66, 0x75, 0x1a, 0xaf, 0x48, 0x9b, 0xd5, 0x72, 0xe8, 0x09, 0x2a, 0xba, 0xb0, 0x1c,
-	0xef, 0x36, 0xc4, 0xff, 0x01, 0x16, 0x06, 0xb1, 0xea, 0x67, 0xdd, 0x74, 0x15, 0x33, 0xd6, 0x4d,
-	0x0b, 0xea, 0x77, 0x7c, 0x5d, 0xba, 0xb8, 0x22, 0xda, 0xe5, 0x15, 0xd1, 0xae, 0xaf, 0x08, 0xfa,
-	0x12, 0x12, 0xf4, 0x3d, 0x24, 0xe8, 0x47, 0x48, 0xd0, 0x45, 0x48, 0xd0, 0xaf, 0x90, 0xa0, 0xdf,
-	0x21, 0xd1, 0xae, 0x43, 0x82, 0xbe, 0x75, 0x89, 0x76, 0xd1, 0x25, 0xda, 0x65, 0x97, 0x68, 0x9f,
-	0xe2, 0xcf, 0x54, 0xc3, 0x50, 0x0f, 0xc9, 0xd3, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x85, 0xa8,
-	0x0d, 0xe8, 0xcd, 0x04, 0x00, 0x00,
+	// 672 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x4d, 0x4f, 0x1a, 0x5d,
+	0x14, 0xc7, 0xe7, 0x22, 0x20, 0x1e, 0x7c, 0x94, 0xe7, 0x6a, 0x5b, 0x24, 0xe6, 0x42, 0x48, 0xd3,
+	0x10, 0x93, 0x0e, 0x0d, 0x6d, 0xd2, 0x2e, 0x9a, 0x26, 0xa8, 0x63, 0x25, 0xda, 0x41, 0x2f, 0x43,
+	0xfa, 0xb2, 0x21, 0xbc, 0x5c, 0xc7, 0x89, 0xc0, 0x1d, 0xef, 0xcc, 0xd4, 0xb0, 0x6a, 0x3f, 0x42,
+	0x97, 0xfd, 0x00, 0x5d, 0xf4, 0xa3, 0xb8, 0x69, 0xe2, 0xd2, 0x55, 0x5b, 0xc7, 0x4d, 0x97, 0x7e,
+	0x84, 0xc6, 0x61, 0xc0, 0x01, 0x21, 0x76, 0x77, 0xce, 0xb9, 0xe7, 0xdc, 0xff, 0x39, 0xbf, 0xfb,
+	0x02, 0x8b, 0x56, 0xf3, 0x90, 0xb5, 0x9c, 0x36, 0x13, 0xb2, 0x29, 0xb8, 0xcd, 0x71, 0x7c, 0x18,
+	0x30, 0x1b, 0xa9, 0xc7, 0xba, 0x61, 0x1f, 0x3a, 0x0d, 0xb9, 0xc9, 0x3b, 0x79, 0x9d, 0xeb, 0x3c,
+	0xef, 0xe5, 0x34, 0x9c, 0x03, 0xcf, 0xf3, 0x1c, 0xcf, 0xea, 0xd7, 0xa6, 0x9e, 0x05, 0xd2, 0x4f,
+	0x58, 0xfd, 0x23, 0x3b, 0xe1, 0xe2, 0xc8, 0xca, 0x37, 0x79, 0xa7, 0xc3, 0xbb, 0xf9, 0x43, 0xdb,
+	0x36, 0x75, 0x61, 0x36, 0x87, 0x86, 0x5f, 0x45, 0x74, 0xce, 0xf5, 0x36, 0xbb, 0xd9, 0xbb, 0xe5,
+	0x88, 0xba, 0x6d, 0xf0, 0x6e, 0x7f, 0x3d, 0xfb, 0x09, 0xf0, 0xbe, 0xc3, 0x84, 0xc1, 0x84, 0xc6,
+	0x2b, 0x83, 0xe6, 0xf0, 0x2a, 0xcc, 0x1d, 0xf7, 0xa3, 0xa5, 0xcd, 0x24, 0xca, 0xa0, 0xdc, 0x1c,
+	0xbd, 0x09, 0xe0, 0x1d, 0x58, 0x30, 0x05, 0x6f, 0x32, 0xcb, 0x32, 0xba, 0xba, 0x66, 0x74, 0x58,
+	0x32, 0x94, 0x41, 0xb9, 0x78, 0x61, 0x45, 0xee, 0x8b, 0xc9, 0x03, 0x31, 0x79, 0xd3, 0x17, 0x5b,
+	0x8f, 0x9d, 0xfe, 0x4c, 0x4b, 0x5f, 0x7f, 0xa5, 0x11, 0x1d, 0x2b, 0xcd, 0xfe, 0x40, 0x80, 0x87,
+	0xc2, 0x1a, 0xf7, 0x9b, 0xc1, 0x49, 0x98, 0xbd, 0x16, 0xec, 0xf9, 0xfa, 0x61, 0x3a, 0x70, 0xf1,
+	0x73, 0x88, 0x5f, 0xcf, 0x48, 0xd9, 0xb1, 0xc3, 0x2c, 0xdb, 0x97, 0xbe, 0x27, 0x0f, 0xe7, 0xde,
+	0xd6, 0xb4, 0x3d, 0x7f, 0x91, 0x06, 0x33, 0x71, 0x0e, 0x16, 0x0f, 0x04, 0xef, 0xda, 0xac, 0xdb,
+	0x2a, 0xb6, 0x5a, 0x82, 0x59, 0x56, 0x72, 0xc6, 0x1b, 0x6d, 0x3c, 0x8c, 0xef, 0x43, 0xd4, 0xb1,
+	0xbc, 0xd9, 0xc3, 0x5e, 0x82, 0xef, 0xe1, 0x2c, 0xcc, 0x5b, 0x76, 0xdd, 0xb6, 0x94, 0x6e, 0xbd,
+	0xd1, 0x66, 0xad, 0x64, 0x24, 0x83, 0x72, 0x31, 0x3a, 0x12, 0xcb, 0x7e, 0x0b, 0xc1, 0xd2, 0x96,
+	0xbf, 0x5f, 0x10, 0xe9, 0x0b, 0x08, 0xdb, 0x3d, 0x93, 0x79, 0xd3, 0x2c, 0x14, 0x1e, 0xca, 0x81,
+	0x9b, 0x20, 0x4f, 0xc8, 0xd7, 0x7a, 0x26, 0xa3, 0x5e, 0xc5, 0xa4, 0xbe, 0x43, 0x93, 0xfb, 0x0e,
+	0x40, 0x9b, 0x19, 0x85, 0x36, 0x6d, 0xa2, 0x31, 0x98, 0x91, 0x7f, 0x86, 0x39, 0x8e, 0x22, 0x7a,
+	0x1b, 0x05, 0x4e, 0x41, 0xcc, 0x14, 0x06, 0x17, 0x86, 0xdd, 0x4b, 0xce, 0x66, 0x50, 0x2e, 0x42,
+	0x87, 0x7e, 0xf6, 0x08, 0x96, 0x02, 0xa7, 0x3e, 0x00, 0x80, 0x5f, 0x41, 0xf4, 0x7a, 0x0b, 0xc7,
+	0xf2, 0x39, 0x3d, 0x1a, 0xe1, 0x34, 0xa1, 0xa2, 0xe2, 0x65, 0x53, 0xbf, 0x0a, 0x2f, 0x43, 0x84,
+	0x09, 0xc1, 0x85, 0x4f, 0xa8, 0xef, 0xac, 0xbd, 0x84, 0x07, 0x53, 0x10, 0xe3, 0x18, 0x84, 0x4b,
+	0x6a, 0x49, 0x4b, 0x48, 0x38, 0x0e, 0xb3, 0x8a, 0xba, 0x5f, 0x55, 0xaa, 0x4a, 0x02, 0x61, 0x80,
+	0xe8, 0x46, 0x51, 0xdd, 0x50, 0x76, 0x13, 0xa1, 0xb5, 0x26, 0xac, 0x4c, 0x15, 0xc6, 0x51, 0x08,
+	0x95, 0x77, 0x12, 0x12, 0xce, 0xc0, 0xaa, 0x56, 0x2e, 0xd7, 0xde, 0x14, 0xd5, 0xf7, 0x35, 0xaa,
+	0xec, 0x57, 0x95, 0x8a, 0x56, 0xa9, 0xed, 0x29, 0xb4, 0xa6, 0x29, 0x6a, 0x51, 0xd5, 0x12, 0x08,
+	0xcf, 0x41, 0x44, 0xa1, 0xb4, 0x4c, 0x13, 0x21, 0xfc, 0x3f, 0xfc, 0x57, 0xd9, 0xae, 0x6a, 0x5a,
+	0x49, 0x7d, 0x5d, 0xdb, 0x2c, 0xbf, 0x55, 0x13, 0x33, 0x85, 0x76, 0x80, 0xc7, 0x16, 0x17, 0x83,
+	0x67, 0x50, 0x85, 0xb8, 0x6f, 0xee, 0x72, 0x6e, 0xe2, 0xf4, 0x08, 0x8e, 0xdb, 0x0f, 0x37, 0x95,
+	0x9e, 0xc6, 0xcb, 0xcf, 0xcd, 0x4a, 0x39, 0xf4, 0x04, 0x15, 0x4c, 0x58, 0x0e, 0xaa, 0x0d, 0xf1,
+	0xbf, 0x83, 0xf9, 0x81, 0xed, 0xe9, 0x65, 0xee, 0xba, 0xa6, 0xa9, 0xcc, 0x5d, 0x07, 0xd4, 0x57,
+	0x5c, 0x2f, 0x9e, 0x5d, 0x10, 0xe9, 0xfc, 0x82, 0x48, 0x57, 0x17, 0x04, 0x7d, 0x76, 0x09, 0xfa,
+	0xee, 0x12, 0x74, 0xea, 0x12, 0x74, 0xe6, 0x12, 0xf4, 0xdb, 0x25, 0xe8, 0x8f, 0x4b, 0xa4, 0x2b,
+	0x97, 0xa0, 0x2f, 0x97, 0x44, 0x3a, 0xbb, 0x24, 0xd2, 0xf9, 0x25, 0x91, 0x3e, 0x04, 0xff, 0xcb,
+	0x46, 0xd4, 0xfb, 0x56, 0x9e, 0xfe, 0x1d, 0x00, 0xd5, 0x16, 0x7e, 0x8b, 0x56, 0x05, 0x00, 0x00,
 }
 
 func (x FrontendToSchedulerType) String() string {
@@ -435,6 +462,9 @@ func (this *QuerierToScheduler) Equal(that interface{}) bool {
 	if this.QuerierID != that1.QuerierID {
 		return false
 	}
+	if this.ProcessingTime != that1.ProcessingTime {
+		return false
+	}
 	return true
 }
 func (this *SchedulerToQuerier) Equal(that interface{}) bool {
@@ -510,6 +540,9 @@ func (this *FrontendToScheduler) Equal(that interface{}) bool {
 	if this.StatsEnabled != that1.StatsEnabled {
 		return false
 	}
+	if this.Priority != that1.Priority {
+		return false
+	}
 	return true
 }
 func (this *SchedulerToFrontend) Equal(that interface{}) bool {
@@ -543,9 +576,10 @@ func (this *QuerierToScheduler) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 5)
+	s := make([]string, 0, 6)
 	s = append(s, "&schedulerpb.QuerierToScheduler{")
 	s = append(s, "QuerierID: "+fmt.Sprintf("%#v", this.QuerierID)+",\n")
+	s = append(s, "ProcessingTime: "+fmt.Sprintf("%#v", this.ProcessingTime)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -569,7 +603,7 @@ func (this *FrontendToScheduler) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 10)
+	s := make([]string, 0, 11)
 	s = append(s, "&schedulerpb.FrontendToScheduler{")
 	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
 	s = append(s, "FrontendAddress: "+fmt.Sprintf("%#v", this.FrontendAddress)+",\n")
@@ -579,6 +613,7 @@ func (this *FrontendToScheduler) GoString() string {
 		s = append(s, "HttpRequest: "+fmt.Sprintf("%#v", this.HttpRequest)+",\n")
 	}
 	s = append(s, "StatsEnabled: "+fmt.Sprintf("%#v", this.StatsEnabled)+",\n")
+	s = append(s, "Priority: "+fmt.Sprintf("%#v", this.Priority)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -616,7 +651,7 @@ const _ = grpc.SupportPackageIsVersion4
 type SchedulerForQuerierClient interface {
 	// After calling this method, both Querier and Scheduler enter a loop, in which querier waits for
 	// "SchedulerToQuerier" messages containing HTTP requests and processes them. After processing the request,
-	// querier signals that it is ready to accept another one by sending empty QuerierToScheduler message.
+	// querier signals that it is ready to accept another one by sending QuerierToScheduler message.
 	//
 	// Long-running loop is used to detect broken connection between scheduler and querier. This is important
 	// for scheduler to keep a list of connected queriers up-to-date.
@@ -666,7 +701,7 @@ func (x *schedulerForQuerierQuerierLoopClient) Recv() (*SchedulerToQuerier, erro
 type SchedulerForQuerierServer interface {
 	// After calling this method, both Querier and Scheduler enter a loop, in which querier waits for
 	// "SchedulerToQuerier" messages containing HTTP requests and processes them. After processing the request,
-	// querier signals that it is ready to accept another one by sending empty QuerierToScheduler message.
+	// querier signals that it is ready to accept another one by sending QuerierToScheduler message.
 	//
 	// Long-running loop is used to detect broken connection between scheduler and querier. This is important
 	// for scheduler to keep a list of connected queriers up-to-date.
@@ -862,6 +897,14 @@ func (m *QuerierToScheduler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	n1, err1 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ProcessingTime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.ProcessingTime):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintScheduler(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x12
 	if len(m.QuerierID) > 0 {
 		i -= len(m.QuerierID)
 		copy(dAtA[i:], m.QuerierID)
@@ -956,6 +999,11 @@ func (m *FrontendToScheduler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Priority != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x38
+	}
 	if m.StatsEnabled {
 		i--
 		if m.StatsEnabled {
@@ -1061,6 +1109,8 @@ func (m *QuerierToScheduler) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovScheduler(uint64(l))
 	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ProcessingTime)
+	n += 1 + l + sovScheduler(uint64(l))
 	return n
 }
 
@@ -1118,6 +1168,9 @@ func (m *FrontendToScheduler) Size() (n int) {
 	if m.StatsEnabled {
 		n += 2
 	}
+	if m.Priority != 0 {
+		n += 1 + sovScheduler(uint64(m.Priority))
+	}
 	return n
 }
 
@@ -1149,6 +1202,7 @@ func (this *QuerierToScheduler) String() string {
 	}
 	s := strings.Join([]string{`&QuerierToScheduler{`,
 		`QuerierID:` + fmt.Sprintf("%v", this.QuerierID) + `,`,
+		`ProcessingTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ProcessingTime), "Duration", "types.Duration", 1), `&`, ``, 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1178,6 +1232,7 @@ func (this *FrontendToScheduler) String() string {
 		`UserID:` + fmt.Sprintf("%v", this.UserID) + `,`,
 		`HttpRequest:` + strings.Replace(fmt.Sprintf("%v", this.HttpRequest), "HTTPRequest", "httpgrpc.HTTPRequest", 1) + `,`,
 		`StatsEnabled:` + fmt.Sprintf("%v", this.StatsEnabled) + `,`,
+		`Priority:` + fmt.Sprintf("%v", this.Priority) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1262,6 +1317,39 @@ func (m *QuerierToScheduler) Unmarshal(dAtA []byte) error {
 			}
 			m.QuerierID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessingTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ProcessingTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipScheduler(dAtA[iNdEx:])
@@ -1665,6 +1753,25 @@ func (m *FrontendToScheduler) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.StatsEnabled = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipScheduler(dAtA[iNdEx:])
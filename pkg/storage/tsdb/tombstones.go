@@ -0,0 +1,225 @@
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/cortexproject/cortex/pkg/storage/bucket"
+	util_log "github.com/cortexproject/cortex/pkg/util/log"
+)
+
+// Relative to user-specific prefix.
+const TombstonesPath = "tombstones"
+
+// TombstonesCacheGenerationFilepath is the path, relative to the tenant's bucket location, of the
+// object storing the cache generation number of the tombstones deleted from the bucket.
+const TombstonesCacheGenerationFilepath = TombstonesPath + "/cache-generation.json"
+
+type TombstoneState string
+
+const (
+	// TombstonePending is the state of a tombstone whose series haven't been
+	// permanently deleted from the storage yet.
+	TombstonePending TombstoneState = "pending"
+
+	// TombstoneProcessed is the state of a tombstone whose series have been
+	// permanently deleted from the blocks by the compactor.
+	TombstoneProcessed TombstoneState = "processed"
+
+	// TombstoneCancelled is the state of a tombstone which has been cancelled
+	// before being processed.
+	TombstoneCancelled TombstoneState = "cancelled"
+)
+
+// Tombstone is a series deletion request for the blocks storage.
+type Tombstone struct {
+	RequestID string `json:"request_id"`
+
+	// Time range (milliseconds, both inclusive) of the samples to delete.
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+
+	// Unix timestamp (milliseconds) when the request was created.
+	RequestCreatedAt int64 `json:"request_created_at"`
+
+	// Unix timestamp (milliseconds) when the tombstone switched to the current state.
+	StateCreatedAt int64 `json:"state_created_at"`
+
+	Selectors []string       `json:"selectors"`
+	State     TombstoneState `json:"state"`
+
+	// Matchers parsed from the selectors. They're populated when the tombstone is read from the bucket.
+	Matchers [][]*labels.Matcher `json:"-"`
+}
+
+func NewTombstone(requestID string, requestCreatedAt, stateCreatedAt, startTime, endTime int64, selectors []string, state TombstoneState) *Tombstone {
+	return &Tombstone{
+		RequestID:        requestID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		RequestCreatedAt: requestCreatedAt,
+		StateCreatedAt:   stateCreatedAt,
+		Selectors:        selectors,
+		State:            state,
+	}
+}
+
+// ParseMatchers parses the tombstone selectors into matchers.
+func (t *Tombstone) ParseMatchers() error {
+	t.Matchers = make([][]*labels.Matcher, 0, len(t.Selectors))
+
+	for _, selector := range t.Selectors {
+		matchers, err := parser.ParseMetricSelector(selector)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse tombstone selector: %s", selector)
+		}
+		t.Matchers = append(t.Matchers, matchers)
+	}
+
+	return nil
+}
+
+// Overlaps returns whether the tombstone overlaps the input time range (milliseconds, both inclusive).
+func (t *Tombstone) Overlaps(minT, maxT int64) bool {
+	return t.StartTime <= maxT && minT <= t.EndTime
+}
+
+// TombstoneFilepath returns the path, relative to the tenant's bucket location, of a tombstone.
+func TombstoneFilepath(requestID string) string {
+	return path.Join(TombstonesPath, requestID+".json")
+}
+
+// Uploads the tombstone to the tenant location in the bucket, overwriting the existing one (if any).
+func WriteTombstone(ctx context.Context, bkt objstore.Bucket, userID string, cfgProvider bucket.TenantConfigProvider, tombstone *Tombstone) error {
+	bkt = bucket.NewUserBucketClient(userID, bkt, cfgProvider)
+
+	data, err := json.Marshal(tombstone)
+	if err != nil {
+		return errors.Wrap(err, "serialize tombstone")
+	}
+
+	return errors.Wrap(bkt.Upload(ctx, TombstoneFilepath(tombstone.RequestID), bytes.NewReader(data)), "upload tombstone")
+}
+
+// Deletes the tombstone with the given request ID from the tenant location in the bucket.
+func DeleteTombstone(ctx context.Context, bkt objstore.Bucket, userID string, cfgProvider bucket.TenantConfigProvider, requestID string) error {
+	bkt = bucket.NewUserBucketClient(userID, bkt, cfgProvider)
+
+	err := bkt.Delete(ctx, TombstoneFilepath(requestID))
+	if bkt.IsObjNotFoundErr(err) {
+		return nil
+	}
+
+	return errors.Wrap(err, "delete tombstone")
+}
+
+// Returns the tombstone with the given request ID, if it exists. If it doesn't exist, returns nil tombstone, and no error.
+func ReadTombstone(ctx context.Context, bkt objstore.BucketReader, userID, requestID string) (*Tombstone, error) {
+	tombstone, err := readTombstone(ctx, bkt, path.Join(userID, TombstoneFilepath(requestID)))
+	if bkt.IsObjNotFoundErr(errors.Cause(err)) {
+		return nil, nil
+	}
+
+	return tombstone, err
+}
+
+// Returns all the tombstones of the given user, whatever is their state.
+func ListTombstones(ctx context.Context, bkt objstore.BucketReader, userID string) ([]*Tombstone, error) {
+	var tombstones []*Tombstone
+
+	err := bkt.Iter(ctx, path.Join(userID, TombstonesPath)+"/", func(name string) error {
+		if !strings.HasSuffix(name, ".json") || name == path.Join(userID, TombstonesCacheGenerationFilepath) {
+			return nil
+		}
+
+		tombstone, err := readTombstone(ctx, bkt, name)
+		if err != nil {
+			return err
+		}
+
+		tombstones = append(tombstones, tombstone)
+		return nil
+	})
+
+	return tombstones, err
+}
+
+// TombstonesCacheGeneration is the cache generation number of the tombstones deleted from the
+// bucket, which is the most recent state change timestamp of the deleted tombstones. It's kept
+// so that the cache generation number doesn't go backwards when tombstones are deleted.
+type TombstonesCacheGeneration struct {
+	Generation int64 `json:"generation"`
+}
+
+// Uploads the cache generation number of the deleted tombstones to the tenant location in the bucket.
+func WriteTombstonesCacheGeneration(ctx context.Context, bkt objstore.Bucket, userID string, cfgProvider bucket.TenantConfigProvider, generation int64) error {
+	bkt = bucket.NewUserBucketClient(userID, bkt, cfgProvider)
+
+	data, err := json.Marshal(TombstonesCacheGeneration{Generation: generation})
+	if err != nil {
+		return errors.Wrap(err, "serialize tombstones cache generation")
+	}
+
+	return errors.Wrap(bkt.Upload(ctx, TombstonesCacheGenerationFilepath, bytes.NewReader(data)), "upload tombstones cache generation")
+}
+
+// Returns the cache generation number of the deleted tombstones, or 0 if no tombstone has been deleted.
+func ReadTombstonesCacheGeneration(ctx context.Context, bkt objstore.BucketReader, userID string) (int64, error) {
+	name := path.Join(userID, TombstonesCacheGenerationFilepath)
+
+	r, err := bkt.Get(ctx, name)
+	if bkt.IsObjNotFoundErr(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read tombstones cache generation object: %s", name)
+	}
+
+	gen := TombstonesCacheGeneration{}
+	err = json.NewDecoder(r).Decode(&gen)
+
+	// Close reader before dealing with decode error.
+	if closeErr := r.Close(); closeErr != nil {
+		level.Warn(util_log.Logger).Log("msg", "failed to close bucket reader", "err", closeErr)
+	}
+
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to decode tombstones cache generation object: %s", name)
+	}
+
+	return gen.Generation, nil
+}
+
+func readTombstone(ctx context.Context, bkt objstore.BucketReader, name string) (*Tombstone, error) {
+	r, err := bkt.Get(ctx, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read tombstone object: %s", name)
+	}
+
+	tombstone := &Tombstone{}
+	err = json.NewDecoder(r).Decode(tombstone)
+
+	// Close reader before dealing with decode error.
+	if closeErr := r.Close(); closeErr != nil {
+		level.Warn(util_log.Logger).Log("msg", "failed to close bucket reader", "err", closeErr)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode tombstone object: %s", name)
+	}
+
+	if err := tombstone.ParseMatchers(); err != nil {
+		return nil, err
+	}
+
+	return tombstone, nil
+}
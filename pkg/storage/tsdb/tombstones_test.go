@@ -0,0 +1,70 @@
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+func TestTombstones_WriteReadAndList(t *testing.T) {
+	const username = "user"
+
+	ctx := context.Background()
+	bkt := objstore.NewInMemBucket()
+
+	// Reading a non existing tombstone should return nil.
+	tombstone, err := ReadTombstone(ctx, bkt, username, "request-1")
+	require.NoError(t, err)
+	assert.Nil(t, tombstone)
+
+	first := NewTombstone("request-1", 10, 10, 0, 100, []string{`{job="a"}`, `up{job="b"}`}, TombstonePending)
+	second := NewTombstone("request-2", 20, 30, 50, 150, []string{`{job="c"}`}, TombstoneProcessed)
+	require.NoError(t, WriteTombstone(ctx, bkt, username, nil, first))
+	require.NoError(t, WriteTombstone(ctx, bkt, username, nil, second))
+
+	// Objects outside the tombstones location should be ignored.
+	require.NoError(t, bkt.Upload(ctx, "user/01EQK4QKFHVSZYVJ908Y7HH9E0/meta.json", bytes.NewReader([]byte("data"))))
+	require.NoError(t, bkt.Upload(ctx, "other/"+TombstoneFilepath("request-3"), bytes.NewReader([]byte("data"))))
+
+	tombstone, err = ReadTombstone(ctx, bkt, username, "request-1")
+	require.NoError(t, err)
+	assert.Equal(t, first.RequestID, tombstone.RequestID)
+	assert.Equal(t, first.Selectors, tombstone.Selectors)
+	assert.Equal(t, TombstonePending, tombstone.State)
+	assert.Equal(t, [][]*labels.Matcher{
+		{labels.MustNewMatcher(labels.MatchEqual, "job", "a")},
+		{labels.MustNewMatcher(labels.MatchEqual, "job", "b"), labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")},
+	}, tombstone.Matchers)
+
+	tombstones, err := ListTombstones(ctx, bkt, username)
+	require.NoError(t, err)
+	require.Len(t, tombstones, 2)
+	assert.Equal(t, "request-1", tombstones[0].RequestID)
+	assert.Equal(t, "request-2", tombstones[1].RequestID)
+	assert.Equal(t, TombstoneProcessed, tombstones[1].State)
+	assert.Equal(t, int64(30), tombstones[1].StateCreatedAt)
+
+	// Overwriting a tombstone should update it.
+	first.State = TombstoneCancelled
+	require.NoError(t, WriteTombstone(ctx, bkt, username, nil, first))
+
+	tombstone, err = ReadTombstone(ctx, bkt, username, "request-1")
+	require.NoError(t, err)
+	assert.Equal(t, TombstoneCancelled, tombstone.State)
+}
+
+func TestTombstone_Overlaps(t *testing.T) {
+	tombstone := NewTombstone("request", 0, 0, 10, 20, nil, TombstonePending)
+
+	assert.True(t, tombstone.Overlaps(0, 10))
+	assert.True(t, tombstone.Overlaps(15, 16))
+	assert.True(t, tombstone.Overlaps(20, 30))
+	assert.True(t, tombstone.Overlaps(0, 30))
+	assert.False(t, tombstone.Overlaps(0, 9))
+	assert.False(t, tombstone.Overlaps(21, 30))
+}
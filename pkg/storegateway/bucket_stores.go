@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
 	tsdb_errors "github.com/prometheus/prometheus/tsdb/errors"
 	"github.com/thanos-io/thanos/pkg/block"
 	thanos_metadata "github.com/thanos-io/thanos/pkg/block/metadata"
@@ -23,13 +24,16 @@ import (
 	"github.com/thanos-io/thanos/pkg/pool"
 	"github.com/thanos-io/thanos/pkg/store"
 	storecache "github.com/thanos-io/thanos/pkg/store/cache"
+	"github.com/thanos-io/thanos/pkg/store/labelpb"
 	"github.com/thanos-io/thanos/pkg/store/storepb"
 	"github.com/weaveworks/common/logging"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/cortexproject/cortex/pkg/chunk/purger"
 	"github.com/cortexproject/cortex/pkg/storage/bucket"
 	"github.com/cortexproject/cortex/pkg/storage/tsdb"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 	"github.com/cortexproject/cortex/pkg/util/spanlogger"
 	"github.com/cortexproject/cortex/pkg/util/validation"
 )
@@ -39,6 +43,7 @@ type BucketStores struct {
 	logger             log.Logger
 	cfg                tsdb.BlocksStorageConfig
 	limits             *validation.Overrides
+	tombstonesLoader   *purger.TombstonesLoader
 	bucket             objstore.Bucket
 	logLevel           logging.Level
 	bucketStoreMetrics *BucketStoreMetrics
@@ -66,7 +71,7 @@ type BucketStores struct {
 }
 
 // NewBucketStores makes a new BucketStores.
-func NewBucketStores(cfg tsdb.BlocksStorageConfig, shardingStrategy ShardingStrategy, bucketClient objstore.Bucket, limits *validation.Overrides, logLevel logging.Level, logger log.Logger, reg prometheus.Registerer) (*BucketStores, error) {
+func NewBucketStores(cfg tsdb.BlocksStorageConfig, shardingStrategy ShardingStrategy, bucketClient objstore.Bucket, limits *validation.Overrides, tombstonesLoader *purger.TombstonesLoader, logLevel logging.Level, logger log.Logger, reg prometheus.Registerer) (*BucketStores, error) {
 	cachingBucket, err := tsdb.CreateCachingBucket(cfg.BucketStore.ChunksCache, cfg.BucketStore.MetadataCache, bucketClient, logger, reg)
 	if err != nil {
 		return nil, errors.Wrapf(err, "create caching bucket")
@@ -84,6 +89,7 @@ func NewBucketStores(cfg tsdb.BlocksStorageConfig, shardingStrategy ShardingStra
 		logger:             logger,
 		cfg:                cfg,
 		limits:             limits,
+		tombstonesLoader:   tombstonesLoader,
 		bucket:             cachingBucket,
 		shardingStrategy:   shardingStrategy,
 		stores:             map[string]*store.BucketStore{},
@@ -251,10 +257,42 @@ func (u *BucketStores) Series(req *storepb.SeriesRequest, srv storepb.Store_Seri
 		return nil
 	}
 
-	return store.Series(req, spanSeriesServer{
+	var seriesSrv storepb.Store_SeriesServer = spanSeriesServer{
 		Store_SeriesServer: srv,
 		ctx:                spanCtx,
-	})
+	}
+
+	// Filter out the chunks deleted by the tenant's delete requests. Chunks only partially
+	// deleted are returned as is and filtered by the querier.
+	if u.tombstonesLoader != nil {
+		tombstones, err := u.tombstonesLoader.GetPendingTombstonesForInterval(userID, model.Time(req.MinTime), model.Time(req.MaxTime))
+		if err != nil {
+			return errors.Wrap(err, "failed to load tombstones")
+		}
+
+		if tombstones.Len() != 0 {
+			seriesSrv = tombstonesSeriesServer{
+				Store_SeriesServer: seriesSrv,
+				tombstones:         tombstones,
+			}
+		}
+	}
+
+	// Series don't have the shard label, so the shard matcher is replaced by filtering series by shard.
+	shard, matchers, err := removeShardFromMatchers(req.Matchers)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse shard matcher")
+	}
+
+	if shard != nil {
+		req.Matchers = matchers
+		seriesSrv = shardSeriesServer{
+			Store_SeriesServer: seriesSrv,
+			shard:              *shard,
+		}
+	}
+
+	return store.Series(req, seriesSrv)
 }
 
 // LabelNames implements the Storegateway proto service.
@@ -479,6 +517,79 @@ func (s spanSeriesServer) Context() context.Context {
 	return s.ctx
 }
 
+// tombstonesSeriesServer removes from the response the chunks fully covered by tombstones,
+// and the series which have no chunks left.
+type tombstonesSeriesServer struct {
+	storepb.Store_SeriesServer
+
+	tombstones *purger.TombstonesSet
+}
+
+func (s tombstonesSeriesServer) Send(resp *storepb.SeriesResponse) error {
+	series := resp.GetSeries()
+	if series == nil {
+		return s.Store_SeriesServer.Send(resp)
+	}
+
+	lbls := labelpb.ZLabelsToPromLabels(series.Labels)
+	chunks := series.Chunks[:0]
+
+	for _, chk := range series.Chunks {
+		chkInterval := model.Interval{Start: model.Time(chk.MinTime), End: model.Time(chk.MaxTime)}
+		deleted := s.tombstones.GetDeletedIntervals(lbls, chkInterval.Start, chkInterval.End)
+
+		if len(deleted) == 1 && deleted[0] == chkInterval {
+			continue
+		}
+
+		chunks = append(chunks, chk)
+	}
+
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	series.Chunks = chunks
+	return s.Store_SeriesServer.Send(resp)
+}
+
+// shardSeriesServer filters out the series not belonging to the shard.
+type shardSeriesServer struct {
+	storepb.Store_SeriesServer
+
+	shard sharding.ShardAnnotation
+}
+
+func (s shardSeriesServer) Send(resp *storepb.SeriesResponse) error {
+	series := resp.GetSeries()
+	if series == nil || s.shard.Matches(labelpb.ZLabelsToPromLabels(series.Labels)) {
+		return s.Store_SeriesServer.Send(resp)
+	}
+
+	return nil
+}
+
+// removeShardFromMatchers extracts the shard from the matchers, if any, and returns the remaining matchers.
+func removeShardFromMatchers(matchers []storepb.LabelMatcher) (*sharding.ShardAnnotation, []storepb.LabelMatcher, error) {
+	for i, matcher := range matchers {
+		if matcher.Name != sharding.ShardLabel || matcher.Type != storepb.LabelMatcher_EQ {
+			continue
+		}
+
+		shard, err := sharding.ParseShard(matcher.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		filtered := make([]storepb.LabelMatcher, 0, len(matchers)-1)
+		filtered = append(filtered, matchers[:i]...)
+		filtered = append(filtered, matchers[i+1:]...)
+		return &shard, filtered, nil
+	}
+
+	return nil, matchers, nil
+}
+
 func newChunksLimiterFactory(limits *validation.Overrides, userID string) store.ChunksLimiterFactory {
 	return func(failedCounter prometheus.Counter) store.ChunksLimiter {
 		// Since limit overrides could be live reloaded, we have to get the current user's limit
@@ -27,10 +27,12 @@ import (
 	"go.uber.org/atomic"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/cortexproject/cortex/pkg/chunk/purger"
 	"github.com/cortexproject/cortex/pkg/storage/bucket"
 	"github.com/cortexproject/cortex/pkg/storage/bucket/filesystem"
 	cortex_tsdb "github.com/cortexproject/cortex/pkg/storage/tsdb"
 	"github.com/cortexproject/cortex/pkg/util/flagext"
+	"github.com/cortexproject/cortex/pkg/util/sharding"
 )
 
 func TestBucketStores_InitialSync(t *testing.T) {
@@ -54,7 +56,7 @@ func TestBucketStores_InitialSync(t *testing.T) {
 	require.NoError(t, err)
 
 	reg := prometheus.NewPedanticRegistry()
-	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucket, defaultLimitsOverrides(t), mockLoggingLevel(), log.NewNopLogger(), reg)
+	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucket, defaultLimitsOverrides(t), nil, mockLoggingLevel(), log.NewNopLogger(), reg)
 	require.NoError(t, err)
 
 	// Query series before the initial sync.
@@ -130,7 +132,7 @@ func TestBucketStores_SyncBlocks(t *testing.T) {
 	require.NoError(t, err)
 
 	reg := prometheus.NewPedanticRegistry()
-	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucket, defaultLimitsOverrides(t), mockLoggingLevel(), log.NewNopLogger(), reg)
+	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucket, defaultLimitsOverrides(t), nil, mockLoggingLevel(), log.NewNopLogger(), reg)
 	require.NoError(t, err)
 
 	// Run an initial sync to discover 1 block.
@@ -184,6 +186,105 @@ func TestBucketStores_SyncBlocks(t *testing.T) {
 	assert.Greater(t, testutil.ToFloat64(stores.syncLastSuccess), float64(0))
 }
 
+func TestBucketStores_Series_ShouldFilterOutDeletedSeries(t *testing.T) {
+	const userID = "user-1"
+
+	ctx := context.Background()
+	cfg, cleanup := prepareStorageConfig(t)
+	defer cleanup()
+
+	storageDir, err := ioutil.TempDir(os.TempDir(), "storage-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(storageDir) //nolint:errcheck
+
+	cfg.Bucket.Backend = bucket.Filesystem
+	cfg.Bucket.Filesystem.Directory = storageDir
+
+	bucketClient, err := filesystem.NewBucketClient(filesystem.Config{Directory: storageDir})
+	require.NoError(t, err)
+
+	generateStorageBlock(t, storageDir, userID, "series_1", 10, 100, 15)
+	generateStorageBlock(t, storageDir, userID, "series_2", 10, 100, 15)
+
+	// Delete series_1 for a time range covering all its samples, and series_2 only partially.
+	deleteStore, err := purger.NewBlocksDeleteStore(cfg, nil, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, deleteStore.AddDeleteRequest(ctx, userID, 0, 200, []string{`{__name__="series_1"}`}))
+	require.NoError(t, deleteStore.AddDeleteRequest(ctx, userID, 0, 50, []string{`{__name__="series_2"}`}))
+
+	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucketClient, defaultLimitsOverrides(t), purger.NewTombstonesLoader(deleteStore, nil), mockLoggingLevel(), log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, stores.InitialSync(ctx))
+
+	seriesSet, warnings, err := querySeries(stores, userID, "series_1", 0, 200)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Empty(t, seriesSet)
+
+	// Partially deleted chunks are returned, because they're filtered by the querier.
+	seriesSet, warnings, err = querySeries(stores, userID, "series_2", 0, 200)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, seriesSet, 1)
+	assert.Equal(t, []labelpb.ZLabel{{Name: labels.MetricName, Value: "series_2"}}, seriesSet[0].Labels)
+}
+
+func TestBucketStores_Series_ShouldFilterSeriesByShard(t *testing.T) {
+	const (
+		userID    = "user-1"
+		numSeries = 10
+		numShards = 3
+	)
+
+	ctx := context.Background()
+	cfg, cleanup := prepareStorageConfig(t)
+	defer cleanup()
+
+	storageDir, err := ioutil.TempDir(os.TempDir(), "storage-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(storageDir) //nolint:errcheck
+
+	bucketClient, err := filesystem.NewBucketClient(filesystem.Config{Directory: storageDir})
+	require.NoError(t, err)
+
+	for n := 0; n < numSeries; n++ {
+		generateStorageBlock(t, storageDir, userID, fmt.Sprintf("series_%d", n), 10, 100, 15)
+	}
+
+	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucketClient, defaultLimitsOverrides(t), nil, mockLoggingLevel(), log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, stores.InitialSync(ctx))
+
+	// Query each shard and ensure each series is returned by exactly one shard.
+	seen := map[string]int{}
+
+	for shardIndex := 0; shardIndex < numShards; shardIndex++ {
+		shard := sharding.ShardAnnotation{Shard: shardIndex, Of: numShards}
+
+		srv := newBucketStoreSeriesServer(setUserIDToGRPCContext(ctx, userID))
+		require.NoError(t, stores.Series(&storepb.SeriesRequest{
+			MinTime: 0,
+			MaxTime: 200,
+			Matchers: []storepb.LabelMatcher{
+				{Type: storepb.LabelMatcher_RE, Name: labels.MetricName, Value: "series_.*"},
+				{Type: storepb.LabelMatcher_EQ, Name: sharding.ShardLabel, Value: shard.String()},
+			},
+			PartialResponseStrategy: storepb.PartialResponseStrategy_ABORT,
+		}, srv))
+
+		for _, series := range srv.SeriesSet {
+			lbls := labelpb.ZLabelsToPromLabels(series.Labels)
+			assert.True(t, shard.Matches(lbls))
+			seen[lbls.String()]++
+		}
+	}
+
+	require.Len(t, seen, numSeries)
+	for _, count := range seen {
+		assert.Equal(t, 1, count)
+	}
+}
+
 func TestBucketStores_syncUsersBlocks(t *testing.T) {
 	allUsers := []string{"user-1", "user-2", "user-3"}
 
@@ -214,7 +315,7 @@ func TestBucketStores_syncUsersBlocks(t *testing.T) {
 			bucketClient := &bucket.ClientMock{}
 			bucketClient.MockIter("", allUsers, nil)
 
-			stores, err := NewBucketStores(cfg, testData.shardingStrategy, bucketClient, defaultLimitsOverrides(t), mockLoggingLevel(), log.NewNopLogger(), nil)
+			stores, err := NewBucketStores(cfg, testData.shardingStrategy, bucketClient, defaultLimitsOverrides(t), nil, mockLoggingLevel(), log.NewNopLogger(), nil)
 			require.NoError(t, err)
 
 			// Sync user stores and count the number of times the callback is called.
@@ -261,7 +362,7 @@ func testBucketStoresSeriesShouldCorrectlyQuerySeriesSpanningMultipleChunks(t *t
 	require.NoError(t, err)
 
 	reg := prometheus.NewPedanticRegistry()
-	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucket, defaultLimitsOverrides(t), mockLoggingLevel(), log.NewNopLogger(), reg)
+	stores, err := NewBucketStores(cfg, NewNoShardingStrategy(), bucket, defaultLimitsOverrides(t), nil, mockLoggingLevel(), log.NewNopLogger(), reg)
 	require.NoError(t, err)
 	require.NoError(t, stores.InitialSync(ctx))
 
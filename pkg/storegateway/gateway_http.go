@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kit/kit/log/level"
 
+	"github.com/cortexproject/cortex/pkg/ring"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 	"github.com/cortexproject/cortex/pkg/util/services"
 )
@@ -51,3 +52,13 @@ func (c *StoreGateway) RingHandler(w http.ResponseWriter, req *http.Request) {
 
 	c.ring.ServeHTTP(w, req)
 }
+
+// Ring returns the store-gateway ring, or nil if sharding is disabled or the store gateway
+// is not running yet.
+func (c *StoreGateway) Ring() *ring.Ring {
+	if !c.gatewayCfg.ShardingEnabled || c.State() != services.Running {
+		return nil
+	}
+
+	return c.ring
+}
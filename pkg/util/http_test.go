@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/util"
 )
@@ -99,6 +100,8 @@ func TestParseProtoReader(t *testing.T) {
 							{Value: 20, TimestampMs: 2},
 							{Value: 30, TimestampMs: 3},
 						},
+						// Timeseries from the pool are unmarshalled with a preallocated exemplars slice.
+						Exemplars: []cortexpb.Exemplar{},
 					},
 				},
 			},
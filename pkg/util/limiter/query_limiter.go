@@ -0,0 +1,112 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"go.uber.org/atomic"
+
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+type queryLimiterCtxKey struct{}
+
+type chunkBytesBudgetCtxKey struct{}
+
+var (
+	ctxKey          = &queryLimiterCtxKey{}
+	budgetCtxKey    = &chunkBytesBudgetCtxKey{}
+	errMaxSeriesHit = "the query hit the max number of series limit while fetching series (limit: %d series)"
+
+	// ErrMaxChunkBytesHit is the error format used when the chunk bytes limit is exceeded.
+	ErrMaxChunkBytesHit = "the query hit the aggregated chunks size limit while fetching chunks (limit: %d bytes)"
+)
+
+// QueryLimiter tracks the series and the chunk bytes fetched by a single query, across
+// all ingesters and store-gateways, and fails once any of the per-query limits is exceeded.
+// The limiter lives in the querier. When a query is split or sharded by the query-frontend,
+// each sub-query gets its own series limit while the chunk bytes limit is shared by all the
+// sub-queries: each sub-query can fetch up to the chunk bytes left to the query when it's
+// sent to the querier, see AddChunkBytesBudgetToContext.
+type QueryLimiter struct {
+	uniqueSeriesMx sync.Mutex
+	uniqueSeries   map[model.Fingerprint]struct{}
+
+	chunkBytesCount atomic.Int64
+
+	maxSeriesPerQuery     int
+	maxChunkBytesPerQuery int
+}
+
+// NewQueryLimiter makes a new per-query limiter. Each limit is disabled if set to 0.
+func NewQueryLimiter(maxSeriesPerQuery, maxChunkBytesPerQuery int) *QueryLimiter {
+	return &QueryLimiter{
+		uniqueSeries:          map[model.Fingerprint]struct{}{},
+		maxSeriesPerQuery:     maxSeriesPerQuery,
+		maxChunkBytesPerQuery: maxChunkBytesPerQuery,
+	}
+}
+
+// AddQueryLimiterToContext returns a context with the input QueryLimiter.
+func AddQueryLimiterToContext(ctx context.Context, limiter *QueryLimiter) context.Context {
+	return context.WithValue(ctx, ctxKey, limiter)
+}
+
+// QueryLimiterFromContextWithFallback returns the QueryLimiter from the context, or
+// a limiter with all limits disabled if the context has no limiter.
+func QueryLimiterFromContextWithFallback(ctx context.Context) *QueryLimiter {
+	ql, ok := ctx.Value(ctxKey).(*QueryLimiter)
+	if !ok {
+		return NewQueryLimiter(0, 0)
+	}
+	return ql
+}
+
+// AddChunkBytesBudgetToContext returns a context with the chunk bytes the query is allowed
+// to fetch, lowering the chunk bytes limit of the QueryLimiter used for the query.
+func AddChunkBytesBudgetToContext(ctx context.Context, budget int) context.Context {
+	return context.WithValue(ctx, budgetCtxKey, budget)
+}
+
+// ChunkBytesBudgetFromContext returns the chunk bytes budget from the context, if any.
+func ChunkBytesBudgetFromContext(ctx context.Context) (int, bool) {
+	budget, ok := ctx.Value(budgetCtxKey).(int)
+	return budget, ok
+}
+
+// AddSeries adds the input series to the set of fetched series and returns an error
+// if the max series limit is exceeded. The same series is counted only once.
+func (ql *QueryLimiter) AddSeries(seriesLabels labels.Labels) error {
+	// Disabled.
+	if ql.maxSeriesPerQuery == 0 {
+		return nil
+	}
+
+	fingerprint := seriesLabels.Hash()
+
+	ql.uniqueSeriesMx.Lock()
+	defer ql.uniqueSeriesMx.Unlock()
+
+	ql.uniqueSeries[model.Fingerprint(fingerprint)] = struct{}{}
+	if len(ql.uniqueSeries) > ql.maxSeriesPerQuery {
+		return validation.LimitError(fmt.Sprintf(errMaxSeriesHit, ql.maxSeriesPerQuery))
+	}
+	return nil
+}
+
+// AddChunkBytes adds the input chunk bytes to the fetched ones and returns an error
+// if the max chunk bytes limit is exceeded.
+func (ql *QueryLimiter) AddChunkBytes(chunkSizeInBytes int) error {
+	// Disabled.
+	if ql.maxChunkBytesPerQuery == 0 {
+		return nil
+	}
+
+	if ql.chunkBytesCount.Add(int64(chunkSizeInBytes)) > int64(ql.maxChunkBytesPerQuery) {
+		return validation.LimitError(fmt.Sprintf(ErrMaxChunkBytesHit, ql.maxChunkBytesPerQuery))
+	}
+	return nil
+}
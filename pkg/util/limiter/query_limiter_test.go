@@ -0,0 +1,61 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexproject/cortex/pkg/util/validation"
+)
+
+func TestQueryLimiter_AddSeries_ShouldReturnErrorOnLimitExceeded(t *testing.T) {
+	first := labels.FromStrings(labels.MetricName, "test", "series", "1")
+	second := labels.FromStrings(labels.MetricName, "test", "series", "2")
+
+	limiter := NewQueryLimiter(1, 0)
+	require.NoError(t, limiter.AddSeries(first))
+
+	// The same series is counted only once.
+	require.NoError(t, limiter.AddSeries(first))
+
+	err := limiter.AddSeries(second)
+	assert.Equal(t, validation.LimitError(fmt.Sprintf(errMaxSeriesHit, 1)), err)
+}
+
+func TestQueryLimiter_AddChunkBytes_ShouldReturnErrorOnLimitExceeded(t *testing.T) {
+	limiter := NewQueryLimiter(0, 100)
+	require.NoError(t, limiter.AddChunkBytes(60))
+	require.NoError(t, limiter.AddChunkBytes(40))
+
+	err := limiter.AddChunkBytes(1)
+	assert.Equal(t, validation.LimitError(fmt.Sprintf(ErrMaxChunkBytesHit, 100)), err)
+}
+
+func TestQueryLimiter_ShouldBeDisabledWhenLimitsAreZero(t *testing.T) {
+	limiter := QueryLimiterFromContextWithFallback(context.Background())
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, limiter.AddSeries(labels.FromStrings(labels.MetricName, "test", "series", fmt.Sprint(i))))
+		require.NoError(t, limiter.AddChunkBytes(1024))
+	}
+}
+
+func TestQueryLimiterFromContextWithFallback(t *testing.T) {
+	limiter := NewQueryLimiter(10, 100)
+	ctx := AddQueryLimiterToContext(context.Background(), limiter)
+
+	assert.Same(t, limiter, QueryLimiterFromContextWithFallback(ctx))
+}
+
+func TestChunkBytesBudgetFromContext(t *testing.T) {
+	_, ok := ChunkBytesBudgetFromContext(context.Background())
+	assert.False(t, ok)
+
+	budget, ok := ChunkBytesBudgetFromContext(AddChunkBytesBudgetToContext(context.Background(), 100))
+	assert.True(t, ok)
+	assert.Equal(t, 100, budget)
+}
@@ -85,7 +85,7 @@ func (w *moduleService) stop(_ error) error {
 		err = w.service.FailureCase()
 	}
 
-	if err != nil && err != ErrStopProcess {
+	if err != nil && !errors.Is(err, ErrStopProcess) {
 		level.Warn(util_log.Logger).Log("msg", "module failed with error", "module", w.name, "err", err)
 	} else {
 		level.Info(util_log.Logger).Log("msg", "module stopped", "module", w.name)
@@ -0,0 +1,441 @@
+package push
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/middleware"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/cortexproject/cortex/pkg/util"
+	"github.com/cortexproject/cortex/pkg/util/log"
+	"github.com/cortexproject/cortex/pkg/util/push/otlppb"
+)
+
+const (
+	otlpProtobufContentType = "application/x-protobuf"
+
+	// Resource attributes defined by the OpenTelemetry semantic conventions, used
+	// to set the job and instance labels.
+	otlpServiceNameAttr       = "service.name"
+	otlpServiceNamespaceAttr  = "service.namespace"
+	otlpServiceInstanceIDAttr = "service.instance.id"
+)
+
+// OTLPHandler is a http.Handler which accepts OTLP/HTTP metrics export requests, encoded
+// in protobuf and optionally gzip compressed. Metrics are translated into series, adding
+// the data point attributes, the job and instance labels and the given resource attributes
+// as labels, and pushed as a single WriteRequest.
+func OTLPHandler(maxRecvMsgSize int, promoteResourceAttributes []string, sourceIPs *middleware.SourceIPExtractor, push Func) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := log.WithContext(ctx, log.Logger)
+		if sourceIPs != nil {
+			source := sourceIPs.Get(r)
+			if source != "" {
+				ctx = util.AddSourceIPsToOutgoingContext(ctx, source)
+				logger = log.WithSourceIPs(source, logger)
+			}
+		}
+
+		if contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType != otlpProtobufContentType {
+			http.Error(w, fmt.Sprintf("unsupported content type %q, only %q is supported", contentType, otlpProtobufContentType), http.StatusUnsupportedMediaType)
+			return
+		}
+
+		body, expectedSize := r.Body, int(r.ContentLength)
+		switch encoding := r.Header.Get("Content-Encoding"); encoding {
+		case "":
+		case "gzip":
+			gzipReader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				level.Error(logger).Log("err", err.Error())
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			defer gzipReader.Close()
+
+			// The size of the decompressed body is unknown.
+			body, expectedSize = gzipReader, -1
+		default:
+			http.Error(w, fmt.Sprintf("unsupported content encoding %q", encoding), http.StatusUnsupportedMediaType)
+			return
+		}
+
+		var exportReq otlppb.ExportMetricsServiceRequest
+		if err := util.ParseProtoReader(ctx, body, expectedSize, maxRecvMsgSize, &exportReq, util.NoCompression); err != nil {
+			level.Error(logger).Log("err", err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req, rejected := otlpToWriteRequest(&exportReq, promoteResourceAttributes)
+		if _, err := push(ctx, req); err != nil {
+			resp, ok := httpgrpc.HTTPResponseFromError(err)
+			if !ok {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if resp.GetCode() != 202 {
+				level.Error(logger).Log("msg", "push error", "err", err)
+			}
+			http.Error(w, string(resp.Body), int(resp.Code))
+			return
+		}
+
+		exportResp := &otlppb.ExportMetricsServiceResponse{}
+		if rejected.count > 0 {
+			exportResp.PartialSuccess = &otlppb.ExportMetricsPartialSuccess{
+				RejectedDataPoints: int64(rejected.count),
+				ErrorMessage:       rejected.String(),
+			}
+		}
+
+		w.Header().Set("Content-Type", otlpProtobufContentType)
+		if err := util.SerializeProtoResponse(w, exportResp, util.NoCompression); err != nil {
+			level.Error(logger).Log("msg", "error sending OTLP response", "err", err)
+		}
+	})
+}
+
+// otlpRejected tracks the data points which can't be translated into series.
+type otlpRejected struct {
+	count int
+	first string
+}
+
+func (r *otlpRejected) add(count int, format string, args ...interface{}) {
+	if count <= 0 {
+		return
+	}
+	if r.count == 0 {
+		r.first = fmt.Sprintf(format, args...)
+	}
+	r.count += count
+}
+
+func (r *otlpRejected) String() string {
+	if r.count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d data points rejected, first error: %s", r.count, r.first)
+}
+
+// otlpToWriteRequest translates the OTLP metrics into a WriteRequest. Gauges and cumulative
+// sums are translated into a series per data point, while cumulative histograms into the
+// _bucket, _sum and _count series, like Prometheus histograms. Data points with delta
+// temporality can't be translated into Prometheus metrics, and are rejected like
+// unsupported metric types.
+//
+// The series are taken from the pool, so ReuseSlice() should be called when done.
+func otlpToWriteRequest(req *otlppb.ExportMetricsServiceRequest, promoteResourceAttributes []string) (*cortexpb.WriteRequest, otlpRejected) {
+	var (
+		rejected otlpRejected
+		lbls     []labels.Labels
+		samples  []cortexpb.Sample
+		metadata []*cortexpb.MetricMetadata
+	)
+
+	addSeries := func(ls labels.Labels, ts int64, v float64) {
+		lbls = append(lbls, ls)
+		samples = append(samples, cortexpb.Sample{TimestampMs: ts, Value: v})
+	}
+
+	for _, rm := range req.ResourceMetrics {
+		if rm == nil {
+			continue
+		}
+		resourceLabels := otlpResourceLabels(rm.Resource, promoteResourceAttributes)
+
+		for _, ilm := range rm.InstrumentationLibraryMetrics {
+			if ilm == nil {
+				continue
+			}
+
+			for _, metric := range ilm.Metrics {
+				if metric == nil {
+					continue
+				}
+				name := sanitizeMetricName(metric.Name)
+
+				switch data := metric.Data.(type) {
+				case *otlppb.Metric_Gauge:
+					for _, dp := range data.Gauge.DataPoints {
+						addSeries(otlpLabels(name, dp.Attributes, resourceLabels), otlpTimestamp(dp.TimeUnixNano), otlpNumberValue(dp))
+					}
+					metadata = append(metadata, otlpMetadata(name, metric, cortexpb.GAUGE))
+
+				case *otlppb.Metric_Sum:
+					if data.Sum.AggregationTemporality != otlppb.AGGREGATION_TEMPORALITY_CUMULATIVE {
+						rejected.add(len(data.Sum.DataPoints), "unsupported %s temporality for sum metric %q", data.Sum.AggregationTemporality, metric.Name)
+						continue
+					}
+					for _, dp := range data.Sum.DataPoints {
+						addSeries(otlpLabels(name, dp.Attributes, resourceLabels), otlpTimestamp(dp.TimeUnixNano), otlpNumberValue(dp))
+					}
+
+					// Non-monotonic sums can go up and down, so they're exposed as gauges.
+					metricType := cortexpb.GAUGE
+					if data.Sum.IsMonotonic {
+						metricType = cortexpb.COUNTER
+					}
+					metadata = append(metadata, otlpMetadata(name, metric, metricType))
+
+				case *otlppb.Metric_Histogram:
+					if data.Histogram.AggregationTemporality != otlppb.AGGREGATION_TEMPORALITY_CUMULATIVE {
+						rejected.add(len(data.Histogram.DataPoints), "unsupported %s temporality for histogram metric %q", data.Histogram.AggregationTemporality, metric.Name)
+						continue
+					}
+					for _, dp := range data.Histogram.DataPoints {
+						if len(dp.BucketCounts) > 0 && len(dp.BucketCounts) != len(dp.ExplicitBounds)+1 {
+							rejected.add(1, "histogram metric %q has %d bucket counts and %d explicit bounds", metric.Name, len(dp.BucketCounts), len(dp.ExplicitBounds))
+							continue
+						}
+
+						ts := otlpTimestamp(dp.TimeUnixNano)
+						noValue := dp.Flags&uint32(otlppb.FLAG_NO_RECORDED_VALUE) != 0
+						sampleValue := func(v float64) float64 {
+							if noValue {
+								return math.Float64frombits(value.StaleNaN)
+							}
+							return v
+						}
+
+						addSeries(otlpLabels(name+"_sum", dp.Attributes, resourceLabels), ts, sampleValue(dp.Sum))
+						addSeries(otlpLabels(name+"_count", dp.Attributes, resourceLabels), ts, sampleValue(float64(dp.Count)))
+
+						// OTLP bucket counts are not cumulative, while Prometheus ones are.
+						cumulative := uint64(0)
+						for i := 0; i < len(dp.BucketCounts)-1; i++ {
+							bound := dp.ExplicitBounds[i]
+							cumulative += dp.BucketCounts[i]
+							bucketLabels := otlpLabels(name+"_bucket", dp.Attributes, resourceLabels, labels.Label{Name: model.BucketLabel, Value: formatFloat(bound)})
+							addSeries(bucketLabels, ts, sampleValue(float64(cumulative)))
+						}
+						infLabels := otlpLabels(name+"_bucket", dp.Attributes, resourceLabels, labels.Label{Name: model.BucketLabel, Value: "+Inf"})
+						addSeries(infLabels, ts, sampleValue(float64(dp.Count)))
+					}
+					metadata = append(metadata, otlpMetadata(name, metric, cortexpb.HISTOGRAM))
+
+				default:
+					rejected.add(1, "unsupported data type for metric %q", metric.Name)
+				}
+			}
+		}
+	}
+
+	return cortexpb.ToWriteRequest(lbls, samples, metadata, cortexpb.API), rejected
+}
+
+// otlpResourceLabels returns the labels to add to all the series of a resource: the job
+// and instance labels, like Prometheus does when scraping, and the promoted attributes.
+func otlpResourceLabels(resource *otlppb.Resource, promoteResourceAttributes []string) labels.Labels {
+	if resource == nil {
+		return nil
+	}
+
+	attrs := make(map[string]string, len(resource.Attributes))
+	for _, attr := range resource.Attributes {
+		if attr != nil {
+			attrs[attr.Key] = otlpAttributeValue(attr.Value)
+		}
+	}
+
+	b := labels.NewBuilder(nil)
+	for _, name := range promoteResourceAttributes {
+		if v, ok := attrs[name]; ok {
+			b.Set(sanitizeLabelName(name), v)
+		}
+	}
+	if serviceName, ok := attrs[otlpServiceNameAttr]; ok {
+		if namespace, ok := attrs[otlpServiceNamespaceAttr]; ok && namespace != "" {
+			serviceName = namespace + "/" + serviceName
+		}
+		b.Set(model.JobLabel, serviceName)
+	}
+	if instance, ok := attrs[otlpServiceInstanceIDAttr]; ok {
+		b.Set(model.InstanceLabel, instance)
+	}
+	return b.Labels()
+}
+
+// otlpLabels returns the labels of a series. The data point attributes take precedence
+// over the resource labels, and attributes whose names collide once sanitized are joined.
+func otlpLabels(name string, attributes []*otlppb.KeyValue, resourceLabels labels.Labels, extra ...labels.Label) labels.Labels {
+	values := make(map[string]string, len(attributes)+len(resourceLabels)+len(extra)+1)
+
+	// Sort the attributes by key, so that the joined values of colliding names are stable.
+	sorted := make([]*otlppb.KeyValue, 0, len(attributes))
+	for _, attr := range attributes {
+		if attr != nil {
+			sorted = append(sorted, attr)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	for _, attr := range sorted {
+		labelName := sanitizeLabelName(attr.Key)
+		if existing, ok := values[labelName]; ok {
+			values[labelName] = existing + ";" + otlpAttributeValue(attr.Value)
+		} else {
+			values[labelName] = otlpAttributeValue(attr.Value)
+		}
+	}
+	for _, l := range resourceLabels {
+		if _, ok := values[l.Name]; !ok {
+			values[l.Name] = l.Value
+		}
+	}
+	for _, l := range extra {
+		values[l.Name] = l.Value
+	}
+	values[model.MetricNameLabel] = name
+
+	ls := make(labels.Labels, 0, len(values))
+	for n, v := range values {
+		// Empty label values are equivalent to missing labels in Prometheus.
+		if v != "" {
+			ls = append(ls, labels.Label{Name: n, Value: v})
+		}
+	}
+	sort.Sort(ls)
+	return ls
+}
+
+func otlpMetadata(name string, metric *otlppb.Metric, metricType cortexpb.MetricMetadata_MetricType) *cortexpb.MetricMetadata {
+	return &cortexpb.MetricMetadata{
+		Type:             metricType,
+		MetricFamilyName: name,
+		Help:             metric.Description,
+		Unit:             metric.Unit,
+	}
+}
+
+func otlpNumberValue(dp *otlppb.NumberDataPoint) float64 {
+	if dp.Flags&uint32(otlppb.FLAG_NO_RECORDED_VALUE) != 0 {
+		return math.Float64frombits(value.StaleNaN)
+	}
+
+	switch v := dp.Value.(type) {
+	case *otlppb.NumberDataPoint_AsInt:
+		return float64(v.AsInt)
+	case *otlppb.NumberDataPoint_AsDouble:
+		return v.AsDouble
+	}
+	return 0
+}
+
+// otlpTimestamp converts a timestamp in nanoseconds into milliseconds.
+func otlpTimestamp(unixNano uint64) int64 {
+	return int64(unixNano / 1e6)
+}
+
+// otlpAttributeValue returns the string representation of an attribute value. Arrays
+// and key-value lists are JSON encoded.
+func otlpAttributeValue(v *otlppb.AnyValue) string {
+	if v == nil {
+		return ""
+	}
+
+	switch val := v.Value.(type) {
+	case *otlppb.AnyValue_StringValue:
+		return val.StringValue
+	case *otlppb.AnyValue_BoolValue:
+		return strconv.FormatBool(val.BoolValue)
+	case *otlppb.AnyValue_IntValue:
+		return strconv.FormatInt(val.IntValue, 10)
+	case *otlppb.AnyValue_DoubleValue:
+		return formatFloat(val.DoubleValue)
+	case *otlppb.AnyValue_BytesValue:
+		return base64.StdEncoding.EncodeToString(val.BytesValue)
+	case *otlppb.AnyValue_ArrayValue, *otlppb.AnyValue_KvlistValue:
+		out, err := json.Marshal(otlpAnyValueToJSON(v))
+		if err != nil {
+			return ""
+		}
+		return string(out)
+	}
+	return ""
+}
+
+func otlpAnyValueToJSON(v *otlppb.AnyValue) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	switch val := v.Value.(type) {
+	case *otlppb.AnyValue_StringValue:
+		return val.StringValue
+	case *otlppb.AnyValue_BoolValue:
+		return val.BoolValue
+	case *otlppb.AnyValue_IntValue:
+		return val.IntValue
+	case *otlppb.AnyValue_DoubleValue:
+		return val.DoubleValue
+	case *otlppb.AnyValue_BytesValue:
+		return val.BytesValue
+	case *otlppb.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(val.ArrayValue.GetValues()))
+		for _, item := range val.ArrayValue.GetValues() {
+			values = append(values, otlpAnyValueToJSON(item))
+		}
+		return values
+	case *otlppb.AnyValue_KvlistValue:
+		values := make(map[string]interface{}, len(val.KvlistValue.GetValues()))
+		for _, item := range val.KvlistValue.GetValues() {
+			if item != nil {
+				values[item.Key] = otlpAnyValueToJSON(item.Value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// sanitizeMetricName replaces the characters not allowed in Prometheus metric names
+// (ie. the dots commonly used in OpenTelemetry metric names) with underscores.
+func sanitizeMetricName(name string) string {
+	return sanitizeName(name, true)
+}
+
+// sanitizeLabelName replaces the characters not allowed in Prometheus label names with
+// underscores.
+func sanitizeLabelName(name string) string {
+	return sanitizeName(name, false)
+}
+
+func sanitizeName(name string, allowColons bool) string {
+	if name == "" {
+		return name
+	}
+
+	sanitized := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || (allowColons && r == ':') {
+			return r
+		}
+		return '_'
+	}, name)
+
+	// Names can't start with a digit.
+	if sanitized[0] >= '0' && sanitized[0] <= '9' {
+		sanitized = "_" + sanitized
+	}
+	return sanitized
+}
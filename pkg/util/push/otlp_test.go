@@ -0,0 +1,282 @@
+package push
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/pkg/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/httpgrpc"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/cortexproject/cortex/pkg/util/push/otlppb"
+)
+
+func TestOTLPToWriteRequest(t *testing.T) {
+	req := &otlppb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlppb.ResourceMetrics{{
+			Resource: &otlppb.Resource{Attributes: []*otlppb.KeyValue{
+				otlpStringAttr("service.name", "api"),
+				otlpStringAttr("service.namespace", "shop"),
+				otlpStringAttr("service.instance.id", "pod-1"),
+				otlpStringAttr("k8s.cluster.name", "prod"),
+				otlpStringAttr("host.name", "not-promoted"),
+			}},
+			InstrumentationLibraryMetrics: []*otlppb.InstrumentationLibraryMetrics{{
+				Metrics: []*otlppb.Metric{
+					{
+						Name:        "queue.size",
+						Description: "Size of the queue.",
+						Data: &otlppb.Metric_Gauge{Gauge: &otlppb.Gauge{DataPoints: []*otlppb.NumberDataPoint{{
+							Attributes:   []*otlppb.KeyValue{otlpStringAttr("queue.name", "orders"), otlpStringAttr("k8s.cluster.name", "overridden")},
+							TimeUnixNano: 1000e6,
+							Value:        &otlppb.NumberDataPoint_AsInt{AsInt: 5},
+						}}}},
+					},
+					{
+						Name: "requests",
+						Data: &otlppb.Metric_Sum{Sum: &otlppb.Sum{
+							AggregationTemporality: otlppb.AGGREGATION_TEMPORALITY_CUMULATIVE,
+							IsMonotonic:            true,
+							DataPoints: []*otlppb.NumberDataPoint{{
+								Attributes:   []*otlppb.KeyValue{{Key: "code", Value: &otlppb.AnyValue{Value: &otlppb.AnyValue_IntValue{IntValue: 200}}}},
+								TimeUnixNano: 2000e6,
+								Value:        &otlppb.NumberDataPoint_AsDouble{AsDouble: 10.5},
+							}, {
+								TimeUnixNano: 3000e6,
+								Flags:        uint32(otlppb.FLAG_NO_RECORDED_VALUE),
+							}},
+						}},
+					},
+					{
+						Name: "delta.requests",
+						Data: &otlppb.Metric_Sum{Sum: &otlppb.Sum{
+							AggregationTemporality: otlppb.AGGREGATION_TEMPORALITY_DELTA,
+							DataPoints:             []*otlppb.NumberDataPoint{{TimeUnixNano: 1000e6}, {TimeUnixNano: 2000e6}},
+						}},
+					},
+					{
+						Name: "latency",
+						Data: &otlppb.Metric_Histogram{Histogram: &otlppb.Histogram{
+							AggregationTemporality: otlppb.AGGREGATION_TEMPORALITY_CUMULATIVE,
+							DataPoints: []*otlppb.HistogramDataPoint{{
+								TimeUnixNano:   4000e6,
+								Count:          6,
+								Sum:            12.5,
+								BucketCounts:   []uint64{1, 2, 3},
+								ExplicitBounds: []float64{0.5, 1},
+							}, {
+								// Invalid, because of the mismatching number of bounds.
+								TimeUnixNano:   4000e6,
+								BucketCounts:   []uint64{1, 2, 3},
+								ExplicitBounds: []float64{0.5},
+							}},
+						}},
+					},
+					{
+						// Unsupported, because it has no data.
+						Name: "unknown",
+					},
+				},
+			}},
+		}},
+	}
+
+	writeReq, rejected := otlpToWriteRequest(req, []string{"k8s.cluster.name", "missing"})
+	defer cortexpb.ReuseSlice(writeReq.Timeseries)
+
+	resourceLabels := []string{"instance", "pod-1", "job", "shop/api"}
+	expected := []struct {
+		labels labels.Labels
+		sample cortexpb.Sample
+	}{
+		{
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "queue_size", "queue_name", "orders", "k8s_cluster_name", "overridden")...),
+			sample: cortexpb.Sample{TimestampMs: 1000, Value: 5},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "requests", "code", "200", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 2000, Value: 10.5},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "requests", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 3000, Value: math.Float64frombits(value.StaleNaN)},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "latency_sum", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 4000, Value: 12.5},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "latency_count", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 4000, Value: 6},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "latency_bucket", "le", "0.5", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 4000, Value: 1},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "latency_bucket", "le", "1", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 4000, Value: 3},
+		}, {
+			labels: labels.FromStrings(append(resourceLabels, "__name__", "latency_bucket", "le", "+Inf", "k8s_cluster_name", "prod")...),
+			sample: cortexpb.Sample{TimestampMs: 4000, Value: 6},
+		},
+	}
+
+	require.Len(t, writeReq.Timeseries, len(expected))
+	for i, e := range expected {
+		ts := writeReq.Timeseries[i]
+		assert.Equal(t, e.labels, cortexpb.FromLabelAdaptersToLabels(ts.Labels))
+		require.Len(t, ts.Samples, 1)
+		assert.Equal(t, e.sample.TimestampMs, ts.Samples[0].TimestampMs)
+		if value.IsStaleNaN(e.sample.Value) {
+			assert.True(t, value.IsStaleNaN(ts.Samples[0].Value))
+		} else {
+			assert.Equal(t, e.sample.Value, ts.Samples[0].Value)
+		}
+	}
+
+	assert.Equal(t, []*cortexpb.MetricMetadata{
+		{Type: cortexpb.GAUGE, MetricFamilyName: "queue_size", Help: "Size of the queue."},
+		{Type: cortexpb.COUNTER, MetricFamilyName: "requests"},
+		{Type: cortexpb.HISTOGRAM, MetricFamilyName: "latency"},
+	}, writeReq.Metadata)
+	assert.Equal(t, cortexpb.API, writeReq.Source)
+
+	// The delta sum data points, the invalid histogram data point and the metric without data.
+	assert.Equal(t, 4, rejected.count)
+	assert.Contains(t, rejected.String(), `unsupported AGGREGATION_TEMPORALITY_DELTA temporality for sum metric "delta.requests"`)
+}
+
+func TestOTLPLabels_ShouldJoinCollidingAttributes(t *testing.T) {
+	ls := otlpLabels("metric", []*otlppb.KeyValue{
+		otlpStringAttr("http_method", "b"),
+		otlpStringAttr("http.method", "a"),
+		{Key: "tags", Value: &otlppb.AnyValue{Value: &otlppb.AnyValue_ArrayValue{ArrayValue: &otlppb.ArrayValue{Values: []*otlppb.AnyValue{
+			{Value: &otlppb.AnyValue_StringValue{StringValue: "x"}},
+			{Value: &otlppb.AnyValue_BoolValue{BoolValue: true}},
+		}}}}},
+		otlpStringAttr("empty", ""),
+		otlpStringAttr("0day", "yes"),
+	}, nil)
+
+	assert.Equal(t, labels.FromStrings("__name__", "metric", "http_method", "a;b", "tags", `["x",true]`, "_0day", "yes"), ls)
+}
+
+func TestOTLPHandler(t *testing.T) {
+	exportReq := &otlppb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*otlppb.ResourceMetrics{{
+			InstrumentationLibraryMetrics: []*otlppb.InstrumentationLibraryMetrics{{
+				Metrics: []*otlppb.Metric{{
+					Name: "foo",
+					Data: &otlppb.Metric_Gauge{Gauge: &otlppb.Gauge{DataPoints: []*otlppb.NumberDataPoint{{
+						TimeUnixNano: 1000e6,
+						Value:        &otlppb.NumberDataPoint_AsDouble{AsDouble: 1},
+					}}}},
+				}, {
+					Name: "bar",
+					Data: &otlppb.Metric_Sum{Sum: &otlppb.Sum{
+						AggregationTemporality: otlppb.AGGREGATION_TEMPORALITY_DELTA,
+						DataPoints:             []*otlppb.NumberDataPoint{{TimeUnixNano: 1000e6}},
+					}},
+				}},
+			}},
+		}},
+	}
+	body, err := exportReq.Marshal()
+	require.NoError(t, err)
+
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, err = gzipWriter.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	tests := map[string]struct {
+		body            []byte
+		contentType     string
+		contentEncoding string
+		pushErr         error
+		expectedCode    int
+		expectedPushes  int
+	}{
+		"protobuf request": {
+			body:           body,
+			contentType:    "application/x-protobuf",
+			expectedCode:   http.StatusOK,
+			expectedPushes: 1,
+		},
+		"gzip compressed protobuf request": {
+			body:            gzipped.Bytes(),
+			contentType:     "application/x-protobuf",
+			contentEncoding: "gzip",
+			expectedCode:    http.StatusOK,
+			expectedPushes:  1,
+		},
+		"JSON request": {
+			body:         []byte("{}"),
+			contentType:  "application/json",
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		"unsupported content encoding": {
+			body:            body,
+			contentType:     "application/x-protobuf",
+			contentEncoding: "snappy",
+			expectedCode:    http.StatusUnsupportedMediaType,
+		},
+		"invalid protobuf": {
+			body:         []byte("invalid"),
+			contentType:  "application/x-protobuf",
+			expectedCode: http.StatusBadRequest,
+		},
+		"request too large": {
+			body:         append(body, make([]byte, 1000)...),
+			contentType:  "application/x-protobuf",
+			expectedCode: http.StatusBadRequest,
+		},
+		"push error": {
+			body:           body,
+			contentType:    "application/x-protobuf",
+			pushErr:        httpgrpc.Errorf(http.StatusTooManyRequests, "ingestion rate limit exceeded"),
+			expectedCode:   http.StatusTooManyRequests,
+			expectedPushes: 1,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			pushes := 0
+			pushFunc := func(_ context.Context, req *client.WriteRequest) (*client.WriteResponse, error) {
+				pushes++
+				require.Len(t, req.Timeseries, 1)
+				assert.Equal(t, labels.FromStrings("__name__", "foo"), cortexpb.FromLabelAdaptersToLabels(req.Timeseries[0].Labels))
+				return &client.WriteResponse{}, testData.pushErr
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/otlp/v1/metrics", bytes.NewReader(testData.body))
+			req.Header.Set("Content-Type", testData.contentType)
+			if testData.contentEncoding != "" {
+				req.Header.Set("Content-Encoding", testData.contentEncoding)
+			}
+
+			resp := httptest.NewRecorder()
+			OTLPHandler(len(body)+100, nil, nil, pushFunc).ServeHTTP(resp, req)
+
+			assert.Equal(t, testData.expectedCode, resp.Code)
+			assert.Equal(t, testData.expectedPushes, pushes)
+
+			if testData.expectedCode == http.StatusOK {
+				exportResp := &otlppb.ExportMetricsServiceResponse{}
+				require.NoError(t, exportResp.Unmarshal(resp.Body.Bytes()))
+				require.NotNil(t, exportResp.PartialSuccess)
+				assert.Equal(t, int64(1), exportResp.PartialSuccess.RejectedDataPoints)
+			}
+		})
+	}
+}
+
+func otlpStringAttr(key, value string) *otlppb.KeyValue {
+	return &otlppb.KeyValue{Key: key, Value: &otlppb.AnyValue{Value: &otlppb.AnyValue_StringValue{StringValue: value}}}
+}
@@ -0,0 +1,102 @@
+package sharding
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+)
+
+const (
+	// ShardLabel is a reserved label referencing a cortex shard
+	ShardLabel = "__cortex_shard__"
+	// ShardLabelFmt is the fmt of the ShardLabel key.
+	ShardLabelFmt = "%d_of_%d"
+)
+
+var (
+	// ShardLabelRE matches a value in ShardLabelFmt
+	ShardLabelRE = regexp.MustCompile("^[0-9]+_of_[0-9]+$")
+)
+
+// ParseShard will extract the shard information encoded in ShardLabelFmt
+func ParseShard(input string) (parsed ShardAnnotation, err error) {
+	if !ShardLabelRE.MatchString(input) {
+		return parsed, errors.Errorf("Invalid ShardLabel value: [%s]", input)
+	}
+
+	matches := strings.Split(input, "_")
+	x, err := strconv.Atoi(matches[0])
+	if err != nil {
+		return parsed, err
+	}
+	of, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return parsed, err
+	}
+
+	if x >= of {
+		return parsed, errors.Errorf("Shards out of bounds: [%d] >= [%d]", x, of)
+	}
+	return ShardAnnotation{
+		Shard: x,
+		Of:    of,
+	}, err
+}
+
+// ShardAnnotation is a convenience struct which holds data from a parsed shard label
+type ShardAnnotation struct {
+	Shard int
+	Of    int
+}
+
+// String encodes a shardAnnotation into a label value
+func (shard ShardAnnotation) String() string {
+	return fmt.Sprintf(ShardLabelFmt, shard.Shard, shard.Of)
+}
+
+// Label generates the ShardAnnotation as a label
+func (shard ShardAnnotation) Label() labels.Label {
+	return labels.Label{
+		Name:  ShardLabel,
+		Value: shard.String(),
+	}
+}
+
+// ShardFromMatchers extracts a ShardAnnotation and the index it was pulled from in the matcher list
+func ShardFromMatchers(matchers []*labels.Matcher) (shard *ShardAnnotation, idx int, err error) {
+	for i, matcher := range matchers {
+		if matcher.Name == ShardLabel && matcher.Type == labels.MatchEqual {
+			shard, err := ParseShard(matcher.Value)
+			if err != nil {
+				return nil, i, err
+			}
+			return &shard, i, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+// Matches returns whether the series with the input labels belongs to the shard.
+// Series are assigned to shards by the hash of their labels.
+func (shard ShardAnnotation) Matches(lbls labels.Labels) bool {
+	return lbls.Hash()%uint64(shard.Of) == uint64(shard.Shard)
+}
+
+// RemoveShardFromMatchers extracts the ShardAnnotation from the matcher list and
+// returns the remaining matchers. If there's no shard matcher, the input matchers
+// are returned unaltered.
+func RemoveShardFromMatchers(matchers []*labels.Matcher) (shard *ShardAnnotation, filtered []*labels.Matcher, err error) {
+	shard, idx, err := ShardFromMatchers(matchers)
+	if err != nil || shard == nil {
+		return nil, matchers, err
+	}
+
+	filtered = make([]*labels.Matcher, 0, len(matchers)-1)
+	filtered = append(filtered, matchers[:idx]...)
+	filtered = append(filtered, matchers[idx+1:]...)
+	return shard, filtered, nil
+}
@@ -0,0 +1,147 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	var testExpr = []struct {
+		input  string
+		output ShardAnnotation
+		err    bool
+	}{
+		{
+			input:  "lsdjf",
+			output: ShardAnnotation{},
+			err:    true,
+		},
+		{
+			input:  "a_of_3",
+			output: ShardAnnotation{},
+			err:    true,
+		},
+		{
+			input:  "3_of_3",
+			output: ShardAnnotation{},
+			err:    true,
+		},
+		{
+			input: "1_of_2",
+			output: ShardAnnotation{
+				Shard: 1,
+				Of:    2,
+			},
+		},
+	}
+
+	for _, c := range testExpr {
+		t.Run(fmt.Sprint(c.input), func(t *testing.T) {
+			shard, err := ParseShard(c.input)
+			if c.err {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, c.output, shard)
+			}
+		})
+	}
+
+}
+
+func TestShardFromMatchers(t *testing.T) {
+	var testExpr = []struct {
+		input []*labels.Matcher
+		shard *ShardAnnotation
+		idx   int
+		err   bool
+	}{
+		{
+			input: []*labels.Matcher{
+				{},
+				{
+					Name: ShardLabel,
+					Type: labels.MatchEqual,
+					Value: ShardAnnotation{
+						Shard: 10,
+						Of:    16,
+					}.String(),
+				},
+				{},
+			},
+			shard: &ShardAnnotation{
+				Shard: 10,
+				Of:    16,
+			},
+			idx: 1,
+			err: false,
+		},
+		{
+			input: []*labels.Matcher{
+				{
+					Name:  ShardLabel,
+					Type:  labels.MatchEqual,
+					Value: "invalid-fmt",
+				},
+			},
+			shard: nil,
+			idx:   0,
+			err:   true,
+		},
+		{
+			input: []*labels.Matcher{},
+			shard: nil,
+			idx:   0,
+			err:   false,
+		},
+	}
+
+	for i, c := range testExpr {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			shard, idx, err := ShardFromMatchers(c.input)
+			if c.err {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, c.shard, shard)
+				require.Equal(t, c.idx, idx)
+			}
+		})
+	}
+
+}
+
+func TestRemoveShardFromMatchers(t *testing.T) {
+	fooMatcher := labels.MustNewMatcher(labels.MatchEqual, "foo", "bar")
+	shardMatcher := labels.MustNewMatcher(labels.MatchEqual, ShardLabel, "1_of_4")
+
+	shard, filtered, err := RemoveShardFromMatchers([]*labels.Matcher{fooMatcher, shardMatcher})
+	require.NoError(t, err)
+	require.Equal(t, &ShardAnnotation{Shard: 1, Of: 4}, shard)
+	require.Equal(t, []*labels.Matcher{fooMatcher}, filtered)
+
+	shard, filtered, err = RemoveShardFromMatchers([]*labels.Matcher{fooMatcher})
+	require.NoError(t, err)
+	require.Nil(t, shard)
+	require.Equal(t, []*labels.Matcher{fooMatcher}, filtered)
+}
+
+func TestShardAnnotation_Matches(t *testing.T) {
+	const shards = 4
+
+	// Each series should belong to exactly one shard.
+	for i := 0; i < 100; i++ {
+		series := labels.FromStrings(labels.MetricName, "foo", "series", fmt.Sprint(i))
+
+		matched := 0
+		for s := 0; s < shards; s++ {
+			if (ShardAnnotation{Shard: s, Of: shards}).Matches(series) {
+				matched++
+			}
+		}
+		require.Equal(t, 1, matched)
+	}
+}
@@ -3,16 +3,20 @@ package validation
 import (
 	"errors"
 	"flag"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/pkg/relabel"
+	"golang.org/x/time/rate"
 
 	"github.com/cortexproject/cortex/pkg/util/flagext"
 )
 
 var (
-	errMaxGlobalSeriesPerUserValidation = errors.New("The ingester.max-global-series-per-user limit is unsupported if distributor.shard-by-all-labels is disabled")
+	errMaxGlobalSeriesPerUserValidation   = errors.New("The ingester.max-global-series-per-user limit is unsupported if distributor.shard-by-all-labels is disabled")
+	errNegativeQuerySchedulerTenantWeight = errors.New("The query-scheduler.tenant-weight limit must not be negative")
 )
 
 // Supported values for enum limits
@@ -61,6 +65,11 @@ type Limits struct {
 	MaxGlobalSeriesPerUser   int `yaml:"max_global_series_per_user"`
 	MaxGlobalSeriesPerMetric int `yaml:"max_global_series_per_metric"`
 	MinChunkLength           int `yaml:"min_chunk_length"`
+	// Exemplars
+	MaxGlobalExemplarsPerUser int `yaml:"max_global_exemplars_per_user"`
+	// Out-of-order samples
+	OutOfOrderTimeWindow             time.Duration `yaml:"out_of_order_time_window"`
+	MaxLocalOutOfOrderSamplesPerUser int           `yaml:"max_out_of_order_samples_per_user"`
 	// Metadata
 	MaxLocalMetricsWithMetadataPerUser  int `yaml:"max_metadata_per_user"`
 	MaxLocalMetadataPerMetric           int `yaml:"max_metadata_per_metric"`
@@ -68,13 +77,16 @@ type Limits struct {
 	MaxGlobalMetadataPerMetric          int `yaml:"max_global_metadata_per_metric"`
 
 	// Querier enforced limits.
-	MaxChunksPerQuery    int            `yaml:"max_chunks_per_query"`
-	MaxQueryLookback     model.Duration `yaml:"max_query_lookback"`
-	MaxQueryLength       time.Duration  `yaml:"max_query_length"`
-	MaxQueryParallelism  int            `yaml:"max_query_parallelism"`
-	CardinalityLimit     int            `yaml:"cardinality_limit"`
-	MaxCacheFreshness    time.Duration  `yaml:"max_cache_freshness"`
-	MaxQueriersPerTenant int            `yaml:"max_queriers_per_tenant"`
+	MaxChunksPerQuery            int            `yaml:"max_chunks_per_query"`
+	MaxFetchedSeriesPerQuery     int            `yaml:"max_fetched_series_per_query"`
+	MaxFetchedChunkBytesPerQuery int            `yaml:"max_fetched_chunk_bytes_per_query"`
+	MaxQueryLookback             model.Duration `yaml:"max_query_lookback"`
+	MaxQueryLength               time.Duration  `yaml:"max_query_length"`
+	MaxQueryParallelism          int            `yaml:"max_query_parallelism"`
+	CardinalityLimit             int            `yaml:"cardinality_limit"`
+	MaxCacheFreshness            time.Duration  `yaml:"max_cache_freshness"`
+	MaxQueriersPerTenant         int            `yaml:"max_queriers_per_tenant"`
+	QuerySchedulerTenantWeight   float64        `yaml:"query_scheduler_tenant_weight"`
 
 	// Ruler defaults and limits.
 	RulerEvaluationDelay        time.Duration `yaml:"ruler_evaluation_delay_duration"`
@@ -85,6 +97,25 @@ type Limits struct {
 	// Store-gateway.
 	StoreGatewayTenantShardSize int `yaml:"store_gateway_tenant_shard_size"`
 
+	// Compactor.
+	CompactorBlocksRetentionPeriod time.Duration `yaml:"compactor_blocks_retention_period"`
+
+	// Alertmanager.
+	AlertmanagerMaxConfigSizeBytes             int                    `yaml:"alertmanager_max_config_size_bytes"`
+	AlertmanagerMaxTemplatesCount              int                    `yaml:"alertmanager_max_templates_count"`
+	AlertmanagerMaxTemplateSizeBytes           int                    `yaml:"alertmanager_max_template_size_bytes"`
+	AlertmanagerMaxReceiversCount              int                    `yaml:"alertmanager_max_receivers_count"`
+	AlertmanagerReceiversAllowedIntegrations   flagext.StringSliceCSV `yaml:"alertmanager_receivers_allowed_integrations"`
+	AlertmanagerReceiversDeniedIntegrations    flagext.StringSliceCSV `yaml:"alertmanager_receivers_denied_integrations"`
+	AlertmanagerReceiversBlockPrivateAddresses bool                   `yaml:"alertmanager_receivers_block_private_addresses"`
+
+	AlertmanagerNotificationRateLimit               float64               `yaml:"alertmanager_notification_rate_limit"`
+	AlertmanagerNotificationRateLimitPerIntegration NotificationLimitsMap `yaml:"alertmanager_notification_rate_limit_per_integration"`
+	AlertmanagerNotificationBurstSize               int                   `yaml:"alertmanager_notification_burst_size"`
+	AlertmanagerNotificationBurstSizePerIntegration NotificationLimitsMap `yaml:"alertmanager_notification_burst_size_per_integration"`
+	AlertmanagerMaxAlertsCount                      int                   `yaml:"alertmanager_max_alerts_count"`
+	AlertmanagerMaxAlertsSizeBytes                  int                   `yaml:"alertmanager_max_alerts_size_bytes"`
+
 	// This config doesn't have a CLI flag registered here because they're registered in
 	// their own original config struct.
 	S3SSEType                 string `yaml:"s3_sse_type" doc:"nocli|description=S3 server-side encryption type. Required to enable server-side encryption overrides for a specific tenant. If not set, the default S3 client settings are used."`
@@ -123,6 +154,9 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxLocalSeriesPerMetric, "ingester.max-series-per-metric", 50000, "The maximum number of active series per metric name, per ingester. 0 to disable.")
 	f.IntVar(&l.MaxGlobalSeriesPerUser, "ingester.max-global-series-per-user", 0, "The maximum number of active series per user, across the cluster. 0 to disable. Supported only if -distributor.shard-by-all-labels is true.")
 	f.IntVar(&l.MaxGlobalSeriesPerMetric, "ingester.max-global-series-per-metric", 0, "The maximum number of active series per metric name, across the cluster. 0 to disable.")
+	f.IntVar(&l.MaxGlobalExemplarsPerUser, "ingester.max-global-exemplars-per-user", 0, "The maximum number of exemplars in memory, across the cluster. 0 to disable exemplars ingestion. Exemplars are supported only by the Cortex blocks storage. Exemplars are not persisted to the WAL and are lost when an ingester restarts.")
+	f.DurationVar(&l.OutOfOrderTimeWindow, "ingester.out-of-order-time-window", 0, "How far back in time, compared to the most recent sample of the tenant in the ingester, out-of-order samples are accepted. Out-of-order samples are kept in memory, and written to a dedicated WAL, until the TSDB head is compacted, when they're written to blocks. 0 to reject out-of-order samples. Enabling it for a tenant takes effect once the tenant's TSDB is opened again in the ingester, because the TSDB must be opened allowing overlapping blocks. This option is supported only by the Cortex blocks storage.")
+	f.IntVar(&l.MaxLocalOutOfOrderSamplesPerUser, "ingester.max-out-of-order-samples-per-user", 1000000, "The maximum number of out-of-order samples per user held in memory, per ingester, until they're written to blocks. 0 to disable.")
 	f.IntVar(&l.MinChunkLength, "ingester.min-chunk-length", 0, "Minimum number of samples in an idle chunk to flush it to the store. Use with care, if chunks are less than this size they will be discarded. This option is ignored when running the Cortex blocks storage. 0 to disable.")
 
 	f.IntVar(&l.MaxLocalMetricsWithMetadataPerUser, "ingester.max-metadata-per-user", 8000, "The maximum number of active metrics with metadata per user, per ingester. 0 to disable.")
@@ -131,12 +165,15 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 	f.IntVar(&l.MaxGlobalMetadataPerMetric, "ingester.max-global-metadata-per-metric", 0, "The maximum number of metadata per metric, across the cluster. 0 to disable.")
 
 	f.IntVar(&l.MaxChunksPerQuery, "store.query-chunk-limit", 2e6, "Maximum number of chunks that can be fetched in a single query. This limit is enforced when fetching chunks from the long-term storage. When running the Cortex chunks storage, this limit is enforced in the querier, while when running the Cortex blocks storage this limit is both enforced in the querier and store-gateway. 0 to disable.")
+	f.IntVar(&l.MaxFetchedSeriesPerQuery, "querier.max-fetched-series-per-query", 0, "The maximum number of unique series a single query can fetch, across all ingesters and store-gateways. This limit is enforced in the querier and it's supported only by the Cortex blocks storage. When the query-frontend splits or shards a query, the limit applies to each sub-query executed by the queriers, not to the whole query. 0 to disable.")
+	f.IntVar(&l.MaxFetchedChunkBytesPerQuery, "querier.max-fetched-chunk-bytes-per-query", 0, "The maximum size, in bytes, of all chunks a single query can fetch, across all ingesters and store-gateways. The chunks of a series fetched from multiple ingester replicas are counted once. This limit is enforced in the querier and query-frontend and it's supported only by the Cortex blocks storage. When the query-frontend splits or shards a query, the limit is shared by all its sub-queries and the query fails once their fetched chunks exceed it. 0 to disable.")
 	f.DurationVar(&l.MaxQueryLength, "store.max-query-length", 0, "Limit the query time range (end - start time). This limit is enforced in the query-frontend (on the received query), in the querier (on the query possibly split by the query-frontend) and in the chunks storage. 0 to disable.")
 	f.Var(&l.MaxQueryLookback, "querier.max-query-lookback", "Limit how long back data (series and metadata) can be queried, up until <lookback> duration ago. This limit is enforced in the query-frontend, querier and ruler. If the requested time range is outside the allowed range, the request will not fail but will be manipulated to only query data within the allowed time range. 0 to disable.")
 	f.IntVar(&l.MaxQueryParallelism, "querier.max-query-parallelism", 14, "Maximum number of split queries will be scheduled in parallel by the frontend.")
 	f.IntVar(&l.CardinalityLimit, "store.cardinality-limit", 1e5, "Cardinality limit for index queries. This limit is ignored when running the Cortex blocks storage. 0 to disable.")
 	f.DurationVar(&l.MaxCacheFreshness, "frontend.max-cache-freshness", 1*time.Minute, "Most recent allowed cacheable result per-tenant, to prevent caching very recent results that might still be in flux.")
 	f.IntVar(&l.MaxQueriersPerTenant, "frontend.max-queriers-per-tenant", 0, "Maximum number of queriers that can handle requests for a single tenant. If set to 0 or value higher than number of available queriers, *all* queriers will handle requests for the tenant. Each frontend (or query-scheduler, if used) will select the same set of queriers for the same tenant (given that all queriers are connected to all frontends / query-schedulers). This option only works with queriers connecting to the query-frontend / query-scheduler, not when using downstream URL.")
+	f.Float64Var(&l.QuerySchedulerTenantWeight, "query-scheduler.tenant-weight", 1, "Weight of the tenant when sharing the querier time with the other tenants in the query-scheduler. Tenants are served in proportion to their weight, based on the querier time consumed by their queries. Queries of multiple tenants use the lowest weight of the tenants. Negative values are rejected, and 0 is the same as 1.")
 
 	f.DurationVar(&l.RulerEvaluationDelay, "ruler.evaluation-delay-duration", 0, "Duration to delay the evaluation of rules to ensure the underlying metrics have been pushed to Cortex.")
 	f.IntVar(&l.RulerTenantShardSize, "ruler.tenant-shard-size", 0, "The default tenant's shard size when the shuffle-sharding strategy is used by ruler. When this setting is specified in the per-tenant overrides, a value of 0 disables shuffle sharding for the tenant.")
@@ -148,6 +185,31 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 
 	// Store-gateway.
 	f.IntVar(&l.StoreGatewayTenantShardSize, "store-gateway.tenant-shard-size", 0, "The default tenant's shard size when the shuffle-sharding strategy is used. Must be set when the store-gateway sharding is enabled with the shuffle-sharding strategy. When this setting is specified in the per-tenant overrides, a value of 0 disables shuffle sharding for the tenant.")
+
+	// Compactor.
+	f.DurationVar(&l.CompactorBlocksRetentionPeriod, "compactor.blocks-retention-period", 0, "Delete blocks containing samples older than the specified retention period. 0 to disable.")
+
+	// Alertmanager.
+	f.IntVar(&l.AlertmanagerMaxConfigSizeBytes, "alertmanager.max-config-size-bytes", 0, "Maximum size (bytes) of an accepted Alertmanager configuration file. 0 = no limit.")
+	f.IntVar(&l.AlertmanagerMaxTemplatesCount, "alertmanager.max-templates-count", 0, "Maximum number of templates in the tenant's Alertmanager configuration. 0 = no limit.")
+	f.IntVar(&l.AlertmanagerMaxTemplateSizeBytes, "alertmanager.max-template-size-bytes", 0, "Maximum size (bytes) of a single template in the tenant's Alertmanager configuration. 0 = no limit.")
+	f.IntVar(&l.AlertmanagerMaxReceiversCount, "alertmanager.max-receivers-count", 0, "Maximum number of receivers in the tenant's Alertmanager configuration. 0 = no limit.")
+	f.Var(&l.AlertmanagerReceiversAllowedIntegrations, "alertmanager.receivers-allowed-integrations", "Comma separated list of receiver integration types the tenant is allowed to use (email, pagerduty, slack, webhook, opsgenie, wechat, pushover, victorops). Empty to allow all integrations.")
+	f.Var(&l.AlertmanagerReceiversDeniedIntegrations, "alertmanager.receivers-denied-integrations", "Comma separated list of receiver integration types the tenant is not allowed to use.")
+	f.BoolVar(&l.AlertmanagerReceiversBlockPrivateAddresses, "alertmanager.receivers-block-private-addresses", false, "True to reject receivers targeting localhost or private (including 100.64.0.0/10), loopback and link-local IP addresses, and receivers configuring a proxy URL. The notifications sent over HTTP are also blocked if the target host name resolves to one of these addresses, while the email smart host is only checked when it is an IP address.")
+
+	f.Float64Var(&l.AlertmanagerNotificationRateLimit, "alertmanager.notification-rate-limit", 0, "Per-tenant rate limit for sending notifications from the Alertmanager, applied separately to each integration type (notifications/sec). Notifications exceeding the limit are dropped. 0 = no limit.")
+	if l.AlertmanagerNotificationRateLimitPerIntegration == nil {
+		l.AlertmanagerNotificationRateLimitPerIntegration = NotificationLimitsMap{}
+	}
+	f.Var(&l.AlertmanagerNotificationRateLimitPerIntegration, "alertmanager.notification-rate-limit-per-integration", "Per-integration notification rate limits, overriding -alertmanager.notification-rate-limit for specific integration types. On the command line, the value is a JSON map of integration name to rate limit, eg. {\"email\": 1}. Allowed integration names: "+strings.Join(allowedIntegrationNames, ", ")+".")
+	f.IntVar(&l.AlertmanagerNotificationBurstSize, "alertmanager.notification-burst-size", 0, "Per-tenant burst size for sending notifications from the Alertmanager, applied separately to each integration type. 0 to use the rate limit rounded up, with a minimum of 1.")
+	if l.AlertmanagerNotificationBurstSizePerIntegration == nil {
+		l.AlertmanagerNotificationBurstSizePerIntegration = NotificationLimitsMap{}
+	}
+	f.Var(&l.AlertmanagerNotificationBurstSizePerIntegration, "alertmanager.notification-burst-size-per-integration", "Per-integration notification burst sizes, overriding -alertmanager.notification-burst-size for specific integration types. On the command line, the value is a JSON map of integration name to burst size.")
+	f.IntVar(&l.AlertmanagerMaxAlertsCount, "alertmanager.max-alerts-count", 0, "Maximum number of alerts that a single tenant can have in the Alertmanager. Alerts posted through the API exceeding the limit are rejected with 400, while the other alerts of the same request are stored. 0 = no limit.")
+	f.IntVar(&l.AlertmanagerMaxAlertsSizeBytes, "alertmanager.max-alerts-size-bytes", 0, "Maximum total size (bytes) of the alerts (labels, annotations and generator URL) that a single tenant can have in the Alertmanager. Alerts posted through the API exceeding the limit are rejected with 400, while the other alerts of the same request are stored. 0 = no limit.")
 }
 
 // Validate the limits config and returns an error if the validation
@@ -159,6 +221,13 @@ func (l *Limits) Validate(shardByAllLabels bool) error {
 		return errMaxGlobalSeriesPerUserValidation
 	}
 
+	return l.validateQuerySchedulerTenantWeight()
+}
+
+func (l *Limits) validateQuerySchedulerTenantWeight() error {
+	if l.QuerySchedulerTenantWeight < 0 {
+		return errNegativeQuerySchedulerTenantWeight
+	}
 	return nil
 }
 
@@ -173,7 +242,12 @@ func (l *Limits) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		*l = *defaultLimits
 	}
 	type plain Limits
-	return unmarshal((*plain)(l))
+	if err := unmarshal((*plain)(l)); err != nil {
+		return err
+	}
+
+	// Validate the per-tenant overrides when loaded, instead of misbehaving at runtime.
+	return l.validateQuerySchedulerTenantWeight()
 }
 
 // When we load YAML from disk, we want the various per-customer limits
@@ -319,11 +393,37 @@ func (o *Overrides) MaxGlobalSeriesPerMetric(userID string) int {
 	return o.getOverridesForUser(userID).MaxGlobalSeriesPerMetric
 }
 
+// MaxGlobalExemplarsPerUser returns the maximum number of exemplars held in memory across the cluster.
+func (o *Overrides) MaxGlobalExemplarsPerUser(userID string) int {
+	return o.getOverridesForUser(userID).MaxGlobalExemplarsPerUser
+}
+
+// OutOfOrderTimeWindow returns how far back in time out-of-order samples are accepted.
+func (o *Overrides) OutOfOrderTimeWindow(userID string) time.Duration {
+	return o.getOverridesForUser(userID).OutOfOrderTimeWindow
+}
+
+// MaxLocalOutOfOrderSamplesPerUser returns the maximum number of out-of-order samples held in memory per ingester.
+func (o *Overrides) MaxLocalOutOfOrderSamplesPerUser(userID string) int {
+	return o.getOverridesForUser(userID).MaxLocalOutOfOrderSamplesPerUser
+}
+
 // MaxChunksPerQuery returns the maximum number of chunks allowed per query.
 func (o *Overrides) MaxChunksPerQuery(userID string) int {
 	return o.getOverridesForUser(userID).MaxChunksPerQuery
 }
 
+// MaxFetchedSeriesPerQuery returns the maximum number of unique series a query (or a sub-query,
+// when split or sharded by the query-frontend) can fetch.
+func (o *Overrides) MaxFetchedSeriesPerQuery(userID string) int {
+	return o.getOverridesForUser(userID).MaxFetchedSeriesPerQuery
+}
+
+// MaxFetchedChunkBytesPerQuery returns the maximum size, in bytes, of the chunks a query can fetch.
+func (o *Overrides) MaxFetchedChunkBytesPerQuery(userID string) int {
+	return o.getOverridesForUser(userID).MaxFetchedChunkBytesPerQuery
+}
+
 // MaxQueryLookback returns the max lookback period of queries.
 func (o *Overrides) MaxQueryLookback(userID string) time.Duration {
 	return time.Duration(o.getOverridesForUser(userID).MaxQueryLookback)
@@ -345,6 +445,12 @@ func (o *Overrides) MaxQueriersPerUser(userID string) int {
 	return o.getOverridesForUser(userID).MaxQueriersPerTenant
 }
 
+// QuerySchedulerTenantWeight returns the weight of the tenant when sharing the querier time with the
+// other tenants in the query-scheduler.
+func (o *Overrides) QuerySchedulerTenantWeight(userID string) float64 {
+	return o.getOverridesForUser(userID).QuerySchedulerTenantWeight
+}
+
 // MaxQueryParallelism returns the limit to the number of split queries the
 // frontend will process in parallel.
 func (o *Overrides) MaxQueryParallelism(userID string) int {
@@ -431,6 +537,93 @@ func (o *Overrides) MaxHAClusters(user string) int {
 	return o.getOverridesForUser(user).HAMaxClusters
 }
 
+// CompactorBlocksRetentionPeriod returns the retention period for a given user.
+func (o *Overrides) CompactorBlocksRetentionPeriod(userID string) time.Duration {
+	return o.getOverridesForUser(userID).CompactorBlocksRetentionPeriod
+}
+
+// AlertmanagerMaxConfigSize returns the maximum size (bytes) of the Alertmanager configuration of a given user.
+func (o *Overrides) AlertmanagerMaxConfigSize(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxConfigSizeBytes
+}
+
+// AlertmanagerMaxTemplatesCount returns the maximum number of Alertmanager templates of a given user.
+func (o *Overrides) AlertmanagerMaxTemplatesCount(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxTemplatesCount
+}
+
+// AlertmanagerMaxTemplateSize returns the maximum size (bytes) of a single Alertmanager template of a given user.
+func (o *Overrides) AlertmanagerMaxTemplateSize(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxTemplateSizeBytes
+}
+
+// AlertmanagerMaxReceiversCount returns the maximum number of Alertmanager receivers of a given user.
+func (o *Overrides) AlertmanagerMaxReceiversCount(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxReceiversCount
+}
+
+// AlertmanagerReceiversAllowedIntegrations returns the receiver integration types a given user is allowed to use.
+func (o *Overrides) AlertmanagerReceiversAllowedIntegrations(userID string) []string {
+	return o.getOverridesForUser(userID).AlertmanagerReceiversAllowedIntegrations
+}
+
+// AlertmanagerReceiversDeniedIntegrations returns the receiver integration types a given user is not allowed to use.
+func (o *Overrides) AlertmanagerReceiversDeniedIntegrations(userID string) []string {
+	return o.getOverridesForUser(userID).AlertmanagerReceiversDeniedIntegrations
+}
+
+// AlertmanagerReceiversBlockPrivateAddresses returns whether receivers targeting private addresses are rejected for a given user.
+func (o *Overrides) AlertmanagerReceiversBlockPrivateAddresses(userID string) bool {
+	return o.getOverridesForUser(userID).AlertmanagerReceiversBlockPrivateAddresses
+}
+
+// NotificationRateLimit returns the notification rate limit of a given user for the given integration.
+func (o *Overrides) NotificationRateLimit(userID string, integration string) rate.Limit {
+	l := o.getOverridesForUser(userID)
+
+	r := l.AlertmanagerNotificationRateLimit
+	if v, ok := l.AlertmanagerNotificationRateLimitPerIntegration[integration]; ok {
+		r = v
+	}
+	if r <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(r)
+}
+
+// NotificationBurstSize returns the notification burst size of a given user for the given integration.
+func (o *Overrides) NotificationBurstSize(userID string, integration string) int {
+	l := o.getOverridesForUser(userID)
+
+	b := float64(l.AlertmanagerNotificationBurstSize)
+	if v, ok := l.AlertmanagerNotificationBurstSizePerIntegration[integration]; ok {
+		b = v
+	}
+	if b <= 0 {
+		// Default the burst size to the rate limit, so that it's possible to send
+		// as many notifications as the limit allows in the first second.
+		r := o.NotificationRateLimit(userID, integration)
+		if r == rate.Inf {
+			return 0
+		}
+		b = math.Ceil(float64(r))
+	}
+	if b < 1 {
+		return 1
+	}
+	return int(b)
+}
+
+// AlertmanagerMaxAlertsCount returns the maximum number of alerts a given user can have in the Alertmanager.
+func (o *Overrides) AlertmanagerMaxAlertsCount(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxAlertsCount
+}
+
+// AlertmanagerMaxAlertsSizeBytes returns the maximum total size of the alerts a given user can have in the Alertmanager.
+func (o *Overrides) AlertmanagerMaxAlertsSizeBytes(userID string) int {
+	return o.getOverridesForUser(userID).AlertmanagerMaxAlertsSizeBytes
+}
+
 // S3SSEType returns the per-tenant S3 SSE type.
 func (o *Overrides) S3SSEType(user string) string {
 	return o.getOverridesForUser(user).S3SSEType
@@ -508,6 +701,24 @@ func SmallestPositiveNonZeroDurationPerTenant(tenantIDs []string, f func(string)
 	return *result
 }
 
+// SmallestPositiveNonZeroFloat64PerTenant is returning the minimal positive
+// and non-zero value of the supplied limit function for all given tenants. It
+// will return 0 only if all inputs are not positive or an empty tenant list is
+// given.
+func SmallestPositiveNonZeroFloat64PerTenant(tenantIDs []string, f func(string) float64) float64 {
+	var result *float64
+	for _, tenantID := range tenantIDs {
+		v := f(tenantID)
+		if v > 0 && (result == nil || v < *result) {
+			result = &v
+		}
+	}
+	if result == nil {
+		return 0
+	}
+	return *result
+}
+
 // MaxDurationPerTenant is returning the maximum duration per tenant. Without
 // tenants given it will return a time.Duration(0).
 func MaxDurationPerTenant(tenantIDs []string, f func(string) time.Duration) time.Duration {
@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/prometheus/pkg/relabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 	"gopkg.in/yaml.v2"
 )
 
@@ -55,6 +56,11 @@ func TestLimits_Validate(t *testing.T) {
 			shardByAllLabels: true,
 			expected:         nil,
 		},
+		"negative query-scheduler tenant weight": {
+			limits:           Limits{QuerySchedulerTenantWeight: -1},
+			shardByAllLabels: true,
+			expected:         errNegativeQuerySchedulerTenantWeight,
+		},
 	}
 
 	for testName, testData := range tests {
@@ -110,6 +116,17 @@ func TestLimitsLoadingFromYaml(t *testing.T) {
 	assert.Equal(t, 100, l.MaxLabelNameLength, "from defaults")
 }
 
+func TestLimitsLoadingFromYaml_ShouldRejectNegativeQuerySchedulerTenantWeight(t *testing.T) {
+	SetDefaultLimitsForYAMLUnmarshalling(Limits{QuerySchedulerTenantWeight: 1})
+
+	l := Limits{}
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`query_scheduler_tenant_weight: 2`), &l))
+	assert.Equal(t, 2.0, l.QuerySchedulerTenantWeight)
+
+	err := yaml.UnmarshalStrict([]byte(`query_scheduler_tenant_weight: -1`), &Limits{})
+	require.Equal(t, errNegativeQuerySchedulerTenantWeight, err)
+}
+
 func TestMetricRelabelConfigLimitsLoadingFromYaml(t *testing.T) {
 	SetDefaultLimitsForYAMLUnmarshalling(Limits{})
 
@@ -133,6 +150,96 @@ metric_relabel_configs:
 	assert.Equal(t, []*relabel.Config{&exp}, l.MetricRelabelConfigs)
 }
 
+func TestNotificationLimitsLoadingFromYaml(t *testing.T) {
+	defaults := Limits{
+		AlertmanagerNotificationRateLimit:               5,
+		AlertmanagerNotificationRateLimitPerIntegration: NotificationLimitsMap{"email": 1, "slack": 2},
+	}
+	SetDefaultLimitsForYAMLUnmarshalling(defaults)
+
+	inp := `
+alertmanager_notification_rate_limit_per_integration:
+  email: 10
+  webhook: 3
+`
+
+	l := Limits{}
+	require.NoError(t, yaml.UnmarshalStrict([]byte(inp), &l))
+
+	assert.Equal(t, NotificationLimitsMap{"email": 10, "slack": 2, "webhook": 3}, l.AlertmanagerNotificationRateLimitPerIntegration)
+	assert.Equal(t, NotificationLimitsMap{"email": 1, "slack": 2}, defaults.AlertmanagerNotificationRateLimitPerIntegration, "defaults should not be modified")
+
+	err := yaml.UnmarshalStrict([]byte("alertmanager_notification_rate_limit_per_integration: {unknown: 1}"), &Limits{})
+	require.EqualError(t, err, "unknown integration name: unknown")
+}
+
+func TestOverrides_NotificationLimits(t *testing.T) {
+	tests := map[string]struct {
+		limits        Limits
+		integration   string
+		expectedRate  rate.Limit
+		expectedBurst int
+	}{
+		"no limits": {
+			integration:   "email",
+			expectedRate:  rate.Inf,
+			expectedBurst: 0,
+		},
+		"burst defaults to the rate limit": {
+			limits:        Limits{AlertmanagerNotificationRateLimit: 2.5},
+			integration:   "email",
+			expectedRate:  2.5,
+			expectedBurst: 3,
+		},
+		"burst is at least 1": {
+			limits:        Limits{AlertmanagerNotificationRateLimit: 0.1},
+			integration:   "email",
+			expectedRate:  0.1,
+			expectedBurst: 1,
+		},
+		"per-integration limits override the defaults": {
+			limits: Limits{
+				AlertmanagerNotificationRateLimit:               1,
+				AlertmanagerNotificationBurstSize:               5,
+				AlertmanagerNotificationRateLimitPerIntegration: NotificationLimitsMap{"slack": 10},
+				AlertmanagerNotificationBurstSizePerIntegration: NotificationLimitsMap{"slack": 20},
+			},
+			integration:   "slack",
+			expectedRate:  10,
+			expectedBurst: 20,
+		},
+		"per-integration limits don't apply to other integrations": {
+			limits: Limits{
+				AlertmanagerNotificationRateLimit:               1,
+				AlertmanagerNotificationBurstSize:               5,
+				AlertmanagerNotificationRateLimitPerIntegration: NotificationLimitsMap{"slack": 10},
+			},
+			integration:   "email",
+			expectedRate:  1,
+			expectedBurst: 5,
+		},
+		"per-integration limit of 0 disables the rate limit": {
+			limits: Limits{
+				AlertmanagerNotificationRateLimit:               1,
+				AlertmanagerNotificationRateLimitPerIntegration: NotificationLimitsMap{"webhook": 0},
+			},
+			integration:   "webhook",
+			expectedRate:  rate.Inf,
+			expectedBurst: 0,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			ov, err := NewOverrides(testData.limits, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, testData.expectedRate, ov.NotificationRateLimit("user1", testData.integration))
+			assert.Equal(t, testData.expectedBurst, ov.NotificationBurstSize("user1", testData.integration))
+		})
+	}
+}
+
 func TestSmallestPositiveIntPerTenant(t *testing.T) {
 	tenantLimits := map[string]*Limits{
 		"tenant-a": {
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortexproject/cortex/pkg/util"
+)
+
+var allowedIntegrationNames = []string{
+	"webhook", "email", "pagerduty", "opsgenie", "wechat", "slack", "victorops", "pushover",
+}
+
+// NotificationLimitsMap is a map of Alertmanager integration name to a notification limit.
+// It's configured as JSON on the command line and as a YAML map in the configuration file.
+type NotificationLimitsMap map[string]float64
+
+// String implements flag.Value.
+func (m NotificationLimitsMap) String() string {
+	out, err := json.Marshal(map[string]float64(m))
+	if err != nil {
+		return fmt.Sprintf("failed to marshal: %v", err)
+	}
+	return string(out)
+}
+
+// Set implements flag.Value.
+func (m *NotificationLimitsMap) Set(s string) error {
+	newMap := map[string]float64{}
+	return m.updateMap(json.Unmarshal([]byte(s), &newMap), newMap)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (m *NotificationLimitsMap) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	newMap := map[string]float64{}
+	return m.updateMap(unmarshal(&newMap), newMap)
+}
+
+func (m *NotificationLimitsMap) updateMap(unmarshalErr error, newMap map[string]float64) error {
+	if unmarshalErr != nil {
+		return unmarshalErr
+	}
+
+	for k := range newMap {
+		if !util.StringsContain(allowedIntegrationNames, k) {
+			return errors.Errorf("unknown integration name: %s", k)
+		}
+	}
+
+	// Values are merged on top of the existing ones (eg. the defaults), so the map
+	// is copied to not modify the one it may share with other limits.
+	merged := make(NotificationLimitsMap, len(*m)+len(newMap))
+	for k, v := range *m {
+		merged[k] = v
+	}
+	for k, v := range newMap {
+		merged[k] = v
+	}
+	*m = merged
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (m NotificationLimitsMap) MarshalYAML() (interface{}, error) {
+	return map[string]float64(m), nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
@@ -12,6 +13,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/weaveworks/common/httpgrpc"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	"github.com/cortexproject/cortex/pkg/util"
 	"github.com/cortexproject/cortex/pkg/util/extract"
@@ -42,6 +44,12 @@ const (
 	errDuplicateLabelName = "duplicate label name: %.200q metric %.200q"
 	errLabelsNotSorted    = "labels not sorted: %.200q metric %.200q"
 
+	errExemplarLabelsMissing = "exemplar missing labels, timestamp: %d series: %s"
+	errExemplarTimestamp     = "exemplar missing timestamp, series: %s"
+	errExemplarLabelsTooLong = "exemplar combined labelset exceeds %d characters, timestamp: %d series: %s labels: %s"
+	errExemplarTooOld        = "exemplar has timestamp too old: %d series: %s"
+	errExemplarTooNew        = "exemplar has timestamp too new: %d series: %s"
+
 	// ErrQueryTooLong is used in chunk store, querier and query frontend.
 	ErrQueryTooLong = "the query time range exceeds the limit (query length: %s, limit: %s)"
 
@@ -56,6 +64,16 @@ const (
 	labelsNotSorted         = "labels_not_sorted"
 	labelValueTooLong       = "label_value_too_long"
 
+	exemplarLabelsMissing    = "exemplar_labels_missing"
+	exemplarLabelsTooLong    = "exemplar_labels_too_long"
+	exemplarTimestampInvalid = "exemplar_timestamp_invalid"
+	exemplarTooOld           = "exemplar_too_old"
+	exemplarTooFarInFuture   = "exemplar_too_far_in_future"
+
+	// ExemplarMaxLabelSetLength is the maximum number of UTF-8 characters allowed
+	// in the combined exemplar label names and values, as defined by OpenMetrics.
+	ExemplarMaxLabelSetLength = 128
+
 	// RateLimited is one of the values for the reason to discard samples.
 	// Declared here to avoid duplication in ingester and distributor.
 	RateLimited = "rate_limited"
@@ -82,9 +100,19 @@ var DiscardedMetadata = prometheus.NewCounterVec(
 	[]string{discardReasonLabel, "user"},
 )
 
+// DiscardedExemplars is a metric of the number of discarded exemplars, by reason.
+var DiscardedExemplars = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cortex_discarded_exemplars_total",
+		Help: "The total number of exemplars that were discarded.",
+	},
+	[]string{discardReasonLabel, "user"},
+)
+
 func init() {
 	prometheus.MustRegister(DiscardedSamples)
 	prometheus.MustRegister(DiscardedMetadata)
+	prometheus.MustRegister(DiscardedExemplars)
 }
 
 // SampleValidationConfig helps with getting required config to validate sample.
@@ -109,6 +137,45 @@ func ValidateSample(cfg SampleValidationConfig, userID string, metricName string
 	return nil
 }
 
+// ValidateExemplar returns an err if the exemplar is invalid. Exemplars are
+// subject to the same per-tenant timestamp limits as samples.
+func ValidateExemplar(cfg SampleValidationConfig, userID string, ls []cortexpb.LabelAdapter, e cortexpb.Exemplar) error {
+	if len(e.Labels) == 0 {
+		DiscardedExemplars.WithLabelValues(exemplarLabelsMissing, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarLabelsMissing, e.TimestampMs, formatLabelSet(ls))
+	}
+
+	if e.TimestampMs == 0 {
+		DiscardedExemplars.WithLabelValues(exemplarTimestampInvalid, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarTimestamp, formatLabelSet(ls))
+	}
+
+	// The exemplar labels length doesn't include the characters used by the
+	// text exposition format (quotes, commas, etc), as defined by OpenMetrics.
+	labelSetLen := 0
+	for _, l := range e.Labels {
+		labelSetLen += utf8.RuneCountInString(l.Name)
+		labelSetLen += utf8.RuneCountInString(l.Value)
+	}
+
+	if labelSetLen > ExemplarMaxLabelSetLength {
+		DiscardedExemplars.WithLabelValues(exemplarLabelsTooLong, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarLabelsTooLong, ExemplarMaxLabelSetLength, e.TimestampMs, formatLabelSet(ls), formatLabelSet(e.Labels))
+	}
+
+	if cfg.RejectOldSamples(userID) && model.Time(e.TimestampMs) < model.Now().Add(-cfg.RejectOldSamplesMaxAge(userID)) {
+		DiscardedExemplars.WithLabelValues(exemplarTooOld, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarTooOld, e.TimestampMs, formatLabelSet(ls))
+	}
+
+	if model.Time(e.TimestampMs) > model.Now().Add(cfg.CreationGracePeriod(userID)) {
+		DiscardedExemplars.WithLabelValues(exemplarTooFarInFuture, userID).Inc()
+		return httpgrpc.Errorf(http.StatusBadRequest, errExemplarTooNew, e.TimestampMs, formatLabelSet(ls))
+	}
+
+	return nil
+}
+
 // LabelValidationConfig helps with getting required config to validate labels.
 type LabelValidationConfig interface {
 	EnforceMetricName(userID string) bool
@@ -251,4 +318,7 @@ func DeletePerUserValidationMetrics(userID string, log log.Logger) {
 	if err := util.DeleteMatchingLabels(DiscardedMetadata, filter); err != nil {
 		level.Warn(log).Log("msg", "failed to remove cortex_discarded_metadata_total metric for user", "user", userID, "err", err)
 	}
+	if err := util.DeleteMatchingLabels(DiscardedExemplars, filter); err != nil {
+		level.Warn(log).Log("msg", "failed to remove cortex_discarded_exemplars_total metric for user", "user", userID, "err", err)
+	}
 }
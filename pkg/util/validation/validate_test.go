@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/httpgrpc"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
 	util_log "github.com/cortexproject/cortex/pkg/util/log"
 )
@@ -52,6 +54,24 @@ func (vm validateMetadataCfg) MaxMetadataLength(userID string) int {
 	return vm.maxMetadataLength
 }
 
+type validateSamplesCfg struct {
+	rejectOldSamples       bool
+	rejectOldSamplesMaxAge time.Duration
+	creationGracePeriod    time.Duration
+}
+
+func (v validateSamplesCfg) RejectOldSamples(userID string) bool {
+	return v.rejectOldSamples
+}
+
+func (v validateSamplesCfg) RejectOldSamplesMaxAge(userID string) time.Duration {
+	return v.rejectOldSamplesMaxAge
+}
+
+func (v validateSamplesCfg) CreationGracePeriod(userID string) time.Duration {
+	return v.creationGracePeriod
+}
+
 func TestValidateLabels(t *testing.T) {
 	var cfg validateLabelsCfg
 	userID := "testUser"
@@ -201,6 +221,75 @@ func TestValidateMetadata(t *testing.T) {
 	`), "cortex_discarded_metadata_total"))
 }
 
+func TestValidateExemplar(t *testing.T) {
+	userID := "testUser"
+	cfg := validateSamplesCfg{
+		rejectOldSamples:       true,
+		rejectOldSamplesMaxAge: time.Hour,
+		creationGracePeriod:    time.Minute,
+	}
+
+	now := model.Now()
+	series := []client.LabelAdapter{{Name: model.MetricNameLabel, Value: "test"}}
+	traceID := []client.LabelAdapter{{Name: "trace_id", Value: "123"}}
+
+	for _, c := range []struct {
+		desc     string
+		exemplar cortexpb.Exemplar
+		err      error
+	}{
+		{
+			"with a valid exemplar",
+			cortexpb.Exemplar{Labels: traceID, Value: 1, TimestampMs: int64(now)},
+			nil,
+		},
+		{
+			"with no labels",
+			cortexpb.Exemplar{Value: 1, TimestampMs: int64(now)},
+			httpgrpc.Errorf(http.StatusBadRequest, errExemplarLabelsMissing, int64(now), "test"),
+		},
+		{
+			"with no timestamp",
+			cortexpb.Exemplar{Labels: traceID, Value: 1},
+			httpgrpc.Errorf(http.StatusBadRequest, errExemplarTimestamp, "test"),
+		},
+		{
+			"with too long labels",
+			cortexpb.Exemplar{Labels: []client.LabelAdapter{{Name: "trace_id", Value: strings.Repeat("a", ExemplarMaxLabelSetLength)}}, Value: 1, TimestampMs: int64(now)},
+			httpgrpc.Errorf(http.StatusBadRequest, errExemplarLabelsTooLong, ExemplarMaxLabelSetLength, int64(now), "test", `{trace_id="`+strings.Repeat("a", ExemplarMaxLabelSetLength)+`"}`),
+		},
+		{
+			"with a too old timestamp",
+			cortexpb.Exemplar{Labels: traceID, Value: 1, TimestampMs: int64(now.Add(-2 * time.Hour))},
+			httpgrpc.Errorf(http.StatusBadRequest, errExemplarTooOld, int64(now.Add(-2*time.Hour)), "test"),
+		},
+		{
+			"with a too new timestamp",
+			cortexpb.Exemplar{Labels: traceID, Value: 1, TimestampMs: int64(now.Add(time.Hour))},
+			httpgrpc.Errorf(http.StatusBadRequest, errExemplarTooNew, int64(now.Add(time.Hour)), "test"),
+		},
+	} {
+		t.Run(c.desc, func(t *testing.T) {
+			err := ValidateExemplar(cfg, userID, series, c.exemplar)
+			assert.Equal(t, c.err, err, "wrong error")
+		})
+	}
+
+	require.NoError(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(`
+			# HELP cortex_discarded_exemplars_total The total number of exemplars that were discarded.
+			# TYPE cortex_discarded_exemplars_total counter
+			cortex_discarded_exemplars_total{reason="exemplar_labels_missing",user="testUser"} 1
+			cortex_discarded_exemplars_total{reason="exemplar_labels_too_long",user="testUser"} 1
+			cortex_discarded_exemplars_total{reason="exemplar_timestamp_invalid",user="testUser"} 1
+			cortex_discarded_exemplars_total{reason="exemplar_too_far_in_future",user="testUser"} 1
+			cortex_discarded_exemplars_total{reason="exemplar_too_old",user="testUser"} 1
+	`), "cortex_discarded_exemplars_total"))
+
+	DeletePerUserValidationMetrics(userID, util_log.Logger)
+
+	require.NoError(t, testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(""), "cortex_discarded_exemplars_total"))
+}
+
 func TestValidateLabelOrder(t *testing.T) {
 	var cfg validateLabelsCfg
 	cfg.maxLabelNameLength = 10
@@ -31,6 +31,7 @@ import (
 	"github.com/cortexproject/cortex/pkg/ring/kv/consul"
 	"github.com/cortexproject/cortex/pkg/ring/kv/etcd"
 	"github.com/cortexproject/cortex/pkg/ring/kv/memberlist"
+	"github.com/cortexproject/cortex/pkg/ring/kv/redis"
 	"github.com/cortexproject/cortex/pkg/ruler"
 	"github.com/cortexproject/cortex/pkg/storage/bucket/s3"
 	"github.com/cortexproject/cortex/pkg/storage/tsdb"
@@ -122,6 +123,11 @@ var (
 			structType: reflect.TypeOf(etcd.Config{}),
 			desc:       "The etcd_config configures the etcd client.",
 		},
+		{
+			name:       "redis_kv_config",
+			structType: reflect.TypeOf(redis.Config{}),
+			desc:       "The redis_kv_config configures the Redis KV store client.",
+		},
 		{
 			name:       "consul_config",
 			structType: reflect.TypeOf(consul.Config{}),